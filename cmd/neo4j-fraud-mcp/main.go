@@ -6,6 +6,7 @@ import (
 	"log"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/cli"
@@ -29,8 +30,9 @@ func main() {
 	// Parse CLI flags for configuration
 	cliArgs := cli.ParseConfigFlags()
 
-	// Load and validate configuration (env vars + CLI overrides)
+	// Load and validate configuration (config file + env vars + CLI overrides)
 	cfg, err := config.LoadConfig(&config.CLIOverrides{
+		ConfigFile:     cliArgs.ConfigFile,
 		URI:            cliArgs.URI,
 		Username:       cliArgs.Username,
 		Password:       cliArgs.Password,
@@ -55,15 +57,15 @@ func main() {
 	logger.Init(cfg.LogLevel, cfg.LogFormat, os.Stderr)
 
 	// Initialize Neo4j driver
-	// For STDIO mode: use environment credentials
-	// For HTTP mode: create driver without auth, per-request credentials will be used via impersonation
-	// Credentials come from per-request Basic Auth headers
-	var authToken neo4j.AuthToken
-	if cfg.TransportMode == config.TransportModeStdio {
-		authToken = neo4j.BasicAuth(cfg.Username, cfg.Password, "")
+	// For STDIO mode, and HTTP mode with API keys or OIDC: use the server's credentials, tokens or tickets from environment
+	// For HTTP mode with Basic Auth: create driver without auth, per-request credentials will be used via impersonation
+	driverTLS, err := database.DriverTLS(cfg)
+	if err != nil {
+		slog.Error("Failed to load Neo4j TLS settings", "error", err)
+		os.Exit(1)
 	}
 
-	driver, err := neo4j.NewDriverWithContext(cfg.URI, authToken)
+	driver, err := neo4j.NewDriverWithContext(cfg.URI, database.DriverAuth(cfg), database.DriverConfig(cfg), driverTLS)
 	if err != nil {
 		slog.Error("Failed to create Neo4j driver", "error", err)
 		os.Exit(1)
@@ -83,6 +85,17 @@ func main() {
 		slog.Error("Failed to create database service", "error", err)
 		return
 	}
+	dbService.SetResilience(database.Resilience{
+		MaxRetries:       int(cfg.QueryRetries),
+		BreakerThreshold: int(cfg.BreakerThreshold),
+		BreakerCooldown:  time.Duration(cfg.BreakerCooldown) * time.Second,
+	})
+	dbService.SetReadRouting(database.ReadRouting{
+		Leader:   cfg.ReadRouting == config.ReadRoutingLeader,
+		Eventual: cfg.ReadConsistency == config.ReadConsistencyEventual,
+	})
+	dbService.SetImpersonation(cfg.Impersonation)
+	dbService.SetResultCache(database.NewResultCache(time.Duration(cfg.ResultCacheTTL)*time.Second, int(cfg.ResultCacheSize)))
 
 	anService := analytics.NewAnalytics(MixPanelToken, MixPanelEndpoint, cfg.URI)
 
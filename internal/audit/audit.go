@@ -0,0 +1,175 @@
+// Package audit writes an append-only JSON record of every tool call, for compliance reviews of
+// who accessed financial-crime data, what they asked for and which queries ran on their behalf.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyslogDestination is the destination sending audit entries to the local syslog daemon
+const SyslogDestination = "syslog"
+
+// Redacted replaces the values of sensitive arguments
+const Redacted = "[REDACTED]"
+
+// DefaultRedactedFields are the argument names whose values are redacted, matched case-insensitively
+// ignoring underscores and dashes. Names containing one of them, such as customerEmail, match too.
+var DefaultRedactedFields = []string{
+	"ssn", "socialsecuritynumber", "taxid", "nationalid", "passport", "driverslicense",
+	"email", "phone", "mobile", "address", "postcode", "zipcode", "dateofbirth", "dob",
+	"accountnumber", "iban", "sortcode", "routingnumber", "cardnumber", "cvv",
+	"password", "secret", "token", "apikey",
+	"firstname", "lastname", "fullname", "identifiervalue", "narrative",
+}
+
+// Entry is the audit record of one tool call
+type Entry struct {
+	Time       time.Time      `json:"time"`
+	Tool       string         `json:"tool"`
+	Identity   string         `json:"identity,omitempty"`  // Authenticated HTTP caller
+	SessionID  string         `json:"sessionId,omitempty"` // MCP session
+	Arguments  map[string]any `json:"arguments,omitempty"` // With sensitive values redacted
+	Queries    []Query        `json:"queries"`
+	Rows       int            `json:"rows"` // Rows returned by all the queries
+	DurationMs float64        `json:"durationMs"`
+	Status     string         `json:"status"`
+	Error      string         `json:"error,omitempty"`
+}
+
+// Query is a query run during a tool call. Parameters are not recorded, since they hold the values
+// the arguments carried, and the Cypher is logged as a fingerprint without its literals.
+type Query struct {
+	Cypher     string  `json:"cypher"`
+	Database   string  `json:"database"`
+	Rows       int     `json:"rows"`
+	DurationMs float64 `json:"durationMs"`
+	Failed     bool    `json:"failed,omitempty"`
+}
+
+// Entry statuses
+const (
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// Logger writes audit entries as JSON lines; it is safe for concurrent use
+type Logger struct {
+	mu       sync.Mutex
+	out      io.WriteCloser
+	redacted []string
+}
+
+// Open opens the audit log at destination: SyslogDestination, or the path of a file entries are appended to.
+// The values of arguments named like one of redactFields are redacted.
+func Open(destination string, redactFields []string) (*Logger, error) {
+	var out io.WriteCloser
+	var err error
+	if destination == SyslogDestination {
+		out, err = openSyslog()
+	} else {
+		out, err = os.OpenFile(destination, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", destination, err)
+	}
+	return NewLogger(out, redactFields), nil
+}
+
+// NewLogger creates a logger writing entries to out
+func NewLogger(out io.WriteCloser, redactFields []string) *Logger {
+	redacted := make([]string, 0, len(redactFields))
+	for _, field := range redactFields {
+		if field = normalizeField(field); field != "" {
+			redacted = append(redacted, field)
+		}
+	}
+	return &Logger{out: out, redacted: redacted}
+}
+
+// Log redacts the arguments of entry, fingerprints its queries and writes it as one line
+func (l *Logger) Log(entry Entry) error {
+	entry.Arguments = l.redactMap(entry.Arguments)
+	queries := make([]Query, len(entry.Queries))
+	for i, query := range entry.Queries {
+		query.Cypher = FingerprintCypher(query.Cypher)
+		queries[i] = query
+	}
+	entry.Queries = queries
+	entry.Error = RedactText(entry.Error)
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, err := l.out.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return nil
+}
+
+// Close closes the audit log
+func (l *Logger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.out.Close()
+}
+
+// redactMap returns a copy of values with the values of sensitive keys and the PII found in other values
+// redacted, in nested maps and lists too. Cypher text is replaced by its fingerprint.
+func (l *Logger) redactMap(values map[string]any) map[string]any {
+	if values == nil {
+		return nil
+	}
+	redacted := make(map[string]any, len(values))
+	for key, value := range values {
+		if l.sensitive(key) {
+			redacted[key] = Redacted
+			continue
+		}
+		if query, ok := value.(string); ok && key == cypherArgument {
+			redacted[key] = FingerprintCypher(query)
+			continue
+		}
+		redacted[key] = l.redactValue(value)
+	}
+	return redacted
+}
+
+func (l *Logger) redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		return l.redactMap(v)
+	case []any:
+		redacted := make([]any, len(v))
+		for i, item := range v {
+			redacted[i] = l.redactValue(item)
+		}
+		return redacted
+	case string:
+		return RedactText(v)
+	default:
+		return value
+	}
+}
+
+func (l *Logger) sensitive(key string) bool {
+	key = normalizeField(key)
+	for _, field := range l.redacted {
+		if strings.Contains(key, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeField(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.TrimSpace(name)))
+}
@@ -0,0 +1,200 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_RedactsSensitiveArguments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := Open(path, append(DefaultRedactedFields, "customer-name"))
+	if err != nil {
+		t.Fatalf("Open() unexpected error = %v", err)
+	}
+
+	arguments := map[string]any{
+		"customerId": "C-1001",
+		"query":      "MATCH (c:Customer {id: $id}) RETURN c",
+		"params": map[string]any{
+			"id":            "C-1001",
+			"SSN":           "123-45-6789",
+			"contact_email": "alice@example.com",
+			"accounts":      []any{map[string]any{"account_number": "12345678", "bank": "ACME"}},
+		},
+		"customerName": "Alice",
+	}
+	entry := Entry{
+		Time:      time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Tool:      "read-cypher",
+		Identity:  "alice",
+		Arguments: arguments,
+		Queries:   []Query{{Cypher: "MATCH (c:Customer {id: $id}) RETURN c", Database: "neo4j", Rows: 1, DurationMs: 2.5}},
+		Rows:      1,
+		Status:    StatusOK,
+	}
+	if err := logger.Log(entry); err != nil {
+		t.Fatalf("Log() unexpected error = %v", err)
+	}
+	if err := logger.Log(Entry{Tool: "kill-query", Status: StatusError, Error: "tool \"kill-query\" is not permitted for your role"}); err != nil {
+		t.Fatalf("Log() unexpected error = %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() unexpected error = %v", err)
+	}
+
+	// The caller's arguments are not modified
+	if arguments["params"].(map[string]any)["SSN"] != "123-45-6789" {
+		t.Error("expected the caller's arguments to be left unredacted")
+	}
+
+	lines := readLines(t, path)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 audit lines, got %d", len(lines))
+	}
+	if strings.Contains(lines[0], "123-45-6789") || strings.Contains(lines[0], "alice@example.com") || strings.Contains(lines[0], "12345678") || strings.Contains(lines[0], "Alice\"") {
+		t.Errorf("expected sensitive values to be redacted, got %s", lines[0])
+	}
+
+	var logged Entry
+	if err := json.Unmarshal([]byte(lines[0]), &logged); err != nil {
+		t.Fatalf("invalid audit line: %v", err)
+	}
+	params := logged.Arguments["params"].(map[string]any)
+	if logged.Arguments["customerId"] != "C-1001" || params["id"] != "C-1001" || params["SSN"] != Redacted || params["contact_email"] != Redacted {
+		t.Errorf("unexpected redacted arguments %v", logged.Arguments)
+	}
+	if account := params["accounts"].([]any)[0].(map[string]any); account["account_number"] != Redacted || account["bank"] != "ACME" {
+		t.Errorf("expected nested values to be redacted, got %v", account)
+	}
+	if logged.Arguments["customerName"] != Redacted {
+		t.Errorf("expected the configured field to be redacted, got %v", logged.Arguments["customerName"])
+	}
+	if logged.Identity != "alice" || len(logged.Queries) != 1 || logged.Queries[0].Rows != 1 {
+		t.Errorf("unexpected entry %+v", logged)
+	}
+
+	var denied map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &denied); err != nil {
+		t.Fatalf("invalid audit line: %v", err)
+	}
+	if denied["status"] != StatusError || denied["queries"] == nil {
+		t.Errorf("expected an error entry with an empty query list, got %s", lines[1])
+	}
+}
+
+func TestLogger_RedactsPIIValuesAndQueryLiterals(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	logger, err := Open(path, DefaultRedactedFields)
+	if err != nil {
+		t.Fatalf("Open() unexpected error = %v", err)
+	}
+
+	query := "MATCH (c:Customer) WHERE c.ssn = '123-45-6789' OR c.email = \"alice@example.com\" // card 4111 1111 1111 1111\nRETURN c LIMIT 10"
+	entry := Entry{
+		Tool: "read-cypher",
+		Arguments: map[string]any{
+			"query":                   query,
+			"excludeIdentifierValues": []any{"+44 20 7946 0958"},
+			"seedIds":                 []any{"C-1001", "bob@example.com", "4111-1111-1111-1111", "GB82 WEST 1234 5698 7654 32"},
+			"statements":              []any{map[string]any{"query": "CREATE (:Customer {name: 'Bob'})"}},
+		},
+		Queries: []Query{{Cypher: query, Database: "neo4j"}},
+		Status:  StatusError,
+		Error:   "Invalid input near 'alice@example.com'",
+	}
+	if err := logger.Log(entry); err != nil {
+		t.Fatalf("Log() unexpected error = %v", err)
+	}
+	_ = logger.Close()
+
+	lines := readLines(t, path)
+	for _, value := range []string{"123-45-6789", "alice@example.com", "4111", "bob@example.com", "7946", "WEST", "Bob"} {
+		if strings.Contains(lines[0], value) {
+			t.Errorf("expected %q to be redacted, got %s", value, lines[0])
+		}
+	}
+
+	var logged Entry
+	if err := json.Unmarshal([]byte(lines[0]), &logged); err != nil {
+		t.Fatalf("invalid audit line: %v", err)
+	}
+	fingerprint := "MATCH (c:Customer) WHERE c.ssn = ? OR c.email = ? \nRETURN c LIMIT ?"
+	if logged.Arguments["query"] != fingerprint || logged.Queries[0].Cypher != fingerprint {
+		t.Errorf("expected the query fingerprint %q, got %q and %q", fingerprint, logged.Arguments["query"], logged.Queries[0].Cypher)
+	}
+	if seedIds := logged.Arguments["seedIds"].([]any); seedIds[0] != "C-1001" || seedIds[1] != Redacted || seedIds[2] != Redacted || seedIds[3] != Redacted {
+		t.Errorf("expected PII values to be redacted by value, got %v", seedIds)
+	}
+	if logged.Arguments["excludeIdentifierValues"] != Redacted {
+		t.Errorf("expected identifier values to be redacted, got %v", logged.Arguments["excludeIdentifierValues"])
+	}
+}
+
+func TestFingerprintCypher(t *testing.T) {
+	tests := map[string]string{
+		"MATCH (n:`Bank Account` {iban: 'GB82'}) RETURN n.x1, $id": "MATCH (n:`Bank Account` {iban: ?}) RETURN n.x1, $id",
+		`RETURN 'it\'s', "a \"b\"", 3.14, 2e10, [1, 2]`:            "RETURN ?, ?, ?, ?, [?, ?]",
+		"MATCH p = (a)-[*1..3]-(b) /* ssn 123 */ RETURN p":         "MATCH p = (a)-[*?..?]-(b)   RETURN p",
+		"RETURN 'unterminated":                                     "RETURN ?",
+	}
+	for query, want := range tests {
+		if got := FingerprintCypher(query); got != want {
+			t.Errorf("FingerprintCypher(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestRedactText_LeavesOrdinaryValues(t *testing.T) {
+	for _, value := range []string{"C-1001", "TX-20250101-0001", "1234567890123", "Customer", "2025-01-01"} {
+		if got := RedactText(value); got != value {
+			t.Errorf("RedactText(%q) = %q, expected it unchanged", value, got)
+		}
+	}
+}
+
+func TestOpen_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for range 2 {
+		logger, err := Open(path, nil)
+		if err != nil {
+			t.Fatalf("Open() unexpected error = %v", err)
+		}
+		if err := logger.Log(Entry{Tool: "get-schema", Status: StatusOK}); err != nil {
+			t.Fatalf("Log() unexpected error = %v", err)
+		}
+		_ = logger.Close()
+	}
+
+	if lines := readLines(t, path); len(lines) != 2 {
+		t.Errorf("expected entries to be appended across restarts, got %d lines", len(lines))
+	}
+	if info, err := os.Stat(path); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("expected the audit log to be private, got mode %v", info.Mode().Perm())
+	}
+}
+
+func TestOpen_InvalidPath(t *testing.T) {
+	if _, err := Open(filepath.Join(t.TempDir(), "missing", "audit.log"), nil); err == nil {
+		t.Error("expected an error for a file in a missing directory")
+	}
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
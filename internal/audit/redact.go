@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// cypherArgument is the argument name carrying Cypher text, in read-cypher, write-cypher,
+// batch-cypher statements and run-in-transaction. Its value is logged as a fingerprint.
+const cypherArgument = "query"
+
+// piiPatterns match PII values, so they are redacted whatever the argument is called
+var piiPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),            // Email address
+	regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`),                                       // Social security number
+	regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,4})?\b`), // IBAN
+	regexp.MustCompile(`\+\d[\d ()\-]{7,}\d`),                                         // International phone number
+}
+
+// cardNumberPattern matches candidate card numbers; only those passing the Luhn check are redacted
+var cardNumberPattern = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+
+// RedactText replaces the PII values found in text, such as email addresses, social security,
+// card and IBAN numbers and international phone numbers, with Redacted
+func RedactText(text string) string {
+	for _, pattern := range piiPatterns {
+		text = pattern.ReplaceAllString(text, Redacted)
+	}
+	return cardNumberPattern.ReplaceAllStringFunc(text, func(candidate string) string {
+		if luhnValid(candidate) {
+			return Redacted
+		}
+		return candidate
+	})
+}
+
+// luhnValid reports whether the digits of number pass the Luhn checksum of card numbers
+func luhnValid(number string) bool {
+	sum, double := 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			continue
+		}
+		digit := int(number[i] - '0')
+		if double {
+			if digit *= 2; digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// FingerprintCypher returns query with its string and number literals replaced by ? and its comments
+// removed, so the audit log shows the shape of a query without the values written into it
+func FingerprintCypher(query string) string {
+	var fingerprint strings.Builder
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			i = skipQuoted(query, i)
+			fingerprint.WriteByte('?')
+		case c == '`':
+			// Escaped names are labels, types and properties, which are kept
+			end := len(query)
+			if closing := strings.IndexByte(query[i+1:], '`'); closing >= 0 {
+				end = i + closing + 2
+			}
+			fingerprint.WriteString(query[i:end])
+			i = end
+		case strings.HasPrefix(query[i:], "//"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 4
+			}
+			fingerprint.WriteByte(' ')
+		case isDigit(c) && (i == 0 || !isIdentifierByte(query[i-1])):
+			for i < len(query) && (isIdentifierByte(query[i]) || query[i] == '.' && i+1 < len(query) && isDigit(query[i+1])) {
+				i++
+			}
+			fingerprint.WriteByte('?')
+		default:
+			fingerprint.WriteByte(c)
+			i++
+		}
+	}
+	return fingerprint.String()
+}
+
+// skipQuoted returns the index after the string literal starting at start, honouring backslash escapes
+func skipQuoted(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		switch query[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(query)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentifierByte(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c == '$' || c >= 0x80
+}
@@ -0,0 +1,54 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+)
+
+// APIKeys validates static API keys, each issued to a named identity
+type APIKeys struct {
+	keys map[string]string // identity -> key
+}
+
+// ParseAPIKeys parses comma-separated identity=key pairs (e.g. "alice=s3cret,ci-bot=t0ken")
+func ParseAPIKeys(pairs string) (*APIKeys, error) {
+	keys := make(map[string]string)
+	owners := make(map[string]string) // key -> identity
+	for _, pair := range strings.Split(pairs, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		identity, key, ok := strings.Cut(pair, "=")
+		identity, key = strings.TrimSpace(identity), strings.TrimSpace(key)
+		if !ok || identity == "" || key == "" {
+			return nil, fmt.Errorf("invalid API key entry %q, expected identity=key", pair)
+		}
+		if _, exists := keys[identity]; exists {
+			return nil, fmt.Errorf("duplicate API key identity %q", identity)
+		}
+		// A shared key would authenticate as either identity; the key itself is kept out of the error
+		if owner, exists := owners[key]; exists {
+			return nil, fmt.Errorf("duplicate API key for identities %q and %q", owner, identity)
+		}
+		keys[identity] = key
+		owners[key] = identity
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no API keys configured")
+	}
+	return &APIKeys{keys: keys}, nil
+}
+
+// Identity returns the identity the key was issued to.
+// Every configured key is compared in constant time so the response time does not reveal near matches.
+func (a *APIKeys) Identity(key string) (string, bool) {
+	matched := ""
+	for identity, candidate := range a.keys {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(key)) == 1 {
+			matched = identity
+		}
+	}
+	return matched, matched != ""
+}
@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		pairs   string
+		wantErr string
+	}{
+		{name: "valid pairs", pairs: "alice=key-a, ci-bot=key-b"},
+		{name: "empty", pairs: " , ", wantErr: "no API keys configured"},
+		{name: "missing key", pairs: "alice=", wantErr: "expected identity=key"},
+		{name: "missing separator", pairs: "alice", wantErr: "expected identity=key"},
+		{name: "duplicate identity", pairs: "alice=key-a,alice=key-b", wantErr: "duplicate API key identity"},
+		{name: "duplicate key", pairs: "alice=key-a,bob=key-a", wantErr: `duplicate API key for identities "alice" and "bob"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAPIKeys(tt.pairs)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseAPIKeys() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseAPIKeys() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAPIKeys_Identity(t *testing.T) {
+	keys, err := ParseAPIKeys("alice=key-a,ci-bot=key-b")
+	if err != nil {
+		t.Fatalf("ParseAPIKeys() unexpected error = %v", err)
+	}
+
+	if identity, ok := keys.Identity("key-b"); !ok || identity != "ci-bot" {
+		t.Errorf("Identity(key-b) = %q, %v, want ci-bot, true", identity, ok)
+	}
+	for _, key := range []string{"", "key-", "key-a ", "alice"} {
+		if identity, ok := keys.Identity(key); ok {
+			t.Errorf("Identity(%q) = %q, want no match", key, identity)
+		}
+	}
+}
@@ -22,3 +22,16 @@ func GetBasicAuthCredentials(ctx context.Context) (string, string, bool) {
 	pass, okPass := ctx.Value(basicAuthPassKey).(string)
 	return user, pass, okUser && okPass
 }
+
+const identityKey contextKey = "identity"
+
+// WithIdentity adds the authenticated caller's identity to the context, so tool calls can be attributed
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// GetIdentity retrieves the authenticated caller's identity from the context
+func GetIdentity(ctx context.Context) (string, bool) {
+	identity, ok := ctx.Value(identityKey).(string)
+	return identity, ok && identity != ""
+}
@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testIssuer serves an OpenID Connect discovery document and a JWKS with one RSA and one EC key
+type testIssuer struct {
+	server     *httptest.Server
+	rsaKey     *rsa.PrivateKey
+	ecKey      *ecdsa.PrivateKey
+	jwksLoaded atomic.Int32
+	jwksFails  atomic.Bool   // Makes the JWKS endpoint fail
+	jwksDelay  chan struct{} // When set, JWKS downloads wait until it is closed
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{"issuer": issuer.server.URL, "jwks_uri": issuer.server.URL + "/jwks"})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		if issuer.jwksDelay != nil {
+			<-issuer.jwksDelay
+		}
+		issuer.jwksLoaded.Add(1)
+		if issuer.jwksFails.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		b64 := base64.RawURLEncoding.EncodeToString
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(rsaKey.N.Bytes()), "e": b64(big.NewInt(int64(rsaKey.E)).Bytes())},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecKey.X.FillBytes(make([]byte, 32))), "y": b64(ecKey.Y.FillBytes(make([]byte, 32)))},
+		}})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// sign returns a JWT with the given claims, signed with the issuer's key for alg
+func (i *testIssuer) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch alg {
+	case "RS256":
+		sig, err := rsa.SignPKCS1v15(rand.Reader, i.rsaKey, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		signature = sig
+	case "ES256":
+		r, s, err := ecdsa.Sign(rand.Reader, i.ecKey, digest[:])
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (i *testIssuer) claims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":   i.server.URL,
+		"aud":   "neo4j-mcp",
+		"sub":   "analyst-42",
+		"email": "analyst@example.com",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+	return claims
+}
+
+func TestOIDCVerifier_Verify(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewOIDCVerifier(issuer.server.URL, "neo4j-mcp", "", issuer.server.Client())
+
+	tests := []struct {
+		name     string
+		token    string
+		identity string
+		wantErr  bool
+	}{
+		{name: "RS256 token", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(nil)), identity: "analyst-42"},
+		{name: "ES256 token", token: issuer.sign(t, "ES256", "ec-1", issuer.claims(nil)), identity: "analyst-42"},
+		{name: "audience list", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(map[string]any{"aud": []string{"other", "neo4j-mcp"}})), identity: "analyst-42"},
+		{name: "expired", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})), wantErr: true},
+		{name: "not valid yet", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(map[string]any{"nbf": time.Now().Add(time.Hour).Unix()})), wantErr: true},
+		{name: "wrong issuer", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(map[string]any{"iss": "https://evil.example.com"})), wantErr: true},
+		{name: "wrong audience", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(map[string]any{"aud": "other"})), wantErr: true},
+		{name: "missing subject", token: issuer.sign(t, "RS256", "rsa-1", issuer.claims(map[string]any{"sub": ""})), wantErr: true},
+		{name: "key of another type", token: issuer.sign(t, "RS256", "ec-1", issuer.claims(nil)), wantErr: true},
+		{name: "unsigned token", token: issuer.sign(t, "none", "rsa-1", issuer.claims(nil)), wantErr: true},
+		{name: "not a JWT", token: "opaque-token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := verifier.Verify(context.Background(), tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Verify() expected error, got identity %q", identity)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() unexpected error = %v", err)
+			}
+			if identity != tt.identity {
+				t.Errorf("Verify() identity = %q, want %q", identity, tt.identity)
+			}
+		})
+	}
+}
+
+func TestOIDCVerifier_TamperedToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewOIDCVerifier(issuer.server.URL, "neo4j-mcp", "", issuer.server.Client())
+
+	token := issuer.sign(t, "RS256", "rsa-1", issuer.claims(nil))
+	parts := strings.Split(token, ".")
+	forged, _ := json.Marshal(issuer.claims(map[string]any{"sub": "admin"}))
+	parts[1] = base64.RawURLEncoding.EncodeToString(forged)
+
+	_, err := verifier.Verify(context.Background(), strings.Join(parts, "."))
+	if !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestOIDCVerifier_IdentityClaim(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewOIDCVerifier(issuer.server.URL+"/", "neo4j-mcp", "email", issuer.server.Client())
+
+	identity, err := verifier.Verify(context.Background(), issuer.sign(t, "RS256", "rsa-1", issuer.claims(nil)))
+	if err != nil {
+		t.Fatalf("Verify() unexpected error = %v", err)
+	}
+	if identity != "analyst@example.com" {
+		t.Errorf("Verify() identity = %q, want %q", identity, "analyst@example.com")
+	}
+}
+
+func TestOIDCVerifier_UnknownKeyRefreshIsRateLimited(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewOIDCVerifier(issuer.server.URL, "neo4j-mcp", "", issuer.server.Client())
+
+	for range 3 {
+		if _, err := verifier.Verify(context.Background(), issuer.sign(t, "RS256", "rotated", issuer.claims(nil))); err == nil {
+			t.Fatal("Verify() expected error for an unknown key ID")
+		}
+	}
+	if issuer.jwksLoaded.Load() != 1 {
+		t.Errorf("JWKS downloaded %d times, want 1", issuer.jwksLoaded.Load())
+	}
+
+	// Known keys keep working without downloading the key set again
+	if _, err := verifier.Verify(context.Background(), issuer.sign(t, "RS256", "rsa-1", issuer.claims(nil))); err != nil {
+		t.Fatalf("Verify() unexpected error = %v", err)
+	}
+	if issuer.jwksLoaded.Load() != 1 {
+		t.Errorf("JWKS downloaded %d times, want 1", issuer.jwksLoaded.Load())
+	}
+}
+
+func TestOIDCVerifier_FailedRefreshIsRetried(t *testing.T) {
+	issuer := newTestIssuer(t)
+	verifier := NewOIDCVerifier(issuer.server.URL, "neo4j-mcp", "", issuer.server.Client())
+	token := issuer.sign(t, "RS256", "rsa-1", issuer.claims(nil))
+
+	issuer.jwksFails.Store(true)
+	if _, err := verifier.Verify(context.Background(), token); err == nil || errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("Verify() expected a key download error, got %v", err)
+	}
+
+	// A failed download does not count towards the rate limit
+	issuer.jwksFails.Store(false)
+	if _, err := verifier.Verify(context.Background(), token); err != nil {
+		t.Fatalf("Verify() unexpected error = %v", err)
+	}
+	if issuer.jwksLoaded.Load() != 2 {
+		t.Errorf("JWKS downloaded %d times, want 2", issuer.jwksLoaded.Load())
+	}
+}
+
+func TestOIDCVerifier_ConcurrentRefreshIsShared(t *testing.T) {
+	issuer := newTestIssuer(t)
+	issuer.jwksDelay = make(chan struct{})
+	verifier := NewOIDCVerifier(issuer.server.URL, "neo4j-mcp", "", issuer.server.Client())
+	token := issuer.sign(t, "ES256", "ec-1", issuer.claims(nil))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := verifier.Verify(context.Background(), token)
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(issuer.jwksDelay)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Verify() unexpected error = %v", err)
+		}
+	}
+	if issuer.jwksLoaded.Load() != 1 {
+		t.Errorf("JWKS downloaded %d times, want 1", issuer.jwksLoaded.Load())
+	}
+}
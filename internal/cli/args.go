@@ -19,6 +19,7 @@ Usage:
 Options:
   -h, --help                          Show this help message
   -v, --version                       Show version information
+  --config <PATH>                     YAML configuration file; environment variables and other flags override its values
   --neo4j-uri <URI>                   Neo4j connection URI (overrides environment variable NEO4J_URI)
   --neo4j-username <USERNAME>         Database username (overrides environment variable NEO4J_USERNAME)
   --neo4j-password <PASSWORD>         Database password (overrides environment variable NEO4J_PASSWORD)
@@ -26,7 +27,7 @@ Options:
   --neo4j-read-only <BOOLEAN>         Enable read-only mode: true or false (overrides environment variable NEO4J_READ_ONLY)
   --neo4j-telemetry <BOOLEAN>         Enable telemetry: true or false (overrides environment variable NEO4J_TELEMETRY)
   --neo4j-schema-sample-size <INT>    Number of nodes to sample for schema inference (overrides environment variable NEO4J_SCHEMA_SAMPLE_SIZE)
-  --neo4j-transport-mode <MODE>       MCP Transport mode (e.g., 'stdio', 'http') (overrides environment variable NEO4J_MCP_TRANSPORT)
+  --neo4j-transport-mode <MODE>       MCP Transport mode ('stdio', 'http' or 'sse') (overrides environment variable NEO4J_MCP_TRANSPORT)
   --neo4j-http-port <PORT>            HTTP server port (overrides environment variable NEO4J_MCP_HTTP_PORT)
   --neo4j-http-host <HOST>            HTTP server host (overrides environment variable NEO4J_MCP_HTTP_HOST)
   --neo4j-http-allowed-origins <ORIGINS> Comma-separated list of allowed CORS origins (overrides environment variable NEO4J_MCP_HTTP_ALLOWED_ORIGINS)
@@ -41,16 +42,75 @@ Required Environment Variables:
 
 Optional Environment Variables:
   NEO4J_DATABASE  Database name (default: neo4j)
+  NEO4J_AUTH_SCHEME How the server authenticates to Neo4j: basic, bearer, kerberos (default: basic)
+  NEO4J_BEARER_TOKEN_FILE File holding the bearer token, read again when it expires (bearer scheme)
+  NEO4J_OAUTH_TOKEN_URL Token endpoint bearer tokens are obtained from with client credentials (bearer scheme)
+  NEO4J_OAUTH_CLIENT_ID Client ID of the client credentials grant
+  NEO4J_OAUTH_CLIENT_SECRET Client secret of the client credentials grant
+  NEO4J_OAUTH_SCOPE Scopes requested with the client credentials grant (optional)
+  NEO4J_KERBEROS_TICKET_FILE File holding the base64-encoded Kerberos ticket (kerberos scheme)
+  NEO4J_TLS_CLIENT_CERT_FILE Client certificate presented to Neo4j for mutual TLS (optional)
+  NEO4J_TLS_CLIENT_KEY_FILE Private key of the Neo4j client certificate (optional)
+  NEO4J_TLS_CA_FILE CA certificates trusted for the Neo4j server certificate (optional)
   NEO4J_TELEMETRY Enable/disable telemetry (default: true)
   NEO4J_READ_ONLY Enable read-only mode (default: false)
   NEO4J_SCHEMA_SAMPLE_SIZE Number of nodes to sample for schema inference (default: 100)
-  NEO4J_MCP_TRANSPORT MCP Transport mode (e.g., 'stdio', 'http') (default: stdio)
+  NEO4J_SCHEMA_CACHE_TTL Seconds a retrieved schema is cached, 0 disables caching (default: 300)
+  NEO4J_RESULT_CACHE_TTL Seconds the query results of idempotent read tools are cached, 0 disables caching (default: 0)
+  NEO4J_RESULT_CACHE_SIZE Query results kept in the result cache (default: 500)
+  NEO4J_REFERENCE_MODEL_CACHE_DIR Directory downloaded reference models are cached in (default: user cache directory)
+  NEO4J_REFERENCE_MODEL_CACHE_TTL Seconds a cached reference model is used before it is revalidated (default: 86400)
+  NEO4J_REFERENCE_MODELS Additional reference models as comma-separated name=url or name=path pairs
+  NEO4J_REFERENCE_MODEL_HOSTS Comma-separated hosts reference models may be downloaded from, besides neo4j.com
+  NEO4J_MAPPING_STORE_FILE File schema mappings saved with save-schema-mapping persist are kept in (default: none, mappings last for the session)
+  NEO4J_QUERY_TIMEOUT Seconds a Cypher tool query may run, 0 disables the timeout (default: 60)
+  NEO4J_QUERY_MAX_ROWS Rows a Cypher tool returns before the result is truncated, 0 disables truncation (default: 1000)
+  NEO4J_RESPONSE_MAX_TOKENS Estimated tokens a tool response may hold before it is split into chunks fetched with get-next-chunk, 0 disables chunking (default: 20000)
+  NEO4J_MAX_CONCURRENT_TOOL_CALLS Tool calls a client may run at once, 0 disables the cap (default: 0)
+  NEO4J_TOOL_CALLS_PER_MINUTE Tool calls a client may start per minute, 0 disables rate limiting (default: 0)
+  NEO4J_QUERY_RETRIES Retries of a query failing with a transient error, 0 disables retries (default: 2)
+  NEO4J_CIRCUIT_BREAKER_THRESHOLD Consecutive failures to reach Neo4j that make queries fail fast, 0 disables (default: 5)
+  NEO4J_CIRCUIT_BREAKER_COOLDOWN Seconds queries fail fast before Neo4j is probed again (default: 30)
+  NEO4J_MAX_CONNECTION_POOL_SIZE Connections the driver keeps per Neo4j server, 0 keeps the driver default (default: 100)
+  NEO4J_CONNECTION_ACQUISITION_TIMEOUT Seconds a query waits for a pooled connection (default: 60)
+  NEO4J_MAX_TRANSACTION_RETRY_TIME Seconds the driver retries a failing transaction (default: 30)
+  NEO4J_FETCH_SIZE Records pulled from Neo4j per batch, -1 pulls all at once (default: 1000)
+  NEO4J_READ_ROUTING Cluster members read queries run on: replicas, leader (default: replicas)
+  NEO4J_READ_CONSISTENCY Whether reads wait for earlier writes: causal, eventual (default: causal)
+  NEO4J_SHUTDOWN_TIMEOUT Seconds a shutdown waits for running tool calls before cancelling them (default: 30)
+  NEO4J_METRICS_ADDRESS host:port serving Prometheus metrics on /metrics, e.g. 127.0.0.1:9090 (optional)
+  OTEL_EXPORTER_OTLP_ENDPOINT Base URL of the OTLP/HTTP collector trace spans are exported to (optional)
+  OTEL_EXPORTER_OTLP_HEADERS Comma-separated key=value headers sent to the OTLP collector (optional)
+  OTEL_SERVICE_NAME Service name of the exported spans (default: neo4j-fraud-mcp)
+  NEO4J_AUDIT_LOG File every tool call is appended to as a JSON line, or 'syslog' (optional)
+  NEO4J_AUDIT_REDACT_FIELDS Comma-separated argument names redacted in the audit log, in addition to the defaults (optional)
+  NEO4J_PII_MASK_MODE How personal data in tool outputs is masked: off, full, partial or hash (default: off)
+  NEO4J_PII_MASK_FIELDS Comma-separated property names masked in tool outputs, replacing the defaults (optional)
+  NEO4J_PII_HASH_KEY Key of the hashed values in hash mode (optional, default: random per run)
+  NEO4J_PII_UNMASK_ROLES Comma-separated roles that may override the masking mode per tool call (optional)
+  NEO4J_QUERY_POLICY_ENABLED Check the queries tools run against the query policy (default: true)
+  NEO4J_QUERY_POLICY_FILE JSON file of allow and deny rules checked before the built-in rules (optional)
+  NEO4J_ADMIN_TOOLS Enable the list-running-queries and kill-query admin tools (default: false)
+  NEO4J_ENABLED_TOOLS Comma-separated tool or category names; only these tools are registered (optional)
+  NEO4J_DISABLED_TOOLS Comma-separated tool or category names that are not registered (optional)
+  NEO4J_PROFILE Deployment profile selecting the tool categories: investigator, analyst, admin or demo (optional)
+  NEO4J_MCP_TRANSPORT MCP Transport mode: 'stdio', 'http' (streamable HTTP, alias 'streamable-http') or 'sse' (default: stdio)
   NEO4J_MCP_HTTP_PORT HTTP server port (default: 443 with TLS, 80 without TLS)
   NEO4J_MCP_HTTP_HOST HTTP server host (default: 127.0.0.1)
   NEO4J_MCP_HTTP_ALLOWED_ORIGINS Comma-separated list of allowed CORS origins (optional)
   NEO4J_MCP_HTTP_TLS_ENABLED Enable TLS/HTTPS for HTTP server (default: false)
   NEO4J_MCP_HTTP_TLS_CERT_FILE Path to TLS certificate file (required when TLS is enabled)
   NEO4J_MCP_HTTP_TLS_KEY_FILE Path to TLS private key file (required when TLS is enabled)
+  NEO4J_MCP_HTTP_AUTH How HTTP/SSE requests are authenticated: 'basic', 'api-key' or 'oidc' (default: basic)
+  NEO4J_MCP_HTTP_API_KEYS Comma-separated identity=key pairs accepted in api-key mode
+  NEO4J_MCP_OIDC_ISSUER OpenID Connect issuer URL bearer tokens are validated against in oidc mode
+  NEO4J_MCP_OIDC_AUDIENCE Audience bearer tokens must be issued for in oidc mode
+  NEO4J_MCP_OIDC_IDENTITY_CLAIM Token claim identifying the caller in oidc mode (default: sub)
+  NEO4J_MCP_RBAC_ENABLED Restrict HTTP callers to the tools granted by their roles (default: false)
+  NEO4J_MCP_ROLES Comma-separated identity=role pairs, roles: analyst, investigator, admin (separate several with '|')
+  NEO4J_MCP_DEFAULT_ROLE Role of HTTP callers without an entry in NEO4J_MCP_ROLES (optional, default: no tools)
+  NEO4J_MCP_TENANTS_FILE JSON file routing HTTP callers to tenant databases and credentials; requires api-key or oidc HTTP auth (optional)
+  NEO4J_IMPERSONATION Run the queries of api-key and oidc callers as the Neo4j user named by their identity (default: false)
 
 Examples:
   # Using environment variables
@@ -59,11 +119,15 @@ Examples:
   # Using CLI flags (takes precedence over environment variables)
   neo4j-mcp --neo4j-uri bolt://localhost:7687 --neo4j-username neo4j --neo4j-password password
 
+  # Using a configuration file (environment variables and flags take precedence)
+  neo4j-mcp --config /etc/neo4j-fraud-mcp/config.yaml
+
 For more information, visit: https://github.com/mkd-neo4j/neo4j-mcp-fraud
 `
 
 // Args holds configuration values parsed from command-line flags
 type Args struct {
+	ConfigFile         string
 	URI                string
 	Username           string
 	Password           string
@@ -83,6 +147,7 @@ type Args struct {
 // this is a list of known configuration flags to be skipped in HandleArgs
 // add new config flags here as needed
 var argsSlice = []string{
+	"--config",
 	"--neo4j-uri",
 	"--neo4j-username",
 	"--neo4j-password",
@@ -102,6 +167,7 @@ var argsSlice = []string{
 // ParseConfigFlags parses CLI flags and returns configuration values.
 // It should be called after HandleArgs to ensure help/version flags are processed first.
 func ParseConfigFlags() *Args {
+	configFile := flag.String("config", "", "YAML configuration file, overridden by environment variables and other flags")
 	neo4jURI := flag.String("neo4j-uri", "", "Neo4j connection URI (overrides NEO4J_URI env var)")
 	neo4jUsername := flag.String("neo4j-username", "", "Neo4j username (overrides NEO4J_USERNAME env var)")
 	neo4jPassword := flag.String("neo4j-password", "", "Neo4j password (overrides NEO4J_PASSWORD env var)")
@@ -109,7 +175,7 @@ func ParseConfigFlags() *Args {
 	neo4jReadOnly := flag.String("neo4j-read-only", "", "Enable read-only mode: true or false (overrides NEO4J_READ_ONLY env var)")
 	neo4jTelemetry := flag.String("neo4j-telemetry", "", "Enable telemetry: true or false (overrides NEO4J_TELEMETRY env var)")
 	neo4jSchemaSampleSize := flag.String("neo4j-schema-sample-size", "", "Number of nodes to sample for schema inference (overrides NEO4J_SCHEMA_SAMPLE_SIZE env var)")
-	neo4jTransportMode := flag.String("neo4j-transport-mode", "", "MCP Transport mode (e.g., 'stdio', 'http') (overrides NEO4J_MCP_TRANSPORT env var)")
+	neo4jTransportMode := flag.String("neo4j-transport-mode", "", "MCP Transport mode ('stdio', 'http' or 'sse') (overrides NEO4J_MCP_TRANSPORT env var)")
 	neo4jHTTPPort := flag.String("neo4j-http-port", "", "HTTP server port (overrides NEO4J_MCP_HTTP_PORT env var)")
 	neo4jHTTPHost := flag.String("neo4j-http-host", "", "HTTP server host (overrides NEO4J_MCP_HTTP_HOST env var)")
 	neo4jHTTPAllowedOrigins := flag.String("neo4j-http-allowed-origins", "", "Comma-separated list of allowed CORS origins (overrides NEO4J_MCP_HTTP_ALLOWED_ORIGINS env var)")
@@ -120,6 +186,7 @@ func ParseConfigFlags() *Args {
 	flag.Parse()
 
 	return &Args{
+		ConfigFile:         *configFile,
 		URI:                *neo4jURI,
 		Username:           *neo4jUsername,
 		Password:           *neo4jPassword,
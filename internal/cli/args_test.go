@@ -134,6 +134,12 @@ func TestHandleArgs(t *testing.T) {
 			version:          testVersion,
 			expectedExitCode: -1, // Should not exit, flag is allowed
 		},
+		{
+			name:             "config file flag",
+			args:             []string{testProgramName, "--config", "config.yaml", "--neo4j-uri", "bolt://localhost:7687"},
+			version:          testVersion,
+			expectedExitCode: -1, // Should not exit, flag is allowed
+		},
 		{
 			name:             "multiple configuration flags",
 			args:             []string{testProgramName, "--neo4j-uri", "bolt://localhost:7687", "--neo4j-username", "user"},
@@ -2,43 +2,182 @@ package config
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"os"
+	"path/filepath"
 	"slices"
 	"strconv"
+	"strings"
 
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/logger"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/policy"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tenant"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tracing"
 )
 
 const (
 	// DefaultSchemaSampleSize is the default number of nodes to sample per label when inferring schema
-	DefaultSchemaSampleSize int32  = 100
-	TransportModeStdio      string = "stdio"
-	TransportModeHTTP       string = "http"
+	DefaultSchemaSampleSize int32 = 100
+	// DefaultSchemaCacheTTL is the default number of seconds a retrieved schema is reused before it is reloaded
+	DefaultSchemaCacheTTL int32 = 300
+	// DefaultResultCacheSize is the default number of read query results kept when result caching is enabled
+	DefaultResultCacheSize int32 = 500
+	// DefaultReferenceModelCacheTTL is the default number of seconds a downloaded reference model is used before it is revalidated
+	DefaultReferenceModelCacheTTL int32 = 86400
+	// DefaultQueryTimeout is the default number of seconds a Cypher tool query may run before it is aborted
+	DefaultQueryTimeout int32 = 60
+	// DefaultQueryMaxRows is the default number of rows a Cypher tool returns before the result is truncated
+	DefaultQueryMaxRows int32 = 1000
+	// DefaultResponseMaxTokens is the default estimated token count above which a tool response is split into chunks
+	DefaultResponseMaxTokens int32 = 20000
+	// DefaultQueryRetries is the default number of times a query failing with a transient error is retried
+	DefaultQueryRetries int32 = 2
+	// DefaultBreakerThreshold is the default number of consecutive failures to reach Neo4j that open the circuit breaker
+	DefaultBreakerThreshold int32 = 5
+	// DefaultBreakerCooldown is the default number of seconds the open circuit breaker fails queries fast
+	DefaultBreakerCooldown int32 = 30
+	// DefaultShutdownTimeout is the default number of seconds a shutdown waits for in-flight tool calls before cancelling them
+	DefaultShutdownTimeout int32 = 30
+	// DefaultOTelServiceName is the default service name of exported trace spans
+	DefaultOTelServiceName string = "neo4j-fraud-mcp"
+	// DefaultFlagAllowedProperties is the default set of properties the flag-entity tool may set
+	DefaultFlagAllowedProperties string = "underReview,riskTier,reviewedBy,reviewedAt,reviewNotes"
+	TransportModeStdio           string = "stdio"
+	TransportModeHTTP            string = "http" // Streamable HTTP, also accepted as "streamable-http"
+	TransportModeSSE             string = "sse"
+	ProfileInvestigator          string = "investigator"
+	ProfileAnalyst               string = "analyst"
+	ProfileAdmin                 string = "admin"
+	ProfileDemo                  string = "demo"
+	HTTPAuthBasic                string = "basic"
+	HTTPAuthAPIKey               string = "api-key"
+	HTTPAuthOIDC                 string = "oidc"
+	RoleAnalyst                  string = "analyst"
+	RoleInvestigator             string = "investigator"
+	RoleAdmin                    string = "admin"
+	PIIMaskModeOff               string = "off"
+	PIIMaskModeFull              string = "full"
+	PIIMaskModePartial           string = "partial"  // Only the last 4 characters are shown
+	PIIMaskModeHash              string = "hash"     // Keyed hash, so equal values can still be correlated
+	ReadRoutingReplicas          string = "replicas" // Read queries run on read replicas and followers
+	ReadRoutingLeader            string = "leader"   // Read queries run on the leader, like writes
+	ReadConsistencyCausal        string = "causal"   // Reads wait for replicas to apply the writes made through the server
+	ReadConsistencyEventual      string = "eventual" // Reads never wait for replicas to catch up
+	AuthSchemeBasic              string = "basic"
+	AuthSchemeBearer             string = "bearer" // SSO token read from a file or obtained with OAuth client credentials
+	AuthSchemeKerberos           string = "kerberos"
 )
 
 // ValidTransportModes defines the allowed transport mode values
-var ValidTransportModes = []string{TransportModeStdio, TransportModeHTTP}
+var ValidTransportModes = []string{TransportModeStdio, TransportModeHTTP, TransportModeSSE}
+
+// transportModeStreamableHTTP is an alias of TransportModeHTTP
+const transportModeStreamableHTTP = "streamable-http"
+
+// IsHTTPTransport reports whether a transport mode serves MCP over HTTP,
+// where callers authenticate on every request
+func IsHTTPTransport(transportMode string) bool {
+	return transportMode == TransportModeHTTP || transportMode == TransportModeSSE
+}
+
+// ValidAuthSchemes defines how the server authenticates to Neo4j with its own credentials
+var ValidAuthSchemes = []string{AuthSchemeBasic, AuthSchemeBearer, AuthSchemeKerberos}
+
+// ValidHTTPAuthModes defines how HTTP and SSE requests are authenticated
+var ValidHTTPAuthModes = []string{HTTPAuthBasic, HTTPAuthAPIKey, HTTPAuthOIDC}
+
+// ValidRoles defines the roles that can be assigned to HTTP callers when role-based access control is enabled
+var ValidRoles = []string{RoleAnalyst, RoleInvestigator, RoleAdmin}
+
+// ValidPIIMaskModes defines how personal data in tool outputs may be masked
+var ValidPIIMaskModes = []string{PIIMaskModeOff, PIIMaskModeFull, PIIMaskModePartial, PIIMaskModeHash}
+
+// ValidProfiles defines the allowed deployment profiles; an empty profile exposes every tool category
+var ValidProfiles = []string{ProfileInvestigator, ProfileAnalyst, ProfileAdmin, ProfileDemo}
 
 // Config holds the application configuration
 type Config struct {
-	URI                string
-	Username           string
-	Password           string
-	Database           string
-	ReadOnly           bool // If true, disables write tools
-	Telemetry          bool // If false, disables telemetry
-	LogLevel           string
-	LogFormat          string
-	SchemaSampleSize   int32
-	TransportMode      string // MCP Transport mode (e.g., "stdio", "http")
-	HTTPPort           string // HTTP server port (default: "443" with TLS, "80" without TLS)
-	HTTPHost           string // HTTP server host (default: "127.0.0.1")
-	HTTPAllowedOrigins string // Comma-separated list of allowed CORS origins (optional, "*" for all)
-	HTTPTLSEnabled     bool   // If true, enables TLS/HTTPS for HTTP server (default: false)
-	HTTPTLSCertFile    string // Path to TLS certificate file (required if HTTPTLSEnabled is true)
-	HTTPTLSKeyFile     string // Path to TLS private key file (required if HTTPTLSEnabled is true)
+	URI                    string
+	Username               string
+	Password               string
+	Database               string
+	AuthScheme             string // How the server authenticates to Neo4j: "basic" (default), "bearer" or "kerberos"
+	BearerTokenFile        string // File holding the bearer token, read again when the token expires
+	OAuthTokenURL          string // Token endpoint bearer tokens are obtained from with the OAuth client credentials grant
+	OAuthClientID          string // Client ID of the client credentials grant
+	OAuthClientSecret      string // Client secret of the client credentials grant
+	OAuthScope             string // Space-separated scopes requested with the client credentials grant (optional)
+	KerberosTicketFile     string // File holding the base64-encoded Kerberos ticket, read again when Neo4j rejects it
+	TLSClientCertFile      string // Client certificate presented to Neo4j for mutual TLS (optional)
+	TLSClientKeyFile       string // Private key of the client certificate
+	TLSCAFile              string // PEM CA certificates trusted for the Neo4j server certificate, instead of the system roots (optional)
+	ReadOnly               bool   // If true, disables write tools
+	AdminTools             bool   // If true, enables the admin tools that list and kill any running query
+	EnabledTools           string // Comma-separated tool or category names; when set, only these tools are registered
+	DisabledTools          string // Comma-separated tool or category names that are never registered
+	Profile                string // Deployment profile selecting the tool categories to expose (e.g., "investigator", "analyst")
+	Telemetry              bool   // If false, disables telemetry
+	LogLevel               string
+	LogFormat              string
+	SchemaSampleSize       int32
+	SchemaCacheTTL         int32  // Seconds a retrieved schema is cached; 0 disables caching
+	ResultCacheTTL         int32  // Seconds the results of idempotent read tools are cached; 0 disables caching
+	ResultCacheSize        int32  // Read query results kept in the result cache
+	ReferenceModelCacheDir string // Directory reference models are cached in; empty disables the disk cache
+	ReferenceModelCacheTTL int32  // Seconds a cached reference model is used before it is revalidated
+	ReferenceModels        string // Comma-separated name=url pairs registering additional reference models
+	ReferenceModelHosts    string // Comma-separated hosts reference models may be downloaded from, besides those of the built-in models
+	MappingStoreFile       string // File schema mappings saved with persist are kept in; empty keeps mappings in memory only
+	QueryTimeout           int32  // Default seconds a Cypher tool query may run; 0 disables the timeout
+	QueryMaxRows           int32  // Default number of rows a Cypher tool returns; 0 disables truncation
+	ResponseMaxTokens      int32  // Estimated tokens a tool response may hold before it is split into chunks; 0 disables chunking
+	MaxConcurrentToolCalls int32  // Tool calls a client may run at once; 0 disables the cap
+	ToolCallsPerMinute     int32  // Tool calls a client may start per minute; 0 disables rate limiting
+	ShutdownTimeout        int32  // Seconds a shutdown waits for in-flight tool calls before cancelling them
+	QueryRetries           int32  // Retries of a query failing with a transient error; 0 disables retries
+	BreakerThreshold       int32  // Consecutive failures to reach Neo4j that open the circuit breaker; 0 disables it
+	BreakerCooldown        int32  // Seconds the open circuit breaker fails queries fast before probing Neo4j again
+	MaxConnectionPoolSize  int32  // Connections the driver keeps per Neo4j server; 0 uses the driver default (100)
+	AcquisitionTimeout     int32  // Seconds a query waits for a pooled connection; 0 uses the driver default (60)
+	MaxTxRetryTime         int32  // Seconds the driver retries a failing transaction; 0 uses the driver default (30)
+	FetchSize              int32  // Records pulled from Neo4j per batch, -1 pulls all at once; 0 uses the driver default (1000)
+	ReadRouting            string // Cluster members read queries run on: "replicas" (default) or "leader"
+	ReadConsistency        string // Whether reads wait for replicas to apply earlier writes: "causal" (default) or "eventual"
+	MetricsAddress         string // host:port the Prometheus metrics endpoint listens on; empty disables metrics
+	OTLPEndpoint           string // Base URL of the OTLP/HTTP collector spans are exported to; empty disables tracing
+	OTLPHeaders            string // Comma-separated key=value headers sent to the OTLP collector
+	OTelServiceName        string // Service name of the exported spans
+	AuditLog               string // File the audit log is appended to, or "syslog"; empty disables auditing
+	AuditRedactFields      string // Comma-separated argument names redacted in the audit log, in addition to the defaults
+	PIIMaskMode            string // How personal data in tool outputs is masked: "off" (default), "full", "partial" or "hash"
+	PIIMaskFields          string // Comma-separated property names masked in tool outputs; empty masks the default fields
+	PIIHashKey             string // Key of the hashes in "hash" mode; empty uses a random key per process
+	PIIUnmaskRoles         string // Comma-separated roles allowed to override the masking mode per tool call
+	QueryPolicyEnabled     bool   // If true, queries run by tools are checked against the query policy (default: true)
+	QueryPolicyFile        string // JSON file of allow and deny rules checked before the built-in rules; empty applies only the built-ins
+	TransportMode          string // MCP Transport mode (e.g., "stdio", "http")
+	HTTPPort               string // HTTP server port (default: "443" with TLS, "80" without TLS)
+	HTTPHost               string // HTTP server host (default: "127.0.0.1")
+	HTTPAllowedOrigins     string // Comma-separated list of allowed CORS origins (optional, "*" for all)
+	HTTPTLSEnabled         bool   // If true, enables TLS/HTTPS for HTTP server (default: false)
+	HTTPTLSCertFile        string // Path to TLS certificate file (required if HTTPTLSEnabled is true)
+	HTTPTLSKeyFile         string // Path to TLS private key file (required if HTTPTLSEnabled is true)
+	HTTPAuthMode           string // How HTTP requests are authenticated: "basic" (default), "api-key" or "oidc"
+	HTTPAPIKeys            string // Comma-separated identity=key pairs accepted in "api-key" mode
+	OIDCIssuer             string // Issuer URL of the OpenID Connect provider in "oidc" mode
+	OIDCAudience           string // Audience bearer tokens must be issued for in "oidc" mode
+	OIDCIdentityClaim      string // Token claim identifying the caller in "oidc" mode (default: "sub")
+	RBACEnabled            bool   // If true, HTTP callers may only list and call the tools their roles grant
+	Roles                  string // Comma-separated identity=role pairs; several roles are separated by "|"
+	DefaultRole            string // Role of callers without an entry in Roles; empty grants no tools
+	TenantsFile            string // JSON file routing HTTP callers to tenant databases and credentials; empty disables tenant routing
+	Impersonation          bool   // If true, queries of HTTP callers run as the Neo4j user named by their identity
+	FlagAllowedProperties  string // Comma-separated list of properties the flag-entity tool is allowed to set
 }
 
 // Validate validates the configuration and returns an error if invalid
@@ -56,27 +195,152 @@ func (c *Config) Validate() error {
 	if c.TransportMode == "" {
 		c.TransportMode = TransportModeStdio
 	}
+	if c.TransportMode == transportModeStreamableHTTP {
+		c.TransportMode = TransportModeHTTP
+	}
 
 	// Validate transport mode
 	if !slices.Contains(ValidTransportModes, c.TransportMode) {
 		return fmt.Errorf("invalid transport mode '%s', must be one of %v", c.TransportMode, ValidTransportModes)
 	}
 
-	// For STDIO mode, require username and password from environment
-	// For HTTP mode, credentials come from per-request Basic Auth headers
-	if c.TransportMode == TransportModeStdio {
-		if c.Username == "" {
-			return fmt.Errorf("Neo4j username is required for STDIO mode")
-		}
-		if c.Password == "" {
-			return fmt.Errorf("Neo4j password is required for STDIO mode")
+	// Validate deployment profile, empty means no profile
+	if c.Profile != "" && !slices.Contains(ValidProfiles, c.Profile) {
+		return fmt.Errorf("invalid profile '%s', must be one of %v", c.Profile, ValidProfiles)
+	}
+
+	if c.HTTPAuthMode == "" {
+		c.HTTPAuthMode = HTTPAuthBasic
+	}
+	if !slices.Contains(ValidHTTPAuthModes, c.HTTPAuthMode) {
+		return fmt.Errorf("invalid HTTP auth mode '%s', must be one of %v", c.HTTPAuthMode, ValidHTTPAuthModes)
+	}
+
+	if c.AuthScheme == "" {
+		c.AuthScheme = AuthSchemeBasic
+	}
+	if !slices.Contains(ValidAuthSchemes, c.AuthScheme) {
+		return fmt.Errorf("invalid NEO4J_AUTH_SCHEME '%s', must be one of %v", c.AuthScheme, ValidAuthSchemes)
+	}
+	if c.AuthScheme != AuthSchemeBasic && !c.UsesServiceCredentials() {
+		return fmt.Errorf("NEO4J_AUTH_SCHEME %s requires STDIO mode or %s or %s HTTP auth mode; with Basic Auth, queries run with each request's credentials", c.AuthScheme, HTTPAuthAPIKey, HTTPAuthOIDC)
+	}
+
+	// For STDIO mode, and HTTP mode authenticating with API keys or OIDC tokens, require the server's credentials from environment
+	// For HTTP mode with Basic Auth, credentials come from per-request Basic Auth headers
+	if c.UsesServiceCredentials() {
+		if err := c.validateServiceAuth(); err != nil {
+			return err
 		}
 	} else if c.Username != "" || c.Password != "" {
 		return fmt.Errorf("Neo4j username and password should not be set for HTTP transport mode; credentials are provided per-request via Basic Auth headers")
 	}
 
-	// For HTTP mode with TLS enabled, require certificate and key files
-	if c.TransportMode == TransportModeHTTP && c.HTTPTLSEnabled {
+	if err := c.validateDriverTLS(); err != nil {
+		return err
+	}
+
+	if IsHTTPTransport(c.TransportMode) {
+		switch c.HTTPAuthMode {
+		case HTTPAuthAPIKey:
+			if _, err := auth.ParseAPIKeys(c.HTTPAPIKeys); err != nil {
+				return fmt.Errorf("invalid NEO4J_MCP_HTTP_API_KEYS: %w", err)
+			}
+		case HTTPAuthOIDC:
+			if c.OIDCIssuer == "" {
+				return fmt.Errorf("OIDC issuer is required when HTTP auth mode is oidc (set NEO4J_MCP_OIDC_ISSUER)")
+			}
+			if c.OIDCAudience == "" {
+				return fmt.Errorf("OIDC audience is required when HTTP auth mode is oidc (set NEO4J_MCP_OIDC_AUDIENCE)")
+			}
+		}
+	}
+
+	if c.RBACEnabled {
+		if _, err := ParseRoleAssignments(c.Roles); err != nil {
+			return fmt.Errorf("invalid NEO4J_MCP_ROLES: %w", err)
+		}
+		if c.DefaultRole != "" && !slices.Contains(ValidRoles, c.DefaultRole) {
+			return fmt.Errorf("invalid default role '%s', must be one of %v", c.DefaultRole, ValidRoles)
+		}
+	}
+
+	if c.TenantsFile != "" {
+		if _, err := tenant.Load(c.TenantsFile); err != nil {
+			return fmt.Errorf("invalid NEO4J_MCP_TENANTS_FILE: %w", err)
+		}
+		// Basic Auth passwords are only checked by Neo4j, so an unverified username must not select tenant credentials
+		if IsHTTPTransport(c.TransportMode) && !c.UsesServiceCredentials() {
+			return fmt.Errorf("NEO4J_MCP_TENANTS_FILE requires %s or %s HTTP auth mode", HTTPAuthAPIKey, HTTPAuthOIDC)
+		}
+	}
+
+	// Callers only have an identity of their own over HTTP, and with Basic Auth queries already run with their credentials
+	if c.Impersonation && (!IsHTTPTransport(c.TransportMode) || !c.UsesServiceCredentials()) {
+		return fmt.Errorf("NEO4J_IMPERSONATION requires HTTP transport with %s or %s HTTP auth mode", HTTPAuthAPIKey, HTTPAuthOIDC)
+	}
+
+	if c.PIIMaskMode == "" {
+		c.PIIMaskMode = PIIMaskModeOff
+	}
+	if !slices.Contains(ValidPIIMaskModes, c.PIIMaskMode) {
+		return fmt.Errorf("invalid NEO4J_PII_MASK_MODE '%s', must be one of %v", c.PIIMaskMode, ValidPIIMaskModes)
+	}
+	for _, role := range ParseList(c.PIIUnmaskRoles) {
+		if !slices.Contains(ValidRoles, role) {
+			return fmt.Errorf("invalid role '%s' in NEO4J_PII_UNMASK_ROLES, must be one of %v", role, ValidRoles)
+		}
+	}
+
+	if c.QueryPolicyEnabled {
+		if _, err := policy.Load(c.QueryPolicyFile); err != nil {
+			return fmt.Errorf("invalid NEO4J_QUERY_POLICY_FILE: %w", err)
+		}
+	}
+
+	for name, value := range map[string]int32{
+		"NEO4J_MAX_CONNECTION_POOL_SIZE":       c.MaxConnectionPoolSize,
+		"NEO4J_CONNECTION_ACQUISITION_TIMEOUT": c.AcquisitionTimeout,
+		"NEO4J_MAX_TRANSACTION_RETRY_TIME":     c.MaxTxRetryTime,
+	} {
+		if value < 0 {
+			return fmt.Errorf("invalid %s %d, must not be negative", name, value)
+		}
+	}
+	if c.FetchSize < -1 {
+		return fmt.Errorf("invalid NEO4J_FETCH_SIZE %d, must be -1 (fetch all records) or more", c.FetchSize)
+	}
+
+	if c.ReadRouting == "" {
+		c.ReadRouting = ReadRoutingReplicas
+	}
+	if c.ReadRouting != ReadRoutingReplicas && c.ReadRouting != ReadRoutingLeader {
+		return fmt.Errorf("invalid NEO4J_READ_ROUTING '%s', must be %s or %s", c.ReadRouting, ReadRoutingReplicas, ReadRoutingLeader)
+	}
+	if c.ReadConsistency == "" {
+		c.ReadConsistency = ReadConsistencyCausal
+	}
+	if c.ReadConsistency != ReadConsistencyCausal && c.ReadConsistency != ReadConsistencyEventual {
+		return fmt.Errorf("invalid NEO4J_READ_CONSISTENCY '%s', must be %s or %s", c.ReadConsistency, ReadConsistencyCausal, ReadConsistencyEventual)
+	}
+
+	if c.MetricsAddress != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddress); err != nil {
+			return fmt.Errorf("invalid NEO4J_METRICS_ADDRESS '%s', expected host:port: %w", c.MetricsAddress, err)
+		}
+	}
+
+	if c.OTLPEndpoint != "" {
+		if endpoint, err := url.Parse(c.OTLPEndpoint); err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+			return fmt.Errorf("invalid OTEL_EXPORTER_OTLP_ENDPOINT '%s', expected an http or https URL", c.OTLPEndpoint)
+		}
+		if _, err := tracing.ParseHeaders(c.OTLPHeaders); err != nil {
+			return fmt.Errorf("invalid OTEL_EXPORTER_OTLP_HEADERS: %w", err)
+		}
+	}
+
+	// For HTTP modes with TLS enabled, require certificate and key files
+	if IsHTTPTransport(c.TransportMode) && c.HTTPTLSEnabled {
 		if c.HTTPTLSCertFile == "" {
 			return fmt.Errorf("TLS certificate file is required when TLS is enabled (set NEO4J_MCP_HTTP_TLS_CERT_FILE)")
 		}
@@ -94,8 +358,115 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// UsesServiceCredentials reports whether Neo4j is accessed with the configured username and password
+// rather than with the Basic Auth credentials of each HTTP request
+func (c *Config) UsesServiceCredentials() bool {
+	return c.TransportMode == TransportModeStdio || (c.HTTPAuthMode != "" && c.HTTPAuthMode != HTTPAuthBasic)
+}
+
+// validateServiceAuth checks the server's own credentials are complete for the auth scheme
+func (c *Config) validateServiceAuth() error {
+	switch c.AuthScheme {
+	case AuthSchemeBearer:
+		if (c.BearerTokenFile == "") == (c.OAuthTokenURL == "") {
+			return fmt.Errorf("bearer auth scheme requires either NEO4J_BEARER_TOKEN_FILE or NEO4J_OAUTH_TOKEN_URL")
+		}
+		if c.OAuthTokenURL != "" {
+			if tokenURL, err := url.Parse(c.OAuthTokenURL); err != nil || (tokenURL.Scheme != "http" && tokenURL.Scheme != "https") || tokenURL.Host == "" {
+				return fmt.Errorf("invalid NEO4J_OAUTH_TOKEN_URL '%s', expected an http or https URL", c.OAuthTokenURL)
+			}
+			if c.OAuthClientID == "" || c.OAuthClientSecret == "" {
+				return fmt.Errorf("NEO4J_OAUTH_CLIENT_ID and NEO4J_OAUTH_CLIENT_SECRET are required with NEO4J_OAUTH_TOKEN_URL")
+			}
+		}
+	case AuthSchemeKerberos:
+		if c.KerberosTicketFile == "" {
+			return fmt.Errorf("kerberos auth scheme requires NEO4J_KERBEROS_TICKET_FILE")
+		}
+	default:
+		if c.Username == "" {
+			return fmt.Errorf("Neo4j username is required for %s", c.credentialsMode())
+		}
+		if c.Password == "" {
+			return fmt.Errorf("Neo4j password is required for %s", c.credentialsMode())
+		}
+	}
+	return nil
+}
+
+// validateDriverTLS checks the client certificate and CA files of connections to Neo4j.
+// The driver only uses them on encrypted connections, so the URI must use a +s or +ssc scheme.
+func (c *Config) validateDriverTLS() error {
+	if c.TLSClientCertFile == "" && c.TLSClientKeyFile == "" && c.TLSCAFile == "" {
+		return nil
+	}
+	if scheme, _, _ := strings.Cut(c.URI, "://"); !strings.HasSuffix(scheme, "+s") && !strings.HasSuffix(scheme, "+ssc") {
+		return fmt.Errorf("NEO4J_TLS_* settings require an encrypted Neo4j URI scheme such as neo4j+s, got '%s'", scheme)
+	}
+	if (c.TLSClientCertFile == "") != (c.TLSClientKeyFile == "") {
+		return fmt.Errorf("NEO4J_TLS_CLIENT_CERT_FILE and NEO4J_TLS_CLIENT_KEY_FILE must be set together")
+	}
+	if c.TLSClientCertFile != "" {
+		if _, err := tls.LoadX509KeyPair(c.TLSClientCertFile, c.TLSClientKeyFile); err != nil {
+			return fmt.Errorf("failed to load Neo4j client certificate and key: %w", err)
+		}
+	}
+	if c.TLSCAFile != "" {
+		if _, err := LoadCertPool(c.TLSCAFile); err != nil {
+			return fmt.Errorf("invalid NEO4J_TLS_CA_FILE: %w", err)
+		}
+	}
+	return nil
+}
+
+// LoadCertPool returns a pool of the PEM certificates in path
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+func (c *Config) credentialsMode() string {
+	if c.TransportMode == TransportModeStdio {
+		return "STDIO mode"
+	}
+	return c.HTTPAuthMode + " HTTP auth mode"
+}
+
+// ParseRoleAssignments parses comma-separated identity=role pairs (e.g. "alice=investigator,bob=analyst|admin")
+// into the roles of each identity
+func ParseRoleAssignments(pairs string) (map[string][]string, error) {
+	assignments := make(map[string][]string)
+	for _, pair := range strings.Split(pairs, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		identity, roles, ok := strings.Cut(pair, "=")
+		identity = strings.TrimSpace(identity)
+		if !ok || identity == "" {
+			return nil, fmt.Errorf("invalid role assignment %q, expected identity=role", pair)
+		}
+		for _, role := range strings.Split(roles, "|") {
+			role = strings.TrimSpace(role)
+			if !slices.Contains(ValidRoles, role) {
+				return nil, fmt.Errorf("invalid role '%s' for %s, must be one of %v", role, identity, ValidRoles)
+			}
+			assignments[identity] = append(assignments[identity], role)
+		}
+	}
+	return assignments, nil
+}
+
 // CLIOverrides holds optional configuration values from CLI flags
 type CLIOverrides struct {
+	ConfigFile     string // YAML configuration file, see loadFile
 	URI            string
 	Username       string
 	Password       string
@@ -111,12 +482,22 @@ type CLIOverrides struct {
 	TLSKeyFile     string
 }
 
-// LoadConfig loads configuration from environment variables, applies CLI overrides, and validates.
-// CLI flag values take precedence over environment variables.
+// LoadConfig loads configuration from the YAML file named by the --config flag, if any, and environment variables,
+// applies CLI overrides, and validates. CLI flag values take precedence over environment variables, which take
+// precedence over the file.
 // Returns an error if required configuration is missing or invalid.
 func LoadConfig(cliOverrides *CLIOverrides) (*Config, error) {
-	logLevel := GetEnvWithDefault("NEO4J_LOG_LEVEL", "info")
-	logFormat := GetEnvWithDefault("NEO4J_LOG_FORMAT", "text")
+	var env fileValues
+	if cliOverrides != nil && cliOverrides.ConfigFile != "" {
+		values, err := loadFile(cliOverrides.ConfigFile)
+		if err != nil {
+			return nil, err
+		}
+		env = values
+	}
+
+	logLevel := env.getWithDefault("NEO4J_LOG_LEVEL", "info")
+	logFormat := env.getWithDefault("NEO4J_LOG_FORMAT", "text")
 
 	// Validate log level and use default if invalid
 	if !slices.Contains(logger.ValidLogLevels, logLevel) {
@@ -131,22 +512,82 @@ func LoadConfig(cliOverrides *CLIOverrides) (*Config, error) {
 	}
 
 	cfg := &Config{
-		URI:                GetEnv("NEO4J_URI"),
-		Username:           GetEnv("NEO4J_USERNAME"),
-		Password:           GetEnv("NEO4J_PASSWORD"),
-		Database:           GetEnvWithDefault("NEO4J_DATABASE", "neo4j"),
-		ReadOnly:           ParseBool(GetEnv("NEO4J_READ_ONLY"), false),
-		Telemetry:          ParseBool(GetEnv("NEO4J_TELEMETRY"), true),
-		LogLevel:           logLevel,
-		LogFormat:          logFormat,
-		SchemaSampleSize:   ParseInt32(GetEnv("NEO4J_SCHEMA_SAMPLE_SIZE"), DefaultSchemaSampleSize),
-		TransportMode:      GetEnvWithDefault("NEO4J_MCP_TRANSPORT", "stdio"),
-		HTTPPort:           GetEnv("NEO4J_MCP_HTTP_PORT"), // Default set after TLS determination
-		HTTPHost:           GetEnvWithDefault("NEO4J_MCP_HTTP_HOST", "127.0.0.1"),
-		HTTPAllowedOrigins: GetEnv("NEO4J_MCP_HTTP_ALLOWED_ORIGINS"),
-		HTTPTLSEnabled:     ParseBool(GetEnv("NEO4J_MCP_HTTP_TLS_ENABLED"), false),
-		HTTPTLSCertFile:    GetEnv("NEO4J_MCP_HTTP_TLS_CERT_FILE"),
-		HTTPTLSKeyFile:     GetEnv("NEO4J_MCP_HTTP_TLS_KEY_FILE"),
+		URI:                    env.get("NEO4J_URI"),
+		Username:               env.get("NEO4J_USERNAME"),
+		Password:               env.get("NEO4J_PASSWORD"),
+		Database:               env.getWithDefault("NEO4J_DATABASE", "neo4j"),
+		AuthScheme:             env.getWithDefault("NEO4J_AUTH_SCHEME", AuthSchemeBasic),
+		BearerTokenFile:        env.get("NEO4J_BEARER_TOKEN_FILE"),
+		OAuthTokenURL:          env.get("NEO4J_OAUTH_TOKEN_URL"),
+		OAuthClientID:          env.get("NEO4J_OAUTH_CLIENT_ID"),
+		OAuthClientSecret:      env.get("NEO4J_OAUTH_CLIENT_SECRET"),
+		OAuthScope:             env.get("NEO4J_OAUTH_SCOPE"),
+		KerberosTicketFile:     env.get("NEO4J_KERBEROS_TICKET_FILE"),
+		TLSClientCertFile:      env.get("NEO4J_TLS_CLIENT_CERT_FILE"),
+		TLSClientKeyFile:       env.get("NEO4J_TLS_CLIENT_KEY_FILE"),
+		TLSCAFile:              env.get("NEO4J_TLS_CA_FILE"),
+		ReadOnly:               ParseBool(env.get("NEO4J_READ_ONLY"), false),
+		AdminTools:             ParseBool(env.get("NEO4J_ADMIN_TOOLS"), false),
+		EnabledTools:           env.get("NEO4J_ENABLED_TOOLS"),
+		DisabledTools:          env.get("NEO4J_DISABLED_TOOLS"),
+		Profile:                env.get("NEO4J_PROFILE"),
+		Telemetry:              ParseBool(env.get("NEO4J_TELEMETRY"), true),
+		LogLevel:               logLevel,
+		LogFormat:              logFormat,
+		SchemaSampleSize:       ParseInt32(env.get("NEO4J_SCHEMA_SAMPLE_SIZE"), DefaultSchemaSampleSize),
+		SchemaCacheTTL:         ParseInt32(env.get("NEO4J_SCHEMA_CACHE_TTL"), DefaultSchemaCacheTTL),
+		ResultCacheTTL:         ParseInt32(env.get("NEO4J_RESULT_CACHE_TTL"), 0),
+		ResultCacheSize:        ParseInt32(env.get("NEO4J_RESULT_CACHE_SIZE"), DefaultResultCacheSize),
+		ReferenceModelCacheDir: env.getWithDefault("NEO4J_REFERENCE_MODEL_CACHE_DIR", defaultReferenceModelCacheDir()),
+		ReferenceModelCacheTTL: ParseInt32(env.get("NEO4J_REFERENCE_MODEL_CACHE_TTL"), DefaultReferenceModelCacheTTL),
+		ReferenceModels:        env.get("NEO4J_REFERENCE_MODELS"),
+		ReferenceModelHosts:    env.get("NEO4J_REFERENCE_MODEL_HOSTS"),
+		MappingStoreFile:       env.get("NEO4J_MAPPING_STORE_FILE"),
+		QueryTimeout:           ParseInt32(env.get("NEO4J_QUERY_TIMEOUT"), DefaultQueryTimeout),
+		QueryMaxRows:           ParseInt32(env.get("NEO4J_QUERY_MAX_ROWS"), DefaultQueryMaxRows),
+		ResponseMaxTokens:      ParseInt32(env.get("NEO4J_RESPONSE_MAX_TOKENS"), DefaultResponseMaxTokens),
+		MaxConcurrentToolCalls: ParseInt32(env.get("NEO4J_MAX_CONCURRENT_TOOL_CALLS"), 0),
+		ToolCallsPerMinute:     ParseInt32(env.get("NEO4J_TOOL_CALLS_PER_MINUTE"), 0),
+		ShutdownTimeout:        ParseInt32(env.get("NEO4J_SHUTDOWN_TIMEOUT"), DefaultShutdownTimeout),
+		QueryRetries:           ParseInt32(env.get("NEO4J_QUERY_RETRIES"), DefaultQueryRetries),
+		BreakerThreshold:       ParseInt32(env.get("NEO4J_CIRCUIT_BREAKER_THRESHOLD"), DefaultBreakerThreshold),
+		BreakerCooldown:        ParseInt32(env.get("NEO4J_CIRCUIT_BREAKER_COOLDOWN"), DefaultBreakerCooldown),
+		MaxConnectionPoolSize:  ParseInt32(env.get("NEO4J_MAX_CONNECTION_POOL_SIZE"), 0),
+		AcquisitionTimeout:     ParseInt32(env.get("NEO4J_CONNECTION_ACQUISITION_TIMEOUT"), 0),
+		MaxTxRetryTime:         ParseInt32(env.get("NEO4J_MAX_TRANSACTION_RETRY_TIME"), 0),
+		FetchSize:              ParseInt32(env.get("NEO4J_FETCH_SIZE"), 0),
+		ReadRouting:            env.getWithDefault("NEO4J_READ_ROUTING", ReadRoutingReplicas),
+		ReadConsistency:        env.getWithDefault("NEO4J_READ_CONSISTENCY", ReadConsistencyCausal),
+		MetricsAddress:         env.get("NEO4J_METRICS_ADDRESS"),
+		OTLPEndpoint:           env.get("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		OTLPHeaders:            env.get("OTEL_EXPORTER_OTLP_HEADERS"),
+		OTelServiceName:        env.getWithDefault("OTEL_SERVICE_NAME", DefaultOTelServiceName),
+		AuditLog:               env.get("NEO4J_AUDIT_LOG"),
+		AuditRedactFields:      env.get("NEO4J_AUDIT_REDACT_FIELDS"),
+		PIIMaskMode:            env.getWithDefault("NEO4J_PII_MASK_MODE", PIIMaskModeOff),
+		PIIMaskFields:          env.get("NEO4J_PII_MASK_FIELDS"),
+		PIIHashKey:             env.get("NEO4J_PII_HASH_KEY"),
+		PIIUnmaskRoles:         env.get("NEO4J_PII_UNMASK_ROLES"),
+		QueryPolicyEnabled:     ParseBool(env.get("NEO4J_QUERY_POLICY_ENABLED"), true),
+		QueryPolicyFile:        env.get("NEO4J_QUERY_POLICY_FILE"),
+		TransportMode:          env.getWithDefault("NEO4J_MCP_TRANSPORT", "stdio"),
+		HTTPPort:               env.get("NEO4J_MCP_HTTP_PORT"), // Default set after TLS determination
+		HTTPHost:               env.getWithDefault("NEO4J_MCP_HTTP_HOST", "127.0.0.1"),
+		HTTPAllowedOrigins:     env.get("NEO4J_MCP_HTTP_ALLOWED_ORIGINS"),
+		HTTPTLSEnabled:         ParseBool(env.get("NEO4J_MCP_HTTP_TLS_ENABLED"), false),
+		HTTPTLSCertFile:        env.get("NEO4J_MCP_HTTP_TLS_CERT_FILE"),
+		HTTPTLSKeyFile:         env.get("NEO4J_MCP_HTTP_TLS_KEY_FILE"),
+		HTTPAuthMode:           env.getWithDefault("NEO4J_MCP_HTTP_AUTH", HTTPAuthBasic),
+		HTTPAPIKeys:            env.get("NEO4J_MCP_HTTP_API_KEYS"),
+		OIDCIssuer:             env.get("NEO4J_MCP_OIDC_ISSUER"),
+		OIDCAudience:           env.get("NEO4J_MCP_OIDC_AUDIENCE"),
+		OIDCIdentityClaim:      env.getWithDefault("NEO4J_MCP_OIDC_IDENTITY_CLAIM", "sub"),
+		RBACEnabled:            ParseBool(env.get("NEO4J_MCP_RBAC_ENABLED"), false),
+		Roles:                  env.get("NEO4J_MCP_ROLES"),
+		DefaultRole:            env.get("NEO4J_MCP_DEFAULT_ROLE"),
+		TenantsFile:            env.get("NEO4J_MCP_TENANTS_FILE"),
+		Impersonation:          ParseBool(env.get("NEO4J_IMPERSONATION"), false),
+		FlagAllowedProperties:  env.getWithDefault("NEO4J_FLAG_ALLOWED_PROPERTIES", DefaultFlagAllowedProperties),
 	}
 
 	// Apply CLI overrides if provided
@@ -210,6 +651,16 @@ func LoadConfig(cliOverrides *CLIOverrides) (*Config, error) {
 	return cfg, nil
 }
 
+// defaultReferenceModelCacheDir returns the user cache directory for reference models,
+// or an empty string (no disk cache) when the platform has none
+func defaultReferenceModelCacheDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(cacheDir, "neo4j-mcp-fraud", "reference-models")
+}
+
 // GetEnv returns the value of an environment variable or empty string if not set
 func GetEnv(key string) string {
 	return os.Getenv(key)
@@ -254,3 +705,14 @@ func ParseInt32(value string, defaultValue int32) int32 {
 	}
 	return int32(parsed)
 }
+
+// ParseList splits a comma-separated list, trimming spaces and dropping empty entries
+func ParseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
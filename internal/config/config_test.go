@@ -1,6 +1,8 @@
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -93,6 +95,27 @@ func TestConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "Neo4j username and password should not be set for HTTP transport mode; credentials are provided per-request via Basic Auth headers",
 		},
+		{
+			name: "valid profile",
+			cfg: &Config{
+				URI:      "bolt://localhost:7687",
+				Username: "neo4j",
+				Password: "password",
+				Profile:  ProfileInvestigator,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid profile",
+			cfg: &Config{
+				URI:      "bolt://localhost:7687",
+				Username: "neo4j",
+				Password: "password",
+				Profile:  "auditor",
+			},
+			wantErr: true,
+			errMsg:  "invalid profile 'auditor'",
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,6 +171,76 @@ func TestLoadConfig_ValidConfig(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_ConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+database:
+  uri: bolt://file-host:7687
+  username: file-user
+  password: file-pass
+  name: fraud
+transport:
+  mode: http
+  http:
+    auth: api-key
+    api_keys:
+      alice: key-a
+      ci-bot: key-b
+access:
+  rbac_enabled: true
+  roles:
+    alice: investigator
+    ci-bot: [analyst, admin]
+tools:
+  disabled: [write-cypher, gds]
+cache:
+  schema_ttl: 60
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("NEO4J_URI", "")
+	t.Setenv("NEO4J_USERNAME", "")
+	t.Setenv("NEO4J_PASSWORD", "")
+	t.Setenv("NEO4J_MCP_TRANSPORT", "")
+	t.Setenv("NEO4J_DATABASE", "env-db")
+
+	cfg, err := LoadConfig(&CLIOverrides{ConfigFile: path, Username: "cli-user"})
+	if err != nil {
+		t.Fatalf("LoadConfig() unexpected error: %v", err)
+	}
+
+	if cfg.URI != "bolt://file-host:7687" || cfg.TransportMode != TransportModeHTTP || cfg.SchemaCacheTTL != 60 {
+		t.Errorf("LoadConfig() = %s, %s, %d, want the file's URI, transport and schema cache TTL", cfg.URI, cfg.TransportMode, cfg.SchemaCacheTTL)
+	}
+	if cfg.Database != "env-db" {
+		t.Errorf("LoadConfig() Database = %v, want the environment to override the file", cfg.Database)
+	}
+	if cfg.Username != "cli-user" {
+		t.Errorf("LoadConfig() Username = %v, want the CLI flag to override the file", cfg.Username)
+	}
+	if cfg.HTTPAPIKeys != "alice=key-a,ci-bot=key-b" {
+		t.Errorf("LoadConfig() HTTPAPIKeys = %v, want key=value pairs", cfg.HTTPAPIKeys)
+	}
+	if cfg.Roles != "alice=investigator,ci-bot=analyst|admin" {
+		t.Errorf("LoadConfig() Roles = %v, want identity=role pairs", cfg.Roles)
+	}
+	if cfg.DisabledTools != "write-cypher,gds" {
+		t.Errorf("LoadConfig() DisabledTools = %v, want a comma-separated list", cfg.DisabledTools)
+	}
+}
+
+func TestLoadConfig_ConfigFileUnknownKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("database:\n  url: bolt://localhost:7687\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadConfig(&CLIOverrides{ConfigFile: path}); err == nil || !strings.Contains(err.Error(), `unknown key "database.url"`) {
+		t.Errorf("LoadConfig() error = %v, want unknown key", err)
+	}
+}
+
 func TestLoadConfig_MissingRequiredEnvVars(t *testing.T) {
 	// Unit test: verify LoadConfig returns error when required env vars are missing
 	t.Setenv("NEO4J_MCP_TRANSPORT", "stdio")
@@ -343,6 +436,540 @@ func TestLoadConfig_ValidIntValue(t *testing.T) {
 			t.Errorf("LoadConfig() SchemaSampleSize = %v, want 100", cfg.SchemaSampleSize)
 		}
 	})
+
+	t.Run("schema cache TTL default and env value", func(t *testing.T) {
+		t.Setenv("NEO4J_SCHEMA_CACHE_TTL", "")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.SchemaCacheTTL != DefaultSchemaCacheTTL {
+			t.Errorf("LoadConfig() SchemaCacheTTL = %v, want %v", cfg.SchemaCacheTTL, DefaultSchemaCacheTTL)
+		}
+
+		t.Setenv("NEO4J_SCHEMA_CACHE_TTL", "0")
+
+		cfg, err = LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.SchemaCacheTTL != 0 {
+			t.Errorf("LoadConfig() SchemaCacheTTL = %v, want 0", cfg.SchemaCacheTTL)
+		}
+	})
+
+	t.Run("result cache defaults and env values", func(t *testing.T) {
+		t.Setenv("NEO4J_RESULT_CACHE_TTL", "")
+		t.Setenv("NEO4J_RESULT_CACHE_SIZE", "")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.ResultCacheTTL != 0 || cfg.ResultCacheSize != DefaultResultCacheSize {
+			t.Errorf("LoadConfig() ResultCacheTTL = %v, ResultCacheSize = %v, want 0 and %v", cfg.ResultCacheTTL, cfg.ResultCacheSize, DefaultResultCacheSize)
+		}
+
+		t.Setenv("NEO4J_RESULT_CACHE_TTL", "30")
+		t.Setenv("NEO4J_RESULT_CACHE_SIZE", "50")
+
+		cfg, err = LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.ResultCacheTTL != 30 || cfg.ResultCacheSize != 50 {
+			t.Errorf("LoadConfig() ResultCacheTTL = %v, ResultCacheSize = %v, want 30 and 50", cfg.ResultCacheTTL, cfg.ResultCacheSize)
+		}
+	})
+
+	t.Run("reference model cache settings", func(t *testing.T) {
+		t.Setenv("NEO4J_REFERENCE_MODEL_CACHE_TTL", "")
+		t.Setenv("NEO4J_REFERENCE_MODEL_CACHE_DIR", "/tmp/reference-models")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.ReferenceModelCacheTTL != DefaultReferenceModelCacheTTL {
+			t.Errorf("LoadConfig() ReferenceModelCacheTTL = %v, want %v", cfg.ReferenceModelCacheTTL, DefaultReferenceModelCacheTTL)
+		}
+		if cfg.ReferenceModelCacheDir != "/tmp/reference-models" {
+			t.Errorf("LoadConfig() ReferenceModelCacheDir = %v, want /tmp/reference-models", cfg.ReferenceModelCacheDir)
+		}
+	})
+
+	t.Run("query limit defaults and env values", func(t *testing.T) {
+		t.Setenv("NEO4J_QUERY_TIMEOUT", "")
+		t.Setenv("NEO4J_QUERY_MAX_ROWS", "")
+		t.Setenv("NEO4J_RESPONSE_MAX_TOKENS", "")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.QueryTimeout != DefaultQueryTimeout || cfg.QueryMaxRows != DefaultQueryMaxRows {
+			t.Errorf("LoadConfig() QueryTimeout = %v, QueryMaxRows = %v, want %v and %v", cfg.QueryTimeout, cfg.QueryMaxRows, DefaultQueryTimeout, DefaultQueryMaxRows)
+		}
+		if cfg.ResponseMaxTokens != DefaultResponseMaxTokens {
+			t.Errorf("LoadConfig() ResponseMaxTokens = %v, want %v", cfg.ResponseMaxTokens, DefaultResponseMaxTokens)
+		}
+
+		t.Setenv("NEO4J_QUERY_TIMEOUT", "5")
+		t.Setenv("NEO4J_QUERY_MAX_ROWS", "0")
+
+		cfg, err = LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.QueryTimeout != 5 || cfg.QueryMaxRows != 0 {
+			t.Errorf("LoadConfig() QueryTimeout = %v, QueryMaxRows = %v, want 5 and 0", cfg.QueryTimeout, cfg.QueryMaxRows)
+		}
+	})
+
+	t.Run("shutdown timeout default and env value", func(t *testing.T) {
+		t.Setenv("NEO4J_SHUTDOWN_TIMEOUT", "")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.ShutdownTimeout != DefaultShutdownTimeout {
+			t.Errorf("LoadConfig() ShutdownTimeout = %v, want %v", cfg.ShutdownTimeout, DefaultShutdownTimeout)
+		}
+
+		t.Setenv("NEO4J_SHUTDOWN_TIMEOUT", "5")
+
+		cfg, err = LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.ShutdownTimeout != 5 {
+			t.Errorf("LoadConfig() ShutdownTimeout = %v, want 5", cfg.ShutdownTimeout)
+		}
+	})
+
+	t.Run("admin tools are disabled by default", func(t *testing.T) {
+		t.Setenv("NEO4J_ADMIN_TOOLS", "")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.AdminTools {
+			t.Error("LoadConfig() AdminTools = true, want false")
+		}
+
+		t.Setenv("NEO4J_ADMIN_TOOLS", "true")
+
+		cfg, err = LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if !cfg.AdminTools {
+			t.Error("LoadConfig() AdminTools = false, want true")
+		}
+	})
+
+	t.Run("tool allow and deny lists are read from the environment", func(t *testing.T) {
+		t.Setenv("NEO4J_ENABLED_TOOLS", "cypher,fraud")
+		t.Setenv("NEO4J_DISABLED_TOOLS", "write-cypher")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if cfg.EnabledTools != "cypher,fraud" || cfg.DisabledTools != "write-cypher" {
+			t.Errorf("LoadConfig() EnabledTools = %q, DisabledTools = %q", cfg.EnabledTools, cfg.DisabledTools)
+		}
+	})
+}
+
+func TestConfig_Validate_TransportModes(t *testing.T) {
+	tests := []struct {
+		name          string
+		transportMode string
+		want          string
+	}{
+		{name: "streamable HTTP", transportMode: "http", want: TransportModeHTTP},
+		{name: "streamable-http alias", transportMode: "streamable-http", want: TransportModeHTTP},
+		{name: "SSE", transportMode: "sse", want: TransportModeSSE},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				URI:           "bolt://localhost:7687",
+				TransportMode: tt.transportMode,
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Fatalf("Validate() unexpected error = %v", err)
+			}
+			if cfg.TransportMode != tt.want {
+				t.Errorf("Validate() TransportMode = %q, want %q", cfg.TransportMode, tt.want)
+			}
+			if !IsHTTPTransport(cfg.TransportMode) {
+				t.Errorf("IsHTTPTransport(%q) = false, want true", cfg.TransportMode)
+			}
+		})
+	}
+
+	t.Run("credentials are rejected for SSE", func(t *testing.T) {
+		cfg := &Config{
+			URI:           "bolt://localhost:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			TransportMode: TransportModeSSE,
+		}
+		if err := cfg.Validate(); err == nil {
+			t.Error("Validate() expected error for credentials in SSE mode")
+		}
+	})
+}
+
+func TestConfig_Validate_HTTPAuth(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "basic auth by default",
+			cfg:  Config{TransportMode: TransportModeHTTP},
+		},
+		{
+			name: "api keys with service credentials",
+			cfg:  Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthAPIKey, HTTPAPIKeys: "alice=key-a", Username: "svc", Password: "secret"},
+		},
+		{
+			name:    "api keys without service credentials",
+			cfg:     Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthAPIKey, HTTPAPIKeys: "alice=key-a"},
+			wantErr: "Neo4j username is required for api-key HTTP auth mode",
+		},
+		{
+			name:    "api key mode without keys",
+			cfg:     Config{TransportMode: TransportModeSSE, HTTPAuthMode: HTTPAuthAPIKey, Username: "svc", Password: "secret"},
+			wantErr: "invalid NEO4J_MCP_HTTP_API_KEYS",
+		},
+		{
+			name:    "malformed api key",
+			cfg:     Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthAPIKey, HTTPAPIKeys: "alice", Username: "svc", Password: "secret"},
+			wantErr: "expected identity=key",
+		},
+		{
+			name:    "api key shared by two identities",
+			cfg:     Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthAPIKey, HTTPAPIKeys: "alice=key-a,bob=key-a", Username: "svc", Password: "secret"},
+			wantErr: "duplicate API key",
+		},
+		{
+			name: "oidc",
+			cfg:  Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthOIDC, OIDCIssuer: "https://issuer.example.com", OIDCAudience: "neo4j-mcp", Username: "svc", Password: "secret"},
+		},
+		{
+			name:    "oidc without issuer",
+			cfg:     Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthOIDC, OIDCAudience: "neo4j-mcp", Username: "svc", Password: "secret"},
+			wantErr: "NEO4J_MCP_OIDC_ISSUER",
+		},
+		{
+			name:    "oidc without audience",
+			cfg:     Config{TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthOIDC, OIDCIssuer: "https://issuer.example.com", Username: "svc", Password: "secret"},
+			wantErr: "NEO4J_MCP_OIDC_AUDIENCE",
+		},
+		{
+			name:    "invalid mode",
+			cfg:     Config{TransportMode: TransportModeHTTP, HTTPAuthMode: "token"},
+			wantErr: "invalid HTTP auth mode 'token'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			cfg.URI = "bolt://localhost:7687"
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error = %v", err)
+				}
+				if cfg.UsesServiceCredentials() != (cfg.HTTPAuthMode != HTTPAuthBasic) {
+					t.Errorf("UsesServiceCredentials() = %v for auth mode %q", cfg.UsesServiceCredentials(), cfg.HTTPAuthMode)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseRoleAssignments(t *testing.T) {
+	assignments, err := ParseRoleAssignments("alice=analyst, bob=investigator|admin")
+	if err != nil {
+		t.Fatalf("ParseRoleAssignments() unexpected error = %v", err)
+	}
+	if got := strings.Join(assignments["bob"], ","); got != "investigator,admin" {
+		t.Errorf("roles of bob = %q, want %q", got, "investigator,admin")
+	}
+
+	for _, pairs := range []string{"alice", "=analyst", "alice=auditor", "alice=analyst|"} {
+		if _, err := ParseRoleAssignments(pairs); err == nil {
+			t.Errorf("ParseRoleAssignments(%q) expected error", pairs)
+		}
+	}
+}
+
+func TestConfig_Validate_RBAC(t *testing.T) {
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, RBACEnabled: true, Roles: "alice=auditor"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_MCP_ROLES") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_MCP_ROLES", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, RBACEnabled: true, DefaultRole: "root"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid default role 'root'") {
+		t.Errorf("Validate() error = %v, want invalid default role", err)
+	}
+}
+
+func TestConfig_Validate_MetricsAddress(t *testing.T) {
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, MetricsAddress: "127.0.0.1:9090"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, MetricsAddress: "9090"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_METRICS_ADDRESS '9090'") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_METRICS_ADDRESS", err)
+	}
+}
+
+func TestConfig_Validate_OTLP(t *testing.T) {
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, OTLPEndpoint: "http://localhost:4318", OTLPHeaders: "Authorization=Bearer%20token"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, OTLPEndpoint: "localhost:4318"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid OTEL_EXPORTER_OTLP_ENDPOINT") {
+		t.Errorf("Validate() error = %v, want invalid OTEL_EXPORTER_OTLP_ENDPOINT", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, OTLPEndpoint: "http://localhost:4318", OTLPHeaders: "novalue"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid OTEL_EXPORTER_OTLP_HEADERS") {
+		t.Errorf("Validate() error = %v, want invalid OTEL_EXPORTER_OTLP_HEADERS", err)
+	}
+}
+
+func TestConfig_Validate_PIIMasking(t *testing.T) {
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP}
+	if err := cfg.Validate(); err != nil || cfg.PIIMaskMode != PIIMaskModeOff {
+		t.Errorf("Validate() error = %v, mode = %q, want masking off by default", err, cfg.PIIMaskMode)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, PIIMaskMode: PIIMaskModeHash, PIIUnmaskRoles: "investigator, admin"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, PIIMaskMode: "redact"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_PII_MASK_MODE 'redact'") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_PII_MASK_MODE", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, PIIMaskMode: PIIMaskModeFull, PIIUnmaskRoles: "auditor"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid role 'auditor' in NEO4J_PII_UNMASK_ROLES") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_PII_UNMASK_ROLES", err)
+	}
+}
+
+func TestConfig_Validate_QueryPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"disabledRules": ["load-json"]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, QueryPolicyEnabled: true}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, QueryPolicyEnabled: true, QueryPolicyFile: path}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_QUERY_POLICY_FILE") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_QUERY_POLICY_FILE", err)
+	}
+}
+
+func TestConfig_Validate_DriverSettings(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{"negative pool size", Config{MaxConnectionPoolSize: -1}, "invalid NEO4J_MAX_CONNECTION_POOL_SIZE"},
+		{"negative acquisition timeout", Config{AcquisitionTimeout: -5}, "invalid NEO4J_CONNECTION_ACQUISITION_TIMEOUT"},
+		{"negative retry time", Config{MaxTxRetryTime: -1}, "invalid NEO4J_MAX_TRANSACTION_RETRY_TIME"},
+		{"fetch size below -1", Config{FetchSize: -2}, "invalid NEO4J_FETCH_SIZE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.URI = "bolt://localhost:7687"
+			tt.cfg.TransportMode = TransportModeHTTP
+			if err := tt.cfg.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, MaxConnectionPoolSize: 400, FetchSize: -1}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+}
+
+func TestConfig_Validate_ReadRouting(t *testing.T) {
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error = %v", err)
+	}
+	if cfg.ReadRouting != ReadRoutingReplicas || cfg.ReadConsistency != ReadConsistencyCausal {
+		t.Errorf("defaults = %s, %s, want %s, %s", cfg.ReadRouting, cfg.ReadConsistency, ReadRoutingReplicas, ReadConsistencyCausal)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, ReadRouting: "followers"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_READ_ROUTING") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_READ_ROUTING", err)
+	}
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, ReadConsistency: "strong"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_READ_CONSISTENCY") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_READ_CONSISTENCY", err)
+	}
+}
+
+func TestConfig_Validate_Tenants(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	if err := os.WriteFile(path, []byte(`{"tenants": {"retail": {"database": "retail"}}, "identities": {"alice": "cards"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, TenantsFile: path}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid NEO4J_MCP_TENANTS_FILE") {
+		t.Errorf("Validate() error = %v, want invalid NEO4J_MCP_TENANTS_FILE", err)
+	}
+
+	valid := filepath.Join(t.TempDir(), "tenants.json")
+	if err := os.WriteFile(valid, []byte(`{"tenants": {"retail": {"database": "retail", "username": "retail_reader", "password": "secret"}}, "identities": {"alice": "retail"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// With Basic Auth, any password would select alice's tenant credentials
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthBasic, TenantsFile: valid}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "NEO4J_MCP_TENANTS_FILE requires") {
+		t.Errorf("Validate() error = %v, want tenants rejected with Basic Auth", err)
+	}
+
+	cfg = &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthAPIKey, HTTPAPIKeys: "alice=key-a", Username: "svc", Password: "secret", TenantsFile: valid}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() error = %v, want tenants accepted with API keys", err)
+	}
+}
+
+func TestConfig_Validate_DriverAuth(t *testing.T) {
+	certPath, keyPath := testutil.GenerateTestTLSCertificate(t)
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "bearer token file without username and password",
+			cfg:  Config{URI: "neo4j://localhost:7687", AuthScheme: AuthSchemeBearer, BearerTokenFile: "/var/run/secrets/neo4j/token"},
+		},
+		{
+			name: "bearer token from client credentials",
+			cfg:  Config{URI: "neo4j://localhost:7687", AuthScheme: AuthSchemeBearer, OAuthTokenURL: "https://login.example.com/token", OAuthClientID: "mcp", OAuthClientSecret: "secret"},
+		},
+		{
+			name:    "bearer without a token source",
+			cfg:     Config{URI: "neo4j://localhost:7687", AuthScheme: AuthSchemeBearer},
+			wantErr: "requires either NEO4J_BEARER_TOKEN_FILE or NEO4J_OAUTH_TOKEN_URL",
+		},
+		{
+			name:    "client credentials without a secret",
+			cfg:     Config{URI: "neo4j://localhost:7687", AuthScheme: AuthSchemeBearer, OAuthTokenURL: "https://login.example.com/token", OAuthClientID: "mcp"},
+			wantErr: "NEO4J_OAUTH_CLIENT_SECRET",
+		},
+		{
+			name: "kerberos ticket file",
+			cfg:  Config{URI: "neo4j://localhost:7687", AuthScheme: AuthSchemeKerberos, KerberosTicketFile: "/var/run/krb5/ticket"},
+		},
+		{
+			name:    "kerberos without a ticket file",
+			cfg:     Config{URI: "neo4j://localhost:7687", AuthScheme: AuthSchemeKerberos},
+			wantErr: "NEO4J_KERBEROS_TICKET_FILE",
+		},
+		{
+			name:    "token scheme with per-request Basic Auth",
+			cfg:     Config{URI: "neo4j://localhost:7687", TransportMode: TransportModeHTTP, AuthScheme: AuthSchemeBearer, BearerTokenFile: "/var/run/secrets/neo4j/token"},
+			wantErr: "NEO4J_AUTH_SCHEME bearer requires",
+		},
+		{
+			name:    "invalid scheme",
+			cfg:     Config{URI: "neo4j://localhost:7687", AuthScheme: "ntlm"},
+			wantErr: "invalid NEO4J_AUTH_SCHEME 'ntlm'",
+		},
+		{
+			name: "client certificate on an encrypted URI",
+			cfg:  Config{URI: "neo4j+s://graph.example.com", Username: "svc", Password: "secret", TLSClientCertFile: certPath, TLSClientKeyFile: keyPath, TLSCAFile: certPath},
+		},
+		{
+			name:    "client certificate on a plain URI",
+			cfg:     Config{URI: "neo4j://localhost:7687", Username: "svc", Password: "secret", TLSClientCertFile: certPath, TLSClientKeyFile: keyPath},
+			wantErr: "require an encrypted Neo4j URI scheme",
+		},
+		{
+			name:    "client certificate without key",
+			cfg:     Config{URI: "neo4j+s://graph.example.com", Username: "svc", Password: "secret", TLSClientCertFile: certPath},
+			wantErr: "must be set together",
+		},
+		{
+			name:    "CA file without certificates",
+			cfg:     Config{URI: "neo4j+s://graph.example.com", Username: "svc", Password: "secret", TLSCAFile: keyPath},
+			wantErr: "invalid NEO4J_TLS_CA_FILE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_Impersonation(t *testing.T) {
+	cfg := &Config{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, HTTPAuthMode: HTTPAuthAPIKey, HTTPAPIKeys: "alice=key-a", Username: "svc", Password: "secret", Impersonation: true}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error = %v", err)
+	}
+
+	for _, cfg := range []*Config{
+		{URI: "bolt://localhost:7687", TransportMode: TransportModeHTTP, Impersonation: true},
+		{URI: "bolt://localhost:7687", TransportMode: TransportModeStdio, Username: "svc", Password: "secret", Impersonation: true},
+	} {
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "NEO4J_IMPERSONATION requires") {
+			t.Errorf("Validate() with %s transport and %s auth error = %v, want NEO4J_IMPERSONATION requires", cfg.TransportMode, cfg.HTTPAuthMode, err)
+		}
+	}
 }
 
 func TestConfig_Validate_TLS(t *testing.T) {
@@ -729,3 +1356,36 @@ func TestLoadConfig_HTTPAllowedOrigins(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadConfig_FlagAllowedProperties(t *testing.T) {
+	t.Run("FlagAllowedProperties uses default when not set", func(t *testing.T) {
+		t.Setenv("NEO4J_URI", "bolt://localhost:7687")
+		t.Setenv("NEO4J_USERNAME", "neo4j")
+		t.Setenv("NEO4J_PASSWORD", "password")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+
+		if cfg.FlagAllowedProperties != DefaultFlagAllowedProperties {
+			t.Errorf("LoadConfig() FlagAllowedProperties = %v, want %v", cfg.FlagAllowedProperties, DefaultFlagAllowedProperties)
+		}
+	})
+
+	t.Run("FlagAllowedProperties from environment variable", func(t *testing.T) {
+		t.Setenv("NEO4J_URI", "bolt://localhost:7687")
+		t.Setenv("NEO4J_USERNAME", "neo4j")
+		t.Setenv("NEO4J_PASSWORD", "password")
+		t.Setenv("NEO4J_FLAG_ALLOWED_PROPERTIES", "underReview,watchlist")
+
+		cfg, err := LoadConfig(nil)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+
+		if cfg.FlagAllowedProperties != "underReview,watchlist" {
+			t.Errorf("LoadConfig() FlagAllowedProperties = %v, want 'underReview,watchlist'", cfg.FlagAllowedProperties)
+		}
+	})
+}
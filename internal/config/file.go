@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileKeys maps the keys of a YAML configuration file, as dotted paths of their sections, to the environment
+// variable each one stands in for. A value set in the environment overrides the file.
+var fileKeys = map[string]string{
+	"database.uri":                            "NEO4J_URI",
+	"database.username":                       "NEO4J_USERNAME",
+	"database.password":                       "NEO4J_PASSWORD",
+	"database.name":                           "NEO4J_DATABASE",
+	"database.auth_scheme":                    "NEO4J_AUTH_SCHEME",
+	"database.bearer_token_file":              "NEO4J_BEARER_TOKEN_FILE",
+	"database.oauth.token_url":                "NEO4J_OAUTH_TOKEN_URL",
+	"database.oauth.client_id":                "NEO4J_OAUTH_CLIENT_ID",
+	"database.oauth.client_secret":            "NEO4J_OAUTH_CLIENT_SECRET",
+	"database.oauth.scope":                    "NEO4J_OAUTH_SCOPE",
+	"database.kerberos_ticket_file":           "NEO4J_KERBEROS_TICKET_FILE",
+	"database.tls.client_cert_file":           "NEO4J_TLS_CLIENT_CERT_FILE",
+	"database.tls.client_key_file":            "NEO4J_TLS_CLIENT_KEY_FILE",
+	"database.tls.ca_file":                    "NEO4J_TLS_CA_FILE",
+	"database.impersonation":                  "NEO4J_IMPERSONATION",
+	"database.read_routing":                   "NEO4J_READ_ROUTING",
+	"database.read_consistency":               "NEO4J_READ_CONSISTENCY",
+	"database.max_connection_pool_size":       "NEO4J_MAX_CONNECTION_POOL_SIZE",
+	"database.connection_acquisition_timeout": "NEO4J_CONNECTION_ACQUISITION_TIMEOUT",
+	"database.max_transaction_retry_time":     "NEO4J_MAX_TRANSACTION_RETRY_TIME",
+	"database.fetch_size":                     "NEO4J_FETCH_SIZE",
+	"database.query_retries":                  "NEO4J_QUERY_RETRIES",
+	"database.circuit_breaker.threshold":      "NEO4J_CIRCUIT_BREAKER_THRESHOLD",
+	"database.circuit_breaker.cooldown":       "NEO4J_CIRCUIT_BREAKER_COOLDOWN",
+
+	"transport.mode":                     "NEO4J_MCP_TRANSPORT",
+	"transport.shutdown_timeout":         "NEO4J_SHUTDOWN_TIMEOUT",
+	"transport.http.host":                "NEO4J_MCP_HTTP_HOST",
+	"transport.http.port":                "NEO4J_MCP_HTTP_PORT",
+	"transport.http.allowed_origins":     "NEO4J_MCP_HTTP_ALLOWED_ORIGINS",
+	"transport.http.tls.enabled":         "NEO4J_MCP_HTTP_TLS_ENABLED",
+	"transport.http.tls.cert_file":       "NEO4J_MCP_HTTP_TLS_CERT_FILE",
+	"transport.http.tls.key_file":        "NEO4J_MCP_HTTP_TLS_KEY_FILE",
+	"transport.http.auth":                "NEO4J_MCP_HTTP_AUTH",
+	"transport.http.api_keys":            "NEO4J_MCP_HTTP_API_KEYS",
+	"transport.http.oidc.issuer":         "NEO4J_MCP_OIDC_ISSUER",
+	"transport.http.oidc.audience":       "NEO4J_MCP_OIDC_AUDIENCE",
+	"transport.http.oidc.identity_claim": "NEO4J_MCP_OIDC_IDENTITY_CLAIM",
+
+	"access.rbac_enabled":              "NEO4J_MCP_RBAC_ENABLED",
+	"access.roles":                     "NEO4J_MCP_ROLES",
+	"access.default_role":              "NEO4J_MCP_DEFAULT_ROLE",
+	"access.tenants_file":              "NEO4J_MCP_TENANTS_FILE",
+	"access.max_concurrent_tool_calls": "NEO4J_MAX_CONCURRENT_TOOL_CALLS",
+	"access.tool_calls_per_minute":     "NEO4J_TOOL_CALLS_PER_MINUTE",
+
+	"tools.read_only":               "NEO4J_READ_ONLY",
+	"tools.admin":                   "NEO4J_ADMIN_TOOLS",
+	"tools.enabled":                 "NEO4J_ENABLED_TOOLS",
+	"tools.disabled":                "NEO4J_DISABLED_TOOLS",
+	"tools.profile":                 "NEO4J_PROFILE",
+	"tools.flag_allowed_properties": "NEO4J_FLAG_ALLOWED_PROPERTIES",
+
+	"query.timeout":             "NEO4J_QUERY_TIMEOUT",
+	"query.max_rows":            "NEO4J_QUERY_MAX_ROWS",
+	"query.response_max_tokens": "NEO4J_RESPONSE_MAX_TOKENS",
+	"query.policy.enabled":      "NEO4J_QUERY_POLICY_ENABLED",
+	"query.policy.file":         "NEO4J_QUERY_POLICY_FILE",
+
+	"redaction.pii_mask_mode":       "NEO4J_PII_MASK_MODE",
+	"redaction.pii_mask_fields":     "NEO4J_PII_MASK_FIELDS",
+	"redaction.pii_hash_key":        "NEO4J_PII_HASH_KEY",
+	"redaction.pii_unmask_roles":    "NEO4J_PII_UNMASK_ROLES",
+	"redaction.audit_redact_fields": "NEO4J_AUDIT_REDACT_FIELDS",
+
+	"cache.schema_sample_size":    "NEO4J_SCHEMA_SAMPLE_SIZE",
+	"cache.schema_ttl":            "NEO4J_SCHEMA_CACHE_TTL",
+	"cache.result_ttl":            "NEO4J_RESULT_CACHE_TTL",
+	"cache.result_size":           "NEO4J_RESULT_CACHE_SIZE",
+	"cache.reference_model_dir":   "NEO4J_REFERENCE_MODEL_CACHE_DIR",
+	"cache.reference_model_ttl":   "NEO4J_REFERENCE_MODEL_CACHE_TTL",
+	"cache.reference_models":      "NEO4J_REFERENCE_MODELS",
+	"cache.reference_model_hosts": "NEO4J_REFERENCE_MODEL_HOSTS",
+	"cache.mapping_store_file":    "NEO4J_MAPPING_STORE_FILE",
+
+	"analytics.telemetry":         "NEO4J_TELEMETRY",
+	"analytics.metrics_address":   "NEO4J_METRICS_ADDRESS",
+	"analytics.audit_log":         "NEO4J_AUDIT_LOG",
+	"analytics.otlp.endpoint":     "OTEL_EXPORTER_OTLP_ENDPOINT",
+	"analytics.otlp.headers":      "OTEL_EXPORTER_OTLP_HEADERS",
+	"analytics.otlp.service_name": "OTEL_SERVICE_NAME",
+
+	"logging.level":  "NEO4J_LOG_LEVEL",
+	"logging.format": "NEO4J_LOG_FORMAT",
+}
+
+// fileValues holds the values of a configuration file, keyed by the environment variable they stand in for
+type fileValues map[string]string
+
+// get returns the value of an environment variable, or else the value the configuration file sets for it
+func (f fileValues) get(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return f[key]
+}
+
+// getWithDefault returns the value of an environment variable or the configuration file, or a default value
+func (f fileValues) getWithDefault(key, defaultValue string) string {
+	if value := f.get(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// loadFile reads a YAML configuration file into the values of the environment variables its keys stand in for.
+// Lists are joined with commas, and maps, such as API keys or roles, become comma-separated key=value pairs.
+func loadFile(path string) (fileValues, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var root map[string]any
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(fileValues)
+	if err := flattenSection("", root, values); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// flattenSection adds the values of a section, and of the sections nested in it, to values
+func flattenSection(prefix string, section map[string]any, values fileValues) error {
+	for _, key := range slices.Sorted(maps.Keys(section)) {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		name, known := fileKeys[path]
+		if !known {
+			nested, ok := section[key].(map[string]any)
+			if !ok {
+				return fmt.Errorf("unknown key %q", path)
+			}
+			if err := flattenSection(path, nested, values); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, err := fileValue(section[key])
+		if err != nil {
+			return fmt.Errorf("invalid value of %q: %w", path, err)
+		}
+		if value != "" {
+			values[name] = value
+		}
+	}
+	return nil
+}
+
+// fileValue formats a value of the configuration file the way its environment variable is written
+func fileValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			formatted, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatted)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		pairs := make([]string, 0, len(v))
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			var formatted string
+			// Several values of one key, such as the roles of an identity, are separated by "|"
+			if items, ok := v[key].([]any); ok {
+				parts := make([]string, 0, len(items))
+				for _, item := range items {
+					parts = append(parts, fmt.Sprint(item))
+				}
+				formatted = strings.Join(parts, "|")
+			} else if _, ok := v[key].(map[string]any); ok {
+				return "", fmt.Errorf("nested map under %q", key)
+			} else {
+				formatted = fmt.Sprint(v[key])
+			}
+			pairs = append(pairs, key+"="+formatted)
+		}
+		return strings.Join(pairs, ","), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
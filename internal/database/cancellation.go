@@ -0,0 +1,256 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// queryTagMetadataKey is the transaction metadata key holding the tag of a single query,
+// so the transaction running it can be found and terminated when the query is cancelled
+const queryTagMetadataKey = "mcpQueryTag"
+
+// terminateTimeout bounds the queries that terminate the transaction of a cancelled query
+const terminateTimeout = 5 * time.Second
+
+// findQueryToTerminate finds the transaction running a query by transaction ID or query ID.
+// $app and $identity restrict it to the transactions of the MCP server and of one caller; null matches any.
+const findQueryToTerminate = `
+		SHOW TRANSACTIONS YIELD transactionId, currentQueryId, currentQuery, metaData
+		WHERE (transactionId = $id OR currentQueryId = $id)
+			AND ($app IS NULL OR metaData.app = $app)
+			AND ($identity IS NULL OR metaData.` + identityMetadataKey + ` = $identity)
+		RETURN transactionId, currentQueryId, currentQuery`
+
+// ErrQueryNotFound is returned for IDs that do not match a running query the caller may terminate
+var ErrQueryNotFound = errors.New("no running query matches this ID; it may have already finished")
+
+// CancelledQuery describes a query terminated by CancelQuery or KillQuery
+type CancelledQuery struct {
+	TransactionID string `json:"transactionId"`
+	QueryID       string `json:"queryId,omitempty"`
+	Query         string `json:"query,omitempty"`
+	Message       string `json:"message"`
+}
+
+// RunningQuery is a query running on the server, as listed by SHOW TRANSACTIONS
+type RunningQuery struct {
+	TransactionID string `json:"transactionId"`
+	QueryID       string `json:"queryId,omitempty"`
+	Query         string `json:"query"`
+	Database      string `json:"database"`
+	Username      string `json:"username,omitempty"`
+	Status        string `json:"status"`
+	ElapsedTimeMs int64  `json:"elapsedTimeMs"`
+	ClientAddress string `json:"clientAddress,omitempty"`
+	FromMCP       bool   `json:"fromMcp"` // Issued through the MCP server
+}
+
+// queryTagKey is the context key of the tag added to the transaction metadata of a query
+type queryTagKey struct{}
+
+// executeQuery runs a query with the service options and waits for all its records, recording it in the query statistics.
+// When ctx is cancelled or times out before the query completes, its server-side transaction is terminated
+// too, since the driver only stops waiting for the result. Transient failures are retried, see runResilient.
+func (s *Neo4jService) executeQuery(ctx context.Context, cypher string, params map[string]any, baseOptions ...neo4j.ExecuteQueryConfigurationOption) (*neo4j.EagerResult, error) {
+	return runQuery(ctx, s, cypher, params, neo4j.EagerResultTransformer, resultRows, baseOptions...)
+}
+
+// runQuery runs a query like executeQuery, passing its records to the transformers created by newTransformer.
+// rows reports the number of records of the transformed result for the query statistics.
+func runQuery[T any](ctx context.Context, s *Neo4jService, cypher string, params map[string]any, newTransformer func() neo4j.ResultTransformer[T], rows func(T) int, baseOptions ...neo4j.ExecuteQueryConfigurationOption) (T, error) {
+	var res T
+	tag, err := newRandomID()
+	if err != nil {
+		return res, err
+	}
+	ctx = context.WithValue(ctx, queryTagKey{}, tag)
+
+	stop := context.AfterFunc(ctx, func() {
+		s.terminateTaggedQuery(ctx, tag)
+	})
+	defer stop()
+
+	queryOptions := s.buildQueryOptions(ctx, baseOptions...)
+	s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	started := time.Now()
+	err = s.runResilient(ctx, func() error {
+		var err error
+		res, err = neo4j.ExecuteQuery(ctx, s.driver, cypher, params, newTransformer, queryOptions...)
+		return err
+	})
+
+	s.recordQuery(ctx, cypher, started, rows(res), err)
+	return res, err
+}
+
+// resultRows returns the number of records of a result, or 0 without a result
+func resultRows(res *neo4j.EagerResult) int {
+	if res == nil {
+		return 0
+	}
+	return len(res.Records)
+}
+
+// terminateTaggedQuery terminates the transactions whose metadata carries tag
+func (s *Neo4jService) terminateTaggedQuery(ctx context.Context, tag string) {
+	// Keep the caller's credentials but neither its cancellation nor its tag
+	ctx, cancel := context.WithTimeout(context.WithValue(context.WithoutCancel(ctx), queryTagKey{}, ""), terminateTimeout)
+	defer cancel()
+
+	res, err := neo4j.ExecuteQuery(ctx, s.driver,
+		"SHOW TRANSACTIONS YIELD transactionId, metaData WHERE metaData[$key] = $tag RETURN collect(transactionId) AS ids",
+		map[string]any{"key": queryTagMetadataKey, "tag": tag},
+		neo4j.EagerResultTransformer, s.buildQueryOptions(ctx, neo4j.ExecuteQueryWithWritersRouting())...)
+	if err != nil {
+		slog.Warn("failed to find the transaction of a cancelled query", "error", err)
+		return
+	}
+	ids := collectedIDs(res.Records)
+	if len(ids) == 0 {
+		return
+	}
+
+	if _, err := s.terminateTransactions(ctx, ids); err != nil {
+		slog.Warn("failed to terminate the transaction of a cancelled query", "transactionIds", ids, "error", err)
+		return
+	}
+	slog.Info("terminated the transaction of a cancelled query", "transactionIds", ids)
+}
+
+// CancelQuery terminates a running query issued by the MCP server.
+// id is the transaction ID (e.g. "neo4j-transaction-42") or query ID (e.g. "query-17") shown by SHOW TRANSACTIONS.
+// Queries from other applications are not matched, nor, for an authenticated caller, queries of other callers.
+func (s *Neo4jService) CancelQuery(ctx context.Context, id string) (*CancelledQuery, error) {
+	return s.terminateQuery(ctx, id, true)
+}
+
+// KillQuery terminates any running query by transaction ID or query ID, whichever application issued it
+func (s *Neo4jService) KillQuery(ctx context.Context, id string) (*CancelledQuery, error) {
+	return s.terminateQuery(ctx, id, false)
+}
+
+// terminateQuery finds the transaction running the query with id and terminates it.
+// With mcpOnly set, only transactions carrying the MCP server metadata and the identity of the caller are matched.
+func (s *Neo4jService) terminateQuery(ctx context.Context, id string, mcpOnly bool) (*CancelledQuery, error) {
+	res, err := neo4j.ExecuteQuery(ctx, s.driver, findQueryToTerminate, s.terminateParams(ctx, id, mcpOnly),
+		neo4j.EagerResultTransformer, s.buildQueryOptions(ctx, neo4j.ExecuteQueryWithWritersRouting())...)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to find query to terminate: %w", err)
+		slog.Error("Error in terminateQuery", "error", wrappedErr)
+		return nil, wrappedErr
+	}
+	if len(res.Records) == 0 {
+		return nil, ErrQueryNotFound
+	}
+
+	record := res.Records[0].AsMap()
+	cancelled := &CancelledQuery{}
+	cancelled.TransactionID, _ = record["transactionId"].(string)
+	cancelled.QueryID, _ = record["currentQueryId"].(string)
+	cancelled.Query, _ = record["currentQuery"].(string)
+
+	messages, err := s.terminateTransactions(ctx, []string{cancelled.TransactionID})
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to terminate query: %w", err)
+		slog.Error("Error in terminateQuery", "error", wrappedErr)
+		return nil, wrappedErr
+	}
+	cancelled.Message = messages[cancelled.TransactionID]
+	slog.Info("terminated running query", "transactionId", cancelled.TransactionID, "mcpOnly", mcpOnly)
+	return cancelled, nil
+}
+
+// ListRunningQueries returns the queries running on the server, longest running first.
+// With mcpOnly set, only queries issued by the MCP server are listed.
+func (s *Neo4jService) ListRunningQueries(ctx context.Context, mcpOnly bool) ([]RunningQuery, error) {
+	res, err := neo4j.ExecuteQuery(ctx, s.driver, `
+		SHOW TRANSACTIONS YIELD transactionId, currentQueryId, currentQuery, database, username, status, elapsedTime, clientAddress, metaData
+		WHERE ($app IS NULL OR metaData.app = $app) AND NOT currentQuery STARTS WITH 'SHOW TRANSACTIONS'
+		RETURN transactionId, currentQueryId, currentQuery, database, username, status, elapsedTime.milliseconds AS elapsedTimeMs, clientAddress, metaData.app AS app
+		ORDER BY elapsedTimeMs DESC`,
+		map[string]any{"app": s.appFilter(ctx, mcpOnly)},
+		neo4j.EagerResultTransformer, s.buildQueryOptions(ctx, neo4j.ExecuteQueryWithWritersRouting())...)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to list running queries: %w", err)
+		slog.Error("Error in ListRunningQueries", "error", wrappedErr)
+		return nil, wrappedErr
+	}
+
+	app := s.txMetadata(ctx)["app"]
+	queries := make([]RunningQuery, 0, len(res.Records))
+	for _, record := range res.Records {
+		values := record.AsMap()
+		query := RunningQuery{}
+		query.TransactionID, _ = values["transactionId"].(string)
+		query.QueryID, _ = values["currentQueryId"].(string)
+		query.Query, _ = values["currentQuery"].(string)
+		query.Database, _ = values["database"].(string)
+		query.Username, _ = values["username"].(string)
+		query.Status, _ = values["status"].(string)
+		query.ElapsedTimeMs, _ = values["elapsedTimeMs"].(int64)
+		query.ClientAddress, _ = values["clientAddress"].(string)
+		query.FromMCP = values["app"] == app
+		queries = append(queries, query)
+	}
+	return queries, nil
+}
+
+// terminateParams returns the parameters of findQueryToTerminate. With mcpOnly set, the transaction must carry
+// the MCP server metadata and, when the caller is authenticated, its identity.
+func (s *Neo4jService) terminateParams(ctx context.Context, id string, mcpOnly bool) map[string]any {
+	params := map[string]any{"id": id, "app": s.appFilter(ctx, mcpOnly), "identity": nil}
+	if identity, ok := auth.GetIdentity(ctx); ok && mcpOnly {
+		params["identity"] = identity
+	}
+	return params
+}
+
+// appFilter returns the app metadata transactions must carry, or nil to match every transaction
+func (s *Neo4jService) appFilter(ctx context.Context, mcpOnly bool) any {
+	if !mcpOnly {
+		return nil
+	}
+	return s.txMetadata(ctx)["app"]
+}
+
+// terminateTransactions terminates transactions by ID and returns the server message for each
+func (s *Neo4jService) terminateTransactions(ctx context.Context, ids []string) (map[string]string, error) {
+	res, err := neo4j.ExecuteQuery(ctx, s.driver,
+		"TERMINATE TRANSACTIONS $ids YIELD transactionId, message RETURN transactionId, message",
+		map[string]any{"ids": ids},
+		neo4j.EagerResultTransformer, s.buildQueryOptions(ctx, neo4j.ExecuteQueryWithWritersRouting())...)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(map[string]string, len(res.Records))
+	for _, record := range res.Records {
+		values := record.AsMap()
+		id, _ := values["transactionId"].(string)
+		message, _ := values["message"].(string)
+		messages[id] = message
+	}
+	return messages, nil
+}
+
+// collectedIDs returns the strings of the ids column of a collect() result
+func collectedIDs(records []*neo4j.Record) []string {
+	if len(records) == 0 {
+		return nil
+	}
+	values, _ := records[0].AsMap()["ids"].([]any)
+	ids := make([]string, 0, len(values))
+	for _, value := range values {
+		if id, ok := value.(string); ok {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
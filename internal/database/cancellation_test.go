@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminateParams(t *testing.T) {
+	driver, err := neo4j.NewDriverWithContext("bolt://localhost:7687", neo4j.NoAuth())
+	require.NoError(t, err)
+	defer driver.Close(context.Background())
+
+	service, err := NewNeo4jService(driver, "neo4j", config.TransportModeHTTP, "test-version")
+	require.NoError(t, err)
+
+	assert.Contains(t, findQueryToTerminate, "metaData.mcpIdentity = $identity")
+
+	t.Run("cancel is restricted to the caller's own queries", func(t *testing.T) {
+		ctx := auth.WithIdentity(context.Background(), "bob")
+		params := service.terminateParams(ctx, "query-17", true)
+
+		assert.Equal(t, "bob", params["identity"])
+		assert.Equal(t, service.txMetadata(ctx)["app"], params["app"])
+	})
+
+	t.Run("cancel without an identity matches any MCP query", func(t *testing.T) {
+		params := service.terminateParams(context.Background(), "query-17", true)
+
+		assert.Nil(t, params["identity"])
+		assert.NotNil(t, params["app"])
+	})
+
+	t.Run("kill matches queries of every caller and application", func(t *testing.T) {
+		params := service.terminateParams(auth.WithIdentity(context.Background(), "bob"), "query-17", false)
+
+		assert.Nil(t, params["identity"])
+		assert.Nil(t, params["app"])
+	})
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+)
+
+// DriverConfig returns the driver option applying the connection pool, retry and fetch settings of cfg.
+// Settings left at 0 keep the driver defaults.
+func DriverConfig(cfg *config.Config) func(*neo4jconfig.Config) {
+	return func(c *neo4jconfig.Config) {
+		if cfg.MaxConnectionPoolSize > 0 {
+			c.MaxConnectionPoolSize = int(cfg.MaxConnectionPoolSize)
+		}
+		if cfg.AcquisitionTimeout > 0 {
+			c.ConnectionAcquisitionTimeout = time.Duration(cfg.AcquisitionTimeout) * time.Second
+		}
+		if cfg.MaxTxRetryTime > 0 {
+			c.MaxTransactionRetryTime = time.Duration(cfg.MaxTxRetryTime) * time.Second
+		}
+		// -1 is the driver's FetchAll
+		if cfg.FetchSize != 0 {
+			c.FetchSize = int(cfg.FetchSize)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package database
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jauth "github.com/neo4j/neo4j-go-driver/v5/neo4j/auth"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+)
+
+// tokenExpiryMargin is how long before it expires a bearer token is replaced, so no query starts with a token about to expire
+const tokenExpiryMargin = time.Minute
+
+// DriverAuth returns the credentials the driver connects with, for the auth scheme of cfg.
+// Bearer tokens are obtained again before they expire, and tokens and Kerberos tickets when Neo4j rejects them.
+// In HTTP mode with Basic Auth the driver has no credentials of its own: every query brings the caller's.
+func DriverAuth(cfg *config.Config) neo4jauth.TokenManager {
+	if !cfg.UsesServiceCredentials() {
+		return neo4j.AuthToken{}
+	}
+
+	switch cfg.AuthScheme {
+	case config.AuthSchemeBearer:
+		if cfg.OAuthTokenURL != "" {
+			source := &oauthTokenSource{
+				tokenURL:     cfg.OAuthTokenURL,
+				clientID:     cfg.OAuthClientID,
+				clientSecret: cfg.OAuthClientSecret,
+				scope:        cfg.OAuthScope,
+				client:       &http.Client{Timeout: 10 * time.Second},
+				now:          time.Now,
+			}
+			return neo4jauth.BearerTokenManager(source.token)
+		}
+		return neo4jauth.BearerTokenManager(func(context.Context) (neo4j.AuthToken, *time.Time, error) {
+			token, err := readSecretFile(cfg.BearerTokenFile)
+			if err != nil {
+				return neo4j.AuthToken{}, nil, fmt.Errorf("failed to read bearer token: %w", err)
+			}
+			return neo4j.BearerAuth(token), tokenExpiry(jwtExpiry(token), time.Now()), nil
+		})
+	case config.AuthSchemeKerberos:
+		return neo4jauth.BasicTokenManager(func(context.Context) (neo4j.AuthToken, error) {
+			ticket, err := readSecretFile(cfg.KerberosTicketFile)
+			if err != nil {
+				return neo4j.AuthToken{}, fmt.Errorf("failed to read Kerberos ticket: %w", err)
+			}
+			return neo4j.KerberosAuth(ticket), nil
+		})
+	default:
+		return neo4j.BasicAuth(cfg.Username, cfg.Password, "")
+	}
+}
+
+// DriverTLS returns the driver option presenting the client certificate of cfg for mutual TLS and trusting its CA file.
+// The option changes nothing when neither is set.
+func DriverTLS(cfg *config.Config) (func(*neo4jconfig.Config), error) {
+	if cfg.TLSClientCertFile == "" && cfg.TLSCAFile == "" {
+		return func(*neo4jconfig.Config) {}, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cfg.TLSClientCertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.TLSClientCertFile, cfg.TLSClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Neo4j client certificate and key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	if cfg.TLSCAFile != "" {
+		pool, err := config.LoadCertPool(cfg.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load Neo4j CA certificates: %w", err)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return func(c *neo4jconfig.Config) {
+		c.TlsConfig = tlsConfig
+	}, nil
+}
+
+// oauthTokenSource obtains bearer tokens from an OAuth token endpoint with the client credentials grant
+type oauthTokenSource struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	scope        string
+	client       *http.Client
+	now          func() time.Time
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// token requests a new access token, and returns it with the time it should be replaced
+func (o *oauthTokenSource) token(ctx context.Context) (neo4j.AuthToken, *time.Time, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if o.scope != "" {
+		form.Set("scope", o.scope)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return neo4j.AuthToken{}, nil, fmt.Errorf("failed to request bearer token: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	// Client credentials are form-encoded before Basic Auth encoding, see RFC 6749 section 2.3.1
+	req.SetBasicAuth(url.QueryEscape(o.clientID), url.QueryEscape(o.clientSecret))
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return neo4j.AuthToken{}, nil, fmt.Errorf("failed to request bearer token: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return neo4j.AuthToken{}, nil, fmt.Errorf("failed to request bearer token: token endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	var token oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return neo4j.AuthToken{}, nil, fmt.Errorf("failed to decode bearer token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return neo4j.AuthToken{}, nil, fmt.Errorf("failed to request bearer token: token endpoint returned no access_token")
+	}
+
+	now := o.now()
+	expiresAt := jwtExpiry(token.AccessToken)
+	if token.ExpiresIn > 0 {
+		at := now.Add(time.Duration(token.ExpiresIn) * time.Second)
+		expiresAt = &at
+	}
+	return neo4j.BearerAuth(token.AccessToken), tokenExpiry(expiresAt, now), nil
+}
+
+// tokenExpiry returns when a token expiring at expiresAt should be replaced: tokenExpiryMargin before it expires,
+// or halfway through its remaining lifetime when that is shorter. Nil keeps the token until Neo4j rejects it.
+func tokenExpiry(expiresAt *time.Time, now time.Time) *time.Time {
+	if expiresAt == nil {
+		return nil
+	}
+	lifetime := expiresAt.Sub(now)
+	replaceAt := expiresAt.Add(-min(tokenExpiryMargin, max(lifetime/2, 0)))
+	return &replaceAt
+}
+
+// jwtExpiry returns the exp claim of a JWT, or nil when token is not a JWT or has no exp claim.
+// The signature is not checked: the token is only passed on to Neo4j, which validates it.
+func jwtExpiry(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return nil
+	}
+	exp := time.Unix(int64(claims.Exp), 0)
+	return &exp
+}
+
+// readSecretFile returns the trimmed content of a token or ticket file, which is read again on every refresh
+// so a sidecar can rotate it
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	secret := strings.TrimSpace(string(data))
+	if secret == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return secret, nil
+}
@@ -0,0 +1,128 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/testutil"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+)
+
+func TestDriverAuth(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("basic auth with the server's credentials", func(t *testing.T) {
+		token, err := database.DriverAuth(&config.Config{TransportMode: config.TransportModeStdio, Username: "svc", Password: "secret"}).GetAuthToken(ctx)
+		if err != nil {
+			t.Fatalf("GetAuthToken() error = %v", err)
+		}
+		if token.Tokens["scheme"] != "basic" || token.Tokens["principal"] != "svc" {
+			t.Errorf("token = %v, want basic auth for svc", token.Tokens)
+		}
+	})
+
+	t.Run("no credentials with per-request Basic Auth", func(t *testing.T) {
+		token, err := database.DriverAuth(&config.Config{TransportMode: config.TransportModeHTTP, HTTPAuthMode: config.HTTPAuthBasic}).GetAuthToken(ctx)
+		if err != nil {
+			t.Fatalf("GetAuthToken() error = %v", err)
+		}
+		if len(token.Tokens) != 0 {
+			t.Errorf("token = %v, want no credentials", token.Tokens)
+		}
+	})
+
+	t.Run("bearer token read from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		if err := os.WriteFile(path, []byte("sso-token\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := database.DriverAuth(&config.Config{TransportMode: config.TransportModeStdio, AuthScheme: config.AuthSchemeBearer, BearerTokenFile: path}).GetAuthToken(ctx)
+		if err != nil {
+			t.Fatalf("GetAuthToken() error = %v", err)
+		}
+		if token.Tokens["scheme"] != "bearer" || token.Tokens["credentials"] != "sso-token" {
+			t.Errorf("token = %v, want bearer sso-token", token.Tokens)
+		}
+	})
+
+	t.Run("bearer token from client credentials is reused until it expires", func(t *testing.T) {
+		requests := 0
+		tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			clientID, clientSecret, _ := r.BasicAuth()
+			if r.FormValue("grant_type") != "client_credentials" || r.FormValue("scope") != "neo4j" || clientID != "mcp" || clientSecret != "secret" {
+				http.Error(w, "invalid_client", http.StatusUnauthorized)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "client-token", "expires_in": 3600})
+		}))
+		defer tokenServer.Close()
+
+		manager := database.DriverAuth(&config.Config{
+			TransportMode: config.TransportModeStdio, AuthScheme: config.AuthSchemeBearer, OAuthTokenURL: tokenServer.URL, OAuthClientID: "mcp", OAuthClientSecret: "secret", OAuthScope: "neo4j",
+		})
+		for range 2 {
+			token, err := manager.GetAuthToken(ctx)
+			if err != nil {
+				t.Fatalf("GetAuthToken() error = %v", err)
+			}
+			if token.Tokens["credentials"] != "client-token" {
+				t.Errorf("token = %v, want client-token", token.Tokens)
+			}
+		}
+		if requests != 1 {
+			t.Errorf("token endpoint requests = %d, want 1", requests)
+		}
+	})
+
+	t.Run("kerberos ticket read from a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "ticket")
+		if err := os.WriteFile(path, []byte("YIIB..."), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		token, err := database.DriverAuth(&config.Config{TransportMode: config.TransportModeStdio, AuthScheme: config.AuthSchemeKerberos, KerberosTicketFile: path}).GetAuthToken(ctx)
+		if err != nil {
+			t.Fatalf("GetAuthToken() error = %v", err)
+		}
+		if token.Tokens["scheme"] != "kerberos" || token.Tokens["credentials"] != "YIIB..." {
+			t.Errorf("token = %v, want kerberos ticket", token.Tokens)
+		}
+	})
+}
+
+func TestDriverTLS(t *testing.T) {
+	t.Run("no TLS settings keep the driver defaults", func(t *testing.T) {
+		var driverConfig neo4jconfig.Config
+		option, err := database.DriverTLS(&config.Config{})
+		if err != nil {
+			t.Fatalf("DriverTLS() error = %v", err)
+		}
+		option(&driverConfig)
+		if driverConfig.TlsConfig != nil {
+			t.Error("expected no TLS config")
+		}
+	})
+
+	t.Run("client certificate and CA are applied", func(t *testing.T) {
+		certPath, keyPath := testutil.GenerateTestTLSCertificate(t)
+
+		var driverConfig neo4jconfig.Config
+		option, err := database.DriverTLS(&config.Config{TLSClientCertFile: certPath, TLSClientKeyFile: keyPath, TLSCAFile: certPath})
+		if err != nil {
+			t.Fatalf("DriverTLS() error = %v", err)
+		}
+		option(&driverConfig)
+		if driverConfig.TlsConfig == nil || len(driverConfig.TlsConfig.Certificates) != 1 || driverConfig.TlsConfig.RootCAs == nil {
+			t.Errorf("TlsConfig = %+v, want the client certificate and CA pool", driverConfig.TlsConfig)
+		}
+	})
+}
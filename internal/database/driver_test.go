@@ -0,0 +1,42 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+)
+
+func TestDriverConfig(t *testing.T) {
+	t.Run("unset settings keep the driver defaults", func(t *testing.T) {
+		driverConfig := neo4jconfig.Config{MaxConnectionPoolSize: 100, ConnectionAcquisitionTimeout: time.Minute, MaxTransactionRetryTime: 30 * time.Second, FetchSize: neo4j.FetchDefault}
+		want := driverConfig
+
+		database.DriverConfig(&config.Config{})(&driverConfig)
+		if driverConfig.MaxConnectionPoolSize != want.MaxConnectionPoolSize || driverConfig.ConnectionAcquisitionTimeout != want.ConnectionAcquisitionTimeout ||
+			driverConfig.MaxTransactionRetryTime != want.MaxTransactionRetryTime || driverConfig.FetchSize != want.FetchSize {
+			t.Errorf("DriverConfig() changed the defaults: %+v", driverConfig)
+		}
+	})
+
+	t.Run("configured settings are applied", func(t *testing.T) {
+		var driverConfig neo4jconfig.Config
+		database.DriverConfig(&config.Config{MaxConnectionPoolSize: 400, AcquisitionTimeout: 10, MaxTxRetryTime: 5, FetchSize: -1})(&driverConfig)
+
+		if driverConfig.MaxConnectionPoolSize != 400 {
+			t.Errorf("MaxConnectionPoolSize = %d, want 400", driverConfig.MaxConnectionPoolSize)
+		}
+		if driverConfig.ConnectionAcquisitionTimeout != 10*time.Second {
+			t.Errorf("ConnectionAcquisitionTimeout = %s, want 10s", driverConfig.ConnectionAcquisitionTimeout)
+		}
+		if driverConfig.MaxTransactionRetryTime != 5*time.Second {
+			t.Errorf("MaxTransactionRetryTime = %s, want 5s", driverConfig.MaxTransactionRetryTime)
+		}
+		if driverConfig.FetchSize != neo4j.FetchAll {
+			t.Errorf("FetchSize = %d, want FetchAll", driverConfig.FetchSize)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"context"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+)
+
+// SetImpersonation makes the queries of an authenticated caller run as the Neo4j user named by their identity,
+// so Neo4j security rules and query logs apply to the analyst rather than to the server's account.
+// The server's account needs the IMPERSONATE privilege. Call it before the service is used.
+func (s *Neo4jService) SetImpersonation(enabled bool) {
+	s.impersonate = enabled
+}
+
+// impersonatedUser returns the Neo4j user the queries of ctx run as, or "" to run as the user of their credentials
+func (s *Neo4jService) impersonatedUser(ctx context.Context) string {
+	if !s.impersonate {
+		return ""
+	}
+	identity, _ := auth.GetIdentity(ctx)
+	return identity
+}
@@ -14,28 +14,75 @@ type QueryExecutor interface {
 	// Returns an error if the query is not read-only.
 	ExecuteReadQuery(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, error)
 
+	// StreamReadQuery executes a read-only Cypher query, passing the records to a sink one at a time
+	// instead of holding the whole result in memory. newSink is called for each attempt of the query.
+	StreamReadQuery(ctx context.Context, cypher string, params map[string]any, newSink func() RecordSink) error
+
 	// ExecuteWriteQuery executes a write-only Cypher query and returns raw records
 	ExecuteWriteQuery(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, error)
 
+	// ExecuteWriteQueryWithSummary executes a write Cypher query and returns raw records with the counters of what it changed
+	ExecuteWriteQueryWithSummary(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, *WriteSummary, error)
+
 	// GetQueryType prefixes the provided query with EXPLAIN and returns the query type (e.g. 'r' for read, 'w' for write, 'rw' etc.)
 	// This allows read-only tools to determine if a query is safe to run in read-only context.
 	GetQueryType(ctx context.Context, cypher string, params map[string]any) (neo4j.StatementType, error)
+
+	// ExplainQuery prefixes the query with EXPLAIN or PROFILE (mode "explain" or "profile") and returns the execution plan.
+	// PROFILE runs the query to collect db hits and rows per operator.
+	ExplainQuery(ctx context.Context, mode string, cypher string, params map[string]any) (*QueryPlan, error)
+}
+
+// TransactionManager runs several statements atomically in an explicit transaction kept open between calls
+type TransactionManager interface {
+	// BeginTransaction opens an explicit write transaction and returns its ID
+	BeginTransaction(ctx context.Context) (string, error)
+
+	// RunInTransaction runs a statement in an open transaction. A failed statement rolls the transaction back.
+	RunInTransaction(ctx context.Context, id string, cypher string, params map[string]any) ([]*neo4j.Record, *WriteSummary, error)
+
+	// CommitTransaction commits an open transaction
+	CommitTransaction(ctx context.Context, id string) error
+
+	// RollbackTransaction rolls back an open transaction
+	RollbackTransaction(ctx context.Context, id string) error
+}
+
+// QueryCanceller lists and stops queries that are already running on the server
+type QueryCanceller interface {
+	// CancelQuery terminates a running query issued by the MCP server for the caller, by transaction ID or query ID
+	CancelQuery(ctx context.Context, id string) (*CancelledQuery, error)
+
+	// KillQuery terminates any running query, by transaction ID or query ID
+	KillQuery(ctx context.Context, id string) (*CancelledQuery, error)
+
+	// ListRunningQueries returns the running queries, longest running first; mcpOnly limits them to the MCP server's queries
+	ListRunningQueries(ctx context.Context, mcpOnly bool) ([]RunningQuery, error)
 }
 
 // RecordFormatter defines the interface for formatting Neo4j records
 type RecordFormatter interface {
-	// Neo4jRecordsToJSON converts Neo4j records to JSON string
-	Neo4jRecordsToJSON(records []*neo4j.Record) (string, error)
+	// Neo4jRecordsToJSON converts Neo4j records to JSON string, masking personal data as configured in ctx
+	Neo4jRecordsToJSON(ctx context.Context, records []*neo4j.Record) (string, error)
+
+	// Neo4jRecordsToCSV converts Neo4j records to delimited text with a header row (CSV with ',', TSV with '\t')
+	Neo4jRecordsToCSV(ctx context.Context, records []*neo4j.Record, delimiter rune) (string, error)
 }
 
 type Helpers interface {
 	VerifyConnectivity(ctx context.Context) error
-	GetDatabaseName() string
+	GetDatabaseName(ctx context.Context) string
+
+	// ForDatabase returns a Service that runs queries against another database on the same driver.
+	// An empty name returns the service for the configured database.
+	ForDatabase(name string) Service
 }
 
 // Service combines query execution and record formatting
 type Service interface {
 	QueryExecutor
+	TransactionManager
+	QueryCanceller
 	RecordFormatter
 	Helpers
 }
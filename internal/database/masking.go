@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+)
+
+// MaskedValue replaces the values of masked properties in full masking mode
+const MaskedValue = "[MASKED]"
+
+// maskedVisibleChars is the number of trailing characters partial masking leaves visible
+const maskedVisibleChars = 4
+
+// DefaultMaskedFields are the property names masked when no fields are configured, matched case-insensitively
+// ignoring underscores, dashes and spaces. Names containing one of them, such as customerEmail, match too.
+var DefaultMaskedFields = []string{
+	"ssn", "socialsecuritynumber", "taxid", "nationalid", "passport", "driverslicense",
+	"accountnumber", "iban", "cardnumber", "email", "phone", "dateofbirth",
+}
+
+// Masking masks the values of personal data in formatted records.
+// It applies to the properties of nodes and relationships, to map keys and to record columns.
+type Masking struct {
+	mode    string
+	fields  []string
+	hashKey []byte
+}
+
+// NewMasking returns the masking of fields in mode, one of the config.PIIMaskMode* values.
+// Hashed values are keyed with hashKey, so they cannot be reversed by hashing candidate values.
+// It returns nil, which masks nothing, when mode is off.
+func NewMasking(mode string, fields []string, hashKey []byte) *Masking {
+	if mode == "" || mode == config.PIIMaskModeOff {
+		return nil
+	}
+	if len(fields) == 0 {
+		fields = DefaultMaskedFields
+	}
+	normalized := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field = normalizeFieldName(field); field != "" {
+			normalized = append(normalized, field)
+		}
+	}
+	return &Masking{mode: mode, fields: normalized, hashKey: hashKey}
+}
+
+// WithMode returns a copy of the masking in another mode, keeping the fields and hash key
+func (m *Masking) WithMode(mode string) *Masking {
+	if m == nil || mode == config.PIIMaskModeOff {
+		return nil
+	}
+	return &Masking{mode: mode, fields: m.fields, hashKey: m.hashKey}
+}
+
+// Mode returns the masking mode; a nil masking is off
+func (m *Masking) Mode() string {
+	if m == nil {
+		return config.PIIMaskModeOff
+	}
+	return m.mode
+}
+
+type maskingKey struct{}
+
+// WithMasking returns a context whose records are masked by Neo4jRecordsToJSON and Neo4jRecordsToCSV
+func WithMasking(ctx context.Context, masking *Masking) context.Context {
+	return context.WithValue(ctx, maskingKey{}, masking)
+}
+
+// maskingFromContext returns the masking of ctx, or nil
+func maskingFromContext(ctx context.Context) *Masking {
+	masking, _ := ctx.Value(maskingKey{}).(*Masking)
+	return masking
+}
+
+// masks reports whether the values of the named property or column are masked
+func (m *Masking) masks(name string) bool {
+	if m == nil {
+		return false
+	}
+	name = normalizeFieldName(name)
+	for _, field := range m.fields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// mask returns the masked form of a normalized value, masking every item of a list
+func (m *Masking) mask(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		masked := make([]any, len(v))
+		for i, item := range v {
+			masked[i] = m.mask(item)
+		}
+		return masked
+	case string, bool, int, int64, float64:
+		return m.maskText(fmt.Sprint(v))
+	}
+	return MaskedValue
+}
+
+func (m *Masking) maskText(text string) string {
+	switch m.mode {
+	case config.PIIMaskModePartial:
+		runes := []rune(text)
+		if len(runes) <= maskedVisibleChars {
+			return strings.Repeat("*", len(runes))
+		}
+		return strings.Repeat("*", len(runes)-maskedVisibleChars) + string(runes[len(runes)-maskedVisibleChars:])
+	case config.PIIMaskModeHash:
+		mac := hmac.New(sha256.New, m.hashKey)
+		mac.Write([]byte(text))
+		return "hash:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	default:
+		return MaskedValue
+	}
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.TrimSpace(name)))
+}
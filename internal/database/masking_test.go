@@ -0,0 +1,109 @@
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+func maskedCustomer(t *testing.T, masking *database.Masking) map[string]any {
+	t.Helper()
+	customer := dbtype.Node{Labels: []string{"Customer"}, Props: map[string]any{
+		"id":            "C-1001",
+		"ssn":           "123-45-6789",
+		"contact_email": "alice@example.com",
+		"phoneNumbers":  []any{"+44 7700 900123"},
+	}}
+	records := []*neo4j.Record{newTestRecord([]string{"c", "accountNumber"}, []any{customer, int64(12345678)})}
+
+	var s database.Neo4jService
+	got, err := s.Neo4jRecordsToJSON(database.WithMasking(context.Background(), masking), records)
+	if err != nil {
+		t.Fatalf("Neo4jRecordsToJSON() unexpected error = %v", err)
+	}
+	var rows []map[string]any
+	decoder := json.NewDecoder(strings.NewReader(got))
+	decoder.UseNumber()
+	if err := decoder.Decode(&rows); err != nil {
+		t.Fatalf("Neo4jRecordsToJSON() returned invalid JSON: %v", err)
+	}
+	return rows[0]
+}
+
+func TestNeo4jRecordsToJSON_Masking(t *testing.T) {
+	hashKey := []byte("test-key")
+
+	tests := []struct {
+		name        string
+		mode        string
+		wantSSN     string
+		wantEmail   string
+		wantAccount string
+		wantPhone   string
+	}{
+		{name: "full", mode: config.PIIMaskModeFull, wantSSN: database.MaskedValue, wantEmail: database.MaskedValue, wantAccount: database.MaskedValue, wantPhone: database.MaskedValue},
+		{name: "partial shows the last 4 characters", mode: config.PIIMaskModePartial, wantSSN: "*******6789", wantEmail: "*************.com", wantAccount: "****5678", wantPhone: "***********0123"},
+		{name: "off", mode: config.PIIMaskModeOff, wantSSN: "123-45-6789", wantEmail: "alice@example.com", wantAccount: "12345678", wantPhone: "+44 7700 900123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			row := maskedCustomer(t, database.NewMasking(tt.mode, nil, hashKey))
+			props := row["c"].(map[string]any)["Props"].(map[string]any)
+			if props["id"] != "C-1001" {
+				t.Errorf("expected unmasked id, got %v", props["id"])
+			}
+			if props["ssn"] != tt.wantSSN || props["contact_email"] != tt.wantEmail {
+				t.Errorf("unexpected masked properties %v", props)
+			}
+			if phones := props["phoneNumbers"].([]any); phones[0] != tt.wantPhone {
+				t.Errorf("expected list items to be masked, got %v", phones)
+			}
+			if account := fmt.Sprint(row["accountNumber"]); account != tt.wantAccount {
+				t.Errorf("expected the accountNumber column to be masked, got %v", account)
+			}
+		})
+	}
+}
+
+func TestNeo4jRecordsToJSON_HashMasking(t *testing.T) {
+	masking := database.NewMasking(config.PIIMaskModeHash, []string{"ssn"}, []byte("test-key"))
+	first, second := maskedCustomer(t, masking), maskedCustomer(t, masking)
+
+	ssn := first["c"].(map[string]any)["Props"].(map[string]any)["ssn"].(string)
+	if !strings.HasPrefix(ssn, "hash:") || strings.Contains(ssn, "6789") {
+		t.Errorf("expected a hashed ssn, got %q", ssn)
+	}
+	if ssn != second["c"].(map[string]any)["Props"].(map[string]any)["ssn"] {
+		t.Error("expected equal values to hash equally, so they can be correlated")
+	}
+	if email := first["c"].(map[string]any)["Props"].(map[string]any)["contact_email"]; email != "alice@example.com" {
+		t.Errorf("expected only the configured fields to be masked, got %v", email)
+	}
+
+	other := database.NewMasking(config.PIIMaskModeHash, []string{"ssn"}, []byte("other-key"))
+	if maskedCustomer(t, other)["c"].(map[string]any)["Props"].(map[string]any)["ssn"] == ssn {
+		t.Error("expected hashes to depend on the key")
+	}
+}
+
+func TestNeo4jRecordsToCSV_Masking(t *testing.T) {
+	records := []*neo4j.Record{newTestRecord([]string{"id", "customerEmail"}, []any{"C-1001", "alice@example.com"})}
+	ctx := database.WithMasking(context.Background(), database.NewMasking(config.PIIMaskModeFull, nil, nil))
+
+	var s database.Neo4jService
+	got, err := s.Neo4jRecordsToCSV(ctx, records, ',')
+	if err != nil {
+		t.Fatalf("Neo4jRecordsToCSV() unexpected error = %v", err)
+	}
+	if want := "id,customerEmail\nC-1001,[MASKED]\n"; got != want {
+		t.Errorf("Neo4jRecordsToCSV() = %q, want %q", got, want)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	database "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	neo4j "github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -56,6 +57,20 @@ func (mr *MockServiceMockRecorder) ExecuteReadQuery(ctx, cypher, params any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteReadQuery", reflect.TypeOf((*MockService)(nil).ExecuteReadQuery), ctx, cypher, params)
 }
 
+// StreamReadQuery mocks base method.
+func (m *MockService) StreamReadQuery(ctx context.Context, cypher string, params map[string]any, newSink func() database.RecordSink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamReadQuery", ctx, cypher, params, newSink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamReadQuery indicates an expected call of StreamReadQuery.
+func (mr *MockServiceMockRecorder) StreamReadQuery(ctx, cypher, params, newSink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamReadQuery", reflect.TypeOf((*MockService)(nil).StreamReadQuery), ctx, cypher, params, newSink)
+}
+
 // ExecuteWriteQuery mocks base method.
 func (m *MockService) ExecuteWriteQuery(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, error) {
 	m.ctrl.T.Helper()
@@ -71,6 +86,22 @@ func (mr *MockServiceMockRecorder) ExecuteWriteQuery(ctx, cypher, params any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteWriteQuery", reflect.TypeOf((*MockService)(nil).ExecuteWriteQuery), ctx, cypher, params)
 }
 
+// ExecuteWriteQueryWithSummary mocks base method.
+func (m *MockService) ExecuteWriteQueryWithSummary(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, *database.WriteSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteWriteQueryWithSummary", ctx, cypher, params)
+	ret0, _ := ret[0].([]*neo4j.Record)
+	ret1, _ := ret[1].(*database.WriteSummary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExecuteWriteQueryWithSummary indicates an expected call of ExecuteWriteQueryWithSummary.
+func (mr *MockServiceMockRecorder) ExecuteWriteQueryWithSummary(ctx, cypher, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteWriteQueryWithSummary", reflect.TypeOf((*MockService)(nil).ExecuteWriteQueryWithSummary), ctx, cypher, params)
+}
+
 // GetQueryType mocks base method.
 func (m *MockService) GetQueryType(ctx context.Context, cypher string, params map[string]any) (neo4j.StatementType, error) {
 	m.ctrl.T.Helper()
@@ -86,19 +117,153 @@ func (mr *MockServiceMockRecorder) GetQueryType(ctx, cypher, params any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQueryType", reflect.TypeOf((*MockService)(nil).GetQueryType), ctx, cypher, params)
 }
 
+// ExplainQuery mocks base method.
+func (m *MockService) ExplainQuery(ctx context.Context, mode, cypher string, params map[string]any) (*database.QueryPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExplainQuery", ctx, mode, cypher, params)
+	ret0, _ := ret[0].(*database.QueryPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExplainQuery indicates an expected call of ExplainQuery.
+func (mr *MockServiceMockRecorder) ExplainQuery(ctx, mode, cypher, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExplainQuery", reflect.TypeOf((*MockService)(nil).ExplainQuery), ctx, mode, cypher, params)
+}
+
+// BeginTransaction mocks base method.
+func (m *MockService) BeginTransaction(ctx context.Context) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTransaction", ctx)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTransaction indicates an expected call of BeginTransaction.
+func (mr *MockServiceMockRecorder) BeginTransaction(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTransaction", reflect.TypeOf((*MockService)(nil).BeginTransaction), ctx)
+}
+
+// RunInTransaction mocks base method.
+func (m *MockService) RunInTransaction(ctx context.Context, id, cypher string, params map[string]any) ([]*neo4j.Record, *database.WriteSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunInTransaction", ctx, id, cypher, params)
+	ret0, _ := ret[0].([]*neo4j.Record)
+	ret1, _ := ret[1].(*database.WriteSummary)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RunInTransaction indicates an expected call of RunInTransaction.
+func (mr *MockServiceMockRecorder) RunInTransaction(ctx, id, cypher, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunInTransaction", reflect.TypeOf((*MockService)(nil).RunInTransaction), ctx, id, cypher, params)
+}
+
+// CancelQuery mocks base method.
+func (m *MockService) CancelQuery(ctx context.Context, id string) (*database.CancelledQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelQuery", ctx, id)
+	ret0, _ := ret[0].(*database.CancelledQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelQuery indicates an expected call of CancelQuery.
+func (mr *MockServiceMockRecorder) CancelQuery(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelQuery", reflect.TypeOf((*MockService)(nil).CancelQuery), ctx, id)
+}
+
+// CommitTransaction mocks base method.
+func (m *MockService) CommitTransaction(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitTransaction", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitTransaction indicates an expected call of CommitTransaction.
+func (mr *MockServiceMockRecorder) CommitTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitTransaction", reflect.TypeOf((*MockService)(nil).CommitTransaction), ctx, id)
+}
+
+// RollbackTransaction mocks base method.
+func (m *MockService) RollbackTransaction(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RollbackTransaction", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RollbackTransaction indicates an expected call of RollbackTransaction.
+func (mr *MockServiceMockRecorder) RollbackTransaction(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RollbackTransaction", reflect.TypeOf((*MockService)(nil).RollbackTransaction), ctx, id)
+}
+
 // Neo4jRecordsToJSON mocks base method.
-func (m *MockService) Neo4jRecordsToJSON(records []*neo4j.Record) (string, error) {
+func (m *MockService) Neo4jRecordsToJSON(ctx context.Context, records []*neo4j.Record) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Neo4jRecordsToJSON", records)
+	ret := m.ctrl.Call(m, "Neo4jRecordsToJSON", ctx, records)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Neo4jRecordsToJSON indicates an expected call of Neo4jRecordsToJSON.
-func (mr *MockServiceMockRecorder) Neo4jRecordsToJSON(records any) *gomock.Call {
+func (mr *MockServiceMockRecorder) Neo4jRecordsToJSON(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Neo4jRecordsToJSON", reflect.TypeOf((*MockService)(nil).Neo4jRecordsToJSON), ctx, records)
+}
+
+// KillQuery mocks base method.
+func (m *MockService) KillQuery(ctx context.Context, id string) (*database.CancelledQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KillQuery", ctx, id)
+	ret0, _ := ret[0].(*database.CancelledQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// KillQuery indicates an expected call of KillQuery.
+func (mr *MockServiceMockRecorder) KillQuery(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Neo4jRecordsToJSON", reflect.TypeOf((*MockService)(nil).Neo4jRecordsToJSON), records)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KillQuery", reflect.TypeOf((*MockService)(nil).KillQuery), ctx, id)
+}
+
+// ListRunningQueries mocks base method.
+func (m *MockService) ListRunningQueries(ctx context.Context, mcpOnly bool) ([]database.RunningQuery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRunningQueries", ctx, mcpOnly)
+	ret0, _ := ret[0].([]database.RunningQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRunningQueries indicates an expected call of ListRunningQueries.
+func (mr *MockServiceMockRecorder) ListRunningQueries(ctx, mcpOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRunningQueries", reflect.TypeOf((*MockService)(nil).ListRunningQueries), ctx, mcpOnly)
+}
+
+// Neo4jRecordsToCSV mocks base method.
+func (m *MockService) Neo4jRecordsToCSV(ctx context.Context, records []*neo4j.Record, delimiter rune) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Neo4jRecordsToCSV", ctx, records, delimiter)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Neo4jRecordsToCSV indicates an expected call of Neo4jRecordsToCSV.
+func (mr *MockServiceMockRecorder) Neo4jRecordsToCSV(ctx, records, delimiter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Neo4jRecordsToCSV", reflect.TypeOf((*MockService)(nil).Neo4jRecordsToCSV), ctx, records, delimiter)
 }
 
 // VerifyConnectivity mocks base method.
@@ -116,15 +281,29 @@ func (mr *MockServiceMockRecorder) VerifyConnectivity(ctx any) *gomock.Call {
 }
 
 // GetDatabaseName mocks base method.
-func (m *MockService) GetDatabaseName() string {
+func (m *MockService) GetDatabaseName(ctx context.Context) string {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDatabaseName")
+	ret := m.ctrl.Call(m, "GetDatabaseName", ctx)
 	ret0, _ := ret[0].(string)
 	return ret0
 }
 
 // GetDatabaseName indicates an expected call of GetDatabaseName.
-func (mr *MockServiceMockRecorder) GetDatabaseName() *gomock.Call {
+func (mr *MockServiceMockRecorder) GetDatabaseName(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseName", reflect.TypeOf((*MockService)(nil).GetDatabaseName), ctx)
+}
+
+// ForDatabase mocks base method.
+func (m *MockService) ForDatabase(name string) database.Service {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForDatabase", name)
+	ret0, _ := ret[0].(database.Service)
+	return ret0
+}
+
+// ForDatabase indicates an expected call of ForDatabase.
+func (mr *MockServiceMockRecorder) ForDatabase(name any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDatabaseName", reflect.TypeOf((*MockService)(nil).GetDatabaseName))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForDatabase", reflect.TypeOf((*MockService)(nil).ForDatabase), name)
 }
@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Query plan modes accepted by ExplainQuery
+const (
+	PlanModeExplain = "explain"
+	PlanModeProfile = "profile"
+)
+
+// QueryPlan is one operator of an execution plan, with its inputs as children.
+// DbHits and Rows are only set for profiled plans, which execute the query.
+type QueryPlan struct {
+	Operator      string      `json:"operator"`
+	Details       string      `json:"details,omitempty"`
+	EstimatedRows float64     `json:"estimatedRows"`
+	Identifiers   []string    `json:"identifiers,omitempty"`
+	DbHits        *int64      `json:"dbHits,omitempty"`
+	Rows          *int64      `json:"rows,omitempty"`
+	Children      []QueryPlan `json:"children,omitempty"`
+}
+
+// TotalDbHits sums the database hits of the plan and all its children
+func (p QueryPlan) TotalDbHits() int64 {
+	var total int64
+	if p.DbHits != nil {
+		total = *p.DbHits
+	}
+	for _, child := range p.Children {
+		total += child.TotalDbHits()
+	}
+	return total
+}
+
+// ExplainQuery prefixes the query with EXPLAIN or PROFILE and returns the resulting plan.
+// PROFILE executes the query, so callers must only profile queries they are allowed to run.
+func (s *Neo4jService) ExplainQuery(ctx context.Context, mode string, cypher string, params map[string]any) (*QueryPlan, error) {
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return nil, err
+	}
+	var prefix string
+	switch mode {
+	case PlanModeExplain:
+		prefix = "EXPLAIN"
+	case PlanModeProfile:
+		prefix = "PROFILE"
+	default:
+		return nil, fmt.Errorf("unsupported plan mode %q, must be %s or %s", mode, PlanModeExplain, PlanModeProfile)
+	}
+
+	res, err := s.executeQuery(ctx, strings.Join([]string{prefix, cypher}, " "), params, s.readOptions()...)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to %s query: %w", mode, err)
+		slog.Error("Error in ExplainQuery", "error", wrappedErr)
+		return nil, wrappedErr
+	}
+
+	if res.Summary == nil {
+		err := fmt.Errorf("failed to %s query: no summary returned", mode)
+		slog.Error("Error in ExplainQuery", "error", err)
+		return nil, err
+	}
+
+	if mode == PlanModeProfile && res.Summary.Profile() != nil {
+		plan := NewProfiledQueryPlan(res.Summary.Profile())
+		return &plan, nil
+	}
+	if res.Summary.Plan() != nil {
+		plan := NewQueryPlan(res.Summary.Plan())
+		return &plan, nil
+	}
+
+	err = fmt.Errorf("failed to %s query: no plan returned", mode)
+	slog.Error("Error in ExplainQuery", "error", err)
+	return nil, err
+}
+
+// NewQueryPlan converts a driver plan into a QueryPlan
+func NewQueryPlan(plan neo4j.Plan) QueryPlan {
+	result := QueryPlan{
+		Operator:      planOperator(plan.Operator()),
+		Details:       planDetails(plan.Arguments()),
+		EstimatedRows: planEstimatedRows(plan.Arguments()),
+		Identifiers:   plan.Identifiers(),
+	}
+	for _, child := range plan.Children() {
+		result.Children = append(result.Children, NewQueryPlan(child))
+	}
+	return result
+}
+
+// NewProfiledQueryPlan converts a driver profiled plan into a QueryPlan with db hits and rows
+func NewProfiledQueryPlan(plan neo4j.ProfiledPlan) QueryPlan {
+	dbHits := plan.DbHits()
+	rows := plan.Records()
+	result := QueryPlan{
+		Operator:      planOperator(plan.Operator()),
+		Details:       planDetails(plan.Arguments()),
+		EstimatedRows: planEstimatedRows(plan.Arguments()),
+		Identifiers:   plan.Identifiers(),
+		DbHits:        &dbHits,
+		Rows:          &rows,
+	}
+	for _, child := range plan.Children() {
+		result.Children = append(result.Children, NewProfiledQueryPlan(child))
+	}
+	return result
+}
+
+// planOperator drops the database suffix Neo4j adds to operator names ("NodeByLabelScan@neo4j")
+func planOperator(operator string) string {
+	name, _, _ := strings.Cut(operator, "@")
+	return name
+}
+
+// planDetails returns the operator details, e.g. "c:Customer" for NodeByLabelScan
+func planDetails(arguments map[string]any) string {
+	details, _ := arguments["Details"].(string)
+	return details
+}
+
+// planEstimatedRows returns the planner's row estimate for an operator
+func planEstimatedRows(arguments map[string]any) float64 {
+	switch rows := arguments["EstimatedRows"].(type) {
+	case float64:
+		return rows
+	case int64:
+		return float64(rows)
+	}
+	return 0
+}
@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// fakeProfiledPlan implements neo4j.ProfiledPlan for plan conversion tests
+type fakeProfiledPlan struct {
+	operator  string
+	arguments map[string]any
+	dbHits    int64
+	records   int64
+	children  []neo4j.ProfiledPlan
+}
+
+func (p fakeProfiledPlan) Operator() string               { return p.operator }
+func (p fakeProfiledPlan) Arguments() map[string]any      { return p.arguments }
+func (p fakeProfiledPlan) Identifiers() []string          { return []string{"c"} }
+func (p fakeProfiledPlan) DbHits() int64                  { return p.dbHits }
+func (p fakeProfiledPlan) Records() int64                 { return p.records }
+func (p fakeProfiledPlan) Children() []neo4j.ProfiledPlan { return p.children }
+func (p fakeProfiledPlan) PageCacheMisses() int64         { return 0 }
+func (p fakeProfiledPlan) PageCacheHits() int64           { return 0 }
+func (p fakeProfiledPlan) PageCacheHitRatio() float64     { return 0 }
+func (p fakeProfiledPlan) Time() int64                    { return 0 }
+
+func TestNewProfiledQueryPlan(t *testing.T) {
+	plan := database.NewProfiledQueryPlan(fakeProfiledPlan{
+		operator:  "ProduceResults@neo4j",
+		arguments: map[string]any{"EstimatedRows": 120.0, "Details": "c"},
+		records:   120,
+		children: []neo4j.ProfiledPlan{
+			fakeProfiledPlan{
+				operator:  "NodeByLabelScan@neo4j",
+				arguments: map[string]any{"EstimatedRows": 120.0, "Details": "c:Customer"},
+				dbHits:    121,
+				records:   120,
+			},
+		},
+	})
+
+	if plan.Operator != "ProduceResults" {
+		t.Errorf("expected database suffix to be dropped, got %q", plan.Operator)
+	}
+	if plan.EstimatedRows != 120 || plan.Rows == nil || *plan.Rows != 120 {
+		t.Errorf("expected estimated and actual rows, got %+v", plan)
+	}
+	if len(plan.Children) != 1 || plan.Children[0].Details != "c:Customer" {
+		t.Fatalf("expected NodeByLabelScan child with details, got %+v", plan.Children)
+	}
+	if plan.TotalDbHits() != 121 {
+		t.Errorf("expected 121 total db hits, got %d", plan.TotalDbHits())
+	}
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/policy"
+)
+
+// queryPolicyKey is the context key of the query policy of a tool call
+type queryPolicyKey struct{}
+
+// queryPolicyCheck checks the queries of one tool call and keeps the first violation
+type queryPolicyCheck struct {
+	policy *policy.Policy
+	tool   string
+
+	mu        sync.Mutex
+	violation *policy.Violation
+}
+
+// WithQueryPolicy returns a context whose queries must be allowed by p as queries of tool.
+// Only the queries tools pass to the service are checked, not the ones the service issues itself,
+// such as the SHOW TRANSACTIONS of query cancellation. The returned function reports the first
+// query p denied, or nil.
+func WithQueryPolicy(ctx context.Context, p *policy.Policy, tool string) (context.Context, func() *policy.Violation) {
+	check := &queryPolicyCheck{policy: p, tool: tool}
+	return context.WithValue(ctx, queryPolicyKey{}, check), func() *policy.Violation {
+		check.mu.Lock()
+		defer check.mu.Unlock()
+		return check.violation
+	}
+}
+
+// checkQueryPolicy returns the violation of cypher, when the policy of ctx denies it
+func checkQueryPolicy(ctx context.Context, cypher string) error {
+	check, _ := ctx.Value(queryPolicyKey{}).(*queryPolicyCheck)
+	if check == nil {
+		return nil
+	}
+	violation := check.policy.Check(check.tool, cypher)
+	if violation == nil {
+		return nil
+	}
+
+	slog.Warn("Query denied by policy", "tool", check.tool, "rule", violation.Rule)
+	check.mu.Lock()
+	if check.violation == nil {
+		check.violation = violation
+	}
+	check.mu.Unlock()
+	return violation
+}
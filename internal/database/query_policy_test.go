@@ -0,0 +1,33 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/policy"
+)
+
+func TestWithQueryPolicy(t *testing.T) {
+	p, err := policy.New(policy.File{})
+	if err != nil {
+		t.Fatalf("policy.New() unexpected error = %v", err)
+	}
+	ctx, violation := database.WithQueryPolicy(context.Background(), p, "write-cypher")
+
+	// The denied queries are rejected before they reach the driver
+	var s database.Neo4jService
+	_, err = s.ExecuteWriteQuery(ctx, "MATCH (n) DETACH DELETE n", nil)
+	var denied *policy.Violation
+	if !errors.As(err, &denied) || denied.Rule != "unfiltered-detach-delete" {
+		t.Fatalf("ExecuteWriteQuery() error = %v, want a policy violation", err)
+	}
+	if _, err := s.GetQueryType(ctx, "LOAD CSV FROM 'file:///x.csv' AS row RETURN row", nil); !errors.As(err, &denied) {
+		t.Fatalf("GetQueryType() error = %v, want a policy violation", err)
+	}
+
+	if v := violation(); v == nil || v.Rule != "unfiltered-detach-delete" || v.Tool != "write-cypher" {
+		t.Errorf("expected the first violation to be reported, got %+v", v)
+	}
+}
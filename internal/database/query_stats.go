@@ -0,0 +1,236 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// QueryStatsCapacity is the number of executed queries the server keeps statistics for
+const QueryStatsCapacity = 1000
+
+// QueryRecord describes one executed query. Only a hash of the query text is kept, so parameters
+// inlined in the text are not retained.
+type QueryRecord struct {
+	Hash       string    `json:"hash"`
+	Tool       string    `json:"tool,omitempty"`
+	Database   string    `json:"database"`
+	DurationMs float64   `json:"durationMs"`
+	Rows       int       `json:"rows"`
+	Failed     bool      `json:"failed,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// ToolLatency summarizes the query durations of one tool
+type ToolLatency struct {
+	Tool    string  `json:"tool"`
+	Queries int     `json:"queries"`
+	Failed  int     `json:"failed"`
+	P50Ms   float64 `json:"p50Ms"`
+	P90Ms   float64 `json:"p90Ms"`
+	P99Ms   float64 `json:"p99Ms"`
+	MaxMs   float64 `json:"maxMs"`
+}
+
+// QueryStatsReport is a snapshot of the recorded queries
+type QueryStatsReport struct {
+	Recorded int           `json:"recorded"`
+	Capacity int           `json:"capacity"`
+	Since    *time.Time    `json:"since,omitempty"` // Time of the oldest recorded query
+	Slowest  []QueryRecord `json:"slowest"`
+	PerTool  []ToolLatency `json:"perTool"`
+}
+
+// QueryStats keeps the most recent executed queries in a ring buffer.
+// A nil QueryStats records nothing.
+type QueryStats struct {
+	mu        sync.Mutex
+	records   []QueryRecord
+	next      int // Index the next record is written to once the buffer is full
+	observers []func(QueryRecord)
+}
+
+// NewQueryStats creates a ring buffer keeping the last capacity queries
+func NewQueryStats(capacity int) *QueryStats {
+	return &QueryStats{records: make([]QueryRecord, 0, capacity)}
+}
+
+// OnRecord registers fn to be called with every recorded query, such as to export query metrics
+func (q *QueryStats) OnRecord(fn func(QueryRecord)) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.observers = append(q.observers, fn)
+}
+
+// Record adds an executed query, replacing the oldest one when the buffer is full
+func (q *QueryStats) Record(record QueryRecord) {
+	if q == nil {
+		return
+	}
+
+	q.mu.Lock()
+	observers := q.observers
+	q.store(record)
+	q.mu.Unlock()
+
+	for _, observe := range observers {
+		observe(record)
+	}
+}
+
+// store writes record to the ring buffer. The caller must hold q.mu.
+func (q *QueryStats) store(record QueryRecord) {
+	if cap(q.records) == 0 {
+		return
+	}
+	if len(q.records) < cap(q.records) {
+		q.records = append(q.records, record)
+		return
+	}
+	q.records[q.next] = record
+	q.next = (q.next + 1) % len(q.records)
+}
+
+// Report returns the slowest recorded queries, up to limit, and the latency percentiles of each tool
+func (q *QueryStats) Report(limit int) QueryStatsReport {
+	report := QueryStatsReport{Slowest: []QueryRecord{}, PerTool: []ToolLatency{}}
+	if q == nil {
+		return report
+	}
+
+	q.mu.Lock()
+	records := slices.Clone(q.records)
+	report.Capacity = cap(q.records)
+	q.mu.Unlock()
+
+	report.Recorded = len(records)
+	if len(records) == 0 {
+		return report
+	}
+
+	since := records[0].At
+	durations := make(map[string][]float64)
+	failed := make(map[string]int)
+	for _, record := range records {
+		if record.At.Before(since) {
+			since = record.At
+		}
+		durations[record.Tool] = append(durations[record.Tool], record.DurationMs)
+		if record.Failed {
+			failed[record.Tool]++
+		}
+	}
+	report.Since = &since
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].DurationMs > records[j].DurationMs })
+	report.Slowest = records[:min(limit, len(records))]
+
+	for tool, toolDurations := range durations {
+		slices.Sort(toolDurations)
+		report.PerTool = append(report.PerTool, ToolLatency{
+			Tool:    tool,
+			Queries: len(toolDurations),
+			Failed:  failed[tool],
+			P50Ms:   percentile(toolDurations, 50),
+			P90Ms:   percentile(toolDurations, 90),
+			P99Ms:   percentile(toolDurations, 99),
+			MaxMs:   toolDurations[len(toolDurations)-1],
+		})
+	}
+	sort.Slice(report.PerTool, func(i, j int) bool { return report.PerTool[i].Tool < report.PerTool[j].Tool })
+	return report
+}
+
+// percentile returns the nearest-rank percentile p of sorted values
+func percentile(sorted []float64, p int) float64 {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	return sorted[max(rank, 1)-1]
+}
+
+// HashQuery returns a short, stable hash identifying the text of a query
+func HashQuery(cypher string) string {
+	sum := sha256.Sum256([]byte(cypher))
+	return hex.EncodeToString(sum[:8])
+}
+
+// queryStatsKey is the context key of the statistics and tool name of a tool call
+type queryStatsKey struct{}
+
+type queryStatsContext struct {
+	stats *QueryStats
+	tool  string
+}
+
+// WithQueryStats returns a context whose queries are recorded in stats under the tool name
+func WithQueryStats(ctx context.Context, stats *QueryStats, tool string) context.Context {
+	return context.WithValue(ctx, queryStatsKey{}, queryStatsContext{stats: stats, tool: tool})
+}
+
+// QueryLog collects the queries run during one tool call, including their text, for the audit log.
+// A nil QueryLog records nothing.
+type QueryLog struct {
+	mu      sync.Mutex
+	queries []LoggedQuery
+}
+
+// LoggedQuery is a query collected by a QueryLog
+type LoggedQuery struct {
+	Cypher     string
+	Database   string
+	DurationMs float64
+	Rows       int
+	Failed     bool
+}
+
+// queryLogKey is the context key of the QueryLog of a tool call
+type queryLogKey struct{}
+
+// WithQueryLog returns a context whose queries are collected in log
+func WithQueryLog(ctx context.Context, log *QueryLog) context.Context {
+	return context.WithValue(ctx, queryLogKey{}, log)
+}
+
+// Queries returns the collected queries in the order they ran
+func (l *QueryLog) Queries() []LoggedQuery {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.queries)
+}
+
+func (l *QueryLog) add(query LoggedQuery) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.queries = append(l.queries, query)
+}
+
+// recordQuery adds an executed query to the statistics and the query log carried by ctx, if any
+func (s *Neo4jService) recordQuery(ctx context.Context, cypher string, started time.Time, rows int, err error) {
+	durationMs := float64(time.Since(started).Microseconds()) / 1000
+	if log, ok := ctx.Value(queryLogKey{}).(*QueryLog); ok {
+		log.add(LoggedQuery{Cypher: cypher, Database: s.databaseName(ctx), DurationMs: durationMs, Rows: rows, Failed: err != nil})
+	}
+
+	statsCtx, ok := ctx.Value(queryStatsKey{}).(queryStatsContext)
+	if !ok {
+		return
+	}
+	statsCtx.stats.Record(QueryRecord{
+		Hash:       HashQuery(cypher),
+		Tool:       statsCtx.tool,
+		Database:   s.databaseName(ctx),
+		DurationMs: durationMs,
+		Rows:       rows,
+		Failed:     err != nil,
+		At:         started,
+	})
+}
@@ -0,0 +1,91 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+)
+
+func TestQueryStats(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("keeps only the most recent queries", func(t *testing.T) {
+		stats := database.NewQueryStats(3)
+		for i := range 5 {
+			stats.Record(database.QueryRecord{Tool: "read-cypher", DurationMs: float64(i), At: start.Add(time.Duration(i) * time.Second)})
+		}
+
+		report := stats.Report(10)
+		if report.Recorded != 3 || report.Capacity != 3 {
+			t.Fatalf("Report() recorded %d of %d, want 3 of 3", report.Recorded, report.Capacity)
+		}
+		if report.Slowest[0].DurationMs != 4 || report.Slowest[2].DurationMs != 2 {
+			t.Errorf("Report() slowest = %+v, want durations 4, 3, 2", report.Slowest)
+		}
+		if !report.Since.Equal(start.Add(2 * time.Second)) {
+			t.Errorf("Report() since = %v, want the oldest kept query", report.Since)
+		}
+	})
+
+	t.Run("per tool percentiles", func(t *testing.T) {
+		stats := database.NewQueryStats(database.QueryStatsCapacity)
+		for i := 1; i <= 100; i++ {
+			stats.Record(database.QueryRecord{Tool: "get-entity-network", DurationMs: float64(i), Failed: i%10 == 0, At: start})
+		}
+		stats.Record(database.QueryRecord{Tool: "read-cypher", DurationMs: 7, At: start})
+
+		report := stats.Report(2)
+		if len(report.Slowest) != 2 || report.Slowest[0].DurationMs != 100 {
+			t.Errorf("Report() slowest = %+v, want the 2 slowest queries", report.Slowest)
+		}
+		if len(report.PerTool) != 2 {
+			t.Fatalf("Report() per tool = %+v, want 2 tools", report.PerTool)
+		}
+
+		network := report.PerTool[0]
+		if network.Tool != "get-entity-network" || network.Queries != 100 || network.Failed != 10 {
+			t.Errorf("Report() per tool = %+v, want 100 queries with 10 failed", network)
+		}
+		if network.P50Ms != 50 || network.P90Ms != 90 || network.P99Ms != 99 || network.MaxMs != 100 {
+			t.Errorf("Report() percentiles = %+v, want 50, 90, 99 and max 100", network)
+		}
+		if readCypher := report.PerTool[1]; readCypher.P50Ms != 7 || readCypher.P99Ms != 7 {
+			t.Errorf("Report() single query percentiles = %+v, want 7", readCypher)
+		}
+	})
+
+	t.Run("observers see every query", func(t *testing.T) {
+		stats := database.NewQueryStats(1)
+		var observed []string
+		stats.OnRecord(func(record database.QueryRecord) { observed = append(observed, record.Tool) })
+
+		stats.Record(database.QueryRecord{Tool: "read-cypher"})
+		stats.Record(database.QueryRecord{Tool: "write-cypher"})
+
+		if len(observed) != 2 || observed[1] != "write-cypher" {
+			t.Errorf("observed = %v, want both queries even once the buffer is full", observed)
+		}
+	})
+
+	t.Run("nil stats record nothing", func(t *testing.T) {
+		var stats *database.QueryStats
+		stats.Record(database.QueryRecord{Tool: "read-cypher"})
+
+		if report := stats.Report(10); report.Recorded != 0 || report.Slowest == nil {
+			t.Errorf("Report() = %+v, want an empty report", report)
+		}
+	})
+}
+
+func TestHashQuery(t *testing.T) {
+	if database.HashQuery("MATCH (n) RETURN n") != database.HashQuery("MATCH (n) RETURN n") {
+		t.Error("HashQuery() is not stable")
+	}
+	if database.HashQuery("MATCH (n) RETURN n") == database.HashQuery("MATCH (n) RETURN n LIMIT 1") {
+		t.Error("HashQuery() returned the same hash for different queries")
+	}
+	if len(database.HashQuery("RETURN 1")) != 16 {
+		t.Errorf("HashQuery() = %q, want 16 hex characters", database.HashQuery("RETURN 1"))
+	}
+}
@@ -0,0 +1,28 @@
+package database
+
+import "github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+// ReadRouting configures where the read queries of a cluster deployment run.
+// By default they run on the read replicas and followers, and wait for them to apply the writes made
+// through the server, so a tool call always reads what an earlier one wrote.
+type ReadRouting struct {
+	Leader   bool // Run read queries on the leader too, e.g. when replicas lag too far behind for the workload
+	Eventual bool // Read from replicas without waiting for them to apply earlier writes, so analytics never wait
+}
+
+// SetReadRouting replaces the read routing. Call it before the service is used.
+func (s *Neo4jService) SetReadRouting(r ReadRouting) {
+	s.readRouting = r
+}
+
+// readOptions returns the routing options of a read query
+func (s *Neo4jService) readOptions() []neo4j.ExecuteQueryConfigurationOption {
+	options := []neo4j.ExecuteQueryConfigurationOption{neo4j.ExecuteQueryWithReadersRouting()}
+	if s.readRouting.Leader {
+		options[0] = neo4j.ExecuteQueryWithWritersRouting()
+	}
+	if s.readRouting.Eventual {
+		options = append(options, neo4j.ExecuteQueryWithoutBookmarkManager())
+	}
+	return options
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+)
+
+// geoJSONPoint is the GeoJSON form of a Neo4j point; srid identifies the coordinate reference system
+type geoJSONPoint struct {
+	Type        string    `json:"type"`
+	Coordinates []float64 `json:"coordinates"`
+	SRID        uint32    `json:"srid"`
+}
+
+// normalizeValue converts Neo4j temporal values to ISO-8601 strings and points to GeoJSON,
+// recursing into lists, maps and the properties of nodes, relationships and paths.
+// Without it dates marshal as {} and durations and points as opaque structs.
+// The values of the map keys and properties masking selects are masked; a nil masking masks nothing.
+func normalizeValue(value any, masking *Masking) any {
+	switch v := value.(type) {
+	case time.Time: // DateTime
+		return v.Format(time.RFC3339Nano)
+	case dbtype.Date:
+		return v.String()
+	case dbtype.Time:
+		return v.String()
+	case dbtype.LocalTime:
+		return v.String()
+	case dbtype.LocalDateTime:
+		return v.String()
+	case dbtype.Duration:
+		return v.String()
+	case dbtype.Point2D:
+		return geoJSONPoint{Type: "Point", Coordinates: []float64{v.X, v.Y}, SRID: v.SpatialRefId}
+	case dbtype.Point3D:
+		return geoJSONPoint{Type: "Point", Coordinates: []float64{v.X, v.Y, v.Z}, SRID: v.SpatialRefId}
+	case dbtype.Node:
+		v.Props = normalizeMap(v.Props, masking)
+		return v
+	case dbtype.Relationship:
+		v.Props = normalizeMap(v.Props, masking)
+		return v
+	case dbtype.Path:
+		nodes := make([]dbtype.Node, len(v.Nodes))
+		for i, node := range v.Nodes {
+			node.Props = normalizeMap(node.Props, masking)
+			nodes[i] = node
+		}
+		relationships := make([]dbtype.Relationship, len(v.Relationships))
+		for i, relationship := range v.Relationships {
+			relationship.Props = normalizeMap(relationship.Props, masking)
+			relationships[i] = relationship
+		}
+		return dbtype.Path{Nodes: nodes, Relationships: relationships}
+	case map[string]any:
+		return normalizeMap(v, masking)
+	case []any:
+		normalized := make([]any, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeValue(item, masking)
+		}
+		return normalized
+	}
+	return value
+}
+
+// normalizeMap returns a copy of m with every value normalized, and masked when masking selects its key
+func normalizeMap(m map[string]any, masking *Masking) map[string]any {
+	if m == nil {
+		return nil
+	}
+	normalized := make(map[string]any, len(m))
+	for key, value := range m {
+		normalized[key] = normalizeValue(value, masking)
+		if masking.masks(key) {
+			normalized[key] = masking.mask(normalized[key])
+		}
+	}
+	return normalized
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	// retryInitialBackoff is the wait before the first retry of a query; each further retry doubles it
+	retryInitialBackoff = 200 * time.Millisecond
+	// retryMaxBackoff caps the wait between retries
+	retryMaxBackoff = 5 * time.Second
+)
+
+// ErrDatabaseDegraded is returned without querying Neo4j while the circuit breaker is open
+var ErrDatabaseDegraded = errors.New("database degraded")
+
+// Resilience configures how the service rides out transient Neo4j and Aura failures
+type Resilience struct {
+	MaxRetries       int           // Retries of a query failing with a transient error; 0 disables retries
+	BreakerThreshold int           // Consecutive failures to reach Neo4j that open the circuit breaker; 0 disables it
+	BreakerCooldown  time.Duration // How long the open circuit breaker fails queries fast before probing Neo4j again
+}
+
+// DefaultResilience is the resilience of a new service
+var DefaultResilience = Resilience{MaxRetries: 2, BreakerThreshold: 5, BreakerCooldown: 30 * time.Second}
+
+// SetResilience replaces the retry and circuit breaker settings. Call it before the service is used,
+// since ForDatabase copies share the circuit breaker of the service they were made from.
+func (s *Neo4jService) SetResilience(r Resilience) {
+	s.maxRetries = max(r.MaxRetries, 0)
+	s.breaker = newCircuitBreaker(r.BreakerThreshold, r.BreakerCooldown)
+}
+
+// circuitBreaker fails queries fast once Neo4j has been unreachable for threshold queries in a row,
+// instead of letting every tool call wait for the driver to time out. After the cooldown one caller
+// probes Neo4j; the breaker closes when the probe reconnects and opens again when it fails.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time // Zero while closed
+	probing  bool
+}
+
+// newCircuitBreaker returns a breaker, or nil, which never opens, when threshold is 0
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow returns ErrDatabaseDegraded while the breaker is open. Once the cooldown is over,
+// it lets a single caller through with probe set, while the others keep failing fast.
+func (b *circuitBreaker) allow() (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return false, nil
+	}
+	retryIn := b.cooldown - b.now().Sub(b.openedAt)
+	if retryIn > 0 || b.probing {
+		return false, fmt.Errorf("%w: Neo4j could not be reached for the last %d queries, retry in %s",
+			ErrDatabaseDegraded, b.failures, max(retryIn, 0).Round(time.Second))
+	}
+	b.probing = true
+	return true, nil
+}
+
+// record counts a failure to reach Neo4j, opening the breaker at the threshold, and closes it on any other outcome
+func (b *circuitBreaker) record(err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if err == nil || !isUnavailable(err) {
+		if !b.openedAt.IsZero() {
+			slog.Info("Neo4j is reachable again, closing the circuit breaker")
+		}
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		if b.openedAt.IsZero() {
+			slog.Error("Neo4j is unreachable, opening the circuit breaker", "failures", b.failures, "cooldown", b.cooldown, "error", err)
+		}
+		b.openedAt = b.now()
+	}
+}
+
+// runResilient runs query, retrying transient failures with exponential backoff, behind the circuit breaker.
+// A caller probing the open breaker first verifies connectivity, so the driver reconnects before the query runs.
+func (s *Neo4jService) runResilient(ctx context.Context, query func() error) error {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return err
+	}
+	if probe {
+		if err := s.driver.VerifyConnectivity(ctx); err != nil {
+			s.breaker.record(err)
+			return fmt.Errorf("%w: Neo4j is still unreachable: %w", ErrDatabaseDegraded, err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = query()
+		if err == nil || attempt >= s.maxRetries || !isTransient(err) || ctx.Err() != nil {
+			break
+		}
+		backoff := retryBackoff(attempt)
+		slog.Warn("Retrying query after a transient error", "attempt", attempt+1, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+	}
+	s.breaker.record(err)
+	return err
+}
+
+// retryBackoff returns the wait before retry attempt+1, with jitter so retrying clients spread out
+func retryBackoff(attempt int) time.Duration {
+	backoff := min(retryInitialBackoff<<attempt, retryMaxBackoff)
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// isTransient reports whether a failed query may succeed when retried: Neo4j could not be reached,
+// or it reported a transient error such as a deadlock or a leader election
+func isTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || IsAccessModeError(err) {
+		return false
+	}
+	if isUnavailable(err) {
+		return true
+	}
+	var neo4jErr *neo4j.Neo4jError
+	return errors.As(lastAttemptError(err), &neo4jErr) && strings.HasPrefix(neo4jErr.Code, "Neo.TransientError.")
+}
+
+// isUnavailable reports whether err means Neo4j could not be reached or could not serve queries at all,
+// as opposed to the failure of one query
+func isUnavailable(err error) bool {
+	err = lastAttemptError(err)
+	var neo4jErr *neo4j.Neo4jError
+	if errors.As(err, &neo4jErr) {
+		return strings.HasSuffix(neo4jErr.Code, "Unavailable")
+	}
+	var connectivityErr *neo4j.ConnectivityError
+	return errors.As(err, &connectivityErr)
+}
+
+// lastAttemptError returns the error of the driver's last attempt when it gave up retrying, which tells why, else err
+func lastAttemptError(err error) error {
+	var limit *neo4j.TransactionExecutionLimit
+	if errors.As(err, &limit) && len(limit.Errors) > 0 {
+		return limit.Errors[len(limit.Errors)-1]
+	}
+	return err
+}
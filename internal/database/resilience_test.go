@@ -0,0 +1,90 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	neo4jconfig "github.com/neo4j/neo4j-go-driver/v5/neo4j/config"
+)
+
+// newUnreachableService returns a service whose driver cannot connect, and does not retry on its own
+func newUnreachableService(t *testing.T, resilience database.Resilience) *database.Neo4jService {
+	t.Helper()
+	driver, err := neo4j.NewDriverWithContext("bolt://127.0.0.1:1", neo4j.NoAuth(), func(c *neo4jconfig.Config) {
+		c.MaxTransactionRetryTime = 0
+	})
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	t.Cleanup(func() { _ = driver.Close(context.Background()) })
+
+	service, err := database.NewNeo4jService(driver, "neo4j", config.TransportModeStdio, "test-version")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	service.SetResilience(resilience)
+	return service
+}
+
+func TestNeo4jService_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("opens after consecutive failures to reach Neo4j", func(t *testing.T) {
+		service := newUnreachableService(t, database.Resilience{BreakerThreshold: 2, BreakerCooldown: time.Hour})
+
+		for range 2 {
+			if _, err := service.ExecuteReadQuery(ctx, "RETURN 1", nil); err == nil || errors.Is(err, database.ErrDatabaseDegraded) {
+				t.Fatalf("ExecuteReadQuery() error = %v, want a connectivity error", err)
+			}
+		}
+		_, err := service.ExecuteReadQuery(ctx, "RETURN 1", nil)
+		if !errors.Is(err, database.ErrDatabaseDegraded) {
+			t.Errorf("ExecuteReadQuery() error = %v, want ErrDatabaseDegraded", err)
+		}
+
+		// Services for other databases share the breaker, since they share the driver
+		if _, err := service.ForDatabase("fraud").ExecuteWriteQuery(ctx, "CREATE (n)", nil); !errors.Is(err, database.ErrDatabaseDegraded) {
+			t.Errorf("ExecuteWriteQuery() error = %v, want ErrDatabaseDegraded", err)
+		}
+	})
+
+	t.Run("probes Neo4j after the cooldown", func(t *testing.T) {
+		service := newUnreachableService(t, database.Resilience{BreakerThreshold: 1})
+
+		if _, err := service.ExecuteReadQuery(ctx, "RETURN 1", nil); errors.Is(err, database.ErrDatabaseDegraded) {
+			t.Fatalf("ExecuteReadQuery() error = %v, want a connectivity error", err)
+		}
+		_, err := service.ExecuteReadQuery(ctx, "RETURN 1", nil)
+		if !errors.Is(err, database.ErrDatabaseDegraded) || !errors.As(err, new(*neo4j.ConnectivityError)) {
+			t.Errorf("ExecuteReadQuery() error = %v, want ErrDatabaseDegraded from the failed probe", err)
+		}
+	})
+
+	t.Run("disabled breaker keeps querying Neo4j", func(t *testing.T) {
+		service := newUnreachableService(t, database.Resilience{})
+
+		for range 3 {
+			if _, err := service.ExecuteReadQuery(ctx, "RETURN 1", nil); err == nil || errors.Is(err, database.ErrDatabaseDegraded) {
+				t.Fatalf("ExecuteReadQuery() error = %v, want a connectivity error", err)
+			}
+		}
+	})
+}
+
+func TestNeo4jService_RetriesTransientErrors(t *testing.T) {
+	service := newUnreachableService(t, database.Resilience{MaxRetries: 2})
+
+	started := time.Now()
+	if _, err := service.ExecuteReadQuery(context.Background(), "RETURN 1", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	// Two retries wait at least half of 200ms and 400ms
+	if elapsed := time.Since(started); elapsed < 300*time.Millisecond {
+		t.Errorf("expected the query to be retried with backoff, it failed after %s", elapsed)
+	}
+}
@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// ResultCache keeps the records of read queries in memory, keyed by database, caller, query and parameters,
+// so an agent repeating the same lookup in one loop does not run it against Neo4j again.
+// Only the queries of a context returned by WithResultCache are cached, and any write through the service
+// clears the cache. A nil cache never returns a hit.
+type ResultCache struct {
+	ttl        time.Duration
+	maxEntries int
+	mu         sync.Mutex
+	entries    map[string]resultCacheEntry
+	generation uint64 // Incremented by Clear, so a read that overlapped a write is not stored
+}
+
+type resultCacheEntry struct {
+	records   []*neo4j.Record
+	expiresAt time.Time
+}
+
+// NewResultCache creates a result cache holding at most maxEntries results for ttl each.
+// It returns nil, disabling caching, when ttl or maxEntries is not positive.
+func NewResultCache(ttl time.Duration, maxEntries int) *ResultCache {
+	if ttl <= 0 || maxEntries <= 0 {
+		return nil
+	}
+	return &ResultCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		entries:    make(map[string]resultCacheEntry),
+	}
+}
+
+// SetResultCache makes the service serve cacheable read queries from cache. Call it before the service is used.
+func (s *Neo4jService) SetResultCache(cache *ResultCache) {
+	s.resultCache = cache
+}
+
+// resultCacheKey is the context key marking the queries of a tool call as cacheable
+type resultCacheKey struct{}
+
+// WithResultCache returns a context whose read queries may be served from the service's result cache.
+// Only use it for tools whose queries return the same result for the same parameters until data is written.
+func WithResultCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, resultCacheKey{}, true)
+}
+
+// cacheKey returns the key of a read query in the result cache, or false when the query must not be cached
+func (s *Neo4jService) cacheKey(ctx context.Context, cypher string, params map[string]any) (string, bool) {
+	if s.resultCache == nil {
+		return "", false
+	}
+	if cacheable, _ := ctx.Value(resultCacheKey{}).(bool); !cacheable {
+		return "", false
+	}
+	// Parameters of types JSON cannot encode are not cached; map keys are encoded in sorted order
+	encodedParams, err := json.Marshal(params)
+	if err != nil {
+		return "", false
+	}
+	// Callers may be shown different data by Neo4j security rules, so each caller has their own entries.
+	// The credentials are part of the key because a Basic Auth username is not verified until Neo4j sees the password.
+	identity, _ := auth.GetIdentity(ctx)
+	key, err := json.Marshal([]string{s.databaseName(ctx), identity, s.credentialsHash(ctx), cypher, string(encodedParams)})
+	if err != nil {
+		return "", false
+	}
+	return string(key), true
+}
+
+// credentialsHash returns a digest of the credentials the queries of ctx run with, or "" for the driver's own
+func (s *Neo4jService) credentialsHash(ctx context.Context) string {
+	token := s.authToken(ctx)
+	if token == nil {
+		return ""
+	}
+	encoded, err := json.Marshal(token.Tokens)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get returns the cached records of a query key if they have not expired.
+// The records are shared with other callers and must not be modified.
+func (c *ResultCache) Get(key string) ([]*neo4j.Record, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.records, true
+}
+
+// Generation returns the number of times the cache was cleared. Read it before running a query and pass
+// it to Set, so a result that may predate a write committed while the query ran is not stored.
+func (c *ResultCache) Generation() uint64 {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Set stores the records of a query key, dropping the entry closest to expiry when the cache is full.
+// The records are not stored when the cache was cleared since generation was read.
+func (c *ResultCache) Set(key string, generation uint64, records []*neo4j.Record) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxEntries {
+		c.evict()
+	}
+	c.entries[key] = resultCacheEntry{records: records, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// Clear drops every cached result, after data was written
+func (c *ResultCache) Clear() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.generation++
+}
+
+// Len returns the number of cached results, including expired ones not dropped yet
+func (c *ResultCache) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// evict drops the expired entries, or the oldest one when none expired. Must be called with mu held.
+func (c *ResultCache) evict() {
+	now := time.Now()
+	oldest := ""
+	for key, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldest == "" || entry.expiresAt.Before(c.entries[oldest].expiresAt) {
+			oldest = key
+		}
+	}
+	if len(c.entries) >= c.maxEntries {
+		delete(c.entries, oldest)
+	}
+}
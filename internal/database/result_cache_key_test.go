@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResultCacheKey_Credentials(t *testing.T) {
+	driver, err := neo4j.NewDriverWithContext("bolt://localhost:7687", neo4j.NoAuth())
+	require.NoError(t, err)
+	defer driver.Close(context.Background())
+
+	service, err := NewNeo4jService(driver, "neo4j", config.TransportModeHTTP, "test-version")
+	require.NoError(t, err)
+	service.SetResultCache(NewResultCache(time.Minute, 10))
+
+	keyFor := func(username, password string) string {
+		t.Helper()
+		ctx := auth.WithIdentity(auth.WithBasicAuth(WithResultCache(context.Background()), username, password), username)
+		key, ok := service.cacheKey(ctx, "MATCH (c:Customer) RETURN c", nil)
+		require.True(t, ok)
+		return key
+	}
+
+	t.Run("same credentials share entries", func(t *testing.T) {
+		assert.Equal(t, keyFor("alice", "secret"), keyFor("alice", "secret"))
+	})
+
+	t.Run("a wrong password does not reach the results of the right one", func(t *testing.T) {
+		key := keyFor("alice", "secret")
+		service.resultCache.Set(key, 0, []*neo4j.Record{{Keys: []string{"name"}, Values: []any{"Jane"}}})
+
+		wrong := keyFor("alice", "wrong-password")
+		assert.NotEqual(t, key, wrong)
+		_, ok := service.resultCache.Get(wrong)
+		assert.False(t, ok)
+	})
+
+	t.Run("keys do not hold the password", func(t *testing.T) {
+		assert.NotContains(t, keyFor("alice", "secret"), "secret")
+	})
+}
@@ -0,0 +1,88 @@
+package database_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultCache(t *testing.T) {
+	records := []*neo4j.Record{{Keys: []string{"name"}, Values: []any{"Jane"}}}
+
+	t.Run("returns stored records per key", func(t *testing.T) {
+		cache := database.NewResultCache(time.Minute, 10)
+		cache.Set("query", 0, records)
+
+		cached, ok := cache.Get("query")
+		assert.True(t, ok)
+		assert.Equal(t, records, cached)
+
+		_, ok = cache.Get("other")
+		assert.False(t, ok)
+	})
+
+	t.Run("expired entries are not returned", func(t *testing.T) {
+		cache := database.NewResultCache(time.Millisecond, 10)
+		cache.Set("query", 0, records)
+		time.Sleep(5 * time.Millisecond)
+
+		_, ok := cache.Get("query")
+		assert.False(t, ok)
+	})
+
+	t.Run("clear drops every entry", func(t *testing.T) {
+		cache := database.NewResultCache(time.Minute, 10)
+		cache.Set("first", 0, records)
+		cache.Set("second", 0, records)
+
+		cache.Clear()
+
+		assert.Equal(t, 0, cache.Len())
+		_, ok := cache.Get("first")
+		assert.False(t, ok)
+	})
+
+	t.Run("a result read before a clear is not stored", func(t *testing.T) {
+		cache := database.NewResultCache(time.Minute, 10)
+		generation := cache.Generation()
+
+		cache.Clear()
+		cache.Set("query", generation, records)
+
+		_, ok := cache.Get("query")
+		assert.False(t, ok)
+
+		cache.Set("query", cache.Generation(), records)
+		_, ok = cache.Get("query")
+		assert.True(t, ok)
+	})
+
+	t.Run("a full cache drops its oldest entry", func(t *testing.T) {
+		cache := database.NewResultCache(time.Minute, 3)
+		for i := range 4 {
+			cache.Set(fmt.Sprintf("query-%d", i), 0, records)
+			time.Sleep(time.Millisecond)
+		}
+
+		assert.Equal(t, 3, cache.Len())
+		_, ok := cache.Get("query-0")
+		assert.False(t, ok)
+		_, ok = cache.Get("query-3")
+		assert.True(t, ok)
+	})
+
+	t.Run("zero TTL or size disables caching", func(t *testing.T) {
+		assert.Nil(t, database.NewResultCache(0, 10))
+		assert.Nil(t, database.NewResultCache(time.Minute, 0))
+
+		var cache *database.ResultCache
+		cache.Set("query", 0, records)
+		cache.Clear()
+		_, ok := cache.Get("query")
+		assert.False(t, ok)
+	})
+}
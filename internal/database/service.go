@@ -1,14 +1,19 @@
 package database
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
-	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
@@ -20,6 +25,25 @@ type Neo4jService struct {
 	database        string
 	transportMode   string // Transport mode (stdio or http)
 	neo4jMCPVersion string
+	transactions    *transactionRegistry // Open explicit transactions, shared with ForDatabase copies
+	inFlight        *atomic.Int64        // Queries currently running, shared with ForDatabase copies
+	maxRetries      int                  // Retries of a query failing with a transient error
+	breaker         *circuitBreaker      // Fails queries fast while Neo4j is unreachable, shared with ForDatabase copies
+	readRouting     ReadRouting
+	impersonate     bool         // Run the queries of authenticated callers as their Neo4j user, see SetImpersonation
+	resultCache     *ResultCache // Results of cacheable read queries, shared with ForDatabase copies; nil disables caching
+}
+
+// Activity is a snapshot of the work the service has in progress against Neo4j.
+// The driver does not expose its connection pool, so these counts stand in for its usage.
+type Activity struct {
+	InFlightQueries  int64
+	OpenTransactions int
+}
+
+// Activity returns the queries running and the explicit transactions open on the service
+func (s *Neo4jService) Activity() Activity {
+	return Activity{InFlightQueries: s.inFlight.Load(), OpenTransactions: s.transactions.count()}
 }
 
 // NewNeo4jService creates a new Neo4jService instance
@@ -33,41 +57,69 @@ func NewNeo4jService(driver neo4j.DriverWithContext, database string, transportM
 		database:        database,
 		transportMode:   transportMode,
 		neo4jMCPVersion: neo4jMCPVersion,
+		transactions:    newTransactionRegistry(TransactionIdleTimeout),
+		inFlight:        new(atomic.Int64),
+		maxRetries:      DefaultResilience.MaxRetries,
+		breaker:         newCircuitBreaker(DefaultResilience.BreakerThreshold, DefaultResilience.BreakerCooldown),
 	}, nil
 }
 
 // buildQueryOptions builds Neo4j query options based on transport mode.
 // For HTTP mode: extracts credentials from context and uses impersonation.
-// Note: HTTP mode requires Basic Auth via middleware, so credentials are always present.
 // If credentials are absent, they are not added to the query options (driver defaults apply).
 // For STDIO mode: uses driver's built-in credentials (no auth token added).
+// A tenant in ctx selects the database, and its credentials replace the caller's. See WithTenant.
+// With impersonation enabled, the query runs as the authenticated caller. See SetImpersonation.
 // The baseOptions parameter allows adding routing-specific options (readers/writers).
-// TxMetadata is added to recognize queries coming from Neo4j MCP.
+// TxMetadata is added to recognize queries coming from Neo4j MCP, and a context deadline becomes a transaction timeout.
 func (s *Neo4jService) buildQueryOptions(ctx context.Context, baseOptions ...neo4j.ExecuteQueryConfigurationOption) []neo4j.ExecuteQueryConfigurationOption {
 
-	txMetadata := neo4j.WithTxMetadata(map[string]any{"app": strings.Join([]string{appName, s.neo4jMCPVersion}, "/")})
+	txConfig := []func(*neo4j.TransactionConfig){
+		neo4j.WithTxMetadata(s.txMetadata(ctx)),
+	}
+	// Mirror the context deadline as a transaction timeout, so the server also stops the query
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining > 0 {
+			txConfig = append(txConfig, neo4j.WithTxTimeout(remaining))
+		}
+	}
 
 	queryOptions := []neo4j.ExecuteQueryConfigurationOption{
-		neo4j.ExecuteQueryWithDatabase(s.database),
-		neo4j.ExecuteQueryWithTransactionConfig(txMetadata),
+		neo4j.ExecuteQueryWithDatabase(s.databaseName(ctx)),
+		neo4j.ExecuteQueryWithTransactionConfig(txConfig...),
 	}
 
 	// Add any base options (routing, etc.)
 	queryOptions = append(queryOptions, baseOptions...)
 
-	// For HTTP mode, extract credentials from context and use impersonation
-	if s.transportMode == config.TransportModeHTTP {
-		username, password, hasAuth := auth.GetBasicAuthCredentials(ctx)
-		if hasAuth {
-			authToken := neo4j.BasicAuth(username, password, "")
-			queryOptions = append(queryOptions, neo4j.ExecuteQueryWithAuthToken(authToken))
-		}
+	// For HTTP mode, use the credentials from context
+	if authToken := s.authToken(ctx); authToken != nil {
+		queryOptions = append(queryOptions, neo4j.ExecuteQueryWithAuthToken(*authToken))
 	}
 	// For STDIO mode, driver's built-in credentials are used automatically (no auth token needed)
 
+	if user := s.impersonatedUser(ctx); user != "" {
+		queryOptions = append(queryOptions, neo4j.ExecuteQueryWithImpersonatedUser(user))
+	}
+
 	return queryOptions
 }
 
+// identityMetadataKey is the transaction metadata key attributing a query to the authenticated HTTP caller
+const identityMetadataKey = "mcpIdentity"
+
+// txMetadata identifies queries coming from Neo4j MCP, the caller that sent them, and the single query when ctx carries its tag
+func (s *Neo4jService) txMetadata(ctx context.Context) map[string]any {
+	metadata := map[string]any{"app": strings.Join([]string{appName, s.neo4jMCPVersion}, "/")}
+	if tag, _ := ctx.Value(queryTagKey{}).(string); tag != "" {
+		metadata[queryTagMetadataKey] = tag
+	}
+	if identity, ok := auth.GetIdentity(ctx); ok {
+		metadata[identityMetadataKey] = identity
+	}
+	return metadata
+}
+
 // VerifyConnectivity checks the driver can establish a valid connection with a Neo4j instance;
 func (s *Neo4jService) VerifyConnectivity(ctx context.Context) error {
 	// Verify database connectivity
@@ -78,30 +130,81 @@ func (s *Neo4jService) VerifyConnectivity(ctx context.Context) error {
 	return nil
 }
 
-// GetDatabaseName returns the name of the configured database
-func (s *Neo4jService) GetDatabaseName() string {
-	return s.database
+// GetDatabaseName returns the name of the database the queries of ctx run against
+func (s *Neo4jService) GetDatabaseName(ctx context.Context) string {
+	return s.databaseName(ctx)
 }
 
-// ExecuteReadQuery executes a read-only Cypher query and returns raw records
-func (s *Neo4jService) ExecuteReadQuery(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, error) {
-	queryOptions := s.buildQueryOptions(ctx, neo4j.ExecuteQueryWithReadersRouting())
+// ForDatabase returns a service bound to another database, sharing the driver and its connection pool
+func (s *Neo4jService) ForDatabase(name string) Service {
+	if name == "" || name == s.database {
+		return s
+	}
 
-	res, err := neo4j.ExecuteQuery(ctx, s.driver, cypher, params, neo4j.EagerResultTransformer, queryOptions...)
+	scoped := *s
+	scoped.database = name
+	return &scoped
+}
+
+// ExecuteReadQuery executes a read-only Cypher query and returns raw records.
+// The query runs in a READ access mode transaction, so the server rejects writes, including
+// procedures that write, whatever the query text looks like. See IsAccessModeError.
+// In a cluster it runs where the read routing sends it, see SetReadRouting.
+func (s *Neo4jService) ExecuteReadQuery(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, error) {
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return nil, err
+	}
+	cacheKey, cacheable := s.cacheKey(ctx, cypher, params)
+	generation := s.resultCache.Generation()
+	if cacheable {
+		if records, ok := s.resultCache.Get(cacheKey); ok {
+			slog.Debug("serving read query from the result cache")
+			return records, nil
+		}
+	}
+	ctx, endSpan := s.startQuerySpan(ctx, "ExecuteReadQuery", cypher)
+	res, err := s.executeQuery(ctx, cypher, params, s.readOptions()...)
+	endSpan(resultRows(res), err)
 	if err != nil {
 		wrappedErr := fmt.Errorf("failed to execute read query: %w", err)
 		slog.Error("Error in ExecuteReadQuery", "error", wrappedErr)
 		return nil, wrappedErr
 	}
 
+	if cacheable {
+		s.resultCache.Set(cacheKey, generation, res.Records)
+	}
 	return res.Records, nil
 }
 
+// IsAccessModeError reports whether the server rejected a query for writing in a READ access mode transaction
+func IsAccessModeError(err error) bool {
+	var neo4jErr *neo4j.Neo4jError
+	if !errors.As(err, &neo4jErr) {
+		return false
+	}
+	return neo4jErr.Code == "Neo.ClientError.Statement.AccessMode" || neo4jErr.Code == "Neo.ClientError.Cluster.NotALeader"
+}
+
+// IsForbiddenError reports whether the server denied a query because the user lacks the privilege to run it
+func IsForbiddenError(err error) bool {
+	var neo4jErr *neo4j.Neo4jError
+	if !errors.As(err, &neo4jErr) {
+		return false
+	}
+	return neo4jErr.Code == "Neo.ClientError.Security.Forbidden"
+}
+
 // ExecuteWriteQuery executes a write-only Cypher query and returns raw records
 func (s *Neo4jService) ExecuteWriteQuery(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, error) {
-	queryOptions := s.buildQueryOptions(ctx, neo4j.ExecuteQueryWithWritersRouting())
-
-	res, err := neo4j.ExecuteQuery(ctx, s.driver, cypher, params, neo4j.EagerResultTransformer, queryOptions...)
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return nil, err
+	}
+	ctx, endSpan := s.startQuerySpan(ctx, "ExecuteWriteQuery", cypher)
+	res, err := s.executeQuery(ctx, cypher, params, neo4j.ExecuteQueryWithWritersRouting())
+	endSpan(resultRows(res), err)
+	// A failed write may still have committed, so cached results are dropped either way
+	s.resultCache.Clear()
 	if err != nil {
 		wrappedErr := fmt.Errorf("failed to execute write query: %w", err)
 		slog.Error("Error in ExecuteWriteQuery", "error", wrappedErr)
@@ -114,11 +217,12 @@ func (s *Neo4jService) ExecuteWriteQuery(ctx context.Context, cypher string, par
 // GetQueryType prefixes the provided query with EXPLAIN and returns the query type (e.g. 'r' for read, 'w' for write, 'rw' etc.)
 // This allows read-only tools to determine if a query is safe to run in read-only context.
 func (s *Neo4jService) GetQueryType(ctx context.Context, cypher string, params map[string]any) (neo4j.StatementType, error) {
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return neo4j.StatementTypeUnknown, err
+	}
 	explainedQuery := strings.Join([]string{"EXPLAIN", cypher}, " ")
 
-	queryOptions := s.buildQueryOptions(ctx)
-
-	res, err := neo4j.ExecuteQuery(ctx, s.driver, explainedQuery, params, neo4j.EagerResultTransformer, queryOptions...)
+	res, err := s.executeQuery(ctx, explainedQuery, params)
 	if err != nil {
 		wrappedErr := fmt.Errorf("error during GetQueryType: %w", err)
 		slog.Error("Error during GetQueryType", "error", wrappedErr)
@@ -135,11 +239,14 @@ func (s *Neo4jService) GetQueryType(ctx context.Context, cypher string, params m
 
 }
 
-// Neo4jRecordsToJSON converts Neo4j records to JSON string
-func (s *Neo4jService) Neo4jRecordsToJSON(records []*neo4j.Record) (string, error) {
+// Neo4jRecordsToJSON converts Neo4j records to JSON string.
+// Temporal values are written as ISO-8601 strings and points as GeoJSON, and personal data is masked
+// as configured by WithMasking.
+func (s *Neo4jService) Neo4jRecordsToJSON(ctx context.Context, records []*neo4j.Record) (string, error) {
+	masking := maskingFromContext(ctx)
 	results := make([]map[string]any, 0)
 	for _, record := range records {
-		recordMap := record.AsMap()
+		recordMap := normalizeMap(record.AsMap(), masking)
 		results = append(results, recordMap)
 	}
 
@@ -152,3 +259,68 @@ func (s *Neo4jService) Neo4jRecordsToJSON(records []*neo4j.Record) (string, erro
 
 	return string(formattedResponse), nil
 }
+
+// Neo4jRecordsToCSV converts Neo4j records to delimited text with a header row, e.g. CSV (',') or TSV ('\t').
+// Temporal values are written as ISO-8601 strings; points, nodes, relationships, maps and lists as JSON within their cell.
+// Personal data is masked as configured by WithMasking.
+func (s *Neo4jService) Neo4jRecordsToCSV(ctx context.Context, records []*neo4j.Record, delimiter rune) (string, error) {
+	if len(records) == 0 {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Comma = delimiter
+
+	masking := maskingFromContext(ctx)
+	if err := writer.Write(records[0].Keys); err != nil {
+		return "", fmt.Errorf("failed to format records as CSV: %w", err)
+	}
+	for _, record := range records {
+		row := make([]string, len(record.Values))
+		for i, value := range record.Values {
+			value = normalizeValue(value, masking)
+			if i < len(record.Keys) && masking.masks(record.Keys[i]) {
+				value = masking.mask(value)
+			}
+			cell, err := csvCell(value)
+			if err != nil {
+				wrappedErr := fmt.Errorf("failed to format records as CSV: %w", err)
+				slog.Error("Error in Neo4jRecordsToCSV", "error", wrappedErr)
+				return "", wrappedErr
+			}
+			row[i] = cell
+		}
+		if err := writer.Write(row); err != nil {
+			return "", fmt.Errorf("failed to format records as CSV: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to format records as CSV: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// csvCell formats a single normalized value for a delimited cell
+func csvCell(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,67 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func TestNeo4jService_Neo4jRecordsToCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		records   []*neo4j.Record
+		delimiter rune
+		want      string
+	}{
+		{
+			name:      "empty slice returns empty text",
+			records:   []*neo4j.Record{},
+			delimiter: ',',
+			want:      "",
+		},
+		{
+			name: "header row followed by one row per record",
+			records: []*neo4j.Record{
+				newTestRecord([]string{"name", "amount"}, []any{"Alice", int64(30)}),
+				newTestRecord([]string{"name", "amount"}, []any{"Bob", 12.5}),
+			},
+			delimiter: ',',
+			want:      "name,amount\nAlice,30\nBob,12.5\n",
+		},
+		{
+			name: "values containing the delimiter are quoted",
+			records: []*neo4j.Record{
+				newTestRecord([]string{"address", "flagged"}, []any{"1 Main St, London", true}),
+			},
+			delimiter: ',',
+			want:      "address,flagged\n\"1 Main St, London\",true\n",
+		},
+		{
+			name: "tab delimiter with null, time and nested values",
+			records: []*neo4j.Record{
+				newTestRecord(
+					[]string{"id", "seen", "tags", "note"},
+					[]any{"C1", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), []any{"pep", "high-risk"}, nil},
+				),
+			},
+			delimiter: '\t',
+			want:      "id\tseen\ttags\tnote\nC1\t2024-01-02T03:04:05Z\t\"[\"\"pep\"\",\"\"high-risk\"\"]\"\t\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s database.Neo4jService
+			got, err := s.Neo4jRecordsToCSV(context.Background(), tt.records, tt.delimiter)
+			if err != nil {
+				t.Fatalf("Neo4jRecordsToCSV() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Neo4jRecordsToCSV() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,15 @@
 package database_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
 )
 
 // helper to construct a *neo4j.Record for testing using public fields
@@ -58,7 +63,7 @@ func TestNeo4jService_Neo4jRecordsToJSON(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var s database.Neo4jService
-			got, err := s.Neo4jRecordsToJSON(tt.records)
+			got, err := s.Neo4jRecordsToJSON(context.Background(), tt.records)
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("Neo4jRecordsToJSON() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -68,3 +73,58 @@ func TestNeo4jService_Neo4jRecordsToJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestNeo4jService_Neo4jRecordsToJSON_TemporalAndSpatial(t *testing.T) {
+	at := time.Date(2024, 3, 9, 14, 30, 5, 250000000, time.FixedZone("", 3600))
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "datetime", value: at, want: `"2024-03-09T14:30:05.25+01:00"`},
+		{name: "date", value: dbtype.Date(at), want: `"2024-03-09"`},
+		{name: "time", value: dbtype.Time(at), want: `"14:30:05.25+01:00"`},
+		{name: "local time", value: dbtype.LocalTime(at), want: `"14:30:05.25"`},
+		{name: "local datetime", value: dbtype.LocalDateTime(at), want: `"2024-03-09T14:30:05.25"`},
+		{name: "duration", value: dbtype.Duration{Months: 1, Days: 2, Seconds: 30}, want: `"P1M2DT30S"`},
+		{
+			name:  "2D point as GeoJSON",
+			value: dbtype.Point2D{X: -0.12, Y: 51.5, SpatialRefId: 4326},
+			want:  `{"type":"Point","coordinates":[-0.12,51.5],"srid":4326}`,
+		},
+		{
+			name:  "3D point as GeoJSON",
+			value: dbtype.Point3D{X: 1, Y: 2, Z: 3, SpatialRefId: 9157},
+			want:  `{"type":"Point","coordinates":[1,2,3],"srid":9157}`,
+		},
+		{
+			name:  "values nested in lists and maps",
+			value: map[string]any{"dates": []any{dbtype.Date(at)}},
+			want:  `{"dates":["2024-03-09"]}`,
+		},
+		{
+			name:  "node properties",
+			value: dbtype.Node{ElementId: "4:x:1", Labels: []string{"Transaction"}, Props: map[string]any{"at": at}},
+			want:  `{"Id":0,"ElementId":"4:x:1","Labels":["Transaction"],"Props":{"at":"2024-03-09T14:30:05.25+01:00"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s database.Neo4jService
+			got, err := s.Neo4jRecordsToJSON(context.Background(), []*neo4j.Record{newTestRecord([]string{"v"}, []any{tt.value})})
+			if err != nil {
+				t.Fatalf("Neo4jRecordsToJSON() unexpected error = %v", err)
+			}
+
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, []byte(got)); err != nil {
+				t.Fatalf("Neo4jRecordsToJSON() returned invalid JSON: %v", err)
+			}
+			if want := `[{"v":` + tt.want + `}]`; compact.String() != want {
+				t.Errorf("Neo4jRecordsToJSON() = %s, want %s", compact.String(), want)
+			}
+		})
+	}
+}
@@ -3,11 +3,13 @@ package database_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tenant"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"go.uber.org/mock/gomock"
 )
@@ -128,6 +130,50 @@ func TestNewNeo4jService(t *testing.T) {
 	})
 }
 
+func TestNeo4jService_ForDatabase(t *testing.T) {
+	driver, err := neo4j.NewDriverWithContext("bolt://localhost:7687", neo4j.NoAuth())
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	defer driver.Close(context.Background())
+
+	service, err := database.NewNeo4jService(driver, "neo4j", config.TransportModeStdio, "test-version")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("empty name keeps the configured database", func(t *testing.T) {
+		if service.ForDatabase("") != database.Service(service) {
+			t.Error("expected the same service for an empty database name")
+		}
+	})
+
+	t.Run("other name returns a scoped service", func(t *testing.T) {
+		scoped := service.ForDatabase("fraud")
+
+		if scoped.GetDatabaseName(context.Background()) != "fraud" {
+			t.Errorf("expected database 'fraud', got: %s", scoped.GetDatabaseName(context.Background()))
+		}
+		if service.GetDatabaseName(context.Background()) != "neo4j" {
+			t.Errorf("expected original service to keep 'neo4j', got: %s", service.GetDatabaseName(context.Background()))
+		}
+	})
+
+	t.Run("tenant in context overrides the database", func(t *testing.T) {
+		ctx := database.WithTenant(context.Background(), &tenant.Tenant{Name: "retail", Database: "retail"})
+
+		if name := service.ForDatabase("fraud").GetDatabaseName(ctx); name != "retail" {
+			t.Errorf("expected the tenant's database 'retail', got: %s", name)
+		}
+	})
+
+	t.Run("idle service reports no activity", func(t *testing.T) {
+		if activity := service.Activity(); activity != (database.Activity{}) {
+			t.Errorf("Activity() = %+v, want no queries or transactions", activity)
+		}
+	})
+}
+
 func TestDatabaseService_ExecuteWriteQuery(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -227,3 +273,27 @@ func TestDatabaseService_ExecuteWriteQuery(t *testing.T) {
 		}
 	})
 }
+
+func TestIsAccessModeError(t *testing.T) {
+	accessModeErr := fmt.Errorf("failed to execute read query: %w", &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.AccessMode", Msg: "Writing in read access mode not allowed."})
+	if !database.IsAccessModeError(accessModeErr) {
+		t.Error("expected wrapped access mode error to be detected")
+	}
+
+	syntaxErr := &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.SyntaxError", Msg: "Invalid input"}
+	if database.IsAccessModeError(syntaxErr) || database.IsAccessModeError(errors.New("connection refused")) || database.IsAccessModeError(nil) {
+		t.Error("expected other errors not to be access mode errors")
+	}
+}
+
+func TestIsForbiddenError(t *testing.T) {
+	forbiddenErr := fmt.Errorf("statement failed and the transaction was rolled back: %w", &neo4j.Neo4jError{Code: "Neo.ClientError.Security.Forbidden", Msg: "Create node with labels '' on database 'neo4j' is not allowed for user 'reader'."})
+	if !database.IsForbiddenError(forbiddenErr) {
+		t.Error("expected wrapped forbidden error to be detected")
+	}
+
+	accessModeErr := &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.AccessMode", Msg: "Writing in read access mode not allowed."}
+	if database.IsForbiddenError(accessModeErr) || database.IsForbiddenError(errors.New("connection refused")) || database.IsForbiddenError(nil) {
+		t.Error("expected other errors not to be forbidden errors")
+	}
+}
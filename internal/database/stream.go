@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// RecordSink consumes the records of a streamed query one at a time, as they arrive from the server.
+// An error returned by Accept or Complete aborts the query.
+type RecordSink interface {
+	// Accept receives the next record of the result
+	Accept(record *neo4j.Record) error
+
+	// Complete is called once all records were accepted, with the result columns
+	Complete(keys []string) error
+}
+
+// StreamReadQuery executes a read-only Cypher query like ExecuteReadQuery, but passes the records to a sink
+// instead of returning them, so a result is never held in memory as a whole.
+// newSink is called for each attempt of the query: a retried query starts again with an empty sink.
+func (s *Neo4jService) StreamReadQuery(ctx context.Context, cypher string, params map[string]any, newSink func() RecordSink) error {
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return err
+	}
+	ctx, endSpan := s.startQuerySpan(ctx, "StreamReadQuery", cypher)
+	newTransformer := func() neo4j.ResultTransformer[int] {
+		return &sinkTransformer{sink: newSink()}
+	}
+	rows, err := runQuery(ctx, s, cypher, params, newTransformer, func(rows int) int { return rows }, s.readOptions()...)
+	endSpan(rows, err)
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to execute read query: %w", err)
+		slog.Error("Error in StreamReadQuery", "error", wrappedErr)
+		return wrappedErr
+	}
+	return nil
+}
+
+// sinkTransformer passes the records of a query to a RecordSink, counting them
+type sinkTransformer struct {
+	sink RecordSink
+	rows int
+}
+
+func (t *sinkTransformer) Accept(record *neo4j.Record) error {
+	t.rows++
+	return t.sink.Accept(record)
+}
+
+func (t *sinkTransformer) Complete(keys []string, _ neo4j.ResultSummary) (int, error) {
+	return t.rows, t.sink.Complete(keys)
+}
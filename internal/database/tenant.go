@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tenant"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// tenantKey is the context key of the tenant of a tool call
+type tenantKey struct{}
+
+// WithTenant returns a context whose queries run against the database of t, with its credentials when set.
+// The tenant's database takes precedence over the database of a ForDatabase copy, so a tool call cannot leave it.
+func WithTenant(ctx context.Context, t *tenant.Tenant) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// tenantFromContext returns the tenant of ctx, or nil
+func tenantFromContext(ctx context.Context) *tenant.Tenant {
+	t, _ := ctx.Value(tenantKey{}).(*tenant.Tenant)
+	return t
+}
+
+// databaseName returns the database the queries of ctx run against
+func (s *Neo4jService) databaseName(ctx context.Context) string {
+	if t := tenantFromContext(ctx); t != nil {
+		return t.Database
+	}
+	return s.database
+}
+
+// authToken returns the credentials the queries of ctx run with: the caller's Basic Auth credentials in HTTP mode,
+// else the tenant's credentials when set. Nil uses the driver's own credentials.
+// Basic Auth callers never get tenant credentials, since only Neo4j checks their password.
+func (s *Neo4jService) authToken(ctx context.Context) *neo4j.AuthToken {
+	if config.IsHTTPTransport(s.transportMode) {
+		if username, password, hasAuth := auth.GetBasicAuthCredentials(ctx); hasAuth {
+			authToken := neo4j.BasicAuth(username, password, "")
+			return &authToken
+		}
+	}
+	if t := tenantFromContext(ctx); t != nil && t.Username != "" {
+		authToken := neo4j.BasicAuth(t.Username, t.Password, "")
+		return &authToken
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tracing"
+)
+
+// startQuerySpan traces a query as a child of the tool call span in ctx, if any.
+// Like the query statistics, the span carries a hash of the query rather than its text.
+// The returned function ends the span with the number of rows returned and the query error.
+func (s *Neo4jService) startQuerySpan(ctx context.Context, operation string, cypher string) (context.Context, func(rows int, err error)) {
+	ctx, span := tracing.Start(ctx, operation, tracing.KindClient,
+		tracing.String("db.system.name", "neo4j"),
+		tracing.String("db.namespace", s.databaseName(ctx)),
+		tracing.String("db.operation.name", operation),
+	)
+	if span == nil {
+		return ctx, func(int, error) {}
+	}
+	span.SetAttributes(tracing.String("db.query.hash", HashQuery(cypher)))
+
+	started := time.Now()
+	return ctx, func(rows int, err error) {
+		span.SetAttributes(
+			tracing.Int("db.response.returned_rows", rows),
+			tracing.Float64("db.query.duration_ms", float64(time.Since(started).Microseconds())/1000),
+		)
+		span.RecordError(err)
+		span.End()
+	}
+}
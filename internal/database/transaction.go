@@ -0,0 +1,293 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// TransactionIdleTimeout is how long an explicit transaction may go unused before it is rolled back
+const TransactionIdleTimeout = 5 * time.Minute
+
+// ErrTransactionNotFound is returned for transaction IDs that are unknown, finished, or expired
+var ErrTransactionNotFound = errors.New("transaction not found; it was committed, rolled back, or expired after being idle")
+
+// openTransaction is an explicit transaction kept open between tool calls
+type openTransaction struct {
+	mu       sync.Mutex // Statements of one transaction run one at a time
+	session  neo4j.SessionWithContext
+	tx       neo4j.ExplicitTransaction
+	owner    string    // identity of the HTTP caller that opened the transaction
+	lastUsed time.Time // Guarded by transactionRegistry.mu
+	finished bool      // Set once committed or rolled back, guarded by mu
+}
+
+// transactionRegistry holds the open explicit transactions, shared by all database-scoped services
+type transactionRegistry struct {
+	mu          sync.Mutex
+	open        map[string]*openTransaction
+	idleTimeout time.Duration
+}
+
+func newTransactionRegistry(idleTimeout time.Duration) *transactionRegistry {
+	return &transactionRegistry{
+		open:        make(map[string]*openTransaction),
+		idleTimeout: idleTimeout,
+	}
+}
+
+// BeginTransaction opens an explicit write transaction and returns its ID.
+// The transaction stays open until it is committed, rolled back, or idle for TransactionIdleTimeout.
+func (s *Neo4jService) BeginTransaction(ctx context.Context) (string, error) {
+	s.transactions.expireIdle(ctx)
+
+	sessionConfig := neo4j.SessionConfig{
+		DatabaseName:     s.databaseName(ctx),
+		AccessMode:       neo4j.AccessModeWrite,
+		Auth:             s.authToken(ctx),
+		ImpersonatedUser: s.impersonatedUser(ctx),
+		// Later reads wait for the commit, as they do for the writes of ExecuteQuery
+		BookmarkManager: s.driver.ExecuteQueryBookmarkManager(),
+	}
+	owner, _ := auth.GetIdentity(ctx)
+
+	var session neo4j.SessionWithContext
+	var tx neo4j.ExplicitTransaction
+	err := s.runResilient(ctx, func() error {
+		session = s.driver.NewSession(ctx, sessionConfig)
+		var err error
+		if tx, err = session.BeginTransaction(ctx, neo4j.WithTxMetadata(s.txMetadata(ctx))); err != nil {
+			_ = session.Close(ctx)
+		}
+		return err
+	})
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to begin transaction: %w", err)
+		slog.Error("Error in BeginTransaction", "error", wrappedErr)
+		return "", wrappedErr
+	}
+
+	id, err := newRandomID()
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		_ = session.Close(ctx)
+		return "", err
+	}
+
+	s.transactions.mu.Lock()
+	s.transactions.open[id] = &openTransaction{session: session, tx: tx, owner: owner, lastUsed: time.Now()}
+	s.transactions.mu.Unlock()
+
+	slog.Info("opened explicit transaction", "transactionId", id, "database", sessionConfig.DatabaseName)
+	return id, nil
+}
+
+// RunInTransaction runs a statement in an open transaction and returns raw records with the update counters.
+// A failed statement rolls the transaction back, since Neo4j does not allow it to continue.
+func (s *Neo4jService) RunInTransaction(ctx context.Context, id string, cypher string, params map[string]any) ([]*neo4j.Record, *WriteSummary, error) {
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return nil, nil, err
+	}
+	open, err := s.transactions.get(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	open.mu.Lock()
+	defer open.mu.Unlock()
+	if open.finished {
+		return nil, nil, ErrTransactionNotFound
+	}
+
+	s.inFlight.Add(1)
+	started := time.Now()
+	result, err := open.tx.Run(ctx, cypher, params)
+	var records []*neo4j.Record
+	if err == nil {
+		records, err = result.Collect(ctx)
+	}
+	var summary neo4j.ResultSummary
+	if err == nil {
+		summary, err = result.Consume(ctx)
+	}
+	s.inFlight.Add(-1)
+	s.recordQuery(ctx, cypher, started, len(records), err)
+	if err != nil {
+		_ = s.transactions.finish(ctx, id, open, false)
+		wrappedErr := fmt.Errorf("statement failed and the transaction was rolled back: %w", err)
+		slog.Error("Error in RunInTransaction", "transactionId", id, "error", wrappedErr)
+		return nil, nil, wrappedErr
+	}
+
+	s.transactions.touch(open)
+	writeSummary := NewWriteSummary(summary)
+	return records, &writeSummary, nil
+}
+
+// CommitTransaction commits an open transaction and closes it
+func (s *Neo4jService) CommitTransaction(ctx context.Context, id string) error {
+	open, err := s.transactions.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	open.mu.Lock()
+	defer open.mu.Unlock()
+	if open.finished {
+		return ErrTransactionNotFound
+	}
+
+	err = s.transactions.finish(ctx, id, open, true)
+	s.resultCache.Clear()
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to commit transaction: %w", err)
+		slog.Error("Error in CommitTransaction", "transactionId", id, "error", wrappedErr)
+		return wrappedErr
+	}
+	return nil
+}
+
+// RollbackTransaction rolls back an open transaction and closes it
+func (s *Neo4jService) RollbackTransaction(ctx context.Context, id string) error {
+	open, err := s.transactions.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	open.mu.Lock()
+	defer open.mu.Unlock()
+	if open.finished {
+		return ErrTransactionNotFound
+	}
+
+	if err := s.transactions.finish(ctx, id, open, false); err != nil {
+		wrappedErr := fmt.Errorf("failed to roll back transaction: %w", err)
+		slog.Error("Error in RollbackTransaction", "transactionId", id, "error", wrappedErr)
+		return wrappedErr
+	}
+	return nil
+}
+
+// get returns an open transaction, checking it belongs to the caller in HTTP mode
+func (r *transactionRegistry) get(ctx context.Context, id string) (*openTransaction, error) {
+	r.expireIdle(ctx)
+
+	r.mu.Lock()
+	open, ok := r.open[id]
+	r.mu.Unlock()
+	if !ok {
+		return nil, ErrTransactionNotFound
+	}
+
+	identity, _ := auth.GetIdentity(ctx)
+	if open.owner != "" && open.owner != identity {
+		return nil, ErrTransactionNotFound
+	}
+	return open, nil
+}
+
+// count returns the number of open transactions
+func (r *transactionRegistry) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.open)
+}
+
+// touch restarts the idle timeout of a transaction
+func (r *transactionRegistry) touch(open *openTransaction) {
+	r.mu.Lock()
+	open.lastUsed = time.Now()
+	r.mu.Unlock()
+}
+
+// finish commits or rolls back a transaction, then closes its session and forgets it.
+// The caller must hold open.mu.
+func (r *transactionRegistry) finish(ctx context.Context, id string, open *openTransaction, commit bool) error {
+	r.mu.Lock()
+	delete(r.open, id)
+	r.mu.Unlock()
+	open.finished = true
+
+	var err error
+	if commit {
+		err = open.tx.Commit(ctx)
+	} else {
+		err = open.tx.Rollback(ctx)
+	}
+	if closeErr := open.session.Close(ctx); closeErr != nil {
+		slog.Warn("failed to close transaction session", "transactionId", id, "error", closeErr)
+	}
+	return err
+}
+
+// expireIdle rolls back transactions that have not been used within the idle timeout
+func (r *transactionRegistry) expireIdle(ctx context.Context) {
+	r.mu.Lock()
+	expired := make(map[string]*openTransaction)
+	for id, open := range r.open {
+		if time.Since(open.lastUsed) > r.idleTimeout {
+			expired[id] = open
+		}
+	}
+	r.mu.Unlock()
+
+	for id, open := range expired {
+		// Skip transactions with a statement still running; they are not idle
+		if !open.mu.TryLock() {
+			continue
+		}
+		if open.finished {
+			open.mu.Unlock()
+			continue
+		}
+		slog.Warn("rolling back idle transaction", "transactionId", id)
+		_ = r.finish(context.WithoutCancel(ctx), id, open, false)
+		open.mu.Unlock()
+	}
+}
+
+// RollbackOpenTransactions rolls back every open explicit transaction, so stopping the server does not
+// leave them open until Neo4j times them out. It returns the number rolled back. A transaction with a
+// statement still running is skipped; closing the driver aborts it.
+func (s *Neo4jService) RollbackOpenTransactions(ctx context.Context) int {
+	return s.transactions.rollbackAll(ctx)
+}
+
+// rollbackAll rolls back every open transaction that has no statement running
+func (r *transactionRegistry) rollbackAll(ctx context.Context) int {
+	r.mu.Lock()
+	open := make(map[string]*openTransaction, len(r.open))
+	maps.Copy(open, r.open)
+	r.mu.Unlock()
+
+	rolledBack := 0
+	for id, tx := range open {
+		if !tx.mu.TryLock() {
+			slog.Warn("skipping rollback of a transaction with a statement running", "transactionId", id)
+			continue
+		}
+		if !tx.finished {
+			if err := r.finish(ctx, id, tx, false); err != nil {
+				slog.Warn("failed to roll back transaction", "transactionId", id, "error", err)
+			}
+			rolledBack++
+		}
+		tx.mu.Unlock()
+	}
+	return rolledBack
+}
+
+// newRandomID returns a random hex ID for explicit transactions and query tags
+func newRandomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// WriteSummary reports what a write query changed and how long it took
+type WriteSummary struct {
+	ContainsUpdates      bool  `json:"containsUpdates"`
+	NodesCreated         int   `json:"nodesCreated"`
+	NodesDeleted         int   `json:"nodesDeleted"`
+	RelationshipsCreated int   `json:"relationshipsCreated"`
+	RelationshipsDeleted int   `json:"relationshipsDeleted"`
+	PropertiesSet        int   `json:"propertiesSet"`
+	LabelsAdded          int   `json:"labelsAdded"`
+	LabelsRemoved        int   `json:"labelsRemoved"`
+	IndexesAdded         int   `json:"indexesAdded"`
+	IndexesRemoved       int   `json:"indexesRemoved"`
+	ConstraintsAdded     int   `json:"constraintsAdded"`
+	ConstraintsRemoved   int   `json:"constraintsRemoved"`
+	SystemUpdates        int   `json:"systemUpdates"`
+	ExecutionTimeMs      int64 `json:"executionTimeMs"` // Time until the first record was available plus time to consume the result
+}
+
+// ExecuteWriteQueryWithSummary executes a write Cypher query and returns raw records with the update counters
+func (s *Neo4jService) ExecuteWriteQueryWithSummary(ctx context.Context, cypher string, params map[string]any) ([]*neo4j.Record, *WriteSummary, error) {
+	if err := checkQueryPolicy(ctx, cypher); err != nil {
+		return nil, nil, err
+	}
+	ctx, endSpan := s.startQuerySpan(ctx, "ExecuteWriteQuery", cypher)
+	res, err := s.executeQuery(ctx, cypher, params, neo4j.ExecuteQueryWithWritersRouting())
+	endSpan(resultRows(res), err)
+	s.resultCache.Clear()
+	if err != nil {
+		wrappedErr := fmt.Errorf("failed to execute write query: %w", err)
+		slog.Error("Error in ExecuteWriteQueryWithSummary", "error", wrappedErr)
+		return nil, nil, wrappedErr
+	}
+
+	if res.Summary == nil {
+		return res.Records, nil, nil
+	}
+	summary := NewWriteSummary(res.Summary)
+	return res.Records, &summary, nil
+}
+
+// NewWriteSummary converts a driver result summary into a WriteSummary
+func NewWriteSummary(summary neo4j.ResultSummary) WriteSummary {
+	counters := summary.Counters()
+	return WriteSummary{
+		ContainsUpdates:      counters.ContainsUpdates() || counters.ContainsSystemUpdates(),
+		NodesCreated:         counters.NodesCreated(),
+		NodesDeleted:         counters.NodesDeleted(),
+		RelationshipsCreated: counters.RelationshipsCreated(),
+		RelationshipsDeleted: counters.RelationshipsDeleted(),
+		PropertiesSet:        counters.PropertiesSet(),
+		LabelsAdded:          counters.LabelsAdded(),
+		LabelsRemoved:        counters.LabelsRemoved(),
+		IndexesAdded:         counters.IndexesAdded(),
+		IndexesRemoved:       counters.IndexesRemoved(),
+		ConstraintsAdded:     counters.ConstraintsAdded(),
+		ConstraintsRemoved:   counters.ConstraintsRemoved(),
+		SystemUpdates:        counters.SystemUpdates(),
+		ExecutionTimeMs:      (summary.ResultAvailableAfter() + summary.ResultConsumedAfter()).Milliseconds(),
+	}
+}
@@ -0,0 +1,258 @@
+// Package policy decides which Cypher queries tools may run. Operators list allow and deny rules,
+// matched by regular expression or by a check of the query's structure, ahead of built-in rules
+// blocking the statements an agent should never issue on a fraud database.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Rule actions
+const (
+	ActionAllow = "allow"
+	ActionDeny  = "deny"
+)
+
+// Rule allows or denies the queries matching its pattern
+type Rule struct {
+	Name    string   `json:"name"`
+	Action  string   `json:"action,omitempty"`  // Deny when empty
+	Pattern string   `json:"pattern"`           // Case-insensitive regular expression
+	Message string   `json:"message,omitempty"` // Reported to the caller when the rule denies a query
+	Tools   []string `json:"tools,omitempty"`   // Tools the rule applies to; all tools when empty
+
+	pattern *regexp.Regexp
+	match   func(query string) bool // Structural check of built-in rules, instead of a pattern
+}
+
+// File is the policy file operators configure
+type File struct {
+	DefaultAction string   `json:"defaultAction,omitempty"` // Action for queries no rule matches; allow when empty
+	DisabledRules []string `json:"disabledRules,omitempty"` // Names of built-in rules to leave out
+	Rules         []Rule   `json:"rules,omitempty"`
+}
+
+// Policy checks queries against its rules in order; the first matching rule decides
+type Policy struct {
+	rules         []*Rule
+	defaultAction string
+}
+
+// Violation describes a query denied by the policy
+type Violation struct {
+	Code    string `json:"error"` // Always ViolationError
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Tool    string `json:"tool,omitempty"`
+}
+
+// ViolationError is the error code of a Violation
+const ViolationError = "query_policy_violation"
+
+// BuiltinRules returns the rules applied after the operator's rules, so operators can allow exceptions to them
+func BuiltinRules() []Rule {
+	return []Rule{
+		{
+			Name:    "unfiltered-detach-delete",
+			Message: "DETACH DELETE must be restricted by a WHERE clause or a property map, so it cannot delete every node of a pattern",
+			match:   isUnfilteredDetachDelete,
+		},
+		{
+			Name:    "load-csv",
+			Pattern: `\bLOAD\s+CSV\b`,
+			Message: "LOAD CSV is not permitted, since it reads files and URLs from the database server",
+		},
+		{
+			Name:    "apoc-periodic",
+			Pattern: `\bapoc\.periodic\.\w+`,
+			Message: "apoc.periodic procedures are not permitted, since they run unbounded background batches",
+		},
+		{
+			Name:    "admin-procedures",
+			Pattern: `\b(dbms\.(security|setConfigValue|kill|cluster|scheduler|quarantineDatabase|upgrade)\w*|db\.(clearQueryCaches|checkpoint|stats\.(clear|collect|stop))\b)`,
+			Message: "database administration procedures are not permitted",
+		},
+		{
+			Name:    "admin-commands",
+			Pattern: `^\s*(USE\s+\S+\s+)?((CREATE|DROP|ALTER|RENAME|START|STOP)\s+(OR\s+REPLACE\s+)?(COMPOSITE\s+)?(USER|ROLE|DATABASE|ALIAS|SERVER)\b|(GRANT|DENY|REVOKE|DEALLOCATE|REALLOCATE)\b|TERMINATE\s+TRANSACTIONS?\b)`,
+			Message: "administration commands are not permitted",
+		},
+	}
+}
+
+// Load reads the policy file at path; an empty path applies only the built-in rules
+func Load(path string) (*Policy, error) {
+	var file File
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read query policy %s: %w", path, err)
+		}
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse query policy %s: %w", path, err)
+		}
+	}
+	return New(file)
+}
+
+// New builds the policy of file
+func New(file File) (*Policy, error) {
+	policy := &Policy{defaultAction: file.DefaultAction}
+	if policy.defaultAction == "" {
+		policy.defaultAction = ActionAllow
+	}
+	if policy.defaultAction != ActionAllow && policy.defaultAction != ActionDeny {
+		return nil, fmt.Errorf("invalid defaultAction '%s', must be %s or %s", file.DefaultAction, ActionAllow, ActionDeny)
+	}
+
+	builtins := BuiltinRules()
+	for _, name := range file.DisabledRules {
+		if !slices.ContainsFunc(builtins, func(rule Rule) bool { return rule.Name == name }) {
+			return nil, fmt.Errorf("unknown built-in rule '%s' in disabledRules", name)
+		}
+	}
+
+	for i, rule := range file.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i+1)
+		}
+		if rule.Action == "" {
+			rule.Action = ActionDeny
+		}
+		if rule.Action != ActionAllow && rule.Action != ActionDeny {
+			return nil, fmt.Errorf("rule %s has invalid action '%s', must be %s or %s", rule.Name, rule.Action, ActionAllow, ActionDeny)
+		}
+		if err := rule.compile(); err != nil {
+			return nil, err
+		}
+		policy.rules = append(policy.rules, &rule)
+	}
+	for _, rule := range builtins {
+		if slices.Contains(file.DisabledRules, rule.Name) {
+			continue
+		}
+		rule.Action = ActionDeny
+		if err := rule.compile(); err != nil {
+			return nil, err
+		}
+		policy.rules = append(policy.rules, &rule)
+	}
+	return policy, nil
+}
+
+func (r *Rule) compile() error {
+	if r.match != nil {
+		return nil
+	}
+	if r.Pattern == "" {
+		return fmt.Errorf("rule %s has no pattern", r.Name)
+	}
+	pattern, err := regexp.Compile("(?is)" + r.Pattern)
+	if err != nil {
+		return fmt.Errorf("rule %s has an invalid pattern: %w", r.Name, err)
+	}
+	r.pattern = pattern
+	return nil
+}
+
+// Check returns the violation of a query run by tool, or nil when the policy allows it
+func (p *Policy) Check(tool string, query string) *Violation {
+	if p == nil {
+		return nil
+	}
+
+	normalized := normalizeQuery(query)
+	for _, rule := range p.rules {
+		if len(rule.Tools) > 0 && !slices.Contains(rule.Tools, tool) {
+			continue
+		}
+		if !rule.matches(normalized) {
+			continue
+		}
+		if rule.Action == ActionAllow {
+			return nil
+		}
+		message := rule.Message
+		if message == "" {
+			message = fmt.Sprintf("query denied by policy rule %s", rule.Name)
+		}
+		return &Violation{Code: ViolationError, Rule: rule.Name, Message: message, Tool: tool}
+	}
+
+	if p.defaultAction == ActionDeny {
+		return &Violation{Code: ViolationError, Rule: "default", Message: "query does not match any rule of the allowlist", Tool: tool}
+	}
+	return nil
+}
+
+func (r *Rule) matches(query string) bool {
+	if r.match != nil {
+		return r.match(query)
+	}
+	return r.pattern.MatchString(query)
+}
+
+var (
+	detachDeletePattern = regexp.MustCompile(`(?i)\bDETACH\s+DELETE\b`)
+	wherePattern        = regexp.MustCompile(`(?i)\bWHERE\b`)
+)
+
+// isUnfilteredDetachDelete reports whether a query detach-deletes nodes it matched without a WHERE
+// clause or a property map, e.g. MATCH (n:Customer) DETACH DELETE n
+func isUnfilteredDetachDelete(query string) bool {
+	location := detachDeletePattern.FindStringIndex(query)
+	if location == nil {
+		return false
+	}
+	filters := query[:location[0]]
+	return !wherePattern.MatchString(filters) && !strings.Contains(filters, "{")
+}
+
+// normalizeQuery replaces comments with spaces and removes the backticks around quoted names,
+// so neither can split a keyword or procedure name to slip past a rule. String literals are kept.
+func normalizeQuery(query string) string {
+	runes := []rune(query)
+	var normalized strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			normalized.WriteString(string(runes[i:min(end+1, len(runes))]))
+			i = end
+		case r == '`':
+			// Quoted names are matched without their backticks
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			normalized.WriteRune(' ')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && (runes[i] != '*' || i+1 >= len(runes) || runes[i+1] != '/') {
+				i++
+			}
+			i++ // Closing slash
+			normalized.WriteRune(' ')
+		default:
+			normalized.WriteRune(r)
+		}
+	}
+	return normalized.String()
+}
+
+// Error implements error
+func (v *Violation) Error() string {
+	return fmt.Sprintf("query denied by policy rule %s: %s", v.Rule, v.Message)
+}
@@ -0,0 +1,153 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPolicy_BuiltinRules(t *testing.T) {
+	p, err := New(File{})
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantRule string
+	}{
+		{name: "read query", query: "MATCH (c:Customer) RETURN c LIMIT 10"},
+		{name: "filtered detach delete", query: "MATCH (c:Case) WHERE c.id = $id DETACH DELETE c"},
+		{name: "detach delete by property map", query: "MATCH (c:Case {id: $id}) DETACH DELETE c"},
+		{name: "unfiltered detach delete", query: "MATCH (n) DETACH DELETE n", wantRule: "unfiltered-detach-delete"},
+		{name: "unfiltered detach delete of a label", query: "match (c:Customer)\ndetach   delete c", wantRule: "unfiltered-detach-delete"},
+		{name: "load csv", query: "LOAD CSV WITH HEADERS FROM 'file:///customers.csv' AS row CREATE (:Customer {id: row.id})", wantRule: "load-csv"},
+		{name: "load csv split by a comment", query: "LOAD/* hidden */CSV FROM 'https://example.com/x.csv' AS row RETURN row", wantRule: "load-csv"},
+		{name: "apoc periodic iterate", query: "CALL apoc.periodic.iterate('MATCH (n) RETURN n', 'SET n.flag = true', {batchSize: 1000})", wantRule: "apoc-periodic"},
+		{name: "apoc periodic with quoted names", query: "CALL `apoc`.`periodic`.`commit`('MATCH (n) RETURN n')", wantRule: "apoc-periodic"},
+		{name: "admin procedure", query: "CALL dbms.security.createUser('mallory', 'secret', false)", wantRule: "admin-procedures"},
+		{name: "config procedure", query: "CALL dbms.setConfigValue('db.logs.query.enabled', 'OFF')", wantRule: "admin-procedures"},
+		{name: "dbms components is allowed", query: "CALL dbms.components()"},
+		{name: "schema procedures are allowed", query: "CALL db.schema.visualization()"},
+		{name: "admin command", query: "CREATE USER mallory SET PASSWORD 'secret'", wantRule: "admin-commands"},
+		{name: "grant", query: "GRANT ROLE admin TO mallory", wantRule: "admin-commands"},
+		{name: "drop database", query: "USE system DROP DATABASE neo4j", wantRule: "admin-commands"},
+		{name: "terminate transactions", query: "TERMINATE TRANSACTIONS 'neo4j-transaction-1'", wantRule: "admin-commands"},
+		{name: "create node is allowed", query: "CREATE (c:Case {id: $id})"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violation := p.Check("write-cypher", tt.query)
+			if tt.wantRule == "" {
+				if violation != nil {
+					t.Errorf("Check() = %+v, want allowed", violation)
+				}
+				return
+			}
+			if violation == nil || violation.Rule != tt.wantRule {
+				t.Fatalf("Check() = %+v, want rule %s", violation, tt.wantRule)
+			}
+			if violation.Code != ViolationError || violation.Tool != "write-cypher" || violation.Message == "" {
+				t.Errorf("unexpected violation %+v", violation)
+			}
+		})
+	}
+}
+
+func TestPolicy_OperatorRules(t *testing.T) {
+	p, err := New(File{
+		DisabledRules: []string{"load-csv"},
+		Rules: []Rule{
+			{Name: "allow-case-cleanup", Action: ActionAllow, Pattern: `^\s*MATCH \(c:Case\) DETACH DELETE c\s*$`, Tools: []string{"write-cypher"}},
+			{Name: "no-ssn", Pattern: `\.ssn\b`, Message: "SSNs may not be queried"},
+			{Name: "no-writes-in-read-cypher", Pattern: `\b(CREATE|MERGE|SET)\b`, Tools: []string{"read-cypher"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+
+	if v := p.Check("write-cypher", "MATCH (c:Case) DETACH DELETE c"); v != nil {
+		t.Errorf("expected the allow rule to exempt the query from the built-in rules, got %+v", v)
+	}
+	if v := p.Check("batch-cypher", "MATCH (c:Case) DETACH DELETE c"); v == nil || v.Rule != "unfiltered-detach-delete" {
+		t.Errorf("expected the allow rule to apply to write-cypher only, got %+v", v)
+	}
+	if v := p.Check("read-cypher", "MATCH (c:Customer) RETURN c.ssn"); v == nil || v.Rule != "no-ssn" || v.Message != "SSNs may not be queried" {
+		t.Errorf("expected the deny rule to match, got %+v", v)
+	}
+	if v := p.Check("read-cypher", "MATCH (c:Customer) SET c.flag = true"); v == nil || v.Rule != "no-writes-in-read-cypher" || !strings.Contains(v.Message, "no-writes-in-read-cypher") {
+		t.Errorf("expected the tool rule to match with a default message, got %+v", v)
+	}
+	if v := p.Check("write-cypher", "MATCH (c:Customer) SET c.flag = true"); v != nil {
+		t.Errorf("expected the tool rule to only apply to read-cypher, got %+v", v)
+	}
+	if v := p.Check("write-cypher", "LOAD CSV FROM 'file:///x.csv' AS row RETURN row"); v != nil {
+		t.Errorf("expected the disabled built-in rule to be skipped, got %+v", v)
+	}
+}
+
+func TestPolicy_Allowlist(t *testing.T) {
+	p, err := New(File{
+		DefaultAction: ActionDeny,
+		Rules:         []Rule{{Name: "customer-reads", Action: ActionAllow, Pattern: `^\s*MATCH \(c:Customer`}},
+	})
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+	if v := p.Check("read-cypher", "MATCH (c:Customer {id: $id}) RETURN c"); v != nil {
+		t.Errorf("expected the allowlisted query to pass, got %+v", v)
+	}
+	if v := p.Check("read-cypher", "MATCH (a:Account) RETURN a"); v == nil || v.Rule != "default" {
+		t.Errorf("expected queries outside the allowlist to be denied, got %+v", v)
+	}
+}
+
+func TestNew_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    File
+		wantErr string
+	}{
+		{name: "default action", file: File{DefaultAction: "block"}, wantErr: "invalid defaultAction"},
+		{name: "unknown built-in", file: File{DisabledRules: []string{"load-json"}}, wantErr: "unknown built-in rule 'load-json'"},
+		{name: "unnamed rule", file: File{Rules: []Rule{{Pattern: "x"}}}, wantErr: "rule 1 has no name"},
+		{name: "rule action", file: File{Rules: []Rule{{Name: "r", Action: "log", Pattern: "x"}}}, wantErr: "invalid action 'log'"},
+		{name: "missing pattern", file: File{Rules: []Rule{{Name: "r"}}}, wantErr: "rule r has no pattern"},
+		{name: "invalid pattern", file: File{Rules: []Rule{{Name: "r", Pattern: "("}}}, wantErr: "rule r has an invalid pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.file); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"rules": [{"name": "no-ssn", "pattern": "\\.ssn\\b"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+	if v := p.Check("read-cypher", "MATCH (c) RETURN c.ssn"); v == nil || v.Rule != "no-ssn" {
+		t.Errorf("expected the rule of the file to apply, got %+v", v)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"rules": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "failed to parse query policy") {
+		t.Errorf("Load() error = %v, want a parse error", err)
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
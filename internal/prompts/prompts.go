@@ -0,0 +1,67 @@
+// Package prompts serves the MCP prompts that guide clients through common fraud workflows.
+package prompts
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"text/template"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+//go:embed templates/*.md
+var templateFiles embed.FS
+
+var templates = template.Must(template.New("prompts").Option("missingkey=zero").ParseFS(templateFiles, "templates/*.md"))
+
+// Prompts returns every prompt with its handler
+func Prompts() []server.ServerPrompt {
+	return []server.ServerPrompt{
+		{
+			Prompt: mcp.NewPrompt("investigation-qualification",
+				mcp.WithPromptDescription("Questions that establish the scope, suspected typology and reporting obligations of a fraud investigation before tools are run"),
+				mcp.WithArgument("entityId", mcp.ArgumentDescription("Optional: ID of the entity under review")),
+				mcp.WithArgument("allegation", mcp.ArgumentDescription("Optional: the reported concern or alert text")),
+			),
+			Handler: handler("Qualify a fraud investigation", "investigation_qualification.md"),
+		},
+		{
+			Prompt: mcp.NewPrompt("sar-drafting",
+				mcp.WithPromptDescription("Step-by-step instructions for drafting a Suspicious Activity Report with the SAR tools"),
+				mcp.WithArgument("subjectId", mcp.ArgumentDescription("ID of the SAR subject"), mcp.RequiredArgument()),
+				mcp.WithArgument("activityType", mcp.ArgumentDescription("Optional: suspected activity, e.g. structuring or synthetic identity fraud")),
+			),
+			Handler: handler("Draft a Suspicious Activity Report", "sar_drafting.md", "subjectId"),
+		},
+		{
+			Prompt: mcp.NewPrompt("schema-mapping",
+				mcp.WithPromptDescription("Instructions for mapping the database schema to the entity, attribute and transaction inputs of the fraud tools"),
+				mcp.WithArgument("nodeLabel", mcp.ArgumentDescription("Optional: entity node label to start from, e.g. Customer")),
+			),
+			Handler: handler("Map the schema to fraud tool inputs", "schema_mapping.md"),
+		},
+	}
+}
+
+// handler renders a template with the prompt arguments as a single user message
+func handler(description, templateName string, required ...string) server.PromptHandlerFunc {
+	return func(_ context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		for _, name := range required {
+			if request.Params.Arguments[name] == "" {
+				return nil, fmt.Errorf("%s argument is required", name)
+			}
+		}
+
+		var text bytes.Buffer
+		if err := templates.ExecuteTemplate(&text, templateName, request.Params.Arguments); err != nil {
+			return nil, fmt.Errorf("failed to render prompt %s: %w", request.Params.Name, err)
+		}
+
+		return mcp.NewGetPromptResult(description, []mcp.PromptMessage{
+			mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text.String())),
+		}), nil
+	}
+}
@@ -0,0 +1,62 @@
+package prompts_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/prompts"
+)
+
+func getPrompt(t *testing.T, name string, arguments map[string]string) (*mcp.GetPromptResult, error) {
+	t.Helper()
+	for _, prompt := range prompts.Prompts() {
+		if prompt.Prompt.Name == name {
+			request := mcp.GetPromptRequest{}
+			request.Params.Name = name
+			request.Params.Arguments = arguments
+			return prompt.Handler(context.Background(), request)
+		}
+	}
+	t.Fatalf("prompt %s is not registered", name)
+	return nil, nil
+}
+
+func TestPrompts(t *testing.T) {
+	t.Run("renders every prompt without arguments", func(t *testing.T) {
+		for _, prompt := range prompts.Prompts() {
+			if prompt.Prompt.Name == "sar-drafting" {
+				continue
+			}
+			result, err := getPrompt(t, prompt.Prompt.Name, nil)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", prompt.Prompt.Name, err)
+			}
+			text := result.Messages[0].Content.(mcp.TextContent).Text
+			if strings.Contains(text, "<no value>") || strings.Contains(text, "``") {
+				t.Errorf("%s: expected optional arguments to be left out, got: %s", prompt.Prompt.Name, text)
+			}
+		}
+	})
+
+	t.Run("substitutes arguments", func(t *testing.T) {
+		result, err := getPrompt(t, "sar-drafting", map[string]string{"subjectId": "C-42", "activityType": "structuring"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		text := result.Messages[0].Content.(mcp.TextContent).Text
+		if !strings.Contains(text, "subject `C-42` about suspected structuring") {
+			t.Errorf("expected the subject and activity in the prompt, got: %s", text)
+		}
+		if result.Messages[0].Role != mcp.RoleUser {
+			t.Errorf("expected a user message, got role %s", result.Messages[0].Role)
+		}
+	})
+
+	t.Run("missing required argument", func(t *testing.T) {
+		if _, err := getPrompt(t, "sar-drafting", map[string]string{}); err == nil {
+			t.Error("expected an error without subjectId")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/audit"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+)
+
+// toolAuditor writes an audit entry for every tool call, including the calls denied by access control
+// or rate limiting. The log is opened when the server starts, so a log that cannot be written stops
+// the server from starting rather than running unaudited.
+type toolAuditor struct {
+	destination  string
+	redactFields []string
+	logger       *audit.Logger
+}
+
+// newToolAuditor returns an auditor, or nil when no audit log is configured
+func newToolAuditor(cfg *config.Config) *toolAuditor {
+	if cfg.AuditLog == "" {
+		return nil
+	}
+	redactFields := append([]string(nil), audit.DefaultRedactedFields...)
+	for _, field := range strings.Split(cfg.AuditRedactFields, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			redactFields = append(redactFields, field)
+		}
+	}
+	return &toolAuditor{destination: cfg.AuditLog, redactFields: redactFields}
+}
+
+// open opens the audit log
+func (a *toolAuditor) open() error {
+	logger, err := audit.Open(a.destination, a.redactFields)
+	if err != nil {
+		return err
+	}
+	a.logger = logger
+	slog.Info("Audit logging enabled", "destination", a.destination)
+	return nil
+}
+
+// close closes the audit log
+func (a *toolAuditor) close() {
+	if a.logger == nil {
+		return
+	}
+	if err := a.logger.Close(); err != nil {
+		slog.Error("Error closing audit log", "error", err)
+	}
+}
+
+// middleware records the caller, arguments, queries and outcome of each tool call
+func (a *toolAuditor) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if a.logger == nil {
+			return next(ctx, request)
+		}
+
+		queryLog := &database.QueryLog{}
+		started := time.Now()
+		result, err := next(database.WithQueryLog(ctx, queryLog), request)
+
+		entry := audit.Entry{
+			Time:       started.UTC(),
+			Tool:       request.Params.Name,
+			Arguments:  request.GetArguments(),
+			DurationMs: float64(time.Since(started).Microseconds()) / 1000,
+			Status:     audit.StatusOK,
+		}
+		entry.Identity, _ = auth.GetIdentity(ctx)
+		if session := server.ClientSessionFromContext(ctx); session != nil {
+			entry.SessionID = session.SessionID()
+		}
+		for _, query := range queryLog.Queries() {
+			entry.Queries = append(entry.Queries, audit.Query{
+				Cypher:     query.Cypher,
+				Database:   query.Database,
+				Rows:       query.Rows,
+				DurationMs: query.DurationMs,
+				Failed:     query.Failed,
+			})
+			entry.Rows += query.Rows
+		}
+		switch {
+		case err != nil:
+			entry.Status, entry.Error = audit.StatusError, err.Error()
+		case result != nil && result.IsError:
+			entry.Status, entry.Error = audit.StatusError, toolResultError(result).Error()
+		}
+
+		if logErr := a.logger.Log(entry); logErr != nil {
+			slog.Error("Failed to write audit entry", "tool", request.Params.Name, "error", logErr)
+		}
+		return result, err
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/audit"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+)
+
+func readAuditEntries(t *testing.T, path string) []audit.Entry {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	var entries []audit.Entry
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var entry audit.Entry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid audit line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestToolAuditor_Middleware(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditor := newToolAuditor(&config.Config{AuditLog: path, AuditRedactFields: "customerName"})
+	if err := auditor.open(); err != nil {
+		t.Fatalf("open() unexpected error = %v", err)
+	}
+
+	handler := auditor.middleware(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Name == "get-customer-profile" {
+			return mcp.NewToolResultError("customer not found"), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	ctx := auth.WithIdentity(context.Background(), "alice")
+	for _, name := range []string{"read-cypher", "get-customer-profile"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		request.Params.Arguments = map[string]any{"customerId": "C-1001", "customerName": "Alice", "email": "alice@example.com"}
+		_, _ = handler(ctx, request)
+	}
+	auditor.close()
+
+	entries := readAuditEntries(t, path)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(entries))
+	}
+	ok, failed := entries[0], entries[1]
+	if ok.Tool != "read-cypher" || ok.Identity != "alice" || ok.Status != audit.StatusOK || ok.Time.IsZero() {
+		t.Errorf("unexpected entry %+v", ok)
+	}
+	if ok.Arguments["customerId"] != "C-1001" || ok.Arguments["customerName"] != audit.Redacted || ok.Arguments["email"] != audit.Redacted {
+		t.Errorf("expected default and configured fields to be redacted, got %v", ok.Arguments)
+	}
+	if failed.Status != audit.StatusError || failed.Error != "customer not found" {
+		t.Errorf("expected the tool error to be audited, got %+v", failed)
+	}
+}
+
+func TestToolAuditor_AuditsDeniedCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	s := newRBACServer(t, &config.Config{Roles: "alice=analyst", AuditLog: path})
+	if err := s.auditor.open(); err != nil {
+		t.Fatalf("open() unexpected error = %v", err)
+	}
+
+	ctx := auth.WithIdentity(context.Background(), "alice")
+	s.MCPServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"write-cypher","arguments":{"query":"CREATE (n {ssn: '123-45-6789'})"}}}`))
+	s.auditor.close()
+
+	entries := readAuditEntries(t, path)
+	if len(entries) != 1 || entries[0].Status != audit.StatusError || !strings.Contains(entries[0].Error, "not permitted") {
+		t.Errorf("expected the denied call to be audited, got %+v", entries)
+	}
+	if entries[0].Arguments["query"] != "CREATE (n {ssn: ?})" || entries[0].Identity != "alice" {
+		t.Errorf("unexpected entry %+v", entries[0])
+	}
+}
+
+func TestNewToolAuditor_Disabled(t *testing.T) {
+	if newToolAuditor(&config.Config{}) != nil {
+		t.Error("expected no auditor without an audit log")
+	}
+}
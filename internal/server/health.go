@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	healthzPath        = "/healthz"      // Liveness probe
+	readyzPath         = "/readyz"       // Readiness probe
+	healthCheckTimeout = 5 * time.Second // Maximum time the readiness checks may take
+
+	readinessErrorMessage = "check failed, see the server log for details"
+)
+
+// healthHandler serves the liveness and readiness probes next to the MCP handler.
+// The probes bypass authentication, since orchestrators such as Kubernetes call them without credentials.
+func (s *Neo4jMCPServer) healthHandler(mcpHandler http.Handler) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+healthzPath, s.handleHealthz)
+	mux.HandleFunc("GET "+readyzPath, s.handleReadyz)
+	mux.Handle("/", mcpHandler)
+	return mux
+}
+
+// handleHealthz reports the process is alive; it does not depend on Neo4j,
+// so an unreachable database does not get the server restarted
+func (s *Neo4jMCPServer) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	writeHealthResponse(w, http.StatusOK, map[string]string{"status": tools.HealthOK})
+}
+
+// handleReadyz reports whether the server can serve tool calls: Neo4j is reachable and tools are loaded.
+// Neo4j is only checked when the server holds its own credentials, since probes carry none.
+// Probes carry no identity either, so every enabled tool is counted regardless of role-based access control.
+func (s *Neo4jMCPServer) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	// A shutting down server rejects tool calls, so load balancers should stop routing to it
+	if s.toolCalls.isClosed() {
+		writeHealthResponse(w, http.StatusServiceUnavailable, map[string]string{"status": shutdownStatus})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	deps := s.toolDependencies()
+	report := tools.CheckHealth(ctx, deps, tools.HealthOptions{QueryDatabase: deps.ServiceCredentials, AllTools: true})
+
+	status := http.StatusOK
+	if !report.Healthy() {
+		slog.Warn("Readiness check failed", "checks", report.Checks)
+		status = http.StatusServiceUnavailable
+	}
+	// The probe is unauthenticated, so Neo4j error details stay in the server log
+	for i, check := range report.Checks {
+		if check.Status == tools.HealthError {
+			report.Checks[i].Message = readinessErrorMessage
+		}
+	}
+	writeHealthResponse(w, status, report)
+}
+
+func writeHealthResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		slog.Error("Error writing health response", "error", err)
+	}
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	newServer := func(cfg *config.Config, dbService *db.MockService) http.Handler {
+		cfg.URI = "bolt://test-host:7687"
+		cfg.TransportMode = config.TransportModeHTTP
+		s := NewNeo4jMCPServer("test-version", cfg, dbService, nil)
+		if err := s.registerTools(); err != nil {
+			t.Fatalf("registerTools() failed: %v", err)
+		}
+		mcpHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		})
+		return s.healthHandler(mcpHandler)
+	}
+	serve := func(handler http.Handler, method, path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec
+	}
+
+	t.Run("liveness does not touch Neo4j", func(t *testing.T) {
+		rec := serve(newServer(&config.Config{}, db.NewMockService(ctrl)), http.MethodGet, "/healthz")
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", rec.Code)
+		}
+	})
+
+	t.Run("readiness skips Neo4j with per-request credentials", func(t *testing.T) {
+		rec := serve(newServer(&config.Config{}, db.NewMockService(ctrl)), http.MethodGet, "/readyz")
+		if rec.Code != http.StatusOK {
+			t.Errorf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("readiness checks Neo4j with service credentials", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Return(errors.New("connection refused"))
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 AS ok", gomock.Any()).Return(nil, errors.New("connection refused"))
+		cfg := &config.Config{HTTPAuthMode: config.HTTPAuthAPIKey, Username: "svc", Password: "secret"}
+
+		rec := serve(newServer(cfg, mockDB), http.MethodGet, "/readyz")
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d", rec.Code)
+		}
+		var report tools.HealthReport
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || report.Status != tools.HealthError {
+			t.Errorf("Expected an error health report, got %s", rec.Body.String())
+		}
+		if strings.Contains(rec.Body.String(), "connection refused") {
+			t.Errorf("Expected Neo4j errors to stay out of the unauthenticated response, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("readiness counts every tool with role-based access control", func(t *testing.T) {
+		s := newRBACServer(t, &config.Config{Roles: "alice=analyst"})
+
+		rec := serve(s.healthHandler(http.NotFoundHandler()), http.MethodGet, "/readyz")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		var report tools.HealthReport
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || report.Tools == nil || report.Tools.Enabled == 0 {
+			t.Errorf("Expected the enabled tools of the server, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("readiness fails while shutting down", func(t *testing.T) {
+		s := NewNeo4jMCPServer("test-version", &config.Config{URI: "bolt://test-host:7687"}, db.NewMockService(ctrl), nil)
+		s.toolCalls.close()
+
+		rec := serve(s.healthHandler(http.NotFoundHandler()), http.MethodGet, "/readyz")
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d", rec.Code)
+		}
+	})
+
+	t.Run("other requests reach the MCP handler", func(t *testing.T) {
+		handler := newServer(&config.Config{}, db.NewMockService(ctrl))
+		for _, req := range []struct{ method, path string }{{http.MethodPost, "/mcp"}, {http.MethodPost, "/healthz"}} {
+			if rec := serve(handler, req.method, req.path); rec.Code != http.StatusTeapot {
+				t.Errorf("%s %s: expected the MCP handler, got status %d", req.method, req.path, rec.Code)
+			}
+		}
+	})
+}
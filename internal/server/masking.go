@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+)
+
+// piiMaskingMeta is the field of a tool call's _meta overriding the masking mode of that call
+const piiMaskingMeta = "piiMasking"
+
+// outputMasking masks personal data in the records returned by tool calls.
+// Callers holding one of the unmask roles may pick another masking mode per call through _meta.
+type outputMasking struct {
+	masking     *database.Masking
+	unmaskRoles []string
+	access      *toolAccessControl
+}
+
+// newOutputMasking returns the masking of the configuration, or nil when masking is off
+func newOutputMasking(cfg *config.Config, access *toolAccessControl) *outputMasking {
+	if cfg.PIIMaskMode == "" || cfg.PIIMaskMode == config.PIIMaskModeOff {
+		return nil
+	}
+
+	hashKey := []byte(cfg.PIIHashKey)
+	if len(hashKey) == 0 {
+		// Hashes only correlate values within this process
+		hashKey = make([]byte, 32)
+		_, _ = rand.Read(hashKey)
+	}
+	unmaskRoles := config.ParseList(cfg.PIIUnmaskRoles)
+	if len(unmaskRoles) > 0 && access == nil {
+		slog.Warn("Ignoring NEO4J_PII_UNMASK_ROLES, masking overrides require role-based access control")
+	}
+	return &outputMasking{
+		masking:     database.NewMasking(cfg.PIIMaskMode, config.ParseList(cfg.PIIMaskFields), hashKey),
+		unmaskRoles: unmaskRoles,
+		access:      access,
+	}
+}
+
+// mayOverride reports whether the caller's roles allow them to change the masking mode
+func (m *outputMasking) mayOverride(ctx context.Context) bool {
+	if m.access == nil {
+		return false
+	}
+	for _, role := range m.access.roles(ctx) {
+		if slices.Contains(m.unmaskRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+// middleware masks the records each tool call formats, in the mode requested by authorized callers
+func (m *outputMasking) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		masking := m.masking
+		if meta := request.Params.Meta; meta != nil {
+			if override, ok := meta.AdditionalFields[piiMaskingMeta]; ok {
+				mode, _ := override.(string)
+				if !slices.Contains(config.ValidPIIMaskModes, mode) {
+					return mcp.NewToolResultError(fmt.Sprintf("_meta.%s must be one of %v", piiMaskingMeta, config.ValidPIIMaskModes)), nil
+				}
+				if mode != masking.Mode() && !m.mayOverride(ctx) {
+					identity, _ := auth.GetIdentity(ctx)
+					slog.Warn("Denied PII masking override", "tool", request.Params.Name, "identity", identity, "mode", mode)
+					return mcp.NewToolResultError("overriding PII masking is not permitted for your role"), nil
+				}
+				masking = masking.WithMode(mode)
+			}
+		}
+		return next(database.WithMasking(ctx, masking), request)
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func newTestOutputMasking(t *testing.T) *outputMasking {
+	t.Helper()
+	cfg := &config.Config{
+		TransportMode:  config.TransportModeHTTP,
+		RBACEnabled:    true,
+		Roles:          "alice=investigator,bob=analyst",
+		PIIMaskMode:    config.PIIMaskModePartial,
+		PIIUnmaskRoles: config.RoleInvestigator,
+	}
+	masking := newOutputMasking(cfg, newToolAccessControl(cfg))
+	if masking == nil {
+		t.Fatal("expected output masking to be enabled")
+	}
+	return masking
+}
+
+func callMaskedTool(t *testing.T, masking *outputMasking, identity string, override any) *mcp.CallToolResult {
+	t.Helper()
+	handler := masking.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var service database.Neo4jService
+		response, err := service.Neo4jRecordsToJSON(ctx, []*neo4j.Record{{Keys: []string{"ssn"}, Values: []any{"123-45-6789"}}})
+		if err != nil {
+			return nil, err
+		}
+		return mcp.NewToolResultText(response), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "get-customer-profile"
+	if override != nil {
+		request.Params.Meta = &mcp.Meta{AdditionalFields: map[string]any{piiMaskingMeta: override}}
+	}
+	result, err := handler(auth.WithIdentity(context.Background(), identity), request)
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	return result
+}
+
+func TestOutputMasking_Middleware(t *testing.T) {
+	masking := newTestOutputMasking(t)
+
+	tests := []struct {
+		name      string
+		identity  string
+		override  any
+		want      string
+		wantError string
+	}{
+		{name: "masks with the configured mode", identity: "bob", want: "*******6789"},
+		{name: "authorized role unmasks", identity: "alice", override: config.PIIMaskModeOff, want: "123-45-6789"},
+		{name: "authorized role changes the mode", identity: "alice", override: config.PIIMaskModeFull, want: database.MaskedValue},
+		{name: "requesting the configured mode needs no role", identity: "bob", override: config.PIIMaskModePartial, want: "*******6789"},
+		{name: "unauthorized role is denied", identity: "bob", override: config.PIIMaskModeOff, wantError: "not permitted"},
+		{name: "invalid mode", identity: "alice", override: "none", wantError: "must be one of"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := callMaskedTool(t, masking, tt.identity, tt.override)
+			text := result.Content[0].(mcp.TextContent).Text
+			if tt.wantError != "" {
+				if !result.IsError || !strings.Contains(text, tt.wantError) {
+					t.Errorf("expected error containing %q, got %s", tt.wantError, text)
+				}
+				return
+			}
+			if result.IsError || !strings.Contains(text, `"ssn": "`+tt.want+`"`) {
+				t.Errorf("expected ssn %q, got %s", tt.want, text)
+			}
+		})
+	}
+}
+
+func TestNewOutputMasking_Disabled(t *testing.T) {
+	if newOutputMasking(&config.Config{PIIMaskMode: config.PIIMaskModeOff}, nil) != nil {
+		t.Error("expected no output masking when the mode is off")
+	}
+}
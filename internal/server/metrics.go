@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsPath = "/metrics"
+
+var (
+	// durationBuckets are histogram buckets in seconds, from 5ms to 60s
+	durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+	// sizeBuckets are histogram buckets for result sizes in rows
+	sizeBuckets = []float64{0, 1, 10, 100, 1000, 10000, 100000}
+)
+
+// Outcomes of tool calls and queries, used as the status label
+const (
+	metricsStatusOK    = "ok"
+	metricsStatusError = "error"
+)
+
+// activityReporter is implemented by database services that track their in-progress work
+type activityReporter interface {
+	Activity() database.Activity
+}
+
+// serverMetrics instruments tool calls and the queries they run for Prometheus
+type serverMetrics struct {
+	registry      *prometheus.Registry
+	toolCalls     *prometheus.CounterVec
+	toolDuration  *prometheus.HistogramVec
+	toolsInFlight atomic.Int64
+	queries       *prometheus.CounterVec
+	queryDuration *prometheus.HistogramVec
+	queryRows     *prometheus.HistogramVec
+}
+
+// newServerMetrics registers the server metrics and observes the queries recorded in queryStats.
+// The Neo4j driver does not expose its connection pool, so the queries and transactions the
+// database service has in progress are reported instead when it tracks them.
+func newServerMetrics(queryStats *database.QueryStats, dbService database.Service) *serverMetrics {
+	registry := prometheus.NewRegistry()
+	factory := promauto.With(registry)
+	m := &serverMetrics{
+		registry: registry,
+		toolCalls: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "neo4j_mcp_tool_calls_total",
+			Help: "Tool calls by tool and status; status is error when the tool returned an error result.",
+		}, []string{"tool", "status"}),
+		toolDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "neo4j_mcp_tool_call_duration_seconds",
+			Help:    "Duration of tool calls in seconds.",
+			Buckets: durationBuckets,
+		}, []string{"tool"}),
+		queries: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "neo4j_mcp_queries_total",
+			Help: "Cypher queries run by tools, by tool and status.",
+		}, []string{"tool", "status"}),
+		queryDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "neo4j_mcp_query_duration_seconds",
+			Help:    "Duration of Cypher queries run by tools in seconds.",
+			Buckets: durationBuckets,
+		}, []string{"tool"}),
+		queryRows: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "neo4j_mcp_query_result_rows",
+			Help:    "Rows returned by Cypher queries run by tools.",
+			Buckets: sizeBuckets,
+		}, []string{"tool"}),
+	}
+	factory.NewGaugeFunc(prometheus.GaugeOpts{
+		Name: "neo4j_mcp_tool_calls_in_flight",
+		Help: "Tool calls currently running.",
+	}, func() float64 {
+		return float64(m.toolsInFlight.Load())
+	})
+
+	if reporter, ok := dbService.(activityReporter); ok {
+		factory.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "neo4j_mcp_queries_in_flight",
+			Help: "Cypher queries currently running against Neo4j.",
+		}, func() float64 {
+			return float64(reporter.Activity().InFlightQueries)
+		})
+		factory.NewGaugeFunc(prometheus.GaugeOpts{
+			Name: "neo4j_mcp_open_transactions",
+			Help: "Explicit transactions currently open.",
+		}, func() float64 {
+			return float64(reporter.Activity().OpenTransactions)
+		})
+	}
+
+	queryStats.OnRecord(m.observeQuery)
+	return m
+}
+
+// observeQuery records a query run by a tool
+func (m *serverMetrics) observeQuery(record database.QueryRecord) {
+	status := metricsStatusOK
+	if record.Failed {
+		status = metricsStatusError
+	}
+	m.queries.WithLabelValues(record.Tool, status).Inc()
+	m.queryDuration.WithLabelValues(record.Tool).Observe(record.DurationMs / 1000)
+	if !record.Failed {
+		m.queryRows.WithLabelValues(record.Tool).Observe(float64(record.Rows))
+	}
+}
+
+// middleware records the outcome and duration of each tool call
+func (m *serverMetrics) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		m.toolsInFlight.Add(1)
+		defer m.toolsInFlight.Add(-1)
+
+		started := time.Now()
+		result, err := next(ctx, request)
+
+		status := metricsStatusOK
+		if err != nil || (result != nil && result.IsError) {
+			status = metricsStatusError
+		}
+		m.toolCalls.WithLabelValues(request.Params.Name, status).Inc()
+		m.toolDuration.WithLabelValues(request.Params.Name).Observe(time.Since(started).Seconds())
+		return result, err
+	}
+}
+
+// handler serves the metrics for Prometheus to scrape
+func (m *serverMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{ErrorLog: slogErrorLogger{}})
+}
+
+// slogErrorLogger reports errors writing the metrics in the server log
+type slogErrorLogger struct{}
+
+func (slogErrorLogger) Println(v ...any) {
+	slog.Debug("Error writing metrics", "error", fmt.Sprint(v...))
+}
+
+// startMetricsServer serves the metrics on their own listener, so they can be scraped in any
+// transport mode and without the authentication of the MCP endpoint
+func (s *Neo4jMCPServer) startMetricsServer() error {
+	listener, err := net.Listen("tcp", s.config.MetricsAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on metrics address %s: %w", s.config.MetricsAddress, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET "+metricsPath, s.metrics.handler())
+	s.metricsServer = &http.Server{
+		Addr:              listener.Addr().String(), // The bound address, when the configured port is 0
+		Handler:           mux,
+		ReadHeaderTimeout: serverHTTPReadHeaderTimeout,
+		ReadTimeout:       serverHTTPReadTimeout,
+		WriteTimeout:      serverHTTPReadTimeout,
+		IdleTimeout:       serverHTTPIdleTimeout,
+	}
+
+	slog.Info("Serving Prometheus metrics", "url", fmt.Sprintf("http://%s%s", s.metricsServer.Addr, metricsPath))
+	go func() {
+		if err := s.metricsServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			slog.Error("Metrics server failed", "error", err)
+		}
+	}()
+	return nil
+}
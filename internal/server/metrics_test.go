@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+)
+
+// activeService is a database service reporting fixed activity
+type activeService struct {
+	database.Service
+}
+
+func (activeService) Activity() database.Activity {
+	return database.Activity{InFlightQueries: 2, OpenTransactions: 1}
+}
+
+func scrapeMetrics(t *testing.T, m *serverMetrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, metricsPath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func assertContainsAll(t *testing.T, out string, want ...string) {
+	t.Helper()
+	for _, line := range want {
+		if !strings.Contains(out, line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, out)
+		}
+	}
+}
+
+func TestServerMetrics_ToolCalls(t *testing.T) {
+	m := newServerMetrics(database.NewQueryStats(10), nil)
+	handler := m.middleware(func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		switch request.Params.Name {
+		case "bad-tool":
+			return mcp.NewToolResultError("invalid input"), nil
+		case "broken-tool":
+			return nil, errors.New("protocol error")
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	for _, name := range []string{"read-cypher", "read-cypher", "bad-tool", "broken-tool"} {
+		request := mcp.CallToolRequest{}
+		request.Params.Name = name
+		_, _ = handler(context.Background(), request)
+	}
+
+	assertContainsAll(t, scrapeMetrics(t, m),
+		`neo4j_mcp_tool_calls_total{status="ok",tool="read-cypher"} 2`,
+		`neo4j_mcp_tool_calls_total{status="error",tool="bad-tool"} 1`,
+		`neo4j_mcp_tool_calls_total{status="error",tool="broken-tool"} 1`,
+		`neo4j_mcp_tool_call_duration_seconds_count{tool="read-cypher"} 2`,
+		"neo4j_mcp_tool_calls_in_flight 0\n",
+	)
+}
+
+func TestServerMetrics_Queries(t *testing.T) {
+	stats := database.NewQueryStats(10)
+	m := newServerMetrics(stats, activeService{})
+
+	stats.Record(database.QueryRecord{Tool: "get-entity-network", DurationMs: 40, Rows: 25})
+	stats.Record(database.QueryRecord{Tool: "get-entity-network", DurationMs: 2000, Failed: true})
+
+	assertContainsAll(t, scrapeMetrics(t, m),
+		`neo4j_mcp_queries_total{status="ok",tool="get-entity-network"} 1`,
+		`neo4j_mcp_queries_total{status="error",tool="get-entity-network"} 1`,
+		`neo4j_mcp_query_duration_seconds_bucket{tool="get-entity-network",le="0.05"} 1`,
+		`neo4j_mcp_query_duration_seconds_sum{tool="get-entity-network"} 2.04`,
+		`neo4j_mcp_query_result_rows_bucket{tool="get-entity-network",le="10"} 0`,
+		`neo4j_mcp_query_result_rows_bucket{tool="get-entity-network",le="100"} 1`,
+		`neo4j_mcp_query_result_rows_count{tool="get-entity-network"} 1`,
+		"neo4j_mcp_queries_in_flight 2\n",
+		"neo4j_mcp_open_transactions 1\n",
+	)
+}
+
+func TestStartMetricsServer(t *testing.T) {
+	cfg := &config.Config{URI: "bolt://test-host:7687", TransportMode: config.TransportModeHTTP, MetricsAddress: "127.0.0.1:0"}
+	s := NewNeo4jMCPServer("test-version", cfg, nil, nil)
+	if s.metrics == nil {
+		t.Fatal("expected metrics when a metrics address is configured")
+	}
+	if err := s.startMetricsServer(); err != nil {
+		t.Fatalf("startMetricsServer() unexpected error = %v", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = s.Stop(ctx)
+	}()
+
+	// Route a tool call through the MCP server, so it passes the metrics middleware
+	s.MCPServer.AddTool(mcp.NewTool("echo"), func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	s.MCPServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"echo"}}`))
+
+	resp, err := http.Get("http://" + s.metricsServer.Addr + metricsPath)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", metricsPath, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	assertContainsAll(t, string(body), `neo4j_mcp_tool_calls_total{status="ok",tool="echo"} 1`)
+}
+
+func TestNewNeo4jMCPServer_MetricsDisabled(t *testing.T) {
+	cfg := &config.Config{URI: "bolt://test-host:7687", TransportMode: config.TransportModeHTTP}
+	if s := NewNeo4jMCPServer("test-version", cfg, nil, nil); s.metrics != nil {
+		t.Error("expected no metrics without a metrics address")
+	}
+}
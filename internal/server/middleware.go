@@ -1,21 +1,26 @@
 package server
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 )
 
 const (
 	corsMaxAgeSeconds = "86400" // 24 hours
+	apiKeyHeaderName  = "X-API-Key"
 )
 
 // chainMiddleware chains together all HTTP middleware
-func chainMiddleware(allowedOrigins []string, next http.Handler) http.Handler {
+func chainMiddleware(allowedOrigins []string, paths []string, authenticate func(http.Handler) http.Handler, next http.Handler) http.Handler {
 	// Chain middleware in reverse order (last added = first to execute)
-	// Execution order: PathValidator -> CORS -> BasicAuth -> Logging -> Handler
+	// Execution order: PathValidator -> CORS -> Auth -> Logging -> Handler
 
 	// Start with the actual handler
 	handler := next
@@ -23,14 +28,14 @@ func chainMiddleware(allowedOrigins []string, next http.Handler) http.Handler {
 	// Add logging middleware
 	handler = loggingMiddleware()(handler)
 
-	// Add basic auth middleware (always requires credentials if header present)
-	handler = basicAuthMiddleware()(handler)
+	// Add auth middleware (basic auth, API key or OIDC bearer token, always required)
+	handler = authenticate(handler)
 
 	// Add CORS middleware (if configured)
 	handler = corsMiddleware(allowedOrigins)(handler)
 
 	// Add path validation middleware last (executes first - reject non-/mcp paths quickly)
-	handler = pathValidationMiddleware()(handler)
+	handler = pathValidationMiddleware(paths...)(handler)
 
 	return handler
 }
@@ -51,11 +56,77 @@ func basicAuthMiddleware() func(http.Handler) http.Handler {
 			}
 			// Credentials provided - store in context
 			ctx := auth.WithBasicAuth(r.Context(), user, pass)
+			ctx = auth.WithIdentity(ctx, user)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// bearerAuthMiddleware enforces bearer token authentication for all requests in HTTP mode.
+// The token is read from the Authorization header, or from X-API-Key when apiKeyHeader is set,
+// and verify resolves it to the caller's identity, which is stored in the request context.
+// Neo4j is then accessed with the server's own credentials.
+// Returns 401 Unauthorized if the token is missing or rejected.
+func bearerAuthMiddleware(apiKeyHeader bool, verify func(ctx context.Context, token string) (string, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := bearerToken(r)
+			if !ok && apiKeyHeader {
+				token = r.Header.Get(apiKeyHeaderName)
+				ok = token != ""
+			}
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="Neo4j MCP Server"`)
+				http.Error(w, "Unauthorized: Bearer authentication required", http.StatusUnauthorized)
+				return
+			}
+
+			identity, err := verify(r.Context(), token)
+			if err != nil {
+				slog.Warn("Rejected HTTP request", "remote_addr", r.RemoteAddr, "error", err)
+				w.Header().Set("WWW-Authenticate", `Bearer realm="Neo4j MCP Server", error="invalid_token"`)
+				http.Error(w, "Unauthorized: invalid credentials", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+		})
+	}
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer <token>" header
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// authMiddleware returns the authentication middleware of the configured HTTP auth mode
+func authMiddleware(cfg *config.Config) (func(http.Handler) http.Handler, error) {
+	switch cfg.HTTPAuthMode {
+	case config.HTTPAuthAPIKey:
+		keys, err := auth.ParseAPIKeys(cfg.HTTPAPIKeys)
+		if err != nil {
+			return nil, err
+		}
+		return bearerAuthMiddleware(true, func(_ context.Context, key string) (string, error) {
+			identity, ok := keys.Identity(key)
+			if !ok {
+				return "", fmt.Errorf("unknown API key")
+			}
+			return identity, nil
+		}), nil
+	case config.HTTPAuthOIDC:
+		verifier := auth.NewOIDCVerifier(cfg.OIDCIssuer, cfg.OIDCAudience, cfg.OIDCIdentityClaim, nil)
+		return bearerAuthMiddleware(false, verifier.Verify), nil
+	default:
+		return basicAuthMiddleware(), nil
+	}
+}
+
 // corsMiddleware implements CORS (Cross-Origin Resource Sharing)
 // If allowedOrigins is empty, CORS is disabled
 // If allowedOrigins is "*", all origins are allowed
@@ -81,7 +152,7 @@ func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 
 			// Set other CORS headers
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, "+apiKeyHeaderName+", "+traceparentHeader)
 			w.Header().Set("Access-Control-Max-Age", corsMaxAgeSeconds)
 
 			// Handle preflight requests
@@ -95,14 +166,15 @@ func corsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
-// pathValidationMiddleware validates that requests are only sent to /mcp path
+// pathValidationMiddleware validates that requests are only sent to the MCP paths of the transport
+// (/mcp for streamable HTTP, /sse and /message for SSE)
 // Returns 404 for all other paths to avoid hanging connections
-func pathValidationMiddleware() func(http.Handler) http.Handler {
+func pathValidationMiddleware(paths ...string) func(http.Handler) http.Handler {
+	notFound := "Not Found: This server only handles requests to " + strings.Join(paths, " and ")
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// Only /mcp path is valid for this MCP server
-			if r.URL.Path != "/mcp" {
-				http.Error(w, "Not Found: This server only handles requests to /mcp", http.StatusNotFound)
+			if !slices.Contains(paths, r.URL.Path) {
+				http.Error(w, notFound, http.StatusNotFound)
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -114,8 +186,10 @@ func pathValidationMiddleware() func(http.Handler) http.Handler {
 func loggingMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			identity, _ := auth.GetIdentity(r.Context())
 
 			slog.Debug("HTTP Request",
+				"identity", identity,
 				"method", r.Method,
 				"url", r.URL.Path,
 				"remote_addr", r.RemoteAddr,
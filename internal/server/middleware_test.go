@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 )
 
 // mockHandler is a simple handler that returns 200 OK
@@ -84,6 +85,99 @@ func TestBasicAuthMiddleware_WithEmptyCredentials(t *testing.T) {
 	}
 }
 
+// identityCheckHandler verifies the caller's identity is in context and no Basic Auth credentials are
+func identityCheckHandler(t *testing.T, expectedIdentity string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity, ok := auth.GetIdentity(r.Context())
+		if !ok || identity != expectedIdentity {
+			t.Errorf("Expected identity %q in context, got %q", expectedIdentity, identity)
+		}
+		if _, _, ok := auth.GetBasicAuthCredentials(r.Context()); ok {
+			t.Error("Expected no Basic Auth credentials in context")
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func TestBasicAuthMiddleware_SetsIdentity(t *testing.T) {
+	handler := basicAuthMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if identity, _ := auth.GetIdentity(r.Context()); identity != "testuser" {
+			t.Errorf("Expected identity %q, got %q", "testuser", identity)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.SetBasicAuth("testuser", "testpass")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", rec.Code)
+	}
+}
+
+func TestAuthMiddleware_APIKey(t *testing.T) {
+	authenticate, err := authMiddleware(&config.Config{HTTPAuthMode: config.HTTPAuthAPIKey, HTTPAPIKeys: "alice=key-a,ci-bot=key-b"})
+	if err != nil {
+		t.Fatalf("authMiddleware() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		header     string
+		value      string
+		wantStatus int
+		identity   string
+	}{
+		{name: "bearer key", header: "Authorization", value: "Bearer key-a", wantStatus: http.StatusOK, identity: "alice"},
+		{name: "X-API-Key header", header: "X-API-Key", value: "key-b", wantStatus: http.StatusOK, identity: "ci-bot"},
+		{name: "unknown key", header: "Authorization", value: "Bearer key-c", wantStatus: http.StatusUnauthorized},
+		{name: "basic auth", header: "Authorization", value: "Basic dXNlcjpwYXNz", wantStatus: http.StatusUnauthorized},
+		{name: "no credentials", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := authenticate(identityCheckHandler(t, tt.identity))
+
+			req := httptest.NewRequest("POST", "/mcp", nil)
+			if tt.header != "" {
+				req.Header.Set(tt.header, tt.value)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("Expected WWW-Authenticate header on 401")
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_OIDCIgnoresAPIKeyHeader(t *testing.T) {
+	authenticate, err := authMiddleware(&config.Config{HTTPAuthMode: config.HTTPAuthOIDC, OIDCIssuer: "https://issuer.example.com", OIDCAudience: "neo4j-mcp"})
+	if err != nil {
+		t.Fatalf("authMiddleware() error = %v", err)
+	}
+	handler := authenticate(mockHandler())
+
+	req := httptest.NewRequest("POST", "/mcp", nil)
+	req.Header.Set("X-API-Key", "key-a")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", rec.Code)
+	}
+}
+
 func TestCORSMiddleware_NoConfiguration(t *testing.T) {
 	handler := corsMiddleware([]string{})(mockHandler())
 
@@ -269,7 +363,7 @@ func TestLoggingMiddleware(t *testing.T) {
 
 func TestAddMiddleware_FullChain(t *testing.T) {
 	allowedOrigins := []string{"http://example.com"}
-	handler := chainMiddleware(allowedOrigins, authCheckHandler(t, true, "user", "pass"))
+	handler := chainMiddleware(allowedOrigins, []string{"/mcp"}, basicAuthMiddleware(), authCheckHandler(t, true, "user", "pass"))
 
 	req := httptest.NewRequest("GET", "/mcp", nil)
 	req.Header.Set("Origin", "http://example.com")
@@ -290,7 +384,7 @@ func TestAddMiddleware_FullChain(t *testing.T) {
 
 func TestAddMiddleware_FullChain_NoAuth(t *testing.T) {
 	allowedOrigins := []string{"http://example.com"}
-	handler := chainMiddleware(allowedOrigins, mockHandler())
+	handler := chainMiddleware(allowedOrigins, []string{"/mcp"}, basicAuthMiddleware(), mockHandler())
 
 	req := httptest.NewRequest("GET", "/mcp", nil)
 	req.Header.Set("Origin", "http://example.com")
@@ -357,7 +451,7 @@ func TestParseAllowedOrigins_WithSpaces(t *testing.T) {
 }
 
 func TestPathValidationMiddleware_ValidPath(t *testing.T) {
-	handler := pathValidationMiddleware()(mockHandler())
+	handler := pathValidationMiddleware("/mcp")(mockHandler())
 
 	req := httptest.NewRequest("GET", "/mcp", nil)
 	rec := httptest.NewRecorder()
@@ -386,7 +480,7 @@ func TestPathValidationMiddleware_InvalidPaths(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			handler := pathValidationMiddleware()(mockHandler())
+			handler := pathValidationMiddleware("/mcp")(mockHandler())
 
 			req := httptest.NewRequest("GET", tc.path, nil)
 			rec := httptest.NewRecorder()
@@ -405,11 +499,26 @@ func TestPathValidationMiddleware_InvalidPaths(t *testing.T) {
 	}
 }
 
+func TestPathValidationMiddleware_SSEPaths(t *testing.T) {
+	handler := pathValidationMiddleware("/sse", "/message")(mockHandler())
+
+	for path, expected := range map[string]int{"/sse": http.StatusOK, "/message": http.StatusOK, "/mcp": http.StatusNotFound} {
+		req := httptest.NewRequest("GET", path, nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != expected {
+			t.Errorf("Expected status %d for path %s, got %d", expected, path, rec.Code)
+		}
+	}
+}
+
 func TestPathValidationMiddleware_InFullChain(t *testing.T) {
 	// Test that path validation happens before auth check
 	// Invalid paths should return 404 without requiring auth
 	allowedOrigins := []string{}
-	handler := chainMiddleware(allowedOrigins, mockHandler())
+	handler := chainMiddleware(allowedOrigins, []string{"/mcp"}, basicAuthMiddleware(), mockHandler())
 
 	req := httptest.NewRequest("GET", "/", nil)
 	// No auth credentials
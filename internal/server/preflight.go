@@ -0,0 +1,169 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+)
+
+const (
+	minNeo4jMajor      = 4 // Oldest Neo4j release the tools support is 4.4
+	minNeo4jMinor      = 4
+	minGDSMajorVersion = 2 // The GDS tools call procedures introduced in GDS 2.0
+
+	schemaProceduresQuery = "SHOW PROCEDURES YIELD name WHERE name IN $names RETURN collect(name) AS names"
+	apocVersionQuery      = "RETURN apoc.version() AS apocVersion"
+	// writeProbeQuery checks the user may write; it runs in a transaction that is always rolled back
+	writeProbeQuery = "CREATE (probe) DELETE probe"
+)
+
+// schemaProcedures are the native procedures behind get-schema, validate-schema and the schema resource
+var schemaProcedures = []string{"db.schema.visualization", "db.schema.nodeTypeProperties", "db.schema.relTypeProperties"}
+
+// capabilityReport records what the Neo4j server offers, as found by the startup preflight checks.
+// Tools whose prerequisites are missing are not registered.
+type capabilityReport struct {
+	server           *analytics.StartupEventInfo // From dbms.components, nil when it could not be read
+	schemaProcedures bool                        // db.schema.* procedures are available
+	apocVersion      string                      // Empty when APOC is not installed
+	gdsVersion       string                      // Empty when GDS is not installed
+	writeAllowed     bool                        // The user may write to the database
+}
+
+// runPreflight checks the Neo4j version, the procedures the tools call, the GDS version and whether the
+// user may write. Only a Neo4j version older than 4.4 fails startup: other missing prerequisites disable tools.
+func (s *Neo4jMCPServer) runPreflight(ctx context.Context) (*capabilityReport, error) {
+	report := &capabilityReport{schemaProcedures: true, writeAllowed: true}
+
+	records, err := s.dbService.ExecuteReadQuery(ctx, "CALL dbms.components()", map[string]any{})
+	if err != nil {
+		slog.Warn("Impossible to read the Neo4j version, dbms.components() query failed", "error", err)
+	} else {
+		info := recordsToStartupEventInfo(records, s.version)
+		report.server = &info
+		if major, minor, ok := parseVersion(info.Neo4jVersion); ok && (major < minNeo4jMajor || major == minNeo4jMajor && minor < minNeo4jMinor) {
+			return nil, fmt.Errorf("neo4j %s is not supported, version %d.%d or later is required", info.Neo4jVersion, minNeo4jMajor, minNeo4jMinor)
+		}
+	}
+
+	report.schemaProcedures = s.hasSchemaProcedures(ctx)
+
+	records, err = s.dbService.ExecuteReadQuery(ctx, apocVersionQuery, nil)
+	if err == nil && len(records) == 1 && len(records[0].Values) == 1 {
+		report.apocVersion, _ = records[0].Values[0].(string)
+	}
+
+	// Call gds.version procedure to determine if GDS is installed
+	records, err = s.dbService.ExecuteReadQuery(ctx, "RETURN gds.version() as gdsVersion", nil)
+	if err != nil {
+		// GDS is optional, so we log a warning and continue, assuming it's not installed.
+		slog.Warn("Impossible to verify GDS installation.")
+	} else if len(records) == 1 && len(records[0].Values) == 1 {
+		report.gdsVersion, _ = records[0].Values[0].(string)
+	}
+
+	if s.writeToolsEnabled() {
+		report.writeAllowed = s.canWrite(ctx)
+	}
+	return report, nil
+}
+
+// hasSchemaProcedures reports whether all db.schema.* procedures are available.
+// When the procedures cannot be listed they are assumed available, and calls report their own errors.
+func (s *Neo4jMCPServer) hasSchemaProcedures(ctx context.Context) bool {
+	records, err := s.dbService.ExecuteReadQuery(ctx, schemaProceduresQuery, map[string]any{"names": schemaProcedures})
+	if err != nil || len(records) != 1 {
+		slog.Warn("Impossible to list the db.schema procedures, assuming they are available", "error", err)
+		return true
+	}
+	namesRaw, _ := records[0].Get("names")
+	names, _ := namesRaw.([]any)
+	return len(names) == len(schemaProcedures)
+}
+
+// canWrite probes write access with a statement run in a transaction that is rolled back.
+// Only a permission or access mode error counts as read-only; other failures leave the write tools enabled.
+func (s *Neo4jMCPServer) canWrite(ctx context.Context) bool {
+	id, err := s.dbService.BeginTransaction(ctx)
+	if err != nil {
+		slog.Warn("Impossible to verify write access", "error", err)
+		return true
+	}
+	if _, _, err := s.dbService.RunInTransaction(ctx, id, writeProbeQuery, nil); err != nil {
+		// A failed statement has already rolled the transaction back
+		if database.IsForbiddenError(err) || database.IsAccessModeError(err) {
+			return false
+		}
+		slog.Warn("Impossible to verify write access", "error", err)
+		return true
+	}
+	if err := s.dbService.RollbackTransaction(ctx, id); err != nil {
+		slog.Warn("Failed to roll back the write access probe", "error", err)
+	}
+	return true
+}
+
+// writeToolsEnabled reports whether the configuration exposes write tools at all
+func (s *Neo4jMCPServer) writeToolsEnabled() bool {
+	return !s.config.ReadOnly && s.config.Profile != config.ProfileDemo
+}
+
+// log writes the capability report, naming the tools disabled by a missing prerequisite
+func (r *capabilityReport) log() {
+	neo4jVersion, edition := "unknown", "unknown"
+	if r.server != nil {
+		neo4jVersion, edition = r.server.Neo4jVersion, r.server.Edition
+	}
+	slog.Info("Neo4j capability report",
+		"neo4jVersion", neo4jVersion,
+		"edition", edition,
+		"schemaProcedures", r.schemaProcedures,
+		"apocVersion", valueOrNone(r.apocVersion),
+		"gdsVersion", valueOrNone(r.gdsVersion),
+		"writeAllowed", r.writeAllowed,
+	)
+	if !r.schemaProcedures {
+		slog.Warn("db.schema procedures are missing, disabling get-schema and validate-schema")
+	}
+	if r.gdsVersion != "" && !r.gdsSupported() {
+		slog.Warn("GDS is older than the supported version, disabling GDS tools", "gdsVersion", r.gdsVersion, "requiredMajorVersion", minGDSMajorVersion)
+	}
+	if !r.writeAllowed {
+		slog.Warn("The Neo4j user cannot write to the database, disabling write tools")
+	}
+}
+
+// gdsSupported reports whether a GDS version the tools can use is installed
+func (r *capabilityReport) gdsSupported() bool {
+	major, _, ok := parseVersion(r.gdsVersion)
+	return ok && major >= minGDSMajorVersion
+}
+
+// parseVersion returns the major and minor numbers of a version such as 5.18.0, 4.4-aura or 2025.01.0
+func parseVersion(version string) (int, int, bool) {
+	majorStr, rest, _ := strings.Cut(version, ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return 0, 0, false
+	}
+	minorStr, _, _ := strings.Cut(rest, ".")
+	minorStr, _, _ = strings.Cut(minorStr, "-")
+	minor, err := strconv.Atoi(minorStr)
+	if err != nil {
+		return major, 0, true
+	}
+	return major, minor, true
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"testing"
+
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/server"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPreflightChecks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	aService := analytics.NewMockService(ctrl)
+	aService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	aService.EXPECT().NewStartupEvent(gomock.Any()).AnyTimes()
+
+	cfg := &config.Config{
+		URI:           "bolt://test-host:7687",
+		Username:      "neo4j",
+		Password:      "password",
+		Database:      "neo4j",
+		TransportMode: config.TransportModeStdio,
+	}
+
+	t.Run("fails startup on a Neo4j version older than 4.4", func(t *testing.T) {
+		mockDB := getPreflightMockDB(ctrl, "4.3.0", func(*db.MockService) {})
+
+		if err := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService).Start(); err == nil {
+			t.Error("Start() expected an error for Neo4j 4.3, got nil")
+		}
+	})
+
+	t.Run("accepts calendar versions", func(t *testing.T) {
+		mockDB := getPreflightMockDB(ctrl, "2025.01.0", func(*db.MockService) {})
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		if err := s.Start(); err != nil {
+			t.Fatalf("Start() unexpected error = %v", err)
+		}
+		if _, ok := s.MCPServer.ListTools()["write-cypher"]; !ok {
+			t.Error("expected write-cypher to be registered")
+		}
+	})
+
+	t.Run("disables write tools when the user cannot write", func(t *testing.T) {
+		mockDB := getPreflightMockDB(ctrl, "5.18.0", func(mockDB *db.MockService) {
+			mockDB.EXPECT().RunInTransaction(gomock.Any(), gomock.Any(), "CREATE (probe) DELETE probe", gomock.Any()).AnyTimes().Return(nil, nil, &neo4j.Neo4jError{
+				Code: "Neo.ClientError.Security.Forbidden",
+				Msg:  "Create node with labels '' on database 'neo4j' is not allowed for user 'reader'.",
+			})
+		})
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		if err := s.Start(); err != nil {
+			t.Fatalf("Start() unexpected error = %v", err)
+		}
+		registeredTools := s.MCPServer.ListTools()
+		if _, ok := registeredTools["write-cypher"]; ok {
+			t.Error("expected write-cypher not to be registered for a read-only user")
+		}
+		if _, ok := registeredTools["read-cypher"]; !ok {
+			t.Error("expected read-cypher to be registered")
+		}
+	})
+
+	t.Run("disables schema tools when db.schema procedures are missing", func(t *testing.T) {
+		mockDB := getPreflightMockDB(ctrl, "5.18.0", func(mockDB *db.MockService) {
+			mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "SHOW PROCEDURES YIELD name WHERE name IN $names RETURN collect(name) AS names", gomock.Any()).AnyTimes().Return([]*neo4j.Record{
+				{
+					Keys:   []string{"names"},
+					Values: []any{[]any{"db.schema.visualization"}},
+				},
+			}, nil)
+		})
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		if err := s.Start(); err != nil {
+			t.Fatalf("Start() unexpected error = %v", err)
+		}
+		registeredTools := s.MCPServer.ListTools()
+		for _, name := range []string{"get-schema", "validate-schema", "suggest-attribute-mappings"} {
+			if _, ok := registeredTools[name]; ok {
+				t.Errorf("expected %s not to be registered", name)
+			}
+		}
+		if _, ok := registeredTools["read-cypher"]; !ok {
+			t.Error("expected read-cypher to be registered")
+		}
+	})
+
+	t.Run("disables GDS tools on GDS older than 2.0", func(t *testing.T) {
+		mockDB := getPreflightMockDB(ctrl, "5.18.0", func(mockDB *db.MockService) {
+			mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN gds.version() as gdsVersion", gomock.Any()).AnyTimes().Return([]*neo4j.Record{
+				{
+					Keys:   []string{"gdsVersion"},
+					Values: []any{"1.8.7"},
+				},
+			}, nil)
+		})
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		if err := s.Start(); err != nil {
+			t.Fatalf("Start() unexpected error = %v", err)
+		}
+		if _, ok := s.MCPServer.ListTools()["list-gds-procedures"]; ok {
+			t.Error("expected list-gds-procedures not to be registered for GDS 1.x")
+		}
+	})
+}
+
+// getPreflightMockDB returns a database mock for a healthy Neo4j server of the given version with GDS 2.x.
+// Expectations set by override are registered first, so they take precedence over the defaults.
+func getPreflightMockDB(ctrl *gomock.Controller, neo4jVersion string, override func(*db.MockService)) *db.MockService {
+	mockDB := db.NewMockService(ctrl)
+	override(mockDB)
+	mockDB.EXPECT().VerifyConnectivity(gomock.Any()).AnyTimes()
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 as first", gomock.Any()).AnyTimes().Return([]*neo4j.Record{
+		{
+			Keys:   []string{"first"},
+			Values: []any{int64(1)},
+		},
+	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).AnyTimes().Return([]*neo4j.Record{
+		{
+			Keys:   []string{"name", "edition", "versions"},
+			Values: []any{"Neo4j Kernel", "enterprise", []any{neo4jVersion}},
+		},
+	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN gds.version() as gdsVersion", gomock.Any()).AnyTimes().Return([]*neo4j.Record{
+		{
+			Keys:   []string{"gdsVersion"},
+			Values: []any{"2.22.0"},
+		},
+	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL gds.list() YIELD name RETURN name", gomock.Any()).AnyTimes()
+	expectPreflightChecks(mockDB)
+	return mockDB
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/policy"
+)
+
+// newQueryPolicy returns the query policy of the configuration, or nil when the policy is disabled
+func newQueryPolicy(cfg *config.Config) *policy.Policy {
+	if !cfg.QueryPolicyEnabled {
+		return nil
+	}
+	// The configuration is validated, so the policy file loads
+	queryPolicy, err := policy.Load(cfg.QueryPolicyFile)
+	if err != nil {
+		slog.Error("Failed to load query policy", "error", err)
+		return nil
+	}
+	return queryPolicy
+}
+
+// enforceQueryPolicy checks the queries each tool call runs against the policy. A denied query fails
+// the tool call with the violation as structured content, whatever error the tool made of it.
+func enforceQueryPolicy(queryPolicy *policy.Policy) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, violation := database.WithQueryPolicy(ctx, queryPolicy, request.Params.Name)
+			result, err := next(ctx, request)
+			if v := violation(); v != nil {
+				return policyViolationResult(v), nil
+			}
+			return result, err
+		}
+	}
+}
+
+// policyViolationResult returns the error result of a violation, as JSON text and structured content
+func policyViolationResult(violation *policy.Violation) *mcp.CallToolResult {
+	text, err := json.Marshal(violation)
+	if err != nil {
+		return mcp.NewToolResultError(violation.Error())
+	}
+	result := mcp.NewToolResultStructured(violation, string(text))
+	result.IsError = true
+	return result
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/policy"
+)
+
+func TestEnforceQueryPolicy(t *testing.T) {
+	queryPolicy := newQueryPolicy(&config.Config{QueryPolicyEnabled: true})
+	if queryPolicy == nil {
+		t.Fatal("expected the query policy to be enabled")
+	}
+
+	// The tool reports the failed query as a plain error, like the Cypher tools do
+	handler := enforceQueryPolicy(queryPolicy)(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var service database.Neo4jService
+		if _, err := service.ExecuteWriteQuery(ctx, request.GetString("query", ""), nil); err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "write-cypher"
+	request.Params.Arguments = map[string]any{"query": "CALL apoc.periodic.iterate('MATCH (c:Case) RETURN c', 'SET c.archived = true', {})"}
+	result, err := handler(context.Background(), request)
+	if err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected an error result")
+	}
+
+	violation, ok := result.StructuredContent.(*policy.Violation)
+	if !ok || violation.Rule != "apoc-periodic" || violation.Tool != "write-cypher" {
+		t.Errorf("expected the violation as structured content, got %#v", result.StructuredContent)
+	}
+	var text map[string]any
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &text); err != nil {
+		t.Fatalf("expected the violation as JSON text: %v", err)
+	}
+	if text["error"] != policy.ViolationError || text["rule"] != "apoc-periodic" {
+		t.Errorf("unexpected violation text %v", text)
+	}
+}
+
+func TestNewQueryPolicy_Disabled(t *testing.T) {
+	if newQueryPolicy(&config.Config{QueryPolicyEnabled: false}) != nil {
+		t.Error("expected no query policy when it is disabled")
+	}
+}
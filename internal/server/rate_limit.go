@@ -0,0 +1,128 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+)
+
+// rateLimiterSweepInterval is how often the state of idle clients is dropped
+const rateLimiterSweepInterval = time.Minute
+
+// toolRateLimiter caps the tool calls each client runs at once and starts per minute,
+// so an agent stuck in a loop of expensive traversals cannot overload the Neo4j cluster.
+// Calls per minute are limited with a token bucket holding a minute's worth of calls,
+// which allows short bursts while keeping the average rate.
+type toolRateLimiter struct {
+	maxConcurrent int
+	perMinute     int
+	now           func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimit
+	lastSweep time.Time
+}
+
+type clientLimit struct {
+	running    int
+	tokens     float64
+	lastRefill time.Time
+}
+
+// newToolRateLimiter returns a limiter, or nil when both limits are disabled
+func newToolRateLimiter(maxConcurrent, perMinute int32) *toolRateLimiter {
+	if maxConcurrent <= 0 && perMinute <= 0 {
+		return nil
+	}
+	return &toolRateLimiter{
+		maxConcurrent: max(int(maxConcurrent), 0),
+		perMinute:     max(int(perMinute), 0),
+		now:           time.Now,
+		clients:       make(map[string]*clientLimit),
+	}
+}
+
+// clientKey identifies the client of a tool call: the authenticated HTTP caller,
+// else the MCP session, else the single STDIO client
+func clientKey(ctx context.Context) string {
+	if identity, ok := auth.GetIdentity(ctx); ok {
+		return "identity:" + identity
+	}
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return "session:" + session.SessionID()
+	}
+	return "local"
+}
+
+// acquire reserves a slot for a tool call of client; it returns a release function,
+// or an error describing the exceeded limit
+func (l *toolRateLimiter) acquire(client string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	limit, ok := l.clients[client]
+	if !ok {
+		limit = &clientLimit{tokens: float64(l.perMinute), lastRefill: now}
+		l.clients[client] = limit
+	}
+
+	if l.maxConcurrent > 0 && limit.running >= l.maxConcurrent {
+		return nil, fmt.Errorf("rate limited: %d tool calls are already running for this client, retry once one of them finishes", limit.running)
+	}
+
+	if l.perMinute > 0 {
+		rate := float64(l.perMinute) / time.Minute.Seconds()
+		limit.tokens = math.Min(float64(l.perMinute), limit.tokens+now.Sub(limit.lastRefill).Seconds()*rate)
+		limit.lastRefill = now
+		if limit.tokens < 1 {
+			retryAfter := time.Duration(math.Ceil((1-limit.tokens)/rate)) * time.Second
+			return nil, fmt.Errorf("rate limited: this client may start %d tool calls per minute, retry in %s", l.perMinute, retryAfter)
+		}
+		limit.tokens--
+	}
+
+	limit.running++
+	return func() {
+		l.mu.Lock()
+		limit.running--
+		l.mu.Unlock()
+	}, nil
+}
+
+// sweep drops idle clients whose bucket has refilled, so their state does not accumulate.
+// The caller must hold l.mu.
+func (l *toolRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimiterSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for client, limit := range l.clients {
+		if limit.running == 0 && now.Sub(limit.lastRefill) >= time.Minute {
+			delete(l.clients, client)
+		}
+	}
+}
+
+// middleware rejects tool calls over the client's limits with a tool error the agent can act on
+func (l *toolRateLimiter) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		client := clientKey(ctx)
+		release, err := l.acquire(client)
+		if err != nil {
+			slog.Warn("Rate limited tool call", "tool", request.Params.Name, "client", client, "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		defer release()
+		return next(ctx, request)
+	}
+}
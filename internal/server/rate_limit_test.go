@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+)
+
+func TestNewToolRateLimiter_Disabled(t *testing.T) {
+	if limiter := newToolRateLimiter(0, 0); limiter != nil {
+		t.Error("expected no limiter when both limits are disabled")
+	}
+}
+
+func TestToolRateLimiter_Concurrency(t *testing.T) {
+	limiter := newToolRateLimiter(2, 0)
+
+	releaseFirst, err := limiter.acquire("alice")
+	if err != nil {
+		t.Fatalf("acquire() unexpected error = %v", err)
+	}
+	if _, err := limiter.acquire("alice"); err != nil {
+		t.Fatalf("acquire() unexpected error = %v", err)
+	}
+	if _, err := limiter.acquire("alice"); err == nil || !strings.Contains(err.Error(), "2 tool calls are already running") {
+		t.Errorf("acquire() error = %v, want concurrency limit", err)
+	}
+
+	// Other clients have their own cap
+	if _, err := limiter.acquire("bob"); err != nil {
+		t.Errorf("acquire() for another client unexpected error = %v", err)
+	}
+
+	releaseFirst()
+	if _, err := limiter.acquire("alice"); err != nil {
+		t.Errorf("acquire() after release unexpected error = %v", err)
+	}
+}
+
+func TestToolRateLimiter_PerMinute(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newToolRateLimiter(0, 3)
+	limiter.now = func() time.Time { return now }
+
+	for range 3 {
+		release, err := limiter.acquire("alice")
+		if err != nil {
+			t.Fatalf("acquire() unexpected error = %v", err)
+		}
+		release()
+	}
+	_, err := limiter.acquire("alice")
+	if err == nil || !strings.Contains(err.Error(), "3 tool calls per minute, retry in 20s") {
+		t.Errorf("acquire() error = %v, want rate limit with retry hint", err)
+	}
+
+	// A third of a minute refills one call
+	now = now.Add(20 * time.Second)
+	if _, err := limiter.acquire("alice"); err != nil {
+		t.Errorf("acquire() after refill unexpected error = %v", err)
+	}
+	if _, err := limiter.acquire("alice"); err == nil {
+		t.Error("acquire() expected rate limit once the refilled call is used")
+	}
+}
+
+func TestToolRateLimiter_SweepsIdleClients(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter := newToolRateLimiter(1, 10)
+	limiter.now = func() time.Time { return now }
+
+	release, _ := limiter.acquire("alice")
+	release()
+	running, _ := limiter.acquire("bob")
+	defer running()
+
+	now = now.Add(2 * time.Minute)
+	_, _ = limiter.acquire("carol")
+
+	if _, ok := limiter.clients["alice"]; ok {
+		t.Error("expected the idle client to be dropped")
+	}
+	if _, ok := limiter.clients["bob"]; !ok {
+		t.Error("expected the client with a running call to be kept")
+	}
+}
+
+func TestToolRateLimiter_Middleware(t *testing.T) {
+	limiter := newToolRateLimiter(0, 1)
+	handler := limiter.middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+	request := mcp.CallToolRequest{}
+	request.Params.Name = "read-cypher"
+
+	alice := auth.WithIdentity(context.Background(), "alice")
+	if result, _ := handler(alice, request); result.IsError {
+		t.Fatal("expected the first call to succeed")
+	}
+	result, err := handler(alice, request)
+	if err != nil {
+		t.Fatalf("expected a tool error rather than a protocol error, got %v", err)
+	}
+	if !result.IsError || !strings.HasPrefix(result.Content[0].(mcp.TextContent).Text, "rate limited:") {
+		t.Errorf("expected a rate limited tool error, got %#v", result)
+	}
+
+	// Calls are counted per client
+	bob := auth.WithIdentity(context.Background(), "bob")
+	if result, _ := handler(bob, request); result.IsError {
+		t.Error("expected another client not to be rate limited")
+	}
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+)
+
+// roleGrant lists the tool categories a role may use, and whether it may use their write tools
+type roleGrant struct {
+	categories []toolCategory
+	write      bool
+}
+
+// roleGrants maps each role to the tools it grants
+var roleGrants = map[string]roleGrant{
+	config.RoleAnalyst:      {categories: []toolCategory{cypherCategory, gdsCategory, schemaCategory, dataCategory}},
+	config.RoleInvestigator: {categories: []toolCategory{cypherCategory, gdsCategory, fraudCategory, schemaCategory, dataCategory}, write: true},
+	config.RoleAdmin:        {categories: []toolCategory{cypherCategory, gdsCategory, fraudCategory, schemaCategory, dataCategory, adminCategory}, write: true},
+}
+
+// toolAccessControl enforces role-based access to the enabled tools for each HTTP caller.
+// The server-wide tool filters decide which tools exist; the caller's roles then decide
+// which of them the caller sees in tools/list and may call.
+type toolAccessControl struct {
+	assignments  map[string][]string // identity -> roles
+	defaultRoles []string
+
+	mu    sync.RWMutex
+	tools map[string]ToolDefinition
+}
+
+// newToolAccessControl returns the access control of the configuration, or nil when RBAC is disabled.
+// RBAC only applies to the HTTP transports, since STDIO has a single local caller.
+func newToolAccessControl(cfg *config.Config) *toolAccessControl {
+	if cfg == nil || !cfg.RBACEnabled {
+		return nil
+	}
+	if !config.IsHTTPTransport(cfg.TransportMode) {
+		slog.Warn("Ignoring NEO4J_MCP_RBAC_ENABLED, role-based access control only applies to HTTP transport modes")
+		return nil
+	}
+
+	// The configuration is validated, so the assignments parse
+	assignments, _ := config.ParseRoleAssignments(cfg.Roles)
+	access := &toolAccessControl{assignments: assignments, tools: make(map[string]ToolDefinition)}
+	if cfg.DefaultRole != "" {
+		access.defaultRoles = []string{cfg.DefaultRole}
+	}
+	return access
+}
+
+// setTools records the enabled tools, so their category and read-only flag can be checked per call
+func (a *toolAccessControl) setTools(toolDefs []ToolDefinition) {
+	if a == nil {
+		return
+	}
+
+	tools := make(map[string]ToolDefinition, len(toolDefs))
+	for _, toolDef := range toolDefs {
+		tools[toolDef.definition.Tool.Name] = toolDef
+	}
+	a.mu.Lock()
+	a.tools = tools
+	a.mu.Unlock()
+}
+
+// roles returns the roles of the authenticated caller
+func (a *toolAccessControl) roles(ctx context.Context) []string {
+	identity, ok := auth.GetIdentity(ctx)
+	if !ok {
+		return nil
+	}
+	if roles, ok := a.assignments[identity]; ok {
+		return roles
+	}
+	return a.defaultRoles
+}
+
+// permitted reports whether the caller's roles grant the tool; a nil access control permits every tool
+func (a *toolAccessControl) permitted(ctx context.Context, name string) bool {
+	if a == nil {
+		return true
+	}
+
+	a.mu.RLock()
+	toolDef, ok := a.tools[name]
+	a.mu.RUnlock()
+	if !ok {
+		return false
+	}
+
+	for _, role := range a.roles(ctx) {
+		grant := roleGrants[role]
+		if slices.Contains(grant.categories, toolDef.category) && (toolDef.readonly || grant.write) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTools hides the tools the caller may not use from tools/list
+func (a *toolAccessControl) filterTools(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+	permittedTools := make([]mcp.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if a.permitted(ctx, tool.Name) {
+			permittedTools = append(permittedTools, tool)
+		}
+	}
+	return permittedTools
+}
+
+// enforce rejects calls to tools the caller's roles do not grant
+func (a *toolAccessControl) enforce(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if !a.permitted(ctx, request.Params.Name) {
+			identity, _ := auth.GetIdentity(ctx)
+			slog.Warn("Denied tool call", "tool", request.Params.Name, "identity", identity, "roles", a.roles(ctx))
+			return mcp.NewToolResultError(fmt.Sprintf("tool %q is not permitted for your role", request.Params.Name)), nil
+		}
+		return next(ctx, request)
+	}
+}
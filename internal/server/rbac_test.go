@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+)
+
+func newRBACServer(t *testing.T, cfg *config.Config) *Neo4jMCPServer {
+	t.Helper()
+	cfg.URI = "bolt://test-host:7687"
+	cfg.TransportMode = config.TransportModeHTTP
+	cfg.RBACEnabled = true
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error = %v", err)
+	}
+	s := NewNeo4jMCPServer("test-version", cfg, nil, nil)
+	if err := s.registerTools(); err != nil {
+		t.Fatalf("registerTools() failed: %v", err)
+	}
+	return s
+}
+
+func listToolNames(t *testing.T, s *Neo4jMCPServer, identity string) []string {
+	t.Helper()
+	ctx := context.Background()
+	if identity != "" {
+		ctx = auth.WithIdentity(ctx, identity)
+	}
+	response := s.MCPServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/list"}`))
+	result, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("Expected a tools/list response, got: %#v", response)
+	}
+	names := make([]string, 0)
+	for _, tool := range result.Result.(mcp.ListToolsResult).Tools {
+		names = append(names, tool.Name)
+	}
+	return names
+}
+
+func TestToolAccessControl(t *testing.T) {
+	s := newRBACServer(t, &config.Config{Roles: "alice=analyst,bob=investigator,carol=admin", AdminTools: true})
+
+	tests := []struct {
+		identity string
+		included []string
+		excluded []string
+	}{
+		{identity: "alice", included: []string{"read-cypher", "get-customer-profile", "validate-schema"}, excluded: []string{"write-cypher", "compute-risk-score", "kill-query"}},
+		{identity: "bob", included: []string{"read-cypher", "write-cypher", "compute-risk-score", "flag-entity"}, excluded: []string{"kill-query", "list-running-queries"}},
+		{identity: "carol", included: []string{"write-cypher", "flag-entity", "kill-query"}},
+		{identity: "mallory", excluded: []string{"read-cypher", "get-schema"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.identity, func(t *testing.T) {
+			names := listToolNames(t, s, tt.identity)
+			for _, name := range tt.included {
+				if !slices.Contains(names, name) {
+					t.Errorf("expected %s to list %s, got %v", tt.identity, name, names)
+				}
+			}
+			for _, name := range tt.excluded {
+				if slices.Contains(names, name) {
+					t.Errorf("expected %s not to list %s", tt.identity, name)
+				}
+			}
+		})
+	}
+}
+
+func TestToolAccessControl_DefaultRole(t *testing.T) {
+	s := newRBACServer(t, &config.Config{Roles: "bob=investigator", DefaultRole: config.RoleAnalyst})
+
+	names := listToolNames(t, s, "someone")
+	if !slices.Contains(names, "read-cypher") || slices.Contains(names, "write-cypher") {
+		t.Errorf("expected callers without an assignment to get the analyst tools, got %v", names)
+	}
+	if names := listToolNames(t, s, ""); len(names) != 0 {
+		t.Errorf("expected unauthenticated callers to get no tools, got %v", names)
+	}
+}
+
+func TestToolAccessControl_DeniesCalls(t *testing.T) {
+	s := newRBACServer(t, &config.Config{Roles: "alice=analyst"})
+
+	ctx := auth.WithIdentity(context.Background(), "alice")
+	response := s.MCPServer.HandleMessage(ctx, []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"write-cypher","arguments":{"query":"CREATE (n)"}}}`))
+	result, ok := response.(mcp.JSONRPCResponse)
+	if !ok {
+		t.Fatalf("Expected a tools/call response, got: %#v", response)
+	}
+	callResult := result.Result.(mcp.CallToolResult)
+	if !callResult.IsError {
+		t.Fatal("expected write-cypher to be denied for the analyst role")
+	}
+	if text := callResult.Content[0].(mcp.TextContent).Text; text != `tool "write-cypher" is not permitted for your role` {
+		t.Errorf("unexpected error message %q", text)
+	}
+}
+
+func TestToolAccessControl_DisabledForStdio(t *testing.T) {
+	if access := newToolAccessControl(&config.Config{RBACEnabled: true, TransportMode: config.TransportModeStdio}); access != nil {
+		t.Error("expected RBAC to be ignored in STDIO mode")
+	}
+	if !(*toolAccessControl)(nil).permitted(context.Background(), "write-cypher") {
+		t.Error("expected a nil access control to permit every tool")
+	}
+}
@@ -0,0 +1,147 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+const (
+	schemaResourceURI         = "neo4j-mcp://schema"
+	referenceModelResourceURI = "neo4j-mcp://reference-models/"
+	toolDocResourceURI        = "neo4j-mcp://tools/"
+)
+
+// registerResources exposes the database schema, the reference data models and the documentation
+// of the registered tools as MCP resources, so clients can load them as context without calling tools.
+// It must run after registerTools, since only registered tools are documented.
+func (s *Neo4jMCPServer) registerResources() {
+	deps := s.toolDependencies()
+	resources := []server.ServerResource{
+		{
+			Resource: mcp.NewResource(schemaResourceURI, "Database schema",
+				mcp.WithResourceDescription("Node labels, relationship types, properties, constraints and counts of the configured database, as returned by get-schema in json format"),
+				mcp.WithMIMEType("application/json"),
+			),
+			Handler: schemaResourceHandler(deps),
+		},
+	}
+
+	for _, model := range s.referenceModels.Models() {
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(referenceModelResourceURI+model.Name, "Reference data model: "+model.Name,
+				mcp.WithResourceDescription(model.Description),
+				mcp.WithMIMEType("text/plain"),
+			),
+			Handler: s.referenceModelResourceHandler(model.URL),
+		})
+	}
+
+	registeredTools := s.MCPServer.ListTools()
+	names := make([]string, 0, len(registeredTools))
+	for name := range registeredTools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tool := registeredTools[name].Tool
+		resources = append(resources, server.ServerResource{
+			Resource: mcp.NewResource(toolDocResourceURI+name, "Tool documentation: "+name,
+				mcp.WithResourceDescription(tool.Annotations.Title),
+				mcp.WithMIMEType("text/markdown"),
+			),
+			Handler: toolDocResourceHandler(tool),
+		})
+	}
+
+	s.MCPServer.AddResources(resources...)
+
+	// Tell clients to re-read the schema resource whenever a tool reloads the schema of the configured database
+	s.schemaCache.OnUpdate(func(key string) {
+		if key == s.dbService.GetDatabaseName(context.Background()) {
+			s.MCPServer.SendNotificationToAllClients(mcp.MethodNotificationResourceUpdated, map[string]any{"uri": schemaResourceURI})
+		}
+	})
+}
+
+func schemaResourceHandler(deps *tools.ToolDependencies) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		schema, err := cypher.LoadSchema(ctx, deps, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load schema: %w", err)
+		}
+		schema, _ = cypher.WithDescriptions(ctx, deps, schema)
+
+		content, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to format schema: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "application/json", Text: string(content)},
+		}, nil
+	}
+}
+
+func (s *Neo4jMCPServer) referenceModelResourceHandler(url string) server.ResourceHandlerFunc {
+	return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		content, _, err := s.referenceModels.Fetch(ctx, url, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch reference model: %w", err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/plain", Text: content},
+		}, nil
+	}
+}
+
+func toolDocResourceHandler(tool mcp.Tool) server.ResourceHandlerFunc {
+	return func(_ context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		content, err := toolDoc(tool)
+		if err != nil {
+			return nil, err
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: request.Params.URI, MIMEType: "text/markdown", Text: content},
+		}, nil
+	}
+}
+
+// toolDoc renders a tool's title, description, hints and input schema as markdown
+func toolDoc(tool mcp.Tool) (string, error) {
+	raw, err := json.Marshal(tool)
+	if err != nil {
+		return "", fmt.Errorf("failed to format tool %s: %w", tool.Name, err)
+	}
+	var definition struct {
+		InputSchema json.RawMessage `json:"inputSchema"`
+	}
+	if err := json.Unmarshal(raw, &definition); err != nil {
+		return "", fmt.Errorf("failed to format tool %s: %w", tool.Name, err)
+	}
+	inputSchema, err := json.MarshalIndent(definition.InputSchema, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format tool %s: %w", tool.Name, err)
+	}
+
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "# %s\n\n", tool.Name)
+	if tool.Annotations.Title != "" {
+		fmt.Fprintf(&doc, "**%s**\n\n", tool.Annotations.Title)
+	}
+	if tool.Annotations.ReadOnlyHint != nil {
+		fmt.Fprintf(&doc, "Read-only: %t\n\n", *tool.Annotations.ReadOnlyHint)
+	}
+	fmt.Fprintf(&doc, "## Description\n\n%s\n\n", strings.TrimSpace(tool.Description))
+	fmt.Fprintf(&doc, "## Input schema\n\n```json\n%s\n```\n", inputSchema)
+	return doc.String(), nil
+}
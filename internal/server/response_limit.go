@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// nextChunkTool is the tool returning the following chunks of a split response
+const nextChunkTool = "get-next-chunk"
+
+// limitResponseSize splits the text of tool results estimated above the chunker's token budget,
+// returning the first chunk with a continuation token instead of filling the client's context window
+func limitResponseSize(chunker *tools.ResponseChunker) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, request)
+			if err != nil || result == nil || result.IsError || request.Params.Name == nextChunkTool {
+				return result, err
+			}
+
+			for i, content := range result.Content {
+				text, ok := content.(mcp.TextContent)
+				if !ok {
+					continue
+				}
+				limited, split := chunker.Limit(ctx, text.Text)
+				if !split {
+					continue
+				}
+				slog.Info("Split a large tool response into chunks", "tool", request.Params.Name, "estimatedTokens", tools.EstimateTokens(text.Text))
+				text.Text = limited
+				result.Content[i] = text
+				// The structured copy of the response would be as large as the text
+				result.StructuredContent = nil
+			}
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+func TestLimitResponseSize(t *testing.T) {
+	chunker := tools.NewResponseChunker(100)
+	large := strings.Repeat("x", 2000)
+	handler := func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(large), nil
+	}
+	call := func(name string) *mcp.CallToolResult {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name}}
+		result, err := limitResponseSize(chunker)(handler)(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return result
+	}
+
+	t.Run("large responses are replaced by their first chunk", func(t *testing.T) {
+		text := call("read-cypher").Content[0].(mcp.TextContent).Text
+
+		var chunk tools.ResponseChunk
+		if err := json.Unmarshal([]byte(text), &chunk); err != nil {
+			t.Fatalf("expected a JSON chunk, got: %s", text)
+		}
+		if chunk.Chunk != 1 || chunk.TotalChunks < 2 || chunk.ContinuationToken == "" {
+			t.Errorf("expected the first of several chunks with a continuation token, got: %+v", chunk)
+		}
+	})
+
+	t.Run("chunks fetched with get-next-chunk are not split again", func(t *testing.T) {
+		if text := call(nextChunkTool).Content[0].(mcp.TextContent).Text; text != large {
+			t.Errorf("expected the get-next-chunk response unchanged")
+		}
+	})
+}
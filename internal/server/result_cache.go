@@ -0,0 +1,17 @@
+package server
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+)
+
+// cacheReadResults lets the read queries of an idempotent tool be served from the database service's result cache.
+// Caching is enabled with NEO4J_RESULT_CACHE_TTL; without it the handler runs unchanged.
+func cacheReadResults(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return next(database.WithResultCache(ctx), request)
+	}
+}
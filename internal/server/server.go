@@ -4,7 +4,6 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
-	"log"
 	"log/slog"
 	"net/http"
 	"os"
@@ -13,16 +12,24 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/prompts"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tracing"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 const (
-	protocolHTTP  = "http"
-	protocolHTTPS = "https"
+	protocolHTTP   = "http"
+	protocolHTTPS  = "https"
+	mcpPath        = "/mcp"     // Streamable HTTP endpoint
+	ssePath        = "/sse"     // SSE event stream endpoint
+	sseMessagePath = "/message" // SSE client message endpoint
 	serverHTTPShutdownTimeout   = 65 * time.Second // Timeout for graceful shutdown (must exceed WriteTimeout to allow active requests to complete)
 	serverHTTPReadHeaderTimeout = 5 * time.Second  // SECURITY: Maximum time to read request headers (prevents Slowloris attacks)
 	serverHTTPReadTimeout       = 15 * time.Second // SECURITY: Maximum time to read entire request including body (prevents slow-read attacks)
@@ -41,15 +48,36 @@ type Neo4jMCPServer struct {
 	version         string
 	anService       analytics.Service
 	gdsInstalled    bool
+	gdsCapabilities *tools.GDSCapabilities
+	capabilities    *capabilityReport // Set by the startup preflight checks, nil when they are skipped
+	schemaCache     *tools.SchemaCache
+	referenceModels *schema.ReferenceModelStore
+	queryStats      *database.QueryStats
+	toolAccess      *toolAccessControl
+	toolCatalog     *tools.ToolCatalog
+	responseChunker *tools.ResponseChunker // nil when responses are never split
+	mappingStore    *tools.MappingStore
+	enrichments     *tools.EnrichmentCache
+	metrics         *serverMetrics
+	metricsServer   *http.Server
+	tracer          *tracing.Tracer
+	auditor         *toolAuditor
+	toolCalls       *toolCallGate
 }
 
 // NewNeo4jMCPServer creates a new MCP server instance
 // The config parameter is expected to be already validated
 func NewNeo4jMCPServer(version string, cfg *config.Config, dbService database.Service, anService analytics.Service) *Neo4jMCPServer {
-	mcpServer := server.NewMCPServer(
-		"neo4j-mcp",
-		version,
+	queryStats := database.NewQueryStats(database.QueryStatsCapacity)
+	toolAccess := newToolAccessControl(cfg)
+	// The gate wraps every other middleware, so a shutdown waits for the whole call, audit record included
+	toolCalls := newToolCallGate()
+	serverOptions := []server.ServerOption{
 		server.WithToolCapabilities(true),
+		server.WithPromptCapabilities(false),
+		server.WithResourceCapabilities(false, true),
+		server.WithToolHandlerMiddleware(recordQueryStats(queryStats)),
+		server.WithToolHandlerMiddleware(toolCalls.middleware),
 		server.WithInstructions("This is the Neo4j official MCP server for fraud detection and banking applications. "+
 			"Available tools: "+
 			"get-schema (returns your database schema with fraud detection context), "+
@@ -57,7 +85,57 @@ func NewNeo4jMCPServer(version string, cfg *config.Config, dbService database.Se
 			"detect-synthetic-identity (finds customers sharing PII for fraud detection), "+
 			"read-cypher and write-cypher (execute Cypher queries), "+
 			"list-gds-procedures (discover graph data science functions)."),
-	)
+	}
+	// Tool call spans wrap the other middleware, so their time and errors are part of the span
+	tracer := newTracer(cfg, version)
+	if tracer != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(traceToolCalls(tracer)))
+	}
+	// Calls denied by access control or rate limiting are audited too
+	auditor := newToolAuditor(cfg)
+	if auditor != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(auditor.middleware))
+	}
+	// Metrics wrap access control and rate limiting, so denied and rate limited calls are counted as errors
+	var toolMetrics *serverMetrics
+	if cfg.MetricsAddress != "" {
+		toolMetrics = newServerMetrics(queryStats, dbService)
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(toolMetrics.middleware))
+	}
+	// With RBAC, each caller only lists and calls the tools granted by their roles
+	if toolAccess != nil {
+		serverOptions = append(serverOptions, server.WithToolFilter(toolAccess.filterTools), server.WithToolHandlerMiddleware(toolAccess.enforce))
+	}
+	// Each caller's tool calls run against their tenant's database
+	if routing := newTenantRouting(cfg); routing != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(routing.middleware))
+	}
+	// Personal data is masked for the tool calls that get past access control
+	if masking := newOutputMasking(cfg, toolAccess); masking != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(masking.middleware))
+	}
+	// Responses too large for the client's context window are returned in chunks
+	responseChunker := tools.NewResponseChunker(int(cfg.ResponseMaxTokens))
+	if responseChunker != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(limitResponseSize(responseChunker)))
+	}
+	// Per-client limits protect Neo4j from agents issuing too many tool calls
+	if limiter := newToolRateLimiter(cfg.MaxConcurrentToolCalls, cfg.ToolCallsPerMinute); limiter != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(limiter.middleware))
+	}
+	// Queries are checked innermost, so the other middleware sees a policy violation as the tool's result
+	if queryPolicy := newQueryPolicy(cfg); queryPolicy != nil {
+		serverOptions = append(serverOptions, server.WithToolHandlerMiddleware(enforceQueryPolicy(queryPolicy)))
+	}
+	mcpServer := server.NewMCPServer("neo4j-mcp", version, serverOptions...)
+
+	referenceModels := schema.NewReferenceModelStore(cfg.ReferenceModelCacheDir, time.Duration(cfg.ReferenceModelCacheTTL)*time.Second)
+	customModels, err := schema.ParseReferenceModelList(cfg.ReferenceModels)
+	if err != nil {
+		slog.Warn("ignoring invalid NEO4J_REFERENCE_MODELS entries", "error", err)
+	}
+	referenceModels.Register(customModels...)
+	referenceModels.AllowHosts(strings.Split(cfg.ReferenceModelHosts, ",")...)
 
 	return &Neo4jMCPServer{
 		MCPServer:       mcpServer,
@@ -68,6 +146,27 @@ func NewNeo4jMCPServer(version string, cfg *config.Config, dbService database.Se
 		version:         version,
 		anService:       anService,
 		gdsInstalled:    false,
+		schemaCache:     tools.NewSchemaCache(time.Duration(cfg.SchemaCacheTTL) * time.Second),
+		referenceModels: referenceModels,
+		queryStats:      queryStats,
+		toolAccess:      toolAccess,
+		toolCatalog:     tools.NewToolCatalog(),
+		responseChunker: responseChunker,
+		mappingStore:    tools.NewMappingStore(cfg.MappingStoreFile),
+		enrichments:     tools.NewEnrichmentCache(),
+		metrics:         toolMetrics,
+		tracer:          tracer,
+		auditor:         auditor,
+		toolCalls:       toolCalls,
+	}
+}
+
+// recordQueryStats tags each tool call so the queries it runs are recorded in stats under the tool name
+func recordQueryStats(stats *database.QueryStats) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return next(database.WithQueryStats(ctx, stats, request.Params.Name), request)
+		}
 	}
 }
 
@@ -84,13 +183,32 @@ func (s *Neo4jMCPServer) Start() error {
 	if err := s.registerTools(); err != nil {
 		return fmt.Errorf("failed to register tools: %w", err)
 	}
+	s.MCPServer.AddPrompts(prompts.Prompts()...)
+	s.registerResources()
+
+	if s.tracer != nil {
+		defer s.shutdownTracer()
+	}
+	if s.auditor != nil {
+		if err := s.auditor.open(); err != nil {
+			return err
+		}
+		defer s.auditor.close()
+	}
+	if s.metrics != nil {
+		if err := s.startMetricsServer(); err != nil {
+			return err
+		}
+	}
 
 	switch s.config.TransportMode {
-	case config.TransportModeHTTP:
+	case config.TransportModeHTTP, config.TransportModeSSE:
 		return s.StartHTTPServer()
 	case config.TransportModeStdio:
 		slog.Info("Starting stdio server")
-		return server.ServeStdio(s.MCPServer)
+		err := server.ServeStdio(s.MCPServer)
+		s.drainToolCalls(context.Background())
+		return err
 	default:
 		return fmt.Errorf("unsupported transport mode: %s", s.config.TransportMode)
 	}
@@ -118,12 +236,13 @@ func parseAllowedOrigins(allowedOriginsStr string) []string {
 // verifyRequirements check the Neo4j requirements:
 // - A valid connection with a Neo4j instance.
 // - The ability to perform a read query (database name is correctly defined).
-// - Required procedures available: db.schema.* (native Neo4j procedures for schema introspection)
-// - In case GDS is not installed a flag is set in the server and tools will be registered accordingly
-// Note: In HTTP mode, these checks are skipped at startup since credentials come from per-request Basic Auth headers.
+// - A supported Neo4j version (4.4 or later).
+// - The preflight capability checks (db.schema.* procedures, APOC, GDS version, write access); tools whose
+//   prerequisites are missing are not registered, see runPreflight.
+// Note: In HTTP mode with Basic Auth, these checks are skipped at startup since credentials come from per-request Basic Auth headers.
 func (s *Neo4jMCPServer) verifyRequirements() error {
-	// Skip verification in HTTP mode - credentials come from per-request Basic Auth headers
-	if s.config.TransportMode == config.TransportModeHTTP {
+	// Skip verification in HTTP mode with Basic Auth - credentials come from per-request Basic Auth headers
+	if !s.config.UsesServiceCredentials() {
 		slog.Info("Skipping startup verification in HTTP mode (credentials required per-request)")
 		return nil
 	}
@@ -153,32 +272,50 @@ func (s *Neo4jMCPServer) verifyRequirements() error {
 	if !ok || one != 1 {
 		return fmt.Errorf("failed to verify connectivity with the Neo4j instance: unexpected response from test query")
 	}
-	// Native schema procedures (db.schema.*) are available in Neo4j 4.0+
-	// We'll skip the explicit check here as it can block startup, and let the actual
-	// tool calls fail gracefully with proper error messages if procedures are missing
-	// Call gds.version procedure to determine if GDS is installed
-	records, err = s.dbService.ExecuteReadQuery(context.Background(), "RETURN gds.version() as gdsVersion", nil)
+	report, err := s.runPreflight(context.Background())
 	if err != nil {
-		// GDS is optional, so we log a warning and continue, assuming it's not installed.
-		log.Print("Impossible to verify GDS installation.")
-		s.gdsInstalled = false
-		return nil
+		return err
 	}
-	if len(records) == 1 && len(records[0].Values) == 1 {
-		_, ok := records[0].Values[0].(string)
-		if ok {
-			s.gdsInstalled = true
-		}
+	s.capabilities = report
+	if report.gdsSupported() {
+		s.gdsInstalled = true
+		s.gdsCapabilities = s.detectGDSCapabilities(report.gdsVersion)
 	}
+	report.log()
 
 	return nil
 }
 
+// detectGDSCapabilities lists the installed GDS procedures so tools can adapt to the GDS version.
+// Listing is best effort: on failure the version is still recorded, without procedure families.
+func (s *Neo4jMCPServer) detectGDSCapabilities(gdsVersion string) *tools.GDSCapabilities {
+	records, err := s.dbService.ExecuteReadQuery(context.Background(), "CALL gds.list() YIELD name RETURN name", nil)
+	if err != nil {
+		slog.Warn("Impossible to list GDS procedures, GDS capabilities are unknown", "error", err)
+		return tools.NewGDSCapabilities(gdsVersion, nil)
+	}
+
+	procedures := make([]string, 0, len(records))
+	for _, record := range records {
+		nameRaw, ok := record.Get("name")
+		if !ok {
+			continue
+		}
+		if name, ok := nameRaw.(string); ok {
+			procedures = append(procedures, name)
+		}
+	}
+
+	capabilities := tools.NewGDSCapabilities(gdsVersion, procedures)
+	slog.Info("Detected GDS capabilities", "version", gdsVersion, "families", capabilities.Families)
+	return capabilities
+}
+
 func (s *Neo4jMCPServer) emitStartupEvent() {
 	var startupInfo analytics.StartupEventInfo
 
-	// In HTTP mode, skip database query since credentials come from per-request Basic Auth headers
-	if s.config.TransportMode == config.TransportModeHTTP {
+	// In HTTP mode with Basic Auth, skip database query since credentials come from per-request Basic Auth headers
+	if !s.config.UsesServiceCredentials() {
 		startupInfo = analytics.StartupEventInfo{
 			Neo4jVersion:  "unknown-http-mode",
 			Edition:       "unknown-http-mode",
@@ -186,15 +323,13 @@ func (s *Neo4jMCPServer) emitStartupEvent() {
 			McpVersion:    s.version,
 		}
 	} else {
-		// CALL dbms.components() to collect meta information about the database such version, edition, Cypher version supported
-		records, err := s.dbService.ExecuteReadQuery(context.Background(), "CALL dbms.components()", map[string]any{})
-
-		if err != nil {
+		// The preflight checks collected the version, edition and Cypher versions from dbms.components()
+		if s.capabilities == nil || s.capabilities.server == nil {
 			slog.Debug("Impossible to collect information using DBMS component, dbms.components() query failed")
 			return
 		}
 
-		startupInfo = recordsToStartupEventInfo(records, s.version)
+		startupInfo = *s.capabilities.server
 	}
 
 	// track startup event
@@ -288,8 +423,18 @@ func (s *Neo4jMCPServer) buildTLSConfig() (*tls.Config, error) {
 	return tlsConfig, nil
 }
 
-// Stop gracefully stops the HTTP server
+// Stop gracefully stops the HTTP server and the metrics server once the tool calls in flight are drained,
+// and exports the pending trace spans
 func (s *Neo4jMCPServer) Stop(ctx context.Context) error {
+	if s.tracer != nil {
+		defer s.shutdownTracer()
+	}
+	s.drainToolCalls(ctx)
+	if s.metricsServer != nil {
+		if err := s.metricsServer.Shutdown(ctx); err != nil {
+			slog.Error("Error shutting down metrics server", "error", err)
+		}
+	}
 	if s.httpServer != nil {
 		slog.Info("Stopping HTTP server...")
 		if err := s.httpServer.Shutdown(ctx); err != nil {
@@ -303,6 +448,20 @@ func (s *Neo4jMCPServer) Stop(ctx context.Context) error {
 	return nil
 }
 
+// transportHandler returns the MCP handler for the configured HTTP transport, the paths it serves,
+// and the write timeout suited to it
+func (s *Neo4jMCPServer) transportHandler() (http.Handler, []string, time.Duration) {
+	if s.config.TransportMode == config.TransportModeSSE {
+		// SSE keeps one event stream open per session on /sse and receives client messages on /message.
+		// The stream outlives any write timeout, so it is disabled; keep-alive pings detect dead clients.
+		return server.NewSSEServer(s.MCPServer, server.WithKeepAlive(true)), []string{ssePath, sseMessagePath}, 0
+	}
+
+	// Streamable HTTP serves stateless requests on /mcp.
+	// Timeouts are optimized for stateless HTTP MCP requests.
+	return server.NewStreamableHTTPServer(s.MCPServer, server.WithStateLess(true)), []string{mcpPath}, serverHTTPWriteTimeout
+}
+
 func (s *Neo4jMCPServer) StartHTTPServer() error {
 	addr := fmt.Sprintf("%s:%s", s.config.HTTPHost, s.config.HTTPPort)
 	protocol := protocolHTTP
@@ -311,20 +470,21 @@ func (s *Neo4jMCPServer) StartHTTPServer() error {
 	}
 	slog.Info("Starting HTTP server", "address", addr, "url", fmt.Sprintf("%s://%s", protocol, addr), "tls", s.config.HTTPTLSEnabled)
 
-	// Create the StreamableHTTPServer - it serves on /mcp path by default
-	mcpServerHTTP := server.NewStreamableHTTPServer(
-		s.MCPServer,
-		server.WithStateLess(true),
-	)
+	mcpHandler, paths, writeTimeout := s.transportHandler()
+
+	authenticate, err := authMiddleware(s.config)
+	if err != nil {
+		return fmt.Errorf("failed to configure HTTP authentication: %w", err)
+	}
+	slog.Info("HTTP authentication configured", "mode", s.config.HTTPAuthMode)
 
 	allowedOrigins := parseAllowedOrigins(s.config.HTTPAllowedOrigins)
 	// Wrap handler with middleware and create HTTP server
 	s.httpServer = &http.Server{
-		Addr:    addr,
-		Handler: chainMiddleware(allowedOrigins, mcpServerHTTP),
-		// Timeouts optimized for stateless HTTP MCP requests
+		Addr:              addr,
+		Handler:           s.healthHandler(traceContextMiddleware(chainMiddleware(allowedOrigins, paths, authenticate, mcpHandler))),
 		ReadTimeout:       serverHTTPReadTimeout,
-		WriteTimeout:      serverHTTPWriteTimeout,
+		WriteTimeout:      writeTimeout,
 		IdleTimeout:       serverHTTPIdleTimeout,
 		ReadHeaderTimeout: serverHTTPReadHeaderTimeout,
 	}
@@ -367,6 +527,7 @@ func (s *Neo4jMCPServer) StartHTTPServer() error {
 		slog.Info("Shutdown signal received", "signal", sig.String())
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverHTTPShutdownTimeout)
 		defer cancel()
+		s.drainToolCalls(shutdownCtx)
 		if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
 			slog.Error("Error during server shutdown", "error", err)
 			return err
@@ -72,6 +72,43 @@ func TestHTTPServerPortConfiguration(t *testing.T) {
 	}
 }
 
+// TestTransportHandler verifies each HTTP transport serves its own paths with a suitable write timeout
+func TestTransportHandler(t *testing.T) {
+	tests := []struct {
+		transportMode string
+		paths         []string
+		writeTimeout  time.Duration
+	}{
+		{transportMode: config.TransportModeHTTP, paths: []string{"/mcp"}, writeTimeout: serverHTTPWriteTimeout},
+		{transportMode: config.TransportModeSSE, paths: []string{"/sse", "/message"}, writeTimeout: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.transportMode, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			cfg := &config.Config{
+				URI:           "bolt://test-host:7687",
+				Database:      "neo4j",
+				TransportMode: tt.transportMode,
+			}
+			srv := NewNeo4jMCPServer("test-version", cfg, db.NewMockService(ctrl), analytics.NewMockService(ctrl))
+
+			handler, paths, writeTimeout := srv.transportHandler()
+			if handler == nil {
+				t.Fatal("Expected non-nil handler")
+			}
+			if len(paths) != len(tt.paths) || paths[0] != tt.paths[0] {
+				t.Errorf("Expected paths %v, got %v", tt.paths, paths)
+			}
+			if writeTimeout != tt.writeTimeout {
+				t.Errorf("Expected write timeout %v, got %v", tt.writeTimeout, writeTimeout)
+			}
+		})
+	}
+}
+
 // TestHTTPServerTLSConfiguration verifies that TLS settings are correctly stored in server config
 func TestHTTPServerTLSConfiguration(t *testing.T) {
 	// Generate test certificates dynamically for TLS test
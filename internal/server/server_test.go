@@ -32,6 +32,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 	t.Run("starts server successfully", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Times(1)
+		expectPreflightChecks(mockDB)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 as first", gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
 				Keys: []string{"first"},
@@ -40,15 +41,6 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
-		checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'db.schema.visualization' RETURN count(name) > 0 AS schemaVisualizationAvailable"
-		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
-			{
-				Keys: []string{"schemaVisualizationAvailable"},
-				Values: []any{
-					bool(true),
-				},
-			},
-		}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -58,6 +50,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL gds.list() YIELD name RETURN name", gomock.Any()).Times(1)
 
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
 
@@ -111,6 +104,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 	t.Run("server creates successfully with all required components", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Times(1)
+		expectPreflightChecks(mockDB)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 as first", gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
 				Keys: []string{"first"},
@@ -119,15 +113,6 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
-		checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'db.schema.visualization' RETURN count(name) > 0 AS schemaVisualizationAvailable"
-		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
-			{
-				Keys: []string{"schemaVisualizationAvailable"},
-				Values: []any{
-					bool(true),
-				},
-			},
-		}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -137,6 +122,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL gds.list() YIELD name RETURN name", gomock.Any()).Times(1)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
 
 		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, analyticsService)
@@ -162,6 +148,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 	t.Run("starts server successfully if GDS is not found", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Times(1)
+		expectPreflightChecks(mockDB)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 as first", gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
 				Keys: []string{"first"},
@@ -170,15 +157,6 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
-		checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'db.schema.visualization' RETURN count(name) > 0 AS schemaVisualizationAvailable"
-		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
-			{
-				Keys: []string{"schemaVisualizationAvailable"},
-				Values: []any{
-					bool(true),
-				},
-			},
-		}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return(nil, fmt.Errorf("Unknown function 'gds.version'"))
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
@@ -197,6 +175,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 	t.Run("stops server successfully", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Times(1)
+		expectPreflightChecks(mockDB)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 as first", gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
 				Keys: []string{"first"},
@@ -205,15 +184,6 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
-		checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'db.schema.visualization' RETURN count(name) > 0 AS schemaVisualizationAvailable"
-		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
-			{
-				Keys: []string{"schemaVisualizationAvailable"},
-				Values: []any{
-					bool(true),
-				},
-			},
-		}, nil)
 		gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
 			{
@@ -223,6 +193,7 @@ func TestNewNeo4jMCPServer(t *testing.T) {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL gds.list() YIELD name RETURN name", gomock.Any()).Times(1)
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
 
 		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, analyticsService)
@@ -260,15 +231,7 @@ func TestNewNeo4jMCPServerEvents(t *testing.T) {
 			},
 		},
 	}, nil)
-	checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'db.schema.visualization' RETURN count(name) > 0 AS schemaVisualizationAvailable"
-	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).AnyTimes().Return([]*neo4j.Record{
-		{
-			Keys: []string{"schemaVisualizationAvailable"},
-			Values: []any{
-				bool(true),
-			},
-		},
-	}, nil)
+	expectPreflightChecks(mockDB)
 	gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).AnyTimes().Return([]*neo4j.Record{
 		{
@@ -278,6 +241,7 @@ func TestNewNeo4jMCPServerEvents(t *testing.T) {
 			},
 		},
 	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL gds.list() YIELD name RETURN name", gomock.Any()).AnyTimes()
 	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1).Return([]*neo4j.Record{
 		{
 			Keys:   []string{"name", "edition", "versions"},
@@ -310,3 +274,18 @@ func TestNewNeo4jMCPServerEvents(t *testing.T) {
 		}
 	})
 }
+
+// expectPreflightChecks mocks the startup capability checks: all db.schema procedures present,
+// no APOC, and a write probe that succeeds and is rolled back
+func expectPreflightChecks(mockDB *db.MockService) {
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "SHOW PROCEDURES YIELD name WHERE name IN $names RETURN collect(name) AS names", gomock.Any()).AnyTimes().Return([]*neo4j.Record{
+		{
+			Keys:   []string{"names"},
+			Values: []any{[]any{"db.schema.visualization", "db.schema.nodeTypeProperties", "db.schema.relTypeProperties"}},
+		},
+	}, nil)
+	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN apoc.version() AS apocVersion", gomock.Any()).AnyTimes().Return(nil, fmt.Errorf("Unknown function 'apoc.version'"))
+	mockDB.EXPECT().BeginTransaction(gomock.Any()).AnyTimes().Return("preflight-tx", nil)
+	mockDB.EXPECT().RunInTransaction(gomock.Any(), "preflight-tx", "CREATE (probe) DELETE probe", gomock.Any()).AnyTimes()
+	mockDB.EXPECT().RollbackTransaction(gomock.Any(), "preflight-tx").AnyTimes()
+}
@@ -0,0 +1,124 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// shutdownCancelGrace is how long a shutdown waits for cancelled tool calls to return,
+// which includes terminating their queries on the server
+const shutdownCancelGrace = 5 * time.Second
+
+// shutdownStatus is the readiness status reported while the server shuts down
+const shutdownStatus = "shutting down"
+
+// toolCallGate tracks the tool calls in flight, so a shutdown can stop accepting new calls
+// and wait for the running ones, cancelling those that outlast the shutdown timeout.
+type toolCallGate struct {
+	mu       sync.Mutex
+	closed   bool
+	nextID   uint64
+	inFlight map[uint64]context.CancelFunc
+	done     sync.WaitGroup
+}
+
+func newToolCallGate() *toolCallGate {
+	return &toolCallGate{inFlight: make(map[uint64]context.CancelFunc)}
+}
+
+// middleware runs tool calls with a context the gate can cancel, and rejects them once the gate is closed
+func (g *toolCallGate) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		g.mu.Lock()
+		if g.closed {
+			g.mu.Unlock()
+			return mcp.NewToolResultError("the server is shutting down, retry the tool call once it is back"), nil
+		}
+		ctx, cancel := context.WithCancel(ctx)
+		id := g.nextID
+		g.nextID++
+		g.inFlight[id] = cancel
+		g.done.Add(1)
+		g.mu.Unlock()
+
+		defer func() {
+			g.mu.Lock()
+			delete(g.inFlight, id)
+			g.mu.Unlock()
+			cancel()
+			g.done.Done()
+		}()
+		return next(ctx, request)
+	}
+}
+
+// close stops accepting tool calls
+func (g *toolCallGate) close() {
+	g.mu.Lock()
+	g.closed = true
+	g.mu.Unlock()
+}
+
+func (g *toolCallGate) isClosed() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.closed
+}
+
+// drain waits up to timeout for the tool calls in flight to finish, then cancels the remaining ones,
+// which terminates their queries, and waits shutdownCancelGrace for them to return.
+// It reports whether every call returned.
+func (g *toolCallGate) drain(timeout time.Duration) bool {
+	finished := make(chan struct{})
+	go func() {
+		g.done.Wait()
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+		return true
+	case <-time.After(timeout):
+	}
+
+	g.mu.Lock()
+	slog.Warn("Cancelling tool calls still running at shutdown", "count", len(g.inFlight), "timeout", timeout)
+	for _, cancel := range g.inFlight {
+		cancel()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-finished:
+		return true
+	case <-time.After(shutdownCancelGrace):
+		return false
+	}
+}
+
+// transactionCloser is implemented by database services holding explicit transactions open across tool calls
+type transactionCloser interface {
+	RollbackOpenTransactions(ctx context.Context) int
+}
+
+// drainToolCalls stops accepting tool calls, waits for the ones in flight within the shutdown timeout,
+// and rolls back the explicit transactions left open, so a restart leaves no transaction dangling on Neo4j.
+// Analytics events are sent within the tool calls, so they are flushed once the calls return.
+func (s *Neo4jMCPServer) drainToolCalls(ctx context.Context) {
+	s.toolCalls.close()
+	timeout := time.Duration(max(s.config.ShutdownTimeout, 0)) * time.Second
+	if !s.toolCalls.drain(timeout) {
+		slog.Error("Tool calls did not return after being cancelled, closing the driver aborts their queries")
+	}
+
+	if closer, ok := s.dbService.(transactionCloser); ok {
+		if rolledBack := closer.RollbackOpenTransactions(ctx); rolledBack > 0 {
+			slog.Info("Rolled back open transactions at shutdown", "count", rolledBack)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestToolCallGate_RejectsCallsOnceClosed(t *testing.T) {
+	gate := newToolCallGate()
+	handler := gate.middleware(func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || result.IsError {
+		t.Fatalf("handler() = %v, %v, want success", result, err)
+	}
+
+	gate.close()
+	result, err = handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("handler() unexpected error = %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected tool calls to be rejected once the gate is closed")
+	}
+}
+
+func TestToolCallGate_DrainWaitsForCallsInFlight(t *testing.T) {
+	gate := newToolCallGate()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	handler := gate.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+		if ctx.Err() != nil {
+			t.Error("expected the call not to be cancelled within the shutdown timeout")
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+	go func() { _, _ = handler(context.Background(), mcp.CallToolRequest{}) }()
+	<-started
+
+	gate.close()
+	time.AfterFunc(50*time.Millisecond, func() { close(release) })
+	if !gate.drain(5 * time.Second) {
+		t.Error("drain() = false, want true")
+	}
+}
+
+func TestToolCallGate_DrainCancelsCallsOutlastingTheTimeout(t *testing.T) {
+	gate := newToolCallGate()
+	started := make(chan struct{})
+	handler := gate.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	go func() { _, _ = handler(context.Background(), mcp.CallToolRequest{}) }()
+	<-started
+
+	gate.close()
+	if !gate.drain(10 * time.Millisecond) {
+		t.Error("drain() = false, want the cancelled call to return")
+	}
+}
+
+// transactionService is a database service holding explicit transactions
+type transactionService struct {
+	*db.MockService
+	rolledBack bool
+}
+
+func (s *transactionService) RollbackOpenTransactions(_ context.Context) int {
+	s.rolledBack = true
+	return 1
+}
+
+func TestDrainToolCalls_RollsBackOpenTransactions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := &transactionService{MockService: db.NewMockService(ctrl)}
+	s := NewNeo4jMCPServer("test-version", &config.Config{URI: "bolt://test-host:7687", ShutdownTimeout: 1}, service, nil)
+
+	s.drainToolCalls(context.Background())
+	if !service.rolledBack {
+		t.Error("expected the open transactions to be rolled back")
+	}
+	if !s.toolCalls.isClosed() {
+		t.Error("expected tool calls to be rejected after the drain")
+	}
+}
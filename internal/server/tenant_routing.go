@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tenant"
+)
+
+// tenantRouting runs the tool calls of each HTTP caller against the database and credentials of their tenant,
+// so one server instance can serve isolated fraud graphs
+type tenantRouting struct {
+	router *tenant.Router
+}
+
+// newTenantRouting returns the tenant routing of the configuration, or nil when no tenants file is set.
+// Tenant routing only applies to the HTTP transports, since STDIO has a single local caller.
+func newTenantRouting(cfg *config.Config) *tenantRouting {
+	if cfg.TenantsFile == "" {
+		return nil
+	}
+	if !config.IsHTTPTransport(cfg.TransportMode) {
+		slog.Warn("Ignoring NEO4J_MCP_TENANTS_FILE, tenant routing only applies to HTTP transport modes")
+		return nil
+	}
+	if !cfg.UsesServiceCredentials() {
+		// Basic Auth usernames are not verified by the server, so they cannot select a tenant and its credentials
+		slog.Error("Tenant routing requires api-key or oidc HTTP auth mode, denying all tool calls")
+		return &tenantRouting{}
+	}
+
+	// The configuration is validated, so the file loads. Should it fail now, every call is denied
+	// rather than run against the shared database.
+	router, err := tenant.Load(cfg.TenantsFile)
+	if err != nil {
+		slog.Error("Failed to load tenants, denying all tool calls", "error", err)
+	} else {
+		slog.Info("Tenant routing enabled", "tenants", router.Names())
+	}
+	return &tenantRouting{router: router}
+}
+
+// middleware binds each tool call to the caller's tenant, rejecting callers without one
+// and calls naming a database other than the tenant's
+func (r *tenantRouting) middleware(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		identity, _ := auth.GetIdentity(ctx)
+		t, ok := r.router.Resolve(identity)
+		if !ok {
+			slog.Warn("Denied tool call without a tenant", "tool", request.Params.Name, "identity", identity)
+			return mcp.NewToolResultError("no tenant is configured for your identity"), nil
+		}
+		if name, _ := request.GetArguments()["database"].(string); name != "" && name != t.Database {
+			slog.Warn("Denied tool call outside the tenant's database", "tool", request.Params.Name, "identity", identity, "tenant", t.Name, "database", name)
+			return mcp.NewToolResultError(fmt.Sprintf("database %q is not available to your tenant", name)), nil
+		}
+		return next(database.WithTenant(ctx, t), request)
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+func TestTenantRouting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	data := `{"tenants": {"retail": {"database": "retail"}, "sandbox": {"database": "sandbox_acme"}}, "identities": {"alice": "retail", "acme-bot": "sandbox"}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if routing := newTenantRouting(&config.Config{TransportMode: config.TransportModeStdio, TenantsFile: path}); routing != nil {
+		t.Error("expected tenant routing to be ignored in STDIO mode")
+	}
+	routing := newTenantRouting(&config.Config{TransportMode: config.TransportModeHTTP, HTTPAuthMode: config.HTTPAuthAPIKey, TenantsFile: path})
+	if routing == nil {
+		t.Fatal("expected tenant routing to be enabled")
+	}
+
+	driver, err := neo4j.NewDriverWithContext("bolt://localhost:7687", neo4j.NoAuth())
+	if err != nil {
+		t.Fatalf("failed to create driver: %v", err)
+	}
+	defer driver.Close(context.Background())
+	service, err := database.NewNeo4jService(driver, "neo4j", config.TransportModeHTTP, "test-version")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler := routing.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText(service.GetDatabaseName(ctx)), nil
+	})
+	call := func(identity string, arguments map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Name = "read-cypher"
+		request.Params.Arguments = arguments
+		result, err := handler(auth.WithIdentity(context.Background(), identity), request)
+		if err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		return result
+	}
+	text := func(result *mcp.CallToolResult) string {
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	t.Run("calls run against the caller's tenant database", func(t *testing.T) {
+		if result := call("acme-bot", nil); result.IsError || text(result) != "sandbox_acme" {
+			t.Errorf("expected the sandbox_acme database, got %s", text(result))
+		}
+		if result := call("alice", map[string]any{"database": "retail"}); result.IsError || text(result) != "retail" {
+			t.Errorf("expected the retail database, got %s", text(result))
+		}
+	})
+
+	t.Run("other databases are denied", func(t *testing.T) {
+		result := call("alice", map[string]any{"database": "sandbox_acme"})
+		if !result.IsError || !strings.Contains(text(result), "not available to your tenant") {
+			t.Errorf("expected the database to be denied, got %s", text(result))
+		}
+	})
+
+	t.Run("callers without a tenant are denied", func(t *testing.T) {
+		result := call("mallory", nil)
+		if !result.IsError || !strings.Contains(text(result), "no tenant is configured") {
+			t.Errorf("expected the call to be denied, got %s", text(result))
+		}
+	})
+
+	t.Run("Basic Auth callers with a wrong password get no tenant", func(t *testing.T) {
+		basic := newTenantRouting(&config.Config{TransportMode: config.TransportModeHTTP, HTTPAuthMode: config.HTTPAuthBasic, TenantsFile: path})
+		handler := basic.middleware(func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			return mcp.NewToolResultText(service.GetDatabaseName(ctx)), nil
+		})
+
+		ctx := auth.WithIdentity(auth.WithBasicAuth(context.Background(), "alice", "wrong-password"), "alice")
+		result, err := handler(ctx, mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		if !result.IsError {
+			t.Errorf("expected the call to be denied, got %s", text(result))
+		}
+	})
+}
@@ -1,9 +1,12 @@
 package server_test
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
 	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
@@ -34,8 +37,8 @@ func TestToolRegister(t *testing.T) {
 
 		// Expected tools that should be registered
 		// update this number when a tool is added or removed.
-		// Current tools: get-schema, read-cypher, write-cypher, list-gds-procedures, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile
-		expectedTotalToolsCount := 8
+		// Current tools: get-schema, read-cypher, write-cypher, list-gds-procedures, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile, get-transaction-history, get-account-profile, get-merchant-profile, get-entity-network, find-connection, compute-risk-score, create-investigation-case, flag-entity, gather-sar-evidence, generate-sar-draft, get-ctr-evidence, audit-kyc-completeness, create-gds-projection, list-gds-projections, drop-gds-projection, run-community-detection, write-community-detection, run-centrality, write-centrality, run-node-similarity, find-similar-to-seeds, estimate-gds-memory, list-capabilities, configure-link-prediction-pipeline, train-link-prediction-model, predict-links, validate-schema, suggest-attribute-mappings, begin-transaction, run-in-transaction, commit-transaction, rollback-transaction, batch-cypher, cancel-query, get-query-stats, list-available-tools, investigate-customer, health-check
+		expectedTotalToolsCount := 49
 
 		// Start server and register tools
 		err := s.Start()
@@ -64,8 +67,8 @@ func TestToolRegister(t *testing.T) {
 
 		// Expected tools that should be registered
 		// update this number when a tool is added or removed.
-		// Readonly tools: get-schema, read-cypher, list-gds-procedures, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile
-		expectedTotalToolsCount := 7
+		// Readonly tools: get-schema, read-cypher, list-gds-procedures, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile, get-transaction-history, get-account-profile, get-merchant-profile, get-entity-network, find-connection, compute-risk-score, gather-sar-evidence, generate-sar-draft, get-ctr-evidence, audit-kyc-completeness, create-gds-projection, list-gds-projections, drop-gds-projection, run-community-detection, run-centrality, run-node-similarity, find-similar-to-seeds, estimate-gds-memory, list-capabilities, configure-link-prediction-pipeline, train-link-prediction-model, predict-links, validate-schema, suggest-attribute-mappings, get-query-stats, list-available-tools, investigate-customer, health-check
+		expectedTotalToolsCount := 38
 
 		// Start server and register tools
 		err := s.Start()
@@ -77,6 +80,11 @@ func TestToolRegister(t *testing.T) {
 		if expectedTotalToolsCount != registeredTools {
 			t.Errorf("Expected %d tools, but test configuration shows %d", expectedTotalToolsCount, registeredTools)
 		}
+		for _, name := range []string{"write-community-detection", "write-centrality"} {
+			if _, ok := s.MCPServer.ListTools()[name]; ok {
+				t.Errorf("Expected %s not to be registered in read-only mode", name)
+			}
+		}
 	})
 	t.Run("should register also not write tools when readonly is set to false", func(t *testing.T) {
 		mockDB := getMockedDBService(ctrl, true)
@@ -93,8 +101,8 @@ func TestToolRegister(t *testing.T) {
 
 		// Expected tools that should be registered
 		// update this number when a tool is added or removed.
-		// All tools: get-schema, read-cypher, write-cypher, list-gds-procedures, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile
-		expectedTotalToolsCount := 8
+		// All tools: get-schema, read-cypher, write-cypher, list-gds-procedures, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile, get-transaction-history, get-account-profile, get-merchant-profile, get-entity-network, find-connection, compute-risk-score, create-investigation-case, flag-entity, gather-sar-evidence, generate-sar-draft, get-ctr-evidence, audit-kyc-completeness, create-gds-projection, list-gds-projections, drop-gds-projection, run-community-detection, write-community-detection, run-centrality, write-centrality, run-node-similarity, find-similar-to-seeds, estimate-gds-memory, list-capabilities, configure-link-prediction-pipeline, train-link-prediction-model, predict-links, validate-schema, suggest-attribute-mappings, begin-transaction, run-in-transaction, commit-transaction, rollback-transaction, batch-cypher, cancel-query, get-query-stats, list-available-tools, investigate-customer, health-check
+		expectedTotalToolsCount := 49
 
 		// Start server and register tools
 		err := s.Start()
@@ -123,8 +131,36 @@ func TestToolRegister(t *testing.T) {
 
 		// Expected tools that should be registered
 		// update this number when a tool is added or removed.
-		// Non-GDS tools: get-schema, read-cypher, write-cypher, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile
-		expectedTotalToolsCount := 7
+		// Non-GDS tools: get-schema, read-cypher, write-cypher, detect-synthetic-identity, get-sar-report-guidance, get-neo4j-reference-data-models, get-customer-profile, get-transaction-history, get-account-profile, get-merchant-profile, get-entity-network, find-connection, compute-risk-score, create-investigation-case, flag-entity, gather-sar-evidence, generate-sar-draft, get-ctr-evidence, audit-kyc-completeness, list-capabilities, validate-schema, suggest-attribute-mappings, begin-transaction, run-in-transaction, commit-transaction, rollback-transaction, batch-cypher, cancel-query, get-query-stats, list-available-tools, investigate-customer, health-check
+		expectedTotalToolsCount := 35
+
+		// Start server and register tools
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+		registeredTools := len(s.MCPServer.ListTools())
+
+		if expectedTotalToolsCount != registeredTools {
+			t.Errorf("Expected %d tools, but test configuration shows %d", expectedTotalToolsCount, registeredTools)
+		}
+	})
+
+	t.Run("should register admin tools when enabled", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			AdminTools:    true,
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		// All tools plus the admin tools: list-running-queries, kill-query
+		expectedTotalToolsCount := 51
 
 		// Start server and register tools
 		err := s.Start()
@@ -137,12 +173,252 @@ func TestToolRegister(t *testing.T) {
 			t.Errorf("Expected %d tools, but test configuration shows %d", expectedTotalToolsCount, registeredTools)
 		}
 	})
+
+	t.Run("should register only the allowlisted tools and categories", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			EnabledTools:  "schema, read-cypher, kill-query",
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		// schema category: get-neo4j-reference-data-models, validate-schema, suggest-attribute-mappings,
+		// save-schema-mapping, list-schema-mappings, store-enriched-schema; plus read-cypher.
+		// kill-query stays disabled because admin tools are not enabled.
+		expectedTotalToolsCount := 7
+
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+		registeredTools := s.MCPServer.ListTools()
+
+		if expectedTotalToolsCount != len(registeredTools) {
+			t.Errorf("Expected %d tools, but test configuration shows %d", expectedTotalToolsCount, len(registeredTools))
+		}
+		if _, ok := registeredTools["read-cypher"]; !ok {
+			t.Error("Expected read-cypher to be registered")
+		}
+	})
+
+	t.Run("should not register denylisted tools and categories", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			DisabledTools: "gds,write-cypher,no-such-tool",
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		// All tools minus the 12 GDS tools and write-cypher
+		expectedTotalToolsCount := 34
+
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+		registeredTools := s.MCPServer.ListTools()
+
+		if expectedTotalToolsCount != len(registeredTools) {
+			t.Errorf("Expected %d tools, but test configuration shows %d", expectedTotalToolsCount, len(registeredTools))
+		}
+		if _, ok := registeredTools["write-cypher"]; ok {
+			t.Error("Expected write-cypher not to be registered")
+		}
+	})
+
+	t.Run("should register only the categories of the deployment profile", func(t *testing.T) {
+		tests := []struct {
+			profile  string
+			expected int
+			included string
+			excluded string
+		}{
+			{profile: config.ProfileInvestigator, expected: 29, included: "detect-synthetic-identity", excluded: "run-centrality"},
+			{profile: config.ProfileAnalyst, expected: 39, included: "run-centrality", excluded: "generate-sar-draft"},
+			{profile: config.ProfileAdmin, expected: 51, included: "kill-query", excluded: ""},
+			{profile: config.ProfileDemo, expected: 26, included: "validate-schema", excluded: "write-cypher"},
+		}
+		for _, tt := range tests {
+			mockDB := getMockedDBService(ctrl, true)
+			mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+			cfg := &config.Config{
+				URI:           "bolt://test-host:7687",
+				Username:      "neo4j",
+				Password:      "password",
+				Database:      "neo4j",
+				Profile:       tt.profile,
+				TransportMode: config.TransportModeStdio,
+			}
+			s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+			err := s.Start()
+			if err != nil {
+				t.Fatalf("Start() failed: %v", err)
+			}
+			registeredTools := s.MCPServer.ListTools()
+
+			if tt.expected != len(registeredTools) {
+				t.Errorf("%s profile: expected %d tools, but test configuration shows %d", tt.profile, tt.expected, len(registeredTools))
+			}
+			if _, ok := registeredTools[tt.included]; !ok {
+				t.Errorf("%s profile: expected %s to be registered", tt.profile, tt.included)
+			}
+			if _, ok := registeredTools[tt.excluded]; ok {
+				t.Errorf("%s profile: expected %s not to be registered", tt.profile, tt.excluded)
+			}
+		}
+	})
+
+	t.Run("registers get-next-chunk only when responses are chunked", func(t *testing.T) {
+		for _, maxTokens := range []int32{0, 20000} {
+			mockDB := getMockedDBService(ctrl, true)
+			mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+			cfg := &config.Config{
+				URI:               "bolt://test-host:7687",
+				Username:          "neo4j",
+				Password:          "password",
+				Database:          "neo4j",
+				ResponseMaxTokens: maxTokens,
+				TransportMode:     config.TransportModeStdio,
+			}
+			s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+			if err := s.Start(); err != nil {
+				t.Fatalf("Start() failed: %v", err)
+			}
+			_, registered := s.MCPServer.ListTools()["get-next-chunk"]
+			if registered != (maxTokens > 0) {
+				t.Errorf("ResponseMaxTokens %d: get-next-chunk registered = %v", maxTokens, registered)
+			}
+		}
+	})
+
+	t.Run("should register the prompts", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+
+		response := s.MCPServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`))
+		result, ok := response.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("Expected a prompts/list response, got: %#v", response)
+		}
+		prompts := result.Result.(mcp.ListPromptsResult).Prompts
+		if len(prompts) != 3 {
+			t.Errorf("Expected 3 prompts, got %d", len(prompts))
+		}
+	})
+
+	t.Run("should register the schema, reference model and tool resources", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			ReadOnly:      true,
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+
+		response := s.MCPServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+		result, ok := response.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("Expected a resources/list response, got: %#v", response)
+		}
+		uris := make(map[string]bool)
+		for _, resource := range result.Result.(mcp.ListResourcesResult).Resources {
+			uris[resource.URI] = true
+		}
+		// schema, 2 built-in reference models and the 38 read-only tools
+		if len(uris) != 41 {
+			t.Errorf("Expected 41 resources, got %d", len(uris))
+		}
+		if !uris["neo4j-mcp://schema"] || !uris["neo4j-mcp://reference-models/transaction-base"] || uris["neo4j-mcp://tools/write-cypher"] {
+			t.Errorf("Expected schema, reference model and read-only tool resources, got: %v", uris)
+		}
+
+		response = s.MCPServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"neo4j-mcp://tools/read-cypher"}}`))
+		result, ok = response.(mcp.JSONRPCResponse)
+		if !ok {
+			t.Fatalf("Expected a resources/read response, got: %#v", response)
+		}
+		text := result.Result.(mcp.ReadResourceResult).Contents[0].(mcp.TextResourceContents).Text
+		if !strings.Contains(text, "# read-cypher") || !strings.Contains(text, `"query"`) {
+			t.Errorf("Expected read-cypher documentation with its input schema, got: %s", text)
+		}
+	})
+
+	t.Run("list-available-tools reports only the enabled tools", func(t *testing.T) {
+		mockDB := getMockedDBService(ctrl, true)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL dbms.components()", gomock.Any()).Times(1)
+		aService.EXPECT().NewToolsEvent("list-available-tools").Times(1)
+		cfg := &config.Config{
+			URI:           "bolt://test-host:7687",
+			Username:      "neo4j",
+			Password:      "password",
+			Database:      "neo4j",
+			ReadOnly:      true,
+			TransportMode: config.TransportModeStdio,
+		}
+		s := server.NewNeo4jMCPServer("test-version", cfg, mockDB, aService)
+
+		err := s.Start()
+		if err != nil {
+			t.Fatalf("Start() failed: %v", err)
+		}
+		tool, ok := s.MCPServer.ListTools()["list-available-tools"]
+		if !ok {
+			t.Fatal("Expected list-available-tools to be registered")
+		}
+
+		result, err := tool.Handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"name": "detect-synthetic-identity"`) || !strings.Contains(text, `"intent"`) {
+			t.Errorf("Expected the catalog to list fraud tools with intents, got: %s", text)
+		}
+		if strings.Contains(text, `"name": "write-cypher"`) || strings.Contains(text, `"name": "admin"`) {
+			t.Errorf("Expected write and admin tools to be left out of the catalog, got: %s", text)
+		}
+	})
 }
 
 // utility to mock the invocation required by VerifyRequirements
 func getMockedDBService(ctrl *gomock.Controller, withGDS bool) *db.MockService {
 	mockDB := db.NewMockService(ctrl)
 	mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Times(1)
+	expectPreflightChecks(mockDB)
 	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 as first", gomock.Any()).Times(1).Return([]*neo4j.Record{
 		{
 			Keys: []string{"first"},
@@ -151,15 +427,6 @@ func getMockedDBService(ctrl *gomock.Controller, withGDS bool) *db.MockService {
 			},
 		},
 	}, nil)
-	checkApocMetaSchemaQuery := "SHOW PROCEDURES YIELD name WHERE name = 'db.schema.visualization' RETURN count(name) > 0 AS schemaVisualizationAvailable"
-	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), checkApocMetaSchemaQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
-		{
-			Keys: []string{"schemaVisualizationAvailable"},
-			Values: []any{
-				bool(true),
-			},
-		},
-	}, nil)
 	gdsVersionQuery := "RETURN gds.version() as gdsVersion"
 	if withGDS {
 		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return([]*neo4j.Record{
@@ -170,6 +437,14 @@ func getMockedDBService(ctrl *gomock.Controller, withGDS bool) *db.MockService {
 				},
 			},
 		}, nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "CALL gds.list() YIELD name RETURN name", gomock.Any()).Times(1).Return([]*neo4j.Record{
+			{
+				Keys: []string{"name"},
+				Values: []any{
+					string("gds.louvain.stream"),
+				},
+			},
+		}, nil)
 		return mockDB
 	}
 	mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), gdsVersionQuery, gomock.Any()).Times(1).Return(nil, fmt.Errorf("Unknown function 'gds.version'"))
@@ -1,14 +1,33 @@
 package server
 
 import (
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
 	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/admin"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/catalog"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/data/account_profile"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/data/customer_profile"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/data/entity_network"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/data/find_connection"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/data/merchant_profile"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/data/transaction_history"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/ctr"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/flag_entity"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/investigation_case"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/kyc_audit"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/risk_score"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/sar"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/synthetic_identity"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/gds"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/workflow"
 )
 
 // registerTools registers all enabled MCP tools and adds them to the provided MCP server.
@@ -33,41 +52,215 @@ const (
 	fraudCategory  toolCategory = 2
 	schemaCategory toolCategory = 3
 	dataCategory   toolCategory = 4 // Generic data retrieval tools
+	adminCategory  toolCategory = 5 // Operator tools, only registered when NEO4J_ADMIN_TOOLS is enabled
 )
 
+// categoryInfo names each category and states what its tools are for, as reported by list-available-tools
+var categoryInfo = map[toolCategory]struct{ name, intent string }{
+	cypherCategory: {"cypher", "Explore the graph schema, run and manage Cypher queries, and discover the server's capabilities"},
+	gdsCategory:    {"gds", "Run Graph Data Science algorithms such as community detection, centrality, similarity and link prediction"},
+	fraudCategory:  {"fraud", "Detect fraud patterns, score risk, record investigations and prepare SAR, CTR and KYC reporting"},
+	schemaCategory: {"schema", "Compare the database against reference fraud data models, derive tool mappings from its schema and save them for reuse"},
+	dataCategory:   {"data", "Retrieve customer, account, merchant and transaction data and the connections between entities"},
+	adminCategory:  {"admin", "Inspect and terminate queries running on the Neo4j server"},
+}
+
+// profileCategories lists the tool categories each deployment profile exposes
+var profileCategories = map[string][]toolCategory{
+	config.ProfileInvestigator: {cypherCategory, fraudCategory, dataCategory},
+	config.ProfileAnalyst:      {cypherCategory, gdsCategory, schemaCategory, dataCategory},
+	config.ProfileAdmin:        {cypherCategory, gdsCategory, fraudCategory, schemaCategory, dataCategory, adminCategory},
+	config.ProfileDemo:         {cypherCategory, fraudCategory, schemaCategory, dataCategory},
+}
+
 type ToolDefinition struct {
-	category   toolCategory
-	definition server.ServerTool
-	readonly   bool
+	category         toolCategory
+	definition       server.ServerTool
+	readonly         bool
+	schemaProcedures bool // Calls the db.schema.* procedures
+	cacheResults     bool // Idempotent reads whose query results may be served from the result cache
 }
 
 func (s *Neo4jMCPServer) getEnabledTools() []server.ServerTool {
 	filters := make([]toolFilter, 0)
 
+	profile := ""
+	if s.config != nil {
+		profile = s.config.Profile
+	}
+
 	// If read-only mode is enabled, expose only tools annotated as read-only.
-	if s.config != nil && s.config.ReadOnly {
+	// The demo profile never exposes write tools.
+	if s.config != nil && (s.config.ReadOnly || profile == config.ProfileDemo) {
 		filters = append(filters, filterWriteTools)
 	}
 	// If GDS is not installed, disable GDS tools.
 	if !s.gdsInstalled {
 		filters = append(filters, filterGDSTools)
 	}
-	deps := &tools.ToolDependencies{
-		DBService:        s.dbService,
-		AnalyticsService: s.anService,
+	// Tools whose prerequisites the preflight checks found missing are disabled too.
+	if s.capabilities != nil {
+		if !s.capabilities.writeAllowed {
+			filters = append(filters, filterWriteTools)
+		}
+		if !s.capabilities.schemaProcedures {
+			filters = append(filters, filterSchemaProcedureTools)
+		}
+	}
+	// get-next-chunk is only needed when large responses are split.
+	if s.responseChunker == nil {
+		filters = append(filters, excludeTools(nextChunkTool))
+	}
+	// Admin tools are opt-in, since they can see and kill the queries of other users.
+	// Choosing the admin profile opts in as well.
+	if s.config == nil || (!s.config.AdminTools && profile != config.ProfileAdmin) {
+		filters = append(filters, filterAdminTools)
+	}
+	// A deployment profile exposes only its own categories.
+	if categories, ok := profileCategories[profile]; ok {
+		filters = append(filters, filterCategories(categories))
 	}
+	deps := s.toolDependencies()
 	toolDefs := s.getAllToolsDefs(deps)
 
+	// Operators can narrow the tool set further by tool or category name.
+	if s.config != nil && s.config.EnabledTools != "" {
+		filters = append(filters, selectTools(toolDefs, "NEO4J_ENABLED_TOOLS", s.config.EnabledTools, true))
+	}
+	if s.config != nil && s.config.DisabledTools != "" {
+		filters = append(filters, selectTools(toolDefs, "NEO4J_DISABLED_TOOLS", s.config.DisabledTools, false))
+	}
+
 	for _, filter := range filters {
 		toolDefs = filter(toolDefs)
 	}
 	enabledTools := make([]server.ServerTool, 0)
+	handlers := make(map[string]tools.ToolHandler, len(toolDefs))
 	for _, toolDef := range toolDefs {
+		if toolDef.cacheResults {
+			toolDef.definition.Handler = cacheReadResults(toolDef.definition.Handler)
+		}
 		enabledTools = append(enabledTools, toolDef.definition)
+		handlers[toolDef.definition.Tool.Name] = tools.ToolHandler(toolDef.definition.Handler)
+	}
+	deps.ToolCatalog.Set(buildToolCatalog(toolDefs), handlers)
+
+	// Role-based access control narrows the enabled tools per caller, for each request
+	if s.toolAccess != nil {
+		s.toolAccess.setTools(toolDefs)
+		deps.ToolCatalog.SetAccess(s.toolAccess.permitted)
 	}
 	return enabledTools
 }
 
+// toolDependencies returns the services shared by the tools and resources
+func (s *Neo4jMCPServer) toolDependencies() *tools.ToolDependencies {
+	deps := &tools.ToolDependencies{
+		DBService:        s.dbService,
+		AnalyticsService: s.anService,
+		GDSCapabilities:  s.gdsCapabilities,
+		SchemaCache:      s.schemaCache,
+		QueryStats:       s.queryStats,
+		ToolCatalog:      s.toolCatalog,
+		ResponseChunker:  s.responseChunker,
+		MappingStore:     s.mappingStore,
+		EnrichmentCache:  s.enrichments,
+	}
+	if s.config != nil {
+		deps.ServiceCredentials = s.config.UsesServiceCredentials()
+		deps.QueryTimeout = time.Duration(s.config.QueryTimeout) * time.Second
+		deps.QueryMaxRows = int(s.config.QueryMaxRows)
+	}
+	return deps
+}
+
+// buildToolCatalog groups the enabled tools by category, in category order
+func buildToolCatalog(toolDefs []ToolDefinition) []tools.ToolCatalogCategory {
+	byCategory := make(map[toolCategory][]tools.ToolCatalogEntry)
+	for _, toolDef := range toolDefs {
+		tool := toolDef.definition.Tool
+		byCategory[toolDef.category] = append(byCategory[toolDef.category], tools.ToolCatalogEntry{
+			Name:        tool.Name,
+			Title:       tool.Annotations.Title,
+			Description: tool.Description,
+			ReadOnly:    toolDef.readonly,
+		})
+	}
+
+	categories := make([]tools.ToolCatalogCategory, 0, len(byCategory))
+	for category := cypherCategory; category <= adminCategory; category++ {
+		entries, ok := byCategory[category]
+		if !ok {
+			continue
+		}
+		info := categoryInfo[category]
+		categories = append(categories, tools.ToolCatalogCategory{
+			Name:   info.name,
+			Intent: info.intent,
+			Tools:  entries,
+		})
+	}
+	return categories
+}
+
+// selectTools returns a filter keeping (allow) or removing (deny) the tools named in a
+// comma-separated list of tool and category names. Names matching no tool are logged and ignored.
+func selectTools(allTools []ToolDefinition, setting, names string, allow bool) toolFilter {
+	selected := make(map[string]bool)
+	for _, name := range strings.Split(names, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			selected[name] = true
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, t := range allTools {
+		known[t.definition.Tool.Name] = true
+		known[categoryInfo[t.category].name] = true
+	}
+	for name := range selected {
+		if !known[name] {
+			slog.Warn("ignoring unknown tool or category", "setting", setting, "name", name)
+		}
+	}
+
+	return func(tools []ToolDefinition) []ToolDefinition {
+		selectedTools := make([]ToolDefinition, 0, len(tools))
+		for _, t := range tools {
+			matched := selected[t.definition.Tool.Name] || selected[categoryInfo[t.category].name]
+			if matched == allow {
+				selectedTools = append(selectedTools, t)
+			}
+		}
+		return selectedTools
+	}
+}
+
+// excludeTools returns a filter removing the named tools
+func excludeTools(names ...string) toolFilter {
+	return func(tools []ToolDefinition) []ToolDefinition {
+		otherTools := make([]ToolDefinition, 0, len(tools))
+		for _, t := range tools {
+			if !slices.Contains(names, t.definition.Tool.Name) {
+				otherTools = append(otherTools, t)
+			}
+		}
+		return otherTools
+	}
+}
+
+func filterCategories(categories []toolCategory) toolFilter {
+	return func(tools []ToolDefinition) []ToolDefinition {
+		categoryTools := make([]ToolDefinition, 0, len(tools))
+		for _, t := range tools {
+			if slices.Contains(categories, t.category) {
+				categoryTools = append(categoryTools, t)
+			}
+		}
+		return categoryTools
+	}
+}
+
 func filterWriteTools(tools []ToolDefinition) []ToolDefinition {
 	readOnlyTools := make([]ToolDefinition, 0, len(tools))
 	for _, t := range tools {
@@ -88,6 +281,26 @@ func filterGDSTools(tools []ToolDefinition) []ToolDefinition {
 	return nonGDSTools
 }
 
+func filterSchemaProcedureTools(tools []ToolDefinition) []ToolDefinition {
+	otherTools := make([]ToolDefinition, 0, len(tools))
+	for _, t := range tools {
+		if !t.schemaProcedures {
+			otherTools = append(otherTools, t)
+		}
+	}
+	return otherTools
+}
+
+func filterAdminTools(tools []ToolDefinition) []ToolDefinition {
+	nonAdminTools := make([]ToolDefinition, 0, len(tools))
+	for _, t := range tools {
+		if t.category != adminCategory {
+			nonAdminTools = append(nonAdminTools, t)
+		}
+	}
+	return nonAdminTools
+}
+
 // getAllToolsDefs returns all available tools with their specs and handlers
 func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDefinition {
 
@@ -98,7 +311,9 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 				Tool:    cypher.GetSchemaSpec(),
 				Handler: cypher.GetSchemaHandler(deps, s.config.SchemaSampleSize),
 			},
-			readonly: true,
+			readonly:         true,
+			cacheResults:     true,
+			schemaProcedures: true,
 		},
 		{
 			category: cypherCategory,
@@ -116,6 +331,95 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 			},
 			readonly: false,
 		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.BatchCypherSpec(),
+				Handler: cypher.BatchCypherHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.BeginTransactionSpec(),
+				Handler: cypher.BeginTransactionHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.RunInTransactionSpec(),
+				Handler: cypher.RunInTransactionHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.CommitTransactionSpec(),
+				Handler: cypher.CommitTransactionHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.RollbackTransactionSpec(),
+				Handler: cypher.RollbackTransactionHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.CancelQuerySpec(),
+				Handler: cypher.CancelQueryHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    cypher.GetQueryStatsSpec(),
+				Handler: cypher.GetQueryStatsHandler(deps),
+			},
+			readonly: true,
+		},
+		// Capability listing is not a GDS tool so it can report that GDS is missing
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    gds.ListCapabilitiesSpec(),
+				Handler: gds.ListCapabilitiesHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    catalog.ListAvailableToolsSpec(),
+				Handler: catalog.ListAvailableToolsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    catalog.HealthCheckSpec(),
+				Handler: catalog.HealthCheckHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: cypherCategory,
+			definition: server.ServerTool{
+				Tool:    catalog.GetNextChunkSpec(),
+				Handler: catalog.GetNextChunkHandler(deps),
+			},
+			readonly: true,
+		},
 		// GDS Category/Section
 		{
 			category: gdsCategory,
@@ -125,6 +429,110 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 			},
 			readonly: true,
 		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.CreateGDSProjectionSpec(),
+				Handler: gds.CreateGDSProjectionHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.ListGDSProjectionsSpec(),
+				Handler: gds.ListGDSProjectionsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.DropGDSProjectionSpec(),
+				Handler: gds.DropGDSProjectionHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.RunCommunityDetectionSpec(),
+				Handler: gds.RunCommunityDetectionHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.WriteCommunityDetectionSpec(),
+				Handler: gds.WriteCommunityDetectionHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.RunCentralitySpec(),
+				Handler: gds.RunCentralityHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.WriteCentralitySpec(),
+				Handler: gds.WriteCentralityHandler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.RunNodeSimilaritySpec(),
+				Handler: gds.RunNodeSimilarityHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.FindSimilarToSeedsSpec(),
+				Handler: gds.FindSimilarToSeedsHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.EstimateGDSMemorySpec(),
+				Handler: gds.EstimateGDSMemoryHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.ConfigureLinkPredictionPipelineSpec(),
+				Handler: gds.ConfigureLinkPredictionPipelineHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.TrainLinkPredictionModelSpec(),
+				Handler: gds.TrainLinkPredictionModelHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: gdsCategory,
+			definition: server.ServerTool{
+				Tool:    gds.PredictLinksSpec(),
+				Handler: gds.PredictLinksHandler(deps),
+			},
+			readonly: true,
+		},
 		// Fraud Detection Category/Section
 		{
 			category: fraudCategory,
@@ -132,7 +540,8 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 				Tool:    synthetic_identity.Spec(),
 				Handler: synthetic_identity.Handler(deps),
 			},
-			readonly: true,
+			readonly:     true,
+			cacheResults: true,
 		},
 		{
 			category: fraudCategory,
@@ -142,15 +551,127 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 			},
 			readonly: true,
 		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    sar.GatherSAREvidenceSpec(),
+				Handler: sar.GatherSAREvidenceHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    sar.GenerateSARDraftSpec(),
+				Handler: sar.GenerateSARDraftHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    ctr.GetCTREvidenceSpec(),
+				Handler: ctr.GetCTREvidenceHandler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    kyc_audit.Spec(),
+				Handler: kyc_audit.Handler(deps),
+			},
+			readonly: true,
+		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    risk_score.Spec(),
+				Handler: risk_score.Handler(deps),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    investigation_case.Spec(),
+				Handler: investigation_case.Handler(deps),
+			},
+			readonly: false,
+		},
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    flag_entity.Spec(),
+				Handler: flag_entity.Handler(deps, s.config.FlagAllowedProperties),
+			},
+			readonly: false,
+		},
+		// Workflows chain the other enabled tools, so they are only as capable as the filters allow
+		{
+			category: fraudCategory,
+			definition: server.ServerTool{
+				Tool:    workflow.InvestigateCustomerSpec(),
+				Handler: workflow.Handler(deps, workflow.InvestigateCustomer),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
 		// Schema Tools Category/Section
 		{
 			category: schemaCategory,
 			definition: server.ServerTool{
 				Tool:    schema.GetReferenceModelsSpec(),
-				Handler: schema.GetReferenceModelsHandler(deps),
+				Handler: schema.GetReferenceModelsHandler(deps, s.referenceModels),
+			},
+			readonly: true,
+		},
+		{
+			category: schemaCategory,
+			definition: server.ServerTool{
+				Tool:    schema.ValidateSchemaSpec(),
+				Handler: schema.ValidateSchemaHandler(deps, s.referenceModels),
+			},
+			readonly:         true,
+			cacheResults:     true,
+			schemaProcedures: true,
+		},
+		{
+			category: schemaCategory,
+			definition: server.ServerTool{
+				Tool:    schema.SuggestAttributeMappingsSpec(),
+				Handler: schema.SuggestAttributeMappingsHandler(deps),
+			},
+			readonly:         true,
+			cacheResults:     true,
+			schemaProcedures: true,
+		},
+		{
+			category: schemaCategory,
+			definition: server.ServerTool{
+				Tool:    schema.SaveSchemaMappingSpec(),
+				Handler: schema.SaveSchemaMappingHandler(deps),
+			},
+			readonly: true, // Saves to the mapping store, never to the database
+		},
+		{
+			category: schemaCategory,
+			definition: server.ServerTool{
+				Tool:    schema.ListSchemaMappingsSpec(),
+				Handler: schema.ListSchemaMappingsHandler(deps),
 			},
 			readonly: true,
 		},
+		{
+			category: schemaCategory,
+			definition: server.ServerTool{
+				Tool:    schema.StoreEnrichedSchemaSpec(),
+				Handler: schema.StoreEnrichedSchemaHandler(deps),
+			},
+			readonly:         true, // Stores descriptions in server memory, never in the database
+			schemaProcedures: true,
+		},
 		// Data Retrieval Category/Section - Generic tools for customer/transaction data
 		{
 			category: dataCategory,
@@ -158,8 +679,71 @@ func (s *Neo4jMCPServer) getAllToolsDefs(deps *tools.ToolDependencies) []ToolDef
 				Tool:    customer_profile.Spec(),
 				Handler: customer_profile.Handler(deps),
 			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		{
+			category: dataCategory,
+			definition: server.ServerTool{
+				Tool:    transaction_history.Spec(),
+				Handler: transaction_history.Handler(deps),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		{
+			category: dataCategory,
+			definition: server.ServerTool{
+				Tool:    account_profile.Spec(),
+				Handler: account_profile.Handler(deps),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		{
+			category: dataCategory,
+			definition: server.ServerTool{
+				Tool:    merchant_profile.Spec(),
+				Handler: merchant_profile.Handler(deps),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		{
+			category: dataCategory,
+			definition: server.ServerTool{
+				Tool:    entity_network.Spec(),
+				Handler: entity_network.Handler(deps),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		{
+			category: dataCategory,
+			definition: server.ServerTool{
+				Tool:    find_connection.Spec(),
+				Handler: find_connection.Handler(deps),
+			},
+			readonly:     true,
+			cacheResults: true,
+		},
+		// Admin Category/Section
+		{
+			category: adminCategory,
+			definition: server.ServerTool{
+				Tool:    admin.ListRunningQueriesSpec(),
+				Handler: admin.ListRunningQueriesHandler(deps),
+			},
 			readonly: true,
 		},
+		{
+			category: adminCategory,
+			definition: server.ServerTool{
+				Tool:    admin.KillQuerySpec(),
+				Handler: admin.KillQueryHandler(deps),
+			},
+			readonly: false,
+		},
 		// Add other categories below...
 	}
 }
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tracing"
+)
+
+const (
+	traceparentHeader      = "traceparent"   // W3C trace context header
+	traceShutdownTimeout   = 5 * time.Second // Maximum time spent exporting the pending spans on shutdown
+	toolCallSpanNamePrefix = "tools/call "
+)
+
+// newTracer returns a tracer exporting spans to the configured OTLP collector, or nil when tracing is disabled
+func newTracer(cfg *config.Config, version string) *tracing.Tracer {
+	if cfg.OTLPEndpoint == "" {
+		return nil
+	}
+	headers, err := tracing.ParseHeaders(cfg.OTLPHeaders)
+	if err != nil {
+		slog.Warn("ignoring invalid OTEL_EXPORTER_OTLP_HEADERS", "error", err)
+	}
+	exporter, err := tracing.NewExporter(context.Background(), tracing.ExporterConfig{
+		Endpoint: cfg.OTLPEndpoint,
+		Headers:  headers,
+	})
+	if err != nil {
+		slog.Warn("Trace export disabled", "error", err)
+		return nil
+	}
+	slog.Info("Exporting trace spans", "endpoint", cfg.OTLPEndpoint, "serviceName", cfg.OTelServiceName)
+	return tracing.NewTracer(exporter, cfg.OTelServiceName, version)
+}
+
+// traceToolCalls starts a span for each tool call; the queries the tool runs become its children.
+// A trace started by the client continues through the traceparent HTTP header or the
+// traceparent field of the request's _meta.
+func traceToolCalls(tracer *tracing.Tracer) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if meta := request.Params.Meta; meta != nil {
+				if traceparent, ok := meta.AdditionalFields[traceparentHeader].(string); ok {
+					ctx = tracing.ContextWithRemoteParent(ctx, traceparent)
+				}
+			}
+
+			attrs := []tracing.Attribute{
+				tracing.String("mcp.method.name", string(mcp.MethodToolsCall)),
+				tracing.String("gen_ai.tool.name", request.Params.Name),
+			}
+			if session := server.ClientSessionFromContext(ctx); session != nil && session.SessionID() != "" {
+				attrs = append(attrs, tracing.String("mcp.session.id", session.SessionID()))
+			}
+			ctx, span := tracer.Start(ctx, toolCallSpanNamePrefix+request.Params.Name, tracing.KindServer, attrs...)
+			defer span.End()
+
+			result, err := next(ctx, request)
+			switch {
+			case err != nil:
+				span.RecordError(err)
+			case result != nil && result.IsError:
+				span.RecordError(toolResultError(result))
+			}
+			return result, err
+		}
+	}
+}
+
+// toolResultError returns the message of a tool error result as an error
+func toolResultError(result *mcp.CallToolResult) error {
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			return errors.New(text.Text)
+		}
+	}
+	return errors.New("tool returned an error result")
+}
+
+// shutdownTracer exports the spans still pending
+func (s *Neo4jMCPServer) shutdownTracer() {
+	ctx, cancel := context.WithTimeout(context.Background(), traceShutdownTimeout)
+	defer cancel()
+	if err := s.tracer.Shutdown(ctx); err != nil {
+		slog.Warn("Failed to export pending trace spans", "error", err)
+	}
+}
+
+// traceContextMiddleware continues the trace of a request carrying a W3C traceparent header
+func traceContextMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if traceparent := r.Header.Get(traceparentHeader); traceparent != "" {
+			r = r.WithContext(tracing.ContextWithRemoteParent(r.Context(), traceparent))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tracing"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testParentID    = "00f067aa0ba902b7"
+	testTraceparent = "00-" + testTraceID + "-" + testParentID + "-01"
+)
+
+func TestTraceToolCalls(t *testing.T) {
+	var mu sync.Mutex
+	var spans []*tracepb.Span
+	var authorization string
+	collector := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, request); err != nil {
+			t.Errorf("invalid export request: %v", err)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		authorization = r.Header.Get("Authorization")
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}))
+	defer collector.Close()
+
+	cfg := &config.Config{URI: "bolt://test-host:7687", TransportMode: config.TransportModeHTTP, OTLPEndpoint: collector.URL, OTLPHeaders: "Authorization=Bearer%20token", OTelServiceName: "fraud-mcp"}
+	s := NewNeo4jMCPServer("test-version", cfg, nil, nil)
+	if s.tracer == nil {
+		t.Fatal("expected a tracer when an OTLP endpoint is configured")
+	}
+
+	s.MCPServer.AddTool(mcp.NewTool("failing-tool"), func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		// Queries run by the tool continue its trace
+		_, querySpan := tracing.Start(ctx, "ExecuteReadQuery", tracing.KindClient)
+		querySpan.End()
+		return mcp.NewToolResultError("customer not found"), nil
+	})
+	s.MCPServer.HandleMessage(context.Background(), []byte(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"failing-tool","_meta":{"traceparent":"`+testTraceparent+`"}}}`))
+	s.shutdownTracer()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %+v", spans)
+	}
+	query, tool := spans[0], spans[1]
+	if tool.Name != "tools/call failing-tool" || hex.EncodeToString(tool.TraceId) != testTraceID || hex.EncodeToString(tool.ParentSpanId) != testParentID {
+		t.Errorf("expected the tool span to continue the client trace, got %v", tool)
+	}
+	if tool.Status.GetCode() != tracepb.Status_STATUS_CODE_ERROR || tool.Status.GetMessage() != "customer not found" {
+		t.Errorf("expected the tool error on the span status, got %v", tool.Status)
+	}
+	if query.Name != "ExecuteReadQuery" || hex.EncodeToString(query.TraceId) != testTraceID || !bytes.Equal(query.ParentSpanId, tool.SpanId) {
+		t.Errorf("expected the query span to be a child of the tool span, got %v", query)
+	}
+	if authorization != "Bearer token" {
+		t.Errorf("expected the configured OTLP headers, got Authorization %q", authorization)
+	}
+}
+
+func TestNewNeo4jMCPServer_TracingDisabled(t *testing.T) {
+	cfg := &config.Config{URI: "bolt://test-host:7687", TransportMode: config.TransportModeHTTP}
+	if s := NewNeo4jMCPServer("test-version", cfg, nil, nil); s.tracer != nil {
+		t.Error("expected no tracer without an OTLP endpoint")
+	}
+}
+
+func TestTraceContextMiddleware(t *testing.T) {
+	var traceID string
+	handler := traceContextMiddleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, span := tracing.NewTracer(nil, "fraud-mcp", "test-version").Start(r.Context(), "tools/call", tracing.KindServer)
+		traceID = span.TraceID()
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, mcpPath, nil)
+	req.Header.Set("traceparent", testTraceparent)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if traceID != testTraceID {
+		t.Errorf("expected spans to continue the trace of the traceparent header, got trace %s", traceID)
+	}
+}
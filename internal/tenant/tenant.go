@@ -0,0 +1,105 @@
+// Package tenant routes the callers of one server instance to isolated fraud graphs. Operators map
+// each caller identity to a tenant: the Neo4j database its tool calls run against and, optionally,
+// the credentials they run with, e.g. one tenant per business unit or per customer sandbox.
+package tenant
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+)
+
+// Tenant is an isolated graph: a database, and the credentials it is accessed with when set
+type Tenant struct {
+	Name        string `json:"-"`
+	Database    string `json:"database"`
+	Username    string `json:"username,omitempty"`    // Empty keeps the caller's or the server's credentials
+	Password    string `json:"password,omitempty"`    // Prefer PasswordEnv, so the file holds no secret
+	PasswordEnv string `json:"passwordEnv,omitempty"` // Environment variable holding the password
+}
+
+// File is the tenant file operators configure
+type File struct {
+	Tenants       map[string]Tenant `json:"tenants"`
+	Identities    map[string]string `json:"identities,omitempty"`    // Caller identity -> tenant name
+	DefaultTenant string            `json:"defaultTenant,omitempty"` // Tenant of callers without an entry in Identities; empty denies them
+}
+
+// Router resolves the tenant of each caller identity
+type Router struct {
+	tenants       map[string]*Tenant
+	identities    map[string]string
+	defaultTenant string
+}
+
+// Load reads the tenant file at path
+func Load(path string) (*Router, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tenants %s: %w", path, err)
+	}
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse tenants %s: %w", path, err)
+	}
+	return New(file)
+}
+
+// New builds the router of file, resolving the passwords held in environment variables
+func New(file File) (*Router, error) {
+	if len(file.Tenants) == 0 {
+		return nil, fmt.Errorf("no tenants are defined")
+	}
+
+	router := &Router{tenants: make(map[string]*Tenant, len(file.Tenants)), identities: file.Identities, defaultTenant: file.DefaultTenant}
+	for name, tenant := range file.Tenants {
+		if tenant.Database == "" {
+			return nil, fmt.Errorf("tenant %s has no database", name)
+		}
+		if tenant.PasswordEnv != "" {
+			tenant.Password = os.Getenv(tenant.PasswordEnv)
+		}
+		if tenant.Username != "" && tenant.Password == "" {
+			return nil, fmt.Errorf("tenant %s has a username but no password", name)
+		}
+		tenant.Name = name
+		router.tenants[name] = &tenant
+	}
+
+	for identity, name := range file.Identities {
+		if _, ok := router.tenants[name]; !ok {
+			return nil, fmt.Errorf("identity %s is mapped to unknown tenant '%s'", identity, name)
+		}
+	}
+	if file.DefaultTenant != "" {
+		if _, ok := router.tenants[file.DefaultTenant]; !ok {
+			return nil, fmt.Errorf("unknown defaultTenant '%s'", file.DefaultTenant)
+		}
+	}
+	return router, nil
+}
+
+// Resolve returns the tenant of a caller identity, or false when the caller has none.
+// A nil router resolves no tenant, so callers are denied rather than sharing a graph.
+func (r *Router) Resolve(identity string) (*Tenant, bool) {
+	if r == nil {
+		return nil, false
+	}
+	name, ok := r.identities[identity]
+	if !ok {
+		name = r.defaultTenant
+	}
+	tenant, ok := r.tenants[name]
+	return tenant, ok
+}
+
+// Names returns the sorted tenant names
+func (r *Router) Names() []string {
+	names := make([]string, 0, len(r.tenants))
+	for name := range r.tenants {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
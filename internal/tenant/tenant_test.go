@@ -0,0 +1,73 @@
+package tenant
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	t.Setenv("SANDBOX_PASSWORD", "s3cret")
+	path := filepath.Join(t.TempDir(), "tenants.json")
+	data := `{
+		"tenants": {
+			"retail": {"database": "retail"},
+			"sandbox": {"database": "sandbox_acme", "username": "acme", "passwordEnv": "SANDBOX_PASSWORD"}
+		},
+		"identities": {"alice": "retail", "acme-bot": "sandbox"},
+		"defaultTenant": "retail"
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	router, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error = %v", err)
+	}
+
+	sandbox, ok := router.Resolve("acme-bot")
+	if !ok || sandbox.Name != "sandbox" || sandbox.Database != "sandbox_acme" || sandbox.Password != "s3cret" {
+		t.Errorf("Resolve(acme-bot) = %+v, %v, want the sandbox tenant with its password", sandbox, ok)
+	}
+	if retail, ok := router.Resolve("bob"); !ok || retail.Name != "retail" {
+		t.Errorf("Resolve(bob) = %+v, %v, want the default tenant", retail, ok)
+	}
+}
+
+func TestResolve_WithoutDefaultTenant(t *testing.T) {
+	router, err := New(File{Tenants: map[string]Tenant{"retail": {Database: "retail"}}, Identities: map[string]string{"alice": "retail"}})
+	if err != nil {
+		t.Fatalf("New() unexpected error = %v", err)
+	}
+	if _, ok := router.Resolve("bob"); ok {
+		t.Error("expected callers without a tenant to resolve none")
+	}
+
+	var unloaded *Router
+	if _, ok := unloaded.Resolve("alice"); ok {
+		t.Error("expected a nil router to resolve no tenant")
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		file File
+		want string
+	}{
+		{"no tenants", File{}, "no tenants are defined"},
+		{"no database", File{Tenants: map[string]Tenant{"retail": {}}}, "tenant retail has no database"},
+		{"username without password", File{Tenants: map[string]Tenant{"retail": {Database: "retail", Username: "svc"}}}, "has a username but no password"},
+		{"unknown identity tenant", File{Tenants: map[string]Tenant{"retail": {Database: "retail"}}, Identities: map[string]string{"alice": "cards"}}, "unknown tenant 'cards'"},
+		{"unknown default tenant", File{Tenants: map[string]Tenant{"retail": {Database: "retail"}}, DefaultTenant: "cards"}, "unknown defaultTenant 'cards'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.file); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("New() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// runningQueriesResult is the list-running-queries response
+type runningQueriesResult struct {
+	Count   int                     `json:"count"`
+	Queries []database.RunningQuery `json:"queries"`
+}
+
+func ListRunningQueriesHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListRunningQueries(ctx, request, deps)
+	}
+}
+
+func KillQueryHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleKillQuery(ctx, request, deps)
+	}
+}
+
+func handleListRunningQueries(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errResult := checkDeps(deps); errResult != nil {
+		return errResult, nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("list-running-queries"))
+
+	var args ListRunningQueriesInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	queries, err := deps.DBService.ListRunningQueries(ctx, !args.IncludeAll)
+	if err != nil {
+		slog.Error("error listing running queries", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := runningQueriesResult{Queries: make([]database.RunningQuery, 0, len(queries))}
+	for _, query := range queries {
+		if query.ElapsedTimeMs >= int64(args.MinElapsedMillis) {
+			result.Queries = append(result.Queries, query)
+		}
+	}
+	result.Count = len(result.Queries)
+
+	response, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		slog.Error("error formatting running queries", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+func handleKillQuery(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errResult := checkDeps(deps); errResult != nil {
+		return errResult, nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("kill-query"))
+
+	var args KillQueryInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.QueryID == "" {
+		errMessage := "queryId parameter is required; call list-running-queries to find it"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	killed, err := deps.DBService.KillQuery(ctx, args.QueryID)
+	if err != nil {
+		slog.Error("error killing query", "queryId", args.QueryID, "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := json.Marshal(killed)
+	if err != nil {
+		slog.Error("error formatting killed query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// checkDeps returns an error result when the services the admin tools need are missing
+func checkDeps(deps *tools.ToolDependencies) *mcp.CallToolResult {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage)
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage)
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/admin"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListRunningQueriesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("list-running-queries").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	running := []database.RunningQuery{
+		{TransactionID: "neo4j-transaction-42", QueryID: "query-17", Query: "MATCH p=(:Customer)-[*]-(:Customer) RETURN p", ElapsedTimeMs: 95000, FromMCP: true},
+		{TransactionID: "neo4j-transaction-43", QueryID: "query-18", Query: "MATCH (c:Customer) RETURN count(c)", ElapsedTimeMs: 20},
+	}
+
+	t.Run("lists MCP queries by default", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ListRunningQueries(gomock.Any(), true).Return(running[:1], nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := admin.ListRunningQueriesHandler(deps)(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"queryId": "query-17"`) {
+			t.Errorf("Expected running query, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("includeAll with minimum elapsed time", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ListRunningQueries(gomock.Any(), false).Return(running, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := admin.ListRunningQueriesHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"includeAll": true, "minElapsedMillis": 1000},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var response struct {
+			Count   int                     `json:"count"`
+			Queries []database.RunningQuery `json:"queries"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected running queries JSON, got: %v", err)
+		}
+		if response.Count != 1 || response.Queries[0].TransactionID != "neo4j-transaction-42" {
+			t.Errorf("Expected only the long running query, got: %+v", response)
+		}
+	})
+}
+
+func TestKillQueryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("kill-query").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("kills a running query", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().KillQuery(gomock.Any(), "neo4j-transaction-42").Return(&database.CancelledQuery{
+			TransactionID: "neo4j-transaction-42",
+			Message:       "Transaction terminated.",
+		}, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := admin.KillQueryHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"queryId": "neo4j-transaction-42"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, "Transaction terminated.") {
+			t.Errorf("Expected termination message, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("terminate failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().KillQuery(gomock.Any(), gomock.Any()).Return(nil, errors.New("permission denied"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := admin.KillQueryHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"queryId": "query-18"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for failed termination")
+		}
+	})
+
+	t.Run("missing query ID", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := admin.KillQueryHandler(deps)(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing query ID")
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type ListRunningQueriesInput struct {
+	IncludeAll       bool `json:"includeAll,omitempty" jsonschema:"description=Optional: list the queries of every application and user. By default only queries issued through this MCP server are listed."`
+	MinElapsedMillis int  `json:"minElapsedMillis,omitempty" jsonschema:"description=Optional: only list queries that have been running for at least this many milliseconds."`
+}
+
+type KillQueryInput struct {
+	QueryID string `json:"queryId" jsonschema:"description=The transactionId (e.g. neo4j-transaction-42) or queryId (e.g. query-17) returned by list-running-queries"`
+}
+
+func ListRunningQueriesSpec() mcp.Tool {
+	return mcp.NewTool("list-running-queries",
+		mcp.WithDescription(`Admin tool listing the queries running on the Neo4j server, longest running first, from SHOW TRANSACTIONS.
+		Each entry has the transactionId, queryId, query text, database, user, status, elapsed time and whether it was issued through this MCP server.
+		Use it to find runaway queries, then stop them with kill-query.`),
+		mcp.WithInputSchema[ListRunningQueriesInput](),
+		mcp.WithTitleAnnotation("List Running Queries"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func KillQuerySpec() mcp.Tool {
+	return mcp.NewTool("kill-query",
+		mcp.WithDescription(`Admin tool terminating a running query with TERMINATE TRANSACTIONS, rolling back its transaction.
+		Unlike cancel-query it can stop queries from any application or user the Neo4j account is allowed to manage.
+		Get the ID from list-running-queries.`),
+		mcp.WithInputSchema[KillQueryInput](),
+		mcp.WithTitleAnnotation("Kill Query"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,43 @@
+package catalog
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+func GetNextChunkHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetNextChunk(ctx, request, deps)
+	}
+}
+
+func handleGetNextChunk(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("get-next-chunk"))
+
+	var args GetNextChunkInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.ContinuationToken == "" {
+		errMessage := "continuationToken is required"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	chunk, err := deps.ResponseChunker.Next(ctx, args.ContinuationToken)
+	if err != nil {
+		slog.Error("error fetching response chunk", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	return mcp.NewToolResultText(chunk), nil
+}
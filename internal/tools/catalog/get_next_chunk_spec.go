@@ -0,0 +1,24 @@
+package catalog
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type GetNextChunkInput struct {
+	ContinuationToken string `json:"continuationToken" jsonschema:"description=The continuationToken of the previous chunk of a split response"`
+}
+
+func GetNextChunkSpec() mcp.Tool {
+	return mcp.NewTool("get-next-chunk",
+		mcp.WithDescription(`Fetches the next chunk of a tool response that was too large to return at once.
+		A split response is returned as {items or text, chunk, totalChunks, continuationToken}: items holds a slice of the elements of a JSON array response, text a piece of any other response to concatenate with the others.
+		Pass the continuationToken to get the next chunk; the last chunk has none. Tokens expire after 10 minutes without a fetch.
+		Before fetching every chunk, consider narrowing the original call instead (filters, maxRows, outputMode "summary").`),
+		mcp.WithInputSchema[GetNextChunkInput](),
+		mcp.WithTitleAnnotation("Get Next Chunk"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
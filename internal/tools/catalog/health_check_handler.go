@@ -0,0 +1,43 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+func HealthCheckHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleHealthCheck(ctx, deps)
+	}
+}
+
+func handleHealthCheck(ctx context.Context, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("health-check"))
+
+	// A tool call carries the caller's credentials, so the database can always be queried
+	report := tools.CheckHealth(ctx, deps, tools.HealthOptions{QueryDatabase: true})
+
+	response, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		slog.Error("error formatting health report", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// An unhealthy server is reported as a successful call, so the agent can read which check failed
+	return mcp.NewToolResultText(string(response)), nil
+}
@@ -0,0 +1,108 @@
+package catalog_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/catalog"
+	"go.uber.org/mock/gomock"
+)
+
+func TestHealthCheckHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("health-check").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	toolCatalog := tools.NewToolCatalog()
+	toolCatalog.Set([]tools.ToolCatalogCategory{
+		{Name: "cypher", Tools: []tools.ToolCatalogEntry{{Name: "read-cypher"}, {Name: "health-check"}}},
+		{Name: "fraud", Tools: []tools.ToolCatalogEntry{{Name: "detect-synthetic-identity"}}},
+	}, nil)
+
+	runHealthCheck := func(t *testing.T, deps *tools.ToolDependencies) tools.HealthReport {
+		t.Helper()
+		result, err := catalog.HealthCheckHandler(deps)(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		var report tools.HealthReport
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report); err != nil {
+			t.Fatalf("Expected a JSON health report, got: %v", err)
+		}
+		return report
+	}
+	checkStatus := func(t *testing.T, report tools.HealthReport, name, want string) {
+		t.Helper()
+		for _, check := range report.Checks {
+			if check.Name == name {
+				if check.Status != want {
+					t.Errorf("Expected %s check to be %q, got %q (%s)", name, want, check.Status, check.Message)
+				}
+				return
+			}
+		}
+		t.Errorf("Expected a %s check", name)
+	}
+
+	t.Run("healthy server", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().VerifyConnectivity(gomock.Any()).Return(nil)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 AS ok", gomock.Any()).Return(nil, nil)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j")
+
+		report := runHealthCheck(t, &tools.ToolDependencies{
+			DBService:          mockDB,
+			AnalyticsService:   analyticsService,
+			GDSCapabilities:    &tools.GDSCapabilities{Installed: true, Version: "2.22.0"},
+			ToolCatalog:        toolCatalog,
+			ServiceCredentials: true,
+		})
+		if report.Status != tools.HealthOK {
+			t.Errorf("Expected status ok, got %q", report.Status)
+		}
+		checkStatus(t, report, "driver", tools.HealthOK)
+		checkStatus(t, report, "database", tools.HealthOK)
+		checkStatus(t, report, "gds", tools.HealthOK)
+		checkStatus(t, report, "tools", tools.HealthOK)
+		if report.Tools.Enabled != 3 || report.Tools.Categories["cypher"] != 2 {
+			t.Errorf("Expected 3 tools with 2 in cypher, got %+v", report.Tools)
+		}
+	})
+
+	t.Run("unreachable database", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), "RETURN 1 AS ok", gomock.Any()).Return(nil, errors.New("connection refused"))
+
+		// With per-request credentials the driver is not checked on its own
+		report := runHealthCheck(t, &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			ToolCatalog:      toolCatalog,
+		})
+		if report.Status != tools.HealthError {
+			t.Errorf("Expected status error, got %q", report.Status)
+		}
+		checkStatus(t, report, "driver", tools.HealthSkipped)
+		checkStatus(t, report, "database", tools.HealthError)
+		checkStatus(t, report, "gds", tools.HealthUnavailable)
+	})
+
+	t.Run("nil database service", func(t *testing.T) {
+		result, err := catalog.HealthCheckHandler(&tools.ToolDependencies{AnalyticsService: analyticsService})(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for a nil database service")
+		}
+	})
+}
@@ -0,0 +1,18 @@
+package catalog
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+func HealthCheckSpec() mcp.Tool {
+	return mcp.NewTool("health-check",
+		mcp.WithDescription(`Checks that the server can do its job: the Neo4j driver connects, the database answers a query, whether Graph Data Science is installed, and how many tools are loaded per category.
+		Returns an overall status ("ok" or "error") with the outcome and duration of each check.
+		Use it when tool calls fail unexpectedly, to tell a database outage from a problem with the query itself.`),
+		mcp.WithTitleAnnotation("Health Check"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
@@ -0,0 +1,64 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// availableToolsResult is the list-available-tools response
+type availableToolsResult struct {
+	ToolCount  int                         `json:"toolCount"`
+	Categories []tools.ToolCatalogCategory `json:"categories"`
+}
+
+func ListAvailableToolsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListAvailableTools(ctx, request, deps)
+	}
+}
+
+func handleListAvailableTools(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("list-available-tools"))
+
+	var args ListAvailableToolsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := availableToolsResult{Categories: make([]tools.ToolCatalogCategory, 0)}
+	names := make([]string, 0)
+	for _, category := range deps.ToolCatalog.Categories(ctx) {
+		names = append(names, category.Name)
+		if args.Category != "" && !strings.EqualFold(args.Category, category.Name) {
+			continue
+		}
+		result.Categories = append(result.Categories, category)
+		result.ToolCount += len(category.Tools)
+	}
+
+	if args.Category != "" && len(result.Categories) == 0 {
+		errMessage := fmt.Sprintf("unknown or disabled category %q, available categories: %s", args.Category, strings.Join(names, ", "))
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	response, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		slog.Error("error formatting tool catalog", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
@@ -0,0 +1,89 @@
+package catalog_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/catalog"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListAvailableToolsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("list-available-tools").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	toolCatalog := tools.NewToolCatalog()
+	toolCatalog.Set([]tools.ToolCatalogCategory{
+		{Name: "cypher", Intent: "Run Cypher", Tools: []tools.ToolCatalogEntry{{Name: "read-cypher", ReadOnly: true}}},
+		{Name: "fraud", Intent: "Detect fraud", Tools: []tools.ToolCatalogEntry{{Name: "detect-synthetic-identity", ReadOnly: true}}},
+	}, nil)
+	deps := &tools.ToolDependencies{
+		AnalyticsService: analyticsService,
+		ToolCatalog:      toolCatalog,
+	}
+
+	t.Run("lists every category", func(t *testing.T) {
+		result, err := catalog.ListAvailableToolsHandler(deps)(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"toolCount": 2`) || !strings.Contains(text, "read-cypher") || !strings.Contains(text, "detect-synthetic-identity") {
+			t.Errorf("Expected both categories, got: %s", text)
+		}
+	})
+
+	t.Run("filters by category", func(t *testing.T) {
+		result, err := catalog.ListAvailableToolsHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"category": "Fraud"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, "detect-synthetic-identity") || strings.Contains(text, "read-cypher") {
+			t.Errorf("Expected only the fraud category, got: %s", text)
+		}
+	})
+
+	t.Run("unknown category", func(t *testing.T) {
+		result, err := catalog.ListAvailableToolsHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"category": "admin"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for a disabled category")
+		}
+	})
+
+	t.Run("lists only the tools the caller may use", func(t *testing.T) {
+		restricted := tools.NewToolCatalog()
+		restricted.Set(toolCatalog.Categories(context.Background()), nil)
+		restricted.SetAccess(func(_ context.Context, name string) bool { return name == "read-cypher" })
+
+		result, err := catalog.ListAvailableToolsHandler(&tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			ToolCatalog:      restricted,
+		})(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"toolCount": 1`) || strings.Contains(text, "detect-synthetic-identity") {
+			t.Errorf("Expected only read-cypher, got: %s", text)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package catalog
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type ListAvailableToolsInput struct {
+	Category string `json:"category,omitempty" jsonschema:"description=Optional: only list the tools of one category (cypher / gds / fraud / schema / data / admin)."`
+}
+
+func ListAvailableToolsSpec() mcp.Tool {
+	return mcp.NewTool("list-available-tools",
+		mcp.WithDescription(`Lists the tools enabled on this server as a structured catalog grouped by category.
+		Each category states its intent (for example fraud detection or data retrieval) and lists its tools with their title, description and whether they are read-only.
+		Use it to answer questions such as "what fraud detections are available here?" and to plan an investigation before calling the tools themselves.`),
+		mcp.WithInputSchema[ListAvailableToolsInput](),
+		mcp.WithTitleAnnotation("List Available Tools"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
@@ -0,0 +1,208 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// Statuses of a statement in a batch-cypher result
+const (
+	batchStatusOK      = "ok"
+	batchStatusFailed  = "failed"
+	batchStatusSkipped = "skipped"
+)
+
+// batchStatementResult is the outcome of one statement; the write result is only set when it succeeded
+type batchStatementResult struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	*writeCypherResult
+}
+
+// batchCypherResult is the batch-cypher response
+type batchCypherResult struct {
+	Transactional bool                   `json:"transactional"`
+	Committed     *bool                  `json:"committed,omitempty"` // Only set for transactional batches
+	Error         string                 `json:"error,omitempty"`     // Commit failure of a transactional batch
+	Succeeded     int                    `json:"succeeded"`
+	Failed        int                    `json:"failed"`
+	Skipped       int                    `json:"skipped"`
+	Results       []batchStatementResult `json:"results"`
+}
+
+func BatchCypherHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleBatchCypher(ctx, request, deps)
+	}
+}
+
+func handleBatchCypher(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("batch-cypher"))
+
+	var args BatchCypherInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	deps = deps.ForDatabase(args.Database)
+
+	if len(args.Statements) == 0 {
+		errMessage := "statements parameter is required and cannot be empty"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	if len(args.Statements) > maxBatchStatements {
+		errMessage := fmt.Sprintf("a batch can contain at most %d statements, got %d", maxBatchStatements, len(args.Statements))
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	for i, statement := range args.Statements {
+		if statement.Query == "" {
+			errMessage := fmt.Sprintf("statement %d has an empty query", i)
+			slog.Error(errMessage)
+			return mcp.NewToolResultError(errMessage), nil
+		}
+		if err := validateParams(statement.Query, statement.Params); err != nil {
+			errMessage := fmt.Sprintf("statement %d: %s", i, err)
+			slog.Error(errMessage)
+			return mcp.NewToolResultError(errMessage), nil
+		}
+	}
+
+	limits, err := resolveQueryLimits(deps, args.MaxRows, args.TimeoutSeconds)
+	if err != nil {
+		slog.Error("invalid query limits", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ctx, cancel := limits.withTimeout(ctx)
+	defer cancel()
+
+	slog.Info("executing cypher batch", "statements", len(args.Statements), "transactional", args.Transactional)
+
+	var result *batchCypherResult
+	if args.Transactional {
+		result, err = runBatchInTransaction(ctx, deps, limits, args.Statements)
+	} else {
+		result, err = runBatch(ctx, deps, limits, args.Statements, args.StopOnError)
+	}
+	if err != nil {
+		slog.Error("error executing cypher batch", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		slog.Error("error formatting batch results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// runBatch executes each statement in its own transaction
+func runBatch(ctx context.Context, deps *tools.ToolDependencies, limits queryLimits, statements []BatchStatement, stopOnError bool) (*batchCypherResult, error) {
+	result := &batchCypherResult{Results: make([]batchStatementResult, 0, len(statements))}
+	stopped := false
+
+	for i, statement := range statements {
+		if stopped {
+			result.add(batchStatementResult{Index: i, Status: batchStatusSkipped})
+			continue
+		}
+
+		records, summary, err := deps.DBService.ExecuteWriteQueryWithSummary(ctx, statement.Query, statement.Params)
+		if err != nil {
+			result.add(batchStatementResult{Index: i, Status: batchStatusFailed, Error: limits.queryError(ctx, err)})
+			stopped = stopOnError || ctx.Err() != nil
+			continue
+		}
+
+		writeResult, err := newWriteCypherResult(ctx, deps, limits, records, summary)
+		if err != nil {
+			return nil, err
+		}
+		result.add(batchStatementResult{Index: i, Status: batchStatusOK, writeCypherResult: writeResult})
+	}
+
+	return result, nil
+}
+
+// runBatchInTransaction executes all statements in one explicit transaction and commits it when they all succeed
+func runBatchInTransaction(ctx context.Context, deps *tools.ToolDependencies, limits queryLimits, statements []BatchStatement) (*batchCypherResult, error) {
+	id, err := deps.DBService.BeginTransaction(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	committed := false
+	result := &batchCypherResult{
+		Transactional: true,
+		Committed:     &committed,
+		Results:       make([]batchStatementResult, 0, len(statements)),
+	}
+	failed := false
+
+	for i, statement := range statements {
+		if failed {
+			result.add(batchStatementResult{Index: i, Status: batchStatusSkipped})
+			continue
+		}
+
+		// A failed statement rolls the transaction back
+		records, summary, err := deps.DBService.RunInTransaction(ctx, id, statement.Query, statement.Params)
+		if err != nil {
+			result.add(batchStatementResult{Index: i, Status: batchStatusFailed, Error: limits.queryError(ctx, err)})
+			failed = true
+			continue
+		}
+
+		writeResult, err := newWriteCypherResult(ctx, deps, limits, records, summary)
+		if err != nil {
+			_ = deps.DBService.RollbackTransaction(context.WithoutCancel(ctx), id)
+			return nil, err
+		}
+		result.add(batchStatementResult{Index: i, Status: batchStatusOK, writeCypherResult: writeResult})
+	}
+
+	if failed {
+		return result, nil
+	}
+
+	if err := deps.DBService.CommitTransaction(ctx, id); err != nil {
+		result.Error = err.Error()
+		return result, nil
+	}
+	committed = true
+	return result, nil
+}
+
+// add appends a statement result and updates the status counts
+func (r *batchCypherResult) add(statement batchStatementResult) {
+	switch statement.Status {
+	case batchStatusOK:
+		r.Succeeded++
+	case batchStatusFailed:
+		r.Failed++
+	case batchStatusSkipped:
+		r.Skipped++
+	}
+	r.Results = append(r.Results, statement)
+}
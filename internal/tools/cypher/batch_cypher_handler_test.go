@@ -0,0 +1,182 @@
+package cypher_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+// batchResponse mirrors the batch-cypher JSON response
+type batchResponse struct {
+	Transactional bool  `json:"transactional"`
+	Committed     *bool `json:"committed"`
+	Succeeded     int   `json:"succeeded"`
+	Failed        int   `json:"failed"`
+	Skipped       int   `json:"skipped"`
+	Results       []struct {
+		Index   int                    `json:"index"`
+		Status  string                 `json:"status"`
+		Error   string                 `json:"error"`
+		Summary *database.WriteSummary `json:"summary"`
+	} `json:"results"`
+}
+
+func TestBatchCypherHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("batch-cypher").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	statements := []map[string]any{
+		{"query": "CREATE (:Customer {id: $id})", "params": map[string]any{"id": "C1"}},
+		{"query": "CREATE (:Customer {id: $id})", "params": map[string]any{"id": "C1"}},
+		{"query": "CREATE (:Account {id: 'A1'})"},
+	}
+
+	callBatch := func(t *testing.T, deps *tools.ToolDependencies, arguments map[string]any) batchResponse {
+		t.Helper()
+		result, err := cypher.BatchCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: arguments},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v %v", err, result)
+		}
+		var response batchResponse
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected batch result JSON, got: %v", err)
+		}
+		return response
+	}
+
+	t.Run("failed statement does not stop the batch", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQueryWithSummary(gomock.Any(), "CREATE (:Customer {id: $id})", gomock.Any()).
+			Return([]*neo4j.Record{}, &database.WriteSummary{ContainsUpdates: true, NodesCreated: 1}, nil)
+		mockDB.EXPECT().
+			ExecuteWriteQueryWithSummary(gomock.Any(), "CREATE (:Customer {id: $id})", gomock.Any()).
+			Return(nil, nil, errors.New("constraint violation"))
+		mockDB.EXPECT().
+			ExecuteWriteQueryWithSummary(gomock.Any(), "CREATE (:Account {id: 'A1'})", gomock.Nil()).
+			Return([]*neo4j.Record{}, &database.WriteSummary{ContainsUpdates: true, NodesCreated: 1}, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil).Times(2)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		response := callBatch(t, deps, map[string]any{"statements": statements})
+
+		if response.Succeeded != 2 || response.Failed != 1 || response.Skipped != 0 {
+			t.Errorf("Expected 2 succeeded and 1 failed, got: %+v", response)
+		}
+		if response.Results[1].Error != "constraint violation" || response.Results[0].Summary == nil || response.Results[0].Summary.NodesCreated != 1 {
+			t.Errorf("Expected per-statement summary and error, got: %+v", response.Results)
+		}
+		if response.Committed != nil {
+			t.Errorf("Expected no commit status without a transaction, got: %v", *response.Committed)
+		}
+	})
+
+	t.Run("stopOnError skips the remaining statements", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQueryWithSummary(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, nil, errors.New("syntax error"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		response := callBatch(t, deps, map[string]any{"statements": statements, "stopOnError": true})
+
+		if response.Failed != 1 || response.Skipped != 2 {
+			t.Errorf("Expected 1 failed and 2 skipped, got: %+v", response)
+		}
+	})
+
+	t.Run("transactional batch commits when every statement succeeds", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().BeginTransaction(gomock.Any()).Return("tx-1", nil),
+			mockDB.EXPECT().
+				RunInTransaction(gomock.Any(), "tx-1", gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{}, &database.WriteSummary{ContainsUpdates: true, NodesCreated: 1}, nil).
+				Times(2),
+			mockDB.EXPECT().CommitTransaction(gomock.Any(), "tx-1").Return(nil),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil).Times(2)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		response := callBatch(t, deps, map[string]any{"statements": statements[1:], "transactional": true})
+
+		if !response.Transactional || response.Committed == nil || !*response.Committed || response.Succeeded != 2 {
+			t.Errorf("Expected committed transactional batch, got: %+v", response)
+		}
+	})
+
+	t.Run("transactional batch stops at the first failure without committing", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().BeginTransaction(gomock.Any()).Return("tx-1", nil),
+			mockDB.EXPECT().
+				RunInTransaction(gomock.Any(), "tx-1", gomock.Any(), gomock.Any()).
+				Return([]*neo4j.Record{}, &database.WriteSummary{ContainsUpdates: true, NodesCreated: 1}, nil),
+			mockDB.EXPECT().
+				RunInTransaction(gomock.Any(), "tx-1", gomock.Any(), gomock.Any()).
+				Return(nil, nil, errors.New("statement failed and the transaction was rolled back: constraint violation")),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		response := callBatch(t, deps, map[string]any{"statements": statements, "transactional": true})
+
+		if response.Committed == nil || *response.Committed {
+			t.Errorf("Expected batch not to be committed, got: %+v", response)
+		}
+		if response.Succeeded != 1 || response.Failed != 1 || response.Skipped != 1 {
+			t.Errorf("Expected 1 succeeded, 1 failed and 1 skipped, got: %+v", response)
+		}
+	})
+
+	t.Run("empty statement is rejected before running anything", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.BatchCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"statements": []map[string]any{{"query": "RETURN 1"}, {"query": ""}}},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for empty statement")
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package cypher
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxBatchStatements bounds the number of statements a single batch-cypher call may run
+const maxBatchStatements = 100
+
+type BatchStatement struct {
+	Query  string `json:"query" jsonschema:"description=The Cypher statement to execute"`
+	Params Params `json:"params,omitempty" jsonschema:"default={},description=Parameters to pass to the Cypher statement"`
+}
+
+type BatchCypherInput struct {
+	Statements     []BatchStatement `json:"statements" jsonschema:"description=The statements to execute in order (at most 100)"`
+	Transactional  bool             `json:"transactional,omitempty" jsonschema:"description=Optional: run all statements in one transaction. The first failing statement rolls back the whole batch. Default false."`
+	StopOnError    bool             `json:"stopOnError,omitempty" jsonschema:"description=Optional: without a transaction stop at the first failing statement instead of running the rest. Default false."`
+	Database       string           `json:"database,omitempty" jsonschema:"description=Optional: name of the database to run against (Neo4j Enterprise/Aura with multiple databases). Defaults to the configured database."`
+	MaxRows        int              `json:"maxRows,omitempty" jsonschema:"description=Optional: maximum number of rows returned per statement. Defaults to the server limit (NEO4J_QUERY_MAX_ROWS)."`
+	TimeoutSeconds int              `json:"timeoutSeconds,omitempty" jsonschema:"description=Optional: seconds the whole batch may run. Defaults to the server limit (NEO4J_QUERY_TIMEOUT)."`
+}
+
+func BatchCypherSpec() mcp.Tool {
+	return mcp.NewTool("batch-cypher",
+		mcp.WithDescription(`Executes a list of Cypher statements in order, with write access, and returns the records, write summary or error of each statement.
+		Use it to seed demo fraud data or run multi-step write flows in one call.
+		By default every statement runs on its own and a failure does not stop the others; set stopOnError to stop at the first failure.
+		Set transactional to run the batch atomically: the first failure rolls back every statement and the remaining ones are skipped.`),
+		mcp.WithInputSchema[BatchCypherInput](),
+		mcp.WithTitleAnnotation("Batch Cypher"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,64 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+func CancelQueryHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleCancelQuery(ctx, request, deps)
+	}
+}
+
+func handleCancelQuery(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("cancel-query"))
+
+	var args CancelQueryInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	deps = deps.ForDatabase(args.Database)
+
+	if args.QueryID == "" {
+		errMessage := "queryId parameter is required"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	cancelled, err := deps.DBService.CancelQuery(ctx, args.QueryID)
+	if errors.Is(err, database.ErrQueryNotFound) {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if err != nil {
+		slog.Error("error cancelling query", "queryId", args.QueryID, "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := json.Marshal(cancelled)
+	if err != nil {
+		slog.Error("error formatting cancelled query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
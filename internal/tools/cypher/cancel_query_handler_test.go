@@ -0,0 +1,88 @@
+package cypher_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCancelQueryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("cancel-query").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("cancels a running query", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().CancelQuery(gomock.Any(), "query-17").Return(&database.CancelledQuery{
+			TransactionID: "neo4j-transaction-42",
+			QueryID:       "query-17",
+			Query:         "MATCH p=(:Customer)-[*]-(:Customer) RETURN p",
+			Message:       "Transaction terminated.",
+		}, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.CancelQueryHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"queryId": "query-17"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"transactionId":"neo4j-transaction-42"`) {
+			t.Errorf("Expected terminated transaction, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("unknown query", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().CancelQuery(gomock.Any(), "query-99").Return(nil, database.ErrQueryNotFound)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.CancelQueryHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"queryId": "query-99"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for unknown query")
+		}
+	})
+
+	t.Run("missing query ID", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.CancelQueryHandler(deps)(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing query ID")
+		}
+	})
+}
@@ -0,0 +1,24 @@
+package cypher
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type CancelQueryInput struct {
+	QueryID  string `json:"queryId" jsonschema:"description=The transaction ID (e.g. neo4j-transaction-42) or query ID (e.g. query-17) of the running query as shown by SHOW TRANSACTIONS"`
+	Database string `json:"database,omitempty" jsonschema:"description=Optional: name of the database the query runs on (Neo4j Enterprise/Aura with multiple databases). Defaults to the configured database."`
+}
+
+func CancelQuerySpec() mcp.Tool {
+	return mcp.NewTool("cancel-query",
+		mcp.WithDescription(`Terminates a running query issued through this MCP server, rolling back its transaction.
+		Use it to stop a runaway traversal, for example an unbounded fraud ring expansion, without waiting for its timeout.
+		Only queries sent by this MCP server can be cancelled, and over authenticated HTTP only those of the calling identity. Queries are also cancelled on the server when the tool call that started them is cancelled.`),
+		mcp.WithInputSchema[CancelQueryInput](),
+		mcp.WithTitleAnnotation("Cancel Query"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
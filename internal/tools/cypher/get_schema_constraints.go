@@ -0,0 +1,167 @@
+package cypher
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	// constraintsQuery retrieves uniqueness, key and existence constraints
+	constraintsQuery = `
+		SHOW CONSTRAINTS
+		YIELD name, type, entityType, labelsOrTypes, properties
+		RETURN name, type, entityType, labelsOrTypes, properties
+	`
+
+	// indexesQuery retrieves property indexes; token lookup indexes cover every label and are skipped
+	indexesQuery = `
+		SHOW INDEXES
+		YIELD name, type, entityType, labelsOrTypes, properties, state
+		WHERE type <> 'LOOKUP'
+		RETURN name, type, entityType, labelsOrTypes, properties, state
+	`
+)
+
+// Constraint describes a schema constraint on a label or relationship type
+type Constraint struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"` // e.g. UNIQUENESS, NODE_KEY, NODE_PROPERTY_EXISTENCE
+	Properties []string `json:"properties"`
+}
+
+// Index describes a property index on a label or relationship type
+type Index struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"` // e.g. RANGE, TEXT, POINT, FULLTEXT, VECTOR
+	Properties []string `json:"properties"`
+	State      string   `json:"state,omitempty"`
+}
+
+// applyConstraintsAndIndexes attaches constraints and indexes to the schema items they belong to.
+// Entries for labels or relationship types that are not part of the schema are ignored.
+func applyConstraintsAndIndexes(schema []SchemaItem, constraintRecords, indexRecords []*neo4j.Record) {
+	itemIndex := make(map[string]int, len(schema))
+	for i, item := range schema {
+		itemIndex[schemaItemKey(item.Value.Type, item.Key)] = i
+	}
+
+	for _, record := range constraintRecords {
+		entry, ok := parseSchemaEntry(record)
+		if !ok {
+			continue
+		}
+		for _, labelOrType := range entry.labelsOrTypes {
+			if i, ok := itemIndex[schemaItemKey(entry.itemType, labelOrType)]; ok {
+				schema[i].Value.Constraints = append(schema[i].Value.Constraints, Constraint{
+					Name:       entry.name,
+					Type:       entry.indexType,
+					Properties: entry.properties,
+				})
+			}
+		}
+	}
+
+	for _, record := range indexRecords {
+		entry, ok := parseSchemaEntry(record)
+		if !ok {
+			continue
+		}
+		state, _ := record.Get("state")
+		stateValue, _ := state.(string)
+		for _, labelOrType := range entry.labelsOrTypes {
+			if i, ok := itemIndex[schemaItemKey(entry.itemType, labelOrType)]; ok {
+				schema[i].Value.Indexes = append(schema[i].Value.Indexes, Index{
+					Name:       entry.name,
+					Type:       entry.indexType,
+					Properties: entry.properties,
+					State:      stateValue,
+				})
+			}
+		}
+	}
+}
+
+// schemaEntry holds the columns shared by SHOW CONSTRAINTS and SHOW INDEXES
+type schemaEntry struct {
+	name          string
+	indexType     string
+	itemType      string
+	labelsOrTypes []string
+	properties    []string
+}
+
+// parseSchemaEntry reads a SHOW CONSTRAINTS or SHOW INDEXES record
+func parseSchemaEntry(record *neo4j.Record) (schemaEntry, bool) {
+	nameRaw, _ := record.Get("name")
+	typeRaw, _ := record.Get("type")
+	entityTypeRaw, _ := record.Get("entityType")
+	labelsRaw, _ := record.Get("labelsOrTypes")
+	propertiesRaw, _ := record.Get("properties")
+
+	name, _ := nameRaw.(string)
+	indexType, _ := typeRaw.(string)
+	entityType, _ := entityTypeRaw.(string)
+	labelsOrTypes := toStringSlice(labelsRaw)
+	if indexType == "" || len(labelsOrTypes) == 0 {
+		slog.Debug("skipping schema entry without type or labels", "name", name)
+		return schemaEntry{}, false
+	}
+
+	itemType := "node"
+	if strings.EqualFold(entityType, "RELATIONSHIP") {
+		itemType = "relationship"
+	}
+
+	return schemaEntry{
+		name:          name,
+		indexType:     indexType,
+		itemType:      itemType,
+		labelsOrTypes: labelsOrTypes,
+		properties:    toStringSlice(propertiesRaw),
+	}, true
+}
+
+func schemaItemKey(itemType, key string) string {
+	return itemType + ":" + key
+}
+
+// toStringSlice converts a driver list value to a string slice, skipping non-string elements
+func toStringSlice(value any) []string {
+	list, ok := value.([]any)
+	if !ok {
+		return nil
+	}
+	result := make([]string, 0, len(list))
+	for _, element := range list {
+		if s, ok := element.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// formatConstraintsAndIndexes writes the constraint and index lines for one schema item
+func formatConstraintsAndIndexes(md *strings.Builder, detail SchemaDetail) {
+	if len(detail.Constraints) > 0 {
+		md.WriteString("*Constraints:*\n\n")
+		for _, constraint := range detail.Constraints {
+			md.WriteString(fmt.Sprintf("  - %s on (%s)\n", constraint.Type, strings.Join(constraint.Properties, ", ")))
+		}
+		md.WriteString("\n")
+	}
+
+	if len(detail.Indexes) > 0 {
+		md.WriteString("*Indexes:*\n\n")
+		for _, index := range detail.Indexes {
+			line := fmt.Sprintf("  - %s index on (%s)", index.Type, strings.Join(index.Properties, ", "))
+			if index.State != "" && index.State != "ONLINE" {
+				line += fmt.Sprintf(" [%s]", index.State)
+			}
+			md.WriteString(line + "\n")
+		}
+		md.WriteString("\n")
+	}
+}
@@ -0,0 +1,67 @@
+package cypher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// loadCounts attaches node counts per label and relationship counts per type to the schema.
+// Each count is a single-label or single-type pattern, which Neo4j answers from its count store
+// without scanning the graph. Counts are best effort: on failure the schema is returned without them.
+func loadCounts(ctx context.Context, deps *tools.ToolDependencies, schema []SchemaItem) {
+	if len(schema) == 0 {
+		return
+	}
+
+	query, params := buildCountsQuery(schema)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Warn("failed to retrieve label and relationship counts, continuing without them", "error", err)
+		return
+	}
+
+	itemIndex := make(map[string]int, len(schema))
+	for i, item := range schema {
+		itemIndex[schemaItemKey(item.Value.Type, item.Key)] = i
+	}
+
+	for _, record := range records {
+		keyRaw, _ := record.Get("key")
+		typeRaw, _ := record.Get("type")
+		countRaw, _ := record.Get("count")
+
+		key, _ := keyRaw.(string)
+		itemType, _ := typeRaw.(string)
+		count, ok := countRaw.(int64)
+		if !ok {
+			continue
+		}
+		if i, ok := itemIndex[schemaItemKey(itemType, key)]; ok {
+			schema[i].Value.Count = &count
+		}
+	}
+}
+
+// buildCountsQuery returns one UNION ALL branch per label and relationship type
+func buildCountsQuery(schema []SchemaItem) (string, map[string]any) {
+	branches := make([]string, 0, len(schema))
+	params := make(map[string]any, len(schema))
+
+	for i, item := range schema {
+		keyParam := fmt.Sprintf("key%d", i)
+		params[keyParam] = item.Key
+
+		switch item.Value.Type {
+		case "node":
+			branches = append(branches, fmt.Sprintf("MATCH (n:%s) RETURN $%s AS key, 'node' AS type, count(n) AS count", quoteIdentifier(item.Key), keyParam))
+		case "relationship":
+			branches = append(branches, fmt.Sprintf("MATCH ()-[r:%s]->() RETURN $%s AS key, 'relationship' AS type, count(r) AS count", quoteIdentifier(item.Key), keyParam))
+		}
+	}
+
+	return strings.Join(branches, "\nUNION ALL\n"), params
+}
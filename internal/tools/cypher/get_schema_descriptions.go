@@ -0,0 +1,30 @@
+package cypher
+
+import (
+	"context"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// WithDescriptions returns a copy of the schema with the business descriptions stored for the database attached,
+// and the stored schema summary. The cached schema is left untouched; without stored descriptions it is returned as is.
+func WithDescriptions(ctx context.Context, deps *tools.ToolDependencies, schema []SchemaItem) ([]SchemaItem, string) {
+	enrichment, ok := deps.EnrichmentCache.Get(deps.DBService.GetDatabaseName(ctx))
+	if !ok {
+		return schema, ""
+	}
+
+	result := make([]SchemaItem, len(schema))
+	copy(result, schema)
+	for i := range result {
+		descriptions := enrichment.Labels
+		if result[i].Value.Type == "relationship" {
+			descriptions = enrichment.RelationshipTypes
+		}
+		if element, ok := descriptions[result[i].Key]; ok {
+			result[i].Value.Description = element.Description
+			result[i].Value.PropertyDescriptions = element.Properties
+		}
+	}
+	return result, enrichment.Summary
+}
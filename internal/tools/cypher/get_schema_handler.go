@@ -2,8 +2,10 @@ package cypher
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -31,15 +33,21 @@ const (
 	`
 )
 
+const (
+	formatMarkdown = "markdown"
+	formatJSON     = "json"
+	formatCompact  = "compact"
+)
+
 // GetSchemaHandler returns a handler function for the get_schema tool
 func GetSchemaHandler(deps *tools.ToolDependencies, schemaSampleSize int32) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleGetSchema(ctx, deps, schemaSampleSize)
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetSchema(ctx, deps, request, schemaSampleSize)
 	}
 }
 
 // handleGetSchema retrieves Neo4j schema information using native procedures
-func handleGetSchema(ctx context.Context, deps *tools.ToolDependencies, schemaSampleSize int32) (*mcp.CallToolResult, error) {
+func handleGetSchema(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest, schemaSampleSize int32) (*mcp.CallToolResult, error) {
 	if deps.DBService == nil {
 		errMessage := "database service is not initialized"
 		slog.Error(errMessage)
@@ -53,60 +61,53 @@ func handleGetSchema(ctx context.Context, deps *tools.ToolDependencies, schemaSa
 	}
 
 	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("get-schema"))
-	slog.Info("retrieving schema from the database", "database", deps.DBService.GetDatabaseName())
 
-	// Execute schema visualization query to get graph structure
-	visualizationRecords, err := deps.DBService.ExecuteReadQuery(ctx, schemaVisualizationQuery, nil)
-	if err != nil {
-		slog.Error("failed to execute schema visualization query", "error", err)
+	var args GetSchemaInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	slog.Debug("schema visualization query completed", "records_count", len(visualizationRecords))
-
-	if len(visualizationRecords) == 0 {
-		// Before declaring database empty, verify with a node count check
-		slog.Warn("schema visualization returned no records, verifying database contents")
-		countRecords, countErr := deps.DBService.ExecuteReadQuery(ctx, "MATCH (n) RETURN count(n) as nodeCount", nil)
-		if countErr != nil {
-			slog.Error("failed to execute node count verification query", "error", countErr)
-			return mcp.NewToolResultError(fmt.Sprintf("schema visualization returned no records and verification failed: %v", countErr)), nil
-		}
-
-		if len(countRecords) > 0 {
-			if nodeCount, ok := countRecords[0].Get("nodeCount"); ok {
-				if count, ok := nodeCount.(int64); ok && count > 0 {
-					slog.Error("database contains nodes but schema visualization returned empty",
-						"nodeCount", count,
-						"database", deps.DBService.GetDatabaseName())
-					return mcp.NewToolResultError(fmt.Sprintf("Internal error: database '%s' contains %d nodes but schema visualization failed. This may indicate a schema introspection issue.", deps.DBService.GetDatabaseName(), count)), nil
-				}
-			}
-		}
+	deps = deps.ForDatabase(args.Database)
 
-		slog.Info("database is empty, no schema to return", "database", deps.DBService.GetDatabaseName())
-		return mcp.NewToolResultText(fmt.Sprintf("The get-schema tool executed successfully; however, since the Neo4j database '%s' contains no data, no schema information was returned.", deps.DBService.GetDatabaseName())), nil
+	if args.Format == "" {
+		args.Format = formatMarkdown
+	}
+	args.Format = strings.ToLower(args.Format)
+	if args.Format != formatMarkdown && args.Format != formatJSON && args.Format != formatCompact {
+		errMessage := fmt.Sprintf("format must be one of %s, %s or %s", formatMarkdown, formatJSON, formatCompact)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
 	}
 
-	// Execute node properties query
-	nodePropsRecords, err := deps.DBService.ExecuteReadQuery(ctx, nodePropertiesQuery, nil)
+	structuredOutput, err := LoadSchema(ctx, deps, args.Refresh)
 	if err != nil {
-		slog.Error("failed to execute node properties query", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	// Execute relationship properties query
-	relPropsRecords, err := deps.DBService.ExecuteReadQuery(ctx, relPropertiesQuery, nil)
-	if err != nil {
-		slog.Error("failed to execute relationship properties query", "error", err)
-		return mcp.NewToolResultError(err.Error()), nil
+	if args.IncludeSamples && len(structuredOutput) > 0 {
+		samples := loadSamples(ctx, deps, structuredOutput, schemaSampleSize, args.Refresh)
+		structuredOutput = withSamples(structuredOutput, samples)
 	}
 
-	// Process the three query results into unified schema
-	structuredOutput, err := processNativeSchema(visualizationRecords, nodePropsRecords, relPropsRecords)
-	if err != nil {
-		slog.Error("failed to process get-schema native queries", "error", err)
-		return mcp.NewToolResultError(err.Error()), nil
+	structuredOutput, summary := WithDescriptions(ctx, deps, structuredOutput)
+
+	if args.Format == formatJSON {
+		schemaJSON, err := json.Marshal(structuredOutput)
+		if err != nil {
+			slog.Error("failed to serialize schema", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(string(schemaJSON)), nil
+	}
+
+	if len(structuredOutput) == 0 {
+		slog.Info("database is empty, no schema to return", "database", deps.DBService.GetDatabaseName(ctx))
+		return mcp.NewToolResultText(fmt.Sprintf("The get-schema tool executed successfully; however, since the Neo4j database '%s' contains no data, no schema information was returned.", deps.DBService.GetDatabaseName(ctx))), nil
+	}
+
+	if args.Format == formatCompact {
+		return mcp.NewToolResultText(FormatSchemaCompact(structuredOutput)), nil
 	}
 
 	// Convert to Neo4j documentation markdown format
@@ -134,12 +135,113 @@ The schema below shows the current structure of your Neo4j database.
 `
 
 	enrichedMarkdown := fraudDatabaseContext + markdown
+	if summary != "" {
+		enrichedMarkdown = fraudDatabaseContext + "## Business Context\n\n" + summary + "\n\n" + markdown
+	}
 
 	slog.Info("returning schema with fraud detection context", "schema_size", len(enrichedMarkdown))
 
 	return mcp.NewToolResultText(enrichedMarkdown), nil
 }
 
+// LoadSchema returns the processed schema of the current database.
+// The result is served from deps.SchemaCache while it is fresh; refresh forces a reload from the native procedures.
+// An empty result means the database contains no data. Empty results are not cached, so data loaded later is picked up.
+func LoadSchema(ctx context.Context, deps *tools.ToolDependencies, refresh bool) ([]SchemaItem, error) {
+	database := deps.DBService.GetDatabaseName(ctx)
+
+	if !refresh {
+		if cached, ok := deps.SchemaCache.Get(ctx, database); ok {
+			if schema, ok := cached.([]SchemaItem); ok {
+				slog.Debug("serving schema from cache", "database", database)
+				return schema, nil
+			}
+		}
+	}
+
+	slog.Info("retrieving schema from the database", "database", database, "refresh", refresh)
+
+	// Execute schema visualization query to get graph structure. It runs first, as it tells whether the database is empty.
+	visualizationRecords, err := deps.DBService.ExecuteReadQuery(ctx, schemaVisualizationQuery, nil)
+	if err != nil {
+		slog.Error("failed to execute schema visualization query", "error", err)
+		return nil, err
+	}
+
+	slog.Debug("schema visualization query completed", "records_count", len(visualizationRecords))
+
+	if len(visualizationRecords) == 0 {
+		// Before declaring database empty, verify with a node count check
+		slog.Warn("schema visualization returned no records, verifying database contents")
+		countRecords, countErr := deps.DBService.ExecuteReadQuery(ctx, "MATCH (n) RETURN count(n) as nodeCount", nil)
+		if countErr != nil {
+			slog.Error("failed to execute node count verification query", "error", countErr)
+			return nil, fmt.Errorf("schema visualization returned no records and verification failed: %v", countErr)
+		}
+
+		if len(countRecords) > 0 {
+			if nodeCount, ok := countRecords[0].Get("nodeCount"); ok {
+				if count, ok := nodeCount.(int64); ok && count > 0 {
+					slog.Error("database contains nodes but schema visualization returned empty",
+						"nodeCount", count,
+						"database", database)
+					return nil, fmt.Errorf("Internal error: database '%s' contains %d nodes but schema visualization failed. This may indicate a schema introspection issue.", database, count)
+				}
+			}
+		}
+
+		deps.SchemaCache.Invalidate(ctx, database)
+		return []SchemaItem{}, nil
+	}
+
+	// The property queries and SHOW commands are independent, so they run in parallel sessions
+	var nodePropsRecords, relPropsRecords, constraintRecords, indexRecords []*neo4j.Record
+	errs := tools.RunParallel(ctx, tools.MaxParallelQueries,
+		readQueryTask(deps, nodePropertiesQuery, &nodePropsRecords),
+		readQueryTask(deps, relPropertiesQuery, &relPropsRecords),
+		readQueryTask(deps, constraintsQuery, &constraintRecords),
+		readQueryTask(deps, indexesQuery, &indexRecords),
+	)
+	if err := errs[0]; err != nil {
+		slog.Error("failed to execute node properties query", "error", err)
+		return nil, err
+	}
+	if err := errs[1]; err != nil {
+		slog.Error("failed to execute relationship properties query", "error", err)
+		return nil, err
+	}
+
+	// Process the three query results into unified schema
+	schema, err := processNativeSchema(visualizationRecords, nodePropsRecords, relPropsRecords)
+	if err != nil {
+		slog.Error("failed to process get-schema native queries", "error", err)
+		return nil, err
+	}
+
+	// Constraints and indexes are best effort: SHOW commands need privileges some users lack
+	if errs[2] != nil {
+		slog.Warn("failed to retrieve constraints, continuing without them", "error", errs[2])
+	}
+	if errs[3] != nil {
+		slog.Warn("failed to retrieve indexes, continuing without them", "error", errs[3])
+	}
+	applyConstraintsAndIndexes(schema, constraintRecords, indexRecords)
+	loadCounts(ctx, deps, schema)
+
+	deps.SchemaCache.Set(ctx, database, schema)
+
+	return schema, nil
+}
+
+// readQueryTask returns a task for tools.RunParallel that runs a query without parameters and stores its records in dest
+func readQueryTask(deps *tools.ToolDependencies, query string, dest *[]*neo4j.Record) func(context.Context) error {
+	return func(ctx context.Context) error {
+		records, err := deps.DBService.ExecuteReadQuery(ctx, query, nil)
+		*dest = records
+		return err
+	}
+}
+
 type SchemaItem struct {
 	Key   string       `json:"key"`
 	Value SchemaDetail `json:"value"`
@@ -149,6 +251,13 @@ type SchemaDetail struct {
 	Type          string                  `json:"type"`
 	Properties    map[string]string       `json:"properties,omitempty"`
 	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Constraints   []Constraint            `json:"constraints,omitempty"`
+	Indexes       []Index                 `json:"indexes,omitempty"`
+	Samples       map[string][]string     `json:"samples,omitempty"` // Example values per property, PII masked
+	Count         *int64                  `json:"count,omitempty"`   // Nodes with the label or relationships of the type
+
+	Description          string            `json:"description,omitempty"`          // Business meaning stored with store-enriched-schema
+	PropertyDescriptions map[string]string `json:"propertyDescriptions,omitempty"` // Business meaning of each property, by name
 }
 
 type Relationship struct {
@@ -658,16 +767,30 @@ func formatSchemaAsMarkdown(items []SchemaItem) string {
 
 		for _, node := range nodes {
 			md.WriteString(fmt.Sprintf("### %s\n\n", node.Key))
+			formatDescription(&md, node.Value)
+
+			if node.Value.Count != nil {
+				md.WriteString(fmt.Sprintf("*Count:* %d nodes\n\n", *node.Value.Count))
+			}
 
 			// Write properties
 			if len(node.Value.Properties) > 0 {
 				md.WriteString("*Properties:*\n\n")
 				for propName, propType := range node.Value.Properties {
-					md.WriteString(fmt.Sprintf("  - `%s` (%s)\n", propName, propType))
+					line := fmt.Sprintf("  - `%s` (%s)", propName, propType)
+					if examples := node.Value.Samples[propName]; len(examples) > 0 {
+						line += fmt.Sprintf(" e.g. `%s`", strings.Join(examples, "`, `"))
+					}
+					if description := node.Value.PropertyDescriptions[propName]; description != "" {
+						line += " - " + description
+					}
+					md.WriteString(line + "\n")
 				}
 				md.WriteString("\n")
 			}
 
+			formatConstraintsAndIndexes(&md, node.Value)
+
 			// Write relationships
 			if len(node.Value.Relationships) > 0 {
 				md.WriteString("*Relationships:*\n\n")
@@ -693,16 +816,127 @@ func formatSchemaAsMarkdown(items []SchemaItem) string {
 
 		for _, rel := range relationships {
 			md.WriteString(fmt.Sprintf("### :%s\n\n", rel.Key))
+			formatDescription(&md, rel.Value)
+
+			if rel.Value.Count != nil {
+				md.WriteString(fmt.Sprintf("*Count:* %d relationships\n\n", *rel.Value.Count))
+			}
 
 			if len(rel.Value.Properties) > 0 {
 				md.WriteString("*Properties:*\n\n")
 				for propName, propType := range rel.Value.Properties {
-					md.WriteString(fmt.Sprintf("  - `%s` (%s)\n", propName, propType))
+					line := fmt.Sprintf("  - `%s` (%s)", propName, propType)
+					if description := rel.Value.PropertyDescriptions[propName]; description != "" {
+						line += " - " + description
+					}
+					md.WriteString(line + "\n")
 				}
 				md.WriteString("\n")
 			}
+
+			formatConstraintsAndIndexes(&md, rel.Value)
 		}
 	}
 
 	return md.String()
 }
+
+// formatDescription writes the stored business description of a label or relationship type, if any
+func formatDescription(md *strings.Builder, detail SchemaDetail) {
+	if detail.Description != "" {
+		md.WriteString(fmt.Sprintf("*Description:* %s\n\n", detail.Description))
+	}
+}
+
+// FormatSchemaCompact renders the schema as one line per label, relationship pattern and relationship type.
+// Properties are sorted so the output is stable between calls.
+//
+//	(:Customer) customerId: STRING, name: STRING | unique: customerId | indexed: name | count: 1200
+//	(:Customer)-[:HAS_ACCOUNT]->(:Account)
+//	[:HAS_ACCOUNT] since: DATE | count: 1500
+func FormatSchemaCompact(items []SchemaItem) string {
+	var nodeLines, patternLines, relationshipLines []string
+
+	for _, item := range items {
+		switch item.Value.Type {
+		case "node":
+			line := fmt.Sprintf("(:%s)", item.Key)
+			if props := formatCompactProperties(item.Value.Properties); props != "" {
+				line += " " + props
+			}
+			line += formatCompactConstraints(item.Value)
+			line += formatCompactCount(item.Value)
+			nodeLines = append(nodeLines, line)
+
+			for relName, rel := range item.Value.Relationships {
+				// Incoming relationships are listed from the start node
+				if rel.Direction != "out" {
+					continue
+				}
+				for _, target := range rel.Labels {
+					patternLines = append(patternLines, fmt.Sprintf("(:%s)-[:%s]->(:%s)", item.Key, relName, target))
+				}
+			}
+		case "relationship":
+			line := fmt.Sprintf("[:%s]", item.Key)
+			if props := formatCompactProperties(item.Value.Properties); props != "" {
+				line += " " + props
+			}
+			line += formatCompactConstraints(item.Value)
+			line += formatCompactCount(item.Value)
+			relationshipLines = append(relationshipLines, line)
+		}
+	}
+
+	sort.Strings(nodeLines)
+	sort.Strings(patternLines)
+	sort.Strings(relationshipLines)
+
+	lines := append(append(nodeLines, patternLines...), relationshipLines...)
+	return strings.Join(lines, "\n")
+}
+
+// formatCompactProperties renders properties as "name: TYPE" pairs sorted by name
+func formatCompactProperties(properties map[string]string) string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", name, properties[name]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// formatCompactConstraints renders unique and indexed property sets as " | unique: ... | indexed: ..."
+func formatCompactConstraints(detail SchemaDetail) string {
+	var unique, indexed []string
+	for _, constraint := range detail.Constraints {
+		if strings.Contains(constraint.Type, "UNIQUENESS") || strings.Contains(constraint.Type, "KEY") {
+			unique = append(unique, strings.Join(constraint.Properties, "+"))
+		}
+	}
+	for _, index := range detail.Indexes {
+		indexed = append(indexed, strings.Join(index.Properties, "+"))
+	}
+
+	var result string
+	if len(unique) > 0 {
+		result += " | unique: " + strings.Join(unique, ", ")
+	}
+	if len(indexed) > 0 {
+		result += " | indexed: " + strings.Join(indexed, ", ")
+	}
+	return result
+}
+
+// formatCompactCount renders the count as " | count: N" when it is known
+func formatCompactCount(detail SchemaDetail) string {
+	if detail.Count == nil {
+		return ""
+	}
+	return fmt.Sprintf(" | count: %d", *detail.Count)
+}
@@ -2,13 +2,15 @@ package cypher_test
 
 import (
 	"context"
-	// "encoding/json" // Commented out - only used in TestGetSchemaProcessing which is now commented out
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
 	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
@@ -29,7 +31,7 @@ func TestGetSchemaHandler(t *testing.T) {
 
 		// Mock GetDatabaseName for logging
 		mockDB.EXPECT().
-			GetDatabaseName().
+			GetDatabaseName(gomock.Any()).
 			Return("neo4j").
 			AnyTimes()
 
@@ -55,20 +57,22 @@ func TestGetSchemaHandler(t *testing.T) {
 				},
 			}, nil)
 
-		// Mock db.schema.nodeTypeProperties query
+		// Mock db.schema.nodeTypeProperties, db.schema.relTypeProperties, SHOW CONSTRAINTS and SHOW INDEXES queries,
+		// which run in parallel so are told apart by their text
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), gomock.Any(), nil).
-			Return([]*neo4j.Record{
-				{
-					Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
-					Values: []any{[]any{"Movie"}, "title", []any{"STRING"}},
-				},
-			}, nil)
-
-		// Mock db.schema.relTypeProperties query
-		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), gomock.Any(), nil).
-			Return([]*neo4j.Record{}, nil)
+			ExecuteReadQuery(gomock.Any(), gomock.Not(gomock.Eq("CALL db.schema.visualization()")), nil).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if strings.Contains(query, "nodeTypeProperties") {
+					return []*neo4j.Record{
+						{
+							Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
+							Values: []any{[]any{"Movie"}, "title", []any{"STRING"}},
+						},
+					}, nil
+				}
+				return []*neo4j.Record{}, nil
+			}).
+			Times(4)
 
 		deps := &tools.ToolDependencies{
 			DBService:        mockDB,
@@ -89,7 +93,7 @@ func TestGetSchemaHandler(t *testing.T) {
 	t.Run("database query failure", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			GetDatabaseName().
+			GetDatabaseName(gomock.Any()).
 			Return("neo4j").
 			AnyTimes()
 		mockDB.EXPECT().
@@ -151,7 +155,7 @@ func TestGetSchemaHandler(t *testing.T) {
 		analyticsService.EXPECT().EmitEvent(gomock.Any()).Times(1)
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			GetDatabaseName().
+			GetDatabaseName(gomock.Any()).
 			Return("neo4j").
 			AnyTimes()
 		// Mock schema visualization returning empty
@@ -201,7 +205,7 @@ func TestGetSchemaHandler(t *testing.T) {
 
 		// Mock GetDatabaseName for logging
 		mockDB.EXPECT().
-			GetDatabaseName().
+			GetDatabaseName(gomock.Any()).
 			Return("neo4j").
 			AnyTimes()
 
@@ -256,27 +260,34 @@ func TestGetSchemaHandler(t *testing.T) {
 				},
 			}, nil)
 
-		// Mock db.schema.nodeTypeProperties query
+		// Mock db.schema.nodeTypeProperties, db.schema.relTypeProperties, SHOW CONSTRAINTS and SHOW INDEXES queries,
+		// which run in parallel so are told apart by their text
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), gomock.Any(), nil).
-			Return([]*neo4j.Record{
-				{
-					Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
-					Values: []any{[]any{"Customer"}, "customerId", []any{"STRING"}},
-				},
-				{
-					Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
-					Values: []any{[]any{"Passport"}, "number", []any{"STRING"}},
-				},
-				{
-					Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
-					Values: []any{[]any{"Email"}, "address", []any{"STRING"}},
-				},
-			}, nil)
+			ExecuteReadQuery(gomock.Any(), gomock.Not(gomock.Eq("CALL db.schema.visualization()")), nil).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if strings.Contains(query, "nodeTypeProperties") {
+					return []*neo4j.Record{
+						{
+							Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
+							Values: []any{[]any{"Customer"}, "customerId", []any{"STRING"}},
+						},
+						{
+							Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
+							Values: []any{[]any{"Passport"}, "number", []any{"STRING"}},
+						},
+						{
+							Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
+							Values: []any{[]any{"Email"}, "address", []any{"STRING"}},
+						},
+					}, nil
+				}
+				return []*neo4j.Record{}, nil
+			}).
+			Times(4)
 
-		// Mock db.schema.relTypeProperties query
+		// Mock label and relationship count query
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), gomock.Any(), nil).
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return([]*neo4j.Record{}, nil)
 
 		deps := &tools.ToolDependencies{
@@ -326,6 +337,497 @@ func TestGetSchemaHandler(t *testing.T) {
 
 }
 
+func TestGetSchemaHandler_Cache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-schema").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	visualizationRecords := []*neo4j.Record{
+		{
+			Keys: []string{"nodes", "relationships"},
+			Values: []any{
+				[]any{dbtype.Node{Id: 1, Labels: []string{"Customer"}, Props: map[string]any{"name": "Customer"}, ElementId: "4:1"}},
+				[]any{},
+			},
+		},
+	}
+	nodePropsRecords := []*neo4j.Record{
+		{
+			Keys:   []string{"nodeLabels", "propertyName", "propertyTypes"},
+			Values: []any{[]any{"Customer"}, "customerId", []any{"STRING"}},
+		},
+	}
+
+	// expectSchemaQueries expects the schema procedures, SHOW commands and count query to run the given number of times
+	expectSchemaQueries := func(mockDB *db.MockService, times int) {
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Eq("CALL db.schema.visualization()"), nil).
+			Return(visualizationRecords, nil).
+			Times(times)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Not(gomock.Eq("CALL db.schema.visualization()")), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if strings.Contains(query, "nodeTypeProperties") {
+					return nodePropsRecords, nil
+				}
+				return []*neo4j.Record{}, nil
+			}).
+			Times(5 * times)
+	}
+
+	t.Run("second call is served from the cache", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		expectSchemaQueries(mockDB, 1)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			SchemaCache:      tools.NewSchemaCache(time.Minute),
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		for i := 0; i < 2; i++ {
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result == nil || result.IsError {
+				t.Fatal("Expected success result")
+			}
+			if !strings.Contains(result.Content[0].(mcp.TextContent).Text, "Customer") {
+				t.Errorf("Expected schema to contain Customer, got: %s", result.Content[0].(mcp.TextContent).Text)
+			}
+		}
+	})
+
+	t.Run("refresh reloads the schema", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		expectSchemaQueries(mockDB, 2)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			SchemaCache:      tools.NewSchemaCache(time.Minute),
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		requests := []mcp.CallToolRequest{
+			{},
+			{Params: mcp.CallToolParams{Arguments: map[string]any{"refresh": true}}},
+		}
+		for _, request := range requests {
+			result, err := handler(context.Background(), request)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result == nil || result.IsError {
+				t.Fatal("Expected success result")
+			}
+		}
+	})
+
+	t.Run("empty database is not cached", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Eq("CALL db.schema.visualization()"), nil).
+			Return([]*neo4j.Record{}, nil).
+			Times(2)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Eq("MATCH (n) RETURN count(n) as nodeCount"), nil).
+			Return([]*neo4j.Record{{Keys: []string{"nodeCount"}, Values: []any{int64(0)}}}, nil).
+			Times(2)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			SchemaCache:      tools.NewSchemaCache(time.Minute),
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		for i := 0; i < 2; i++ {
+			result, err := handler(context.Background(), mcp.CallToolRequest{})
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if result == nil || result.IsError {
+				t.Fatal("Expected success result")
+			}
+		}
+	})
+}
+
+// schemaQueries answers every schema query with a Customer-Account schema, returning showErr for the SHOW commands when set
+func schemaQueries(showErr error) func(context.Context, string, map[string]any) ([]*neo4j.Record, error) {
+	return func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+		switch {
+		case strings.Contains(query, "AS count"):
+			return []*neo4j.Record{
+				{Keys: []string{"key", "type", "count"}, Values: []any{"Customer", "node", int64(120)}},
+				{Keys: []string{"key", "type", "count"}, Values: []any{"Account", "node", int64(150)}},
+				{Keys: []string{"key", "type", "count"}, Values: []any{"HAS_ACCOUNT", "relationship", int64(150)}},
+			}, nil
+		case strings.Contains(query, "db.schema.visualization"):
+			return []*neo4j.Record{
+				{
+					Keys: []string{"nodes", "relationships"},
+					Values: []any{
+						[]any{
+							dbtype.Node{Id: 1, Labels: []string{"Customer"}, Props: map[string]any{"name": "Customer"}, ElementId: "4:1"},
+							dbtype.Node{Id: 2, Labels: []string{"Account"}, Props: map[string]any{"name": "Account"}, ElementId: "4:2"},
+						},
+						[]any{
+							dbtype.Relationship{Id: 1, StartId: 1, EndId: 2, Type: "HAS_ACCOUNT", Props: map[string]any{"name": "HAS_ACCOUNT"}, ElementId: "5:1"},
+						},
+					},
+				},
+			}, nil
+		case strings.Contains(query, "SHOW CONSTRAINTS"):
+			if showErr != nil {
+				return nil, showErr
+			}
+			return []*neo4j.Record{
+				{
+					Keys:   []string{"name", "type", "entityType", "labelsOrTypes", "properties"},
+					Values: []any{"customer_id", "UNIQUENESS", "NODE", []any{"Customer"}, []any{"customerId"}},
+				},
+				{
+					Keys:   []string{"name", "type", "entityType", "labelsOrTypes", "properties"},
+					Values: []any{"has_account_since", "RELATIONSHIP_PROPERTY_EXISTENCE", "RELATIONSHIP", []any{"HAS_ACCOUNT"}, []any{"since"}},
+				},
+			}, nil
+		case strings.Contains(query, "MATCH (n:`Customer`)"):
+			return []*neo4j.Record{
+				{Keys: []string{"key", "values"}, Values: []any{"customerId", []any{"CUS-00123", "CUS-00456"}}},
+				{Keys: []string{"key", "values"}, Values: []any{"email", []any{"jane@example.com"}}},
+			}, nil
+		case strings.Contains(query, "MATCH (n:`Account`)"):
+			return nil, errors.New("sampling failed")
+		case strings.Contains(query, "SHOW INDEXES"):
+			if showErr != nil {
+				return nil, showErr
+			}
+			return []*neo4j.Record{
+				{
+					Keys:   []string{"name", "type", "entityType", "labelsOrTypes", "properties", "state"},
+					Values: []any{"account_number", "RANGE", "NODE", []any{"Account"}, []any{"accountNumber"}, "ONLINE"},
+				},
+				{
+					Keys:   []string{"name", "type", "entityType", "labelsOrTypes", "properties", "state"},
+					Values: []any{"customer_name", "TEXT", "NODE", []any{"Customer"}, []any{"name"}, "POPULATING"},
+				},
+			}, nil
+		}
+		return []*neo4j.Record{}, nil
+	}
+}
+
+func TestGetSchemaHandler_ConstraintsAndIndexes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-schema").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("constraints and indexes are listed per label and type", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(nil)).
+			Times(6)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		schema, err := cypher.LoadSchema(context.Background(), deps, false)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		for _, item := range schema {
+			switch item.Key {
+			case "Customer":
+				if len(item.Value.Constraints) != 1 || item.Value.Constraints[0].Type != "UNIQUENESS" {
+					t.Errorf("Expected uniqueness constraint on Customer, got: %+v", item.Value.Constraints)
+				}
+				if len(item.Value.Indexes) != 1 || item.Value.Indexes[0].State != "POPULATING" {
+					t.Errorf("Expected populating text index on Customer, got: %+v", item.Value.Indexes)
+				}
+			case "Account":
+				if len(item.Value.Indexes) != 1 || item.Value.Indexes[0].Properties[0] != "accountNumber" {
+					t.Errorf("Expected range index on Account, got: %+v", item.Value.Indexes)
+				}
+			case "HAS_ACCOUNT":
+				if len(item.Value.Constraints) != 1 || item.Value.Constraints[0].Properties[0] != "since" {
+					t.Errorf("Expected existence constraint on HAS_ACCOUNT, got: %+v", item.Value.Constraints)
+				}
+			}
+		}
+	})
+
+	t.Run("markdown includes constraints and indexes", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(nil)).
+			Times(6)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		output := result.Content[0].(mcp.TextContent).Text
+		for _, expected := range []string{
+			"  - UNIQUENESS on (customerId)",
+			"  - TEXT index on (name) [POPULATING]",
+			"  - RANGE index on (accountNumber)\n",
+			"  - RELATIONSHIP_PROPERTY_EXISTENCE on (since)",
+			"*Count:* 120 nodes",
+			"*Count:* 150 relationships",
+		} {
+			if !strings.Contains(output, expected) {
+				t.Errorf("Expected output to contain %q.\nOutput:\n%s", expected, output)
+			}
+		}
+	})
+
+	t.Run("failing SHOW commands do not fail the schema", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(errors.New("permission denied"))).
+			Times(6)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		if strings.Contains(result.Content[0].(mcp.TextContent).Text, "*Constraints:*") {
+			t.Error("Expected no constraints section when SHOW CONSTRAINTS fails")
+		}
+	})
+}
+
+func TestGetSchemaHandler_Format(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-schema").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	callWithFormat := func(t *testing.T, format string) string {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(nil)).
+			Times(6)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"format": format}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		return result.Content[0].(mcp.TextContent).Text
+	}
+
+	t.Run("json returns raw schema items", func(t *testing.T) {
+		output := callWithFormat(t, "json")
+
+		var items []cypher.SchemaItem
+		if err := json.Unmarshal([]byte(output), &items); err != nil {
+			t.Fatalf("Expected schema items JSON, got: %s", output)
+		}
+		if len(items) != 3 {
+			t.Errorf("Expected 3 schema items, got: %d", len(items))
+		}
+		if strings.Contains(output, "Fraud Detection") {
+			t.Error("Expected no fraud detection context in JSON output")
+		}
+	})
+
+	t.Run("compact returns one line per item", func(t *testing.T) {
+		output := callWithFormat(t, "compact")
+
+		expected := strings.Join([]string{
+			"(:Account) | indexed: accountNumber | count: 150",
+			"(:Customer) | unique: customerId | indexed: name | count: 120",
+			"(:Customer)-[:HAS_ACCOUNT]->(:Account)",
+			"[:HAS_ACCOUNT] | count: 150",
+		}, "\n")
+		if output != expected {
+			t.Errorf("Expected compact output:\n%s\ngot:\n%s", expected, output)
+		}
+	})
+
+	t.Run("json includes masked samples", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(nil)).
+			Times(6)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{"sampleSize": int32(50), "samplesPerProperty": 3}).
+			DoAndReturn(schemaQueries(nil)).
+			Times(2)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 50)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"format": "json", "includeSamples": true}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		var items []cypher.SchemaItem
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &items); err != nil {
+			t.Fatalf("Expected schema items JSON, got: %v", err)
+		}
+		for _, item := range items {
+			switch item.Key {
+			case "Customer":
+				if strings.Join(item.Value.Samples["customerId"], ",") != "CUS-00123,CUS-00456" {
+					t.Errorf("Expected unmasked identifier samples, got: %v", item.Value.Samples["customerId"])
+				}
+				if strings.Join(item.Value.Samples["email"], ",") != "xxxx@xxxxxxx.xxx" {
+					t.Errorf("Expected masked email samples, got: %v", item.Value.Samples["email"])
+				}
+			case "Account":
+				if item.Value.Samples != nil {
+					t.Errorf("Expected no samples for a label whose sampling failed, got: %v", item.Value.Samples)
+				}
+			}
+		}
+	})
+
+	t.Run("cached samples are not served to other credentials", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		// Both calls read the schema and the samples: the second caller has the same username but a wrong password
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{"sampleSize": int32(50), "samplesPerProperty": 3}).
+			DoAndReturn(schemaQueries(nil)).
+			Times(4)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(nil)).
+			Times(12)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			SchemaCache:      tools.NewSchemaCache(time.Minute),
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 50)
+		for _, password := range []string{"secret", "wrong-password"} {
+			ctx := auth.WithIdentity(auth.WithBasicAuth(context.Background(), "alice", password), "alice")
+			result, err := handler(ctx, mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Arguments: map[string]any{"format": "json", "includeSamples": true}},
+			})
+			if err != nil || result == nil || result.IsError {
+				t.Fatalf("Expected success result, got: %v", err)
+			}
+		}
+	})
+
+	t.Run("introspects the requested database", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		fraudDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ForDatabase("fraud").Return(fraudDB)
+		fraudDB.EXPECT().GetDatabaseName(gomock.Any()).Return("fraud").AnyTimes()
+		fraudDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(schemaQueries(nil)).
+			Times(6)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			SchemaCache:      tools.NewSchemaCache(time.Minute),
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"database": "fraud", "format": "compact"}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		if _, ok := deps.SchemaCache.Get(context.Background(), "fraud"); !ok {
+			t.Error("Expected schema to be cached under the requested database")
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			DBService:        db.NewMockService(ctrl),
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.GetSchemaHandler(deps, 100)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"format": "yaml"}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid format")
+		}
+	})
+}
+
 // TestGetSchemaProcessing tests are commented out because they test the old APOC-based
 // processCypherSchema function which is no longer used by the handler (replaced with native Neo4j procedures).
 // The processCypherSchema function is kept for potential backward compatibility but is not actively used.
@@ -0,0 +1,164 @@
+package cypher
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	// samplesPerProperty is the number of distinct example values returned per property
+	samplesPerProperty = 3
+	// maxSampleLength truncates long example values such as free text
+	maxSampleLength = 40
+	// samplesCachePrefix keys sampled values in the schema cache next to the schema itself
+	samplesCachePrefix = "samples:"
+)
+
+// piiNamePatterns mark properties (by name) or labels whose values are masked in samples
+var piiNamePatterns = []string{
+	"ssn", "social", "email", "phone", "mobile", "name", "address", "street", "postcode", "zip",
+	"birth", "dob", "passport", "license", "licence", "card", "iban", "account", "tax",
+}
+
+// sampleValuesQuery collects a few distinct values per property from the first $sampleSize nodes of a label
+const sampleValuesQuery = `
+		MATCH (n:%s)
+		WITH n LIMIT $sampleSize
+		UNWIND keys(n) AS key
+		WITH key, n[key] AS value
+		WHERE value IS NOT NULL
+		WITH key, collect(DISTINCT toString(value))[..$samplesPerProperty] AS values
+		RETURN key, values
+	`
+
+// loadSamples returns example values per label and property, served from deps.SchemaCache while fresh.
+// The samples are real property values, so like the schema they are only served to the caller that read them.
+// Labels whose sampling query fails are skipped so a single label cannot fail the whole schema.
+func loadSamples(ctx context.Context, deps *tools.ToolDependencies, schema []SchemaItem, sampleSize int32, refresh bool) map[string]map[string][]string {
+	cacheKey := samplesCachePrefix + deps.DBService.GetDatabaseName(ctx)
+	if !refresh {
+		if cached, ok := deps.SchemaCache.Get(ctx, cacheKey); ok {
+			if samples, ok := cached.(map[string]map[string][]string); ok {
+				return samples
+			}
+		}
+	}
+
+	// Labels are sampled in parallel sessions, each task adding its label under mu
+	var mu sync.Mutex
+	samples := make(map[string]map[string][]string)
+	tasks := make([]func(context.Context) error, 0, len(schema))
+	for _, item := range schema {
+		if item.Value.Type != "node" {
+			continue
+		}
+
+		label := item.Key
+		tasks = append(tasks, func(ctx context.Context) error {
+			query := fmt.Sprintf(sampleValuesQuery, quoteIdentifier(label))
+			records, err := deps.DBService.ExecuteReadQuery(ctx, query, map[string]any{
+				"sampleSize":         sampleSize,
+				"samplesPerProperty": samplesPerProperty,
+			})
+			if err != nil {
+				slog.Warn("failed to sample property values, skipping label", "label", label, "error", err)
+				return nil
+			}
+
+			labelSamples := sampleRecords(records, isPIIName(label))
+			if len(labelSamples) > 0 {
+				mu.Lock()
+				samples[label] = labelSamples
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	tools.RunParallel(ctx, tools.MaxParallelQueries, tasks...)
+
+	deps.SchemaCache.Set(ctx, cacheKey, samples)
+	return samples
+}
+
+// sampleRecords returns the example values per property of a label, masking PII.
+// maskLabel masks every value, for labels whose name marks them as PII.
+func sampleRecords(records []*neo4j.Record, maskLabel bool) map[string][]string {
+	samples := make(map[string][]string)
+	for _, record := range records {
+		keyRaw, _ := record.Get("key")
+		valuesRaw, _ := record.Get("values")
+		key, ok := keyRaw.(string)
+		if !ok {
+			continue
+		}
+
+		values := toStringSlice(valuesRaw)
+		for i, value := range values {
+			if maskLabel || isPIIName(key) || strings.Contains(value, "@") {
+				value = maskValue(value)
+			}
+			values[i] = truncateSample(value)
+		}
+		samples[key] = values
+	}
+	return samples
+}
+
+// withSamples returns a copy of the schema with example values attached, leaving the cached schema untouched
+func withSamples(schema []SchemaItem, samples map[string]map[string][]string) []SchemaItem {
+	result := make([]SchemaItem, len(schema))
+	copy(result, schema)
+	for i := range result {
+		if result[i].Value.Type == "node" {
+			result[i].Value.Samples = samples[result[i].Key]
+		}
+	}
+	return result
+}
+
+// isPIIName reports whether a label or property name suggests personal data
+func isPIIName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, pattern := range piiNamePatterns {
+		if strings.Contains(lower, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskValue hides a value while keeping its shape, so "CUS-00123" becomes "XXX-99999"
+// and "jane@example.com" becomes "xxxx@xxxxxxx.xxx"
+func maskValue(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsDigit(r):
+			return '9'
+		case unicode.IsUpper(r):
+			return 'X'
+		case unicode.IsLetter(r):
+			return 'x'
+		}
+		return r
+	}, value)
+}
+
+func truncateSample(value string) string {
+	runes := []rune(value)
+	if len(runes) <= maxSampleLength {
+		return value
+	}
+	return string(runes[:maxSampleLength]) + "..."
+}
+
+// quoteIdentifier escapes a label for use in a Cypher pattern
+func quoteIdentifier(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
@@ -4,6 +4,13 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+type GetSchemaInput struct {
+	Refresh        bool   `json:"refresh,omitempty" jsonschema:"default=false,description=Reload the schema from the database instead of returning the cached copy. Use after the data model has changed."`
+	Format         string `json:"format,omitempty" jsonschema:"default=markdown,enum=markdown,enum=json,enum=compact,description=Output format: markdown (documentation with fraud detection context), json (raw schema items for programmatic clients) or compact (one line per label, pattern and relationship type)"`
+	IncludeSamples bool   `json:"includeSamples,omitempty" jsonschema:"default=false,description=Add a few example values per node property so the format of identifiers and dates is visible. Values of personal data properties are masked (digits become 9, letters become X or x)."`
+	Database       string `json:"database,omitempty" jsonschema:"description=Optional: name of the database to introspect (Neo4j Enterprise/Aura with multiple databases). Defaults to the configured database."`
+}
+
 func GetSchemaSpec() mcp.Tool {
 	return mcp.NewTool("get-schema",
 		mcp.WithDescription(`
@@ -12,11 +19,22 @@ func GetSchemaSpec() mcp.Tool {
 		Returns the structure of your Neo4j database including:
 		- Node labels and their properties with data types
 		- Relationship types and their directions
+		- Uniqueness, key and existence constraints, and the indexes covering each label and relationship type
+		- Node counts per label and relationship counts per type, to pick selective anchors and avoid exploding traversals
 		- Fraud detection context explaining the purpose of this database
 
 		This tool provides complete schema information with business context in one call.
 
+		Set includeSamples to see example values per property (e.g. whether IDs look like "CUS-00123" or are numeric). Personal data is masked.
+
+		Business descriptions stored with store-enriched-schema are included with the labels, relationship types and properties they describe.
+
+		Use format "json" for the raw schema items or "compact" for a token-efficient summary; both omit the fraud detection context.
+
+		The schema is cached for a configurable period (NEO4J_SCHEMA_CACHE_TTL). Set refresh to true to reload it after the data model has changed.
+
 		If the database contains no data, no schema information is returned.`),
+		mcp.WithInputSchema[GetSchemaInput](),
 		mcp.WithTitleAnnotation("Get Neo4j Schema"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithIdempotentHintAnnotation(true),
@@ -0,0 +1,55 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// Tabular result formats accepted by read-cypher and write-cypher, next to formatJSON
+const (
+	formatCSV = "csv"
+	formatTSV = "tsv"
+)
+
+// isValidFormat reports whether format is a supported read-cypher or write-cypher result format; empty means json
+func isValidFormat(format string) bool {
+	switch format {
+	case "", formatJSON, formatCSV, formatTSV:
+		return true
+	}
+	return false
+}
+
+// isTabularFormat reports whether format returns the rows as delimited text instead of JSON
+func isTabularFormat(format string) bool {
+	return format == formatCSV || format == formatTSV
+}
+
+// formatTable converts records to CSV or TSV with a header row
+func formatTable(ctx context.Context, deps *tools.ToolDependencies, format string, records []*neo4j.Record) (string, error) {
+	delimiter := ','
+	if format == formatTSV {
+		delimiter = '\t'
+	}
+	return deps.DBService.Neo4jRecordsToCSV(ctx, records, delimiter)
+}
+
+// tableResult returns the table as the first text content, followed by the metadata as JSON when there is any
+func tableResult(table string, metadata any) (*mcp.CallToolResult, error) {
+	result := mcp.NewToolResultText(table)
+	if metadata == nil {
+		return result, nil
+	}
+
+	encoded, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to format result metadata: %w", err)
+	}
+	result.Content = append(result.Content, mcp.NewTextContent(string(encoded)))
+	return result, nil
+}
@@ -0,0 +1,202 @@
+package cypher
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Kinds of value a parameter must hold, inferred from the keyword that precedes it
+const (
+	paramKindAny     = ""
+	paramKindList    = "list"
+	paramKindInteger = "integer"
+)
+
+// paramKindKeywords maps the keywords that constrain the parameter following them to the kind they require
+var paramKindKeywords = map[string]string{
+	"IN":     paramKindList,
+	"UNWIND": paramKindList,
+	"LIMIT":  paramKindInteger,
+	"SKIP":   paramKindInteger,
+}
+
+// queryParam is a $parameter referenced by a query
+type queryParam struct {
+	kind    string
+	keyword string // Keyword that determined the kind, if any
+}
+
+// validateParams checks the parameters a query references against the provided ones before it is sent to the database.
+// It reports missing parameters and values that cannot work where they are used, such as a string after LIMIT.
+func validateParams(query string, params map[string]any) error {
+	used := findQueryParams(query)
+
+	var missing []string
+	for name := range used {
+		if _, ok := params[name]; !ok {
+			missing = append(missing, "$"+name)
+		}
+	}
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("query uses %s but params does not set %s; add the missing values to params or replace them with literals",
+			strings.Join(missing, ", "), pluralize(len(missing), "it", "them"))
+	}
+
+	names := make([]string, 0, len(used))
+	for name := range used {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		param := used[name]
+		value := params[name]
+		switch param.kind {
+		case paramKindList:
+			if value == nil || (reflect.TypeOf(value).Kind() != reflect.Slice && reflect.TypeOf(value).Kind() != reflect.Array) {
+				return fmt.Errorf("$%s follows %s and must be a list, got %s; pass a JSON array such as [%s]", name, param.keyword, describeValue(value), exampleItem(value))
+			}
+		case paramKindInteger:
+			if !isInteger(value) {
+				return fmt.Errorf("$%s follows %s and must be an integer, got %s; pass a number without a decimal point", name, param.keyword, describeValue(value))
+			}
+		}
+	}
+	return nil
+}
+
+// findQueryParams returns the parameters referenced by query, ignoring string literals, comments and quoted names
+func findQueryParams(query string) map[string]queryParam {
+	params := make(map[string]queryParam)
+	runes := []rune(query)
+	var code strings.Builder // Query text outside literals and comments, to find the preceding keyword
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\'' || r == '"' || r == '`':
+			i = skipQuoted(runes, i)
+			code.WriteRune(' ')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '/':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+			code.WriteRune(' ')
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i += 2
+			for i < len(runes) && (runes[i] != '*' || i+1 >= len(runes) || runes[i+1] != '/') {
+				i++
+			}
+			i++ // Closing slash
+			code.WriteRune(' ')
+		case r == '$':
+			name, end := readParamName(runes, i+1)
+			if name == "" {
+				code.WriteRune(r)
+				continue
+			}
+			param := queryParam{kind: paramKindAny}
+			if keyword := lastWord(code.String()); paramKindKeywords[keyword] != "" {
+				param = queryParam{kind: paramKindKeywords[keyword], keyword: keyword}
+			}
+			if existing, ok := params[name]; !ok || existing.kind == paramKindAny {
+				params[name] = param
+			}
+			i = end - 1
+			code.WriteString(" $param ")
+		default:
+			code.WriteRune(r)
+		}
+	}
+	return params
+}
+
+// skipQuoted returns the index of the quote closing the literal or quoted name opened at start
+func skipQuoted(runes []rune, start int) int {
+	quote := runes[start]
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] == '\\' && quote != '`' {
+			i++
+			continue
+		}
+		if runes[i] == quote {
+			return i
+		}
+	}
+	return len(runes)
+}
+
+// readParamName reads the parameter name starting at start, either plain or quoted with backticks
+func readParamName(runes []rune, start int) (string, int) {
+	if start < len(runes) && runes[start] == '`' {
+		end := skipQuoted(runes, start)
+		if end >= len(runes) {
+			return "", start
+		}
+		return string(runes[start+1 : end]), end + 1
+	}
+
+	end := start
+	for end < len(runes) && (unicode.IsLetter(runes[end]) || unicode.IsDigit(runes[end]) || runes[end] == '_') {
+		end++
+	}
+	return string(runes[start:end]), end
+}
+
+// lastWord returns the last word of text in upper case
+func lastWord(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToUpper(fields[len(fields)-1])
+}
+
+// isInteger reports whether value is an integer; Neo4j rejects floats such as 10.0 for LIMIT and SKIP
+func isInteger(value any) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return true
+	}
+	return false
+}
+
+// describeValue names the JSON type of value for error messages
+func describeValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("the string %q", value)
+	case bool:
+		return "a boolean"
+	case int, int8, int16, int32, int64, uint8, uint16, uint32, float32, float64:
+		return fmt.Sprintf("the number %v", value)
+	case map[string]any:
+		return "an object"
+	}
+	return fmt.Sprintf("a %T", value)
+}
+
+// exampleItem suggests the list a single value was probably meant to be
+func exampleItem(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return fmt.Sprintf("%q", v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// pluralize returns one when n is 1 and many otherwise
+func pluralize(n int, one string, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,86 @@
+package cypher
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		params  map[string]any
+		wantErr string
+	}{
+		{
+			name:   "all parameters provided",
+			query:  "MATCH (c:Customer {id: $id})-[:HAS_ACCOUNT]->(a) WHERE a.id IN $accounts RETURN a LIMIT $limit",
+			params: map[string]any{"id": "C1", "accounts": []any{"A1"}, "limit": int64(10)},
+		},
+		{
+			name:    "missing parameters are listed",
+			query:   "MATCH (c:Customer {id: $id}) WHERE c.riskScore > $minScore RETURN c",
+			params:  map[string]any{"id": "C1"},
+			wantErr: "query uses $minScore but params does not set it",
+		},
+		{
+			name:    "nil params",
+			query:   "MATCH (c:Customer {id: $id}) RETURN c",
+			wantErr: "query uses $id",
+		},
+		{
+			name:   "dollar signs in literals and comments are ignored",
+			query:  "MATCH (t:Transaction) // costs in $USD\nWHERE t.currency = '$' /* $amount */ RETURN t.`$label`",
+			params: nil,
+		},
+		{
+			name:   "backtick quoted parameter name",
+			query:  "MATCH (c:Customer {id: $`customer id`}) RETURN c",
+			params: map[string]any{"customer id": "C1"},
+		},
+		{
+			name:    "string after IN",
+			query:   "MATCH (c:Customer) WHERE c.id in $ids RETURN c",
+			params:  map[string]any{"ids": "C1"},
+			wantErr: `$ids follows IN and must be a list, got the string "C1"; pass a JSON array such as ["C1"]`,
+		},
+		{
+			name:    "object after UNWIND",
+			query:   "UNWIND $rows AS row CREATE (:Customer {id: row.id})",
+			params:  map[string]any{"rows": map[string]any{"id": "C1"}},
+			wantErr: "$rows follows UNWIND and must be a list",
+		},
+		{
+			name:    "float after LIMIT",
+			query:   "MATCH (c:Customer) RETURN c LIMIT $limit",
+			params:  map[string]any{"limit": 10.0},
+			wantErr: "$limit follows LIMIT and must be an integer",
+		},
+		{
+			name:    "string after SKIP",
+			query:   "MATCH (c:Customer) RETURN c SKIP $skip LIMIT 10",
+			params:  map[string]any{"skip": "20"},
+			wantErr: "$skip follows SKIP and must be an integer",
+		},
+		{
+			name:   "extra parameters are allowed",
+			query:  "RETURN 1",
+			params: map[string]any{"unused": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateParams(tt.query, tt.params)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateParams() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateParams() error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package query_builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// aggregateFunctions lists the supported Aggregation functions
+var aggregateFunctions = map[string]bool{
+	"count": true,
+	"sum":   true,
+	"avg":   true,
+	"min":   true,
+	"max":   true,
+}
+
+// AggregationBuilder helps construct map expressions of aggregate functions for RETURN clauses.
+type AggregationBuilder struct {
+	items []string
+}
+
+// NewAggregationBuilder creates a new aggregation builder.
+func NewAggregationBuilder() *AggregationBuilder {
+	return &AggregationBuilder{
+		items: make([]string, 0),
+	}
+}
+
+// Add adds an aggregate of a variable's property to the map.
+// count without a property counts the non-null values of the variable itself.
+//
+// Example:
+//
+//	builder.Add("totalAmount", "sum", "t", "amount")
+//	// Generates: totalAmount: sum(t.amount)
+//	builder.Add("count", "count", "t", "")
+//	// Generates: count: count(t)
+func (a *AggregationBuilder) Add(alias string, function string, sourceVar string, property string) {
+	expression := sourceVar
+	if property != "" {
+		expression = sourceVar + "." + EscapeIdentifier(property)
+	}
+	a.items = append(a.items, fmt.Sprintf("%s: %s(%s)", EscapeIdentifier(alias), strings.ToLower(function), expression))
+}
+
+// Build returns the aggregates as a map expression.
+//
+// Example: {count: count(t), totalAmount: sum(t.amount)}
+func (a *AggregationBuilder) Build() string {
+	if len(a.items) == 0 {
+		return "{}"
+	}
+	return "{" + strings.Join(a.items, ", ") + "}"
+}
+
+// BuildAggregationMap constructs the aggregation map expression of an attribute mapping's Aggregations.
+//
+// Example:
+//
+//	expr := BuildAggregationMap("attr0", AttributeMapping{
+//	    Aggregations: []Aggregation{
+//	        {Function: "count", Alias: "count"},
+//	        {Function: "max", Property: "date", Alias: "lastDate"},
+//	    },
+//	})
+//	// Returns: {count: count(attr0), lastDate: max(attr0.date)}
+func BuildAggregationMap(varName string, mapping AttributeMapping) string {
+	builder := NewAggregationBuilder()
+	for _, aggregation := range mapping.Aggregations {
+		builder.Add(aggregation.Alias, aggregation.Function, varName, aggregation.Property)
+	}
+	return builder.Build()
+}
+
+// ValidateAttributeAggregations checks the Aggregations of every attribute mapping.
+func ValidateAttributeAggregations(mappings []AttributeMapping) string {
+	for i, mapping := range mappings {
+		aliases := make(map[string]bool)
+		for j, aggregation := range mapping.Aggregations {
+			field := fmt.Sprintf("attributeMappings[%d].aggregations[%d]", i, j)
+			function := strings.ToLower(aggregation.Function)
+			if !aggregateFunctions[function] {
+				return fmt.Sprintf("%s has invalid function '%s', must be one of: count, sum, avg, min, max", field, aggregation.Function)
+			}
+			if aggregation.Property == "" && function != "count" {
+				return fmt.Sprintf("%s.property is required for %s", field, function)
+			}
+			if aggregation.Property != "" && !identifierPattern.MatchString(aggregation.Property) {
+				return fmt.Sprintf("%s.property '%s' must start with a letter or underscore and contain only letters, digits and underscores", field, aggregation.Property)
+			}
+			if !identifierPattern.MatchString(aggregation.Alias) {
+				return fmt.Sprintf("%s.alias '%s' must start with a letter or underscore and contain only letters, digits and underscores", field, aggregation.Alias)
+			}
+			if aliases[aggregation.Alias] {
+				return fmt.Sprintf("%s.alias '%s' is used by another aggregation of the mapping", field, aggregation.Alias)
+			}
+			aliases[aggregation.Alias] = true
+		}
+	}
+	return ""
+}
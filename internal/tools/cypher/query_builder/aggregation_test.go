@@ -0,0 +1,75 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregationBuilder(t *testing.T) {
+	builder := NewAggregationBuilder()
+	builder.Add("count", "count", "t", "")
+	builder.Add("averageAmount", "AVG", "t", "amount")
+	builder.Add("firstDate", "min", "t", "date")
+
+	assert.Equal(t, "{count: count(t), averageAmount: avg(t.amount), firstDate: min(t.date)}", builder.Build())
+}
+
+func TestAggregationBuilder_Empty(t *testing.T) {
+	assert.Equal(t, "{}", NewAggregationBuilder().Build())
+}
+
+func TestBuildAggregationMap(t *testing.T) {
+	mapping := AttributeMapping{
+		Aggregations: []Aggregation{
+			{Function: "count", Alias: "accounts"},
+			{Function: "sum", Property: "balance", Alias: "totalBalance"},
+		},
+	}
+
+	assert.Equal(t, "{accounts: count(attr0), totalBalance: sum(attr0.balance)}", BuildAggregationMap("attr0", mapping))
+}
+
+func TestValidateAttributeAggregations(t *testing.T) {
+	tests := []struct {
+		name         string
+		aggregations []Aggregation
+		expected     string
+	}{
+		{
+			name:         "valid aggregations",
+			aggregations: []Aggregation{{Function: "count", Alias: "count"}, {Function: "Max", Property: "date", Alias: "lastDate"}},
+		},
+		{
+			name:         "unsupported function",
+			aggregations: []Aggregation{{Function: "stdev", Property: "amount", Alias: "spread"}},
+			expected:     "attributeMappings[0].aggregations[0] has invalid function 'stdev'",
+		},
+		{
+			name:         "missing property",
+			aggregations: []Aggregation{{Function: "sum", Alias: "total"}},
+			expected:     "attributeMappings[0].aggregations[0].property is required for sum",
+		},
+		{
+			name:         "invalid alias",
+			aggregations: []Aggregation{{Function: "count", Alias: "n}) RETURN 1 //"}},
+			expected:     "attributeMappings[0].aggregations[0].alias",
+		},
+		{
+			name:         "duplicate alias",
+			aggregations: []Aggregation{{Function: "count", Alias: "n"}, {Function: "sum", Property: "amount", Alias: "n"}},
+			expected:     "attributeMappings[0].aggregations[1].alias 'n' is used by another aggregation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errMessage := ValidateAttributeAggregations([]AttributeMapping{{Aggregations: tt.aggregations}})
+			if tt.expected == "" {
+				assert.Empty(t, errMessage)
+			} else {
+				assert.Contains(t, errMessage, tt.expected)
+			}
+		})
+	}
+}
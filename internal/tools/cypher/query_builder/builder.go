@@ -2,14 +2,17 @@ package query_builder
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 // OptionalMatchBuilder helps construct OPTIONAL MATCH clauses dynamically.
 // This allows building schema-aware queries without hardcoding relationship names or node labels.
 type OptionalMatchBuilder struct {
-	clauses    []string
-	varCounter int
+	clauses              []string
+	varCounter           int
+	filters              *FilterBuilder
+	collectionSubqueries bool
 }
 
 // NewOptionalMatchBuilder creates a new builder instance.
@@ -17,6 +20,7 @@ func NewOptionalMatchBuilder() *OptionalMatchBuilder {
 	return &OptionalMatchBuilder{
 		clauses:    make([]string, 0),
 		varCounter: 0,
+		filters:    NewFilterBuilder(),
 	}
 }
 
@@ -31,6 +35,11 @@ func NewOptionalMatchBuilder() *OptionalMatchBuilder {
 //	})
 //	// Generates: OPTIONAL MATCH (c)-[:HAS_EMAIL]->(attr0:Email)
 //	// Returns: "attr0"
+//
+// Set mapping.Direction to "in" or "both" to match relationships pointing at the source node.
+// When mapping.IncludeRelationshipProperties is set, the relationship is bound to RelationshipVar(varName)
+// so BuildPropertyMap can project its properties.
+// mapping.Filters are added as a WHERE clause of the match, with their values bound to parameters returned by Params.
 func (b *OptionalMatchBuilder) AddAttributeMatch(
 	sourceVar string,
 	mapping AttributeMapping,
@@ -38,16 +47,140 @@ func (b *OptionalMatchBuilder) AddAttributeMatch(
 	varName := fmt.Sprintf("attr%d", b.varCounter)
 	b.varCounter++
 
-	clause := fmt.Sprintf("OPTIONAL MATCH (%s)-[:%s]->(%s:%s)",
-		sourceVar,
-		mapping.RelationshipType,
-		varName,
-		mapping.TargetLabel)
-
-	b.clauses = append(b.clauses, clause)
+	b.clauses = append(b.clauses, b.attributeMatchClause(sourceVar, varName, mapping))
 	return varName
 }
 
+// attributeMatchClause returns the OPTIONAL MATCH clause of an attribute mapping bound to varName
+func (b *OptionalMatchBuilder) attributeMatchClause(sourceVar string, varName string, mapping AttributeMapping) string {
+	relVar := ""
+	if len(mapping.IncludeRelationshipProperties) > 0 {
+		relVar = RelationshipVar(varName)
+	}
+
+	var clause string
+	switch mapping.Direction {
+	case "in":
+		clause = fmt.Sprintf("OPTIONAL MATCH (%s)<-[%s:%s]-(%s:%s)",
+			sourceVar,
+			relVar,
+			EscapeIdentifier(mapping.RelationshipType),
+			varName,
+			EscapeLabelExpression(mapping.TargetLabel))
+	case "both":
+		clause = fmt.Sprintf("OPTIONAL MATCH (%s)-[%s:%s]-(%s:%s)",
+			sourceVar,
+			relVar,
+			EscapeIdentifier(mapping.RelationshipType),
+			varName,
+			EscapeLabelExpression(mapping.TargetLabel))
+	default:
+		// Default to "out"
+		clause = fmt.Sprintf("OPTIONAL MATCH (%s)-[%s:%s]->(%s:%s)",
+			sourceVar,
+			relVar,
+			EscapeIdentifier(mapping.RelationshipType),
+			varName,
+			EscapeLabelExpression(mapping.TargetLabel))
+	}
+	if where := b.filters.BuildWhere(varName, mapping.Filters); where != "" {
+		clause += " " + where
+	}
+	return clause
+}
+
+// SetCollectionSubqueries switches the builder to collection subquery mode, where UsesCollectionSubquery
+// reports true for every mapping. Each attribute is then matched and collected in its own CALL subquery, so the
+// matches of one attribute never multiply the rows of another: a profile of n attributes with m matches each
+// reads n*m rows instead of m^n.
+func (b *OptionalMatchBuilder) SetCollectionSubqueries(enabled bool) {
+	b.collectionSubqueries = enabled
+}
+
+// UsesCollectionSubquery reports whether a mapping must be collected with BuildCollectionSubquery:
+// always in collection subquery mode, otherwise when NeedsCollectionSubquery.
+func (b *OptionalMatchBuilder) UsesCollectionSubquery(mapping AttributeMapping) bool {
+	return b.collectionSubqueries || NeedsCollectionSubquery(mapping)
+}
+
+// NeedsCollectionSubquery reports whether an attribute mapping sets OrderBy, Limit or Aggregations,
+// in which case it must be collected with BuildCollectionSubquery rather than AddAttributeMatch:
+// its rows must not be multiplied by the matches of other attributes.
+func NeedsCollectionSubquery(mapping AttributeMapping) bool {
+	return mapping.OrderBy != "" || mapping.Limit > 0 || len(mapping.Aggregations) > 0
+}
+
+// BuildCollectionSubquery returns a CALL subquery collecting an attribute mapping into alias, applying its
+// OrderBy and Limit, or summarizing it when it sets Aggregations. The match runs inside the subquery, so it is
+// not added to the clauses returned by Build, and the subquery must follow the WITH clause aggregating the other attributes.
+//
+// Example:
+//
+//	subquery := builder.BuildCollectionSubquery("c", AttributeMapping{
+//	    RelationshipType: "PERFORMS",
+//	    TargetLabel: "Transaction",
+//	    OrderBy: "date DESC",
+//	    Limit: 10,
+//	}, "activity_transactions")
+//	// Generates:
+//	// CALL {
+//	//   WITH c
+//	//   OPTIONAL MATCH (c)-[:PERFORMS]->(attr0:Transaction)
+//	//   WITH DISTINCT attr0
+//	//   ORDER BY attr0.date DESC
+//	//   LIMIT $attr0_limit
+//	//   RETURN collect(attr0{.*}) as activity_transactions
+//	// }
+func (b *OptionalMatchBuilder) BuildCollectionSubquery(sourceVar string, mapping AttributeMapping, alias string) string {
+	varName := fmt.Sprintf("attr%d", b.varCounter)
+	b.varCounter++
+
+	var subquery strings.Builder
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString(fmt.Sprintf("  WITH %s\n", sourceVar))
+	subquery.WriteString("  " + b.attributeMatchClause(sourceVar, varName, mapping) + "\n")
+	if len(mapping.IncludeRelationshipProperties) > 0 && len(mapping.Aggregations) == 0 {
+		subquery.WriteString(fmt.Sprintf("  WITH DISTINCT %s, %s\n", varName, RelationshipVar(varName)))
+	} else {
+		subquery.WriteString(fmt.Sprintf("  WITH DISTINCT %s\n", varName))
+	}
+	if property, descending, ok := parseOrderBy(mapping.OrderBy); ok {
+		subquery.WriteString(fmt.Sprintf("  ORDER BY %s.%s", varName, EscapeIdentifier(property)))
+		if descending {
+			subquery.WriteString(" DESC")
+		}
+		subquery.WriteString("\n")
+	}
+	if mapping.Limit > 0 {
+		limitParam := varName + "_limit"
+		b.filters.params[limitParam] = mapping.Limit
+		subquery.WriteString(fmt.Sprintf("  LIMIT $%s\n", limitParam))
+	}
+	if len(mapping.Aggregations) > 0 {
+		subquery.WriteString(fmt.Sprintf("  RETURN %s as %s\n", BuildAggregationMap(varName, mapping), alias))
+	} else {
+		// Rows are already distinct, and collect keeps their order
+		subquery.WriteString(fmt.Sprintf("  RETURN collect(%s) as %s\n", BuildPropertyMap(varName, mapping), alias))
+	}
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
+
+// Params returns the query parameters bound by the filters and limits of the matches added so far.
+func (b *OptionalMatchBuilder) Params() map[string]any {
+	return b.filters.Params()
+}
+
+// RelationshipVar returns the variable AddAttributeMatch binds the relationship of an attribute variable to.
+//
+// Example:
+//
+//	RelationshipVar("attr0") // attr0_rel
+func RelationshipVar(varName string) string {
+	return varName + "_rel"
+}
+
 // AddPathMatch adds an OPTIONAL MATCH clause for a path traversal.
 // Returns the generated variable name for the end node.
 //
@@ -90,25 +223,25 @@ func (b *OptionalMatchBuilder) AddPathMatch(
 	if path.Direction == "in" {
 		clause = fmt.Sprintf("OPTIONAL MATCH (%s)<-[:%s%s]-(%s:%s)",
 			sourceVar,
-			path.RelationshipType,
+			EscapeIdentifier(path.RelationshipType),
 			hopSpec,
 			varName,
-			path.TargetLabel)
+			EscapeLabelExpression(path.TargetLabel))
 	} else if path.Direction == "both" {
 		clause = fmt.Sprintf("OPTIONAL MATCH (%s)-[:%s%s]-(%s:%s)",
 			sourceVar,
-			path.RelationshipType,
+			EscapeIdentifier(path.RelationshipType),
 			hopSpec,
 			varName,
-			path.TargetLabel)
+			EscapeLabelExpression(path.TargetLabel))
 	} else {
 		// Default to "out"
 		clause = fmt.Sprintf("OPTIONAL MATCH (%s)-[:%s%s]->(%s:%s)",
 			sourceVar,
-			path.RelationshipType,
+			EscapeIdentifier(path.RelationshipType),
 			hopSpec,
 			varName,
-			path.TargetLabel)
+			EscapeLabelExpression(path.TargetLabel))
 	}
 
 	b.clauses = append(b.clauses, clause)
@@ -153,7 +286,7 @@ func NewCollectionBuilder() *CollectionBuilder {
 //	builder.AddProperty("email", "e", "address")
 //	// Generates: email: e.address
 func (c *CollectionBuilder) AddProperty(propName string, sourceVar string, sourceProp string) {
-	c.items = append(c.items, fmt.Sprintf("%s: %s.%s", propName, sourceVar, sourceProp))
+	c.items = append(c.items, fmt.Sprintf("%s: %s.%s", EscapeIdentifier(propName), sourceVar, EscapeIdentifier(sourceProp)))
 }
 
 // AddAllProperties adds all properties from a node using the properties() function.
@@ -163,7 +296,7 @@ func (c *CollectionBuilder) AddProperty(propName string, sourceVar string, sourc
 //	builder.AddAllProperties("email", "e")
 //	// Generates: email: properties(e)
 func (c *CollectionBuilder) AddAllProperties(key string, sourceVar string) {
-	c.items = append(c.items, fmt.Sprintf("%s: properties(%s)", key, sourceVar))
+	c.items = append(c.items, fmt.Sprintf("%s: properties(%s)", EscapeIdentifier(key), sourceVar))
 }
 
 // AddCustomExpression adds a custom key-value expression.
@@ -207,16 +340,32 @@ func GroupMappingsByCategory(mappings []AttributeMapping) map[string][]Attribute
 	categorized := make(map[string][]AttributeMapping)
 
 	for _, mapping := range mappings {
-		category := mapping.AttributeCategory
-		if category == "" {
-			category = "other_attributes"
-		}
+		category := mappingCategory(mapping)
 		categorized[category] = append(categorized[category], mapping)
 	}
 
 	return categorized
 }
 
+// SortedCategories returns the categories of grouped mappings in alphabetical order.
+// Iterate over them instead of the map so generated queries are the same on every run.
+func SortedCategories(categorized map[string][]AttributeMapping) []string {
+	categories := make([]string, 0, len(categorized))
+	for category := range categorized {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// mappingCategory returns the category of an attribute mapping, other_attributes when it has none
+func mappingCategory(mapping AttributeMapping) string {
+	if mapping.AttributeCategory == "" {
+		return "other_attributes"
+	}
+	return mapping.AttributeCategory
+}
+
 // BuildPropertyMap constructs a map projection expression for a single attribute mapping.
 // Uses Neo4j map projection syntax to avoid implicit grouping expression errors in aggregations.
 //
@@ -234,28 +383,42 @@ func GroupMappingsByCategory(mappings []AttributeMapping) map[string][]Attribute
 //	    IdentifierProperty: "address",
 //	})
 //	// Returns: email0{.address, .*}
+//
+// With relationship properties, projected from the relationship variable bound by AddAttributeMatch:
+//
+//	expr := BuildPropertyMap("attr0", AttributeMapping{
+//	    IdentifierProperty: "entityId",
+//	    IncludeRelationshipProperties: []string{"role"},
+//	})
+//	// Returns: attr0{.entityId, .*, relationship: attr0_rel{.role}}
 func BuildPropertyMap(varName string, mapping AttributeMapping) string {
 	var projections []string
 
 	if len(mapping.IncludeProperties) > 0 {
 		// Include specific properties using map projection syntax
 		if mapping.IdentifierProperty != "" {
-			projections = append(projections, "."+mapping.IdentifierProperty)
+			projections = append(projections, "."+EscapeIdentifier(mapping.IdentifierProperty))
 		}
 		for _, prop := range mapping.IncludeProperties {
-			projections = append(projections, "."+prop)
+			projections = append(projections, "."+EscapeIdentifier(prop))
 		}
-		return fmt.Sprintf("%s{%s}", varName, strings.Join(projections, ", "))
+	} else if mapping.IdentifierProperty != "" {
+		// Include identifier explicitly, then all other properties
+		projections = append(projections, "."+EscapeIdentifier(mapping.IdentifierProperty), ".*")
+	} else {
+		// Just return all properties
+		projections = append(projections, ".*")
 	}
 
-	// Return all properties using .* projection
-	if mapping.IdentifierProperty != "" {
-		// Include identifier explicitly, then all other properties
-		return fmt.Sprintf("%s{.%s, .*}", varName, mapping.IdentifierProperty)
+	if len(mapping.IncludeRelationshipProperties) > 0 {
+		relProjections := make([]string, 0, len(mapping.IncludeRelationshipProperties))
+		for _, prop := range mapping.IncludeRelationshipProperties {
+			relProjections = append(relProjections, "."+EscapeIdentifier(prop))
+		}
+		projections = append(projections, fmt.Sprintf("relationship: %s{%s}", RelationshipVar(varName), strings.Join(relProjections, ", ")))
 	}
 
-	// Just return all properties
-	return fmt.Sprintf("%s{.*}", varName)
+	return fmt.Sprintf("%s{%s}", varName, strings.Join(projections, ", "))
 }
 
 // SanitizeIdentifier sanitizes a string to be used as a Cypher variable name.
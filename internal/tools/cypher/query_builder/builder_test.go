@@ -21,6 +21,35 @@ func TestOptionalMatchBuilder_AddAttributeMatch(t *testing.T) {
 	assert.Contains(t, query, "OPTIONAL MATCH (c)-[:HAS_EMAIL]->(attr0:Email)")
 }
 
+func TestOptionalMatchBuilder_AddAttributeMatch_EscapesIdentifiers(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	builder.AddAttributeMatch("c", AttributeMapping{
+		RelationshipType: "HAS EMAIL",
+		TargetLabel:      "Email) DETACH DELETE c //`",
+	})
+
+	query := builder.Build()
+	assert.Contains(t, query, "OPTIONAL MATCH (c)-[:`HAS EMAIL`]->(attr0:`Email) DETACH DELETE c //```)")
+}
+
+func TestOptionalMatchBuilder_AddAttributeMatch_LabelExpression(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	builder.AddAttributeMatch("c", AttributeMapping{
+		RelationshipType: "HAS_EMAIL",
+		TargetLabel:      "Email|EmailAddress",
+	})
+	builder.AddAttributeMatch("c", AttributeMapping{
+		RelationshipType: "HAS_DOCUMENT",
+		TargetLabel:      "Document:Verified",
+	})
+
+	query := builder.Build()
+	assert.Contains(t, query, "OPTIONAL MATCH (c)-[:HAS_EMAIL]->(attr0:Email|EmailAddress)")
+	assert.Contains(t, query, "OPTIONAL MATCH (c)-[:HAS_DOCUMENT]->(attr1:Document:Verified)")
+}
+
 func TestOptionalMatchBuilder_AddMultipleMatches(t *testing.T) {
 	builder := NewOptionalMatchBuilder()
 
@@ -43,6 +72,164 @@ func TestOptionalMatchBuilder_AddMultipleMatches(t *testing.T) {
 	assert.Equal(t, 2, builder.GetClauseCount())
 }
 
+func TestOptionalMatchBuilder_AddAttributeMatch_Direction(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	builder.AddAttributeMatch("a", AttributeMapping{
+		RelationshipType: "OWNS",
+		TargetLabel:      "Customer",
+		Direction:        "in",
+	})
+	builder.AddAttributeMatch("a", AttributeMapping{
+		RelationshipType: "LINKED_TO",
+		TargetLabel:      "Account",
+		Direction:        "both",
+	})
+
+	query := builder.Build()
+	assert.Contains(t, query, "OPTIONAL MATCH (a)<-[:OWNS]-(attr0:Customer)")
+	assert.Contains(t, query, "OPTIONAL MATCH (a)-[:LINKED_TO]-(attr1:Account)")
+}
+
+func TestOptionalMatchBuilder_AddAttributeMatch_RelationshipProperties(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	varName := builder.AddAttributeMatch("c", AttributeMapping{
+		RelationshipType:              "BENEFICIAL_OWNER_OF",
+		TargetLabel:                   "Entity",
+		IncludeRelationshipProperties: []string{"role"},
+	})
+	builder.AddAttributeMatch("a", AttributeMapping{
+		RelationshipType:              "OWNS",
+		TargetLabel:                   "Customer",
+		Direction:                     "in",
+		IncludeRelationshipProperties: []string{"since"},
+	})
+
+	assert.Equal(t, "attr0", varName)
+	query := builder.Build()
+	assert.Contains(t, query, "OPTIONAL MATCH (c)-[attr0_rel:BENEFICIAL_OWNER_OF]->(attr0:Entity)")
+	assert.Contains(t, query, "OPTIONAL MATCH (a)<-[attr1_rel:OWNS]-(attr1:Customer)")
+}
+
+func TestOptionalMatchBuilder_AddAttributeMatch_Filters(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	builder.AddAttributeMatch("c", AttributeMapping{
+		RelationshipType: "OWNS",
+		TargetLabel:      "Account",
+		Filters: []PropertyFilter{
+			{PropertyName: "status", Operator: "=", Value: "active"},
+			{PropertyName: "balance", Operator: ">=", Value: 1000},
+		},
+	})
+	builder.AddAttributeMatch("c", AttributeMapping{
+		RelationshipType: "HAS_EMAIL",
+		TargetLabel:      "Email",
+		Filters: []PropertyFilter{
+			{PropertyName: "address", Operator: "ends with", Value: "@example.com"},
+		},
+	})
+
+	query := builder.Build()
+	assert.Contains(t, query, "OPTIONAL MATCH (c)-[:OWNS]->(attr0:Account) WHERE attr0.status = $attr0_filter0 AND attr0.balance >= $attr0_filter1")
+	assert.Contains(t, query, "OPTIONAL MATCH (c)-[:HAS_EMAIL]->(attr1:Email) WHERE attr1.address ENDS WITH $attr1_filter0")
+	assert.Equal(t, map[string]any{
+		"attr0_filter0": "active",
+		"attr0_filter1": 1000,
+		"attr1_filter0": "@example.com",
+	}, builder.Params())
+}
+
+func TestNeedsCollectionSubquery(t *testing.T) {
+	assert.False(t, NeedsCollectionSubquery(AttributeMapping{}))
+	assert.True(t, NeedsCollectionSubquery(AttributeMapping{OrderBy: "date"}))
+	assert.True(t, NeedsCollectionSubquery(AttributeMapping{Limit: 5}))
+	assert.True(t, NeedsCollectionSubquery(AttributeMapping{Aggregations: []Aggregation{{Function: "count", Alias: "count"}}}))
+}
+
+func TestOptionalMatchBuilder_UsesCollectionSubquery(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+	assert.False(t, builder.UsesCollectionSubquery(AttributeMapping{}))
+	assert.True(t, builder.UsesCollectionSubquery(AttributeMapping{Limit: 5}))
+
+	builder.SetCollectionSubqueries(true)
+	assert.True(t, builder.UsesCollectionSubquery(AttributeMapping{}))
+
+	subquery := builder.BuildCollectionSubquery("c", AttributeMapping{
+		RelationshipType:   "HAS_EMAIL",
+		TargetLabel:        "Email",
+		IdentifierProperty: "address",
+	}, "contact_information_emails")
+
+	assert.Equal(t, "CALL {\n"+
+		"  WITH c\n"+
+		"  OPTIONAL MATCH (c)-[:HAS_EMAIL]->(attr0:Email)\n"+
+		"  WITH DISTINCT attr0\n"+
+		"  RETURN collect(attr0{.address, .*}) as contact_information_emails\n"+
+		"}\n", subquery)
+}
+
+func TestOptionalMatchBuilder_BuildCollectionSubquery(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	subquery := builder.BuildCollectionSubquery("c", AttributeMapping{
+		RelationshipType:  "PERFORMS",
+		TargetLabel:       "Transaction",
+		IncludeProperties: []string{"amount", "date"},
+		OrderBy:           "date desc",
+		Limit:             10,
+	}, "activity_transactions")
+
+	assert.Equal(t, "CALL {\n"+
+		"  WITH c\n"+
+		"  OPTIONAL MATCH (c)-[:PERFORMS]->(attr0:Transaction)\n"+
+		"  WITH DISTINCT attr0\n"+
+		"  ORDER BY attr0.date DESC\n"+
+		"  LIMIT $attr0_limit\n"+
+		"  RETURN collect(attr0{.amount, .date}) as activity_transactions\n"+
+		"}\n", subquery)
+	assert.Equal(t, map[string]any{"attr0_limit": 10}, builder.Params())
+	assert.Zero(t, builder.GetClauseCount())
+}
+
+func TestOptionalMatchBuilder_BuildCollectionSubquery_RelationshipProperties(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	subquery := builder.BuildCollectionSubquery("c", AttributeMapping{
+		RelationshipType:              "OWNS",
+		TargetLabel:                   "Account",
+		IncludeRelationshipProperties: []string{"since"},
+		OrderBy:                       "openedDate",
+	}, "accounts")
+
+	assert.Contains(t, subquery, "  OPTIONAL MATCH (c)-[attr0_rel:OWNS]->(attr0:Account)\n")
+	assert.Contains(t, subquery, "  WITH DISTINCT attr0, attr0_rel\n")
+	assert.Contains(t, subquery, "  ORDER BY attr0.openedDate\n")
+	assert.NotContains(t, subquery, "LIMIT")
+	assert.Empty(t, builder.Params())
+}
+
+func TestOptionalMatchBuilder_BuildCollectionSubquery_Aggregations(t *testing.T) {
+	builder := NewOptionalMatchBuilder()
+
+	subquery := builder.BuildCollectionSubquery("c", AttributeMapping{
+		RelationshipType:              "PERFORMS",
+		TargetLabel:                   "Transaction",
+		IncludeRelationshipProperties: []string{"channel"},
+		Aggregations: []Aggregation{
+			{Function: "count", Alias: "count"},
+			{Function: "SUM", Property: "amount", Alias: "totalAmount"},
+			{Function: "max", Property: "date", Alias: "lastDate"},
+		},
+	}, "activity_transactions")
+
+	// Aggregates are computed over distinct nodes, whatever the relationships between them
+	assert.Contains(t, subquery, "  WITH DISTINCT attr0\n")
+	assert.Contains(t, subquery, "  RETURN {count: count(attr0), totalAmount: sum(attr0.amount), lastDate: max(attr0.date)} as activity_transactions\n")
+	assert.NotContains(t, subquery, "collect(")
+}
+
 func TestOptionalMatchBuilder_AddPathMatch_OutDirection(t *testing.T) {
 	builder := NewOptionalMatchBuilder()
 
@@ -223,6 +410,17 @@ func TestGroupMappingsByCategory(t *testing.T) {
 	assert.Equal(t, "Note", grouped["other_attributes"][0].TargetLabel)
 }
 
+func TestSortedCategories(t *testing.T) {
+	grouped := GroupMappingsByCategory([]AttributeMapping{
+		{RelationshipType: "HAS_SSN", TargetLabel: "SSN", AttributeCategory: "identity_documents"},
+		{RelationshipType: "HAS_NOTE", TargetLabel: "Note"},
+		{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", AttributeCategory: "contact_information"},
+	})
+
+	assert.Equal(t, []string{"contact_information", "identity_documents", "other_attributes"}, SortedCategories(grouped))
+	assert.Empty(t, SortedCategories(GroupMappingsByCategory(nil)))
+}
+
 func TestBuildPropertyMap_WithSpecificProperties(t *testing.T) {
 	mapping := AttributeMapping{
 		IdentifierProperty: "address",
@@ -269,6 +467,31 @@ func TestBuildPropertyMap_NoIdentifierNoProperties(t *testing.T) {
 	assert.Equal(t, "node0{.*}", result)
 }
 
+func TestBuildPropertyMap_RelationshipProperties(t *testing.T) {
+	t.Run("with specific node properties", func(t *testing.T) {
+		mapping := AttributeMapping{
+			IdentifierProperty:            "accountNumber",
+			IncludeProperties:             []string{"status"},
+			IncludeRelationshipProperties: []string{"since", "ownershipPercentage"},
+		}
+
+		result := BuildPropertyMap("attr0", mapping)
+
+		assert.Equal(t, "attr0{.accountNumber, .status, relationship: attr0_rel{.since, .ownershipPercentage}}", result)
+	})
+
+	t.Run("with all node properties", func(t *testing.T) {
+		mapping := AttributeMapping{
+			IdentifierProperty:            "entityId",
+			IncludeRelationshipProperties: []string{"role"},
+		}
+
+		result := BuildPropertyMap("attr1", mapping)
+
+		assert.Equal(t, "attr1{.entityId, .*, relationship: attr1_rel{.role}}", result)
+	})
+}
+
 func TestSanitizeIdentifier(t *testing.T) {
 	tests := []struct {
 		input    string
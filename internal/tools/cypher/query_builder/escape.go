@@ -0,0 +1,111 @@
+package query_builder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// maxIdentifierLength bounds the node labels, relationship types and property names accepted from tool arguments
+const maxIdentifierLength = 256
+
+// backtickEscapePattern matches the unicode escape of a backtick, which Cypher reads as a backtick inside quoted names
+var backtickEscapePattern = regexp.MustCompile(`\\[uU]0060`)
+
+// EscapeIdentifier returns a node label, relationship type or property name ready to be written into a query.
+// Plain names are returned unchanged; any other name is quoted with backticks, doubling the backticks it contains,
+// including those written as the \u0060 escape, so it cannot end the identifier and inject Cypher.
+//
+// Example:
+//
+//	EscapeIdentifier("Customer")          // Customer
+//	EscapeIdentifier("Bank Account")      // `Bank Account`
+//	EscapeIdentifier("x`) DETACH DELETE") // `x``) DETACH DELETE`
+//	EscapeIdentifier(`x\u0060) DELETE`)   // `x``) DELETE`
+func EscapeIdentifier(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	name = backtickEscapePattern.ReplaceAllLiteralString(name, "`")
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// IsPlainIdentifier reports whether name is a letter or underscore followed by letters, digits and underscores,
+// so it can be written into a query without quoting.
+func IsPlainIdentifier(name string) bool {
+	return identifierPattern.MatchString(name)
+}
+
+// EscapeIdentifiers escapes each name with EscapeIdentifier.
+func EscapeIdentifiers(names []string) []string {
+	escaped := make([]string, len(names))
+	for i, name := range names {
+		escaped[i] = EscapeIdentifier(name)
+	}
+	return escaped
+}
+
+// QuoteString returns s as a single-quoted Cypher string literal, escaping backslashes and quotes.
+// Prefer query parameters for values; use it only for names written into a query, such as labels returned as values.
+//
+// Example:
+//
+//	QuoteString("Email")   // 'Email'
+//	QuoteString("O'Brien") // 'O\'Brien'
+func QuoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
+
+// ValidateIdentifier checks a node label, relationship type or property name can be escaped into a query:
+// it must not be empty, too long, or contain control characters.
+// Returns an error message naming field, or "" when the name is valid.
+func ValidateIdentifier(field string, name string) string {
+	if name == "" {
+		return fmt.Sprintf("%s cannot be empty", field)
+	}
+	if len(name) > maxIdentifierLength {
+		return fmt.Sprintf("%s cannot be longer than %d characters", field, maxIdentifierLength)
+	}
+	if strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return fmt.Sprintf("%s '%s' cannot contain control characters", field, strings.Map(printable, name))
+	}
+	return ""
+}
+
+// ValidateAttributeIdentifiers checks the labels, relationship types and property names of every attribute mapping.
+func ValidateAttributeIdentifiers(mappings []AttributeMapping) string {
+	for i, mapping := range mappings {
+		field := fmt.Sprintf("attributeMappings[%d]", i)
+		if errMessage := ValidateIdentifier(field+".relationshipType", mapping.RelationshipType); errMessage != "" {
+			return errMessage
+		}
+		if errMessage := ValidateLabelExpression(field+".targetLabel", mapping.TargetLabel); errMessage != "" {
+			return errMessage
+		}
+		if mapping.IdentifierProperty != "" {
+			if errMessage := ValidateIdentifier(field+".identifierProperty", mapping.IdentifierProperty); errMessage != "" {
+				return errMessage
+			}
+		}
+		for j, prop := range mapping.IncludeProperties {
+			if errMessage := ValidateIdentifier(fmt.Sprintf("%s.includeProperties[%d]", field, j), prop); errMessage != "" {
+				return errMessage
+			}
+		}
+		for j, prop := range mapping.IncludeRelationshipProperties {
+			if errMessage := ValidateIdentifier(fmt.Sprintf("%s.includeRelationshipProperties[%d]", field, j), prop); errMessage != "" {
+				return errMessage
+			}
+		}
+	}
+	return ""
+}
+
+// printable replaces control characters so they can be shown in an error message
+func printable(r rune) rune {
+	if unicode.IsControl(r) {
+		return '?'
+	}
+	return r
+}
@@ -0,0 +1,90 @@
+package query_builder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeIdentifier(t *testing.T) {
+	tests := map[string]string{
+		"Customer":                    "Customer",
+		"HAS_EMAIL":                   "HAS_EMAIL",
+		"_private":                    "_private",
+		"Bank Account":                "`Bank Account`",
+		"1stParty":                    "`1stParty`",
+		"date-of-birth":               "`date-of-birth`",
+		"x`) DETACH DELETE n //":      "`x``) DETACH DELETE n //`",
+		"Email]->(x) DELETE x //":     "`Email]->(x) DELETE x //`",
+		"``":                          "``````",
+		"Kundeä":                      "`Kundeä`",
+		"name}) RETURN 1 UNION //":    "`name}) RETURN 1 UNION //`",
+		"label` WITH * MATCH (n) //":  "`label`` WITH * MATCH (n) //`",
+		`x\u0060) DETACH DELETE n //`: "`x``) DETACH DELETE n //`",
+		`x\U0060\u0060 RETURN 1 //`:   "`x```` RETURN 1 //`",
+		`C:\users`:                    "`C:\\users`",
+		`path\u0041`:                  "`path\\u0041`",
+	}
+
+	for name, expected := range tests {
+		assert.Equal(t, expected, EscapeIdentifier(name), "EscapeIdentifier(%q)", name)
+	}
+}
+
+func TestIsPlainIdentifier(t *testing.T) {
+	assert.True(t, IsPlainIdentifier("Customer"))
+	assert.True(t, IsPlainIdentifier("_HAS_EMAIL2"))
+	assert.False(t, IsPlainIdentifier(""))
+	assert.False(t, IsPlainIdentifier("2Customer"))
+	assert.False(t, IsPlainIdentifier("Bank Account"))
+	assert.False(t, IsPlainIdentifier(`x\u0060`))
+}
+
+func TestEscapeIdentifiers(t *testing.T) {
+	assert.Equal(t, []string{"HAS_EMAIL", "`HAS PHONE`"}, EscapeIdentifiers([]string{"HAS_EMAIL", "HAS PHONE"}))
+	assert.Empty(t, EscapeIdentifiers(nil))
+}
+
+func TestQuoteString(t *testing.T) {
+	assert.Equal(t, "'Email'", QuoteString("Email"))
+	assert.Equal(t, `'O\'Brien'`, QuoteString("O'Brien"))
+	assert.Equal(t, `'a\\b'`, QuoteString(`a\b`))
+	assert.Equal(t, `'x\\\' RETURN 1 //'`, QuoteString(`x\' RETURN 1 //`))
+}
+
+func TestValidateIdentifier(t *testing.T) {
+	assert.Empty(t, ValidateIdentifier("nodeLabel", "Customer"))
+	assert.Empty(t, ValidateIdentifier("nodeLabel", "Bank Account"))
+	assert.Contains(t, ValidateIdentifier("nodeLabel", ""), "nodeLabel cannot be empty")
+	assert.Contains(t, ValidateIdentifier("nodeLabel", strings.Repeat("a", maxIdentifierLength+1)), "cannot be longer than 256 characters")
+	assert.Contains(t, ValidateIdentifier("nodeLabel", "Cust\nomer"), "nodeLabel 'Cust?omer' cannot contain control characters")
+}
+
+func TestValidateAttributeIdentifiers(t *testing.T) {
+	t.Run("valid mappings", func(t *testing.T) {
+		mappings := []AttributeMapping{
+			{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", IdentifierProperty: "address"},
+			{RelationshipType: "HAS ADDRESS", TargetLabel: "Postal Address", IncludeProperties: []string{"post code"}},
+		}
+
+		assert.Empty(t, ValidateAttributeIdentifiers(mappings))
+	})
+
+	t.Run("empty target label is rejected", func(t *testing.T) {
+		mappings := []AttributeMapping{
+			{RelationshipType: "HAS_EMAIL", TargetLabel: "Email"},
+			{RelationshipType: "HAS_PHONE"},
+		}
+
+		assert.Contains(t, ValidateAttributeIdentifiers(mappings), "attributeMappings[1].targetLabel cannot be empty")
+	})
+
+	t.Run("control characters in properties are rejected", func(t *testing.T) {
+		mappings := []AttributeMapping{
+			{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", IncludeRelationshipProperties: []string{"since", "ver\x00ified"}},
+		}
+
+		assert.Contains(t, ValidateAttributeIdentifiers(mappings), "attributeMappings[0].includeRelationshipProperties[1]")
+	})
+}
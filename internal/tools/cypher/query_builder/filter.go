@@ -0,0 +1,101 @@
+package query_builder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// filterOperators lists the supported PropertyFilter operators
+var filterOperators = map[string]bool{
+	"=":           true,
+	"<>":          true,
+	">":           true,
+	"<":           true,
+	">=":          true,
+	"<=":          true,
+	"CONTAINS":    true,
+	"STARTS WITH": true,
+	"ENDS WITH":   true,
+	"IN":          true,
+}
+
+// FilterBuilder converts property filters into WHERE clauses.
+// Filter values are never written into the query: each one is bound to a parameter collected in Params.
+type FilterBuilder struct {
+	params map[string]any
+}
+
+// NewFilterBuilder creates a new filter builder.
+func NewFilterBuilder() *FilterBuilder {
+	return &FilterBuilder{
+		params: make(map[string]any),
+	}
+}
+
+// BuildWhere returns a WHERE clause matching all filters on the properties of varName, or "" when there are none.
+// Parameters are named after the variable, so filters on different variables of one query do not collide.
+//
+// Example:
+//
+//	where := builder.BuildWhere("attr0", []PropertyFilter{
+//	    {PropertyName: "status", Operator: "=", Value: "active"},
+//	    {PropertyName: "amount", Operator: ">", Value: 1000},
+//	})
+//	// Returns: WHERE attr0.status = $attr0_filter0 AND attr0.amount > $attr0_filter1
+//	// Params:  {attr0_filter0: "active", attr0_filter1: 1000}
+func (f *FilterBuilder) BuildWhere(varName string, filters []PropertyFilter) string {
+	if len(filters) == 0 {
+		return ""
+	}
+
+	conditions := make([]string, 0, len(filters))
+	for i, filter := range filters {
+		paramName := fmt.Sprintf("%s_filter%d", varName, i)
+		f.params[paramName] = filter.Value
+		conditions = append(conditions, fmt.Sprintf("%s.%s %s $%s",
+			varName,
+			EscapeIdentifier(filter.PropertyName),
+			normalizeOperator(filter.Operator),
+			paramName))
+	}
+	return "WHERE " + strings.Join(conditions, " AND ")
+}
+
+// Params returns the parameters bound by the WHERE clauses built so far, to pass along with the query.
+func (f *FilterBuilder) Params() map[string]any {
+	return f.params
+}
+
+// ValidateFilters checks property filters use a supported operator on a plain property name.
+// Returns an error message for the caller, or "" when the filters are valid.
+func ValidateFilters(field string, filters []PropertyFilter) string {
+	for i, filter := range filters {
+		if !identifierPattern.MatchString(filter.PropertyName) {
+			return fmt.Sprintf("%s[%d].propertyName '%s' must start with a letter or underscore and contain only letters, digits and underscores", field, i, filter.PropertyName)
+		}
+		operator := normalizeOperator(filter.Operator)
+		if !filterOperators[operator] {
+			return fmt.Sprintf("%s[%d] has invalid operator '%s', must be one of: =, <>, >, <, >=, <=, CONTAINS, STARTS WITH, ENDS WITH, IN", field, i, filter.Operator)
+		}
+		if operator == "IN" && (filter.Value == nil || reflect.TypeOf(filter.Value).Kind() != reflect.Slice) {
+			return fmt.Sprintf("%s[%d] uses the IN operator and requires a list value", field, i)
+		}
+	}
+	return ""
+}
+
+// ValidateAttributeFilters checks the filters of every attribute mapping with ValidateFilters.
+func ValidateAttributeFilters(mappings []AttributeMapping) string {
+	for i, mapping := range mappings {
+		if errMessage := ValidateFilters(fmt.Sprintf("attributeMappings[%d].filters", i), mapping.Filters); errMessage != "" {
+			return errMessage
+		}
+	}
+	return ""
+}
+
+// normalizeOperator upper-cases an operator and collapses its whitespace, so "starts  with" matches STARTS WITH
+func normalizeOperator(operator string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(operator)), " ")
+}
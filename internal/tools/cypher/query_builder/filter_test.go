@@ -0,0 +1,81 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterBuilder_BuildWhere(t *testing.T) {
+	builder := NewFilterBuilder()
+
+	where := builder.BuildWhere("t", []PropertyFilter{
+		{PropertyName: "amount", Operator: ">", Value: 1000},
+		{PropertyName: "currency", Operator: "IN", Value: []any{"USD", "EUR"}},
+		{PropertyName: "reference", Operator: "starts  with", Value: "WIRE"},
+	})
+
+	assert.Equal(t, "WHERE t.amount > $t_filter0 AND t.currency IN $t_filter1 AND t.reference STARTS WITH $t_filter2", where)
+	assert.Equal(t, map[string]any{
+		"t_filter0": 1000,
+		"t_filter1": []any{"USD", "EUR"},
+		"t_filter2": "WIRE",
+	}, builder.Params())
+}
+
+func TestFilterBuilder_BuildWhere_NoFilters(t *testing.T) {
+	builder := NewFilterBuilder()
+
+	assert.Empty(t, builder.BuildWhere("t", nil))
+	assert.Empty(t, builder.Params())
+}
+
+func TestFilterBuilder_ValuesAreNeverInlined(t *testing.T) {
+	builder := NewFilterBuilder()
+
+	where := builder.BuildWhere("c", []PropertyFilter{
+		{PropertyName: "name", Operator: "=", Value: "x' OR 1=1 //"},
+	})
+
+	assert.Equal(t, "WHERE c.name = $c_filter0", where)
+	assert.Equal(t, "x' OR 1=1 //", builder.Params()["c_filter0"])
+}
+
+func TestValidateFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		filters  []PropertyFilter
+		expected string
+	}{
+		{
+			name:    "valid filters",
+			filters: []PropertyFilter{{PropertyName: "status", Operator: "=", Value: "active"}, {PropertyName: "type", Operator: "in", Value: []any{"a"}}},
+		},
+		{
+			name:     "unsupported operator",
+			filters:  []PropertyFilter{{PropertyName: "status", Operator: "=~", Value: ".*"}},
+			expected: "filters[0] has invalid operator '=~'",
+		},
+		{
+			name:     "property name with Cypher",
+			filters:  []PropertyFilter{{PropertyName: "status = 1 OR true", Operator: "=", Value: 1}},
+			expected: "filters[0].propertyName 'status = 1 OR true' must start with a letter or underscore",
+		},
+		{
+			name:     "IN without a list",
+			filters:  []PropertyFilter{{PropertyName: "status", Operator: "IN", Value: "active"}},
+			expected: "filters[0] uses the IN operator and requires a list value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errMessage := ValidateFilters("filters", tt.filters)
+			if tt.expected == "" {
+				assert.Empty(t, errMessage)
+			} else {
+				assert.Contains(t, errMessage, tt.expected)
+			}
+		})
+	}
+}
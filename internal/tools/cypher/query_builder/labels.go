@@ -0,0 +1,85 @@
+package query_builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// labelOperators are the operators accepted between the labels of a label expression.
+// ':' is the legacy form of '&', so "Customer:Person" matches nodes with both labels.
+// '|' and '&' need Neo4j 5, while a conjunction written with ':' also runs on Neo4j 4.4.
+const labelOperators = "|&:"
+
+// EscapeLabelExpression returns a node label or label expression ready to be written into a node pattern
+// or a label predicate such as "n:%s". Each label is escaped with EscapeIdentifier; the operators between
+// them are kept, so "Customer|Person" matches nodes with either label and "Customer&Verified" nodes with both.
+// Labels joined only by ':' keep it, so they run on Neo4j 4.4; mixed with | or & it becomes '&', since
+// Neo4j 5 rejects an expression combining both forms. A plain label is returned exactly as EscapeIdentifier would return it.
+//
+// Example:
+//
+//	EscapeLabelExpression("Customer")         // Customer
+//	EscapeLabelExpression("Customer|Person")  // Customer|Person
+//	EscapeLabelExpression("Customer:Person")  // Customer:Person
+//	EscapeLabelExpression("Customer:Person|Loan") // Customer&Person|Loan
+//	EscapeLabelExpression("Bank Account|Loan") // `Bank Account`|Loan
+func EscapeLabelExpression(expression string) string {
+	labels, operators := splitLabelExpression(expression)
+	legacy := !strings.ContainsAny(string(operators), "|&")
+
+	var escaped strings.Builder
+	for i, label := range labels {
+		if i > 0 {
+			operator := operators[i-1]
+			if operator == ':' && !legacy {
+				operator = '&'
+			}
+			escaped.WriteByte(operator)
+		}
+		escaped.WriteString(EscapeIdentifier(label))
+	}
+	return escaped.String()
+}
+
+// LabelExpressionLabels returns the labels named in a label expression, in order.
+//
+// Example:
+//
+//	LabelExpressionLabels("Customer|Person") // [Customer Person]
+func LabelExpressionLabels(expression string) []string {
+	labels, _ := splitLabelExpression(expression)
+	return labels
+}
+
+// ValidateLabelExpression checks a node label or label expression can be escaped into a query:
+// every label joined by |, & or : must pass ValidateIdentifier.
+// Returns an error message naming field, or "" when the expression is valid.
+func ValidateLabelExpression(field string, expression string) string {
+	if errMessage := ValidateIdentifier(field, expression); errMessage != "" {
+		return errMessage
+	}
+	for _, label := range LabelExpressionLabels(expression) {
+		if label == "" {
+			return fmt.Sprintf("%s '%s' must name a label on each side of |, & and :", field, expression)
+		}
+	}
+	return ""
+}
+
+// splitLabelExpression splits a label expression into its trimmed labels and the operators between them.
+// There is always one operator fewer than labels.
+func splitLabelExpression(expression string) ([]string, []byte) {
+	var labels []string
+	var operators []byte
+	start := 0
+	for i := 0; i < len(expression); i++ {
+		if !strings.ContainsRune(labelOperators, rune(expression[i])) {
+			continue
+		}
+		labels = append(labels, strings.TrimSpace(expression[start:i]))
+		operators = append(operators, expression[i])
+		start = i + 1
+	}
+	labels = append(labels, strings.TrimSpace(expression[start:]))
+	return labels, operators
+}
@@ -0,0 +1,43 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeLabelExpression(t *testing.T) {
+	tests := map[string]string{
+		"Customer":                        "Customer",
+		"Customer|Person":                 "Customer|Person",
+		"Customer | Person":               "Customer|Person",
+		"Customer&Verified":               "Customer&Verified",
+		"Customer:Verified":               "Customer:Verified",
+		"Customer : Person:Verified":      "Customer:Person:Verified",
+		"Customer:Verified|Person":        "Customer&Verified|Person",
+		"Customer|Person&Verified":        "Customer|Person&Verified",
+		"Bank Account|Loan":               "`Bank Account`|Loan",
+		"Email) DETACH DELETE n //|Phone": "`Email) DETACH DELETE n //`|Phone",
+		"Email`|Phone":                    "`Email```|Phone",
+	}
+
+	for expression, expected := range tests {
+		assert.Equal(t, expected, EscapeLabelExpression(expression), "EscapeLabelExpression(%q)", expression)
+	}
+}
+
+func TestLabelExpressionLabels(t *testing.T) {
+	assert.Equal(t, []string{"Customer"}, LabelExpressionLabels("Customer"))
+	assert.Equal(t, []string{"Customer", "Person", "Verified"}, LabelExpressionLabels("Customer|Person & Verified"))
+	assert.Equal(t, []string{"Customer", ""}, LabelExpressionLabels("Customer|"))
+}
+
+func TestValidateLabelExpression(t *testing.T) {
+	assert.Empty(t, ValidateLabelExpression("nodeLabel", "Customer"))
+	assert.Empty(t, ValidateLabelExpression("nodeLabel", "Customer|Person"))
+	assert.Empty(t, ValidateLabelExpression("nodeLabel", "Customer:Verified"))
+	assert.Contains(t, ValidateLabelExpression("nodeLabel", ""), "nodeLabel cannot be empty")
+	assert.Contains(t, ValidateLabelExpression("nodeLabel", "Customer||Person"), "must name a label on each side of |, & and :")
+	assert.Contains(t, ValidateLabelExpression("nodeLabel", "|Customer"), "must name a label on each side of |, & and :")
+	assert.Contains(t, ValidateLabelExpression("nodeLabel", "Customer&\tPerson"), "cannot contain control characters")
+}
@@ -0,0 +1,75 @@
+package query_builder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// identifierPattern restricts names written into the query as map keys, variables and property names
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// irregularPlurals lists the nouns likely to appear as node labels whose plural does not follow the suffix rules
+var irregularPlurals = map[string]string{
+	"person":   "people",
+	"child":    "children",
+	"man":      "men",
+	"woman":    "women",
+	"data":     "data",
+	"media":    "media",
+	"criteria": "criteria",
+	"series":   "series",
+	"news":     "news",
+}
+
+// Pluralize returns the English plural of a lowercase noun.
+//
+// Example:
+//
+//	Pluralize("email")   // emails
+//	Pluralize("address") // addresses
+//	Pluralize("entity")  // entities
+//	Pluralize("person")  // people
+func Pluralize(word string) string {
+	if plural, ok := irregularPlurals[word]; ok {
+		return plural
+	}
+
+	switch {
+	case strings.HasSuffix(word, "s"), strings.HasSuffix(word, "x"), strings.HasSuffix(word, "z"),
+		strings.HasSuffix(word, "ch"), strings.HasSuffix(word, "sh"):
+		return word + "es"
+	case len(word) > 1 && strings.HasSuffix(word, "y") && !strings.ContainsRune("aeiou", rune(word[len(word)-2])):
+		return word[:len(word)-1] + "ies"
+	}
+	return word + "s"
+}
+
+// CollectionKey returns the key of an attribute mapping's list in the profile output: its CollectionKey
+// when set, otherwise the pluralized, lowercase target label (e.g. "addresses" for Address).
+// For a label expression the first label names the list, so "Email|EmailAddress" gives "emails".
+func CollectionKey(mapping AttributeMapping) string {
+	if mapping.CollectionKey != "" {
+		return mapping.CollectionKey
+	}
+	return Pluralize(strings.ToLower(LabelExpressionLabels(mapping.TargetLabel)[0]))
+}
+
+// ValidateCollectionKeys checks the collection keys of attribute mappings are valid identifiers and unique
+// within each category, since two lists under the same key would overwrite each other.
+func ValidateCollectionKeys(mappings []AttributeMapping) string {
+	seen := make(map[string]int)
+	for i, mapping := range mappings {
+		if mapping.CollectionKey != "" && !identifierPattern.MatchString(mapping.CollectionKey) {
+			return fmt.Sprintf("attributeMappings[%d].collectionKey '%s' must start with a letter or underscore and contain only letters, digits and underscores", i, mapping.CollectionKey)
+		}
+
+		category := mappingCategory(mapping)
+		key := category + "." + CollectionKey(mapping)
+		if first, ok := seen[key]; ok {
+			return fmt.Sprintf("attributeMappings[%d] and attributeMappings[%d] both produce the collection '%s' in category '%s'. Set collectionKey on one of them to tell them apart.", first, i, CollectionKey(mapping), category)
+		}
+		seen[key] = i
+	}
+	return ""
+}
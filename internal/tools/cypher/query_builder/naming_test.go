@@ -0,0 +1,67 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := map[string]string{
+		"email":         "emails",
+		"account":       "accounts",
+		"ssn":           "ssns",
+		"driverlicense": "driverlicenses",
+		"address":       "addresses",
+		"ipaddress":     "ipaddresses",
+		"status":        "statuses",
+		"box":           "boxes",
+		"branch":        "branches",
+		"entity":        "entities",
+		"company":       "companies",
+		"day":           "days",
+		"person":        "people",
+		"data":          "data",
+	}
+
+	for word, expected := range tests {
+		assert.Equal(t, expected, Pluralize(word), "Pluralize(%q)", word)
+	}
+}
+
+func TestCollectionKey(t *testing.T) {
+	assert.Equal(t, "addresses", CollectionKey(AttributeMapping{TargetLabel: "Address"}))
+	assert.Equal(t, "entities", CollectionKey(AttributeMapping{TargetLabel: "Entity"}))
+	assert.Equal(t, "emails", CollectionKey(AttributeMapping{TargetLabel: "Email|EmailAddress"}))
+	assert.Equal(t, "home_addresses", CollectionKey(AttributeMapping{TargetLabel: "Address", CollectionKey: "home_addresses"}))
+}
+
+func TestValidateCollectionKeys(t *testing.T) {
+	t.Run("distinct keys are valid", func(t *testing.T) {
+		mappings := []AttributeMapping{
+			{TargetLabel: "Address", AttributeCategory: "contact_information", CollectionKey: "home_addresses"},
+			{TargetLabel: "Address", AttributeCategory: "contact_information"},
+			{TargetLabel: "Address", AttributeCategory: "business"},
+		}
+
+		assert.Empty(t, ValidateCollectionKeys(mappings))
+	})
+
+	t.Run("duplicate keys in a category are rejected", func(t *testing.T) {
+		mappings := []AttributeMapping{
+			{TargetLabel: "Address", AttributeCategory: "contact_information"},
+			{TargetLabel: "Email"},
+			{TargetLabel: "Address", AttributeCategory: "contact_information"},
+		}
+
+		assert.Contains(t, ValidateCollectionKeys(mappings), "attributeMappings[0] and attributeMappings[2] both produce the collection 'addresses'")
+	})
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		mappings := []AttributeMapping{
+			{TargetLabel: "Address", CollectionKey: "home addresses}) RETURN 1 //"},
+		}
+
+		assert.Contains(t, ValidateCollectionKeys(mappings), "attributeMappings[0].collectionKey")
+	})
+}
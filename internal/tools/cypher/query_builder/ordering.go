@@ -0,0 +1,43 @@
+package query_builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxCollectionLimit is the largest Limit an attribute mapping may set
+const MaxCollectionLimit = 1000
+
+// parseOrderBy splits an OrderBy value such as "date DESC" into its property and direction.
+// It reports false when orderBy is empty or malformed.
+func parseOrderBy(orderBy string) (property string, descending bool, ok bool) {
+	fields := strings.Fields(orderBy)
+	if len(fields) == 0 || len(fields) > 2 || !identifierPattern.MatchString(fields[0]) {
+		return "", false, false
+	}
+	if len(fields) == 1 {
+		return fields[0], false, true
+	}
+	switch strings.ToUpper(fields[1]) {
+	case "ASC":
+		return fields[0], false, true
+	case "DESC":
+		return fields[0], true, true
+	}
+	return "", false, false
+}
+
+// ValidateAttributeOrdering checks the OrderBy and Limit of every attribute mapping.
+func ValidateAttributeOrdering(mappings []AttributeMapping) string {
+	for i, mapping := range mappings {
+		if mapping.OrderBy != "" {
+			if _, _, ok := parseOrderBy(mapping.OrderBy); !ok {
+				return fmt.Sprintf("attributeMappings[%d].orderBy '%s' must be a property name, optionally followed by ASC or DESC (e.g., 'date DESC')", i, mapping.OrderBy)
+			}
+		}
+		if mapping.Limit < 0 || mapping.Limit > MaxCollectionLimit {
+			return fmt.Sprintf("attributeMappings[%d].limit must be between 1 and %d", i, MaxCollectionLimit)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,38 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOrderBy(t *testing.T) {
+	tests := []struct {
+		orderBy    string
+		property   string
+		descending bool
+		ok         bool
+	}{
+		{orderBy: "date", property: "date", ok: true},
+		{orderBy: "date ASC", property: "date", ok: true},
+		{orderBy: "date desc", property: "date", descending: true, ok: true},
+		{orderBy: ""},
+		{orderBy: "date DESC, amount"},
+		{orderBy: "date SIDEWAYS"},
+		{orderBy: "a.date"},
+	}
+
+	for _, tt := range tests {
+		property, descending, ok := parseOrderBy(tt.orderBy)
+		assert.Equal(t, tt.property, property, "parseOrderBy(%q)", tt.orderBy)
+		assert.Equal(t, tt.descending, descending, "parseOrderBy(%q)", tt.orderBy)
+		assert.Equal(t, tt.ok, ok, "parseOrderBy(%q)", tt.orderBy)
+	}
+}
+
+func TestValidateAttributeOrdering(t *testing.T) {
+	assert.Empty(t, ValidateAttributeOrdering([]AttributeMapping{{OrderBy: "date DESC", Limit: 10}, {}}))
+	assert.Contains(t, ValidateAttributeOrdering([]AttributeMapping{{}, {OrderBy: "date) RETURN 1 //"}}), "attributeMappings[1].orderBy")
+	assert.Contains(t, ValidateAttributeOrdering([]AttributeMapping{{Limit: MaxCollectionLimit + 1}}), "attributeMappings[0].limit must be between 1 and 1000")
+	assert.Contains(t, ValidateAttributeOrdering([]AttributeMapping{{Limit: -1}}), "attributeMappings[0].limit")
+}
@@ -0,0 +1,85 @@
+package query_builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProfileSections holds the Cypher fragments shared by the profile tools (account, merchant, ...).
+type ProfileSections struct {
+	// Matches contains the OPTIONAL MATCH clauses for the attribute mappings (may be empty)
+	Matches string
+
+	// With is the aggregating WITH clause collecting every attribute mapping into its own alias.
+	// Aggregating before any CALL subquery keeps attribute rows from multiplying subquery results.
+	With string
+
+	// Subqueries contains a CALL subquery per attribute mapping with OrderBy or Limit (may be empty).
+	// It must directly follow With.
+	Subqueries string
+
+	// Entries are the RETURN map entries: base_details followed by one entry per category
+	Entries []string
+
+	// Params are the query parameters bound by attribute filters and limits, to pass along with the query
+	Params map[string]any
+}
+
+// BuildProfileSections builds the attribute part of a profile query anchored on sourceVar.
+// Categories are emitted in alphabetical order so the generated query is stable.
+//
+// Example:
+//
+//	sections := BuildProfileSections("e", []string{"status"}, mappings)
+//	// sections.Matches: OPTIONAL MATCH (e)<-[:OWNS]-(attr0:Customer)
+//	// sections.With:    WITH e,\n     collect(DISTINCT attr0{.customerId, .*}) as ownership_customers
+//	// sections.Entries: ["  base_details: {\n    status: e.status\n  }", "  ownership: {\n    customers: ownership_customers\n  }"]
+func BuildProfileSections(sourceVar string, baseProperties []string, mappings []AttributeMapping) ProfileSections {
+	categorizedMappings := GroupMappingsByCategory(mappings)
+	categories := SortedCategories(categorizedMappings)
+
+	matchBuilder := NewOptionalMatchBuilder()
+	var withBuilder, subqueryBuilder strings.Builder
+	withBuilder.WriteString("WITH " + sourceVar)
+
+	entries := []string{buildBaseDetailsEntry(sourceVar, baseProperties)}
+	for _, category := range categories {
+		collections := make([]string, 0)
+		for _, mapping := range categorizedMappings[category] {
+			collectionKey := CollectionKey(mapping)
+			collectionAlias := fmt.Sprintf("%s_%s", strings.ReplaceAll(category, "-", "_"), collectionKey)
+
+			if NeedsCollectionSubquery(mapping) {
+				subqueryBuilder.WriteString(matchBuilder.BuildCollectionSubquery(sourceVar, mapping, collectionAlias))
+			} else {
+				varName := matchBuilder.AddAttributeMatch(sourceVar, mapping)
+				withBuilder.WriteString(fmt.Sprintf(",\n     collect(DISTINCT %s) as %s", BuildPropertyMap(varName, mapping), collectionAlias))
+			}
+			collections = append(collections, fmt.Sprintf("    %s: %s", collectionKey, collectionAlias))
+		}
+		entries = append(entries, fmt.Sprintf("  %s: {\n%s\n  }", category, strings.Join(collections, ",\n")))
+	}
+
+	return ProfileSections{
+		Matches:    matchBuilder.Build(),
+		With:       withBuilder.String(),
+		Subqueries: subqueryBuilder.String(),
+		Entries:    entries,
+		Params:     matchBuilder.Params(),
+	}
+}
+
+// buildBaseDetailsEntry returns the base_details RETURN map entry.
+// If no base properties are given, all properties are returned using properties() function.
+func buildBaseDetailsEntry(sourceVar string, baseProperties []string) string {
+	if len(baseProperties) == 0 {
+		return fmt.Sprintf("  base_details: properties(%s)", sourceVar)
+	}
+
+	props := make([]string, 0, len(baseProperties))
+	for _, prop := range baseProperties {
+		escaped := EscapeIdentifier(prop)
+		props = append(props, fmt.Sprintf("    %s: %s.%s", escaped, sourceVar, escaped))
+	}
+	return "  base_details: {\n" + strings.Join(props, ",\n") + "\n  }"
+}
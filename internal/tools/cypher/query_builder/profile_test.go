@@ -0,0 +1,74 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildProfileSections(t *testing.T) {
+	mappings := []AttributeMapping{
+		{
+			RelationshipType:   "OWNS",
+			TargetLabel:        "Customer",
+			IdentifierProperty: "customerId",
+			AttributeCategory:  "ownership",
+			Direction:          "in",
+		},
+		{
+			RelationshipType:   "HAS_ADDRESS",
+			TargetLabel:        "Address",
+			IdentifierProperty: "postcode",
+			AttributeCategory:  "contact_information",
+			IncludeProperties:  []string{"city"},
+		},
+	}
+
+	sections := BuildProfileSections("e", []string{"status"}, mappings)
+
+	// Categories are sorted, so contact_information is matched first
+	assert.Equal(t, "OPTIONAL MATCH (e)-[:HAS_ADDRESS]->(attr0:Address)\nOPTIONAL MATCH (e)<-[:OWNS]-(attr1:Customer)", sections.Matches)
+	assert.Equal(t, "WITH e,\n     collect(DISTINCT attr0{.postcode, .city}) as contact_information_addresses,\n     collect(DISTINCT attr1{.customerId, .*}) as ownership_customers", sections.With)
+	require.Len(t, sections.Entries, 3)
+	assert.Equal(t, "  base_details: {\n    status: e.status\n  }", sections.Entries[0])
+	assert.Equal(t, "  contact_information: {\n    addresses: contact_information_addresses\n  }", sections.Entries[1])
+	assert.Equal(t, "  ownership: {\n    customers: ownership_customers\n  }", sections.Entries[2])
+}
+
+func TestBuildProfileSections_OrderedCollection(t *testing.T) {
+	mappings := []AttributeMapping{
+		{
+			RelationshipType:  "HAS_ADDRESS",
+			TargetLabel:       "Address",
+			AttributeCategory: "contact_information",
+		},
+		{
+			RelationshipType:  "PERFORMS",
+			TargetLabel:       "Transaction",
+			AttributeCategory: "activity",
+			OrderBy:           "date DESC",
+			Limit:             5,
+		},
+	}
+
+	sections := BuildProfileSections("e", nil, mappings)
+
+	// The ordered collection is matched in its subquery, after the aggregation of the others
+	assert.Equal(t, "OPTIONAL MATCH (e)-[:HAS_ADDRESS]->(attr1:Address)", sections.Matches)
+	assert.Equal(t, "WITH e,\n     collect(DISTINCT attr1{.*}) as contact_information_addresses", sections.With)
+	assert.Contains(t, sections.Subqueries, "OPTIONAL MATCH (e)-[:PERFORMS]->(attr0:Transaction)")
+	assert.Contains(t, sections.Subqueries, "ORDER BY attr0.date DESC\n  LIMIT $attr0_limit\n")
+	assert.Contains(t, sections.Subqueries, "RETURN collect(attr0{.*}) as activity_transactions")
+	assert.Equal(t, map[string]any{"attr0_limit": 5}, sections.Params)
+	assert.Contains(t, sections.Entries, "  activity: {\n    transactions: activity_transactions\n  }")
+}
+
+func TestBuildProfileSections_NoMappings(t *testing.T) {
+	sections := BuildProfileSections("m", nil, nil)
+
+	assert.Empty(t, sections.Matches)
+	assert.Empty(t, sections.Subqueries)
+	assert.Equal(t, "WITH m", sections.With)
+	assert.Equal(t, []string{"  base_details: properties(m)"}, sections.Entries)
+}
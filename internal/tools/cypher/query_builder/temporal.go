@@ -0,0 +1,128 @@
+package query_builder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Date formats of a TimeProperty
+const (
+	DateFormatDatetime    = "datetime"
+	DateFormatDate        = "date"
+	DateFormatEpochMillis = "epochMillis"
+)
+
+// TimeProperty is a date property of a query variable and the format its values are stored in.
+// The time window helpers compare it to parameters converted to that format, so an index on the property stays usable.
+type TimeProperty struct {
+	// Variable is the query variable holding the property (e.g., "t")
+	Variable string
+
+	// Property is the property name (e.g., "date", "timestamp")
+	Property string
+
+	// Format is how values are stored: DateFormatDatetime (default), DateFormatDate or DateFormatEpochMillis
+	Format string
+}
+
+// Expression returns the property as a temporal value: the property itself, or a datetime for epoch millis.
+// Use it wherever the value is read rather than compared, e.g. when collecting dates or grouping by day.
+//
+// Example:
+//
+//	TimeProperty{Variable: "t", Property: "date"}.Expression()                            // t.date
+//	TimeProperty{Variable: "t", Property: "ts", Format: DateFormatEpochMillis}.Expression() // datetime({epochMillis: t.ts})
+func (p TimeProperty) Expression() string {
+	property := fmt.Sprintf("%s.%s", p.Variable, EscapeIdentifier(p.Property))
+	if p.Format == DateFormatEpochMillis {
+		return fmt.Sprintf("datetime({epochMillis: %s})", property)
+	}
+	return property
+}
+
+// compare returns a predicate comparing the raw property to a temporal expression converted to its format
+func (p TimeProperty) compare(operator string, temporal string) string {
+	property := fmt.Sprintf("%s.%s", p.Variable, EscapeIdentifier(p.Property))
+	if p.Format == DateFormatEpochMillis {
+		return fmt.Sprintf("%s %s (%s).epochMillis", property, operator, temporal)
+	}
+	return fmt.Sprintf("%s %s %s", property, operator, temporal)
+}
+
+// temporalFunction returns the Cypher function converting strings to the property's temporal type
+func (p TimeProperty) temporalFunction() string {
+	if p.Format == DateFormatDate {
+		return "date"
+	}
+	return "datetime"
+}
+
+// BetweenDates returns a predicate bounding the property by the ISO 8601 values of startParam and endParam,
+// both inclusive. An empty parameter name leaves that side open; "" is returned when both are empty.
+//
+// Example:
+//
+//	BetweenDates(TimeProperty{Variable: "t", Property: "date"}, "startDate", "endDate")
+//	// Returns: t.date >= datetime($startDate) AND t.date <= datetime($endDate)
+func BetweenDates(property TimeProperty, startParam string, endParam string) string {
+	function := property.temporalFunction()
+	conditions := make([]string, 0, 2)
+	if startParam != "" {
+		conditions = append(conditions, property.compare(">=", fmt.Sprintf("%s($%s)", function, startParam)))
+	}
+	if endParam != "" {
+		conditions = append(conditions, property.compare("<=", fmt.Sprintf("%s($%s)", function, endParam)))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// LastDays returns a predicate keeping values within the number of days held by daysParam, up to now.
+//
+// Example:
+//
+//	LastDays(TimeProperty{Variable: "t", Property: "ts", Format: DateFormatEpochMillis}, "windowDays")
+//	// Returns: t.ts >= (datetime() - duration({days: $windowDays})).epochMillis
+func LastDays(property TimeProperty, daysParam string) string {
+	return property.compare(">=", fmt.Sprintf("%s() - duration({days: $%s})", property.temporalFunction(), daysParam))
+}
+
+// WithinHours returns a predicate keeping the temporal value of expression within the number of hours held by
+// hoursParam before anchor, e.g. the dates collected by a velocity check against their most recent one.
+//
+// Example:
+//
+//	WithinHours("d", "latest", "windowHours")
+//	// Returns: d >= latest - duration({hours: $windowHours})
+func WithinHours(expression string, anchor string, hoursParam string) string {
+	return fmt.Sprintf("%s >= %s - duration({hours: $%s})", expression, anchor, hoursParam)
+}
+
+// BusinessHours returns a predicate keeping values whose hour is at least the value of startHourParam and
+// before the value of endHourParam, on weekdays only when weekdaysOnly is set. The property needs a time
+// component, so it cannot be used with DateFormatDate.
+//
+// Example:
+//
+//	BusinessHours(TimeProperty{Variable: "t", Property: "date"}, "startHour", "endHour", true)
+//	// Returns: t.date.hour >= $startHour AND t.date.hour < $endHour AND t.date.dayOfWeek <= 5
+func BusinessHours(property TimeProperty, startHourParam string, endHourParam string, weekdaysOnly bool) string {
+	expression := property.Expression()
+	conditions := []string{
+		fmt.Sprintf("%s.hour >= $%s", expression, startHourParam),
+		fmt.Sprintf("%s.hour < $%s", expression, endHourParam),
+	}
+	if weekdaysOnly {
+		conditions = append(conditions, fmt.Sprintf("%s.dayOfWeek <= 5", expression))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// ValidateDateFormat checks a date format is empty, meaning DateFormatDatetime, or a supported format.
+// Returns an error message naming field, or "" when the format is valid.
+func ValidateDateFormat(field string, format string) string {
+	switch format {
+	case "", DateFormatDatetime, DateFormatDate, DateFormatEpochMillis:
+		return ""
+	}
+	return fmt.Sprintf("invalid %s '%s', must be one of: %s, %s, %s", field, format, DateFormatDatetime, DateFormatDate, DateFormatEpochMillis)
+}
@@ -0,0 +1,96 @@
+package query_builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeProperty_Expression(t *testing.T) {
+	assert.Equal(t, "t.date", TimeProperty{Variable: "t", Property: "date"}.Expression())
+	assert.Equal(t, "t.`booked at`", TimeProperty{Variable: "t", Property: "booked at", Format: DateFormatDate}.Expression())
+	assert.Equal(t, "datetime({epochMillis: t.ts})", TimeProperty{Variable: "t", Property: "ts", Format: DateFormatEpochMillis}.Expression())
+}
+
+func TestBetweenDates(t *testing.T) {
+	tests := []struct {
+		name       string
+		property   TimeProperty
+		startParam string
+		endParam   string
+		expected   string
+	}{
+		{
+			name:       "datetime",
+			property:   TimeProperty{Variable: "t", Property: "date"},
+			startParam: "startDate",
+			endParam:   "endDate",
+			expected:   "t.date >= datetime($startDate) AND t.date <= datetime($endDate)",
+		},
+		{
+			name:       "date",
+			property:   TimeProperty{Variable: "t", Property: "date", Format: DateFormatDate},
+			startParam: "startDate",
+			endParam:   "endDate",
+			expected:   "t.date >= date($startDate) AND t.date <= date($endDate)",
+		},
+		{
+			name:       "epoch millis",
+			property:   TimeProperty{Variable: "t", Property: "ts", Format: DateFormatEpochMillis},
+			startParam: "startDate",
+			endParam:   "endDate",
+			expected:   "t.ts >= (datetime($startDate)).epochMillis AND t.ts <= (datetime($endDate)).epochMillis",
+		},
+		{
+			name:       "open end",
+			property:   TimeProperty{Variable: "t", Property: "date"},
+			startParam: "startDate",
+			expected:   "t.date >= datetime($startDate)",
+		},
+		{
+			name:     "open start",
+			property: TimeProperty{Variable: "t", Property: "date"},
+			endParam: "endDate",
+			expected: "t.date <= datetime($endDate)",
+		},
+		{
+			name:     "no bounds",
+			property: TimeProperty{Variable: "t", Property: "date"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BetweenDates(tt.property, tt.startParam, tt.endParam))
+		})
+	}
+}
+
+func TestLastDays(t *testing.T) {
+	assert.Equal(t, "t.date >= datetime() - duration({days: $windowDays})",
+		LastDays(TimeProperty{Variable: "t", Property: "date"}, "windowDays"))
+	assert.Equal(t, "t.date >= date() - duration({days: $windowDays})",
+		LastDays(TimeProperty{Variable: "t", Property: "date", Format: DateFormatDate}, "windowDays"))
+	assert.Equal(t, "t.ts >= (datetime() - duration({days: $windowDays})).epochMillis",
+		LastDays(TimeProperty{Variable: "t", Property: "ts", Format: DateFormatEpochMillis}, "windowDays"))
+}
+
+func TestWithinHours(t *testing.T) {
+	assert.Equal(t, "d >= latest - duration({hours: $windowHours})", WithinHours("d", "latest", "windowHours"))
+}
+
+func TestBusinessHours(t *testing.T) {
+	assert.Equal(t, "t.date.hour >= $startHour AND t.date.hour < $endHour AND t.date.dayOfWeek <= 5",
+		BusinessHours(TimeProperty{Variable: "t", Property: "date"}, "startHour", "endHour", true))
+	assert.Equal(t, "datetime({epochMillis: t.ts}).hour >= $startHour AND datetime({epochMillis: t.ts}).hour < $endHour",
+		BusinessHours(TimeProperty{Variable: "t", Property: "ts", Format: DateFormatEpochMillis}, "startHour", "endHour", false))
+}
+
+func TestValidateDateFormat(t *testing.T) {
+	assert.Empty(t, ValidateDateFormat("dateFormat", ""))
+	assert.Empty(t, ValidateDateFormat("dateFormat", DateFormatDatetime))
+	assert.Empty(t, ValidateDateFormat("dateFormat", DateFormatDate))
+	assert.Empty(t, ValidateDateFormat("dateFormat", DateFormatEpochMillis))
+	assert.Contains(t, ValidateDateFormat("dateFormat", "unix"), "invalid dateFormat 'unix'")
+}
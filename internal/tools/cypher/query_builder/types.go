@@ -7,7 +7,8 @@ type AttributeMapping struct {
 	// RelationshipType is the relationship type name from the schema (e.g., "HAS_EMAIL", "OWNS")
 	RelationshipType string `json:"relationshipType"`
 
-	// TargetLabel is the node label of the connected entity (e.g., "Email", "Account")
+	// TargetLabel is the node label of the connected entity (e.g., "Email", "Account"),
+	// or a label expression such as "Email|EmailAddress"
 	TargetLabel string `json:"targetLabel"`
 
 	// IdentifierProperty is the primary property containing the key identifier.
@@ -22,6 +23,47 @@ type AttributeMapping struct {
 	// IncludeProperties specifies which properties to retrieve from the target node.
 	// If empty, all properties are returned using properties() function.
 	IncludeProperties []string `json:"includeProperties,omitempty"`
+
+	// IncludeRelationshipProperties specifies which properties to retrieve from the relationship itself.
+	// They are returned under a "relationship" key of each attribute, e.g. role on BENEFICIAL_OWNER_OF or since on OWNS.
+	// If empty, no relationship properties are returned.
+	IncludeRelationshipProperties []string `json:"includeRelationshipProperties,omitempty"`
+
+	// Filters restricts the connected nodes to those whose properties match every filter.
+	// Example: [{"propertyName": "status", "operator": "=", "value": "active"}] to only return active accounts.
+	Filters []PropertyFilter `json:"filters,omitempty"`
+
+	// OrderBy sorts the collection by a property of the target node, optionally followed by ASC or DESC.
+	// Example: "date DESC" for the most recent transactions first.
+	OrderBy string `json:"orderBy,omitempty"`
+
+	// Limit caps the number of items in the collection, applied after OrderBy. 0 means no limit.
+	Limit int `json:"limit,omitempty"`
+
+	// Aggregations summarizes the target nodes instead of listing them: the collection becomes a map of
+	// aggregate values, e.g. {count: 42, totalAmount: 1250.5}. Applied after Filters, OrderBy and Limit.
+	Aggregations []Aggregation `json:"aggregations,omitempty"`
+
+	// Direction specifies the relationship direction from the source node: "out" (default), "in", or "both".
+	// Use "in" for attributes pointing at the source, e.g. (:Customer)-[:OWNS]->(:Account) seen from the Account.
+	Direction string `json:"direction,omitempty"`
+
+	// CollectionKey names the list of this attribute in the profile output, within its category.
+	// Defaults to the pluralized, lowercase target label (e.g., "emails" for Email, "addresses" for Address).
+	// Set it to tell apart mappings sharing a target label, e.g. "home_addresses" and "mailing_addresses".
+	CollectionKey string `json:"collectionKey,omitempty"`
+}
+
+// Aggregation defines an aggregate value computed over the target nodes of an attribute mapping.
+type Aggregation struct {
+	// Function is the aggregate function: "count", "sum", "avg", "min" or "max"
+	Function string `json:"function"`
+
+	// Property is the target node property to aggregate. Optional for count, which then counts the nodes.
+	Property string `json:"property,omitempty"`
+
+	// Alias is the key of the value in the output (e.g., "totalAmount")
+	Alias string `json:"alias"`
 }
 
 // PathSpecification defines a graph traversal path for finding related nodes.
@@ -49,7 +91,7 @@ type PropertyFilter struct {
 	PropertyName string `json:"propertyName"`
 
 	// Operator defines the comparison operator.
-	// Supported: "=", ">", "<", ">=", "<=", "CONTAINS", "STARTS WITH", "ENDS WITH", "IN"
+	// Supported: "=", "<>", ">", "<", ">=", "<=", "CONTAINS", "STARTS WITH", "ENDS WITH", "IN"
 	Operator string `json:"operator"`
 
 	// Value is the value to compare against
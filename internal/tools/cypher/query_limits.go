@@ -0,0 +1,173 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// queryLimits bounds how long a Cypher tool query may run and how many rows it returns.
+// A zero value disables the corresponding limit.
+type queryLimits struct {
+	timeout time.Duration
+	maxRows int
+}
+
+// pagedResult is returned instead of the plain record array when the result is a page of a larger one
+type pagedResult struct {
+	Records      json.RawMessage `json:"records,omitempty"` // Omitted when the rows are returned as CSV or TSV
+	Truncated    bool            `json:"truncated"`
+	Skip         int             `json:"skip"`
+	ReturnedRows int             `json:"returnedRows"`
+	TotalRows    int             `json:"totalRows"`
+	MaxRows      int             `json:"maxRows,omitempty"`
+	HasMore      bool            `json:"hasMore"`
+	NextSkip     *int            `json:"nextSkip,omitempty"` // Pass as skip to fetch the next page
+}
+
+// resolveQueryLimits applies the server defaults from deps to the limits requested by the caller
+func resolveQueryLimits(deps *tools.ToolDependencies, maxRows int, timeoutSeconds int) (queryLimits, error) {
+	if maxRows < 0 {
+		return queryLimits{}, fmt.Errorf("maxRows must be a positive number, got %d", maxRows)
+	}
+	if timeoutSeconds < 0 {
+		return queryLimits{}, fmt.Errorf("timeoutSeconds must be a positive number, got %d", timeoutSeconds)
+	}
+
+	limits := queryLimits{timeout: deps.QueryTimeout, maxRows: deps.QueryMaxRows}
+	if maxRows > 0 {
+		limits.maxRows = maxRows
+	}
+	if timeoutSeconds > 0 {
+		limits.timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	return limits, nil
+}
+
+// withTimeout returns a context that expires after the query timeout, when one is set
+func (l queryLimits) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if l.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, l.timeout)
+}
+
+// queryError rewrites timeouts into a message telling the caller how to recover
+func (l queryLimits) queryError(ctx context.Context, err error) string {
+	var neo4jErr *neo4j.Neo4jError
+	timedOut := errors.Is(ctx.Err(), context.DeadlineExceeded) ||
+		(errors.As(err, &neo4jErr) && strings.Contains(neo4jErr.Code, "TransactionTimedOut"))
+	if timedOut {
+		return fmt.Sprintf("query exceeded the timeout of %s and was aborted; narrow the query (add filters or a LIMIT) or raise timeoutSeconds", l.timeout)
+	}
+	return err.Error()
+}
+
+// pageRecords returns the records starting at skip, up to maxRows, with the paging metadata
+func (l queryLimits) pageRecords(records []*neo4j.Record, skip int) ([]*neo4j.Record, pagedResult) {
+	page := records[min(skip, len(records)):]
+	if l.maxRows > 0 && len(page) > l.maxRows {
+		page = page[:l.maxRows]
+	}
+	return page, l.pageMeta(len(records), skip, len(page))
+}
+
+// pageMeta returns the paging metadata of a page of returned rows starting at skip, in a result of total rows
+func (l queryLimits) pageMeta(total int, skip int, returned int) pagedResult {
+	hasMore := skip+returned < total
+	meta := pagedResult{
+		Truncated:    hasMore,
+		Skip:         skip,
+		ReturnedRows: returned,
+		TotalRows:    total,
+		MaxRows:      l.maxRows,
+		HasMore:      hasMore,
+	}
+	if hasMore {
+		nextSkip := skip + returned
+		meta.NextSkip = &nextSkip
+	}
+	return meta
+}
+
+// streamPage runs a read query, keeping only the page of records starting at skip, up to maxRows.
+// The other rows are counted as they stream past, so a large result is never held in memory.
+func (l queryLimits) streamPage(ctx context.Context, deps *tools.ToolDependencies, query string, params map[string]any, skip int) (*pageSink, error) {
+	sink := &pageSink{skip: skip, maxRows: l.maxRows}
+	err := deps.DBService.StreamReadQuery(ctx, query, params, func() database.RecordSink {
+		sink = &pageSink{skip: skip, maxRows: l.maxRows}
+		return sink
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sink, nil
+}
+
+// pageSink keeps the page of a streamed result starting at skip, up to maxRows, and counts all its rows
+type pageSink struct {
+	skip    int
+	maxRows int // Zero keeps every row from skip on
+	page    []*neo4j.Record
+	total   int
+	keys    []string
+}
+
+func (p *pageSink) Accept(record *neo4j.Record) error {
+	if p.total >= p.skip && (p.maxRows <= 0 || len(p.page) < p.maxRows) {
+		p.page = append(p.page, record)
+	}
+	p.total++
+	return nil
+}
+
+func (p *pageSink) Complete(keys []string) error {
+	p.keys = keys
+	return nil
+}
+
+// meta returns the paging metadata of the kept page
+func (p *pageSink) meta(l queryLimits) pagedResult {
+	return l.pageMeta(p.total, p.skip, len(p.page))
+}
+
+// formatRecords converts a page of records to JSON.
+// A truncated or skipped page is wrapped in an object with the paging metadata.
+func (l queryLimits) formatRecords(ctx context.Context, deps *tools.ToolDependencies, page []*neo4j.Record, result pagedResult) (string, error) {
+	if result.Skip == 0 && !result.Truncated {
+		return deps.DBService.Neo4jRecordsToJSON(ctx, page)
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, page)
+	if err != nil {
+		return "", err
+	}
+	result.Records = json.RawMessage(response)
+
+	paged, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format paged results: %w", err)
+	}
+	return string(paged), nil
+}
+
+// tableRecordsResult returns a page of records as CSV or TSV.
+// A truncated or skipped page is followed by a second text content with the paging metadata.
+func (l queryLimits) tableRecordsResult(ctx context.Context, deps *tools.ToolDependencies, format string, page []*neo4j.Record, result pagedResult) (*mcp.CallToolResult, error) {
+	table, err := formatTable(ctx, deps, format, page)
+	if err != nil {
+		return nil, err
+	}
+	if result.Skip == 0 && !result.Truncated {
+		return tableResult(table, nil)
+	}
+	return tableResult(table, result)
+}
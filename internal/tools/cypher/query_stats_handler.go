@@ -0,0 +1,50 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+func GetQueryStatsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetQueryStats(request, deps)
+	}
+}
+
+func handleGetQueryStats(request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("get-query-stats"))
+
+	var args GetQueryStatsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Limit < 0 {
+		errMessage := fmt.Sprintf("limit must be a positive number, got %d", args.Limit)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultSlowestQueries
+	}
+
+	response, err := json.MarshalIndent(deps.QueryStats.Report(args.Limit), "", "  ")
+	if err != nil {
+		slog.Error("error formatting query stats", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
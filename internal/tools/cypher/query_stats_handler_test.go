@@ -0,0 +1,74 @@
+package cypher_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetQueryStatsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-query-stats").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	stats := database.NewQueryStats(database.QueryStatsCapacity)
+	for i := 1; i <= 20; i++ {
+		stats.Record(database.QueryRecord{Hash: "abc", Tool: "read-cypher", DurationMs: float64(i), Rows: 1, At: time.Now()})
+	}
+
+	t.Run("returns the slowest queries and tool percentiles", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			QueryStats:       stats,
+		}
+
+		result, err := cypher.GetQueryStatsHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"limit": 3},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var report database.QueryStatsReport
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &report); err != nil {
+			t.Fatalf("Expected query stats JSON, got: %v", err)
+		}
+		if report.Recorded != 20 || len(report.Slowest) != 3 || report.Slowest[0].DurationMs != 20 {
+			t.Errorf("Expected the 3 slowest of 20 queries, got: %+v", report)
+		}
+		if len(report.PerTool) != 1 || report.PerTool[0].P90Ms != 18 {
+			t.Errorf("Expected read-cypher percentiles, got: %+v", report.PerTool)
+		}
+	})
+
+	t.Run("negative limit", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			QueryStats:       stats,
+		}
+
+		result, err := cypher.GetQueryStatsHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"limit": -1},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for negative limit")
+		}
+	})
+}
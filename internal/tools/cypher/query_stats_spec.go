@@ -0,0 +1,26 @@
+package cypher
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// defaultSlowestQueries is the number of slowest queries get-query-stats returns by default
+const defaultSlowestQueries = 10
+
+type GetQueryStatsInput struct {
+	Limit int `json:"limit,omitempty" jsonschema:"description=Optional: number of slowest queries to return. Default 10."`
+}
+
+func GetQueryStatsSpec() mcp.Tool {
+	return mcp.NewTool("get-query-stats",
+		mcp.WithDescription(`Reports statistics of the last 1000 queries run by this MCP server's tools: the slowest queries and the p50, p90 and p99 latency of each tool.
+		Queries are identified by a hash of their text, with the tool that ran them, database, duration in milliseconds and row count.
+		Use it to find which fraud tools or Cypher patterns are slow before tuning them with read-cypher explain.`),
+		mcp.WithInputSchema[GetQueryStatsInput](),
+		mcp.WithTitleAnnotation("Get Query Stats"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
@@ -2,14 +2,20 @@ package cypher
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
+// readOnlyRejection is returned for queries that are not read-only
+const readOnlyRejection = "read-cypher can only run read-only Cypher statements. For write operations (CREATE, MERGE, DELETE, SET, etc...), schema/admin commands, or PROFILE queries, use write-cypher instead."
+
 func ReadCypherHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return handleReadCypher(ctx, request, deps)
@@ -39,6 +45,7 @@ func handleReadCypher(ctx context.Context, request mcp.CallToolRequest, deps *to
 	}
 	Query := args.Query
 	Params := args.Params
+	deps = deps.ForDatabase(args.Database)
 
 	slog.Info("executing read cypher query", "query", Query)
 
@@ -58,6 +65,45 @@ func handleReadCypher(ctx context.Context, request mcp.CallToolRequest, deps *to
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
+	if err := validateParams(Query, Params); err != nil {
+		slog.Error("invalid query parameters", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args.Explain = strings.ToLower(args.Explain)
+	if args.Explain != "" && args.Explain != database.PlanModeExplain && args.Explain != database.PlanModeProfile {
+		errMessage := fmt.Sprintf("explain must be %s or %s", database.PlanModeExplain, database.PlanModeProfile)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	args.OutputMode = strings.ToLower(args.OutputMode)
+	if !isValidOutputMode(args.OutputMode) {
+		errMessage := fmt.Sprintf("outputMode must be %s, %s or %s", outputModeFull, outputModeSummary, outputModeCount)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	args.Format = strings.ToLower(args.Format)
+	if !isValidFormat(args.Format) {
+		errMessage := fmt.Sprintf("format must be %s, %s or %s", formatJSON, formatCSV, formatTSV)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	limits, err := resolveQueryLimits(deps, args.MaxRows, args.TimeoutSeconds)
+	if err != nil {
+		slog.Error("invalid query limits", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if args.Skip < 0 {
+		errMessage := fmt.Sprintf("skip must not be negative, got %d", args.Skip)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	ctx, cancel := limits.withTimeout(ctx)
+	defer cancel()
+
 	// Get queryType by pre-appending "EXPLAIN" to identify if the query is of type "r", if not raise a ToolResultError
 	queryType, err := deps.DBService.GetQueryType(ctx, Query, Params)
 	if err != nil {
@@ -66,20 +112,53 @@ func handleReadCypher(ctx context.Context, request mcp.CallToolRequest, deps *to
 	}
 
 	if queryType != neo4j.StatementTypeReadOnly { // only queryType == "r" are allowed in read-cypher
-		errMessage := "read-cypher can only run read-only Cypher statements. For write operations (CREATE, MERGE, DELETE, SET, etc...), schema/admin commands, or PROFILE queries, use write-cypher instead."
 		slog.Error("rejected non-read query", "type", queryType, "query", Query)
-		return mcp.NewToolResultError(errMessage), nil
+		return mcp.NewToolResultError(readOnlyRejection), nil
+	}
+
+	if args.Explain != "" {
+		return explainReadQuery(ctx, deps, args.Explain, Query, Params)
 	}
 
 	// Execute the Cypher query using the database service (now confirmed read-only)
-	records, err := deps.DBService.ExecuteReadQuery(ctx, Query, Params)
+	// The query also runs in a READ access mode transaction, so writes the EXPLAIN check missed are rejected by the server
+	// The records are streamed: only the returned page (or the summary sample) is kept in memory, the others are counted
+	pageLimits := limits
+	skip := args.Skip
+	if args.OutputMode == outputModeSummary || args.OutputMode == outputModeCount {
+		pageLimits.maxRows = summarySampleRows
+		skip = 0
+	}
+	sink, err := pageLimits.streamPage(ctx, deps, Query, Params, skip)
+	if database.IsAccessModeError(err) {
+		slog.Error("server rejected write in read-cypher", "query", Query, "error", err)
+		return mcp.NewToolResultError(readOnlyRejection), nil
+	}
 	if err != nil {
 		slog.Error("error executing cypher query", "error", err)
-		return mcp.NewToolResultError(err.Error()), nil
+		return mcp.NewToolResultError(limits.queryError(ctx, err)), nil
+	}
+
+	if args.OutputMode == outputModeSummary || args.OutputMode == outputModeCount {
+		response, err := summarizeRecords(ctx, deps, args.OutputMode, sink)
+		if err != nil {
+			slog.Error("error summarizing query results", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return mcp.NewToolResultText(response), nil
+	}
+
+	if isTabularFormat(args.Format) {
+		result, err := limits.tableRecordsResult(ctx, deps, args.Format, sink.page, sink.meta(limits))
+		if err != nil {
+			slog.Error("error formatting query results", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		return result, nil
 	}
 
-	// Format records to JSON
-	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	// Format the requested page of records to JSON, truncated to the row limit
+	response, err := limits.formatRecords(ctx, deps, sink.page, sink.meta(limits))
 	if err != nil {
 		slog.Error("error formatting query results", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
@@ -87,3 +166,32 @@ func handleReadCypher(ctx context.Context, request mcp.CallToolRequest, deps *to
 
 	return mcp.NewToolResultText(response), nil
 }
+
+// explainReadQuery returns the plan of a read-only query as JSON, with the total db hits when profiled
+func explainReadQuery(ctx context.Context, deps *tools.ToolDependencies, mode string, query string, params map[string]any) (*mcp.CallToolResult, error) {
+	plan, err := deps.DBService.ExplainQuery(ctx, mode, query, params)
+	if database.IsAccessModeError(err) {
+		slog.Error("server rejected write in read-cypher", "query", query, "error", err)
+		return mcp.NewToolResultError(readOnlyRejection), nil
+	}
+	if err != nil {
+		slog.Error("error explaining cypher query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response := map[string]any{
+		"mode": mode,
+		"plan": plan,
+	}
+	if mode == database.PlanModeProfile {
+		response["totalDbHits"] = plan.TotalDbHits()
+	}
+
+	planJSON, err := json.Marshal(response)
+	if err != nil {
+		slog.Error("error formatting query plan", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(planJSON)), nil
+}
@@ -2,11 +2,15 @@ package cypher_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
@@ -24,13 +28,13 @@ func TestReadCypherHandler(t *testing.T) {
 	t.Run("successful cypher execution with parameters", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), "MATCH (n:Person {name: $name}) RETURN n", map[string]any{"name": "Alice"}).
-			Return([]*neo4j.Record{}, nil)
+			StreamReadQuery(gomock.Any(), "MATCH (n:Person {name: $name}) RETURN n", map[string]any{"name": "Alice"}, gomock.Any()).
+			DoAndReturn(streamRecords([]*neo4j.Record{}, nil))
 		mockDB.EXPECT().
 			GetQueryType(gomock.Any(), "MATCH (n:Person {name: $name}) RETURN n", map[string]any{"name": "Alice"}).
 			Return(neo4j.StatementTypeReadOnly, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"n": {"name": "Alice"}}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -58,16 +62,57 @@ func TestReadCypherHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("runs against the requested database", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		fraudDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ForDatabase("fraud").
+			Return(fraudDB)
+		fraudDB.EXPECT().
+			GetQueryType(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil()).
+			Return(neo4j.StatementTypeReadOnly, nil)
+		fraudDB.EXPECT().
+			StreamReadQuery(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil(), gomock.Any()).
+			DoAndReturn(streamRecords([]*neo4j.Record{}, nil))
+		fraudDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"count(n)": 7}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.ReadCypherHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"query":    "MATCH (n) RETURN count(n)",
+					"database": "fraud",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
 	t.Run("successful cypher execution without parameters", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
 			GetQueryType(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil()).
 			Return(neo4j.StatementTypeReadOnly, nil)
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil()).
-			Return([]*neo4j.Record{}, nil)
+			StreamReadQuery(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil(), gomock.Any()).
+			DoAndReturn(streamRecords([]*neo4j.Record{}, nil))
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"count(n)": 42}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -122,7 +167,7 @@ func TestReadCypherHandler(t *testing.T) {
 
 	t.Run("missing required arguments", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
-		// The handler should NOT call ExecuteReadQuery when query is empty
+		// The handler should NOT call StreamReadQuery when query is empty
 		// No expectations set for mockDB since it shouldn't be called
 
 		deps := &tools.ToolDependencies{
@@ -152,7 +197,7 @@ func TestReadCypherHandler(t *testing.T) {
 
 	t.Run("empty query parameter", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
-		// The handler should NOT call ExecuteReadQuery when query is empty
+		// The handler should NOT call StreamReadQuery when query is empty
 		// No expectations set for mockDB since it shouldn't be called
 
 		deps := &tools.ToolDependencies{
@@ -180,6 +225,28 @@ func TestReadCypherHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("missing query parameter is rejected before running the query", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		// No expectations: the query must not reach the database
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer {id: $customerId}) RETURN c"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "$customerId") {
+			t.Errorf("Expected error naming the missing parameter, got: %+v", result)
+		}
+	})
+
 	t.Run("nil database service", func(t *testing.T) {
 		deps := &tools.ToolDependencies{
 			DBService:        nil,
@@ -228,8 +295,8 @@ func TestReadCypherHandler(t *testing.T) {
 			GetQueryType(gomock.Any(), "INVALID CYPHER", gomock.Nil()).
 			Return(neo4j.StatementTypeReadOnly, nil)
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), "INVALID CYPHER", gomock.Nil()).
-			Return(nil, errors.New("syntax error"))
+			StreamReadQuery(gomock.Any(), "INVALID CYPHER", gomock.Nil(), gomock.Any()).
+			DoAndReturn(streamRecords(nil, errors.New("syntax error")))
 
 		deps := &tools.ToolDependencies{
 			DBService:        mockDB,
@@ -261,10 +328,10 @@ func TestReadCypherHandler(t *testing.T) {
 			GetQueryType(gomock.Any(), "MATCH (n) RETURN n", gomock.Nil()).
 			Return(neo4j.StatementTypeReadOnly, nil)
 		mockDB.EXPECT().
-			ExecuteReadQuery(gomock.Any(), "MATCH (n) RETURN n", gomock.Nil()).
-			Return([]*neo4j.Record{}, nil)
+			StreamReadQuery(gomock.Any(), "MATCH (n) RETURN n", gomock.Nil(), gomock.Any()).
+			DoAndReturn(streamRecords([]*neo4j.Record{}, nil))
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return("", errors.New("JSON marshaling failed"))
 
 		deps := &tools.ToolDependencies{
@@ -350,6 +417,43 @@ func TestReadCypherHandler(t *testing.T) {
 	})
 }
 
+func TestReadCypherHandler_AccessMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("read-cypher").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("write rejected by the server is reported as a non-read query", func(t *testing.T) {
+		query := "CALL custom.flagCustomers()"
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), query, gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().
+			StreamReadQuery(gomock.Any(), query, gomock.Any(), gomock.Any()).
+			DoAndReturn(streamRecords(nil, &neo4j.Neo4jError{Code: "Neo.ClientError.Statement.AccessMode", Msg: "Writing in read access mode not allowed."}))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": query},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Fatal("Expected error result for rejected write")
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "use write-cypher instead") {
+			t.Errorf("Expected read-only rejection message, got: %s", text)
+		}
+	})
+}
+
 func TestReadCypherHandlerEvents(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -362,8 +466,8 @@ func TestReadCypherHandlerEvents(t *testing.T) {
 
 		query := "CALL gds.graph.project('myGraph', 'Node', 'REL')"
 		mockDB.EXPECT().GetQueryType(gomock.Any(), query, gomock.Nil()).Return(neo4j.StatementTypeReadOnly, nil)
-		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), query, gomock.Nil()).Return([]*neo4j.Record{}, nil)
-		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), query, gomock.Nil(), gomock.Any()).DoAndReturn(streamRecords([]*neo4j.Record{}, nil))
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil)
 
 		analyticServiceExplicitMock := analytics.NewMockService(ctrl)
 		analyticServiceExplicitMock.EXPECT().NewGDSProjCreatedEvent().Times(1)
@@ -399,8 +503,8 @@ func TestReadCypherHandlerEvents(t *testing.T) {
 
 		query := "CALL gds.graph.drop('myGraph')"
 		mockDB.EXPECT().GetQueryType(gomock.Any(), query, gomock.Nil()).Return(neo4j.StatementTypeReadOnly, nil)
-		mockDB.EXPECT().ExecuteReadQuery(gomock.Any(), query, gomock.Nil()).Return([]*neo4j.Record{}, nil)
-		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), query, gomock.Nil(), gomock.Any()).DoAndReturn(streamRecords([]*neo4j.Record{}, nil))
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil)
 
 		analyticServiceExplicitMock.EXPECT().NewGDSProjDropEvent().Times(1)
 		analyticServiceExplicitMock.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
@@ -426,3 +530,516 @@ func TestReadCypherHandlerEvents(t *testing.T) {
 		}
 	})
 }
+
+func TestReadCypherHandler_Explain(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("read-cypher").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("profile returns the plan with db hits", func(t *testing.T) {
+		scanHits, scanRows := int64(120), int64(120)
+		resultHits, resultRows := int64(0), int64(120)
+
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			GetQueryType(gomock.Any(), "MATCH (c:Customer) RETURN c", gomock.Nil()).
+			Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().
+			ExplainQuery(gomock.Any(), "profile", "MATCH (c:Customer) RETURN c", gomock.Nil()).
+			Return(&database.QueryPlan{
+				Operator: "ProduceResults",
+				DbHits:   &resultHits,
+				Rows:     &resultRows,
+				Children: []database.QueryPlan{
+					{Operator: "NodeByLabelScan", Details: "c:Customer", EstimatedRows: 120, DbHits: &scanHits, Rows: &scanRows},
+				},
+			}, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.ReadCypherHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"query":   "MATCH (c:Customer) RETURN c",
+					"explain": "PROFILE",
+				},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		var response struct {
+			Mode        string             `json:"mode"`
+			Plan        database.QueryPlan `json:"plan"`
+			TotalDbHits int64              `json:"totalDbHits"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected plan JSON, got: %v", err)
+		}
+		if response.Mode != "profile" || response.TotalDbHits != 120 {
+			t.Errorf("Expected profile with 120 db hits, got: %+v", response)
+		}
+		if len(response.Plan.Children) != 1 || response.Plan.Children[0].Operator != "NodeByLabelScan" {
+			t.Errorf("Expected NodeByLabelScan child operator, got: %+v", response.Plan)
+		}
+	})
+
+	t.Run("write queries are not profiled", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			GetQueryType(gomock.Any(), "CREATE (n:Test)", gomock.Nil()).
+			Return(neo4j.StatementTypeWriteOnly, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.ReadCypherHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"query":   "CREATE (n:Test)",
+					"explain": "profile",
+				},
+			},
+		})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for profiling a write query")
+		}
+	})
+
+	t.Run("invalid explain mode", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			DBService:        db.NewMockService(ctrl),
+			AnalyticsService: analyticsService,
+		}
+
+		handler := cypher.ReadCypherHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"query":   "MATCH (n) RETURN n",
+					"explain": "analyze",
+				},
+			},
+		})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid explain mode")
+		}
+	})
+}
+
+func TestReadCypherHandler_Limits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("read-cypher").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	rows := []*neo4j.Record{
+		{Keys: []string{"id"}, Values: []any{int64(1)}},
+		{Keys: []string{"id"}, Values: []any{int64(2)}},
+		{Keys: []string{"id"}, Values: []any{int64(3)}},
+	}
+
+	t.Run("truncates results to the server default", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, records []*neo4j.Record) (string, error) {
+				if len(records) != 2 {
+					t.Errorf("Expected 2 records to be formatted, got %d", len(records))
+				}
+				return `[{"id": 1}, {"id": 2}]`, nil
+			})
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			QueryMaxRows:     2,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var response struct {
+			Records   []map[string]any `json:"records"`
+			Truncated bool             `json:"truncated"`
+			TotalRows int              `json:"totalRows"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected truncated result JSON, got: %v", err)
+		}
+		if !response.Truncated || response.TotalRows != 3 || len(response.Records) != 2 {
+			t.Errorf("Expected 2 of 3 records marked truncated, got: %+v", response)
+		}
+	})
+
+	t.Run("maxRows overrides the server default", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), rows).Return(`[{"id": 1}, {"id": 2}, {"id": 3}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			QueryMaxRows:     2,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id", "maxRows": 10},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; text != `[{"id": 1}, {"id": 2}, {"id": 3}]` {
+			t.Errorf("Expected untruncated record array, got: %s", text)
+		}
+	})
+
+	t.Run("skip returns the following page", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), rows[1:2]).Return(`[{"id": 2}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id ORDER BY id", "skip": 1, "maxRows": 1},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var response struct {
+			Skip      int  `json:"skip"`
+			TotalRows int  `json:"totalRows"`
+			HasMore   bool `json:"hasMore"`
+			NextSkip  *int `json:"nextSkip"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected paged result JSON, got: %v", err)
+		}
+		if response.Skip != 1 || response.TotalRows != 3 || !response.HasMore || response.NextSkip == nil || *response.NextSkip != 2 {
+			t.Errorf("Expected second page with nextSkip 2, got: %+v", response)
+		}
+	})
+
+	t.Run("last page has no more rows", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), rows[2:]).Return(`[{"id": 3}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			QueryMaxRows:     2,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id ORDER BY id", "skip": 2},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"hasMore": false`) || strings.Contains(text, "nextSkip") {
+			t.Errorf("Expected final page without nextSkip, got: %s", text)
+		}
+	})
+
+	t.Run("query exceeding the timeout is aborted", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().
+			StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ string, _ map[string]any, _ func() database.RecordSink) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("Expected the query context to have a deadline")
+				}
+				<-ctx.Done()
+				return ctx.Err()
+			})
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			QueryTimeout:     time.Millisecond,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH p = (:Customer)-[*]-() RETURN p"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Fatal("Expected error result for timed out query")
+		}
+		if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, "exceeded the timeout") {
+			t.Errorf("Expected timeout message, got: %s", text)
+		}
+	})
+
+	t.Run("negative maxRows", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (n) RETURN n", "maxRows": -1},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for negative maxRows")
+		}
+	})
+}
+
+func TestReadCypherHandler_OutputMode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("read-cypher").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	rows := make([]*neo4j.Record, 0, 8)
+	for i := range 8 {
+		rows = append(rows, &neo4j.Record{Keys: []string{"id", "name"}, Values: []any{int64(i), "customer"}})
+	}
+
+	t.Run("summary returns count, columns and sample rows", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), rows[:5]).Return(`[{"id": 0}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id, c.name AS name", "outputMode": "summary"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var summary struct {
+			RowCount   int              `json:"rowCount"`
+			Columns    []string         `json:"columns"`
+			SampleRows []map[string]any `json:"sampleRows"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &summary); err != nil {
+			t.Fatalf("Expected summary JSON, got: %v", err)
+		}
+		if summary.RowCount != 8 || strings.Join(summary.Columns, ",") != "id,name" || len(summary.SampleRows) != 1 {
+			t.Errorf("Expected 8 rows with id and name columns, got: %+v", summary)
+		}
+	})
+
+	t.Run("count does not format rows", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id, c.name AS name", "outputMode": "count"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"rowCount": 8`) || strings.Contains(text, "sampleRows") {
+			t.Errorf("Expected row count without sample rows, got: %s", text)
+		}
+	})
+
+	t.Run("invalid output mode", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (n) RETURN n", "outputMode": "graph"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid output mode")
+		}
+	})
+}
+
+func TestReadCypherHandler_Format(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("read-cypher").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	rows := make([]*neo4j.Record, 0, 4)
+	for i := range 4 {
+		rows = append(rows, &neo4j.Record{Keys: []string{"id", "name"}, Values: []any{int64(i), "customer"}})
+	}
+
+	t.Run("csv returns the rows as a table", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().Neo4jRecordsToCSV(gomock.Any(), rows, ',').Return("id,name\n0,customer\n", nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id, c.name AS name", "format": "csv"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if len(result.Content) != 1 || result.Content[0].(mcp.TextContent).Text != "id,name\n0,customer\n" {
+			t.Errorf("Expected only the CSV table, got: %+v", result.Content)
+		}
+	})
+
+	t.Run("truncated tsv is followed by the paging metadata", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetQueryType(gomock.Any(), gomock.Any(), gomock.Any()).Return(neo4j.StatementTypeReadOnly, nil)
+		mockDB.EXPECT().StreamReadQuery(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(streamRecords(rows, nil))
+		mockDB.EXPECT().Neo4jRecordsToCSV(gomock.Any(), rows[:2], '\t').Return("id\tname\n0\tcustomer\n1\tcustomer\n", nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (c:Customer) RETURN c.id AS id, c.name AS name", "format": "tsv", "maxRows": 2},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if len(result.Content) != 2 {
+			t.Fatalf("Expected table and metadata contents, got: %+v", result.Content)
+		}
+
+		var meta map[string]any
+		if err := json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &meta); err != nil {
+			t.Fatalf("Expected paging metadata JSON, got: %v", err)
+		}
+		if meta["hasMore"] != true || meta["nextSkip"] != float64(2) || meta["records"] != nil {
+			t.Errorf("Expected paging metadata without records, got: %v", meta)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.ReadCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (n) RETURN n", "format": "xml"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid format")
+		}
+	})
+}
+
+// streamRecords returns a StreamReadQuery mock action passing records to the sink, or failing with err
+func streamRecords(records []*neo4j.Record, err error) func(context.Context, string, map[string]any, func() database.RecordSink) error {
+	return func(_ context.Context, _ string, _ map[string]any, newSink func() database.RecordSink) error {
+		if err != nil {
+			return err
+		}
+		sink := newSink()
+		for _, record := range records {
+			if err := sink.Accept(record); err != nil {
+				return err
+			}
+		}
+		var keys []string
+		if len(records) > 0 {
+			keys = records[0].Keys
+		}
+		return sink.Complete(keys)
+	}
+}
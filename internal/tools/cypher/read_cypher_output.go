@@ -0,0 +1,62 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// Output modes accepted by read-cypher
+const (
+	outputModeFull    = "full"
+	outputModeSummary = "summary"
+	outputModeCount   = "count"
+)
+
+// summarySampleRows is the number of rows included in a summary
+const summarySampleRows = 5
+
+// resultSummary describes the shape of a result without returning all of its rows
+type resultSummary struct {
+	Mode       string          `json:"mode"`
+	RowCount   int             `json:"rowCount"`
+	Columns    []string        `json:"columns"`
+	SampleRows json.RawMessage `json:"sampleRows,omitempty"` // Only in summary mode
+}
+
+// isValidOutputMode reports whether mode is a supported read-cypher output mode; empty means full
+func isValidOutputMode(mode string) bool {
+	switch mode {
+	case "", outputModeFull, outputModeSummary, outputModeCount:
+		return true
+	}
+	return false
+}
+
+// summarizeRecords returns the row count and columns of a streamed result, with the first rows in summary mode
+func summarizeRecords(ctx context.Context, deps *tools.ToolDependencies, mode string, sink *pageSink) (string, error) {
+	summary := resultSummary{
+		Mode:     mode,
+		RowCount: sink.total,
+		Columns:  []string{},
+	}
+	if len(sink.keys) > 0 {
+		summary.Columns = sink.keys
+	}
+
+	if mode == outputModeSummary {
+		sample, err := deps.DBService.Neo4jRecordsToJSON(ctx, sink.page)
+		if err != nil {
+			return "", err
+		}
+		summary.SampleRows = json.RawMessage(sample)
+	}
+
+	response, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format result summary: %w", err)
+	}
+	return string(response), nil
+}
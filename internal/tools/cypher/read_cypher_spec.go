@@ -5,13 +5,20 @@ import (
 )
 
 type ReadCypherInput struct {
-	Query  string `json:"query" jsonschema:"default=MATCH(n) RETURN n,description=The Cypher query to execute"`
-	Params Params `json:"params,omitempty" jsonschema:"default={},description=Parameters to pass to the Cypher query"`
+	Query          string `json:"query" jsonschema:"default=MATCH(n) RETURN n,description=The Cypher query to execute"`
+	Params         Params `json:"params,omitempty" jsonschema:"default={},description=Parameters to pass to the Cypher query"`
+	Database       string `json:"database,omitempty" jsonschema:"description=Optional: name of the database to run against (Neo4j Enterprise/Aura with multiple databases). Defaults to the configured database."`
+	Explain        string `json:"explain,omitempty" jsonschema:"enum=explain,enum=profile,description=Optional: return the execution plan as JSON instead of the results. explain plans without running the query; profile runs it and adds db hits and rows per operator."`
+	MaxRows        int    `json:"maxRows,omitempty" jsonschema:"description=Optional: maximum number of rows to return. Defaults to the server limit (NEO4J_QUERY_MAX_ROWS). Extra rows are dropped and the result is marked truncated."`
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty" jsonschema:"description=Optional: seconds the query may run before it is aborted. Defaults to the server limit (NEO4J_QUERY_TIMEOUT)."`
+	Skip           int    `json:"skip,omitempty" jsonschema:"description=Optional: number of rows to skip before the returned page. Use the nextSkip of a previous result to fetch the next page; add ORDER BY so pages are stable."`
+	OutputMode     string `json:"outputMode,omitempty" jsonschema:"enum=full,enum=summary,enum=count,description=Optional: full (default) returns the rows; summary returns the row count and column names with the first 5 rows; count returns only the row count and column names. Use summary to check the shape of a result before fetching it."`
+	Format         string `json:"format,omitempty" jsonschema:"enum=json,enum=csv,enum=tsv,description=Optional: json (default) returns the rows as a JSON array; csv or tsv return them as a table with a header row which takes far fewer tokens for wide tabular results. Paging metadata follows the table as JSON. Applies to the full output mode."`
 }
 
 func ReadCypherSpec() mcp.Tool {
 	return mcp.NewTool("read-cypher",
-		mcp.WithDescription("read-cypher can run only read-only Cypher statements. For write operations (CREATE, MERGE, DELETE, SET, etc...), schema/admin commands, or PROFILE queries, use write-cypher instead."),
+		mcp.WithDescription("read-cypher can run only read-only Cypher statements. For write operations (CREATE, MERGE, DELETE, SET, etc...), schema/admin commands, or PROFILE queries, use write-cypher instead. To see why a query is slow, set explain to \"explain\" or \"profile\" rather than prefixing the query. Results are limited by maxRows and timeoutSeconds; a result with more rows is returned as a page {records, truncated, totalRows, hasMore, nextSkip}; pass nextSkip as skip to fetch the next page. Set outputMode to \"summary\" or \"count\" to check the size and columns of a result cheaply. Set format to \"csv\" or \"tsv\" for compact tabular reports."),
 		mcp.WithInputSchema[ReadCypherInput](),
 		mcp.WithTitleAnnotation("Read Cypher"),
 		mcp.WithReadOnlyHintAnnotation(true),
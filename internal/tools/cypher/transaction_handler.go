@@ -0,0 +1,182 @@
+package cypher
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+func BeginTransactionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleBeginTransaction(ctx, request, deps)
+	}
+}
+
+func RunInTransactionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleRunInTransaction(ctx, request, deps)
+	}
+}
+
+func CommitTransactionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleEndTransaction(ctx, request, deps, "commit-transaction")
+	}
+}
+
+func RollbackTransactionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleEndTransaction(ctx, request, deps, "rollback-transaction")
+	}
+}
+
+func handleBeginTransaction(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errResult := checkTransactionDeps(deps); errResult != nil {
+		return errResult, nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("begin-transaction"))
+
+	var args BeginTransactionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	deps = deps.ForDatabase(args.Database)
+
+	id, err := deps.DBService.BeginTransaction(ctx)
+	if err != nil {
+		slog.Error("error beginning transaction", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := json.Marshal(map[string]any{
+		"transactionId":      id,
+		"idleTimeoutSeconds": int(database.TransactionIdleTimeout.Seconds()),
+	})
+	if err != nil {
+		slog.Error("error formatting transaction", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+func handleRunInTransaction(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errResult := checkTransactionDeps(deps); errResult != nil {
+		return errResult, nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("run-in-transaction"))
+
+	var args RunInTransactionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.TransactionID == "" {
+		errMessage := "transactionId parameter is required; call begin-transaction first"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	if args.Query == "" {
+		errMessage := "Query parameter is required and cannot be empty"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if err := validateParams(args.Query, args.Params); err != nil {
+		slog.Error("invalid query parameters", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	limits, err := resolveQueryLimits(deps, args.MaxRows, args.TimeoutSeconds)
+	if err != nil {
+		slog.Error("invalid query limits", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ctx, cancel := limits.withTimeout(ctx)
+	defer cancel()
+
+	slog.Info("executing cypher query in transaction", "transactionId", args.TransactionID, "query", args.Query)
+
+	records, summary, err := deps.DBService.RunInTransaction(ctx, args.TransactionID, args.Query, args.Params)
+	if err != nil {
+		slog.Error("error executing cypher query in transaction", "error", err)
+		return mcp.NewToolResultError(limits.queryError(ctx, err)), nil
+	}
+
+	response, err := formatWriteResult(ctx, deps, limits, records, summary)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// handleEndTransaction commits or rolls back a transaction, depending on the tool
+func handleEndTransaction(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies, toolName string) (*mcp.CallToolResult, error) {
+	if errResult := checkTransactionDeps(deps); errResult != nil {
+		return errResult, nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent(toolName))
+
+	var args TransactionIDInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.TransactionID == "" {
+		errMessage := "transactionId parameter is required"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	var err error
+	status := "committed"
+	if toolName == "commit-transaction" {
+		err = deps.DBService.CommitTransaction(ctx, args.TransactionID)
+	} else {
+		status = "rolled back"
+		err = deps.DBService.RollbackTransaction(ctx, args.TransactionID)
+	}
+	if err != nil {
+		slog.Error("error ending transaction", "tool", toolName, "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := json.Marshal(map[string]any{
+		"transactionId": args.TransactionID,
+		"status":        status,
+	})
+	if err != nil {
+		slog.Error("error formatting transaction", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// checkTransactionDeps returns an error result when the services the transaction tools need are missing
+func checkTransactionDeps(deps *tools.ToolDependencies) *mcp.CallToolResult {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage)
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage)
+	}
+	return nil
+}
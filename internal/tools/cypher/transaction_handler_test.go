@@ -0,0 +1,187 @@
+package cypher_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransactionHandlers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent(gomock.Any()).AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("begin, run and commit", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().BeginTransaction(gomock.Any()).Return("tx-1", nil),
+			mockDB.EXPECT().
+				RunInTransaction(gomock.Any(), "tx-1", "CREATE (c:Case {id: $id})", map[string]any{"id": "CASE-1"}).
+				Return([]*neo4j.Record{}, &database.WriteSummary{ContainsUpdates: true, NodesCreated: 1}, nil),
+			mockDB.EXPECT().CommitTransaction(gomock.Any(), "tx-1").Return(nil),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.BeginTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected begin to succeed, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"transactionId":"tx-1"`) {
+			t.Errorf("Expected transaction ID, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+
+		result, err = cypher.RunInTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"transactionId": "tx-1",
+					"query":         "CREATE (c:Case {id: $id})",
+					"params":        map[string]any{"id": "CASE-1"},
+				},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected run to succeed, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"nodesCreated": 1`) {
+			t.Errorf("Expected write summary, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+
+		result, err = cypher.CommitTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"transactionId": "tx-1"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected commit to succeed, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"status":"committed"`) {
+			t.Errorf("Expected committed status, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("begin on another database", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		fraudDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().ForDatabase("fraud").Return(fraudDB)
+		fraudDB.EXPECT().BeginTransaction(gomock.Any()).Return("tx-2", nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.BeginTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"database": "fraud"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected begin to succeed, got: %v", err)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().RollbackTransaction(gomock.Any(), "tx-1").Return(nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.RollbackTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"transactionId": "tx-1"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected rollback to succeed, got: %v", err)
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"status":"rolled back"`) {
+			t.Errorf("Expected rolled back status, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			RunInTransaction(gomock.Any(), "expired", gomock.Any(), gomock.Any()).
+			Return(nil, nil, database.ErrTransactionNotFound)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.RunInTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"transactionId": "expired", "query": "MATCH (n) RETURN n"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for unknown transaction")
+		}
+	})
+
+	t.Run("failing commit", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().CommitTransaction(gomock.Any(), "tx-1").Return(errors.New("constraint violation"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.CommitTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"transactionId": "tx-1"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for failing commit")
+		}
+	})
+
+	t.Run("missing transaction ID", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.RunInTransactionHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "MATCH (n) RETURN n"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing transaction ID")
+		}
+	})
+}
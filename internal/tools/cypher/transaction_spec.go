@@ -0,0 +1,72 @@
+package cypher
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type BeginTransactionInput struct {
+	Database string `json:"database,omitempty" jsonschema:"description=Optional: name of the database to run against (Neo4j Enterprise/Aura with multiple databases). Defaults to the configured database."`
+}
+
+type RunInTransactionInput struct {
+	TransactionID  string `json:"transactionId" jsonschema:"description=The ID returned by begin-transaction"`
+	Query          string `json:"query" jsonschema:"description=The Cypher statement to run in the transaction"`
+	Params         Params `json:"params,omitempty" jsonschema:"default={},description=Parameters to pass to the Cypher statement"`
+	MaxRows        int    `json:"maxRows,omitempty" jsonschema:"description=Optional: maximum number of rows to return. Defaults to the server limit (NEO4J_QUERY_MAX_ROWS)."`
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty" jsonschema:"description=Optional: seconds the statement may run. Defaults to the server limit (NEO4J_QUERY_TIMEOUT)."`
+}
+
+type TransactionIDInput struct {
+	TransactionID string `json:"transactionId" jsonschema:"description=The ID returned by begin-transaction"`
+}
+
+func BeginTransactionSpec() mcp.Tool {
+	return mcp.NewTool("begin-transaction",
+		mcp.WithDescription(`Opens an explicit write transaction and returns its transactionId.
+		Use it when several statements must succeed or fail together, for example creating an investigation case, linking its evidence and flagging the entities involved.
+		Run each statement with run-in-transaction, then call commit-transaction to make the changes visible or rollback-transaction to discard them.
+		A failed statement rolls the whole transaction back. Transactions idle for 5 minutes are rolled back automatically.`),
+		mcp.WithInputSchema[BeginTransactionInput](),
+		mcp.WithTitleAnnotation("Begin Transaction"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func RunInTransactionSpec() mcp.Tool {
+	return mcp.NewTool("run-in-transaction",
+		mcp.WithDescription("Runs a read or write Cypher statement inside a transaction opened with begin-transaction. Changes are only visible to other queries after commit-transaction. Returns {records, summary} like write-cypher."),
+		mcp.WithInputSchema[RunInTransactionInput](),
+		mcp.WithTitleAnnotation("Run In Transaction"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func CommitTransactionSpec() mcp.Tool {
+	return mcp.NewTool("commit-transaction",
+		mcp.WithDescription("Commits a transaction opened with begin-transaction, applying all of its statements atomically."),
+		mcp.WithInputSchema[TransactionIDInput](),
+		mcp.WithTitleAnnotation("Commit Transaction"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+func RollbackTransactionSpec() mcp.Tool {
+	return mcp.NewTool("rollback-transaction",
+		mcp.WithDescription("Rolls back a transaction opened with begin-transaction, discarding all of its statements."),
+		mcp.WithInputSchema[TransactionIDInput](),
+		mcp.WithTitleAnnotation("Rollback Transaction"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
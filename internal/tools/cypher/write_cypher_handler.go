@@ -2,11 +2,15 @@ package cypher
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 func WriteCypherHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -39,6 +43,7 @@ func handleWriteCypher(ctx context.Context, request mcp.CallToolRequest, deps *t
 
 	Query := args.Query
 	Params := args.Params
+	deps = deps.ForDatabase(args.Database)
 
 	// Validate that query is not empty
 	if Query == "" {
@@ -47,6 +52,26 @@ func handleWriteCypher(ctx context.Context, request mcp.CallToolRequest, deps *t
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
+	if err := validateParams(Query, Params); err != nil {
+		slog.Error("invalid query parameters", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args.Format = strings.ToLower(args.Format)
+	if !isValidFormat(args.Format) {
+		errMessage := fmt.Sprintf("format must be %s, %s or %s", formatJSON, formatCSV, formatTSV)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	limits, err := resolveQueryLimits(deps, args.MaxRows, args.TimeoutSeconds)
+	if err != nil {
+		slog.Error("invalid query limits", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	ctx, cancel := limits.withTimeout(ctx)
+	defer cancel()
+
 	slog.Info("executing write cypher query", "query", Query)
 
 	lowerCaseQuery := strings.ToLower(Query)
@@ -59,17 +84,124 @@ func handleWriteCypher(ctx context.Context, request mcp.CallToolRequest, deps *t
 	}
 
 	// Execute the Cypher query using the database service
-	records, err := deps.DBService.ExecuteWriteQuery(ctx, Query, Params)
+	if args.DryRun {
+		return dryRunWriteQuery(ctx, deps, limits, args.Format, Query, Params)
+	}
+
+	records, summary, err := deps.DBService.ExecuteWriteQueryWithSummary(ctx, Query, Params)
 	if err != nil {
 		slog.Error("error executing cypher query", "error", err)
+		return mcp.NewToolResultError(limits.queryError(ctx, err)), nil
+	}
+
+	result, err := writeResult(ctx, deps, limits, args.Format, records, summary, false)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return result, nil
+}
+
+// writeCypherResult is the write-cypher response: the returned rows and the counters of what the query changed
+type writeCypherResult struct {
+	Records   json.RawMessage        `json:"records,omitempty"` // Omitted when the rows are returned as CSV or TSV
+	Truncated bool                   `json:"truncated,omitempty"`
+	TotalRows int                    `json:"totalRows,omitempty"` // Set when the rows were truncated to maxRows
+	Summary   *database.WriteSummary `json:"summary,omitempty"`
+	DryRun    bool                   `json:"dryRun,omitempty"` // The changes were rolled back
+}
+
+// dryRunWriteQuery runs a write query in an explicit transaction and rolls it back, reporting what it would have changed
+func dryRunWriteQuery(ctx context.Context, deps *tools.ToolDependencies, limits queryLimits, format string, query string, params map[string]any) (*mcp.CallToolResult, error) {
+	id, err := deps.DBService.BeginTransaction(ctx)
+	if err != nil {
+		slog.Error("error beginning dry run transaction", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// A failed statement already rolls the transaction back
+	records, summary, err := deps.DBService.RunInTransaction(ctx, id, query, params)
+	if err != nil {
+		slog.Error("error executing dry run cypher query", "error", err)
+		return mcp.NewToolResultError(limits.queryError(ctx, err)), nil
+	}
+
+	// Roll back even when the tool call timed out, so the dry run never leaves changes behind
+	if err := deps.DBService.RollbackTransaction(context.WithoutCancel(ctx), id); err != nil {
+		slog.Error("error rolling back dry run transaction", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	result, err := writeResult(ctx, deps, limits, format, records, summary, true)
 	if err != nil {
 		slog.Error("error formatting query results", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(response), nil
+	return result, nil
+}
+
+// writeResult returns the write-cypher response in the requested format.
+// As CSV or TSV the rows come first, followed by the summary and truncation metadata as JSON.
+func writeResult(ctx context.Context, deps *tools.ToolDependencies, limits queryLimits, format string, records []*neo4j.Record, summary *database.WriteSummary, dryRun bool) (*mcp.CallToolResult, error) {
+	if !isTabularFormat(format) {
+		result, err := newWriteCypherResult(ctx, deps, limits, records, summary)
+		if err != nil {
+			return nil, err
+		}
+		result.DryRun = dryRun
+
+		response, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to format write results: %w", err)
+		}
+		return mcp.NewToolResultText(string(response)), nil
+	}
+
+	page, meta := limits.pageRecords(records, 0)
+	table, err := formatTable(ctx, deps, format, page)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &writeCypherResult{Summary: summary, DryRun: dryRun}
+	if meta.Truncated {
+		metadata.Truncated = true
+		metadata.TotalRows = meta.TotalRows
+	}
+	return tableResult(table, metadata)
+}
+
+// newWriteCypherResult formats the records, truncated to the row limit, together with the write summary
+func newWriteCypherResult(ctx context.Context, deps *tools.ToolDependencies, limits queryLimits, records []*neo4j.Record, summary *database.WriteSummary) (*writeCypherResult, error) {
+	page, meta := limits.pageRecords(records, 0)
+	formatted, err := deps.DBService.Neo4jRecordsToJSON(ctx, page)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &writeCypherResult{
+		Records: json.RawMessage(formatted),
+		Summary: summary,
+	}
+	if meta.Truncated {
+		result.Truncated = true
+		result.TotalRows = meta.TotalRows
+	}
+	return result, nil
+}
+
+// formatWriteResult returns the write-cypher response as JSON
+func formatWriteResult(ctx context.Context, deps *tools.ToolDependencies, limits queryLimits, records []*neo4j.Record, summary *database.WriteSummary) (string, error) {
+	result, err := newWriteCypherResult(ctx, deps, limits, records, summary)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format write results: %w", err)
+	}
+	return string(response), nil
 }
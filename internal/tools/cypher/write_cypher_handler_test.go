@@ -2,11 +2,13 @@ package cypher_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
@@ -24,10 +26,10 @@ func TestWriteCypherHandler(t *testing.T) {
 	t.Run("successful cypher execution with parameters", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			ExecuteWriteQuery(gomock.Any(), "MATCH (n:Person {name: $name}) RETURN n", map[string]any{"name": "Alice"}).
-			Return([]*neo4j.Record{}, nil)
+			ExecuteWriteQueryWithSummary(gomock.Any(), "MATCH (n:Person {name: $name}) RETURN n", map[string]any{"name": "Alice"}).
+			Return([]*neo4j.Record{}, nil, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"n": {"name": "Alice"}}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -58,10 +60,10 @@ func TestWriteCypherHandler(t *testing.T) {
 	t.Run("successful cypher execution without parameters", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			ExecuteWriteQuery(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil()).
-			Return([]*neo4j.Record{}, nil)
+			ExecuteWriteQueryWithSummary(gomock.Any(), "MATCH (n) RETURN count(n)", gomock.Nil()).
+			Return([]*neo4j.Record{}, nil, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"count(n)": 42}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -88,6 +90,151 @@ func TestWriteCypherHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("returns the write summary with the records", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQueryWithSummary(gomock.Any(), "CREATE (c:Customer {id: $id})-[:HAS_ACCOUNT]->(a:Account) RETURN c.id AS id", gomock.Any()).
+			Return([]*neo4j.Record{{Keys: []string{"id"}, Values: []any{"C1"}}}, &database.WriteSummary{
+				ContainsUpdates:      true,
+				NodesCreated:         2,
+				RelationshipsCreated: 1,
+				PropertiesSet:        1,
+				LabelsAdded:          2,
+				ExecutionTimeMs:      4,
+			}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"id": "C1"}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.WriteCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"query":  "CREATE (c:Customer {id: $id})-[:HAS_ACCOUNT]->(a:Account) RETURN c.id AS id",
+					"params": map[string]any{"id": "C1"},
+				},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var response struct {
+			Records []map[string]any      `json:"records"`
+			Summary database.WriteSummary `json:"summary"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected write result JSON, got: %v", err)
+		}
+		if len(response.Records) != 1 || response.Summary.NodesCreated != 2 || response.Summary.RelationshipsCreated != 1 || !response.Summary.ContainsUpdates {
+			t.Errorf("Expected record and write counters, got: %+v", response)
+		}
+	})
+
+	t.Run("csv returns the records as a table followed by the summary", func(t *testing.T) {
+		records := []*neo4j.Record{{Keys: []string{"id"}, Values: []any{"C1"}}}
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQueryWithSummary(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(records, &database.WriteSummary{ContainsUpdates: true, NodesCreated: 1}, nil)
+		mockDB.EXPECT().Neo4jRecordsToCSV(gomock.Any(), records, ',').Return("id\nC1\n", nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.WriteCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "CREATE (c:Customer {id: 'C1'}) RETURN c.id AS id", "format": "csv"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+		if len(result.Content) != 2 || result.Content[0].(mcp.TextContent).Text != "id\nC1\n" {
+			t.Fatalf("Expected CSV table and summary contents, got: %+v", result.Content)
+		}
+
+		var response struct {
+			Records json.RawMessage       `json:"records"`
+			Summary database.WriteSummary `json:"summary"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[1].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected write summary JSON, got: %v", err)
+		}
+		if response.Records != nil || response.Summary.NodesCreated != 1 {
+			t.Errorf("Expected summary without records, got: %+v", response)
+		}
+	})
+
+	t.Run("dry run rolls the write back", func(t *testing.T) {
+		query := "MATCH (c:Customer) WHERE c.riskScore > 80 SET c.underReview = true RETURN count(c) AS flagged"
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().BeginTransaction(gomock.Any()).Return("tx-1", nil),
+			mockDB.EXPECT().
+				RunInTransaction(gomock.Any(), "tx-1", query, gomock.Nil()).
+				Return([]*neo4j.Record{{Keys: []string{"flagged"}, Values: []any{int64(12)}}}, &database.WriteSummary{ContainsUpdates: true, PropertiesSet: 12}, nil),
+			mockDB.EXPECT().RollbackTransaction(gomock.Any(), "tx-1").Return(nil),
+		)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return(`[{"flagged": 12}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.WriteCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": query, "dryRun": true},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var response struct {
+			DryRun  bool                  `json:"dryRun"`
+			Summary database.WriteSummary `json:"summary"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected write result JSON, got: %v", err)
+		}
+		if !response.DryRun || response.Summary.PropertiesSet != 12 {
+			t.Errorf("Expected dry run with 12 properties set, got: %+v", response)
+		}
+	})
+
+	t.Run("failed dry run is reported", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().BeginTransaction(gomock.Any()).Return("tx-1", nil)
+		mockDB.EXPECT().
+			RunInTransaction(gomock.Any(), "tx-1", gomock.Any(), gomock.Any()).
+			Return(nil, nil, errors.New("statement failed and the transaction was rolled back: syntax error"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		result, err := cypher.WriteCypherHandler(deps)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"query": "SET", "dryRun": true},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for failed dry run")
+		}
+	})
+
 	t.Run("invalid arguments binding", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 
@@ -116,7 +263,7 @@ func TestWriteCypherHandler(t *testing.T) {
 
 	t.Run("missing required arguments", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
-		// The handler should NOT call ExecuteWriteQuery when query is empty
+		// The handler should NOT call ExecuteWriteQueryWithSummary when query is empty
 		// No expectations set for mockDB since it shouldn't be called
 
 		deps := &tools.ToolDependencies{
@@ -146,7 +293,7 @@ func TestWriteCypherHandler(t *testing.T) {
 
 	t.Run("empty query parameter", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
-		// The handler should NOT call ExecuteWriteQuery when query is empty
+		// The handler should NOT call ExecuteWriteQueryWithSummary when query is empty
 		// No expectations set for mockDB since it shouldn't be called
 
 		deps := &tools.ToolDependencies{
@@ -219,8 +366,8 @@ func TestWriteCypherHandler(t *testing.T) {
 	t.Run("database query execution failure", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			ExecuteWriteQuery(gomock.Any(), "INVALID CYPHER", gomock.Nil()).
-			Return(nil, errors.New("syntax error"))
+			ExecuteWriteQueryWithSummary(gomock.Any(), "INVALID CYPHER", gomock.Nil()).
+			Return(nil, nil, errors.New("syntax error"))
 
 		deps := &tools.ToolDependencies{
 			DBService:        mockDB,
@@ -249,10 +396,10 @@ func TestWriteCypherHandler(t *testing.T) {
 	t.Run("JSON formatting failure", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 		mockDB.EXPECT().
-			ExecuteWriteQuery(gomock.Any(), "MATCH (n) RETURN n", gomock.Nil()).
-			Return([]*neo4j.Record{}, nil)
+			ExecuteWriteQueryWithSummary(gomock.Any(), "MATCH (n) RETURN n", gomock.Nil()).
+			Return([]*neo4j.Record{}, nil, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return("", errors.New("JSON marshaling failed"))
 
 		deps := &tools.ToolDependencies{
@@ -291,8 +438,8 @@ func TestWriteCypherHandlerEvents(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 
 		query := "CALL gds.graph.project('myGraph', 'Node', 'REL')"
-		mockDB.EXPECT().ExecuteWriteQuery(gomock.Any(), query, gomock.Nil()).Return([]*neo4j.Record{}, nil)
-		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+		mockDB.EXPECT().ExecuteWriteQueryWithSummary(gomock.Any(), query, gomock.Nil()).Return([]*neo4j.Record{}, nil, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil)
 
 		analyticServiceExplicitMock := analytics.NewMockService(ctrl)
 		analyticServiceExplicitMock.EXPECT().NewGDSProjCreatedEvent().Times(1)
@@ -327,8 +474,8 @@ func TestWriteCypherHandlerEvents(t *testing.T) {
 		analyticServiceExplicitMock := analytics.NewMockService(ctrl)
 
 		query := "CALL gds.graph.drop('myGraph')"
-		mockDB.EXPECT().ExecuteWriteQuery(gomock.Any(), query, gomock.Nil()).Return([]*neo4j.Record{}, nil)
-		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any()).Return("[]", nil)
+		mockDB.EXPECT().ExecuteWriteQueryWithSummary(gomock.Any(), query, gomock.Nil()).Return([]*neo4j.Record{}, nil, nil)
+		mockDB.EXPECT().Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).Return("[]", nil)
 
 		analyticServiceExplicitMock.EXPECT().NewGDSProjDropEvent().Times(1)
 		analyticServiceExplicitMock.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
@@ -5,13 +5,18 @@ import (
 )
 
 type WriteCypherInput struct {
-	Query  string `json:"query" jsonschema:"default=MATCH(n) RETURN n,description=The Cypher query to execute"`
-	Params Params `json:"params,omitempty" jsonschema:"default={},description=Parameters to pass to the Cypher query"`
+	Query          string `json:"query" jsonschema:"default=MATCH(n) RETURN n,description=The Cypher query to execute"`
+	Params         Params `json:"params,omitempty" jsonschema:"default={},description=Parameters to pass to the Cypher query"`
+	Database       string `json:"database,omitempty" jsonschema:"description=Optional: name of the database to run against (Neo4j Enterprise/Aura with multiple databases). Defaults to the configured database."`
+	MaxRows        int    `json:"maxRows,omitempty" jsonschema:"description=Optional: maximum number of rows to return. Defaults to the server limit (NEO4J_QUERY_MAX_ROWS). Extra rows are dropped and the result is marked truncated."`
+	TimeoutSeconds int    `json:"timeoutSeconds,omitempty" jsonschema:"description=Optional: seconds the query may run before it is aborted. Defaults to the server limit (NEO4J_QUERY_TIMEOUT)."`
+	DryRun         bool   `json:"dryRun,omitempty" jsonschema:"description=Optional: run the query in a transaction that is rolled back. Returns the records and write summary it would produce without changing the database. Use it to preview bulk changes before running them."`
+	Format         string `json:"format,omitempty" jsonschema:"enum=json,enum=csv,enum=tsv,description=Optional: json (default) returns {records and summary}; csv or tsv return the records as a table with a header row followed by the summary as JSON."`
 }
 
 func WriteCypherSpec() mcp.Tool {
 	return mcp.NewTool("write-cypher",
-		mcp.WithDescription("write-cypher executes any arbitrary Cypher query, with write access, against the user-configured Neo4j database."),
+		mcp.WithDescription("write-cypher executes any arbitrary Cypher query, with write access, against the user-configured Neo4j database. Returns {records, summary}, where summary counts the nodes, relationships, properties and labels the query changed and its execution time. Results are limited by maxRows and timeoutSeconds; truncated records are marked with truncated and totalRows. Set dryRun to preview the effect of a write without committing it. Set format to \"csv\" or \"tsv\" for compact tabular records."),
 		mcp.WithInputSchema[WriteCypherInput](),
 		mcp.WithTitleAnnotation("Write Cypher"),
 		mcp.WithReadOnlyHintAnnotation(false),
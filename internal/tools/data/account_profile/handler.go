@@ -0,0 +1,283 @@
+package account_profile
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultBalanceHistoryLimit = 12
+	maxBalanceHistoryLimit     = 365
+)
+
+// Handler returns the tool handler function for get-account-profile
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetAccountProfile(ctx, request, deps)
+	}
+}
+
+func handleGetAccountProfile(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("get-account-profile"),
+	)
+
+	// Parse arguments
+	var args GetAccountProfileInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("retrieving account profile",
+		"accountId", args.AccountId,
+		"accountLabel", args.AccountConfig.NodeLabel,
+		"attributeMappings", len(args.AttributeMappings),
+		"balanceHistory", args.BalanceHistory != nil,
+		"transactionSummary", args.TransactionSummary != nil)
+
+	// Build dynamic Cypher query based on the configuration
+	query, filterParams := buildAccountProfileQuery(args)
+
+	params := map[string]any{
+		"entityId": args.AccountId,
+	}
+	if args.BalanceHistory != nil {
+		params["balanceHistoryLimit"] = args.BalanceHistory.Limit
+	}
+	if args.TransactionSummary != nil && args.TransactionSummary.WindowDays > 0 {
+		params["windowDays"] = args.TransactionSummary.WindowDays
+	}
+
+	maps.Copy(params, filterParams)
+
+	slog.Debug("executing account profile query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	// Execute query
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing account profile query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks the account, its attribute mappings and the enabled balance and transaction sections
+func validateInput(args *GetAccountProfileInput) string {
+	if args.AccountId == "" {
+		return "accountId parameter is required"
+	}
+	if args.AccountConfig.NodeLabel == "" {
+		return "accountConfig.nodeLabel is required. Specify the account node label (e.g., 'Account')."
+	}
+	if args.AccountConfig.IdProperty == "" {
+		return "accountConfig.idProperty is required. Specify the property name containing the unique identifier (e.g., 'accountNumber')."
+	}
+
+	for i, mapping := range args.AttributeMappings {
+		if mapping.RelationshipType == "" || mapping.TargetLabel == "" {
+			return fmt.Sprintf("attributeMappings[%d] requires relationshipType and targetLabel. Use get-schema to discover these first.", i)
+		}
+		if mapping.Direction != "" && mapping.Direction != "out" && mapping.Direction != "in" && mapping.Direction != "both" {
+			return fmt.Sprintf("attributeMappings[%d] has invalid direction '%s', must be one of: out, in, both", i, mapping.Direction)
+		}
+	}
+	if errMessage := query_builder.ValidateCollectionKeys(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeFilters(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeOrdering(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeAggregations(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeIdentifiers(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+
+	if balance := args.BalanceHistory; balance != nil {
+		if balance.RelationshipType == "" || balance.TargetLabel == "" {
+			return "balanceHistory.relationshipType and balanceHistory.targetLabel are required (e.g., 'HAS_BALANCE' and 'BalanceSnapshot')."
+		}
+		if balance.DateProperty == "" || balance.BalanceProperty == "" {
+			return "balanceHistory.dateProperty and balanceHistory.balanceProperty are required (e.g., 'date' and 'balance')."
+		}
+		if balance.Limit == 0 {
+			balance.Limit = defaultBalanceHistoryLimit
+		}
+		if balance.Limit < 0 || balance.Limit > maxBalanceHistoryLimit {
+			return fmt.Sprintf("balanceHistory.limit must be between 1 and %d", maxBalanceHistoryLimit)
+		}
+	}
+
+	if summary := args.TransactionSummary; summary != nil {
+		if summary.TransactionLabel != "" {
+			if summary.PerformsRelationshipType == "" || summary.BenefitsToRelationshipType == "" {
+				return "transactionSummary.performsRelationshipType and transactionSummary.benefitsToRelationshipType are required when transactionLabel is set (e.g., 'PERFORMS' and 'BENEFITS_TO')."
+			}
+		} else if summary.TransactionRelationshipType == "" {
+			return "transactionSummary must describe the transaction model: set transactionLabel with performsRelationshipType/benefitsToRelationshipType for transaction nodes, or transactionRelationshipType for transaction relationships. Use get-schema to discover these first."
+		}
+		if summary.DateProperty == "" || summary.AmountProperty == "" {
+			return "transactionSummary.dateProperty and transactionSummary.amountProperty are required (e.g., 'date' and 'amount')."
+		}
+		if summary.WindowDays < 0 {
+			return "transactionSummary.windowDays cannot be negative"
+		}
+	}
+
+	if len(args.AttributeMappings) == 0 && args.BalanceHistory == nil && args.TransactionSummary == nil {
+		return "at least one of attributeMappings, balanceHistory or transactionSummary is required. Use get-schema to discover how accounts are connected first."
+	}
+
+	return ""
+}
+
+// buildAccountProfileQuery constructs a dynamic Cypher query based on the account configuration,
+// returning it with the parameters bound by attribute filters and limits
+func buildAccountProfileQuery(args GetAccountProfileInput) (string, map[string]any) {
+	accountConfig := args.AccountConfig
+	var queryBuilder strings.Builder
+
+	// Start with base account match using dynamic node label and ID property
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})\n", query_builder.EscapeLabelExpression(accountConfig.NodeLabel), query_builder.EscapeIdentifier(accountConfig.IdProperty)))
+
+	// Linked nodes are aggregated before the subqueries so their rows do not multiply
+	sections := query_builder.BuildProfileSections("e", accountConfig.BaseProperties, args.AttributeMappings)
+	if sections.Matches != "" {
+		queryBuilder.WriteString(sections.Matches + "\n")
+	}
+	queryBuilder.WriteString(sections.With + "\n")
+	queryBuilder.WriteString(sections.Subqueries)
+
+	if args.BalanceHistory != nil {
+		queryBuilder.WriteString(buildBalanceHistorySubquery(*args.BalanceHistory))
+	}
+
+	if args.TransactionSummary != nil {
+		queryBuilder.WriteString(buildTransactionSummarySubquery(*args.TransactionSummary, "out", "outgoingSummary"))
+		queryBuilder.WriteString(buildTransactionSummarySubquery(*args.TransactionSummary, "in", "incomingSummary"))
+	}
+
+	// Build RETURN clause from pre-aggregated variables
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString(strings.Join(sections.Entries, ",\n"))
+
+	if args.BalanceHistory != nil {
+		queryBuilder.WriteString(",\n  balance_history: balanceHistory")
+	}
+
+	if args.TransactionSummary != nil {
+		queryBuilder.WriteString(",\n  transaction_summary: {\n")
+		queryBuilder.WriteString("    incoming: incomingSummary,\n")
+		queryBuilder.WriteString("    outgoing: outgoingSummary\n")
+		queryBuilder.WriteString("  }")
+	}
+
+	queryBuilder.WriteString("\n} as accountProfile")
+
+	return queryBuilder.String(), sections.Params
+}
+
+// buildBalanceHistorySubquery returns a CALL subquery collecting the most recent balance snapshots as balanceHistory
+func buildBalanceHistorySubquery(config BalanceHistoryConfig) string {
+	var subquery strings.Builder
+
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString("  WITH e\n")
+	subquery.WriteString(fmt.Sprintf("  OPTIONAL MATCH (e)-[:%s]->(b:%s)\n", query_builder.EscapeIdentifier(config.RelationshipType), query_builder.EscapeIdentifier(config.TargetLabel)))
+	subquery.WriteString("  WITH b\n")
+	subquery.WriteString(fmt.Sprintf("  ORDER BY b.%s DESC\n", query_builder.EscapeIdentifier(config.DateProperty)))
+	subquery.WriteString("  LIMIT $balanceHistoryLimit\n")
+	subquery.WriteString(fmt.Sprintf("  RETURN collect(b{.%s, .%s}) as balanceHistory\n", query_builder.EscapeIdentifier(config.DateProperty), query_builder.EscapeIdentifier(config.BalanceProperty)))
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
+
+// buildTransactionSummarySubquery returns a CALL subquery aggregating the account's transactions
+// in a single direction into a summary map bound to alias
+func buildTransactionSummarySubquery(config TransactionSummaryConfig, direction, alias string) string {
+	var subquery strings.Builder
+
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString("  WITH e\n")
+	subquery.WriteString(fmt.Sprintf("  OPTIONAL MATCH %s\n", buildTransactionPattern(config, direction)))
+	if config.WindowDays > 0 {
+		subquery.WriteString(fmt.Sprintf("  WHERE %s\n", query_builder.LastDays(query_builder.TimeProperty{Variable: "t", Property: config.DateProperty}, "windowDays")))
+	}
+	subquery.WriteString("  RETURN {\n")
+	subquery.WriteString("    count: count(DISTINCT t),\n")
+	subquery.WriteString(fmt.Sprintf("    total: coalesce(sum(t.%s), 0),\n", query_builder.EscapeIdentifier(config.AmountProperty)))
+	subquery.WriteString(fmt.Sprintf("    average: avg(t.%s),\n", query_builder.EscapeIdentifier(config.AmountProperty)))
+	subquery.WriteString(fmt.Sprintf("    largest: max(t.%s),\n", query_builder.EscapeIdentifier(config.AmountProperty)))
+	subquery.WriteString(fmt.Sprintf("    firstDate: min(t.%s),\n", query_builder.EscapeIdentifier(config.DateProperty)))
+	subquery.WriteString(fmt.Sprintf("    lastDate: max(t.%s),\n", query_builder.EscapeIdentifier(config.DateProperty)))
+	subquery.WriteString("    counterparties: count(DISTINCT cp)\n")
+	subquery.WriteString(fmt.Sprintf("  } as %s\n", alias))
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
+
+// buildTransactionPattern builds the pattern binding the transaction (t) and counterparty (cp)
+// relative to the account (e) for a single direction
+func buildTransactionPattern(config TransactionSummaryConfig, direction string) string {
+	if config.TransactionLabel != "" {
+		// Node model: (sender)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(receiver)
+		if direction == "in" {
+			return fmt.Sprintf("(cp)-[:%s]->(t:%s)-[:%s]->(e)",
+				query_builder.EscapeIdentifier(config.PerformsRelationshipType), query_builder.EscapeIdentifier(config.TransactionLabel), query_builder.EscapeIdentifier(config.BenefitsToRelationshipType))
+		}
+		return fmt.Sprintf("(e)-[:%s]->(t:%s)-[:%s]->(cp)",
+			query_builder.EscapeIdentifier(config.PerformsRelationshipType), query_builder.EscapeIdentifier(config.TransactionLabel), query_builder.EscapeIdentifier(config.BenefitsToRelationshipType))
+	}
+
+	// Relationship model: (sender)-[t:TRANSACTION]->(receiver)
+	if direction == "in" {
+		return fmt.Sprintf("(e)<-[t:%s]-(cp)", query_builder.EscapeIdentifier(config.TransactionRelationshipType))
+	}
+	return fmt.Sprintf("(e)-[t:%s]->(cp)", query_builder.EscapeIdentifier(config.TransactionRelationshipType))
+}
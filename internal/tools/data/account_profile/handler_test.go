@@ -0,0 +1,201 @@
+package account_profile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInput() GetAccountProfileInput {
+	return GetAccountProfileInput{
+		AccountId: "ACC1001",
+		AccountConfig: AccountConfig{
+			NodeLabel:      "Account",
+			IdProperty:     "accountNumber",
+			BaseProperties: []string{"accountType", "status"},
+		},
+		AttributeMappings: []query_builder.AttributeMapping{
+			{
+				RelationshipType:   "OWNS",
+				TargetLabel:        "Customer",
+				IdentifierProperty: "customerId",
+				AttributeCategory:  "ownership",
+				IncludeProperties:  []string{"firstName", "lastName"},
+				Direction:          "in",
+			},
+			{
+				RelationshipType:   "ACCESSED_FROM",
+				TargetLabel:        "Device",
+				IdentifierProperty: "deviceId",
+				AttributeCategory:  "devices",
+			},
+		},
+	}
+}
+
+func TestBuildAccountProfileQuery_AttributeMappings(t *testing.T) {
+	args := testInput()
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildAccountProfileQuery(args)
+
+	assert.Contains(t, query, "MATCH (e:Account {accountNumber: $entityId})")
+	assert.Contains(t, query, "(e)<-[:OWNS]-")
+	assert.Contains(t, query, "(e)-[:ACCESSED_FROM]->")
+	assert.Contains(t, query, "{.customerId, .firstName, .lastName}) as ownership_customers")
+	assert.Contains(t, query, "as devices_devices")
+	assert.Contains(t, query, "accountType: e.accountType")
+	assert.Contains(t, query, "ownership: {\n    customers: ownership_customers\n  }")
+	assert.Contains(t, query, "} as accountProfile")
+	assert.NotContains(t, query, "CALL {")
+
+	// Categories are emitted in a stable order
+	assert.Less(t, strings.Index(query, "  devices: {"), strings.Index(query, "  ownership: {"))
+}
+
+func TestBuildAccountProfileQuery_AttributeFilters(t *testing.T) {
+	args := testInput()
+	args.AttributeMappings[1].Filters = []query_builder.PropertyFilter{
+		{PropertyName: "trusted", Operator: "=", Value: false},
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, params := buildAccountProfileQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:ACCESSED_FROM]->(attr0:Device) WHERE attr0.trusted = $attr0_filter0")
+	assert.Equal(t, map[string]any{"attr0_filter0": false}, params)
+}
+
+func TestBuildAccountProfileQuery_AttributeAggregations(t *testing.T) {
+	args := testInput()
+	args.AttributeMappings[1].Aggregations = []query_builder.Aggregation{
+		{Function: "count", Alias: "count"},
+		{Function: "max", Property: "lastSeen", Alias: "lastSeen"},
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildAccountProfileQuery(args)
+
+	// The summary subquery follows the aggregation of the listed attributes
+	assert.Contains(t, query, "collect(DISTINCT attr1{.customerId, .firstName, .lastName}) as ownership_customers\nCALL {\n  WITH e\n  OPTIONAL MATCH (e)-[:ACCESSED_FROM]->(attr0:Device)\n")
+	assert.Contains(t, query, "  RETURN {count: count(attr0), lastSeen: max(attr0.lastSeen)} as devices_devices\n}\n")
+	assert.Contains(t, query, "devices: {\n    devices: devices_devices\n  }")
+}
+
+func TestBuildAccountProfileQuery_BalanceHistory(t *testing.T) {
+	args := testInput()
+	args.BalanceHistory = &BalanceHistoryConfig{
+		RelationshipType: "HAS_BALANCE",
+		TargetLabel:      "BalanceSnapshot",
+		DateProperty:     "date",
+		BalanceProperty:  "balance",
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildAccountProfileQuery(args)
+
+	assert.Equal(t, defaultBalanceHistoryLimit, args.BalanceHistory.Limit)
+	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:HAS_BALANCE]->(b:BalanceSnapshot)")
+	assert.Contains(t, query, "ORDER BY b.date DESC")
+	assert.Contains(t, query, "LIMIT $balanceHistoryLimit")
+	assert.Contains(t, query, "collect(b{.date, .balance}) as balanceHistory")
+	assert.Contains(t, query, "balance_history: balanceHistory")
+}
+
+func TestBuildAccountProfileQuery_TransactionSummaryNodeModel(t *testing.T) {
+	args := testInput()
+	args.TransactionSummary = &TransactionSummaryConfig{
+		TransactionLabel:           "Transaction",
+		PerformsRelationshipType:   "PERFORMS",
+		BenefitsToRelationshipType: "BENEFITS_TO",
+		DateProperty:               "date",
+		AmountProperty:             "amount",
+		WindowDays:                 90,
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildAccountProfileQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(cp)")
+	assert.Contains(t, query, "OPTIONAL MATCH (cp)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(e)")
+	assert.Contains(t, query, "WHERE t.date >= datetime() - duration({days: $windowDays})")
+	assert.Contains(t, query, "total: coalesce(sum(t.amount), 0)")
+	assert.Contains(t, query, "} as outgoingSummary")
+	assert.Contains(t, query, "} as incomingSummary")
+	assert.Contains(t, query, "transaction_summary: {")
+}
+
+func TestBuildAccountProfileQuery_TransactionSummaryRelationshipModel(t *testing.T) {
+	args := testInput()
+	args.AttributeMappings = nil
+	args.TransactionSummary = &TransactionSummaryConfig{
+		TransactionRelationshipType: "TRANSACTION",
+		DateProperty:                "timestamp",
+		AmountProperty:              "amount",
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildAccountProfileQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH (e)-[t:TRANSACTION]->(cp)")
+	assert.Contains(t, query, "OPTIONAL MATCH (e)<-[t:TRANSACTION]-(cp)")
+	assert.NotContains(t, query, "$windowDays")
+}
+
+func TestValidateInput(t *testing.T) {
+	t.Run("requires accountId", func(t *testing.T) {
+		args := testInput()
+		args.AccountId = ""
+
+		assert.Contains(t, validateInput(&args), "accountId parameter is required")
+	})
+
+	t.Run("requires at least one section", func(t *testing.T) {
+		args := testInput()
+		args.AttributeMappings = nil
+
+		assert.Contains(t, validateInput(&args), "at least one of attributeMappings")
+	})
+
+	t.Run("rejects invalid mapping direction", func(t *testing.T) {
+		args := testInput()
+		args.AttributeMappings[0].Direction = "sideways"
+
+		assert.Contains(t, validateInput(&args), "invalid direction")
+	})
+
+	t.Run("rejects invalid filter operator", func(t *testing.T) {
+		args := testInput()
+		args.AttributeMappings[0].Filters = []query_builder.PropertyFilter{
+			{PropertyName: "status", Operator: "LIKE", Value: "active"},
+		}
+
+		assert.Contains(t, validateInput(&args), "attributeMappings[0].filters[0] has invalid operator 'LIKE'")
+	})
+
+	t.Run("rejects balance history limit above maximum", func(t *testing.T) {
+		args := testInput()
+		args.BalanceHistory = &BalanceHistoryConfig{
+			RelationshipType: "HAS_BALANCE",
+			TargetLabel:      "BalanceSnapshot",
+			DateProperty:     "date",
+			BalanceProperty:  "balance",
+			Limit:            maxBalanceHistoryLimit + 1,
+		}
+
+		assert.Contains(t, validateInput(&args), "balanceHistory.limit must be between")
+	})
+
+	t.Run("requires a transaction model", func(t *testing.T) {
+		args := testInput()
+		args.TransactionSummary = &TransactionSummaryConfig{
+			DateProperty:   "date",
+			AmountProperty: "amount",
+		}
+
+		assert.Contains(t, validateInput(&args), "transactionSummary must describe the transaction model")
+	})
+}
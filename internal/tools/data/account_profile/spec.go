@@ -0,0 +1,187 @@
+package account_profile
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+// AccountConfig defines the configuration for the account node to retrieve
+type AccountConfig struct {
+	// NodeLabel is the label of the account node (e.g., "Account", "BankAccount")
+	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the account (e.g. Account, BankAccount). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+
+	// IdProperty is the property name containing the unique identifier (e.g., "accountNumber", "accountId")
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. accountNumber, accountId)"`
+
+	// BaseProperties are the properties from the account node to include in base details.
+	// If empty, all properties will be returned using properties() function.
+	BaseProperties []string `json:"baseProperties,omitempty" jsonschema:"description=List of base properties to include (e.g. [accountType, openedDate, status, balance]). If empty, returns all properties."`
+}
+
+// BalanceHistoryConfig describes how balance snapshots are attached to the account,
+// e.g. (:Account)-[:HAS_BALANCE]->(:BalanceSnapshot {date, balance})
+type BalanceHistoryConfig struct {
+	// RelationshipType connects the account to its balance snapshots (e.g., "HAS_BALANCE")
+	RelationshipType string `json:"relationshipType" jsonschema:"description=Relationship from the account to balance snapshots (e.g. HAS_BALANCE)"`
+
+	// TargetLabel is the label of the balance snapshot nodes (e.g., "BalanceSnapshot")
+	TargetLabel string `json:"targetLabel" jsonschema:"description=Node label of balance snapshots (e.g. BalanceSnapshot)"`
+
+	// DateProperty holds the snapshot date, used to return the most recent snapshots first (e.g., "date")
+	DateProperty string `json:"dateProperty" jsonschema:"description=Property holding the snapshot date (e.g. date)"`
+
+	// BalanceProperty holds the balance amount (e.g., "balance")
+	BalanceProperty string `json:"balanceProperty" jsonschema:"description=Property holding the balance amount (e.g. balance)"`
+
+	// Limit is the number of most recent snapshots to return
+	Limit int `json:"limit,omitempty" jsonschema:"default=12,minimum=1,maximum=365,description=Number of most recent balance snapshots to return"`
+}
+
+// TransactionSummaryConfig describes how transactions are modelled so they can be aggregated.
+// Two models are supported:
+//   - Node model: (:Account)-[:PERFORMS]->(:Transaction)-[:BENEFITS_TO]->(:Account), set TransactionLabel,
+//     PerformsRelationshipType and BenefitsToRelationshipType
+//   - Relationship model: (:Account)-[:TRANSACTION]->(:Account), set TransactionRelationshipType
+type TransactionSummaryConfig struct {
+	// TransactionLabel is the label of transaction nodes (node model only, e.g., "Transaction")
+	TransactionLabel string `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction). Omit when transactions are relationships."`
+
+	// PerformsRelationshipType connects the sending account to the transaction node (node model only, e.g., "PERFORMS")
+	PerformsRelationshipType string `json:"performsRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the sending account to the transaction (e.g. PERFORMS)"`
+
+	// BenefitsToRelationshipType connects the transaction node to the receiving account (node model only, e.g., "BENEFITS_TO")
+	BenefitsToRelationshipType string `json:"benefitsToRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the receiving account (e.g. BENEFITS_TO)"`
+
+	// TransactionRelationshipType is the relationship between sending and receiving accounts (relationship model only, e.g., "TRANSACTION")
+	TransactionRelationshipType string `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: relationship from the sending to the receiving account (e.g. TRANSACTION)"`
+
+	// DateProperty holds the transaction timestamp (e.g., "date", "timestamp")
+	DateProperty string `json:"dateProperty" jsonschema:"description=Property holding the transaction datetime (e.g. date or timestamp)"`
+
+	// AmountProperty holds the transaction amount (e.g., "amount")
+	AmountProperty string `json:"amountProperty" jsonschema:"description=Property holding the transaction amount (e.g. amount)"`
+
+	// WindowDays restricts the aggregates to the last N days. If 0, all transactions are aggregated.
+	WindowDays int `json:"windowDays,omitempty" jsonschema:"minimum=0,description=Optional: only aggregate transactions from the last N days. If omitted, aggregates all transactions."`
+}
+
+// GetAccountProfileInput defines the input parameters for the get-account-profile tool
+type GetAccountProfileInput struct {
+	// AccountId is the unique identifier for the account (required)
+	AccountId string `json:"accountId" jsonschema:"description=Account ID to retrieve profile for (required)"`
+
+	// AccountConfig defines the account node configuration
+	AccountConfig AccountConfig `json:"accountConfig" jsonschema:"description=Configuration for the account node (node label, ID property, base properties)"`
+
+	// AttributeMappings defines owners, signatories, devices and other linked nodes based on the actual schema.
+	// Discovered via get-schema tool.
+	AttributeMappings []query_builder.AttributeMapping `json:"attributeMappings,omitempty" jsonschema:"description=Array of attribute mappings discovered from the schema (owners, signatories, devices). Use direction=in for relationships pointing at the account."`
+
+	// BalanceHistory configures recent balance snapshots. Optional.
+	BalanceHistory *BalanceHistoryConfig `json:"balanceHistory,omitempty" jsonschema:"description=Optional: how balance snapshots are modelled. Omit if the schema has no balance history."`
+
+	// TransactionSummary configures incoming/outgoing transaction aggregates. Optional.
+	TransactionSummary *TransactionSummaryConfig `json:"transactionSummary,omitempty" jsonschema:"description=Optional: how transactions are modelled. Omit to skip transaction aggregates."`
+
+	// PreviewQuery returns the generated query and parameters instead of executing it
+	PreviewQuery bool `json:"previewQuery,omitempty" jsonschema:"description=Optional: return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for get-account-profile
+func Spec() mcp.Tool {
+	return mcp.NewTool("get-account-profile",
+		mcp.WithDescription(`Retrieves a comprehensive account-centric profile: owners, signatories, linked devices, recent balance history and transaction aggregates.
+
+**SCHEMA-AWARE DESIGN:**
+This tool dynamically adapts to your database schema. It does NOT make assumptions about relationship names, node labels, or property names.
+It mirrors get-customer-profile, but is anchored on an account node.
+
+**REQUIRED WORKFLOW:**
+1. **Call get-schema** to discover your database structure
+2. **Analyze the Account node** to identify linked nodes (owners, signatories, devices, cards, etc.)
+3. **For each linked node**, construct an AttributeMapping with:
+   - relationshipType: The relationship name from your schema
+   - targetLabel: The connected node label from your schema
+   - identifierProperty: The property containing the key identifier
+   - attributeCategory: Logical grouping ("ownership", "signatories", "devices", ...)
+   - includeProperties: Optional list of specific properties to retrieve
+   - includeRelationshipProperties: Optional list of properties of the relationship itself (e.g. "since" on OWNS), returned under a "relationship" key of each attribute
+   - filters: Optional conditions on the connected node's properties, e.g. [{"propertyName": "status", "operator": "=", "value": "active"}]. Operators: =, <>, >, <, >=, <=, CONTAINS, STARTS WITH, ENDS WITH, IN (list value)
+   - orderBy / limit: Optional sort and cap of the list, e.g. "orderBy": "date DESC", "limit": 10 for the 10 most recent (limit up to 1000)
+   - aggregations: Optional summary returned instead of the list, e.g. [{"function": "count", "alias": "count"}, {"function": "sum", "property": "amount", "alias": "totalAmount"}]. Functions: count, sum, avg, min, max
+   - direction: "in" when the relationship points at the account (e.g. (:Customer)-[:OWNS]->(:Account)), "out" (default) otherwise
+   - collectionKey: Optional key of the list in the output (default: the pluralized label, e.g. "customers" for Customer)
+4. **Optionally configure balanceHistory** if balance snapshots are stored as nodes
+5. **Optionally configure transactionSummary** with the transaction model to get incoming/outgoing aggregates
+
+**EXAMPLE:**
+{
+  "accountId": "ACC1001",
+  "accountConfig": {
+    "nodeLabel": "Account",
+    "idProperty": "accountNumber",
+    "baseProperties": ["accountType", "openedDate", "status", "balance"]
+  },
+  "attributeMappings": [
+    {
+      "relationshipType": "OWNS",
+      "targetLabel": "Customer",
+      "identifierProperty": "customerId",
+      "attributeCategory": "ownership",
+      "includeProperties": ["firstName", "lastName"],
+      "direction": "in"
+    },
+    {
+      "relationshipType": "SIGNATORY_FOR",
+      "targetLabel": "Person",
+      "identifierProperty": "personId",
+      "attributeCategory": "signatories",
+      "direction": "in"
+    },
+    {
+      "relationshipType": "ACCESSED_FROM",
+      "targetLabel": "Device",
+      "identifierProperty": "deviceId",
+      "attributeCategory": "devices",
+      "includeProperties": ["type", "ipAddress"]
+    }
+  ],
+  "balanceHistory": {
+    "relationshipType": "HAS_BALANCE",
+    "targetLabel": "BalanceSnapshot",
+    "dateProperty": "date",
+    "balanceProperty": "balance",
+    "limit": 12
+  },
+  "transactionSummary": {
+    "transactionLabel": "Transaction",
+    "performsRelationshipType": "PERFORMS",
+    "benefitsToRelationshipType": "BENEFITS_TO",
+    "dateProperty": "date",
+    "amountProperty": "amount",
+    "windowDays": 90
+  }
+}
+
+**WHEN TO USE THIS TOOL:**
+- Gathering account details for SAR filings (account holders, signatories, activity totals)
+- Reviewing who controls an account and which devices access it
+- Spotting unusual balance swings or pass-through activity (incoming total close to outgoing total)
+
+**OUTPUT STRUCTURE:**
+- base_details: account properties (from accountConfig.baseProperties)
+- one entry per attributeCategory, e.g. ownership: {customers: [...]}, devices: {devices: [...]}
+- balance_history: most recent snapshots first, [{date, balance}] (if balanceHistory configured)
+- transaction_summary: {incoming: {...}, outgoing: {...}} with count, total, average, largest, firstDate, lastDate, counterparties (if transactionSummary configured)
+
+**IMPORTANT NOTES:**
+- This tool uses OPTIONAL MATCH, so missing relationships will return empty arrays (not errors)
+- windowDays is compared with datetime(), so the transaction date property must be stored as a DATETIME`),
+		mcp.WithInputSchema[GetAccountProfileInput](),
+		mcp.WithTitleAnnotation("Get Account Profile"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
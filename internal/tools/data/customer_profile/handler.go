@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 )
 
 // Handler returns the tool handler function for get-customer-profile
@@ -37,6 +39,12 @@ func handleGetCustomerProfile(ctx context.Context, request mcp.CallToolRequest,
 		deps.AnalyticsService.NewToolsEvent("get-customer-profile"),
 	)
 
+	// Fill in the arguments of a saved mapping
+	if err := deps.MappingStore.Apply(ctx, &request); err != nil {
+		slog.Error("error applying saved mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	// Parse arguments
 	var args GetCustomerProfileInput
 	if err := request.BindArguments(&args); err != nil {
@@ -63,35 +71,87 @@ func handleGetCustomerProfile(ctx context.Context, request mcp.CallToolRequest,
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
+	if errMessage := query_builder.ValidateLabelExpression("entityConfig.nodeLabel", args.EntityConfig.NodeLabel); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if errMessage := query_builder.ValidateIdentifier("entityConfig.idProperty", args.EntityConfig.IdProperty); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
 	if len(args.AttributeMappings) == 0 {
 		errMessage := "attributeMappings parameter is required and cannot be empty. Use get-schema to discover available attributes first."
 		slog.Error(errMessage)
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
+	if errMessage := query_builder.ValidateCollectionKeys(args.AttributeMappings); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if errMessage := query_builder.ValidateAttributeFilters(args.AttributeMappings); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if errMessage := query_builder.ValidateAttributeOrdering(args.AttributeMappings); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if errMessage := query_builder.ValidateAttributeAggregations(args.AttributeMappings); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if errMessage := query_builder.ValidateAttributeIdentifiers(args.AttributeMappings); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
 	slog.Info("retrieving entity profile",
 		"entityId", args.EntityId,
 		"entityLabel", args.EntityConfig.NodeLabel,
 		"attributeMappings", len(args.AttributeMappings))
 
-	// Build dynamic Cypher query based on attribute mappings
-	query := buildCustomerProfileQuery(args.EntityConfig, args.AttributeMappings)
-
-	params := map[string]any{
-		"entityId": args.EntityId,
+	// Each attribute category is independent of the others, so it is loaded by its own query in a parallel session.
+	// This also avoids multiplying the rows of every OPTIONAL MATCH in one query.
+	categorizedMappings := query_builder.GroupMappingsByCategory(args.AttributeMappings)
+	categories := query_builder.SortedCategories(categorizedMappings)
+
+	results := make([][]*neo4j.Record, len(categories))
+	tasks := make([]func(context.Context) error, len(categories))
+	previews := make([]tools.QueryPreview, len(categories))
+	for i, category := range categories {
+		query, params := buildCustomerProfileQuery(args.EntityConfig, categorizedMappings[category])
+		params["entityId"] = args.EntityId
+		previews[i] = tools.QueryPreview{Name: category, Query: query, Params: params}
+		slog.Debug("executing customer profile query", "category", category, "query", query)
+
+		tasks[i] = func(ctx context.Context) error {
+			records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+			results[i] = records
+			return err
+		}
 	}
 
-	slog.Debug("executing customer profile query", "query", query)
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(previews...), nil
+	}
 
-	// Execute query
-	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
-	if err != nil {
-		slog.Error("error executing customer profile query", "error", err)
-		return mcp.NewToolResultError(err.Error()), nil
+	for _, err := range tools.RunParallel(ctx, tools.MaxParallelQueries, tasks...) {
+		if err != nil {
+			slog.Error("error executing customer profile query", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
 	}
+	records := mergeProfileRecords(results)
 
 	// Format records to JSON
-	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
 	if err != nil {
 		slog.Error("error formatting query results", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
@@ -100,24 +160,49 @@ func handleGetCustomerProfile(ctx context.Context, request mcp.CallToolRequest,
 	return mcp.NewToolResultText(response), nil
 }
 
-// buildCustomerProfileQuery constructs a dynamic Cypher query based on attribute mappings
-func buildCustomerProfileQuery(entityConfig EntityConfig, mappings []query_builder.AttributeMapping) string {
+// mergeProfileRecords combines the entityProfile maps returned by the per-category queries into one record.
+// Every query returns the base details, so they are kept once. No records are returned when the entity was not found.
+func mergeProfileRecords(results [][]*neo4j.Record) []*neo4j.Record {
+	profile := make(map[string]any)
+	for _, records := range results {
+		if len(records) == 0 {
+			return []*neo4j.Record{}
+		}
+		part, _ := records[0].Get("entityProfile")
+		if partMap, ok := part.(map[string]any); ok {
+			maps.Copy(profile, partMap)
+		}
+	}
+	return []*neo4j.Record{{Keys: []string{"entityProfile"}, Values: []any{profile}}}
+}
+
+// buildCustomerProfileQuery constructs a dynamic Cypher query based on attribute mappings,
+// returning it with the parameters bound by attribute filters and limits
+func buildCustomerProfileQuery(entityConfig EntityConfig, mappings []query_builder.AttributeMapping) (string, map[string]any) {
 	var queryBuilder strings.Builder
 
 	// Start with base entity match using dynamic node label and ID property
-	queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})\n", entityConfig.NodeLabel, entityConfig.IdProperty))
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})\n", query_builder.EscapeLabelExpression(entityConfig.NodeLabel), query_builder.EscapeIdentifier(entityConfig.IdProperty)))
 
-	// Group mappings by category for organized output
+	// Group mappings by category for organized output. Categories are emitted in alphabetical order,
+	// and mappings in the order given, so the generated query is the same on every run.
 	categorizedMappings := query_builder.GroupMappingsByCategory(mappings)
+	categories := query_builder.SortedCategories(categorizedMappings)
 
-	// Build OPTIONAL MATCH clauses for each attribute
+	// Each attribute is matched and collected in its own CALL subquery, so large profiles
+	// do not multiply the matches of every attribute with each other
 	matchBuilder := query_builder.NewOptionalMatchBuilder()
+	matchBuilder.SetCollectionSubqueries(true)
 	varsByCategory := make(map[string][]string)
 
-	for category, categoryMappings := range categorizedMappings {
+	for _, category := range categories {
 		vars := make([]string, 0)
-		for _, mapping := range categoryMappings {
-			varName := matchBuilder.AddAttributeMatch("e", mapping)
+		for _, mapping := range categorizedMappings[category] {
+			// Attributes collected by a subquery are matched inside it below
+			varName := ""
+			if !matchBuilder.UsesCollectionSubquery(mapping) {
+				varName = matchBuilder.AddAttributeMatch("e", mapping)
+			}
 			vars = append(vars, varName)
 		}
 		varsByCategory[category] = vars
@@ -134,24 +219,28 @@ func buildCustomerProfileQuery(entityConfig EntityConfig, mappings []query_build
 	queryBuilder.WriteString("WITH e")
 
 	// Collect all attributes by category into pre-aggregated variables
-	collectionAliases := make(map[string]map[string]string) // category -> {collectionKey -> alias}
-	for category, categoryMappings := range categorizedMappings {
-		collectionAliases[category] = make(map[string]string)
-		for i, mapping := range categoryMappings {
-			varName := varsByCategory[category][i]
-			propMap := query_builder.BuildPropertyMap(varName, mapping)
-
-			// Build collection key based on target label (pluralized, lowercase)
-			collectionKey := strings.ToLower(mapping.TargetLabel) + "s"
+	collectionsByCategory := make(map[string][]categoryCollection)
+	var subqueries strings.Builder
+	for _, category := range categories {
+		for i, mapping := range categorizedMappings[category] {
+			// Collection key defaults to the pluralized, lowercase target label
+			collectionKey := query_builder.CollectionKey(mapping)
 
 			// Create unique alias for this collection
 			collectionAlias := fmt.Sprintf("%s_%s", strings.ReplaceAll(category, "-", "_"), collectionKey)
-			collectionAliases[category][collectionKey] = collectionAlias
+			collectionsByCategory[category] = append(collectionsByCategory[category], categoryCollection{key: collectionKey, alias: collectionAlias})
 
+			varName := varsByCategory[category][i]
+			if varName == "" {
+				subqueries.WriteString(matchBuilder.BuildCollectionSubquery("e", mapping, collectionAlias))
+				continue
+			}
+			propMap := query_builder.BuildPropertyMap(varName, mapping)
 			queryBuilder.WriteString(fmt.Sprintf(",\n     collect(DISTINCT %s) as %s", propMap, collectionAlias))
 		}
 	}
 	queryBuilder.WriteString("\n")
+	queryBuilder.WriteString(subqueries.String())
 
 	// Build RETURN clause - now only references pre-aggregated variables
 	queryBuilder.WriteString("RETURN {\n")
@@ -165,7 +254,8 @@ func buildCustomerProfileQuery(entityConfig EntityConfig, mappings []query_build
 			if i > 0 {
 				queryBuilder.WriteString(",\n")
 			}
-			queryBuilder.WriteString(fmt.Sprintf("    %s: e.%s", prop, prop))
+			escaped := query_builder.EscapeIdentifier(prop)
+			queryBuilder.WriteString(fmt.Sprintf("    %s: e.%s", escaped, escaped))
 		}
 		queryBuilder.WriteString("\n  }")
 	} else {
@@ -174,30 +264,35 @@ func buildCustomerProfileQuery(entityConfig EntityConfig, mappings []query_build
 	}
 
 	// Add collections for each category using pre-collected variables
-	for category := range categorizedMappings {
+	for _, category := range categories {
 		queryBuilder.WriteString(",\n")
-		returnClause := buildCategoryReturnClauseFromCollections(category, collectionAliases[category])
+		returnClause := buildCategoryReturnClauseFromCollections(category, collectionsByCategory[category])
 		queryBuilder.WriteString(returnClause)
 	}
 
 	queryBuilder.WriteString("\n} as entityProfile")
 
-	return queryBuilder.String()
+	return queryBuilder.String(), matchBuilder.Params()
+}
+
+// categoryCollection is a collection of a category in the RETURN map and the pre-collected variable holding it
+type categoryCollection struct {
+	key   string
+	alias string
 }
 
-// buildCategoryReturnClauseFromCollections constructs the RETURN clause using pre-collected variables
-func buildCategoryReturnClauseFromCollections(category string, collectionAliases map[string]string) string {
+// buildCategoryReturnClauseFromCollections constructs the RETURN clause using pre-collected variables,
+// keeping the collections in the order given
+func buildCategoryReturnClauseFromCollections(category string, collections []categoryCollection) string {
 	var clauseBuilder strings.Builder
 
 	clauseBuilder.WriteString(fmt.Sprintf("  %s: {\n", category))
 
-	i := 0
-	for collectionKey, alias := range collectionAliases {
+	for i, collection := range collections {
 		if i > 0 {
 			clauseBuilder.WriteString(",\n")
 		}
-		clauseBuilder.WriteString(fmt.Sprintf("    %s: %s", collectionKey, alias))
-		i++
+		clauseBuilder.WriteString(fmt.Sprintf("    %s: %s", collection.key, collection.alias))
 	}
 
 	clauseBuilder.WriteString("\n  }")
@@ -1,11 +1,20 @@
 package customer_profile
 
 import (
+	"context"
+	"encoding/json"
 	"strings"
 	"testing"
 
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
 var testEntityConfig = EntityConfig{
@@ -25,19 +34,19 @@ func TestBuildCustomerProfileQuery_BasicContactInformation(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify query structure
 	assert.Contains(t, query, "MATCH (e:Customer {customerId: $entityId})")
 	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:HAS_EMAIL]->")
 	assert.Contains(t, query, ":Email")
 	assert.Contains(t, query, "WITH e")
-	assert.Contains(t, query, "collect(DISTINCT")
+	assert.Contains(t, query, "CALL {\n  WITH e\n")
 	assert.Contains(t, query, "base_details")
 	assert.Contains(t, query, "contact_information")
 	assert.Contains(t, query, "emails:")
-	// Should use map projection syntax in WITH clause
-	assert.Contains(t, query, "attr0{.address, .verified, .createdAt}")
+	// Should use map projection syntax in the subquery collection
+	assert.Contains(t, query, "RETURN collect(attr0{.address, .verified, .createdAt}) as contact_information_emails")
 }
 
 func TestBuildCustomerProfileQuery_MultipleIdentityDocuments(t *testing.T) {
@@ -58,7 +67,7 @@ func TestBuildCustomerProfileQuery_MultipleIdentityDocuments(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify both identity documents are included
 	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:HAS_SSN]->")
@@ -85,7 +94,7 @@ func TestBuildCustomerProfileQuery_WithAccounts(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify accounts are included via AttributeMappings
 	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:OWNS]->")
@@ -113,13 +122,13 @@ func TestBuildCustomerProfileQuery_WithRelationships(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify relationships are included via AttributeMappings
 	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:BENEFICIAL_OWNER_OF]->")
 	assert.Contains(t, query, ":Entity")
 	assert.Contains(t, query, "relationships")
-	assert.Contains(t, query, "entitys:")  // Note: simple pluralization adds 's'
+	assert.Contains(t, query, "entities:")
 	// Should use map projection syntax - variable depends on order
 	assert.Contains(t, query, "{.entityId, .name, .type}")
 }
@@ -162,7 +171,7 @@ func TestBuildCustomerProfileQuery_CompleteProfile(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify all sections are present
 	assert.Contains(t, query, "base_details")
@@ -199,7 +208,7 @@ func TestBuildCustomerProfileQuery_MixedCategories(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify all categories are present
 	assert.Contains(t, query, "contact_information")
@@ -214,12 +223,12 @@ func TestBuildCustomerProfileQuery_MixedCategories(t *testing.T) {
 
 func TestBuildCategoryReturnClause(t *testing.T) {
 	// Test the new approach using pre-collected variables
-	collectionAliases := map[string]string{
-		"emails": "contact_information_emails",
-		"phones": "contact_information_phones",
+	collections := []categoryCollection{
+		{key: "emails", alias: "contact_information_emails"},
+		{key: "phones", alias: "contact_information_phones"},
 	}
 
-	clause := buildCategoryReturnClauseFromCollections("contact_information", collectionAliases)
+	clause := buildCategoryReturnClauseFromCollections("contact_information", collections)
 
 	// Verify structure
 	assert.Contains(t, clause, "contact_information: {")
@@ -232,11 +241,64 @@ func TestBuildCategoryReturnClause(t *testing.T) {
 	assert.NotContains(t, clause, "collect(")
 }
 
+func TestBuildCustomerProfileQuery_DeterministicOrder(t *testing.T) {
+	mappings := []query_builder.AttributeMapping{
+		{RelationshipType: "HAS_SSN", TargetLabel: "SSN", AttributeCategory: "identity_documents"},
+		{RelationshipType: "HAS_PHONE", TargetLabel: "Phone", AttributeCategory: "contact_information"},
+		{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", AttributeCategory: "contact_information"},
+		{RelationshipType: "HAS_PASSPORT", TargetLabel: "Passport", AttributeCategory: "identity_documents"},
+		{RelationshipType: "OWNS", TargetLabel: "Account", AttributeCategory: "account_information"},
+	}
+
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
+	for range 20 {
+		repeated, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
+		assert.Equal(t, query, repeated, "the generated query should not change between runs")
+	}
+
+	// Categories are alphabetical, collections keep the order of the mappings
+	expectedOrder := []string{
+		"account_information: {\n    accounts: account_information_accounts",
+		"contact_information: {\n    phones: contact_information_phones,\n    emails: contact_information_emails",
+		"identity_documents: {\n    ssns: identity_documents_ssns,\n    passports: identity_documents_passports",
+	}
+	lastIndex := -1
+	for _, expected := range expectedOrder {
+		index := strings.Index(query, expected)
+		assert.Greater(t, index, lastIndex, "expected %q after the previous category", expected)
+		lastIndex = index
+	}
+}
+
+func TestBuildCustomerProfileQuery_CollectionSubqueries(t *testing.T) {
+	mappings := []query_builder.AttributeMapping{
+		{
+			RelationshipType:  "HAS_EMAIL",
+			TargetLabel:       "Email",
+			AttributeCategory: "contact_information",
+		},
+		{
+			RelationshipType:  "HAS_PHONE",
+			TargetLabel:       "Phone",
+			AttributeCategory: "contact_information",
+		},
+	}
+
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
+
+	// Every attribute is collected in its own subquery, so no match runs outside one
+	assert.Equal(t, 2, strings.Count(query, "CALL {\n  WITH e\n"))
+	assert.Equal(t, 2, strings.Count(query, "  OPTIONAL MATCH"))
+	assert.NotContains(t, query, "\nOPTIONAL MATCH")
+	assert.Contains(t, query, "RETURN collect(attr0{.*}) as contact_information_emails\n}")
+	assert.Contains(t, query, "RETURN collect(attr1{.*}) as contact_information_phones\n}")
+}
+
 func TestBuildCustomerProfileQuery_NoMappings(t *testing.T) {
 	// This should not happen in practice due to validation, but test the builder behavior
 	mappings := []query_builder.AttributeMapping{}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Should still have base query structure
 	assert.Contains(t, query, "MATCH (e:Customer {customerId: $entityId})")
@@ -258,12 +320,101 @@ func TestBuildCustomerProfileQuery_AllPropertiesMode(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Should use .* map projection for all properties
 	assert.Contains(t, query, "attr0{.*}")
-	// Note: simple pluralization adds 's' -> "addresss" (Address + s)
-	assert.Contains(t, query, "addresss:")
+	assert.Contains(t, query, "addresses:")
+}
+
+func TestBuildCustomerProfileQuery_RelationshipProperties(t *testing.T) {
+	mappings := []query_builder.AttributeMapping{
+		{
+			RelationshipType:              "BENEFICIAL_OWNER_OF",
+			TargetLabel:                   "Entity",
+			IdentifierProperty:            "entityId",
+			AttributeCategory:             "relationships",
+			IncludeProperties:             []string{"name"},
+			IncludeRelationshipProperties: []string{"role", "since"},
+		},
+	}
+
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
+
+	assert.Contains(t, query, "OPTIONAL MATCH (e)-[attr0_rel:BENEFICIAL_OWNER_OF]->(attr0:Entity)")
+	assert.Contains(t, query, "  WITH DISTINCT attr0, attr0_rel\n")
+	assert.Contains(t, query, "RETURN collect(attr0{.entityId, .name, relationship: attr0_rel{.role, .since}}) as relationships_entities")
+}
+
+func TestBuildCustomerProfileQuery_OrderedCollection(t *testing.T) {
+	mappings := []query_builder.AttributeMapping{
+		{
+			RelationshipType:  "HAS_EMAIL",
+			TargetLabel:       "Email",
+			AttributeCategory: "activity",
+		},
+		{
+			RelationshipType:  "PERFORMS",
+			TargetLabel:       "Transaction",
+			AttributeCategory: "activity",
+			OrderBy:           "date DESC",
+			Limit:             10,
+		},
+	}
+
+	query, params := buildCustomerProfileQuery(testEntityConfig, mappings)
+
+	assert.Contains(t, query, "WITH e\nCALL {\n  WITH e\n  OPTIONAL MATCH (e)-[:HAS_EMAIL]->(attr0:Email)\n  WITH DISTINCT attr0\n  RETURN collect(attr0{.*}) as activity_emails\n}\nCALL {\n")
+	assert.Contains(t, query, "  OPTIONAL MATCH (e)-[:PERFORMS]->(attr1:Transaction)\n  WITH DISTINCT attr1\n  ORDER BY attr1.date DESC\n  LIMIT $attr1_limit\n")
+	assert.Contains(t, query, "  RETURN collect(attr1{.*}) as activity_transactions\n}\nRETURN {")
+	assert.Contains(t, query, "transactions: activity_transactions")
+	assert.Equal(t, map[string]any{"attr1_limit": 10}, params)
+}
+
+func TestBuildCustomerProfileQuery_CollectionKeyOverride(t *testing.T) {
+	mappings := []query_builder.AttributeMapping{
+		{
+			RelationshipType:  "HAS_HOME_ADDRESS",
+			TargetLabel:       "Address",
+			AttributeCategory: "contact_information",
+			CollectionKey:     "home_addresses",
+		},
+		{
+			RelationshipType:  "HAS_MAILING_ADDRESS",
+			TargetLabel:       "Address",
+			AttributeCategory: "contact_information",
+			CollectionKey:     "mailing_addresses",
+		},
+	}
+
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
+
+	assert.Contains(t, query, "as contact_information_home_addresses")
+	assert.Contains(t, query, "as contact_information_mailing_addresses")
+	assert.Contains(t, query, "home_addresses: contact_information_home_addresses")
+	assert.Contains(t, query, "mailing_addresses: contact_information_mailing_addresses")
+	assert.NotContains(t, query, " addresses:")
+}
+
+func TestBuildCustomerProfileQuery_LabelExpressions(t *testing.T) {
+	entityConfig := EntityConfig{
+		NodeLabel:  "Customer|Person",
+		IdProperty: "customerId",
+	}
+	mappings := []query_builder.AttributeMapping{
+		{
+			RelationshipType:   "HAS_EMAIL",
+			TargetLabel:        "Email|EmailAddress",
+			IdentifierProperty: "address",
+			AttributeCategory:  "contact_information",
+		},
+	}
+
+	query, _ := buildCustomerProfileQuery(entityConfig, mappings)
+
+	assert.Contains(t, query, "MATCH (e:Customer|Person {customerId: $entityId})")
+	assert.Contains(t, query, "OPTIONAL MATCH (e)-[:HAS_EMAIL]->(attr0:Email|EmailAddress)")
+	assert.Contains(t, query, "emails: contact_information_emails")
 }
 
 func TestBuildCustomerProfileQuery_EnsuresValidCypher(t *testing.T) {
@@ -288,7 +439,7 @@ func TestBuildCustomerProfileQuery_EnsuresValidCypher(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Verify Cypher syntax essentials
 	assert.True(t, strings.HasPrefix(query, "MATCH"))
@@ -309,7 +460,7 @@ func TestBuildCustomerProfileQuery_BaseDetailsAlwaysFirst(t *testing.T) {
 		},
 	}
 
-	query := buildCustomerProfileQuery(testEntityConfig, mappings)
+	query, _ := buildCustomerProfileQuery(testEntityConfig, mappings)
 
 	// Find RETURN clause
 	returnPos := strings.Index(query, "RETURN {")
@@ -325,3 +476,128 @@ func TestBuildCustomerProfileQuery_BaseDetailsAlwaysFirst(t *testing.T) {
 	assert.True(t, baseDetailsPos < contactInfoPos,
 		"base_details should appear before other categories in RETURN clause")
 }
+
+func TestMergeProfileRecords(t *testing.T) {
+	profileRecord := func(profile map[string]any) []*neo4j.Record {
+		return []*neo4j.Record{{Keys: []string{"entityProfile"}, Values: []any{profile}}}
+	}
+	baseDetails := map[string]any{"firstName": "Jane"}
+
+	merged := mergeProfileRecords([][]*neo4j.Record{
+		profileRecord(map[string]any{"base_details": baseDetails, "contact_information": map[string]any{"emails": []any{}}}),
+		profileRecord(map[string]any{"base_details": baseDetails, "account_information": map[string]any{"accounts": []any{}}}),
+	})
+
+	assert.Len(t, merged, 1)
+	profile, _ := merged[0].Get("entityProfile")
+	assert.Equal(t, map[string]any{
+		"base_details":        baseDetails,
+		"contact_information": map[string]any{"emails": []any{}},
+		"account_information": map[string]any{"accounts": []any{}},
+	}, profile)
+}
+
+func TestMergeProfileRecords_EntityNotFound(t *testing.T) {
+	merged := mergeProfileRecords([][]*neo4j.Record{
+		{},
+		{},
+	})
+
+	assert.Empty(t, merged)
+}
+
+func TestHandleGetCustomerProfile_PreviewQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-customer-profile").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	// No expectations: any database call fails the test
+	deps := &tools.ToolDependencies{
+		DBService:        db.NewMockService(ctrl),
+		AnalyticsService: analyticsService,
+	}
+	request := mcp.CallToolRequest{
+		Params: mcp.CallToolParams{
+			Arguments: map[string]any{
+				"entityId":     "CUS123",
+				"entityConfig": map[string]any{"nodeLabel": "Customer", "idProperty": "customerId"},
+				"attributeMappings": []map[string]any{
+					{"relationshipType": "HAS_EMAIL", "targetLabel": "Email", "attributeCategory": "contact_information"},
+					{"relationshipType": "HAS_SSN", "targetLabel": "SSN", "attributeCategory": "identity_documents"},
+				},
+				"previewQuery": true,
+			},
+		},
+	}
+
+	result, err := Handler(deps)(context.Background(), request)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var preview struct {
+		Preview bool                 `json:"preview"`
+		Queries []tools.QueryPreview `json:"queries"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &preview))
+
+	assert.True(t, preview.Preview)
+	require.Len(t, preview.Queries, 2, "one query per attribute category")
+	assert.Equal(t, "contact_information", preview.Queries[0].Name)
+	assert.Equal(t, "identity_documents", preview.Queries[1].Name)
+	for _, query := range preview.Queries {
+		assert.Contains(t, query.Query, "MATCH (e:Customer {customerId: $entityId})")
+		assert.Equal(t, "CUS123", query.Params["entityId"])
+	}
+}
+
+func TestHandleGetCustomerProfile_MappingName(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-customer-profile").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	store := tools.NewMappingStore("")
+	require.NoError(t, store.Save(context.Background(), tools.SchemaMapping{
+		Name:              "retail",
+		EntityConfig:      json.RawMessage(`{"nodeLabel": "Customer", "idProperty": "customerId"}`),
+		AttributeMappings: json.RawMessage(`[{"relationshipType": "HAS_EMAIL", "targetLabel": "Email", "attributeCategory": "contact_information"}]`),
+	}, false))
+	deps := &tools.ToolDependencies{
+		DBService:        db.NewMockService(ctrl),
+		AnalyticsService: analyticsService,
+		MappingStore:     store,
+	}
+
+	t.Run("saved mapping supplies the omitted arguments", func(t *testing.T) {
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"entityId": "CUS123", "mappingName": "retail", "previewQuery": true},
+			},
+		}
+
+		result, err := Handler(deps)(context.Background(), request)
+		require.NoError(t, err)
+		require.False(t, result.IsError, result.Content)
+
+		var preview struct {
+			Queries []tools.QueryPreview `json:"queries"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &preview))
+		require.Len(t, preview.Queries, 1)
+		assert.Equal(t, "contact_information", preview.Queries[0].Name)
+		assert.Contains(t, preview.Queries[0].Query, "MATCH (e:Customer {customerId: $entityId})")
+	})
+
+	t.Run("unknown mapping name", func(t *testing.T) {
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"entityId": "CUS123", "mappingName": "missing"},
+			},
+		}
+
+		result, err := Handler(deps)(context.Background(), request)
+		require.NoError(t, err)
+		assert.True(t, result.IsError)
+	})
+}
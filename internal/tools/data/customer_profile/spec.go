@@ -8,7 +8,7 @@ import (
 // EntityConfig defines the configuration for the entity node to retrieve
 type EntityConfig struct {
 	// NodeLabel is the label of the entity node (e.g., "Customer", "Person", "Account")
-	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the entity (e.g. Customer, Person, Account)"`
+	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the entity (e.g. Customer, Person, Account). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
 
 	// IdProperty is the property name containing the unique identifier (e.g., "customerId", "personId")
 	IdProperty string `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. customerId, personId)"`
@@ -29,6 +29,12 @@ type GetCustomerProfileInput struct {
 	// AttributeMappings defines which attributes to retrieve based on the actual schema.
 	// Discovered via get-schema tool.
 	AttributeMappings []query_builder.AttributeMapping `json:"attributeMappings" jsonschema:"description=Array of attribute mappings discovered from the schema. Use get-schema to discover these first."`
+
+	// MappingName names a saved mapping supplying entityConfig and attributeMappings when they are omitted
+	MappingName string `json:"mappingName,omitempty" jsonschema:"description=Optional: name of a mapping saved with save-schema-mapping. Supplies entityConfig and attributeMappings when they are omitted."`
+
+	// PreviewQuery returns the generated queries and parameters instead of executing them
+	PreviewQuery bool `json:"previewQuery,omitempty" jsonschema:"description=Optional: return the generated Cypher queries and their parameters without executing them, to review what the tool will run"`
 }
 
 // Spec returns the MCP tool specification for get-customer-profile
@@ -48,6 +54,12 @@ This tool dynamically adapts to your database schema. It does NOT make assumptio
    - identifierProperty: The property containing the key identifier (e.g., "address" for Email, "number" for Phone/SSN)
    - attributeCategory: Logical grouping ("contact_information", "identity_documents", "employment_details", "account_information")
    - includeProperties: Optional list of specific properties to retrieve
+   - includeRelationshipProperties: Optional list of properties of the relationship itself (e.g. "role" on BENEFICIAL_OWNER_OF, "since" on OWNS), returned under a "relationship" key of each attribute
+   - filters: Optional conditions on the connected node's properties, e.g. [{"propertyName": "status", "operator": "=", "value": "active"}]. Operators: =, <>, >, <, >=, <=, CONTAINS, STARTS WITH, ENDS WITH, IN (list value)
+   - orderBy / limit: Optional sort and cap of the list, e.g. "orderBy": "date DESC", "limit": 10 for the 10 most recent (limit up to 1000)
+   - aggregations: Optional summary returned instead of the list, e.g. [{"function": "count", "alias": "count"}, {"function": "sum", "property": "amount", "alias": "totalAmount"}]. Functions: count, sum, avg, min, max
+   - direction: Optional, "in" when the relationship points at the customer (default "out")
+   - collectionKey: Optional key of the list in the output (default: the pluralized label, e.g. "addresses" for Address). Required to tell apart two mappings to the same label in one category, e.g. "home_addresses" and "mailing_addresses"
 4. **Pass discovered mappings** to this tool's attributeMappings parameter
 
 **EXAMPLE ATTRIBUTE MAPPINGS:**
@@ -94,14 +106,16 @@ For identity attributes, accounts, and relationships:
     "targetLabel": "Account",
     "identifierProperty": "accountNumber",
     "attributeCategory": "account_information",
-    "includeProperties": ["accountType", "openedDate", "status", "balance"]
+    "includeProperties": ["accountType", "openedDate", "status", "balance"],
+    "includeRelationshipProperties": ["since"]
   },
   {
     "relationshipType": "BENEFICIAL_OWNER_OF",
     "targetLabel": "Entity",
     "identifierProperty": "entityId",
     "attributeCategory": "relationships",
-    "includeProperties": ["name", "type"]
+    "includeProperties": ["name", "type"],
+    "includeRelationshipProperties": ["role"]
   }
 ]
 
@@ -128,7 +142,8 @@ Returns a structured customer profile organized by attribute categories:
 - account_information: accounts owned by the entity (if mapped via AttributeMappings with category "account_information")
 - relationships: beneficial owners, authorized users, etc. (if mapped via AttributeMappings with category "relationships")
 
-All categories are determined by the attributeCategory field in your AttributeMappings.
+All categories are determined by the attributeCategory field in your AttributeMappings. Within a category,
+each mapping's list is keyed by its collectionKey, or the pluralized target label (Email -> emails, Entity -> entities).
 
 **EXAMPLE USAGE:**
 User: "Get the complete profile for customer CUS123 including all identity documents"
@@ -138,7 +153,9 @@ LLM: [calls get-schema, analyzes Customer relationships, constructs AttributeMap
 - This tool uses OPTIONAL MATCH, so missing relationships will return empty arrays (not errors)
 - All attribute categories are optional - only include what exists in your schema
 - The tool is generic and works for ANY Neo4j graph schema with Customer nodes
-- Not fraud-specific - suitable for KYC, compliance, analytics, and general data retrieval`),
+- Not fraud-specific - suitable for KYC, compliance, analytics, and general data retrieval
+- Set previewQuery to true to review the generated Cypher and parameters without running them
+- Once the mappings are confirmed, save them with save-schema-mapping and pass mappingName instead of repeating entityConfig and attributeMappings`),
 		mcp.WithInputSchema[GetCustomerProfileInput](),
 		mcp.WithTitleAnnotation("Get Customer Profile"),
 		mcp.WithReadOnlyHintAnnotation(true),
@@ -0,0 +1,251 @@
+package entity_network
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultMaxHops          = 2
+	maxHopsLimit            = 4
+	defaultMaxNodes         = 100
+	maxNodesLimit           = 1000
+	defaultMaxRelationships = 500
+	maxRelationshipsLimit   = 5000
+)
+
+// Handler returns the tool handler function for get-entity-network
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetEntityNetwork(ctx, request, deps)
+	}
+}
+
+func handleGetEntityNetwork(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("get-entity-network"),
+	)
+
+	// Fill in the arguments of a saved mapping
+	if err := deps.MappingStore.Apply(ctx, &request); err != nil {
+		slog.Error("error applying saved mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Parse arguments
+	var args GetEntityNetworkInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("retrieving entity network",
+		"entityId", args.EntityId,
+		"entityLabel", args.EntityConfig.NodeLabel,
+		"maxHops", args.MaxHops,
+		"direction", args.Direction,
+		"relationshipTypes", len(args.RelationshipTypes),
+		"maxNodes", args.MaxNodes)
+
+	query := buildEntityNetworkQuery(args)
+
+	params := map[string]any{
+		"entityId":         args.EntityId,
+		"maxNodes":         args.MaxNodes,
+		"maxRelationships": args.MaxRelationships,
+	}
+
+	slog.Debug("executing entity network query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	// Execute query
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing entity network query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks the entity and fills in the hop, direction and size bounds of the traversal
+func validateInput(args *GetEntityNetworkInput) string {
+	if args.EntityId == "" {
+		return "entityId parameter is required"
+	}
+	if args.EntityConfig.NodeLabel == "" {
+		return "entityConfig.nodeLabel is required. Specify the entity node label (e.g., 'Customer', 'Account')."
+	}
+	if args.EntityConfig.IdProperty == "" {
+		return "entityConfig.idProperty is required. Specify the property name containing the unique identifier (e.g., 'customerId', 'accountNumber')."
+	}
+
+	if args.MaxHops == 0 {
+		args.MaxHops = defaultMaxHops
+	}
+	if args.MaxHops < 1 || args.MaxHops > maxHopsLimit {
+		return fmt.Sprintf("maxHops must be between 1 and %d", maxHopsLimit)
+	}
+
+	if args.Direction == "" {
+		args.Direction = "both"
+	}
+	if args.Direction != "out" && args.Direction != "in" && args.Direction != "both" {
+		return fmt.Sprintf("invalid direction '%s', must be one of: out, in, both", args.Direction)
+	}
+
+	for i, selection := range args.NodeProperties {
+		if selection.Label == "" || len(selection.Properties) == 0 {
+			return fmt.Sprintf("nodeProperties[%d] requires a label and at least one property", i)
+		}
+	}
+
+	if args.MaxNodes == 0 {
+		args.MaxNodes = defaultMaxNodes
+	}
+	if args.MaxNodes < 1 || args.MaxNodes > maxNodesLimit {
+		return fmt.Sprintf("maxNodes must be between 1 and %d", maxNodesLimit)
+	}
+
+	if args.MaxRelationships == 0 {
+		args.MaxRelationships = defaultMaxRelationships
+	}
+	if args.MaxRelationships < 1 || args.MaxRelationships > maxRelationshipsLimit {
+		return fmt.Sprintf("maxRelationships must be between 1 and %d", maxRelationshipsLimit)
+	}
+
+	return ""
+}
+
+// buildEntityNetworkQuery constructs the neighbourhood extraction query.
+// Neighbours are ranked by their shortest distance to the entity, capped at $maxNodes,
+// and only relationships between the returned nodes are included.
+func buildEntityNetworkQuery(args GetEntityNetworkInput) string {
+	var queryBuilder strings.Builder
+
+	relFilter := ""
+	if len(args.RelationshipTypes) > 0 {
+		relFilter = ":" + strings.Join(query_builder.EscapeIdentifiers(args.RelationshipTypes), "|")
+	}
+
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})\n", query_builder.EscapeLabelExpression(args.EntityConfig.NodeLabel), query_builder.EscapeIdentifier(args.EntityConfig.IdProperty)))
+
+	// Expand the neighbourhood and keep the shortest distance per neighbour
+	queryBuilder.WriteString(fmt.Sprintf("OPTIONAL MATCH p = %s\n", buildExpansionPattern(args.Direction, relFilter, args.MaxHops)))
+	queryBuilder.WriteString("WHERE n <> e")
+	if len(args.IncludeLabels) > 0 {
+		labelChecks := make([]string, 0, len(args.IncludeLabels))
+		for _, label := range args.IncludeLabels {
+			labelChecks = append(labelChecks, "n:"+label)
+		}
+		queryBuilder.WriteString(" AND (" + strings.Join(labelChecks, " OR ") + ")")
+	}
+	queryBuilder.WriteString("\n")
+	queryBuilder.WriteString("WITH e, n, min(length(p)) as distance\n")
+	queryBuilder.WriteString("ORDER BY distance ASC, elementId(n) ASC\n")
+	queryBuilder.WriteString("WITH e, [row IN collect({node: n, distance: distance}) WHERE row.node IS NOT NULL] as found\n")
+
+	// Apply the node cap, the entity itself is always included
+	queryBuilder.WriteString("WITH e, size(found) > $maxNodes as truncated, [{node: e, distance: 0}] + found[0..$maxNodes] as rows\n")
+	queryBuilder.WriteString("WITH e, truncated, rows, [row IN rows | row.node] as nodes\n")
+
+	// Collect relationships between the returned nodes
+	queryBuilder.WriteString("CALL {\n")
+	queryBuilder.WriteString("  WITH nodes\n")
+	queryBuilder.WriteString("  UNWIND nodes as a\n")
+	queryBuilder.WriteString(fmt.Sprintf("  MATCH (a)-[r%s]->(b)\n", relFilter))
+	queryBuilder.WriteString("  WHERE b IN nodes\n")
+	queryBuilder.WriteString("  WITH collect(DISTINCT r) as allRelationships\n")
+	queryBuilder.WriteString("  RETURN allRelationships[0..$maxRelationships] as relationships,\n")
+	queryBuilder.WriteString("         size(allRelationships) > $maxRelationships as relationshipsTruncated\n")
+	queryBuilder.WriteString("}\n")
+
+	// Project nodes with per-label property selection
+	queryBuilder.WriteString("UNWIND rows as row\n")
+	queryBuilder.WriteString("WITH e, truncated, relationships, relationshipsTruncated, row.node as n, row.distance as distance\n")
+	queryBuilder.WriteString("WITH e, truncated, relationships, relationshipsTruncated,\n")
+	queryBuilder.WriteString(fmt.Sprintf("     collect({id: elementId(n), labels: labels(n), distance: distance, properties: %s}) as nodes\n",
+		buildPropertySelection("n", args.NodeProperties)))
+
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString("  center: elementId(e),\n")
+	queryBuilder.WriteString("  nodes: nodes,\n")
+	queryBuilder.WriteString("  relationships: [r IN relationships | {id: elementId(r), type: type(r), start: elementId(startNode(r)), end: elementId(endNode(r)), properties: properties(r)}],\n")
+	queryBuilder.WriteString("  nodeCount: size(nodes),\n")
+	queryBuilder.WriteString("  truncated: truncated,\n")
+	queryBuilder.WriteString("  relationshipsTruncated: relationshipsTruncated\n")
+	queryBuilder.WriteString("} as network")
+
+	return queryBuilder.String()
+}
+
+// buildExpansionPattern builds the variable-length pattern from the entity (e) to its neighbours (n)
+func buildExpansionPattern(direction, relFilter string, maxHops int) string {
+	rel := fmt.Sprintf("[%s*1..%d]", relFilter, maxHops)
+
+	switch direction {
+	case "out":
+		return fmt.Sprintf("(e)-%s->(n)", rel)
+	case "in":
+		return fmt.Sprintf("(e)<-%s-(n)", rel)
+	default:
+		return fmt.Sprintf("(e)-%s-(n)", rel)
+	}
+}
+
+// buildPropertySelection builds a CASE expression returning the selected properties for each configured label.
+// Nodes matching none of the selections return all properties.
+func buildPropertySelection(varName string, selections []NodePropertySelection) string {
+	if len(selections) == 0 {
+		return fmt.Sprintf("properties(%s)", varName)
+	}
+
+	var caseBuilder strings.Builder
+	caseBuilder.WriteString("CASE")
+	for _, selection := range selections {
+		projections := make([]string, 0, len(selection.Properties))
+		for _, prop := range selection.Properties {
+			projections = append(projections, "."+query_builder.EscapeIdentifier(prop))
+		}
+		caseBuilder.WriteString(fmt.Sprintf(" WHEN %s:%s THEN %s{%s}", varName, query_builder.EscapeIdentifier(selection.Label), varName, strings.Join(projections, ", ")))
+	}
+	caseBuilder.WriteString(fmt.Sprintf(" ELSE properties(%s) END", varName))
+
+	return caseBuilder.String()
+}
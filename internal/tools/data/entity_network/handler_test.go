@@ -0,0 +1,107 @@
+package entity_network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInput() GetEntityNetworkInput {
+	return GetEntityNetworkInput{
+		EntityId: "CUS123",
+		EntityConfig: EntityConfig{
+			NodeLabel:  "Customer",
+			IdProperty: "customerId",
+		},
+	}
+}
+
+func TestBuildEntityNetworkQuery_Defaults(t *testing.T) {
+	args := testInput()
+	require.Empty(t, validateInput(&args))
+
+	query := buildEntityNetworkQuery(args)
+
+	assert.Equal(t, defaultMaxHops, args.MaxHops)
+	assert.Equal(t, defaultMaxNodes, args.MaxNodes)
+	assert.Equal(t, defaultMaxRelationships, args.MaxRelationships)
+	assert.Contains(t, query, "MATCH (e:Customer {customerId: $entityId})")
+	assert.Contains(t, query, "OPTIONAL MATCH p = (e)-[*1..2]-(n)")
+	assert.Contains(t, query, "WHERE n <> e\n")
+	assert.Contains(t, query, "min(length(p)) as distance")
+	assert.Contains(t, query, "found[0..$maxNodes]")
+	assert.Contains(t, query, "MATCH (a)-[r]->(b)")
+	assert.Contains(t, query, "allRelationships[0..$maxRelationships]")
+	assert.Contains(t, query, "properties: properties(n)}) as nodes")
+	assert.Contains(t, query, "} as network")
+}
+
+func TestBuildEntityNetworkQuery_RelationshipTypesAndDirection(t *testing.T) {
+	args := testInput()
+	args.MaxHops = 3
+	args.Direction = "out"
+	args.RelationshipTypes = []string{"HAS_EMAIL", "OWNS"}
+	require.Empty(t, validateInput(&args))
+
+	query := buildEntityNetworkQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH p = (e)-[:HAS_EMAIL|OWNS*1..3]->(n)")
+	assert.Contains(t, query, "MATCH (a)-[r:HAS_EMAIL|OWNS]->(b)")
+}
+
+func TestBuildEntityNetworkQuery_IncludeLabels(t *testing.T) {
+	args := testInput()
+	args.IncludeLabels = []string{"Customer", "Device"}
+	require.Empty(t, validateInput(&args))
+
+	query := buildEntityNetworkQuery(args)
+
+	assert.Contains(t, query, "WHERE n <> e AND (n:Customer OR n:Device)")
+}
+
+func TestBuildPropertySelection(t *testing.T) {
+	selection := buildPropertySelection("n", []NodePropertySelection{
+		{Label: "Customer", Properties: []string{"customerId", "firstName"}},
+		{Label: "Email", Properties: []string{"address"}},
+	})
+
+	assert.Equal(t, "CASE WHEN n:Customer THEN n{.customerId, .firstName} WHEN n:Email THEN n{.address} ELSE properties(n) END", selection)
+}
+
+func TestValidateInput(t *testing.T) {
+	t.Run("requires entityId", func(t *testing.T) {
+		args := testInput()
+		args.EntityId = ""
+
+		assert.Contains(t, validateInput(&args), "entityId parameter is required")
+	})
+
+	t.Run("rejects maxHops above maximum", func(t *testing.T) {
+		args := testInput()
+		args.MaxHops = maxHopsLimit + 1
+
+		assert.Contains(t, validateInput(&args), "maxHops must be between")
+	})
+
+	t.Run("rejects invalid direction", func(t *testing.T) {
+		args := testInput()
+		args.Direction = "sideways"
+
+		assert.Contains(t, validateInput(&args), "invalid direction")
+	})
+
+	t.Run("rejects empty property selection", func(t *testing.T) {
+		args := testInput()
+		args.NodeProperties = []NodePropertySelection{{Label: "Customer"}}
+
+		assert.Contains(t, validateInput(&args), "nodeProperties[0] requires a label and at least one property")
+	})
+
+	t.Run("rejects maxNodes above maximum", func(t *testing.T) {
+		args := testInput()
+		args.MaxNodes = maxNodesLimit + 1
+
+		assert.Contains(t, validateInput(&args), "maxNodes must be between")
+	})
+}
@@ -0,0 +1,117 @@
+package entity_network
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// EntityConfig defines the configuration for the entity node at the centre of the network
+type EntityConfig struct {
+	// NodeLabel is the label of the entity node (e.g., "Customer", "Account")
+	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the entity (e.g. Customer, Person, Account). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+
+	// IdProperty is the property name containing the unique identifier (e.g., "customerId", "accountNumber")
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. customerId, accountNumber)"`
+}
+
+// NodePropertySelection selects which properties are returned for nodes with a given label
+type NodePropertySelection struct {
+	// Label is the node label the selection applies to (e.g., "Customer")
+	Label string `json:"label" jsonschema:"description=Node label the selection applies to (e.g. Customer)"`
+
+	// Properties are the properties returned for nodes with this label (e.g., ["customerId", "firstName"])
+	Properties []string `json:"properties" jsonschema:"description=Properties to return for nodes with this label (e.g. [customerId, firstName, lastName])"`
+}
+
+// GetEntityNetworkInput defines the input parameters for the get-entity-network tool
+type GetEntityNetworkInput struct {
+	// EntityId is the unique identifier for the entity (required)
+	EntityId string `json:"entityId" jsonschema:"description=Entity ID at the centre of the network (required)"`
+
+	// EntityConfig defines the entity node configuration
+	EntityConfig EntityConfig `json:"entityConfig" jsonschema:"description=Configuration for the entity node (node label and ID property)"`
+
+	// MappingName names a saved mapping supplying entityConfig when it is omitted
+	MappingName string `json:"mappingName,omitempty" jsonschema:"description=Optional: name of a mapping saved with save-schema-mapping. Supplies entityConfig when it is omitted."`
+
+	// MaxHops is the neighbourhood radius
+	MaxHops int `json:"maxHops,omitempty" jsonschema:"default=2,minimum=1,maximum=4,description=Number of hops to expand from the entity"`
+
+	// RelationshipTypes restricts the traversal to the given relationship types. If empty, all relationships are traversed.
+	RelationshipTypes []string `json:"relationshipTypes,omitempty" jsonschema:"description=Optional: relationship types to traverse (e.g. [HAS_EMAIL, HAS_PHONE, OWNS]). If omitted, traverses all relationship types."`
+
+	// Direction of the traversal relative to the entity
+	Direction string `json:"direction,omitempty" jsonschema:"enum=out,enum=in,enum=both,default=both,description=Traversal direction relative to the entity"`
+
+	// IncludeLabels restricts the returned neighbours to the given labels. Intermediate nodes are still traversed.
+	IncludeLabels []string `json:"includeLabels,omitempty" jsonschema:"description=Optional: only return neighbours with one of these labels. Nodes with other labels are still traversed but left out of the result."`
+
+	// NodeProperties selects the properties returned per label. Labels without a selection return all properties.
+	NodeProperties []NodePropertySelection `json:"nodeProperties,omitempty" jsonschema:"description=Optional: per-label property selection. Labels without a selection return all properties."`
+
+	// MaxNodes caps the number of neighbour nodes returned, closest first
+	MaxNodes int `json:"maxNodes,omitempty" jsonschema:"default=100,minimum=1,maximum=1000,description=Maximum number of neighbour nodes to return (closest first)"`
+
+	// MaxRelationships caps the number of relationships returned between the returned nodes
+	MaxRelationships int `json:"maxRelationships,omitempty" jsonschema:"default=500,minimum=1,maximum=5000,description=Maximum number of relationships to return"`
+
+	// PreviewQuery returns the generated query and parameters instead of executing it
+	PreviewQuery bool `json:"previewQuery,omitempty" jsonschema:"description=Optional: return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for get-entity-network
+func Spec() mcp.Tool {
+	return mcp.NewTool("get-entity-network",
+		mcp.WithDescription(`Extracts the graph neighbourhood of an entity up to N hops as structured JSON (nodes and relationships), suitable for reasoning about connections or for visualization.
+
+**SCHEMA-AWARE DESIGN:**
+This tool dynamically adapts to your database schema. Use get-schema first to pick the relationship types worth traversing and the properties worth returning per label.
+
+**REQUIRED WORKFLOW:**
+1. **Call get-schema** to discover your database structure
+2. **Identify the entity node** (label and ID property)
+3. **Choose relationshipTypes** to keep the neighbourhood focused (e.g. identity attributes and accounts, not every transaction)
+4. **Choose nodeProperties** per label to keep the output compact
+5. **Call this tool**; if truncated is true, narrow relationshipTypes/includeLabels or reduce maxHops
+
+**EXAMPLE:**
+{
+  "entityId": "CUS123",
+  "entityConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "maxHops": 2,
+  "relationshipTypes": ["HAS_EMAIL", "HAS_PHONE", "OWNS"],
+  "nodeProperties": [
+    {"label": "Customer", "properties": ["customerId", "firstName", "lastName"]},
+    {"label": "Email", "properties": ["address"]},
+    {"label": "Phone", "properties": ["number"]}
+  ],
+  "maxNodes": 50
+}
+
+**WHEN TO USE THIS TOOL:**
+- Understanding what an entity is connected to before deeper investigation
+- Producing a subgraph for visualization or for a SAR narrative
+- Spotting hubs (shared devices, addresses) around a suspicious entity
+
+**OUTPUT STRUCTURE:**
+{
+  "center": "<elementId of the entity>",
+  "nodes": [{"id": "...", "labels": ["Customer"], "distance": 0, "properties": {...}}],
+  "relationships": [{"id": "...", "type": "HAS_EMAIL", "start": "...", "end": "...", "properties": {...}}],
+  "nodeCount": 12,
+  "truncated": false,
+  "relationshipsTruncated": false
+}
+
+**IMPORTANT NOTES:**
+- The entity itself is always returned with distance 0 and does not count towards maxNodes
+- Node and relationship ids are element ids, valid for the current transaction only; use them to join nodes and relationships
+- Only relationships between returned nodes are included
+- Expanding many hops without relationshipTypes can be expensive on dense graphs`),
+		mcp.WithInputSchema[GetEntityNetworkInput](),
+		mcp.WithTitleAnnotation("Get Entity Network"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,225 @@
+package find_connection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultMaxHops            = 6
+	maxHopsLimit              = 10
+	maxWeightedHopsLimit      = 5
+	defaultMaxPaths           = 3
+	maxPathsLimit             = 25
+	defaultRelationshipWeight = 1.0
+)
+
+// Handler returns the tool handler function for find-connection
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleFindConnection(ctx, request, deps)
+	}
+}
+
+func handleFindConnection(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("find-connection"),
+	)
+
+	// Parse arguments
+	var args FindConnectionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("finding connection",
+		"sourceId", args.SourceId,
+		"targetId", args.TargetId,
+		"maxHops", args.MaxHops,
+		"weighted", args.WeightProperty != "",
+		"relationshipTypes", len(args.RelationshipTypes))
+
+	query := buildFindConnectionQuery(args)
+
+	params := map[string]any{
+		"sourceId": args.SourceId,
+		"targetId": args.TargetId,
+		"maxPaths": args.MaxPaths,
+	}
+	if args.WeightProperty != "" {
+		params["defaultWeight"] = *args.DefaultWeight
+	}
+
+	slog.Debug("executing find connection query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	// Execute query
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing find connection query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks that source and target are distinct entities and fills in the path search defaults
+func validateInput(args *FindConnectionInput) string {
+	if args.SourceId == "" || args.TargetId == "" {
+		return "sourceId and targetId parameters are required"
+	}
+	if args.SourceConfig.NodeLabel == "" || args.SourceConfig.IdProperty == "" {
+		return "sourceConfig.nodeLabel and sourceConfig.idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+	if args.TargetConfig.NodeLabel == "" || args.TargetConfig.IdProperty == "" {
+		return "targetConfig.nodeLabel and targetConfig.idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+	if args.SourceId == args.TargetId && args.SourceConfig == args.TargetConfig {
+		return "sourceId and targetId refer to the same entity"
+	}
+
+	if args.Direction == "" {
+		args.Direction = "both"
+	}
+	if args.Direction != "out" && args.Direction != "both" {
+		return fmt.Sprintf("invalid direction '%s', must be one of: out, both", args.Direction)
+	}
+
+	hopsLimit := maxHopsLimit
+	if args.WeightProperty != "" {
+		hopsLimit = maxWeightedHopsLimit
+	}
+	if args.MaxHops == 0 {
+		args.MaxHops = min(defaultMaxHops, hopsLimit)
+	}
+	if args.MaxHops < 1 || args.MaxHops > hopsLimit {
+		return fmt.Sprintf("maxHops must be between 1 and %d", hopsLimit)
+	}
+
+	if args.DefaultWeight == nil {
+		weight := defaultRelationshipWeight
+		args.DefaultWeight = &weight
+	}
+	if *args.DefaultWeight < 0 {
+		return "defaultWeight cannot be negative"
+	}
+
+	if args.MaxPaths == 0 {
+		args.MaxPaths = defaultMaxPaths
+	}
+	if args.MaxPaths < 1 || args.MaxPaths > maxPathsLimit {
+		return fmt.Sprintf("maxPaths must be between 1 and %d", maxPathsLimit)
+	}
+
+	for i, display := range args.DisplayProperties {
+		if display.Label == "" || display.Property == "" {
+			return fmt.Sprintf("displayProperties[%d] requires a label and a property", i)
+		}
+	}
+
+	return ""
+}
+
+// buildFindConnectionQuery constructs the path finding query.
+// Unweighted mode uses allShortestPaths; weighted mode enumerates paths up to maxHops and ranks them by total cost.
+func buildFindConnectionQuery(args FindConnectionInput) string {
+	var queryBuilder strings.Builder
+
+	relFilter := ""
+	if len(args.RelationshipTypes) > 0 {
+		relFilter = ":" + strings.Join(query_builder.EscapeIdentifiers(args.RelationshipTypes), "|")
+	}
+	arrowHead := ""
+	if args.Direction == "out" {
+		arrowHead = ">"
+	}
+
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (source:%s {%s: $sourceId})\n", query_builder.EscapeLabelExpression(args.SourceConfig.NodeLabel), query_builder.EscapeIdentifier(args.SourceConfig.IdProperty)))
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (target:%s {%s: $targetId})\n", query_builder.EscapeLabelExpression(args.TargetConfig.NodeLabel), query_builder.EscapeIdentifier(args.TargetConfig.IdProperty)))
+
+	if args.WeightProperty != "" {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH p = (source)-[%s*1..%d]-%s(target)\n", relFilter, args.MaxHops, arrowHead))
+		queryBuilder.WriteString(fmt.Sprintf("WITH p, reduce(cost = 0.0, r IN relationships(p) | cost + coalesce(toFloat(r.%s), $defaultWeight)) as cost\n", query_builder.EscapeIdentifier(args.WeightProperty)))
+		queryBuilder.WriteString("ORDER BY cost ASC, length(p) ASC\n")
+	} else {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH p = allShortestPaths((source)-[%s*..%d]-%s(target))\n", relFilter, args.MaxHops, arrowHead))
+		queryBuilder.WriteString("WITH p, length(p) as cost\n")
+	}
+	queryBuilder.WriteString("LIMIT $maxPaths\n")
+
+	// Name every node, then walk the relationships to build the explanation
+	queryBuilder.WriteString("WITH p, cost, nodes(p) as pathNodes, relationships(p) as pathRels\n")
+	queryBuilder.WriteString(fmt.Sprintf("WITH p, cost, pathNodes, pathRels, [n IN pathNodes | %s] as names\n", buildNameExpression("n", args)))
+	queryBuilder.WriteString("RETURN length(p) as hops,\n")
+	queryBuilder.WriteString("       cost,\n")
+	queryBuilder.WriteString("       [i IN range(0, size(pathNodes) - 1) | {labels: labels(pathNodes[i]), name: names[i], properties: properties(pathNodes[i])}] as nodes,\n")
+	queryBuilder.WriteString("       [r IN pathRels | {type: type(r), properties: properties(r)}] as relationships,\n")
+	queryBuilder.WriteString("       reduce(s = names[0], i IN range(0, size(pathRels) - 1) |\n")
+	queryBuilder.WriteString("         s + CASE WHEN startNode(pathRels[i]) = pathNodes[i]\n")
+	queryBuilder.WriteString("                  THEN ' -[' + type(pathRels[i]) + ']-> '\n")
+	queryBuilder.WriteString("                  ELSE ' <-[' + type(pathRels[i]) + ']- ' END + names[i + 1]) as explanation")
+
+	return queryBuilder.String()
+}
+
+// buildNameExpression builds a CASE expression naming a node by its label and display property.
+// Configured display properties take precedence over the source and target ID properties.
+func buildNameExpression(varName string, args FindConnectionInput) string {
+	displays := append([]DisplayProperty{}, args.DisplayProperties...)
+	displays = append(displays,
+		DisplayProperty{Label: args.SourceConfig.NodeLabel, Property: args.SourceConfig.IdProperty},
+		DisplayProperty{Label: args.TargetConfig.NodeLabel, Property: args.TargetConfig.IdProperty},
+	)
+
+	var caseBuilder strings.Builder
+	caseBuilder.WriteString("CASE")
+	seen := make(map[string]bool)
+	for _, display := range displays {
+		if seen[display.Label] {
+			continue
+		}
+		seen[display.Label] = true
+		caseBuilder.WriteString(fmt.Sprintf(" WHEN %s:%s THEN %s + coalesce(toString(%s.%s), '?')",
+			varName, query_builder.EscapeLabelExpression(display.Label), query_builder.QuoteString(display.Label+" "), varName, query_builder.EscapeIdentifier(display.Property)))
+	}
+	caseBuilder.WriteString(fmt.Sprintf(" ELSE labels(%s)[0] END", varName))
+
+	return caseBuilder.String()
+}
@@ -0,0 +1,117 @@
+package find_connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInput() FindConnectionInput {
+	return FindConnectionInput{
+		SourceId:     "CUS123",
+		SourceConfig: EntityConfig{NodeLabel: "Customer", IdProperty: "customerId"},
+		TargetId:     "CUS456",
+		TargetConfig: EntityConfig{NodeLabel: "Customer", IdProperty: "customerId"},
+	}
+}
+
+func TestBuildFindConnectionQuery_Unweighted(t *testing.T) {
+	args := testInput()
+	args.RelationshipTypes = []string{"HAS_EMAIL", "HAS_PHONE"}
+	require.Empty(t, validateInput(&args))
+
+	query := buildFindConnectionQuery(args)
+
+	assert.Contains(t, query, "MATCH (source:Customer {customerId: $sourceId})")
+	assert.Contains(t, query, "MATCH (target:Customer {customerId: $targetId})")
+	assert.Contains(t, query, "MATCH p = allShortestPaths((source)-[:HAS_EMAIL|HAS_PHONE*..6]-(target))")
+	assert.Contains(t, query, "WITH p, length(p) as cost")
+	assert.Contains(t, query, "LIMIT $maxPaths")
+	assert.Contains(t, query, "as explanation")
+	assert.NotContains(t, query, "$defaultWeight")
+}
+
+func TestBuildFindConnectionQuery_WeightedOutgoing(t *testing.T) {
+	args := testInput()
+	args.WeightProperty = "distance"
+	args.Direction = "out"
+	args.MaxHops = 3
+	require.Empty(t, validateInput(&args))
+
+	query := buildFindConnectionQuery(args)
+
+	assert.Equal(t, defaultRelationshipWeight, *args.DefaultWeight)
+	assert.Contains(t, query, "MATCH p = (source)-[*1..3]->(target)")
+	assert.Contains(t, query, "reduce(cost = 0.0, r IN relationships(p) | cost + coalesce(toFloat(r.distance), $defaultWeight)) as cost")
+	assert.Contains(t, query, "ORDER BY cost ASC, length(p) ASC")
+	assert.NotContains(t, query, "allShortestPaths")
+}
+
+func TestBuildNameExpression(t *testing.T) {
+	args := testInput()
+	args.TargetConfig = EntityConfig{NodeLabel: "Account", IdProperty: "accountNumber"}
+	args.DisplayProperties = []DisplayProperty{
+		{Label: "Email", Property: "address"},
+		{Label: "Customer", Property: "fullName"},
+	}
+
+	expression := buildNameExpression("n", args)
+
+	// Configured display properties win over the entity ID properties
+	assert.Equal(t, "CASE"+
+		" WHEN n:Email THEN 'Email ' + coalesce(toString(n.address), '?')"+
+		" WHEN n:Customer THEN 'Customer ' + coalesce(toString(n.fullName), '?')"+
+		" WHEN n:Account THEN 'Account ' + coalesce(toString(n.accountNumber), '?')"+
+		" ELSE labels(n)[0] END", expression)
+}
+
+func TestValidateInput(t *testing.T) {
+	t.Run("applies defaults", func(t *testing.T) {
+		args := testInput()
+
+		assert.Empty(t, validateInput(&args))
+		assert.Equal(t, "both", args.Direction)
+		assert.Equal(t, defaultMaxHops, args.MaxHops)
+		assert.Equal(t, defaultMaxPaths, args.MaxPaths)
+	})
+
+	t.Run("weighted mode lowers the default hops", func(t *testing.T) {
+		args := testInput()
+		args.WeightProperty = "distance"
+
+		assert.Empty(t, validateInput(&args))
+		assert.Equal(t, maxWeightedHopsLimit, args.MaxHops)
+	})
+
+	t.Run("rejects weighted maxHops above maximum", func(t *testing.T) {
+		args := testInput()
+		args.WeightProperty = "distance"
+		args.MaxHops = maxWeightedHopsLimit + 1
+
+		assert.Contains(t, validateInput(&args), "maxHops must be between 1 and 5")
+	})
+
+	t.Run("rejects same entity", func(t *testing.T) {
+		args := testInput()
+		args.TargetId = args.SourceId
+
+		assert.Contains(t, validateInput(&args), "same entity")
+	})
+
+	t.Run("rejects incoming direction", func(t *testing.T) {
+		args := testInput()
+		args.Direction = "in"
+
+		assert.Contains(t, validateInput(&args), "invalid direction")
+	})
+
+	t.Run("rejects negative default weight", func(t *testing.T) {
+		weight := -1.0
+		args := testInput()
+		args.WeightProperty = "distance"
+		args.DefaultWeight = &weight
+
+		assert.Contains(t, validateInput(&args), "defaultWeight cannot be negative")
+	})
+}
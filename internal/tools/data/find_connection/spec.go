@@ -0,0 +1,108 @@
+package find_connection
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// EntityConfig defines the configuration for one end of the connection
+type EntityConfig struct {
+	// NodeLabel is the label of the entity node (e.g., "Customer", "Account")
+	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the entity (e.g. Customer, Person, Account). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+
+	// IdProperty is the property name containing the unique identifier (e.g., "customerId", "accountNumber")
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. customerId, accountNumber)"`
+}
+
+// DisplayProperty selects the property used to name nodes of a label in the explanation
+type DisplayProperty struct {
+	// Label is the node label (e.g., "Email")
+	Label string `json:"label" jsonschema:"description=Node label (e.g. Email)"`
+
+	// Property is the property naming nodes of this label (e.g., "address")
+	Property string `json:"property" jsonschema:"description=Property naming nodes of this label in the explanation (e.g. address)"`
+}
+
+// FindConnectionInput defines the input parameters for the find-connection tool
+type FindConnectionInput struct {
+	// SourceId and SourceConfig identify the first entity (required)
+	SourceId     string       `json:"sourceId" jsonschema:"description=ID of the first entity (required)"`
+	SourceConfig EntityConfig `json:"sourceConfig" jsonschema:"description=Configuration for the first entity node (node label and ID property)"`
+
+	// TargetId and TargetConfig identify the second entity (required)
+	TargetId     string       `json:"targetId" jsonschema:"description=ID of the second entity (required)"`
+	TargetConfig EntityConfig `json:"targetConfig" jsonschema:"description=Configuration for the second entity node (node label and ID property)"`
+
+	// RelationshipTypes restricts the paths to the given relationship types. If empty, all relationships are used.
+	RelationshipTypes []string `json:"relationshipTypes,omitempty" jsonschema:"description=Optional: relationship types the paths may use (e.g. [HAS_EMAIL, HAS_PHONE, OWNS, TRANSACTION]). If omitted, any relationship type is used."`
+
+	// Direction of the paths from source to target
+	Direction string `json:"direction,omitempty" jsonschema:"enum=out,enum=both,default=both,description=Path direction: out follows relationships from source to target only, both ignores direction"`
+
+	// MaxHops is the maximum path length
+	MaxHops int `json:"maxHops,omitempty" jsonschema:"default=6,minimum=1,maximum=10,description=Maximum number of relationships in a path (at most 5 when weightProperty is set)"`
+
+	// WeightProperty switches to weighted mode: the path with the lowest total weight wins
+	WeightProperty string `json:"weightProperty,omitempty" jsonschema:"description=Optional: numeric relationship property used as cost (e.g. distance or 1/amount). Paths with the lowest total cost are returned instead of the fewest hops."`
+
+	// DefaultWeight is the cost of relationships without the weight property
+	DefaultWeight *float64 `json:"defaultWeight,omitempty" jsonschema:"description=Optional: cost of relationships missing weightProperty (default 1.0)"`
+
+	// MaxPaths is the maximum number of paths to return
+	MaxPaths int `json:"maxPaths,omitempty" jsonschema:"default=3,minimum=1,maximum=25,description=Maximum number of paths to return"`
+
+	// DisplayProperties names nodes in the explanation. The source and target ID properties are used for their labels automatically.
+	DisplayProperties []DisplayProperty `json:"displayProperties,omitempty" jsonschema:"description=Optional: property naming the nodes of each label in the explanation (e.g. [{label: Email, property: address}]). Other labels are named by label only."`
+
+	// PreviewQuery returns the generated query and parameters instead of executing it
+	PreviewQuery bool `json:"previewQuery,omitempty" jsonschema:"description=Optional: return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for find-connection
+func Spec() mcp.Tool {
+	return mcp.NewTool("find-connection",
+		mcp.WithDescription(`Finds how two entities are connected: computes the shortest paths between them (optionally weighted, optionally restricted to relationship types) and returns a human-readable explanation of each path.
+
+**SCHEMA-AWARE DESIGN:**
+This tool dynamically adapts to your database schema. Use get-schema first to choose the relationship types that represent meaningful links (shared identifiers, ownership, transactions).
+
+**MODES:**
+- **Unweighted (default):** returns the paths with the fewest hops
+- **Weighted (weightProperty set):** returns the paths with the lowest total cost, summing weightProperty along each path
+
+**EXAMPLE:**
+{
+  "sourceId": "CUS123",
+  "sourceConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "targetId": "CUS456",
+  "targetConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "relationshipTypes": ["HAS_EMAIL", "HAS_PHONE", "OWNS", "TRANSACTION"],
+  "maxHops": 4,
+  "displayProperties": [
+    {"label": "Email", "property": "address"},
+    {"label": "Account", "property": "accountNumber"}
+  ]
+}
+
+**OUTPUT (one row per path, best first):**
+- hops: number of relationships in the path
+- cost: total weight (weighted mode) or hops
+- nodes: [{labels, name, properties}]
+- relationships: [{type, properties}]
+- explanation: e.g. "Customer CUS123 -[HAS_EMAIL]-> Email a@b.com <-[HAS_EMAIL]- Customer CUS456"
+
+**WHEN TO USE THIS TOOL:**
+- Explaining why two customers are linked during an investigation
+- Writing the "how are the subjects connected" part of a SAR narrative
+- Checking whether a new applicant is connected to known fraudsters
+
+**IMPORTANT NOTES:**
+- An empty result means no connection exists within maxHops using the allowed relationship types
+- Weighted mode enumerates all paths up to maxHops, so keep maxHops small and relationshipTypes focused on dense graphs`),
+		mcp.WithInputSchema[FindConnectionInput](),
+		mcp.WithTitleAnnotation("Find Connection"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
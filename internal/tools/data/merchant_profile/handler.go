@@ -0,0 +1,379 @@
+package merchant_profile
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultCustomerHops = 3
+	maxCustomerHops     = 4
+	defaultClusterLimit = 10
+	maxClusterLimit     = 100
+)
+
+// Handler returns the tool handler function for get-merchant-profile
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetMerchantProfile(ctx, request, deps)
+	}
+}
+
+func handleGetMerchantProfile(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("get-merchant-profile"),
+	)
+
+	// Parse arguments
+	var args GetMerchantProfileInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("retrieving merchant profile",
+		"merchantId", args.MerchantId,
+		"merchantLabel", args.MerchantConfig.NodeLabel,
+		"attributeMappings", len(args.AttributeMappings),
+		"transactionVolume", args.TransactionVolume != nil,
+		"customerClusters", args.CustomerClusters != nil)
+
+	// Build dynamic Cypher query based on the configuration
+	query, filterParams := buildMerchantProfileQuery(args)
+
+	params := map[string]any{
+		"entityId": args.MerchantId,
+	}
+	if args.TransactionVolume != nil && args.TransactionVolume.WindowDays > 0 {
+		params["windowDays"] = args.TransactionVolume.WindowDays
+	}
+	if args.CustomerClusters != nil && len(args.CustomerClusters.SharedAttributes) > 0 {
+		params["clusterLimit"] = args.CustomerClusters.Limit
+	}
+
+	maps.Copy(params, filterParams)
+
+	slog.Debug("executing merchant profile query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	// Execute query
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing merchant profile query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks the merchant, its attribute mappings and the enabled volume and cluster sections
+func validateInput(args *GetMerchantProfileInput) string {
+	if args.MerchantId == "" {
+		return "merchantId parameter is required"
+	}
+	if args.MerchantConfig.NodeLabel == "" {
+		return "merchantConfig.nodeLabel is required. Specify the merchant node label (e.g., 'Merchant')."
+	}
+	if args.MerchantConfig.IdProperty == "" {
+		return "merchantConfig.idProperty is required. Specify the property name containing the unique identifier (e.g., 'merchantId')."
+	}
+
+	for i, mapping := range args.AttributeMappings {
+		if mapping.RelationshipType == "" || mapping.TargetLabel == "" {
+			return fmt.Sprintf("attributeMappings[%d] requires relationshipType and targetLabel. Use get-schema to discover these first.", i)
+		}
+		if mapping.Direction != "" && mapping.Direction != "out" && mapping.Direction != "in" && mapping.Direction != "both" {
+			return fmt.Sprintf("attributeMappings[%d] has invalid direction '%s', must be one of: out, in, both", i, mapping.Direction)
+		}
+	}
+	if errMessage := query_builder.ValidateCollectionKeys(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeFilters(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeOrdering(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeAggregations(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeIdentifiers(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+
+	if volume := args.TransactionVolume; volume != nil {
+		if volume.TransactionLabel != "" {
+			if volume.MerchantRelationshipType == "" {
+				return "transactionVolume.merchantRelationshipType is required when transactionLabel is set (e.g., 'TO_MERCHANT')."
+			}
+		} else if volume.TransactionRelationshipType == "" {
+			return "transactionVolume must describe the transaction model: set transactionLabel with merchantRelationshipType for transaction nodes, or transactionRelationshipType for payment relationships. Use get-schema to discover these first."
+		}
+		if volume.DateProperty == "" || volume.AmountProperty == "" {
+			return "transactionVolume.dateProperty and transactionVolume.amountProperty are required (e.g., 'date' and 'amount')."
+		}
+		if (volume.ChargebackRelationshipType == "") != (volume.ChargebackLabel == "") {
+			return "transactionVolume.chargebackRelationshipType and transactionVolume.chargebackLabel must be set together (e.g., 'HAS_CHARGEBACK' and 'Chargeback')."
+		}
+		if volume.ChargebackRelationshipType != "" && volume.TransactionLabel == "" {
+			return "chargeback nodes require the transaction node model. Use transactionVolume.chargebackProperty when transactions are relationships."
+		}
+		if volume.ChargebackRelationshipType != "" && volume.ChargebackProperty != "" {
+			return "set either transactionVolume.chargebackProperty or transactionVolume.chargebackRelationshipType, not both"
+		}
+		if volume.WindowDays < 0 {
+			return "transactionVolume.windowDays cannot be negative"
+		}
+	}
+
+	if clusters := args.CustomerClusters; clusters != nil {
+		if clusters.CustomerLabel == "" || clusters.CustomerIdProperty == "" {
+			return "customerClusters.customerLabel and customerClusters.customerIdProperty are required (e.g., 'Customer' and 'customerId')."
+		}
+		if len(clusters.RelationshipTypes) == 0 {
+			return "customerClusters.relationshipTypes is required. Specify the relationship types between merchant and customers (e.g., ['TO_MERCHANT', 'PERFORMS'])."
+		}
+		for i, attribute := range clusters.SharedAttributes {
+			if attribute.RelationshipType == "" || attribute.TargetLabel == "" {
+				return fmt.Sprintf("customerClusters.sharedAttributes[%d] requires relationshipType and targetLabel", i)
+			}
+		}
+		if clusters.MaxHops == 0 {
+			clusters.MaxHops = defaultCustomerHops
+		}
+		if clusters.MaxHops < 1 || clusters.MaxHops > maxCustomerHops {
+			return fmt.Sprintf("customerClusters.maxHops must be between 1 and %d", maxCustomerHops)
+		}
+		if clusters.Limit == 0 {
+			clusters.Limit = defaultClusterLimit
+		}
+		if clusters.Limit < 1 || clusters.Limit > maxClusterLimit {
+			return fmt.Sprintf("customerClusters.limit must be between 1 and %d", maxClusterLimit)
+		}
+	}
+
+	if len(args.AttributeMappings) == 0 && args.TransactionVolume == nil && args.CustomerClusters == nil {
+		return "at least one of attributeMappings, transactionVolume or customerClusters is required. Use get-schema to discover how merchants are connected first."
+	}
+
+	return ""
+}
+
+// buildMerchantProfileQuery constructs a dynamic Cypher query based on the merchant configuration,
+// returning it with the parameters bound by attribute filters and limits
+func buildMerchantProfileQuery(args GetMerchantProfileInput) (string, map[string]any) {
+	merchantConfig := args.MerchantConfig
+	var queryBuilder strings.Builder
+
+	// Start with base merchant match using dynamic node label and ID property
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (m:%s {%s: $entityId})\n", query_builder.EscapeLabelExpression(merchantConfig.NodeLabel), query_builder.EscapeIdentifier(merchantConfig.IdProperty)))
+
+	// Linked nodes are aggregated before the subqueries so their rows do not multiply
+	sections := query_builder.BuildProfileSections("m", merchantConfig.BaseProperties, args.AttributeMappings)
+	if sections.Matches != "" {
+		queryBuilder.WriteString(sections.Matches + "\n")
+	}
+	queryBuilder.WriteString(sections.With + "\n")
+	queryBuilder.WriteString(sections.Subqueries)
+
+	if args.TransactionVolume != nil {
+		queryBuilder.WriteString(buildTransactionVolumeSubquery(*args.TransactionVolume))
+	}
+
+	if args.CustomerClusters != nil {
+		queryBuilder.WriteString(buildLinkedCustomersSubquery(*args.CustomerClusters))
+		if len(args.CustomerClusters.SharedAttributes) > 0 {
+			queryBuilder.WriteString(buildCustomerClustersSubquery(*args.CustomerClusters))
+		}
+	}
+
+	// Build RETURN clause from pre-aggregated variables
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString(strings.Join(sections.Entries, ",\n"))
+
+	if args.TransactionVolume != nil {
+		queryBuilder.WriteString(",\n  transaction_volume: transactionVolume")
+	}
+
+	if args.CustomerClusters != nil {
+		queryBuilder.WriteString(",\n  linked_customers: {\n")
+		queryBuilder.WriteString("    count: size(linkedCustomers)")
+		if len(args.CustomerClusters.SharedAttributes) > 0 {
+			queryBuilder.WriteString(",\n    clusters: customerClusters")
+		}
+		queryBuilder.WriteString("\n  }")
+	}
+
+	queryBuilder.WriteString("\n} as merchantProfile")
+
+	return queryBuilder.String(), sections.Params
+}
+
+// buildTransactionVolumeSubquery returns a CALL subquery aggregating payments to the merchant as transactionVolume
+func buildTransactionVolumeSubquery(config TransactionVolumeConfig) string {
+	var subquery strings.Builder
+
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString("  WITH m\n")
+	if config.TransactionLabel != "" {
+		subquery.WriteString(fmt.Sprintf("  OPTIONAL MATCH (t:%s)-[:%s]->(m)\n", query_builder.EscapeIdentifier(config.TransactionLabel), query_builder.EscapeIdentifier(config.MerchantRelationshipType)))
+	} else {
+		subquery.WriteString(fmt.Sprintf("  OPTIONAL MATCH ()-[t:%s]->(m)\n", query_builder.EscapeIdentifier(config.TransactionRelationshipType)))
+	}
+	if config.WindowDays > 0 {
+		subquery.WriteString(fmt.Sprintf("  WHERE %s\n", query_builder.LastDays(query_builder.TimeProperty{Variable: "t", Property: config.DateProperty}, "windowDays")))
+	}
+
+	chargebackCondition := buildChargebackCondition(config)
+	if chargebackCondition != "" {
+		subquery.WriteString(fmt.Sprintf("  WITH t, %s as chargeback\n", chargebackCondition))
+	}
+
+	subquery.WriteString("  WITH count(t) as transactions,\n")
+	subquery.WriteString(fmt.Sprintf("       coalesce(sum(t.%s), 0) as total,\n", query_builder.EscapeIdentifier(config.AmountProperty)))
+	subquery.WriteString(fmt.Sprintf("       avg(t.%s) as average,\n", query_builder.EscapeIdentifier(config.AmountProperty)))
+	subquery.WriteString(fmt.Sprintf("       max(t.%s) as largest,\n", query_builder.EscapeIdentifier(config.AmountProperty)))
+	subquery.WriteString(fmt.Sprintf("       min(t.%s) as firstDate,\n", query_builder.EscapeIdentifier(config.DateProperty)))
+	subquery.WriteString(fmt.Sprintf("       max(t.%s) as lastDate", query_builder.EscapeIdentifier(config.DateProperty)))
+	if chargebackCondition != "" {
+		subquery.WriteString(",\n       count(CASE WHEN chargeback THEN t END) as chargebacks,\n")
+		subquery.WriteString(fmt.Sprintf("       coalesce(sum(CASE WHEN chargeback THEN t.%s END), 0) as chargebackAmount", query_builder.EscapeIdentifier(config.AmountProperty)))
+	}
+	subquery.WriteString("\n")
+
+	subquery.WriteString("  RETURN {\n")
+	subquery.WriteString("    count: transactions,\n")
+	subquery.WriteString("    total: total,\n")
+	subquery.WriteString("    average: average,\n")
+	subquery.WriteString("    largest: largest,\n")
+	subquery.WriteString("    firstDate: firstDate,\n")
+	subquery.WriteString("    lastDate: lastDate")
+	if chargebackCondition != "" {
+		subquery.WriteString(",\n    chargebacks: chargebacks,\n")
+		subquery.WriteString("    chargebackAmount: chargebackAmount,\n")
+		subquery.WriteString("    chargebackRate: CASE WHEN transactions > 0 THEN toFloat(chargebacks) / transactions ELSE 0.0 END")
+	}
+	subquery.WriteString("\n  } as transactionVolume\n")
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
+
+// buildChargebackCondition returns the boolean expression marking a transaction (t) as a chargeback,
+// or an empty string when chargebacks are not configured
+func buildChargebackCondition(config TransactionVolumeConfig) string {
+	if config.ChargebackRelationshipType != "" {
+		return fmt.Sprintf("EXISTS { (t)-[:%s]->(:%s) }", query_builder.EscapeIdentifier(config.ChargebackRelationshipType), query_builder.EscapeIdentifier(config.ChargebackLabel))
+	}
+	if config.ChargebackProperty != "" {
+		return fmt.Sprintf("coalesce(t.%s, false) = true", query_builder.EscapeIdentifier(config.ChargebackProperty))
+	}
+	return ""
+}
+
+// buildLinkedCustomersSubquery returns a CALL subquery collecting the customers reachable from the merchant as linkedCustomers
+func buildLinkedCustomersSubquery(config CustomerClusterConfig) string {
+	var subquery strings.Builder
+
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString("  WITH m\n")
+	subquery.WriteString(fmt.Sprintf("  OPTIONAL MATCH (m)-[:%s*1..%d]-(c:%s)\n",
+		strings.Join(query_builder.EscapeIdentifiers(config.RelationshipTypes), "|"), config.MaxHops, query_builder.EscapeIdentifier(config.CustomerLabel)))
+	subquery.WriteString("  RETURN collect(DISTINCT c) as linkedCustomers\n")
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
+
+// buildCustomerClustersSubquery returns a CALL subquery grouping linked customers by the attribute nodes they share.
+// Each cluster is one shared attribute node used by two or more of the merchant's customers.
+func buildCustomerClustersSubquery(config CustomerClusterConfig) string {
+	relTypes := make([]string, 0, len(config.SharedAttributes))
+	labelChecks := make([]string, 0, len(config.SharedAttributes))
+	for _, attribute := range config.SharedAttributes {
+		relTypes = append(relTypes, query_builder.EscapeIdentifier(attribute.RelationshipType))
+		labelChecks = append(labelChecks, "shared:"+query_builder.EscapeLabelExpression(attribute.TargetLabel))
+	}
+
+	var subquery strings.Builder
+
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString("  WITH linkedCustomers\n")
+	subquery.WriteString("  UNWIND linkedCustomers as c\n")
+	subquery.WriteString(fmt.Sprintf("  MATCH (c)-[:%s]->(shared)\n", strings.Join(relTypes, "|")))
+	subquery.WriteString(fmt.Sprintf("  WHERE %s\n", strings.Join(labelChecks, " OR ")))
+	subquery.WriteString(fmt.Sprintf("  WITH shared, collect(DISTINCT c.%s) as members\n", query_builder.EscapeIdentifier(config.CustomerIdProperty)))
+	subquery.WriteString("  WHERE size(members) > 1\n")
+	subquery.WriteString("  WITH shared, members\n")
+	subquery.WriteString("  ORDER BY size(members) DESC\n")
+	subquery.WriteString("  LIMIT $clusterLimit\n")
+	subquery.WriteString("  RETURN collect({\n")
+	subquery.WriteString(fmt.Sprintf("    sharedAttribute: %s,\n", buildSharedAttributeCase(config.SharedAttributes, false)))
+	subquery.WriteString(fmt.Sprintf("    value: %s,\n", buildSharedAttributeCase(config.SharedAttributes, true)))
+	subquery.WriteString("    customers: members,\n")
+	subquery.WriteString("    size: size(members)\n")
+	subquery.WriteString("  }) as customerClusters\n")
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
+
+// buildSharedAttributeCase returns a CASE expression resolving the shared node to either its label
+// or, when identifier is true, its identifier value
+func buildSharedAttributeCase(attributes []query_builder.AttributeMapping, identifier bool) string {
+	var caseBuilder strings.Builder
+
+	caseBuilder.WriteString("CASE")
+	for _, attribute := range attributes {
+		value := query_builder.QuoteString(attribute.TargetLabel)
+		if identifier {
+			if attribute.IdentifierProperty == "" {
+				value = "properties(shared)"
+			} else {
+				value = "shared." + query_builder.EscapeIdentifier(attribute.IdentifierProperty)
+			}
+		}
+		caseBuilder.WriteString(fmt.Sprintf(" WHEN shared:%s THEN %s", query_builder.EscapeLabelExpression(attribute.TargetLabel), value))
+	}
+	caseBuilder.WriteString(" END")
+
+	return caseBuilder.String()
+}
@@ -0,0 +1,190 @@
+package merchant_profile
+
+import (
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testInput() GetMerchantProfileInput {
+	return GetMerchantProfileInput{
+		MerchantId: "MER42",
+		MerchantConfig: MerchantConfig{
+			NodeLabel:      "Merchant",
+			IdProperty:     "merchantId",
+			BaseProperties: []string{"name", "category"},
+		},
+		AttributeMappings: []query_builder.AttributeMapping{
+			{
+				RelationshipType:  "LOCATED_AT",
+				TargetLabel:       "Address",
+				AttributeCategory: "location",
+				IncludeProperties: []string{"city"},
+			},
+		},
+	}
+}
+
+func TestBuildMerchantProfileQuery_AttributeMappings(t *testing.T) {
+	args := testInput()
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildMerchantProfileQuery(args)
+
+	assert.Contains(t, query, "MATCH (m:Merchant {merchantId: $entityId})")
+	assert.Contains(t, query, "OPTIONAL MATCH (m)-[:LOCATED_AT]->(attr0:Address)")
+	assert.Contains(t, query, "name: m.name")
+	assert.Contains(t, query, "} as merchantProfile")
+	assert.NotContains(t, query, "CALL {")
+}
+
+func TestBuildMerchantProfileQuery_TransactionVolumeWithChargebackNodes(t *testing.T) {
+	args := testInput()
+	args.TransactionVolume = &TransactionVolumeConfig{
+		TransactionLabel:           "Transaction",
+		MerchantRelationshipType:   "TO_MERCHANT",
+		DateProperty:               "date",
+		AmountProperty:             "amount",
+		ChargebackRelationshipType: "HAS_CHARGEBACK",
+		ChargebackLabel:            "Chargeback",
+		WindowDays:                 30,
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildMerchantProfileQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH (t:Transaction)-[:TO_MERCHANT]->(m)")
+	assert.Contains(t, query, "WHERE t.date >= datetime() - duration({days: $windowDays})")
+	assert.Contains(t, query, "WITH t, EXISTS { (t)-[:HAS_CHARGEBACK]->(:Chargeback) } as chargeback")
+	assert.Contains(t, query, "count(CASE WHEN chargeback THEN t END) as chargebacks")
+	assert.Contains(t, query, "chargebackRate: CASE WHEN transactions > 0 THEN toFloat(chargebacks) / transactions ELSE 0.0 END")
+	assert.Contains(t, query, "transaction_volume: transactionVolume")
+}
+
+func TestBuildMerchantProfileQuery_TransactionVolumeRelationshipModel(t *testing.T) {
+	args := testInput()
+	args.TransactionVolume = &TransactionVolumeConfig{
+		TransactionRelationshipType: "PAID",
+		DateProperty:                "timestamp",
+		AmountProperty:              "amount",
+		ChargebackProperty:          "isChargeback",
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildMerchantProfileQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH ()-[t:PAID]->(m)")
+	assert.Contains(t, query, "coalesce(t.isChargeback, false) = true as chargeback")
+	assert.NotContains(t, query, "$windowDays")
+}
+
+func TestBuildMerchantProfileQuery_TransactionVolumeWithoutChargebacks(t *testing.T) {
+	args := testInput()
+	args.TransactionVolume = &TransactionVolumeConfig{
+		TransactionRelationshipType: "PAID",
+		DateProperty:                "timestamp",
+		AmountProperty:              "amount",
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildMerchantProfileQuery(args)
+
+	assert.NotContains(t, query, "chargeback")
+	assert.Contains(t, query, "count: transactions")
+}
+
+func TestBuildMerchantProfileQuery_CustomerClusters(t *testing.T) {
+	args := testInput()
+	args.CustomerClusters = &CustomerClusterConfig{
+		CustomerLabel:      "Customer",
+		CustomerIdProperty: "customerId",
+		RelationshipTypes:  []string{"TO_MERCHANT", "PERFORMS"},
+		SharedAttributes: []query_builder.AttributeMapping{
+			{RelationshipType: "HAS_DEVICE", TargetLabel: "Device", IdentifierProperty: "deviceId"},
+			{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", IdentifierProperty: "address"},
+		},
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildMerchantProfileQuery(args)
+
+	assert.Equal(t, defaultCustomerHops, args.CustomerClusters.MaxHops)
+	assert.Equal(t, defaultClusterLimit, args.CustomerClusters.Limit)
+	assert.Contains(t, query, "OPTIONAL MATCH (m)-[:TO_MERCHANT|PERFORMS*1..3]-(c:Customer)")
+	assert.Contains(t, query, "RETURN collect(DISTINCT c) as linkedCustomers")
+	assert.Contains(t, query, "MATCH (c)-[:HAS_DEVICE|HAS_EMAIL]->(shared)")
+	assert.Contains(t, query, "WHERE shared:Device OR shared:Email")
+	assert.Contains(t, query, "WHERE size(members) > 1")
+	assert.Contains(t, query, "LIMIT $clusterLimit")
+	assert.Contains(t, query, "sharedAttribute: CASE WHEN shared:Device THEN 'Device' WHEN shared:Email THEN 'Email' END")
+	assert.Contains(t, query, "value: CASE WHEN shared:Device THEN shared.deviceId WHEN shared:Email THEN shared.address END")
+	assert.Contains(t, query, "count: size(linkedCustomers)")
+	assert.Contains(t, query, "clusters: customerClusters")
+}
+
+func TestBuildMerchantProfileQuery_LinkedCustomersOnly(t *testing.T) {
+	args := testInput()
+	args.CustomerClusters = &CustomerClusterConfig{
+		CustomerLabel:      "Customer",
+		CustomerIdProperty: "customerId",
+		RelationshipTypes:  []string{"PAID"},
+		MaxHops:            1,
+	}
+	require.Empty(t, validateInput(&args))
+
+	query, _ := buildMerchantProfileQuery(args)
+
+	assert.Contains(t, query, "OPTIONAL MATCH (m)-[:PAID*1..1]-(c:Customer)")
+	assert.NotContains(t, query, "customerClusters")
+	assert.NotContains(t, query, "$clusterLimit")
+}
+
+func TestValidateInput(t *testing.T) {
+	t.Run("requires merchantId", func(t *testing.T) {
+		args := testInput()
+		args.MerchantId = ""
+
+		assert.Contains(t, validateInput(&args), "merchantId parameter is required")
+	})
+
+	t.Run("requires at least one section", func(t *testing.T) {
+		args := testInput()
+		args.AttributeMappings = nil
+
+		assert.Contains(t, validateInput(&args), "at least one of attributeMappings")
+	})
+
+	t.Run("requires a transaction model", func(t *testing.T) {
+		args := testInput()
+		args.TransactionVolume = &TransactionVolumeConfig{DateProperty: "date", AmountProperty: "amount"}
+
+		assert.Contains(t, validateInput(&args), "transactionVolume must describe the transaction model")
+	})
+
+	t.Run("rejects chargeback nodes on relationship model", func(t *testing.T) {
+		args := testInput()
+		args.TransactionVolume = &TransactionVolumeConfig{
+			TransactionRelationshipType: "PAID",
+			DateProperty:                "date",
+			AmountProperty:              "amount",
+			ChargebackRelationshipType:  "HAS_CHARGEBACK",
+			ChargebackLabel:             "Chargeback",
+		}
+
+		assert.Contains(t, validateInput(&args), "chargeback nodes require the transaction node model")
+	})
+
+	t.Run("rejects maxHops above maximum", func(t *testing.T) {
+		args := testInput()
+		args.CustomerClusters = &CustomerClusterConfig{
+			CustomerLabel:      "Customer",
+			CustomerIdProperty: "customerId",
+			RelationshipTypes:  []string{"PAID"},
+			MaxHops:            maxCustomerHops + 1,
+		}
+
+		assert.Contains(t, validateInput(&args), "customerClusters.maxHops must be between")
+	})
+}
@@ -0,0 +1,171 @@
+package merchant_profile
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+// MerchantConfig defines the configuration for the merchant node to retrieve
+type MerchantConfig struct {
+	// NodeLabel is the label of the merchant node (e.g., "Merchant")
+	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the merchant (e.g. Merchant). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+
+	// IdProperty is the property name containing the unique identifier (e.g., "merchantId")
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. merchantId)"`
+
+	// BaseProperties are the properties from the merchant node to include in base details.
+	// If empty, all properties will be returned using properties() function.
+	BaseProperties []string `json:"baseProperties,omitempty" jsonschema:"description=List of base properties to include (e.g. [name, category, mcc, country]). If empty, returns all properties."`
+}
+
+// TransactionVolumeConfig describes how payments reach the merchant so they can be aggregated.
+// Two models are supported:
+//   - Node model: (:Transaction)-[:TO_MERCHANT]->(:Merchant), set TransactionLabel and MerchantRelationshipType
+//   - Relationship model: (:Card)-[:PAID]->(:Merchant), set TransactionRelationshipType
+type TransactionVolumeConfig struct {
+	// TransactionLabel is the label of transaction nodes (node model only, e.g., "Transaction")
+	TransactionLabel string `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction). Omit when transactions are relationships."`
+
+	// MerchantRelationshipType connects the transaction node to the merchant (node model only, e.g., "TO_MERCHANT")
+	MerchantRelationshipType string `json:"merchantRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the merchant (e.g. TO_MERCHANT or BENEFITS_TO)"`
+
+	// TransactionRelationshipType is the payment relationship pointing at the merchant (relationship model only, e.g., "PAID")
+	TransactionRelationshipType string `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: payment relationship pointing at the merchant (e.g. PAID)"`
+
+	// DateProperty holds the transaction timestamp (e.g., "date", "timestamp")
+	DateProperty string `json:"dateProperty" jsonschema:"description=Property holding the transaction datetime (e.g. date or timestamp)"`
+
+	// AmountProperty holds the transaction amount (e.g., "amount")
+	AmountProperty string `json:"amountProperty" jsonschema:"description=Property holding the transaction amount (e.g. amount)"`
+
+	// ChargebackProperty is a boolean transaction property marking chargebacks (e.g., "isChargeback")
+	ChargebackProperty string `json:"chargebackProperty,omitempty" jsonschema:"description=Optional: boolean transaction property marking chargebacks (e.g. isChargeback)"`
+
+	// ChargebackRelationshipType and ChargebackLabel model chargebacks as nodes linked to the transaction (node model only),
+	// e.g. (:Transaction)-[:HAS_CHARGEBACK]->(:Chargeback)
+	ChargebackRelationshipType string `json:"chargebackRelationshipType,omitempty" jsonschema:"description=Optional, node model only: relationship from the transaction to a chargeback node (e.g. HAS_CHARGEBACK)"`
+	ChargebackLabel            string `json:"chargebackLabel,omitempty" jsonschema:"description=Optional, node model only: label of chargeback nodes (e.g. Chargeback)"`
+
+	// WindowDays restricts the aggregates to the last N days. If 0, all transactions are aggregated.
+	WindowDays int `json:"windowDays,omitempty" jsonschema:"minimum=0,description=Optional: only aggregate transactions from the last N days. If omitted, aggregates all transactions."`
+}
+
+// CustomerClusterConfig describes how customers are linked to the merchant and which shared attributes group them into clusters
+type CustomerClusterConfig struct {
+	// CustomerLabel is the label of customer nodes (e.g., "Customer")
+	CustomerLabel string `json:"customerLabel" jsonschema:"description=Node label of customers (e.g. Customer)"`
+
+	// CustomerIdProperty is the unique identifier of a customer (e.g., "customerId")
+	CustomerIdProperty string `json:"customerIdProperty" jsonschema:"description=Property containing the customer identifier (e.g. customerId)"`
+
+	// RelationshipTypes are the relationship types traversed between the merchant and its customers
+	RelationshipTypes []string `json:"relationshipTypes" jsonschema:"description=Relationship types on the path between merchant and customer, in any direction (e.g. [TO_MERCHANT, PERFORMS, OWNS])"`
+
+	// MaxHops bounds the path length between the merchant and its customers
+	MaxHops int `json:"maxHops,omitempty" jsonschema:"default=3,minimum=1,maximum=4,description=Maximum path length between the merchant and its customers"`
+
+	// SharedAttributes are the attributes that link customers into clusters (e.g., HAS_DEVICE, HAS_EMAIL)
+	SharedAttributes []query_builder.AttributeMapping `json:"sharedAttributes,omitempty" jsonschema:"description=Attributes that link customers into clusters (relationshipType, targetLabel, identifierProperty), e.g. HAS_DEVICE or HAS_EMAIL. Omit to only count linked customers."`
+
+	// Limit is the maximum number of clusters returned
+	Limit int `json:"limit,omitempty" jsonschema:"default=10,minimum=1,maximum=100,description=Maximum number of customer clusters to return (largest first)"`
+}
+
+// GetMerchantProfileInput defines the input parameters for the get-merchant-profile tool
+type GetMerchantProfileInput struct {
+	// MerchantId is the unique identifier for the merchant (required)
+	MerchantId string `json:"merchantId" jsonschema:"description=Merchant ID to retrieve profile for (required)"`
+
+	// MerchantConfig defines the merchant node configuration
+	MerchantConfig MerchantConfig `json:"merchantConfig" jsonschema:"description=Configuration for the merchant node (node label, ID property, base properties)"`
+
+	// AttributeMappings defines addresses, owners, terminals and other linked nodes based on the actual schema.
+	// Discovered via get-schema tool.
+	AttributeMappings []query_builder.AttributeMapping `json:"attributeMappings,omitempty" jsonschema:"description=Array of attribute mappings discovered from the schema (address, owners, terminals). Use direction=in for relationships pointing at the merchant."`
+
+	// TransactionVolume configures transaction volume and chargeback aggregates. Optional.
+	TransactionVolume *TransactionVolumeConfig `json:"transactionVolume,omitempty" jsonschema:"description=Optional: how payments to the merchant are modelled. Omit to skip volume and chargeback aggregates."`
+
+	// CustomerClusters configures linked customer clusters. Optional.
+	CustomerClusters *CustomerClusterConfig `json:"customerClusters,omitempty" jsonschema:"description=Optional: how customers reach the merchant and which attributes link them. Omit to skip linked customers."`
+
+	// PreviewQuery returns the generated query and parameters instead of executing it
+	PreviewQuery bool `json:"previewQuery,omitempty" jsonschema:"description=Optional: return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for get-merchant-profile
+func Spec() mcp.Tool {
+	return mcp.NewTool("get-merchant-profile",
+		mcp.WithDescription(`Retrieves a merchant-centric profile: merchant details, transaction volume aggregates, chargeback counts and clusters of linked customers.
+
+**SCHEMA-AWARE DESIGN:**
+This tool dynamically adapts to your database schema. It does NOT make assumptions about relationship names, node labels, or property names.
+It is driven by attribute mappings in the same way as get-customer-profile.
+
+**REQUIRED WORKFLOW:**
+1. **Call get-schema** to discover your database structure
+2. **Analyze the Merchant node** to identify linked nodes (address, owners, terminals) and build attributeMappings
+   (use direction "in" when the relationship points at the merchant)
+3. **Optionally configure transactionVolume** with how payments reach the merchant and how chargebacks are recorded
+4. **Optionally configure customerClusters** with the path from merchant to customers and the attributes customers may share
+
+**EXAMPLE:**
+{
+  "merchantId": "MER42",
+  "merchantConfig": {
+    "nodeLabel": "Merchant",
+    "idProperty": "merchantId",
+    "baseProperties": ["name", "category", "country"]
+  },
+  "attributeMappings": [
+    {
+      "relationshipType": "LOCATED_AT",
+      "targetLabel": "Address",
+      "attributeCategory": "location",
+      "includeProperties": ["city", "country"]
+    }
+  ],
+  "transactionVolume": {
+    "transactionLabel": "Transaction",
+    "merchantRelationshipType": "TO_MERCHANT",
+    "dateProperty": "date",
+    "amountProperty": "amount",
+    "chargebackRelationshipType": "HAS_CHARGEBACK",
+    "chargebackLabel": "Chargeback",
+    "windowDays": 30
+  },
+  "customerClusters": {
+    "customerLabel": "Customer",
+    "customerIdProperty": "customerId",
+    "relationshipTypes": ["TO_MERCHANT", "PERFORMS", "OWNS"],
+    "maxHops": 3,
+    "sharedAttributes": [
+      {"relationshipType": "HAS_DEVICE", "targetLabel": "Device", "identifierProperty": "deviceId"},
+      {"relationshipType": "HAS_EMAIL", "targetLabel": "Email", "identifierProperty": "address"}
+    ]
+  }
+}
+
+**WHEN TO USE THIS TOOL:**
+- Investigating merchant collusion, bust-out or transaction laundering
+- Reviewing chargeback exposure of a merchant
+- Finding groups of customers sharing devices or identifiers who all pay the same merchant
+
+**OUTPUT STRUCTURE:**
+- base_details: merchant properties (from merchantConfig.baseProperties)
+- one entry per attributeCategory (e.g. location)
+- transaction_volume: count, total, average, largest, firstDate, lastDate, chargebacks, chargebackAmount, chargebackRate (if transactionVolume configured)
+- linked_customers: {count, clusters: [{sharedAttribute, value, customers, size}]} largest clusters first (if customerClusters configured)
+
+**IMPORTANT NOTES:**
+- This tool uses OPTIONAL MATCH, so missing relationships will return empty arrays (not errors)
+- windowDays is compared with datetime(), so the transaction date property must be stored as a DATETIME
+- A cluster is a shared attribute node (e.g. one device) used by two or more of the merchant's customers`),
+		mcp.WithInputSchema[GetMerchantProfileInput](),
+		mcp.WithTitleAnnotation("Get Merchant Profile"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,429 @@
+package transaction_history
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 500
+)
+
+// transactionHistoryPage is the paginated response returned by get-transaction-history
+type transactionHistoryPage struct {
+	Transactions json.RawMessage `json:"transactions"`
+	Count        int             `json:"count"`
+	HasMore      bool            `json:"hasMore"`
+	NextCursor   string          `json:"nextCursor,omitempty"`
+}
+
+// pageCursor is the decoded form of the opaque cursor handed back to the caller
+type pageCursor struct {
+	Offset int `json:"offset"`
+}
+
+// Handler returns the tool handler function for get-transaction-history
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetTransactionHistory(ctx, request, deps)
+	}
+}
+
+func handleGetTransactionHistory(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("get-transaction-history"),
+	)
+
+	// Fill in the arguments of a saved mapping
+	if err := deps.MappingStore.Apply(ctx, &request); err != nil {
+		slog.Error("error applying saved mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Parse arguments
+	var args GetTransactionHistoryInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	cursor, err := decodeCursor(args.Cursor)
+	if err != nil {
+		slog.Error("error decoding cursor", "error", err)
+		return mcp.NewToolResultError("cursor is invalid. Pass the nextCursor value from a previous get-transaction-history response, or omit it to fetch the first page."), nil
+	}
+
+	slog.Info("retrieving transaction history",
+		"entityId", args.EntityId,
+		"entityLabel", args.EntityConfig.NodeLabel,
+		"direction", args.Direction,
+		"sortBy", args.SortBy,
+		"pageSize", args.PageSize,
+		"offset", cursor.Offset)
+
+	query := buildTransactionHistoryQuery(args)
+
+	// Fetch one extra row to find out whether another page exists
+	params := map[string]any{
+		"entityId": args.EntityId,
+		"skip":     cursor.Offset,
+		"limit":    args.PageSize + 1,
+	}
+	if args.StartDate != "" {
+		params["startDate"] = args.StartDate
+	}
+	if args.EndDate != "" {
+		params["endDate"] = args.EndDate
+	}
+	if args.MinAmount != nil {
+		params["minAmount"] = *args.MinAmount
+	}
+	if args.MaxAmount != nil {
+		params["maxAmount"] = *args.MaxAmount
+	}
+	if len(args.Counterparties) > 0 {
+		params["counterparties"] = args.Counterparties
+	}
+
+	slog.Debug("executing transaction history query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	// Execute query
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing transaction history query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	hasMore := len(records) > args.PageSize
+	if hasMore {
+		records = records[:args.PageSize]
+	}
+
+	// Format records to JSON
+	transactions, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	page := transactionHistoryPage{
+		Transactions: json.RawMessage(transactions),
+		Count:        len(records),
+		HasMore:      hasMore,
+	}
+	if hasMore {
+		page.NextCursor = encodeCursor(pageCursor{Offset: cursor.Offset + len(records)})
+	}
+
+	response, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		slog.Error("error formatting transaction history page", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// validateInput checks the entity and transaction model, then fills in the direction, sort order and page size
+func validateInput(args *GetTransactionHistoryInput) string {
+	if args.EntityId == "" {
+		return "entityId parameter is required"
+	}
+	if args.EntityConfig.NodeLabel == "" {
+		return "entityConfig.nodeLabel is required. Specify the entity node label (e.g., 'Customer', 'Account')."
+	}
+	if args.EntityConfig.IdProperty == "" {
+		return "entityConfig.idProperty is required. Specify the property name containing the unique identifier (e.g., 'customerId', 'accountNumber')."
+	}
+
+	txConfig := args.TransactionConfig
+	if txConfig.AccountLabel == "" {
+		return "transactionConfig.accountLabel is required. Specify the account node label (e.g., 'Account')."
+	}
+	if txConfig.AccountIdProperty == "" {
+		return "transactionConfig.accountIdProperty is required. Specify the property identifying accounts (e.g., 'accountNumber')."
+	}
+	if txConfig.TransactionLabel != "" {
+		if txConfig.PerformsRelationshipType == "" || txConfig.BenefitsToRelationshipType == "" {
+			return "transactionConfig.performsRelationshipType and transactionConfig.benefitsToRelationshipType are required when transactionLabel is set (e.g., 'PERFORMS' and 'BENEFITS_TO')."
+		}
+	} else if txConfig.TransactionRelationshipType == "" {
+		return "transactionConfig must describe the transaction model: set transactionLabel with performsRelationshipType/benefitsToRelationshipType for transaction nodes, or transactionRelationshipType for transaction relationships. Use get-schema to discover these first."
+	}
+	if txConfig.DateProperty == "" {
+		return "transactionConfig.dateProperty is required. Specify the property holding the transaction datetime (e.g., 'date', 'timestamp')."
+	}
+	if errMessage := query_builder.ValidateDateFormat("transactionConfig.dateFormat", txConfig.DateFormat); errMessage != "" {
+		return errMessage
+	}
+	if txConfig.AmountProperty == "" {
+		return "transactionConfig.amountProperty is required. Specify the property holding the transaction amount (e.g., 'amount')."
+	}
+	if errMessage := validateIdentifiers(args); errMessage != "" {
+		return errMessage
+	}
+
+	if args.Direction == "" {
+		args.Direction = "both"
+	}
+	if args.Direction != "out" && args.Direction != "in" && args.Direction != "both" {
+		return fmt.Sprintf("invalid direction '%s', must be one of: out, in, both", args.Direction)
+	}
+
+	if args.SortBy == "" {
+		args.SortBy = "date"
+	}
+	if args.SortBy != "date" && args.SortBy != "amount" {
+		return fmt.Sprintf("invalid sortBy '%s', must be one of: date, amount", args.SortBy)
+	}
+
+	args.SortOrder = strings.ToLower(args.SortOrder)
+	if args.SortOrder == "" {
+		args.SortOrder = "desc"
+	}
+	if args.SortOrder != "asc" && args.SortOrder != "desc" {
+		return fmt.Sprintf("invalid sortOrder '%s', must be one of: asc, desc", args.SortOrder)
+	}
+
+	if args.PageSize == 0 {
+		args.PageSize = defaultPageSize
+	}
+	if args.PageSize < 0 || args.PageSize > maxPageSize {
+		return fmt.Sprintf("pageSize must be between 1 and %d", maxPageSize)
+	}
+
+	if args.MinAmount != nil && args.MaxAmount != nil && *args.MinAmount > *args.MaxAmount {
+		return "minAmount cannot be greater than maxAmount"
+	}
+
+	return ""
+}
+
+// validateIdentifiers checks the labels, relationship types and property names written into the query
+func validateIdentifiers(args *GetTransactionHistoryInput) string {
+	if errMessage := query_builder.ValidateLabelExpression("entityConfig.nodeLabel", args.EntityConfig.NodeLabel); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateIdentifier("entityConfig.idProperty", args.EntityConfig.IdProperty); errMessage != "" {
+		return errMessage
+	}
+
+	txConfig := args.TransactionConfig
+	identifiers := []struct{ field, name string }{
+		{"transactionConfig.accountRelationshipType", txConfig.AccountRelationshipType},
+		{"transactionConfig.accountLabel", txConfig.AccountLabel},
+		{"transactionConfig.accountIdProperty", txConfig.AccountIdProperty},
+		{"transactionConfig.transactionLabel", txConfig.TransactionLabel},
+		{"transactionConfig.performsRelationshipType", txConfig.PerformsRelationshipType},
+		{"transactionConfig.benefitsToRelationshipType", txConfig.BenefitsToRelationshipType},
+		{"transactionConfig.transactionRelationshipType", txConfig.TransactionRelationshipType},
+		{"transactionConfig.idProperty", txConfig.IdProperty},
+		{"transactionConfig.dateProperty", txConfig.DateProperty},
+		{"transactionConfig.amountProperty", txConfig.AmountProperty},
+	}
+	for _, identifier := range identifiers {
+		// Settings of the other transaction model are left empty
+		if identifier.name == "" {
+			continue
+		}
+		if errMessage := query_builder.ValidateIdentifier(identifier.field, identifier.name); errMessage != "" {
+			return errMessage
+		}
+	}
+	for i, property := range txConfig.IncludeProperties {
+		if errMessage := query_builder.ValidateIdentifier(fmt.Sprintf("transactionConfig.includeProperties[%d]", i), property); errMessage != "" {
+			return errMessage
+		}
+	}
+	return ""
+}
+
+// buildTransactionHistoryQuery constructs a dynamic Cypher query based on the transaction configuration
+func buildTransactionHistoryQuery(args GetTransactionHistoryInput) string {
+	txConfig := args.TransactionConfig
+	var queryBuilder strings.Builder
+
+	// Anchor on the entity's accounts, or on the entity itself when it is the account
+	if txConfig.AccountRelationshipType != "" {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})-[:%s]->(a:%s)\n",
+			query_builder.EscapeLabelExpression(args.EntityConfig.NodeLabel), query_builder.EscapeIdentifier(args.EntityConfig.IdProperty),
+			query_builder.EscapeIdentifier(txConfig.AccountRelationshipType), query_builder.EscapeIdentifier(txConfig.AccountLabel)))
+	} else {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH (a:%s {%s: $entityId})\n",
+			query_builder.EscapeLabelExpression(args.EntityConfig.NodeLabel), query_builder.EscapeIdentifier(args.EntityConfig.IdProperty)))
+	}
+
+	// Match transactions in the requested direction(s)
+	switch args.Direction {
+	case "out", "in":
+		queryBuilder.WriteString(fmt.Sprintf("MATCH %s\n", buildTransactionPattern(txConfig, args.Direction)))
+		queryBuilder.WriteString(fmt.Sprintf("WITH a, t, cp, '%s' as direction\n", args.Direction))
+	default:
+		queryBuilder.WriteString("CALL {\n")
+		queryBuilder.WriteString("  WITH a\n")
+		queryBuilder.WriteString(fmt.Sprintf("  MATCH %s\n", buildTransactionPattern(txConfig, "out")))
+		queryBuilder.WriteString("  RETURN t, cp, 'out' as direction\n")
+		queryBuilder.WriteString("  UNION\n")
+		queryBuilder.WriteString("  WITH a\n")
+		queryBuilder.WriteString(fmt.Sprintf("  MATCH %s\n", buildTransactionPattern(txConfig, "in")))
+		queryBuilder.WriteString("  RETURN t, cp, 'in' as direction\n")
+		queryBuilder.WriteString("}\n")
+		queryBuilder.WriteString("WITH a, t, cp, direction\n")
+	}
+
+	// Apply filters
+	filters := buildFilters(args)
+	if len(filters) > 0 {
+		queryBuilder.WriteString("WHERE " + strings.Join(filters, "\n  AND ") + "\n")
+		queryBuilder.WriteString("WITH a, t, cp, direction\n")
+	}
+
+	// Stable ordering: the internal id breaks ties so pages never overlap
+	sortProperty := txConfig.DateProperty
+	if args.SortBy == "amount" {
+		sortProperty = txConfig.AmountProperty
+	}
+	queryBuilder.WriteString(fmt.Sprintf("ORDER BY t.%s %s, id(t) ASC\n", query_builder.EscapeIdentifier(sortProperty), strings.ToUpper(args.SortOrder)))
+	queryBuilder.WriteString("SKIP $skip\n")
+	queryBuilder.WriteString("LIMIT $limit\n")
+
+	transactionMap := query_builder.BuildPropertyMap("t", query_builder.AttributeMapping{
+		IdentifierProperty: txConfig.IdProperty,
+		IncludeProperties:  transactionProperties(txConfig),
+	})
+	queryBuilder.WriteString(fmt.Sprintf("RETURN %s as transaction,\n", transactionMap))
+	queryBuilder.WriteString("       direction,\n")
+	queryBuilder.WriteString(fmt.Sprintf("       a.%s as account,\n", query_builder.EscapeIdentifier(txConfig.AccountIdProperty)))
+	queryBuilder.WriteString(fmt.Sprintf("       cp.%s as counterparty", query_builder.EscapeIdentifier(txConfig.AccountIdProperty)))
+
+	return queryBuilder.String()
+}
+
+// buildTransactionPattern builds the MATCH pattern binding the transaction (t) and counterparty account (cp)
+// relative to the anchor account (a) for a single direction
+func buildTransactionPattern(txConfig TransactionConfig, direction string) string {
+	if txConfig.TransactionLabel != "" {
+		// Node model: (sender)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(receiver)
+		if direction == "in" {
+			return fmt.Sprintf("(cp:%s)-[:%s]->(t:%s)-[:%s]->(a)",
+				query_builder.EscapeIdentifier(txConfig.AccountLabel), query_builder.EscapeIdentifier(txConfig.PerformsRelationshipType),
+				query_builder.EscapeIdentifier(txConfig.TransactionLabel), query_builder.EscapeIdentifier(txConfig.BenefitsToRelationshipType))
+		}
+		return fmt.Sprintf("(a)-[:%s]->(t:%s)-[:%s]->(cp:%s)",
+			query_builder.EscapeIdentifier(txConfig.PerformsRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel),
+			query_builder.EscapeIdentifier(txConfig.BenefitsToRelationshipType), query_builder.EscapeIdentifier(txConfig.AccountLabel))
+	}
+
+	// Relationship model: (sender)-[t:TRANSACTION]->(receiver)
+	if direction == "in" {
+		return fmt.Sprintf("(a)<-[t:%s]-(cp:%s)", query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType), query_builder.EscapeIdentifier(txConfig.AccountLabel))
+	}
+	return fmt.Sprintf("(a)-[t:%s]->(cp:%s)", query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType), query_builder.EscapeIdentifier(txConfig.AccountLabel))
+}
+
+// buildFilters returns the WHERE conditions for the optional date, amount and counterparty filters
+func buildFilters(args GetTransactionHistoryInput) []string {
+	txConfig := args.TransactionConfig
+	filters := make([]string, 0)
+
+	date := transactionDate(txConfig)
+	if args.StartDate != "" {
+		filters = append(filters, query_builder.BetweenDates(date, "startDate", ""))
+	}
+	if args.EndDate != "" {
+		filters = append(filters, query_builder.BetweenDates(date, "", "endDate"))
+	}
+	if args.MinAmount != nil {
+		filters = append(filters, fmt.Sprintf("t.%s >= $minAmount", query_builder.EscapeIdentifier(txConfig.AmountProperty)))
+	}
+	if args.MaxAmount != nil {
+		filters = append(filters, fmt.Sprintf("t.%s <= $maxAmount", query_builder.EscapeIdentifier(txConfig.AmountProperty)))
+	}
+	if len(args.Counterparties) > 0 {
+		filters = append(filters, fmt.Sprintf("cp.%s IN $counterparties", query_builder.EscapeIdentifier(txConfig.AccountIdProperty)))
+	}
+
+	return filters
+}
+
+// transactionDate returns the transaction date property, in the configured format
+func transactionDate(txConfig TransactionConfig) query_builder.TimeProperty {
+	return query_builder.TimeProperty{Variable: "t", Property: txConfig.DateProperty, Format: txConfig.DateFormat}
+}
+
+// transactionProperties returns the transaction properties to project.
+// When specific properties are requested, the date and amount are always included.
+func transactionProperties(txConfig TransactionConfig) []string {
+	if len(txConfig.IncludeProperties) == 0 {
+		return nil
+	}
+
+	props := []string{txConfig.DateProperty, txConfig.AmountProperty}
+	for _, prop := range txConfig.IncludeProperties {
+		if prop == txConfig.DateProperty || prop == txConfig.AmountProperty || prop == txConfig.IdProperty {
+			continue
+		}
+		props = append(props, prop)
+	}
+	return props
+}
+
+// encodeCursor serializes a page cursor into an opaque string
+func encodeCursor(cursor pageCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses an opaque cursor. An empty cursor denotes the first page.
+func decodeCursor(encoded string) (pageCursor, error) {
+	var cursor pageCursor
+	if encoded == "" {
+		return cursor, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return cursor, fmt.Errorf("failed to parse cursor: %w", err)
+	}
+	if cursor.Offset < 0 {
+		return cursor, fmt.Errorf("cursor offset cannot be negative")
+	}
+
+	return cursor, nil
+}
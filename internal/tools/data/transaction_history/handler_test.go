@@ -0,0 +1,236 @@
+package transaction_history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func nodeModelInput() GetTransactionHistoryInput {
+	return GetTransactionHistoryInput{
+		EntityId: "CUS123",
+		EntityConfig: EntityConfig{
+			NodeLabel:  "Customer",
+			IdProperty: "customerId",
+		},
+		TransactionConfig: TransactionConfig{
+			AccountRelationshipType:    "OWNS",
+			AccountLabel:               "Account",
+			AccountIdProperty:          "accountNumber",
+			TransactionLabel:           "Transaction",
+			PerformsRelationshipType:   "PERFORMS",
+			BenefitsToRelationshipType: "BENEFITS_TO",
+			IdProperty:                 "transactionId",
+			DateProperty:               "date",
+			AmountProperty:             "amount",
+		},
+	}
+}
+
+func relationshipModelInput() GetTransactionHistoryInput {
+	return GetTransactionHistoryInput{
+		EntityId: "ACC1001",
+		EntityConfig: EntityConfig{
+			NodeLabel:  "Account",
+			IdProperty: "accountNumber",
+		},
+		TransactionConfig: TransactionConfig{
+			AccountLabel:                "Account",
+			AccountIdProperty:           "accountNumber",
+			TransactionRelationshipType: "TRANSACTION",
+			DateProperty:                "timestamp",
+			AmountProperty:              "amount",
+		},
+	}
+}
+
+func TestBuildTransactionHistoryQuery_NodeModelBothDirections(t *testing.T) {
+	args := nodeModelInput()
+	require.Empty(t, validateInput(&args))
+
+	query := buildTransactionHistoryQuery(args)
+
+	assert.Contains(t, query, "MATCH (e:Customer {customerId: $entityId})-[:OWNS]->(a:Account)")
+	assert.Contains(t, query, "CALL {")
+	assert.Contains(t, query, "MATCH (a)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(cp:Account)")
+	assert.Contains(t, query, "MATCH (cp:Account)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(a)")
+	assert.Contains(t, query, "UNION")
+	assert.Contains(t, query, "ORDER BY t.date DESC, id(t) ASC")
+	assert.Contains(t, query, "SKIP $skip")
+	assert.Contains(t, query, "LIMIT $limit")
+	assert.Contains(t, query, "t{.transactionId, .*} as transaction")
+	assert.Contains(t, query, "cp.accountNumber as counterparty")
+	assert.NotContains(t, query, "WHERE")
+}
+
+func TestBuildTransactionHistoryQuery_RelationshipModelSingleDirection(t *testing.T) {
+	args := relationshipModelInput()
+	args.Direction = "in"
+	require.Empty(t, validateInput(&args))
+
+	query := buildTransactionHistoryQuery(args)
+
+	assert.Contains(t, query, "MATCH (a:Account {accountNumber: $entityId})")
+	assert.Contains(t, query, "MATCH (a)<-[t:TRANSACTION]-(cp:Account)")
+	assert.Contains(t, query, "WITH a, t, cp, 'in' as direction")
+	assert.NotContains(t, query, "CALL {")
+	assert.Contains(t, query, "t{.*} as transaction")
+}
+
+func TestBuildTransactionHistoryQuery_Filters(t *testing.T) {
+	minAmount := 5000.0
+	maxAmount := 10000.0
+	args := nodeModelInput()
+	args.Direction = "out"
+	args.StartDate = "2024-01-01T00:00:00Z"
+	args.EndDate = "2024-03-31T23:59:59Z"
+	args.MinAmount = &minAmount
+	args.MaxAmount = &maxAmount
+	args.Counterparties = []string{"ACC2002"}
+	require.Empty(t, validateInput(&args))
+
+	query := buildTransactionHistoryQuery(args)
+
+	assert.Contains(t, query, "t.date >= datetime($startDate)")
+	assert.Contains(t, query, "t.date <= datetime($endDate)")
+	assert.Contains(t, query, "t.amount >= $minAmount")
+	assert.Contains(t, query, "t.amount <= $maxAmount")
+	assert.Contains(t, query, "cp.accountNumber IN $counterparties")
+}
+
+func TestBuildTransactionHistoryQuery_EpochMillisDates(t *testing.T) {
+	args := relationshipModelInput()
+	args.TransactionConfig.DateFormat = "epochMillis"
+	args.StartDate = "2024-01-01T00:00:00Z"
+	args.EndDate = "2024-03-31T23:59:59Z"
+	require.Empty(t, validateInput(&args))
+
+	query := buildTransactionHistoryQuery(args)
+
+	assert.Contains(t, query, "t.timestamp >= (datetime($startDate)).epochMillis")
+	assert.Contains(t, query, "t.timestamp <= (datetime($endDate)).epochMillis")
+}
+
+func TestBuildTransactionHistoryQuery_SortByAmountAscending(t *testing.T) {
+	args := nodeModelInput()
+	args.SortBy = "amount"
+	args.SortOrder = "ASC"
+	require.Empty(t, validateInput(&args))
+
+	query := buildTransactionHistoryQuery(args)
+
+	assert.Contains(t, query, "ORDER BY t.amount ASC, id(t) ASC")
+}
+
+func TestBuildTransactionHistoryQuery_IncludeProperties(t *testing.T) {
+	args := nodeModelInput()
+	args.TransactionConfig.IncludeProperties = []string{"currency", "amount", "type"}
+	require.Empty(t, validateInput(&args))
+
+	query := buildTransactionHistoryQuery(args)
+
+	// date and amount are always projected, duplicates are skipped
+	assert.Contains(t, query, "t{.transactionId, .date, .amount, .currency, .type} as transaction")
+}
+
+func TestValidateInput(t *testing.T) {
+	t.Run("applies defaults", func(t *testing.T) {
+		args := nodeModelInput()
+
+		assert.Empty(t, validateInput(&args))
+		assert.Equal(t, "both", args.Direction)
+		assert.Equal(t, "date", args.SortBy)
+		assert.Equal(t, "desc", args.SortOrder)
+		assert.Equal(t, defaultPageSize, args.PageSize)
+	})
+
+	t.Run("requires a transaction model", func(t *testing.T) {
+		args := nodeModelInput()
+		args.TransactionConfig.TransactionLabel = ""
+
+		assert.Contains(t, validateInput(&args), "transactionConfig must describe the transaction model")
+	})
+
+	t.Run("requires node model relationships", func(t *testing.T) {
+		args := nodeModelInput()
+		args.TransactionConfig.BenefitsToRelationshipType = ""
+
+		assert.Contains(t, validateInput(&args), "benefitsToRelationshipType")
+	})
+
+	t.Run("rejects invalid direction", func(t *testing.T) {
+		args := nodeModelInput()
+		args.Direction = "sideways"
+
+		assert.Contains(t, validateInput(&args), "invalid direction")
+	})
+
+	t.Run("rejects page size above maximum", func(t *testing.T) {
+		args := nodeModelInput()
+		args.PageSize = maxPageSize + 1
+
+		assert.Contains(t, validateInput(&args), "pageSize must be between")
+	})
+
+	t.Run("rejects inverted amount range", func(t *testing.T) {
+		minAmount := 100.0
+		maxAmount := 10.0
+		args := nodeModelInput()
+		args.MinAmount = &minAmount
+		args.MaxAmount = &maxAmount
+
+		assert.Contains(t, validateInput(&args), "minAmount cannot be greater than maxAmount")
+	})
+
+	t.Run("rejects unknown date format", func(t *testing.T) {
+		args := nodeModelInput()
+		args.TransactionConfig.DateFormat = "unix"
+
+		assert.Contains(t, validateInput(&args), "invalid transactionConfig.dateFormat")
+	})
+
+	t.Run("rejects control characters in transaction labels", func(t *testing.T) {
+		args := nodeModelInput()
+		args.TransactionConfig.TransactionLabel = "Transaction\n"
+
+		assert.Contains(t, validateInput(&args), "transactionConfig.transactionLabel 'Transaction?' cannot contain control characters")
+	})
+
+	t.Run("rejects empty included properties", func(t *testing.T) {
+		args := nodeModelInput()
+		args.TransactionConfig.IncludeProperties = []string{"currency", ""}
+
+		assert.Contains(t, validateInput(&args), "transactionConfig.includeProperties[1] cannot be empty")
+	})
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	encoded := encodeCursor(pageCursor{Offset: 150})
+
+	decoded, err := decodeCursor(encoded)
+
+	require.NoError(t, err)
+	assert.Equal(t, 150, decoded.Offset)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	t.Run("empty cursor is the first page", func(t *testing.T) {
+		decoded, err := decodeCursor("")
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, decoded.Offset)
+	})
+
+	t.Run("rejects malformed cursor", func(t *testing.T) {
+		_, err := decodeCursor("not a cursor!")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects negative offset", func(t *testing.T) {
+		_, err := decodeCursor(encodeCursor(pageCursor{Offset: -1}))
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,177 @@
+package transaction_history
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// EntityConfig defines the configuration for the entity node whose transactions are retrieved
+type EntityConfig struct {
+	// NodeLabel is the label of the entity node (e.g., "Customer", "Account")
+	NodeLabel string `json:"nodeLabel" jsonschema:"description=Node label of the entity (e.g. Customer, Person, Account). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+
+	// IdProperty is the property name containing the unique identifier (e.g., "customerId", "accountNumber")
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. customerId, accountNumber)"`
+}
+
+// TransactionConfig describes how transactions are modelled in the graph.
+// Two models are supported:
+//   - Node model: (:Account)-[:PERFORMS]->(:Transaction)-[:BENEFITS_TO]->(:Account), set TransactionLabel,
+//     PerformsRelationshipType and BenefitsToRelationshipType
+//   - Relationship model: (:Account)-[:TRANSACTION]->(:Account), set TransactionRelationshipType
+type TransactionConfig struct {
+	// AccountRelationshipType connects the entity to its accounts (e.g., "OWNS").
+	// If empty, the entity itself is treated as the account.
+	AccountRelationshipType string `json:"accountRelationshipType,omitempty" jsonschema:"description=Relationship from the entity to its accounts (e.g. OWNS). Omit when the entity is the account itself."`
+
+	// AccountLabel is the label of account nodes on both sides of a transaction (e.g., "Account")
+	AccountLabel string `json:"accountLabel" jsonschema:"description=Node label of accounts on both sides of a transaction (e.g. Account)"`
+
+	// AccountIdProperty identifies accounts and counterparties in results and counterparty filters (e.g., "accountNumber")
+	AccountIdProperty string `json:"accountIdProperty" jsonschema:"description=Property identifying accounts and counterparties (e.g. accountNumber)"`
+
+	// TransactionLabel is the label of transaction nodes (node model only, e.g., "Transaction")
+	TransactionLabel string `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction). Omit when transactions are relationships."`
+
+	// PerformsRelationshipType connects the sending account to the transaction node (node model only, e.g., "PERFORMS")
+	PerformsRelationshipType string `json:"performsRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the sending account to the transaction (e.g. PERFORMS)"`
+
+	// BenefitsToRelationshipType connects the transaction node to the receiving account (node model only, e.g., "BENEFITS_TO")
+	BenefitsToRelationshipType string `json:"benefitsToRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the receiving account (e.g. BENEFITS_TO)"`
+
+	// TransactionRelationshipType is the relationship between sending and receiving accounts (relationship model only, e.g., "TRANSACTION")
+	TransactionRelationshipType string `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: relationship from the sending to the receiving account (e.g. TRANSACTION)"`
+
+	// IdProperty is the unique identifier of a transaction (e.g., "transactionId")
+	IdProperty string `json:"idProperty,omitempty" jsonschema:"description=Property containing the transaction identifier (e.g. transactionId)"`
+
+	// DateProperty holds the transaction timestamp, used for date filters and date sorting (e.g., "date", "timestamp")
+	DateProperty string `json:"dateProperty" jsonschema:"description=Property holding the transaction datetime (e.g. date or timestamp)"`
+
+	// DateFormat is how DateProperty values are stored: "datetime" (default), "date" or "epochMillis"
+	DateFormat string `json:"dateFormat,omitempty" jsonschema:"enum=datetime,enum=date,enum=epochMillis,default=datetime,description=How dateProperty values are stored: datetime values (default), date values or epoch milliseconds"`
+
+	// AmountProperty holds the transaction amount, used for amount filters and amount sorting (e.g., "amount")
+	AmountProperty string `json:"amountProperty" jsonschema:"description=Property holding the transaction amount (e.g. amount)"`
+
+	// IncludeProperties lists the transaction properties to return. If empty, all properties are returned.
+	IncludeProperties []string `json:"includeProperties,omitempty" jsonschema:"description=Transaction properties to return (e.g. [currency, type, description]). If empty, returns all properties."`
+}
+
+// GetTransactionHistoryInput defines the input parameters for the get-transaction-history tool
+type GetTransactionHistoryInput struct {
+	// EntityId is the unique identifier for the entity (required)
+	EntityId string `json:"entityId" jsonschema:"description=Entity ID to retrieve transactions for (required)"`
+
+	// EntityConfig defines the entity node configuration
+	EntityConfig EntityConfig `json:"entityConfig" jsonschema:"description=Configuration for the entity node (node label and ID property)"`
+
+	// MappingName names a saved mapping supplying entityConfig when it is omitted
+	MappingName string `json:"mappingName,omitempty" jsonschema:"description=Optional: name of a mapping saved with save-schema-mapping. Supplies entityConfig when it is omitted."`
+
+	// TransactionConfig describes how accounts and transactions are modelled. Discovered via get-schema tool.
+	TransactionConfig TransactionConfig `json:"transactionConfig" jsonschema:"description=How accounts and transactions are modelled in the schema. Use get-schema to discover these first."`
+
+	// Direction filters on the flow of funds relative to the entity's accounts
+	Direction string `json:"direction,omitempty" jsonschema:"enum=out,enum=in,enum=both,default=both,description=Flow of funds relative to the entity: out (sent), in (received) or both"`
+
+	// StartDate and EndDate bound the transaction datetime (inclusive, ISO 8601)
+	StartDate string `json:"startDate,omitempty" jsonschema:"description=Optional: earliest transaction datetime (ISO 8601, e.g. 2024-01-01T00:00:00Z)"`
+	EndDate   string `json:"endDate,omitempty" jsonschema:"description=Optional: latest transaction datetime (ISO 8601, e.g. 2024-03-31T23:59:59Z)"`
+
+	// MinAmount and MaxAmount bound the transaction amount (inclusive)
+	MinAmount *float64 `json:"minAmount,omitempty" jsonschema:"description=Optional: minimum transaction amount (inclusive)"`
+	MaxAmount *float64 `json:"maxAmount,omitempty" jsonschema:"description=Optional: maximum transaction amount (inclusive)"`
+
+	// Counterparties restricts results to transactions with the given counterparty accounts
+	Counterparties []string `json:"counterparties,omitempty" jsonschema:"description=Optional: only return transactions with these counterparty account IDs (matched on accountIdProperty)"`
+
+	// SortBy and SortOrder control result ordering
+	SortBy    string `json:"sortBy,omitempty" jsonschema:"enum=date,enum=amount,default=date,description=Sort transactions by date or amount"`
+	SortOrder string `json:"sortOrder,omitempty" jsonschema:"enum=asc,enum=desc,default=desc,description=Sort order"`
+
+	// PageSize is the maximum number of transactions per page
+	PageSize int `json:"pageSize,omitempty" jsonschema:"default=50,minimum=1,maximum=500,description=Maximum number of transactions per page"`
+
+	// Cursor is the opaque nextCursor value returned by a previous call
+	Cursor string `json:"cursor,omitempty" jsonschema:"description=Optional: nextCursor value from a previous page. Omit to fetch the first page. Keep all other parameters unchanged between pages."`
+
+	// PreviewQuery returns the generated query and parameters instead of executing it
+	PreviewQuery bool `json:"previewQuery,omitempty" jsonschema:"description=Optional: return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for get-transaction-history
+func Spec() mcp.Tool {
+	return mcp.NewTool("get-transaction-history",
+		mcp.WithDescription(`Retrieves the transaction history of an entity (customer, account, etc.) with filtering, sorting and cursor-based pagination.
+
+**SCHEMA-AWARE DESIGN:**
+This tool dynamically adapts to your database schema. It does NOT make assumptions about relationship names, node labels, or property names, and supports both common transaction models:
+- **Node model:** (:Account)-[:PERFORMS]->(:Transaction)-[:BENEFITS_TO]->(:Account)
+- **Relationship model:** (:Account)-[:TRANSACTION]->(:Account)
+
+**REQUIRED WORKFLOW:**
+1. **Call get-schema** to discover your database structure
+2. **Identify how the entity reaches its accounts** (e.g., (:Customer)-[:OWNS]->(:Account)). Omit accountRelationshipType if the entity is the account.
+3. **Identify the transaction model** and fill transactionConfig accordingly
+4. **Identify the date and amount properties** of the transaction (e.g., "date", "amount")
+5. **Call this tool**, then pass nextCursor back as cursor to fetch further pages
+
+**EXAMPLE (node model):**
+{
+  "entityId": "CUS123",
+  "entityConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "transactionConfig": {
+    "accountRelationshipType": "OWNS",
+    "accountLabel": "Account",
+    "accountIdProperty": "accountNumber",
+    "transactionLabel": "Transaction",
+    "performsRelationshipType": "PERFORMS",
+    "benefitsToRelationshipType": "BENEFITS_TO",
+    "idProperty": "transactionId",
+    "dateProperty": "date",
+    "amountProperty": "amount"
+  },
+  "direction": "out",
+  "startDate": "2024-01-01T00:00:00Z",
+  "minAmount": 5000,
+  "pageSize": 50
+}
+
+**EXAMPLE (relationship model):**
+{
+  "entityId": "ACC1001",
+  "entityConfig": {"nodeLabel": "Account", "idProperty": "accountNumber"},
+  "transactionConfig": {
+    "accountLabel": "Account",
+    "accountIdProperty": "accountNumber",
+    "transactionRelationshipType": "TRANSACTION",
+    "dateProperty": "timestamp",
+    "amountProperty": "amount"
+  },
+  "sortBy": "amount"
+}
+
+**WHEN TO USE THIS TOOL:**
+- Gathering transaction evidence for SAR filings (date ranges, thresholds)
+- Reviewing flows with specific counterparties
+- Paging through large transaction histories without hand-writing SKIP/LIMIT Cypher
+
+**OUTPUT STRUCTURE:**
+{
+  "transactions": [{"transaction": {...}, "direction": "out", "account": "...", "counterparty": "..."}],
+  "count": 50,
+  "hasMore": true,
+  "nextCursor": "..."
+}
+
+**IMPORTANT NOTES:**
+- startDate/endDate are compared with datetime(), so the date property must be stored as a DATETIME
+- Ordering is stable across pages; keep every parameter except cursor unchanged when paging`),
+		mcp.WithInputSchema[GetTransactionHistoryInput](),
+		mcp.WithTitleAnnotation("Get Transaction History"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,246 @@
+package ctr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultThreshold = 10000.0
+	defaultLimit     = 50
+	maxLimit         = 500
+)
+
+// GetCTREvidenceHandler returns a handler function for the get-ctr-evidence tool
+func GetCTREvidenceHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGetCTREvidence(ctx, deps, request)
+	}
+}
+
+// handleGetCTREvidence aggregates cash activity per customer and day and returns the reportable days
+func handleGetCTREvidence(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("get-ctr-evidence"))
+
+	var args GetCTREvidenceInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("gathering CTR evidence",
+		"customerId", args.CustomerId,
+		"threshold", args.Threshold,
+		"limit", args.Limit)
+
+	query := buildCTREvidenceQuery(args)
+
+	params := map[string]any{
+		"cashValues": args.TransactionConfig.CashValues,
+		"threshold":  args.Threshold,
+		"limit":      args.Limit,
+	}
+	if args.CustomerId != "" {
+		params["customerId"] = args.CustomerId
+	}
+	if args.StartDate != "" {
+		params["startDate"] = args.StartDate
+	}
+	if args.EndDate != "" {
+		params["endDate"] = args.EndDate
+	}
+
+	slog.Debug("executing CTR evidence query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing CTR evidence query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks the customer and cash transaction settings and fills in the threshold and limit
+func validateInput(args *GetCTREvidenceInput) string {
+	if args.CustomerConfig.NodeLabel == "" || args.CustomerConfig.IdProperty == "" {
+		return "customerConfig.nodeLabel and customerConfig.idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+
+	txConfig := args.TransactionConfig
+	if txConfig.TransactionLabel != "" {
+		if txConfig.PerformsRelationshipType == "" || txConfig.BenefitsToRelationshipType == "" {
+			return "transactionConfig.performsRelationshipType and transactionConfig.benefitsToRelationshipType are required when transactionLabel is set (e.g., 'PERFORMS' and 'BENEFITS_TO')."
+		}
+	} else if txConfig.TransactionRelationshipType == "" {
+		return "transactionConfig must describe the transaction model: set transactionLabel with performsRelationshipType/benefitsToRelationshipType for transaction nodes, or transactionRelationshipType for transaction relationships. Use get-schema to discover these first."
+	}
+	if txConfig.DateProperty == "" || txConfig.AmountProperty == "" {
+		return "transactionConfig.dateProperty and transactionConfig.amountProperty are required (e.g., 'date' and 'amount')."
+	}
+	if errMessage := query_builder.ValidateDateFormat("transactionConfig.dateFormat", txConfig.DateFormat); errMessage != "" {
+		return errMessage
+	}
+	if txConfig.CashProperty == "" || len(txConfig.CashValues) == 0 {
+		return "transactionConfig.cashProperty and transactionConfig.cashValues are required to identify cash transactions (e.g., 'channel' and ['CASH'])."
+	}
+
+	if args.Threshold == 0 {
+		args.Threshold = defaultThreshold
+	}
+	if args.Threshold < 0 {
+		return "threshold cannot be negative"
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultLimit
+	}
+	if args.Limit < 1 || args.Limit > maxLimit {
+		return fmt.Sprintf("limit must be between 1 and %d", maxLimit)
+	}
+
+	return ""
+}
+
+// buildCTREvidenceQuery constructs the query aggregating cash in and cash out per customer and day
+func buildCTREvidenceQuery(args GetCTREvidenceInput) string {
+	customer := args.CustomerConfig
+	txConfig := args.TransactionConfig
+	var queryBuilder strings.Builder
+
+	if args.CustomerId != "" {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $customerId})\n", query_builder.EscapeLabelExpression(customer.NodeLabel), query_builder.EscapeIdentifier(customer.IdProperty)))
+	} else {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s)\n", query_builder.EscapeLabelExpression(customer.NodeLabel)))
+	}
+
+	// Cash in and cash out transactions, each with the account they touched
+	filter := buildCashFilter(args)
+	queryBuilder.WriteString("CALL {\n")
+	for i, direction := range []string{"in", "out"} {
+		if i > 0 {
+			queryBuilder.WriteString("  UNION\n")
+		}
+		pattern, accountVar := buildCashPattern(txConfig, direction)
+		queryBuilder.WriteString("  WITH e\n")
+		queryBuilder.WriteString(fmt.Sprintf("  MATCH %s\n", pattern))
+		queryBuilder.WriteString(fmt.Sprintf("  WHERE %s\n", filter))
+		queryBuilder.WriteString(fmt.Sprintf("  RETURN %s as account, t, '%s' as direction\n", accountVar, direction))
+	}
+	queryBuilder.WriteString("}\n")
+
+	// Aggregate per business day, keeping cash in and cash out separate
+	amount := "t." + query_builder.EscapeIdentifier(txConfig.AmountProperty)
+	queryBuilder.WriteString(fmt.Sprintf("WITH e, date(%s) as day,\n", cashDate(txConfig).Expression()))
+	queryBuilder.WriteString(fmt.Sprintf("     sum(CASE WHEN direction = 'in' THEN %s ELSE 0 END) as cashIn,\n", amount))
+	queryBuilder.WriteString(fmt.Sprintf("     sum(CASE WHEN direction = 'out' THEN %s ELSE 0 END) as cashOut,\n", amount))
+	queryBuilder.WriteString(fmt.Sprintf("     coalesce(max(CASE WHEN direction = 'in' THEN %s END), 0) as largestIn,\n", amount))
+	queryBuilder.WriteString(fmt.Sprintf("     coalesce(max(CASE WHEN direction = 'out' THEN %s END), 0) as largestOut,\n", amount))
+	if txConfig.AccountIdProperty != "" {
+		queryBuilder.WriteString(fmt.Sprintf("     collect(DISTINCT account.%s) as accounts,\n", query_builder.EscapeIdentifier(txConfig.AccountIdProperty)))
+	}
+	queryBuilder.WriteString("     collect({direction: CASE direction WHEN 'in' THEN 'cash_in' ELSE 'cash_out' END, transaction: properties(t)}) as transactions\n")
+	queryBuilder.WriteString("WHERE cashIn > $threshold OR cashOut > $threshold\n")
+
+	customerProperties := "properties(e)"
+	if len(customer.Properties) > 0 {
+		customerProperties = fmt.Sprintf("e{.%s}", strings.Join(query_builder.EscapeIdentifiers(customer.Properties), ", ."))
+	}
+
+	queryBuilder.WriteString(fmt.Sprintf("RETURN e.%s as customerId,\n", query_builder.EscapeIdentifier(customer.IdProperty)))
+	queryBuilder.WriteString(fmt.Sprintf("       %s as partI,\n", customerProperties))
+	queryBuilder.WriteString("       {\n")
+	queryBuilder.WriteString("         transactionDate: toString(day),\n")
+	queryBuilder.WriteString("         totalCashIn: cashIn,\n")
+	queryBuilder.WriteString("         totalCashOut: cashOut,\n")
+	if txConfig.AccountIdProperty != "" {
+		queryBuilder.WriteString("         accounts: accounts,\n")
+	}
+	queryBuilder.WriteString("         multipleTransactions: size(transactions) > 1\n")
+	queryBuilder.WriteString("       } as partII,\n")
+	queryBuilder.WriteString("       (cashIn > $threshold AND largestIn <= $threshold) OR (cashOut > $threshold AND largestOut <= $threshold) as aggregated,\n")
+	queryBuilder.WriteString("       transactions\n")
+	queryBuilder.WriteString(fmt.Sprintf("ORDER BY day DESC, e.%s ASC\n", query_builder.EscapeIdentifier(customer.IdProperty)))
+	queryBuilder.WriteString("LIMIT $limit")
+
+	return queryBuilder.String()
+}
+
+// buildCashPattern builds the pattern binding the cash transaction (t) for a single direction
+// and returns the variable holding the account it touched
+func buildCashPattern(txConfig CashTransactionConfig, direction string) (string, string) {
+	account := "(e)"
+	accountVar := "e"
+	if txConfig.AccountRelationshipType != "" {
+		accountLabel := ""
+		if txConfig.AccountLabel != "" {
+			accountLabel = ":" + query_builder.EscapeIdentifier(txConfig.AccountLabel)
+		}
+		account = fmt.Sprintf("(e)-[:%s]->(acct%s)", query_builder.EscapeIdentifier(txConfig.AccountRelationshipType), accountLabel)
+		accountVar = "acct"
+	}
+
+	if txConfig.TransactionLabel != "" {
+		// Node model: cash in benefits the account, cash out is performed by it
+		if direction == "in" {
+			return fmt.Sprintf("%s<-[:%s]-(t:%s)", account, query_builder.EscapeIdentifier(txConfig.BenefitsToRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel)), accountVar
+		}
+		return fmt.Sprintf("%s-[:%s]->(t:%s)", account, query_builder.EscapeIdentifier(txConfig.PerformsRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel)), accountVar
+	}
+
+	// Relationship model: the other end is the cash source or destination
+	if direction == "in" {
+		return fmt.Sprintf("%s<-[t:%s]-()", account, query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType)), accountVar
+	}
+	return fmt.Sprintf("%s-[t:%s]->()", account, query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType)), accountVar
+}
+
+// buildCashFilter returns the WHERE predicate selecting cash transactions within the optional date range
+func buildCashFilter(args GetCTREvidenceInput) string {
+	txConfig := args.TransactionConfig
+	filters := []string{fmt.Sprintf("t.%s IN $cashValues", query_builder.EscapeIdentifier(txConfig.CashProperty))}
+	if args.StartDate != "" {
+		filters = append(filters, query_builder.BetweenDates(cashDate(txConfig), "startDate", ""))
+	}
+	if args.EndDate != "" {
+		filters = append(filters, query_builder.BetweenDates(cashDate(txConfig), "", "endDate"))
+	}
+	return strings.Join(filters, " AND ")
+}
+
+// cashDate returns the transaction date property, in the configured format
+func cashDate(txConfig CashTransactionConfig) query_builder.TimeProperty {
+	return query_builder.TimeProperty{Variable: "t", Property: txConfig.DateProperty, Format: txConfig.DateFormat}
+}
@@ -0,0 +1,237 @@
+package ctr_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/ctr"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+var customerConfig = map[string]any{
+	"nodeLabel":  "Customer",
+	"idProperty": "customerId",
+	"properties": []string{"firstName", "lastName"},
+}
+
+var nodeTransactionConfig = map[string]any{
+	"accountRelationshipType":    "OWNS",
+	"accountLabel":               "Account",
+	"accountIdProperty":          "accountNumber",
+	"transactionLabel":           "Transaction",
+	"performsRelationshipType":   "PERFORMS",
+	"benefitsToRelationshipType": "BENEFITS_TO",
+	"dateProperty":               "date",
+	"amountProperty":             "amount",
+	"cashProperty":               "channel",
+	"cashValues":                 []string{"CASH"},
+}
+
+func TestGetCTREvidenceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("get-ctr-evidence").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("all customers with defaults", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"cashValues": []string{"CASH"},
+				"threshold":  10000.0,
+				"limit":      50,
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "MATCH (e:Customer)\n") {
+					t.Errorf("Expected all customers match, got: %s", query)
+				}
+				if !strings.Contains(query, "MATCH (e)-[:OWNS]->(acct:Account)<-[:BENEFITS_TO]-(t:Transaction)") {
+					t.Errorf("Expected cash in pattern, got: %s", query)
+				}
+				if !strings.Contains(query, "MATCH (e)-[:OWNS]->(acct:Account)-[:PERFORMS]->(t:Transaction)") {
+					t.Errorf("Expected cash out pattern, got: %s", query)
+				}
+				if !strings.Contains(query, "WHERE t.channel IN $cashValues\n") {
+					t.Errorf("Expected cash filter, got: %s", query)
+				}
+				if !strings.Contains(query, "WHERE cashIn > $threshold OR cashOut > $threshold") {
+					t.Errorf("Expected threshold filter, got: %s", query)
+				}
+				if !strings.Contains(query, "e{.firstName, .lastName} as partI") {
+					t.Errorf("Expected customer projection, got: %s", query)
+				}
+				if !strings.Contains(query, "collect(DISTINCT account.accountNumber) as accounts") {
+					t.Errorf("Expected account collection, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := ctr.GetCTREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig":    customerConfig,
+					"transactionConfig": nodeTransactionConfig,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("single customer with relationship model and date range", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"customerId": "CUS123",
+				"cashValues": []string{"CASH_DEPOSIT", "CASH_WITHDRAWAL"},
+				"threshold":  3000.0,
+				"limit":      10,
+				"startDate":  "2024-03-01T00:00:00Z",
+				"endDate":    "2024-03-31T23:59:59Z",
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "MATCH (e:Account {accountNumber: $customerId})") {
+					t.Errorf("Expected single customer match, got: %s", query)
+				}
+				if !strings.Contains(query, "MATCH (e)<-[t:TRANSFER]-()") {
+					t.Errorf("Expected relationship model cash in pattern, got: %s", query)
+				}
+				if !strings.Contains(query, "RETURN e as account, t, 'out' as direction") {
+					t.Errorf("Expected customer to act as the account, got: %s", query)
+				}
+				if !strings.Contains(query, "t.timestamp >= datetime($startDate) AND t.timestamp <= datetime($endDate)") {
+					t.Errorf("Expected date range filter, got: %s", query)
+				}
+				if !strings.Contains(query, "properties(e) as partI") {
+					t.Errorf("Expected all customer properties, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := ctr.GetCTREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerId":     "CUS123",
+					"customerConfig": map[string]any{"nodeLabel": "Account", "idProperty": "accountNumber"},
+					"transactionConfig": map[string]any{
+						"transactionRelationshipType": "TRANSFER",
+						"dateProperty":                "timestamp",
+						"amountProperty":              "amount",
+						"cashProperty":                "type",
+						"cashValues":                  []string{"CASH_DEPOSIT", "CASH_WITHDRAWAL"},
+					},
+					"threshold": 3000,
+					"limit":     10,
+					"startDate": "2024-03-01T00:00:00Z",
+					"endDate":   "2024-03-31T23:59:59Z",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("missing cash configuration", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := ctr.GetCTREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig": customerConfig,
+					"transactionConfig": map[string]any{
+						"transactionRelationshipType": "TRANSFER",
+						"dateProperty":                "date",
+						"amountProperty":              "amount",
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing cash configuration")
+		}
+	})
+
+	t.Run("database query failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection failed"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := ctr.GetCTREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig":    customerConfig,
+					"transactionConfig": nodeTransactionConfig,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for database failure")
+		}
+	})
+}
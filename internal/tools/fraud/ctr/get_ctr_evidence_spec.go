@@ -0,0 +1,90 @@
+package ctr
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// CustomerConfig identifies the customer nodes whose cash activity is aggregated
+type CustomerConfig struct {
+	NodeLabel  string   `json:"nodeLabel" jsonschema:"description=Node label of customers (e.g. Customer, Person). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+	IdProperty string   `json:"idProperty" jsonschema:"description=Property name for the unique identifier (e.g. customerId)"`
+	Properties []string `json:"properties,omitempty" jsonschema:"description=Customer properties for Form 112 Part I (e.g. [firstName, lastName, dateOfBirth, ssn]). If empty, returns all properties."`
+}
+
+// CashTransactionConfig describes how the customer reaches cash transactions.
+// Cash in is money received by the customer's account, cash out is money sent from it.
+type CashTransactionConfig struct {
+	AccountRelationshipType     string   `json:"accountRelationshipType,omitempty" jsonschema:"description=Relationship from the customer to its accounts (e.g. OWNS). Omit when the customer is the account itself."`
+	AccountLabel                string   `json:"accountLabel,omitempty" jsonschema:"description=Node label of accounts (e.g. Account)"`
+	AccountIdProperty           string   `json:"accountIdProperty,omitempty" jsonschema:"description=Property identifying accounts in results (e.g. accountNumber)"`
+	TransactionLabel            string   `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction)"`
+	PerformsRelationshipType    string   `json:"performsRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the paying account to the transaction (e.g. PERFORMS)"`
+	BenefitsToRelationshipType  string   `json:"benefitsToRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the receiving account (e.g. BENEFITS_TO)"`
+	TransactionRelationshipType string   `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: relationship between the paying and receiving nodes (e.g. TRANSACTION)"`
+	DateProperty                string   `json:"dateProperty" jsonschema:"description=Property holding the transaction datetime (e.g. date)"`
+	DateFormat                  string   `json:"dateFormat,omitempty" jsonschema:"enum=datetime,enum=date,enum=epochMillis,default=datetime,description=How dateProperty values are stored: datetime values (default), date values or epoch milliseconds"`
+	AmountProperty              string   `json:"amountProperty" jsonschema:"description=Property holding the transaction amount in USD (e.g. amount)"`
+	CashProperty                string   `json:"cashProperty" jsonschema:"description=Property identifying the transaction channel or type (e.g. channel, type)"`
+	CashValues                  []string `json:"cashValues" jsonschema:"description=Values of cashProperty that denote cash (e.g. [CASH, CASH_DEPOSIT, CASH_WITHDRAWAL])"`
+}
+
+type GetCTREvidenceInput struct {
+	CustomerId        string                `json:"customerId,omitempty" jsonschema:"description=Optional: restrict to a single customer. If empty, all customers are checked."`
+	CustomerConfig    CustomerConfig        `json:"customerConfig" jsonschema:"description=Configuration for customer nodes. Discovered from get-schema."`
+	TransactionConfig CashTransactionConfig `json:"transactionConfig" jsonschema:"description=How customers reach cash transactions. Discovered from get-schema."`
+	Threshold         float64               `json:"threshold,omitempty" jsonschema:"default=10000,description=Reporting threshold in USD. Daily cash in or cash out totals above it are reportable."`
+	StartDate         string                `json:"startDate,omitempty" jsonschema:"description=Optional: earliest transaction datetime (ISO 8601)"`
+	EndDate           string                `json:"endDate,omitempty" jsonschema:"description=Optional: latest transaction datetime (ISO 8601)"`
+	Limit             int                   `json:"limit,omitempty" jsonschema:"default=50,description=Maximum number of reportable customer-days to return (1-500)"`
+	PreviewQuery      bool                  `json:"previewQuery,omitempty" jsonschema:"default=false,description=Return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// GetCTREvidenceSpec returns the tool specification for get-ctr-evidence
+func GetCTREvidenceSpec() mcp.Tool {
+	return mcp.NewTool("get-ctr-evidence",
+		mcp.WithDescription(`Identifies Currency Transaction Report (CTR) obligations and returns the data points needed for FinCEN Form 112.
+
+**CTR RULES APPLIED:**
+- A CTR is required for cash transactions of more than $10,000 in a single business day
+- Multiple cash transactions by or on behalf of the same person are aggregated per day
+- Cash in and cash out are aggregated separately and never netted
+- Reportable days are flagged as aggregated when no single transaction exceeded the threshold on its own (a possible structuring indicator worth reviewing for a SAR)
+
+**FORM 112 DATA RETURNED PER CUSTOMER-DAY:**
+- **Part I (person involved):** customer ID and configured customer properties
+- **Part II (amount and type of transaction):** transaction date, total cash in, total cash out, accounts affected, multiple-transactions indicator
+- **transactions:** the individual cash transactions making up the totals
+Part III (financial institution) is not stored in the graph and must be supplied by the filer.
+
+**REQUIRED WORKFLOW - Schema Discovery:**
+1. **Call get-schema tool** to retrieve the database schema
+2. **Find how cash is marked** on transactions (e.g. a channel or type property) and set cashProperty/cashValues
+3. **Configure the transaction model** as for get-transaction-history
+
+**Example:**
+{
+  "customerConfig": {"nodeLabel": "Customer", "idProperty": "customerId", "properties": ["firstName", "lastName", "dateOfBirth"]},
+  "transactionConfig": {
+    "accountRelationshipType": "OWNS",
+    "accountLabel": "Account",
+    "accountIdProperty": "accountNumber",
+    "transactionLabel": "Transaction",
+    "performsRelationshipType": "PERFORMS",
+    "benefitsToRelationshipType": "BENEFITS_TO",
+    "dateProperty": "date",
+    "amountProperty": "amount",
+    "cashProperty": "channel",
+    "cashValues": ["CASH"]
+  },
+  "startDate": "2024-03-01T00:00:00Z",
+  "endDate": "2024-03-31T23:59:59Z"
+}
+
+**Returns:**
+- One row per reportable customer-day, most recent first: {customerId, partI, partII, aggregated, transactions}`),
+		mcp.WithInputSchema[GetCTREvidenceInput](),
+		mcp.WithTitleAnnotation("Get CTR Evidence"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
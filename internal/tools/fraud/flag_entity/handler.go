@@ -0,0 +1,165 @@
+package flag_entity
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+// Handler returns the tool handler function for flag-entity.
+// allowedProperties is the comma-separated list of flag properties the tool may set.
+func Handler(deps *tools.ToolDependencies, allowedProperties string) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	allowed := parseAllowedProperties(allowedProperties)
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleFlagEntity(ctx, request, deps, allowed)
+	}
+}
+
+func handleFlagEntity(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies, allowedProperties []string) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("flag-entity"),
+	)
+
+	// Parse arguments
+	var args FlagEntityInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and the flag allowlist
+	if errMessage := validateInput(args, allowedProperties); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("flagging entity",
+		"nodeLabel", args.NodeLabel,
+		"entityId", args.EntityId,
+		"flags", len(args.Flags))
+
+	query := buildFlagEntityQuery(args, allowedProperties)
+	params := map[string]any{
+		"entityId": args.EntityId,
+		"flags":    args.Flags,
+	}
+
+	slog.Debug("executing flag entity query", "query", query)
+
+	// Execute query
+	records, err := deps.DBService.ExecuteWriteQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing flag entity query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if len(records) == 0 {
+		errMessage := fmt.Sprintf("no %s found with %s '%s'", args.NodeLabel, args.IdProperty, args.EntityId)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// parseAllowedProperties splits the configured allowlist, dropping blanks and names that are not valid identifiers
+func parseAllowedProperties(allowedProperties string) []string {
+	allowed := make([]string, 0)
+	for _, property := range strings.Split(allowedProperties, ",") {
+		property = strings.TrimSpace(property)
+		if property == "" || slices.Contains(allowed, property) {
+			continue
+		}
+		if !query_builder.IsPlainIdentifier(property) {
+			slog.Warn("ignoring invalid flag property name", "property", property)
+			continue
+		}
+		allowed = append(allowed, property)
+	}
+	return allowed
+}
+
+// validateInput checks required parameters and that every flag is allowed and has a scalar value.
+func validateInput(args FlagEntityInput, allowedProperties []string) string {
+	if len(allowedProperties) == 0 {
+		return "no flag properties are allowed; configure NEO4J_FLAG_ALLOWED_PROPERTIES"
+	}
+	if args.EntityId == "" {
+		return "entityId parameter is required"
+	}
+	if args.NodeLabel == "" || args.IdProperty == "" {
+		return "nodeLabel and idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+	if !query_builder.IsPlainIdentifier(args.NodeLabel) || !query_builder.IsPlainIdentifier(args.IdProperty) {
+		return "nodeLabel and idProperty must be valid identifiers"
+	}
+	if len(args.Flags) == 0 {
+		return "at least one flag is required"
+	}
+
+	// Report disallowed keys in a stable order
+	keys := make([]string, 0, len(args.Flags))
+	for key := range args.Flags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !slices.Contains(allowedProperties, key) {
+			return fmt.Sprintf("flag '%s' is not allowed, must be one of: %s", key, strings.Join(allowedProperties, ", "))
+		}
+		switch args.Flags[key].(type) {
+		case nil, string, bool, float64, int, int64:
+		default:
+			return fmt.Sprintf("flag '%s' must be a string, number, boolean or null", key)
+		}
+	}
+
+	return ""
+}
+
+// buildFlagEntityQuery constructs the query that sets the flags and returns every allowed flag property
+func buildFlagEntityQuery(args FlagEntityInput, allowedProperties []string) string {
+	var queryBuilder strings.Builder
+
+	projection := make([]string, 0, len(allowedProperties))
+	for _, property := range allowedProperties {
+		projection = append(projection, "."+query_builder.EscapeIdentifier(property))
+	}
+
+	queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})\n", query_builder.EscapeIdentifier(args.NodeLabel), query_builder.EscapeIdentifier(args.IdProperty)))
+	queryBuilder.WriteString("SET e += $flags\n")
+	queryBuilder.WriteString(fmt.Sprintf("RETURN e.%s as entityId,\n", query_builder.EscapeIdentifier(args.IdProperty)))
+	queryBuilder.WriteString("       labels(e) as labels,\n")
+	queryBuilder.WriteString(fmt.Sprintf("       e{%s} as flags", strings.Join(projection, ", ")))
+
+	return queryBuilder.String()
+}
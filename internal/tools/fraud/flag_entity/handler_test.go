@@ -0,0 +1,252 @@
+package flag_entity_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/config"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/flag_entity"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFlagEntityHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("flag-entity").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("sets allowed flags", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"entityId": "CUS123",
+				"flags": map[string]any{
+					"underReview": true,
+					"riskTier":    "HIGH",
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "MATCH (e:Customer {customerId: $entityId})") {
+					t.Errorf("Expected entity match, got: %s", query)
+				}
+				if !strings.Contains(query, "SET e += $flags") {
+					t.Errorf("Expected flags to be set, got: %s", query)
+				}
+				if !strings.Contains(query, "e{.underReview, .riskTier, .reviewedBy, .reviewedAt, .reviewNotes} as flags") {
+					t.Errorf("Expected allowed flag projection, got: %s", query)
+				}
+				return []*neo4j.Record{{Keys: []string{"entityId"}, Values: []any{"CUS123"}}}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"entityId": "CUS123"}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := flag_entity.Handler(deps, config.DefaultFlagAllowedProperties)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"nodeLabel":  "Customer",
+					"idProperty": "customerId",
+					"entityId":   "CUS123",
+					"flags": map[string]any{
+						"underReview": true,
+						"riskTier":    "HIGH",
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("rejects flag outside the allowlist", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := flag_entity.Handler(deps, config.DefaultFlagAllowedProperties)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"nodeLabel":  "Customer",
+					"idProperty": "customerId",
+					"entityId":   "CUS123",
+					"flags": map[string]any{
+						"underReview": true,
+						"balance":     0,
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for disallowed flag")
+		}
+	})
+
+	t.Run("custom allowlist", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "e{.watchlist} as flags") {
+					t.Errorf("Expected custom flag projection, got: %s", query)
+				}
+				return []*neo4j.Record{{Keys: []string{"entityId"}, Values: []any{"ACC1"}}}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"entityId": "ACC1"}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		// Invalid and duplicate names in the configuration are ignored
+		handler := flag_entity.Handler(deps, " watchlist , watchlist, bad-name,")
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"nodeLabel":  "Account",
+					"idProperty": "accountNumber",
+					"entityId":   "ACC1",
+					"flags":      map[string]any{"watchlist": "sanctions"},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("rejects non-scalar values", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := flag_entity.Handler(deps, config.DefaultFlagAllowedProperties)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"nodeLabel":  "Customer",
+					"idProperty": "customerId",
+					"entityId":   "CUS123",
+					"flags":      map[string]any{"reviewNotes": map[string]any{"nested": true}},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for non-scalar value")
+		}
+	})
+
+	t.Run("entity not found", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := flag_entity.Handler(deps, config.DefaultFlagAllowedProperties)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"nodeLabel":  "Customer",
+					"idProperty": "customerId",
+					"entityId":   "MISSING",
+					"flags":      map[string]any{"underReview": true},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing entity")
+		}
+	})
+
+	t.Run("database query failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection failed"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := flag_entity.Handler(deps, config.DefaultFlagAllowedProperties)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"nodeLabel":  "Customer",
+					"idProperty": "customerId",
+					"entityId":   "CUS123",
+					"flags":      map[string]any{"underReview": true},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for database failure")
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package flag_entity
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type FlagEntityInput struct {
+	NodeLabel  string         `json:"nodeLabel" jsonschema:"description=The node label of the entity to flag (e.g. Customer, Account)"`
+	IdProperty string         `json:"idProperty" jsonschema:"description=The property name containing the unique identifier (e.g. customerId, accountNumber)"`
+	EntityId   string         `json:"entityId" jsonschema:"description=The identifier value of the entity to flag"`
+	Flags      map[string]any `json:"flags" jsonschema:"description=Flag properties to set. Keys must be in the server's allowed flag list. Values must be strings, numbers or booleans; null removes the flag."`
+}
+
+// Spec returns the MCP tool specification for entity flagging
+func Spec() mcp.Tool {
+	return mcp.NewTool("flag-entity",
+		mcp.WithDescription(`Sets investigation review flags on a single node identified by label and ID, so the outcome of an investigation is visible in the graph.
+
+**SAFETY:**
+Only properties on the server's allowed flag list can be written. The list is configured with NEO4J_FLAG_ALLOWED_PROPERTIES
+and defaults to: underReview, riskTier, reviewedBy, reviewedAt, reviewNotes.
+Any other key is rejected and nothing is written. This is a write tool and is not available when the server runs in read-only mode.
+
+**VALUES:**
+- Strings, numbers and booleans are stored as given
+- null removes the flag from the node
+
+**Example:**
+{
+  "nodeLabel": "Customer",
+  "idProperty": "customerId",
+  "entityId": "CUS123",
+  "flags": {
+    "underReview": true,
+    "riskTier": "HIGH",
+    "reviewedBy": "analyst.jane",
+    "reviewedAt": "2024-05-01T10:00:00Z"
+  }
+}
+
+**When to use this tool:**
+- Marking entities surfaced by detection tools as under review
+- Recording the risk tier from compute-risk-score
+- Clearing flags once a review is closed
+
+**Returns:**
+- entityId, labels, and the current values of all allowed flag properties`),
+		mcp.WithInputSchema[FlagEntityInput](),
+		mcp.WithTitleAnnotation("Flag Entity"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
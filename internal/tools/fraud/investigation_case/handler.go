@@ -0,0 +1,310 @@
+package investigation_case
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultStatus   = "open"
+	defaultSeverity = "medium"
+)
+
+var (
+	validStatuses   = []string{"open", "in_review", "escalated", "closed"}
+	validSeverities = []string{"low", "medium", "high", "critical"}
+)
+
+// Handler returns the tool handler function for create-investigation-case
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleCreateInvestigationCase(ctx, request, deps)
+	}
+}
+
+func handleCreateInvestigationCase(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("create-investigation-case"),
+	)
+
+	// Parse arguments
+	var args CreateInvestigationCaseInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("creating investigation case",
+		"title", args.Title,
+		"severity", args.Severity,
+		"subjects", len(args.Subjects),
+		"evidence", len(args.Evidence),
+		"alerts", len(args.Alerts))
+
+	query := buildCreateCaseQuery(args)
+	params := buildParams(args)
+
+	slog.Debug("executing create investigation case query", "query", query)
+
+	// Execute query
+	records, err := deps.DBService.ExecuteWriteQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing create investigation case query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// The query only creates the case when every referenced node was matched
+	if len(records) == 0 {
+		errMessage := "case was not created: one or more subjects or evidence nodes were not found, or a case with this caseId already exists"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks the case status and severity, the model identifiers and every entity reference
+func validateInput(args *CreateInvestigationCaseInput) string {
+	if strings.TrimSpace(args.Title) == "" {
+		return "title parameter is required"
+	}
+	if len(args.Subjects) == 0 {
+		return "at least one subject is required"
+	}
+
+	if args.Status == "" {
+		args.Status = defaultStatus
+	}
+	if !slices.Contains(validStatuses, args.Status) {
+		return fmt.Sprintf("invalid status '%s', must be one of: %s", args.Status, strings.Join(validStatuses, ", "))
+	}
+	if args.Severity == "" {
+		args.Severity = defaultSeverity
+	}
+	if !slices.Contains(validSeverities, args.Severity) {
+		return fmt.Sprintf("invalid severity '%s', must be one of: %s", args.Severity, strings.Join(validSeverities, ", "))
+	}
+
+	if args.ModelConfig == nil {
+		args.ModelConfig = &CaseModelConfig{}
+	}
+	applyModelDefaults(args.ModelConfig)
+	for _, identifier := range []string{
+		args.ModelConfig.CaseLabel,
+		args.ModelConfig.AlertLabel,
+		args.ModelConfig.InvolvesRelationshipType,
+		args.ModelConfig.HasAlertRelationshipType,
+		args.ModelConfig.HasEvidenceRelationshipType,
+	} {
+		if !query_builder.IsPlainIdentifier(identifier) {
+			return fmt.Sprintf("invalid identifier '%s' in modelConfig", identifier)
+		}
+	}
+
+	for i, subject := range args.Subjects {
+		if errMessage := validateReference(subject, fmt.Sprintf("subjects[%d]", i)); errMessage != "" {
+			return errMessage
+		}
+	}
+	for i, evidence := range args.Evidence {
+		if errMessage := validateReference(evidence, fmt.Sprintf("evidence[%d]", i)); errMessage != "" {
+			return errMessage
+		}
+	}
+	for i := range args.Alerts {
+		alert := &args.Alerts[i]
+		if alert.AlertType == "" {
+			return fmt.Sprintf("alerts[%d].alertType is required", i)
+		}
+		if alert.Severity == "" {
+			alert.Severity = args.Severity
+		}
+		if !slices.Contains(validSeverities, alert.Severity) {
+			return fmt.Sprintf("invalid severity '%s' for alerts[%d], must be one of: %s", alert.Severity, i, strings.Join(validSeverities, ", "))
+		}
+		for j, evidence := range alert.Evidence {
+			if errMessage := validateReference(evidence, fmt.Sprintf("alerts[%d].evidence[%d]", i, j)); errMessage != "" {
+				return errMessage
+			}
+		}
+	}
+
+	return ""
+}
+
+func applyModelDefaults(config *CaseModelConfig) {
+	if config.CaseLabel == "" {
+		config.CaseLabel = "Case"
+	}
+	if config.AlertLabel == "" {
+		config.AlertLabel = "Alert"
+	}
+	if config.InvolvesRelationshipType == "" {
+		config.InvolvesRelationshipType = "INVOLVES"
+	}
+	if config.HasAlertRelationshipType == "" {
+		config.HasAlertRelationshipType = "HAS_ALERT"
+	}
+	if config.HasEvidenceRelationshipType == "" {
+		config.HasEvidenceRelationshipType = "HAS_EVIDENCE"
+	}
+}
+
+func validateReference(reference EntityReference, path string) string {
+	if reference.NodeLabel == "" || reference.IdProperty == "" || reference.EntityId == "" {
+		return fmt.Sprintf("%s requires nodeLabel, idProperty and entityId", path)
+	}
+	if !query_builder.IsPlainIdentifier(reference.NodeLabel) || !query_builder.IsPlainIdentifier(reference.IdProperty) {
+		return fmt.Sprintf("%s has an invalid nodeLabel or idProperty", path)
+	}
+	return ""
+}
+
+// buildCreateCaseQuery constructs the case creation query.
+// All referenced nodes are matched before anything is created, so a missing node produces no rows and no writes.
+func buildCreateCaseQuery(args CreateInvestigationCaseInput) string {
+	var queryBuilder strings.Builder
+	model := args.ModelConfig
+
+	// Refuse to create a second case with a caller-supplied ID
+	if args.CaseId != "" {
+		queryBuilder.WriteString(fmt.Sprintf("OPTIONAL MATCH (existing:%s {caseId: $caseId})\n", query_builder.EscapeIdentifier(model.CaseLabel)))
+		queryBuilder.WriteString("WITH count(existing) as existingCases\n")
+		queryBuilder.WriteString("WHERE existingCases = 0\n")
+	}
+
+	var matchedVars []string
+	for i, subject := range args.Subjects {
+		varName := fmt.Sprintf("s%d", i)
+		queryBuilder.WriteString(buildReferenceMatch(varName, subject, fmt.Sprintf("subject%dId", i)))
+		matchedVars = append(matchedVars, varName)
+	}
+	for i, evidence := range args.Evidence {
+		varName := fmt.Sprintf("ev%d", i)
+		queryBuilder.WriteString(buildReferenceMatch(varName, evidence, fmt.Sprintf("evidence%dId", i)))
+		matchedVars = append(matchedVars, varName)
+	}
+	for i, alert := range args.Alerts {
+		for j, evidence := range alert.Evidence {
+			varName := fmt.Sprintf("a%dev%d", i, j)
+			queryBuilder.WriteString(buildReferenceMatch(varName, evidence, fmt.Sprintf("alert%dEvidence%dId", i, j)))
+			matchedVars = append(matchedVars, varName)
+		}
+	}
+	queryBuilder.WriteString(fmt.Sprintf("WITH %s\n", strings.Join(matchedVars, ", ")))
+	queryBuilder.WriteString("LIMIT 1\n")
+
+	// Case node and its links
+	queryBuilder.WriteString(fmt.Sprintf("CREATE (c:%s)\n", query_builder.EscapeIdentifier(model.CaseLabel)))
+	queryBuilder.WriteString("SET c = $caseProperties, c.caseId = coalesce($caseProperties.caseId, randomUUID()), c.createdAt = datetime()\n")
+	for i := range args.Subjects {
+		queryBuilder.WriteString(fmt.Sprintf("CREATE (c)-[:%s]->(s%d)\n", query_builder.EscapeIdentifier(model.InvolvesRelationshipType), i))
+	}
+	for i := range args.Evidence {
+		queryBuilder.WriteString(fmt.Sprintf("CREATE (c)-[:%s]->(ev%d)\n", query_builder.EscapeIdentifier(model.HasEvidenceRelationshipType), i))
+	}
+
+	// Alert nodes and their evidence
+	alertMaps := make([]string, 0, len(args.Alerts))
+	for i, alert := range args.Alerts {
+		queryBuilder.WriteString(fmt.Sprintf("CREATE (c)-[:%s]->(a%d:%s)\n", query_builder.EscapeIdentifier(model.HasAlertRelationshipType), i, query_builder.EscapeIdentifier(model.AlertLabel)))
+		queryBuilder.WriteString(fmt.Sprintf("SET a%d = $alert%d, a%d.alertId = randomUUID(), a%d.createdAt = datetime()\n", i, i, i, i))
+		for j := range alert.Evidence {
+			queryBuilder.WriteString(fmt.Sprintf("CREATE (a%d)-[:%s]->(a%dev%d)\n", i, query_builder.EscapeIdentifier(model.HasEvidenceRelationshipType), i, j))
+		}
+		alertMaps = append(alertMaps, fmt.Sprintf("a%d{.*}", i))
+	}
+
+	queryBuilder.WriteString("RETURN c.caseId as caseId,\n")
+	queryBuilder.WriteString("       c{.*} as case,\n")
+	queryBuilder.WriteString(fmt.Sprintf("       [%s] as alerts,\n", strings.Join(alertMaps, ", ")))
+	queryBuilder.WriteString(fmt.Sprintf("       %d as subjectsLinked,\n", len(args.Subjects)))
+	queryBuilder.WriteString(fmt.Sprintf("       %d as evidenceLinked", len(args.Evidence)))
+
+	return queryBuilder.String()
+}
+
+func buildReferenceMatch(varName string, reference EntityReference, paramName string) string {
+	return fmt.Sprintf("MATCH (%s:%s {%s: $%s})\n", varName, query_builder.EscapeIdentifier(reference.NodeLabel), query_builder.EscapeIdentifier(reference.IdProperty), paramName)
+}
+
+// buildParams collects the query parameters. Optional case properties are only set when provided.
+func buildParams(args CreateInvestigationCaseInput) map[string]any {
+	caseProperties := map[string]any{
+		"title":    args.Title,
+		"status":   args.Status,
+		"severity": args.Severity,
+	}
+	if args.CaseId != "" {
+		caseProperties["caseId"] = args.CaseId
+	}
+	if args.Description != "" {
+		caseProperties["description"] = args.Description
+	}
+	if args.CreatedBy != "" {
+		caseProperties["createdBy"] = args.CreatedBy
+	}
+
+	params := map[string]any{
+		"caseProperties": caseProperties,
+	}
+	if args.CaseId != "" {
+		params["caseId"] = args.CaseId
+	}
+	for i, subject := range args.Subjects {
+		params[fmt.Sprintf("subject%dId", i)] = subject.EntityId
+	}
+	for i, evidence := range args.Evidence {
+		params[fmt.Sprintf("evidence%dId", i)] = evidence.EntityId
+	}
+	for i, alert := range args.Alerts {
+		alertProperties := map[string]any{
+			"alertType": alert.AlertType,
+			"severity":  alert.Severity,
+			"status":    defaultStatus,
+		}
+		if alert.Description != "" {
+			alertProperties["description"] = alert.Description
+		}
+		params[fmt.Sprintf("alert%d", i)] = alertProperties
+		for j, evidence := range alert.Evidence {
+			params[fmt.Sprintf("alert%dEvidence%dId", i, j)] = evidence.EntityId
+		}
+	}
+
+	return params
+}
@@ -0,0 +1,306 @@
+package investigation_case_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/investigation_case"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+var subject = map[string]any{
+	"nodeLabel":  "Customer",
+	"idProperty": "customerId",
+	"entityId":   "CUS123",
+}
+
+var emailEvidence = map[string]any{
+	"nodeLabel":  "Email",
+	"idProperty": "address",
+	"entityId":   "shared@example.com",
+}
+
+func TestCreateInvestigationCaseHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("create-investigation-case").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("creates case with subjects, evidence and alerts", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"caseProperties": map[string]any{
+					"title":     "Shared email ring",
+					"status":    "open",
+					"severity":  "high",
+					"createdBy": "analyst",
+				},
+				"subject0Id":        "CUS123",
+				"evidence0Id":       "shared@example.com",
+				"alert0Evidence0Id": "shared@example.com",
+				"alert0": map[string]any{
+					"alertType": "shared_pii",
+					"severity":  "high",
+					"status":    "open",
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "MATCH (s0:Customer {customerId: $subject0Id})") {
+					t.Errorf("Expected subject match, got: %s", query)
+				}
+				if !strings.Contains(query, "CREATE (c)-[:INVOLVES]->(s0)") {
+					t.Errorf("Expected subject link, got: %s", query)
+				}
+				if !strings.Contains(query, "CREATE (c)-[:HAS_ALERT]->(a0:Alert)") {
+					t.Errorf("Expected alert creation, got: %s", query)
+				}
+				if !strings.Contains(query, "CREATE (a0)-[:HAS_EVIDENCE]->(a0ev0)") {
+					t.Errorf("Expected alert evidence link, got: %s", query)
+				}
+				if strings.Contains(query, "existing") {
+					t.Errorf("Expected no existing case check without caseId, got: %s", query)
+				}
+				return []*neo4j.Record{{Keys: []string{"caseId"}, Values: []any{"generated"}}}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"caseId": "generated"}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"title":     "Shared email ring",
+					"severity":  "high",
+					"createdBy": "analyst",
+					"subjects":  []map[string]any{subject},
+					"evidence":  []map[string]any{emailEvidence},
+					"alerts": []map[string]any{
+						{"alertType": "shared_pii", "evidence": []map[string]any{emailEvidence}},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("custom model and caseId", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query string, params map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "OPTIONAL MATCH (existing:Investigation {caseId: $caseId})") {
+					t.Errorf("Expected existing case check, got: %s", query)
+				}
+				if !strings.Contains(query, "CREATE (c)-[:CONCERNS]->(s0)") {
+					t.Errorf("Expected custom subject relationship, got: %s", query)
+				}
+				if params["caseId"] != "CASE-1" {
+					t.Errorf("Expected caseId parameter, got: %v", params["caseId"])
+				}
+				return []*neo4j.Record{{Keys: []string{"caseId"}, Values: []any{"CASE-1"}}}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"caseId": "CASE-1"}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"caseId":   "CASE-1",
+					"title":    "Mule investigation",
+					"subjects": []map[string]any{subject},
+					"modelConfig": map[string]any{
+						"caseLabel":                "Investigation",
+						"involvesRelationshipType": "CONCERNS",
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("missing referenced node creates nothing", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"title":    "Unknown subject",
+					"subjects": []map[string]any{subject},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result when no case was created")
+		}
+	})
+
+	t.Run("missing subjects", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"title": "No subjects",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing subjects")
+		}
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"title":    "Bad status",
+					"status":   "pending",
+					"subjects": []map[string]any{subject},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid status")
+		}
+	})
+
+	t.Run("rejects unsafe label", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"title": "Injection",
+					"subjects": []map[string]any{
+						{"nodeLabel": "Customer) DETACH DELETE (x", "idProperty": "customerId", "entityId": "CUS123"},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for unsafe label")
+		}
+	})
+
+	t.Run("database query failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteWriteQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection failed"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := investigation_case.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"title":    "Failure",
+					"subjects": []map[string]any{subject},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for database failure")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package investigation_case
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type EntityReference struct {
+	NodeLabel  string `json:"nodeLabel" jsonschema:"description=The node label of the referenced entity (e.g. Customer, Account, Transaction)"`
+	IdProperty string `json:"idProperty" jsonschema:"description=The property name containing the unique identifier (e.g. customerId, transactionId)"`
+	EntityId   string `json:"entityId" jsonschema:"description=The identifier value of the referenced entity"`
+}
+
+type AlertInput struct {
+	AlertType   string            `json:"alertType" jsonschema:"description=Type of finding (e.g. synthetic_identity, shared_pii, velocity, mule_account)"`
+	Description string            `json:"description,omitempty" jsonschema:"description=Short description of the finding"`
+	Severity    string            `json:"severity,omitempty" jsonschema:"enum=low,enum=medium,enum=high,enum=critical,description=Alert severity. Defaults to the case severity."`
+	Evidence    []EntityReference `json:"evidence,omitempty" jsonschema:"description=Nodes supporting this specific alert"`
+}
+
+// CaseModelConfig controls the labels and relationship types used for case data.
+// Every field is optional and defaults to the model shown in the tool description.
+type CaseModelConfig struct {
+	CaseLabel                   string `json:"caseLabel,omitempty" jsonschema:"default=Case,description=Node label for the case"`
+	AlertLabel                  string `json:"alertLabel,omitempty" jsonschema:"default=Alert,description=Node label for alerts"`
+	InvolvesRelationshipType    string `json:"involvesRelationshipType,omitempty" jsonschema:"default=INVOLVES,description=Relationship from the case to each subject"`
+	HasAlertRelationshipType    string `json:"hasAlertRelationshipType,omitempty" jsonschema:"default=HAS_ALERT,description=Relationship from the case to each alert"`
+	HasEvidenceRelationshipType string `json:"hasEvidenceRelationshipType,omitempty" jsonschema:"default=HAS_EVIDENCE,description=Relationship from the case or an alert to an evidence node"`
+}
+
+type CreateInvestigationCaseInput struct {
+	CaseId      string            `json:"caseId,omitempty" jsonschema:"description=Optional case identifier. A UUID is generated when omitted. Creation fails if a case with this ID already exists."`
+	Title       string            `json:"title" jsonschema:"description=Case title (required)"`
+	Description string            `json:"description,omitempty" jsonschema:"description=Case summary"`
+	Status      string            `json:"status,omitempty" jsonschema:"default=open,enum=open,enum=in_review,enum=escalated,enum=closed,description=Case status"`
+	Severity    string            `json:"severity,omitempty" jsonschema:"default=medium,enum=low,enum=medium,enum=high,enum=critical,description=Case severity"`
+	CreatedBy   string            `json:"createdBy,omitempty" jsonschema:"description=Analyst or system creating the case"`
+	Subjects    []EntityReference `json:"subjects" jsonschema:"description=Suspect entities the case is about (at least one)"`
+	Evidence    []EntityReference `json:"evidence,omitempty" jsonschema:"description=Nodes supporting the case as a whole (transactions, shared PII, devices)"`
+	Alerts      []AlertInput      `json:"alerts,omitempty" jsonschema:"description=Individual findings to record as Alert nodes on the case"`
+	ModelConfig *CaseModelConfig  `json:"modelConfig,omitempty" jsonschema:"description=Optional overrides for the case labels and relationship types"`
+}
+
+// Spec returns the MCP tool specification for investigation case creation
+func Spec() mcp.Tool {
+	return mcp.NewTool("create-investigation-case",
+		mcp.WithDescription(`Persists fraud findings back into the graph by creating a Case node, optional Alert nodes, and relationships to the suspect entities and evidence nodes.
+
+**GRAPH MODEL (defaults, overridable via modelConfig):**
+(:Case)-[:INVOLVES]->(subject)
+(:Case)-[:HAS_EVIDENCE]->(evidence)
+(:Case)-[:HAS_ALERT]->(:Alert)-[:HAS_EVIDENCE]->(evidence)
+
+**BEHAVIOUR:**
+- Every subject and evidence node is matched by label and ID first. If any of them is missing, nothing is created.
+- The case gets status (default open), severity (default medium), createdAt and a generated caseId unless one is supplied.
+- Each alert gets a generated alertId, status open, createdAt and the case severity unless its own severity is set.
+- This is a write tool and is not available when the server runs in read-only mode.
+
+**REQUIRED WORKFLOW:**
+1. **Call get-schema tool** to find the labels and ID properties of the subjects and evidence
+2. **Run detection tools** (e.g. detect-synthetic-identity, compute-risk-score) to collect findings
+3. **Create the case** referencing the subjects, evidence and one alert per finding
+
+**Example:**
+{
+  "title": "Synthetic identity ring around CUS123",
+  "severity": "high",
+  "createdBy": "analyst.jane",
+  "subjects": [
+    {"nodeLabel": "Customer", "idProperty": "customerId", "entityId": "CUS123"},
+    {"nodeLabel": "Customer", "idProperty": "customerId", "entityId": "CUS456"}
+  ],
+  "evidence": [{"nodeLabel": "Email", "idProperty": "address", "entityId": "shared@example.com"}],
+  "alerts": [
+    {"alertType": "shared_pii", "description": "Customers share an email address", "evidence": [{"nodeLabel": "Email", "idProperty": "address", "entityId": "shared@example.com"}]}
+  ]
+}
+
+**Returns:**
+- caseId, case properties, alerts (with alertIds), and counts of linked subjects and evidence`),
+		mcp.WithInputSchema[CreateInvestigationCaseInput](),
+		mcp.WithTitleAnnotation("Create Investigation Case"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,233 @@
+package kyc_audit
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+// Handler returns the tool handler function for audit-kyc-completeness
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleAuditKYCCompleteness(ctx, request, deps)
+	}
+}
+
+func handleAuditKYCCompleteness(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	// Validate dependencies
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("audit-kyc-completeness"),
+	)
+
+	// Parse arguments
+	var args AuditKYCCompletenessInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Validate required parameters and apply defaults
+	if errMessage := validateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("auditing KYC completeness",
+		"customerId", args.CustomerId,
+		"checklistItems", len(args.Checklist),
+		"includeComplete", args.IncludeComplete)
+
+	query := buildAuditQuery(args)
+
+	params := map[string]any{
+		"limit": args.Limit,
+	}
+	if args.CustomerId != "" {
+		params["customerId"] = args.CustomerId
+	}
+	for i, item := range args.Checklist {
+		params[fmt.Sprintf("check%dName", i)] = item.Name
+		if item.MaxAgeDays > 0 {
+			params[fmt.Sprintf("check%dMaxAgeDays", i)] = item.MaxAgeDays
+		}
+	}
+
+	slog.Debug("executing KYC audit query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	// Execute query
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing KYC audit query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Format records to JSON
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateInput checks that each checklist item names either a property or a relationship, and fills in the limit
+func validateInput(args *AuditKYCCompletenessInput) string {
+	if args.CustomerConfig.NodeLabel == "" || args.CustomerConfig.IdProperty == "" {
+		return "customerConfig.nodeLabel and customerConfig.idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+	if len(args.Checklist) == 0 {
+		return "checklist must contain at least one item"
+	}
+
+	names := make(map[string]bool)
+	for i, item := range args.Checklist {
+		if item.Name == "" {
+			return fmt.Sprintf("checklist[%d].name is required", i)
+		}
+		if names[item.Name] {
+			return fmt.Sprintf("checklist item name '%s' is used more than once", item.Name)
+		}
+		names[item.Name] = true
+
+		isProperty := item.Property != ""
+		isRelationship := item.RelationshipType != "" || item.TargetLabel != ""
+		if isProperty == isRelationship {
+			return fmt.Sprintf("checklist[%d] must set either property, or relationshipType and targetLabel", i)
+		}
+		if isRelationship && (item.RelationshipType == "" || item.TargetLabel == "") {
+			return fmt.Sprintf("checklist[%d] requires both relationshipType and targetLabel", i)
+		}
+		if item.MaxAgeDays < 0 {
+			return fmt.Sprintf("checklist[%d].maxAgeDays cannot be negative", i)
+		}
+		if item.MaxAgeDays > 0 && item.VerificationDateProperty == "" {
+			return fmt.Sprintf("checklist[%d].maxAgeDays requires verificationDateProperty", i)
+		}
+	}
+
+	if args.Limit == 0 {
+		args.Limit = defaultLimit
+	}
+	if args.Limit < 1 || args.Limit > maxLimit {
+		return fmt.Sprintf("limit must be between 1 and %d", maxLimit)
+	}
+
+	return ""
+}
+
+// buildAuditQuery constructs a query evaluating every checklist item per customer in its own CALL subquery
+func buildAuditQuery(args AuditKYCCompletenessInput) string {
+	customer := args.CustomerConfig
+	var queryBuilder strings.Builder
+
+	if args.CustomerId != "" {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $customerId})\n", query_builder.EscapeLabelExpression(customer.NodeLabel), query_builder.EscapeIdentifier(customer.IdProperty)))
+	} else {
+		queryBuilder.WriteString(fmt.Sprintf("MATCH (e:%s)\n", query_builder.EscapeLabelExpression(customer.NodeLabel)))
+	}
+
+	checkVars := make([]string, 0, len(args.Checklist))
+	for i, item := range args.Checklist {
+		queryBuilder.WriteString(buildCheckSubquery(i, item))
+		checkVars = append(checkVars, fmt.Sprintf("check%d", i))
+	}
+
+	queryBuilder.WriteString(fmt.Sprintf("WITH e, [%s] as checks\n", strings.Join(checkVars, ", ")))
+	queryBuilder.WriteString("WITH e, checks,\n")
+	queryBuilder.WriteString("     [c IN checks WHERE NOT c.present | c.name] as missing,\n")
+	queryBuilder.WriteString("     [c IN checks WHERE c.present AND c.verified = false | c.name] as unverified,\n")
+	queryBuilder.WriteString("     [c IN checks WHERE c.present AND c.stale = true | c.name] as stale\n")
+	if !args.IncludeComplete {
+		queryBuilder.WriteString("WHERE size(missing) + size(unverified) + size(stale) > 0\n")
+	}
+	queryBuilder.WriteString(fmt.Sprintf("RETURN e.%s as customerId,\n", query_builder.EscapeIdentifier(customer.IdProperty)))
+	queryBuilder.WriteString("       round(100.0 * size([c IN checks WHERE c.present AND coalesce(c.verified, true) AND NOT coalesce(c.stale, false)]) / size(checks), 1) as completeness,\n")
+	queryBuilder.WriteString("       missing,\n")
+	queryBuilder.WriteString("       unverified,\n")
+	queryBuilder.WriteString("       stale,\n")
+	queryBuilder.WriteString("       checks\n")
+	queryBuilder.WriteString("ORDER BY completeness ASC, customerId ASC\n")
+	queryBuilder.WriteString("LIMIT $limit")
+
+	return queryBuilder.String()
+}
+
+// buildCheckSubquery returns a CALL subquery binding check{index} to {name, present, verified, lastVerified, stale}.
+// verified and stale are null when the item does not configure them.
+func buildCheckSubquery(index int, item ChecklistItem) string {
+	var subquery strings.Builder
+
+	subquery.WriteString("CALL {\n")
+	subquery.WriteString("  WITH e\n")
+
+	verified := "null"
+	lastVerified := "null"
+	if item.Property != "" {
+		// Property items are read from the customer itself
+		subquery.WriteString(fmt.Sprintf("  WITH e.%s IS NOT NULL as present,\n", query_builder.EscapeIdentifier(item.Property)))
+		if item.VerifiedProperty != "" {
+			verified = fmt.Sprintf("coalesce(e.%s, false) = true", query_builder.EscapeIdentifier(item.VerifiedProperty))
+		}
+		if item.VerificationDateProperty != "" {
+			lastVerified = "e." + query_builder.EscapeIdentifier(item.VerificationDateProperty)
+		}
+		subquery.WriteString(fmt.Sprintf("       %s as verified,\n", verified))
+		subquery.WriteString(fmt.Sprintf("       %s as lastVerified\n", lastVerified))
+	} else {
+		subquery.WriteString(fmt.Sprintf("  OPTIONAL MATCH (e)-[:%s]->(a:%s)\n", query_builder.EscapeIdentifier(item.RelationshipType), query_builder.EscapeIdentifier(item.TargetLabel)))
+		if item.VerifiedProperty != "" {
+			verified = fmt.Sprintf("any(v IN collect(a.%s) WHERE v = true)", query_builder.EscapeIdentifier(item.VerifiedProperty))
+		}
+		if item.VerificationDateProperty != "" {
+			lastVerified = fmt.Sprintf("max(a.%s)", query_builder.EscapeIdentifier(item.VerificationDateProperty))
+		}
+		subquery.WriteString("  WITH count(a) > 0 as present,\n")
+		subquery.WriteString(fmt.Sprintf("       %s as verified,\n", verified))
+		subquery.WriteString(fmt.Sprintf("       %s as lastVerified\n", lastVerified))
+	}
+
+	// A present item without a verification date is stale, since it cannot be shown to be current
+	stale := "null"
+	if item.MaxAgeDays > 0 {
+		stale = fmt.Sprintf("lastVerified IS NULL OR datetime(toString(lastVerified)) < datetime() - duration({days: $check%dMaxAgeDays})", index)
+	}
+
+	subquery.WriteString("  RETURN {\n")
+	subquery.WriteString(fmt.Sprintf("    name: $check%dName,\n", index))
+	subquery.WriteString("    present: present,\n")
+	subquery.WriteString("    verified: CASE WHEN present THEN verified ELSE null END,\n")
+	subquery.WriteString("    lastVerified: lastVerified,\n")
+	subquery.WriteString(fmt.Sprintf("    stale: CASE WHEN present THEN %s ELSE null END\n", stale))
+	subquery.WriteString(fmt.Sprintf("  } as check%d\n", index))
+	subquery.WriteString("}\n")
+
+	return subquery.String()
+}
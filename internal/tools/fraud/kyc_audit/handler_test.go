@@ -0,0 +1,239 @@
+package kyc_audit_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/kyc_audit"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+var customerConfig = map[string]any{
+	"nodeLabel":  "Customer",
+	"idProperty": "customerId",
+}
+
+func TestAuditKYCCompletenessHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("audit-kyc-completeness").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("audits all customers", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"limit":            100,
+				"check0Name":       "address",
+				"check0MaxAgeDays": 365,
+				"check1Name":       "verified_phone",
+				"check2Name":       "occupation",
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "MATCH (e:Customer)\n") {
+					t.Errorf("Expected all customers match, got: %s", query)
+				}
+				if !strings.Contains(query, "OPTIONAL MATCH (e)-[:HAS_ADDRESS]->(a:Address)") {
+					t.Errorf("Expected address check, got: %s", query)
+				}
+				if !strings.Contains(query, "datetime(toString(lastVerified)) < datetime() - duration({days: $check0MaxAgeDays})") {
+					t.Errorf("Expected staleness check, got: %s", query)
+				}
+				if !strings.Contains(query, "any(v IN collect(a.verified) WHERE v = true) as verified") {
+					t.Errorf("Expected phone verification check, got: %s", query)
+				}
+				if !strings.Contains(query, "WITH e.occupation IS NOT NULL as present") {
+					t.Errorf("Expected property check, got: %s", query)
+				}
+				if !strings.Contains(query, "WITH e, [check0, check1, check2] as checks") {
+					t.Errorf("Expected checks to be combined, got: %s", query)
+				}
+				if !strings.Contains(query, "WHERE size(missing) + size(unverified) + size(stale) > 0") {
+					t.Errorf("Expected complete customers to be excluded, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := kyc_audit.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig": customerConfig,
+					"checklist": []map[string]any{
+						{"name": "address", "relationshipType": "HAS_ADDRESS", "targetLabel": "Address", "verificationDateProperty": "verifiedAt", "maxAgeDays": 365},
+						{"name": "verified_phone", "relationshipType": "HAS_PHONE", "targetLabel": "Phone", "verifiedProperty": "verified"},
+						{"name": "occupation", "property": "occupation"},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("single customer including complete results", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"limit":      100,
+				"customerId": "CUS123",
+				"check0Name": "ssn",
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "MATCH (e:Customer {customerId: $customerId})") {
+					t.Errorf("Expected single customer match, got: %s", query)
+				}
+				if strings.Contains(query, "WHERE size(missing)") {
+					t.Errorf("Expected complete customers to be included, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := kyc_audit.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerId":      "CUS123",
+					"customerConfig":  customerConfig,
+					"includeComplete": true,
+					"checklist": []map[string]any{
+						{"name": "ssn", "relationshipType": "HAS_SSN", "targetLabel": "SSN"},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("item with both property and relationship", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := kyc_audit.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig": customerConfig,
+					"checklist": []map[string]any{
+						{"name": "ssn", "property": "ssn", "relationshipType": "HAS_SSN", "targetLabel": "SSN"},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for ambiguous checklist item")
+		}
+	})
+
+	t.Run("maxAgeDays without verification date", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := kyc_audit.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig": customerConfig,
+					"checklist": []map[string]any{
+						{"name": "address", "relationshipType": "HAS_ADDRESS", "targetLabel": "Address", "maxAgeDays": 365},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for maxAgeDays without verificationDateProperty")
+		}
+	})
+
+	t.Run("database query failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection failed"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := kyc_audit.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"customerConfig": customerConfig,
+					"checklist": []map[string]any{
+						{"name": "ssn", "relationshipType": "HAS_SSN", "targetLabel": "SSN"},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for database failure")
+		}
+	})
+}
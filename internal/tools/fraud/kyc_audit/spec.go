@@ -0,0 +1,71 @@
+package kyc_audit
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type CustomerConfig struct {
+	NodeLabel  string `json:"nodeLabel" jsonschema:"description=Node label of customers (e.g. Customer, Person, Company). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for the unique identifier (e.g. customerId)"`
+}
+
+// ChecklistItem is one required KYC/CDD attribute.
+// The attribute is either a property on the customer (property) or a linked node (relationshipType + targetLabel).
+type ChecklistItem struct {
+	Name                     string `json:"name" jsonschema:"description=Name of the requirement as shown in the report (e.g. ssn, address, verified_phone)"`
+	Property                 string `json:"property,omitempty" jsonschema:"description=Customer property holding the attribute (e.g. ssn). Use instead of relationshipType/targetLabel."`
+	RelationshipType         string `json:"relationshipType,omitempty" jsonschema:"description=Relationship from the customer to the attribute node (e.g. HAS_SSN)"`
+	TargetLabel              string `json:"targetLabel,omitempty" jsonschema:"description=Node label of the attribute node (e.g. SSN)"`
+	VerifiedProperty         string `json:"verifiedProperty,omitempty" jsonschema:"description=Optional boolean property marking the attribute as verified (e.g. verified). Read from the attribute node, or from the customer for property items."`
+	VerificationDateProperty string `json:"verificationDateProperty,omitempty" jsonschema:"description=Optional property holding the last verification date (e.g. verifiedAt)"`
+	MaxAgeDays               int    `json:"maxAgeDays,omitempty" jsonschema:"description=Optional: verifications older than this many days are reported as stale (requires verificationDateProperty)"`
+}
+
+type AuditKYCCompletenessInput struct {
+	CustomerId      string          `json:"customerId,omitempty" jsonschema:"description=Optional: audit a single customer. If empty, all customers are audited."`
+	CustomerConfig  CustomerConfig  `json:"customerConfig" jsonschema:"description=Configuration for customer nodes. Discovered from get-schema."`
+	Checklist       []ChecklistItem `json:"checklist" jsonschema:"description=Required attributes to check for every customer"`
+	IncludeComplete bool            `json:"includeComplete,omitempty" jsonschema:"default=false,description=Also return customers with no findings"`
+	Limit           int             `json:"limit,omitempty" jsonschema:"default=100,description=Maximum number of customers to return, least complete first (1-1000)"`
+	PreviewQuery    bool            `json:"previewQuery,omitempty" jsonschema:"default=false,description=Return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for the KYC/CDD completeness audit
+func Spec() mcp.Tool {
+	return mcp.NewTool("audit-kyc-completeness",
+		mcp.WithDescription(`Audits customers against a configurable KYC/CDD checklist and reports missing attributes, unverified attributes and stale verifications. Useful for periodic customer due diligence reviews.
+
+**FINDINGS PER CHECKLIST ITEM:**
+- **missing:** the property is null, or no linked attribute node exists
+- **unverified:** verifiedProperty is set on the item but no value is marked true
+- **stale:** the most recent verification date is older than maxAgeDays
+
+**COMPLETENESS:**
+Percentage of checklist items that are present, verified (when checked) and not stale.
+
+**REQUIRED WORKFLOW - Schema Discovery:**
+1. **Call get-schema tool** to find how identity attributes are stored
+2. **Build the checklist** from your CDD policy, one item per requirement
+3. **Audit one customer** with customerId, or the whole population without it
+
+**Example:**
+{
+  "customerConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "checklist": [
+    {"name": "ssn", "relationshipType": "HAS_SSN", "targetLabel": "SSN"},
+    {"name": "address", "relationshipType": "HAS_ADDRESS", "targetLabel": "Address", "verificationDateProperty": "verifiedAt", "maxAgeDays": 365},
+    {"name": "verified_phone", "relationshipType": "HAS_PHONE", "targetLabel": "Phone", "verifiedProperty": "verified"},
+    {"name": "id_document", "relationshipType": "HAS_DRIVER_LICENSE", "targetLabel": "DriverLicense"},
+    {"name": "beneficial_owner", "relationshipType": "HAS_BENEFICIAL_OWNER", "targetLabel": "Person"},
+    {"name": "occupation", "property": "occupation"}
+  ]
+}
+
+**Returns:**
+- One row per customer, least complete first: {customerId, completeness, missing, unverified, stale, checks}`),
+		mcp.WithInputSchema[AuditKYCCompletenessInput](),
+		mcp.WithTitleAnnotation("Audit KYC Completeness"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
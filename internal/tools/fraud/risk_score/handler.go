@@ -0,0 +1,362 @@
+package risk_score
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+const (
+	signalTypeSharedPII         = "shared_pii"
+	signalTypeVelocity          = "velocity"
+	signalTypeHighRiskGeography = "high_risk_geography"
+	signalTypeMuleIndicators    = "mule_indicators"
+
+	defaultVelocityWindowHours = 24
+)
+
+// defaultThresholds is the raw value at which each signal type scores its full weight
+var defaultThresholds = map[string]float64{
+	signalTypeSharedPII:         3,
+	signalTypeVelocity:          10,
+	signalTypeHighRiskGeography: 1,
+	signalTypeMuleIndicators:    0.9,
+}
+
+// Handler returns a handler function for the compute-risk-score tool
+func Handler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleComputeRiskScore(ctx, request, deps)
+	}
+}
+
+func handleComputeRiskScore(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Emit analytics event
+	deps.AnalyticsService.EmitEvent(
+		deps.AnalyticsService.NewToolsEvent("compute-risk-score"),
+	)
+
+	if err := deps.MappingStore.Apply(ctx, &request); err != nil {
+		slog.Error("error applying saved mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var args ComputeRiskScoreInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.EntityId == "" {
+		errMessage := "entityId is required"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if args.EntityConfig.NodeLabel == "" || args.EntityConfig.IdProperty == "" {
+		errMessage := "entityConfig.nodeLabel and entityConfig.idProperty are required. Call get-schema to discover the entity node first."
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if len(args.Signals) == 0 {
+		errMessage := "signals is required and cannot be empty. Configure at least one of sharedPII, velocity, highRiskGeography or muleIndicators."
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	// Resolve signal types and apply defaults
+	signalTypes := make([]string, len(args.Signals))
+	totalWeight := 0.0
+	for i := range args.Signals {
+		signalType, errMessage := resolveSignal(&args.Signals[i], args.TransactionConfig)
+		if errMessage != "" {
+			errMessage = fmt.Sprintf("signals[%d]: %s", i, errMessage)
+			slog.Error(errMessage)
+			return mcp.NewToolResultError(errMessage), nil
+		}
+		signalTypes[i] = signalType
+		totalWeight += args.Signals[i].Weight
+	}
+
+	query := buildRiskScoreQuery(args.EntityConfig, args.TransactionConfig, args.Signals, signalTypes)
+
+	signalParams := make([]map[string]any, len(args.Signals))
+	params := map[string]any{
+		"entityId":    args.EntityId,
+		"totalWeight": totalWeight,
+	}
+	for i, signal := range args.Signals {
+		signalParams[i] = map[string]any{
+			"name":      signal.Name,
+			"type":      signalTypes[i],
+			"weight":    signal.Weight,
+			"threshold": signal.Threshold,
+		}
+		switch signalTypes[i] {
+		case signalTypeVelocity:
+			params[fmt.Sprintf("signal%dWindowHours", i)] = signal.Velocity.WindowHours
+		case signalTypeHighRiskGeography:
+			params[fmt.Sprintf("signal%dCountries", i)] = signal.HighRiskGeography.Countries
+		}
+	}
+	params["signals"] = signalParams
+
+	slog.Info("computing risk score",
+		"entityId", args.EntityId,
+		"entityLabel", args.EntityConfig.NodeLabel,
+		"signals", len(args.Signals))
+	slog.Debug("executing risk score query", "query", query)
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("error executing risk score query", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("error formatting risk score results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// resolveSignal validates a signal, applies its defaults and returns its type.
+// Returns an error message for the caller when the signal is invalid.
+func resolveSignal(signal *RiskSignal, txConfig *TransactionConfig) (string, string) {
+	signalType := ""
+	configured := 0
+	if signal.SharedPII != nil {
+		signalType = signalTypeSharedPII
+		configured++
+	}
+	if signal.Velocity != nil {
+		signalType = signalTypeVelocity
+		configured++
+	}
+	if signal.HighRiskGeography != nil {
+		signalType = signalTypeHighRiskGeography
+		configured++
+	}
+	if signal.MuleIndicators != nil {
+		signalType = signalTypeMuleIndicators
+		configured++
+	}
+	if configured != 1 {
+		return "", "exactly one of sharedPII, velocity, highRiskGeography or muleIndicators must be set"
+	}
+
+	switch signalType {
+	case signalTypeSharedPII:
+		if len(signal.SharedPII.PIIRelationships) == 0 {
+			return "", "sharedPII.piiRelationships cannot be empty"
+		}
+	case signalTypeVelocity:
+		if errMessage := validateTransactionConfig(txConfig); errMessage != "" {
+			return "", errMessage
+		}
+		if txConfig.DateProperty == "" {
+			return "", "velocity signals require transactionConfig.dateProperty"
+		}
+		if signal.Velocity.WindowHours == 0 {
+			signal.Velocity.WindowHours = defaultVelocityWindowHours
+		}
+		if signal.Velocity.WindowHours < 0 {
+			return "", "velocity.windowHours must be positive"
+		}
+	case signalTypeHighRiskGeography:
+		geography := signal.HighRiskGeography
+		if geography.RelationshipType == "" || geography.TargetLabel == "" || geography.CountryProperty == "" {
+			return "", "highRiskGeography requires relationshipType, targetLabel and countryProperty"
+		}
+		if len(geography.Countries) == 0 {
+			return "", "highRiskGeography.countries cannot be empty"
+		}
+	case signalTypeMuleIndicators:
+		if errMessage := validateTransactionConfig(txConfig); errMessage != "" {
+			return "", errMessage
+		}
+		if txConfig.AmountProperty == "" {
+			return "", "muleIndicators signals require transactionConfig.amountProperty"
+		}
+	}
+
+	if signal.Name == "" {
+		signal.Name = signalType
+	}
+	if signal.Weight == 0 {
+		signal.Weight = 1
+	}
+	if signal.Weight < 0 {
+		return "", "weight cannot be negative"
+	}
+	if signal.Threshold == 0 {
+		signal.Threshold = defaultThresholds[signalType]
+	}
+	if signal.Threshold < 0 {
+		return "", "threshold must be positive"
+	}
+
+	return signalType, ""
+}
+
+// validateTransactionConfig checks that a transaction model is described
+func validateTransactionConfig(txConfig *TransactionConfig) string {
+	if txConfig == nil {
+		return "transactionConfig is required for transaction based signals"
+	}
+	if txConfig.AccountRelationshipType != "" && txConfig.AccountLabel == "" {
+		return "transactionConfig.accountLabel is required when accountRelationshipType is set"
+	}
+	if errMessage := query_builder.ValidateDateFormat("transactionConfig.dateFormat", txConfig.DateFormat); errMessage != "" {
+		return errMessage
+	}
+	if txConfig.TransactionLabel != "" {
+		if txConfig.PerformsRelationshipType == "" || txConfig.BenefitsToRelationshipType == "" {
+			return "transactionConfig.performsRelationshipType and transactionConfig.benefitsToRelationshipType are required when transactionLabel is set"
+		}
+		return ""
+	}
+	if txConfig.TransactionRelationshipType == "" {
+		return "transactionConfig must set transactionLabel (transaction nodes) or transactionRelationshipType (transaction relationships)"
+	}
+	return ""
+}
+
+// buildRiskScoreQuery evaluates every signal in its own CALL subquery, then normalizes and weights the raw values
+func buildRiskScoreQuery(entityConfig EntityConfig, txConfig *TransactionConfig, signals []RiskSignal, signalTypes []string) string {
+	var query strings.Builder
+
+	query.WriteString(fmt.Sprintf("MATCH (e:%s {%s: $entityId})\n", query_builder.EscapeLabelExpression(entityConfig.NodeLabel), query_builder.EscapeIdentifier(entityConfig.IdProperty)))
+
+	raws := make([]string, len(signals))
+	for i, signal := range signals {
+		raws[i] = fmt.Sprintf("raw%d", i)
+
+		query.WriteString("CALL {\n")
+		query.WriteString("  WITH e\n")
+		switch signalTypes[i] {
+		case signalTypeSharedPII:
+			query.WriteString(buildSharedPIISignal(entityConfig, signal.SharedPII, raws[i]))
+		case signalTypeVelocity:
+			query.WriteString(buildVelocitySignal(*txConfig, i, raws[i]))
+		case signalTypeHighRiskGeography:
+			query.WriteString(buildHighRiskGeographySignal(signal.HighRiskGeography, i, raws[i]))
+		case signalTypeMuleIndicators:
+			query.WriteString(buildMuleIndicatorSignal(*txConfig, raws[i]))
+		}
+		query.WriteString("}\n")
+	}
+
+	// Normalize each raw value against its threshold and weight it
+	query.WriteString(fmt.Sprintf("WITH e, [%s] as raws\n", strings.Join(raws, ", ")))
+	query.WriteString(`WITH e, [i IN range(0, size(raws) - 1) | {
+       name: $signals[i].name,
+       type: $signals[i].type,
+       raw: raws[i],
+       threshold: $signals[i].threshold,
+       weight: $signals[i].weight,
+       score: CASE WHEN raws[i] >= $signals[i].threshold THEN 1.0 ELSE toFloat(raws[i]) / $signals[i].threshold END
+     }] as scored
+WITH e, [s IN scored | s{.*, contribution: s.weight * s.score}] as signals
+WITH e, signals, CASE WHEN $totalWeight > 0 THEN 100.0 * reduce(total = 0.0, s IN signals | total + s.contribution) / $totalWeight ELSE 0.0 END as score
+`)
+	query.WriteString(fmt.Sprintf("RETURN e.%s as entityId,\n", query_builder.EscapeIdentifier(entityConfig.IdProperty)))
+	query.WriteString(`       round(score, 1) as riskScore,
+       CASE WHEN score < 30 THEN 'LOW' WHEN score < 60 THEN 'MEDIUM' WHEN score < 80 THEN 'HIGH' ELSE 'CRITICAL' END as riskTier,
+       signals`)
+
+	return query.String()
+}
+
+// buildSharedPIISignal counts other entities sharing any configured PII node with the entity
+func buildSharedPIISignal(entityConfig EntityConfig, signal *SharedPIISignal, alias string) string {
+	relTypes := make([]string, 0, len(signal.PIIRelationships))
+	labelChecks := make([]string, 0, len(signal.PIIRelationships))
+	for _, rel := range signal.PIIRelationships {
+		relTypes = append(relTypes, query_builder.EscapeIdentifier(rel.RelationshipType))
+		labelChecks = append(labelChecks, "pii:"+query_builder.EscapeLabelExpression(rel.TargetLabel))
+	}
+	rels := strings.Join(relTypes, "|")
+
+	return fmt.Sprintf(`  OPTIONAL MATCH (e)-[:%s]->(pii)<-[:%s]-(other:%s)
+  WHERE other <> e AND (%s)
+  RETURN count(DISTINCT other) as %s
+`, rels, rels, query_builder.EscapeLabelExpression(entityConfig.NodeLabel), strings.Join(labelChecks, " OR "), alias)
+}
+
+// buildVelocitySignal counts outgoing transactions in the window ending at the most recent one.
+// Anchoring on the latest transaction keeps the signal meaningful on historical data.
+func buildVelocitySignal(txConfig TransactionConfig, index int, alias string) string {
+	date := query_builder.TimeProperty{Variable: "t", Property: txConfig.DateProperty, Format: txConfig.DateFormat}.Expression()
+	return fmt.Sprintf(`  OPTIONAL MATCH %s
+  WITH collect(%s) as dates, max(%s) as latest
+  RETURN size([d IN dates WHERE %s]) as %s
+`, buildTransactionPattern(txConfig, "out"), date, date, query_builder.WithinHours("d", "latest", fmt.Sprintf("signal%dWindowHours", index)), alias)
+}
+
+// buildHighRiskGeographySignal counts linked locations in high-risk countries
+func buildHighRiskGeographySignal(signal *HighRiskGeographySignal, index int, alias string) string {
+	return fmt.Sprintf(`  OPTIONAL MATCH (e)-[:%s]->(g:%s)
+  WHERE g.%s IN $signal%dCountries
+  RETURN count(DISTINCT g) as %s
+`, query_builder.EscapeIdentifier(signal.RelationshipType), query_builder.EscapeIdentifier(signal.TargetLabel), query_builder.EscapeIdentifier(signal.CountryProperty), index, alias)
+}
+
+// buildMuleIndicatorSignal computes the pass-through ratio: the smaller of the incoming and outgoing totals divided by the larger
+func buildMuleIndicatorSignal(txConfig TransactionConfig, alias string) string {
+	return fmt.Sprintf(`  OPTIONAL MATCH %s
+  WITH e, coalesce(sum(t.%s), 0) as outTotal
+  OPTIONAL MATCH %s
+  WITH outTotal, coalesce(sum(t.%s), 0) as inTotal
+  RETURN CASE
+    WHEN inTotal <= 0 OR outTotal <= 0 THEN 0.0
+    WHEN inTotal < outTotal THEN toFloat(inTotal) / outTotal
+    ELSE toFloat(outTotal) / inTotal
+  END as %s
+`, buildTransactionPattern(txConfig, "out"), query_builder.EscapeIdentifier(txConfig.AmountProperty),
+		buildTransactionPattern(txConfig, "in"), query_builder.EscapeIdentifier(txConfig.AmountProperty), alias)
+}
+
+// buildTransactionPattern builds the pattern binding the entity's transactions (t) in one direction
+func buildTransactionPattern(txConfig TransactionConfig, direction string) string {
+	account := "(e)"
+	if txConfig.AccountRelationshipType != "" {
+		account = fmt.Sprintf("(e)-[:%s]->(:%s)", query_builder.EscapeIdentifier(txConfig.AccountRelationshipType), query_builder.EscapeIdentifier(txConfig.AccountLabel))
+	}
+
+	if txConfig.TransactionLabel != "" {
+		if direction == "in" {
+			return fmt.Sprintf("%s<-[:%s]-(t:%s)", account, query_builder.EscapeIdentifier(txConfig.BenefitsToRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel))
+		}
+		return fmt.Sprintf("%s-[:%s]->(t:%s)", account, query_builder.EscapeIdentifier(txConfig.PerformsRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel))
+	}
+
+	if direction == "in" {
+		return fmt.Sprintf("%s<-[t:%s]-()", account, query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType))
+	}
+	return fmt.Sprintf("%s-[t:%s]->()", account, query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType))
+}
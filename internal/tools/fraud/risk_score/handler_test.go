@@ -0,0 +1,290 @@
+package risk_score_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/risk_score"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+var entityConfig = map[string]any{
+	"nodeLabel":  "Customer",
+	"idProperty": "customerId",
+}
+
+var transactionConfig = map[string]any{
+	"accountRelationshipType":    "OWNS",
+	"accountLabel":               "Account",
+	"transactionLabel":           "Transaction",
+	"performsRelationshipType":   "PERFORMS",
+	"benefitsToRelationshipType": "BENEFITS_TO",
+	"dateProperty":               "date",
+	"amountProperty":             "amount",
+}
+
+func TestComputeRiskScoreHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("compute-risk-score").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	t.Run("shared PII signal with defaults", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"entityId":    "CUS123",
+				"totalWeight": 1.0,
+				"signals": []map[string]any{
+					{"name": "shared_pii", "type": "shared_pii", "weight": 1.0, "threshold": 3.0},
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "OPTIONAL MATCH (e)-[:HAS_EMAIL|HAS_PHONE]->(pii)<-[:HAS_EMAIL|HAS_PHONE]-(other:Customer)") {
+					t.Errorf("Expected shared PII pattern, got: %s", query)
+				}
+				if !strings.Contains(query, "WHERE other <> e AND (pii:Email OR pii:Phone)") {
+					t.Errorf("Expected PII label checks, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"entityId": "CUS123", "riskScore": 66.7, "riskTier": "HIGH"}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := risk_score.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":     "CUS123",
+					"entityConfig": entityConfig,
+					"signals": []map[string]any{
+						{
+							"sharedPII": map[string]any{
+								"piiRelationships": []map[string]any{
+									{"relationshipType": "HAS_EMAIL", "targetLabel": "Email"},
+									{"relationshipType": "HAS_PHONE", "targetLabel": "Phone"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("transaction and geography signals pass their parameters", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"entityId":           "CUS123",
+				"totalWeight":        6.0,
+				"signal0WindowHours": 12,
+				"signal1Countries":   []string{"KP", "IR"},
+				"signals": []map[string]any{
+					{"name": "velocity", "type": "velocity", "weight": 2.0, "threshold": 20.0},
+					{"name": "geo", "type": "high_risk_geography", "weight": 1.0, "threshold": 1.0},
+					{"name": "mule_indicators", "type": "mule_indicators", "weight": 3.0, "threshold": 0.9},
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "(e)-[:OWNS]->(:Account)-[:PERFORMS]->(t:Transaction)") {
+					t.Errorf("Expected outgoing transaction pattern, got: %s", query)
+				}
+				if !strings.Contains(query, "(e)-[:OWNS]->(:Account)<-[:BENEFITS_TO]-(t:Transaction)") {
+					t.Errorf("Expected incoming transaction pattern, got: %s", query)
+				}
+				if !strings.Contains(query, "WHERE g.countryCode IN $signal1Countries") {
+					t.Errorf("Expected geography filter, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := risk_score.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":          "CUS123",
+					"entityConfig":      entityConfig,
+					"transactionConfig": transactionConfig,
+					"signals": []map[string]any{
+						{"name": "velocity", "weight": 2, "threshold": 20, "velocity": map[string]any{"windowHours": 12}},
+						{"name": "geo", "highRiskGeography": map[string]any{
+							"relationshipType": "HAS_ADDRESS",
+							"targetLabel":      "Address",
+							"countryProperty":  "countryCode",
+							"countries":        []string{"KP", "IR"},
+						}},
+						{"weight": 3, "muleIndicators": map[string]any{}},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("missing signals", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := risk_score.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":     "CUS123",
+					"entityConfig": entityConfig,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing signals")
+		}
+	})
+
+	t.Run("signal with more than one type", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := risk_score.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":          "CUS123",
+					"entityConfig":      entityConfig,
+					"transactionConfig": transactionConfig,
+					"signals": []map[string]any{
+						{"velocity": map[string]any{}, "muleIndicators": map[string]any{}},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for ambiguous signal")
+		}
+	})
+
+	t.Run("transaction signal without transactionConfig", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := risk_score.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":     "CUS123",
+					"entityConfig": entityConfig,
+					"signals": []map[string]any{
+						{"velocity": map[string]any{}},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing transactionConfig")
+		}
+	})
+
+	t.Run("database query failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection failed"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := risk_score.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":     "CUS123",
+					"entityConfig": entityConfig,
+					"signals": []map[string]any{
+						{"sharedPII": map[string]any{
+							"piiRelationships": []map[string]any{{"relationshipType": "HAS_EMAIL", "targetLabel": "Email"}},
+						}},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for database failure")
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package risk_score
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type EntityConfig struct {
+	NodeLabel  string `json:"nodeLabel" jsonschema:"description=The node label of the entity to score (e.g. Customer, Person, Account). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+	IdProperty string `json:"idProperty" jsonschema:"description=The property name containing the unique identifier (e.g. customerId, accountNumber)"`
+}
+
+type PIIRelationship struct {
+	RelationshipType string `json:"relationshipType" jsonschema:"description=The relationship type connecting the entity to PII (e.g. HAS_EMAIL)"`
+	TargetLabel      string `json:"targetLabel" jsonschema:"description=The node label of the PII entity (e.g. Email). Label expressions such as Email|EmailAddress are accepted"`
+}
+
+// TransactionConfig describes how the entity reaches its transactions.
+// Node model: (:Account)-[:PERFORMS]->(:Transaction)-[:BENEFITS_TO]->(:Account).
+// Relationship model: (:Account)-[:TRANSACTION]->(:Account).
+type TransactionConfig struct {
+	AccountRelationshipType     string `json:"accountRelationshipType,omitempty" jsonschema:"description=Relationship from the entity to its accounts (e.g. OWNS). Omit when the entity is the account itself."`
+	AccountLabel                string `json:"accountLabel,omitempty" jsonschema:"description=Node label of accounts (e.g. Account)"`
+	TransactionLabel            string `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction)"`
+	PerformsRelationshipType    string `json:"performsRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the sending account to the transaction (e.g. PERFORMS)"`
+	BenefitsToRelationshipType  string `json:"benefitsToRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the receiving account (e.g. BENEFITS_TO)"`
+	TransactionRelationshipType string `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: relationship from the sending to the receiving account (e.g. TRANSACTION)"`
+	DateProperty                string `json:"dateProperty,omitempty" jsonschema:"description=Property holding the transaction datetime (e.g. date). Required for velocity signals."`
+	DateFormat                  string `json:"dateFormat,omitempty" jsonschema:"enum=datetime,enum=date,enum=epochMillis,default=datetime,description=How dateProperty values are stored: datetime values (default), date values or epoch milliseconds"`
+	AmountProperty              string `json:"amountProperty,omitempty" jsonschema:"description=Property holding the transaction amount (e.g. amount). Required for mule indicator signals."`
+}
+
+type SharedPIISignal struct {
+	PIIRelationships []PIIRelationship `json:"piiRelationships" jsonschema:"description=PII relationships to check. Raw value is the number of other entities sharing any of these PII nodes."`
+}
+
+type VelocitySignal struct {
+	WindowHours int `json:"windowHours,omitempty" jsonschema:"default=24,description=Window length in hours. Raw value is the number of outgoing transactions in the window ending at the entity's most recent transaction."`
+}
+
+type HighRiskGeographySignal struct {
+	RelationshipType string   `json:"relationshipType" jsonschema:"description=Relationship from the entity to a location node (e.g. HAS_ADDRESS, LOCATED_IN)"`
+	TargetLabel      string   `json:"targetLabel" jsonschema:"description=Node label of the location (e.g. Address, Country)"`
+	CountryProperty  string   `json:"countryProperty" jsonschema:"description=Property holding the country code on the location node (e.g. countryCode)"`
+	Countries        []string `json:"countries" jsonschema:"description=High-risk country codes (e.g. FATF grey/black list). Raw value is the number of linked locations in these countries."`
+}
+
+type MuleIndicatorSignal struct{}
+
+type RiskSignal struct {
+	Name              string                   `json:"name" jsonschema:"description=Name of the signal as shown in the result (e.g. shared_pii)"`
+	Weight            float64                  `json:"weight,omitempty" jsonschema:"default=1,description=Relative weight of the signal in the composite score"`
+	Threshold         float64                  `json:"threshold,omitempty" jsonschema:"description=Raw value at which the signal scores its full weight. Defaults: shared PII 3, velocity 10, geography 1, mule 0.9."`
+	SharedPII         *SharedPIISignal         `json:"sharedPII,omitempty" jsonschema:"description=Counts other entities sharing PII with the entity"`
+	Velocity          *VelocitySignal          `json:"velocity,omitempty" jsonschema:"description=Counts outgoing transactions in a short window (requires transactionConfig.dateProperty)"`
+	HighRiskGeography *HighRiskGeographySignal `json:"highRiskGeography,omitempty" jsonschema:"description=Counts linked locations in high-risk countries"`
+	MuleIndicators    *MuleIndicatorSignal     `json:"muleIndicators,omitempty" jsonschema:"description=Pass-through ratio between incoming and outgoing funds, 1.0 means everything received is sent on (requires transactionConfig.amountProperty)"`
+}
+
+type ComputeRiskScoreInput struct {
+	EntityId          string             `json:"entityId" jsonschema:"description=Entity ID to score (required)"`
+	EntityConfig      EntityConfig       `json:"entityConfig" jsonschema:"description=Configuration for the entity node being scored. Discovered from get-schema."`
+	MappingName       string             `json:"mappingName,omitempty" jsonschema:"description=Optional: name of a mapping saved with save-schema-mapping. Supplies entityConfig when it is omitted."`
+	TransactionConfig *TransactionConfig `json:"transactionConfig,omitempty" jsonschema:"description=How the entity reaches its transactions. Required for velocity and mule indicator signals."`
+	Signals           []RiskSignal       `json:"signals" jsonschema:"description=Signals to evaluate. Each signal sets exactly one of sharedPII, velocity, highRiskGeography or muleIndicators."`
+	PreviewQuery      bool               `json:"previewQuery,omitempty" jsonschema:"default=false,description=Return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
+}
+
+// Spec returns the MCP tool specification for composite risk scoring
+func Spec() mcp.Tool {
+	return mcp.NewTool("compute-risk-score",
+		mcp.WithDescription(`Computes a composite risk score (0-100) for an entity by evaluating a configurable set of fraud signals and combining them with weights. Returns the score, a risk tier and the contribution of every signal.
+
+**SIGNALS:**
+- **sharedPII:** number of other entities sharing PII (emails, phones, SSNs) with the entity
+- **velocity:** number of outgoing transactions within windowHours of the entity's most recent transaction
+- **highRiskGeography:** number of linked locations in high-risk countries
+- **muleIndicators:** pass-through ratio (smaller of incoming/outgoing totals divided by the larger one)
+
+**SCORING:**
+Each signal's raw value is normalized against its threshold: score = min(raw / threshold, 1).
+The contribution of a signal is weight x score, and the composite riskScore is 100 x sum(contributions) / sum(weights).
+riskTier is LOW (< 30), MEDIUM (< 60), HIGH (< 80) or CRITICAL.
+
+**REQUIRED WORKFLOW - Schema Discovery:**
+1. **Call get-schema tool** to retrieve the database schema
+2. **Configure entityConfig** with the entity label and ID property
+3. **Configure transactionConfig** if velocity or mule indicator signals are used
+4. **Choose signals, weights and thresholds** appropriate for the investigation
+
+**Example:**
+{
+  "entityId": "CUS123",
+  "entityConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "transactionConfig": {
+    "accountRelationshipType": "OWNS",
+    "accountLabel": "Account",
+    "transactionLabel": "Transaction",
+    "performsRelationshipType": "PERFORMS",
+    "benefitsToRelationshipType": "BENEFITS_TO",
+    "dateProperty": "date",
+    "amountProperty": "amount"
+  },
+  "signals": [
+    {"name": "shared_pii", "weight": 3, "sharedPII": {"piiRelationships": [{"relationshipType": "HAS_EMAIL", "targetLabel": "Email"}, {"relationshipType": "HAS_PHONE", "targetLabel": "Phone"}]}},
+    {"name": "velocity", "weight": 2, "threshold": 20, "velocity": {"windowHours": 24}},
+    {"name": "geography", "weight": 1, "highRiskGeography": {"relationshipType": "HAS_ADDRESS", "targetLabel": "Address", "countryProperty": "countryCode", "countries": ["IR", "KP", "MM"]}},
+    {"name": "mule", "weight": 2, "muleIndicators": {}}
+  ]
+}
+
+**When to use this tool:**
+- Triage: ranking entities surfaced by other detectors
+- Summarizing risk for a case or SAR with an explainable breakdown
+- Comparing entities consistently using the same signal configuration
+
+**Returns:**
+- entityId, riskScore (0-100), riskTier
+- signals: [{name, type, raw, threshold, weight, score, contribution}]`),
+		mcp.WithInputSchema[ComputeRiskScoreInput](),
+		mcp.WithTitleAnnotation("Compute Risk Score"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,512 @@
+package sar
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const (
+	defaultTransactionLimit    = 10
+	maxTransactionLimit        = 100
+	defaultNetworkMaxHops      = 2
+	maxNetworkHops             = 4
+	defaultNetworkLimit        = 25
+	maxNetworkLimit            = 100
+	defaultVelocityWindowHours = 24
+	maxVelocityWindowHours     = 720
+	defaultVelocityBusiestDays = 7
+	maxVelocityBusiestDays     = 31
+)
+
+// evidenceSection is a single evidence query whose first column becomes a section of the result
+type evidenceSection struct {
+	name  string
+	query string
+}
+
+// GatherSAREvidenceHandler returns a handler function for the gather-sar-evidence tool
+func GatherSAREvidenceHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGatherSAREvidence(ctx, deps, request)
+	}
+}
+
+// handleGatherSAREvidence runs each evidence section against the database and combines the results
+func handleGatherSAREvidence(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.DBService == nil {
+		errMessage := "database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("gather-sar-evidence"))
+
+	if err := deps.MappingStore.Apply(ctx, &request); err != nil {
+		slog.Error("error applying saved mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var args GatherSAREvidenceInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateEvidenceInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("gathering SAR evidence",
+		"subjectId", args.SubjectId,
+		"transactions", args.TransactionConfig != nil,
+		"network", args.NetworkConfig != nil)
+
+	params := buildEvidenceParams(args)
+
+	// The profile doubles as the existence check for the subject, so it must succeed
+	profileQuery, filterParams := buildProfileEvidenceQuery(args)
+	profileParams := maps.Clone(params)
+	maps.Copy(profileParams, filterParams)
+
+	if args.PreviewQuery {
+		previews := []tools.QueryPreview{{Name: "profile", Query: profileQuery, Params: profileParams}}
+		for _, section := range buildOptionalSections(args) {
+			previews = append(previews, tools.QueryPreview{Name: section.name, Query: section.query, Params: params})
+		}
+		return tools.NewQueryPreviewResult(previews...), nil
+	}
+
+	slog.Debug("executing SAR evidence query", "section", "profile", "query", profileQuery)
+	records, err := deps.DBService.ExecuteReadQuery(ctx, profileQuery, profileParams)
+	if err != nil {
+		slog.Error("error executing SAR evidence query", "section", "profile", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	if len(records) == 0 {
+		errMessage := fmt.Sprintf("no %s found with %s '%s'", args.SubjectConfig.NodeLabel, args.SubjectConfig.IdProperty, args.SubjectId)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	evidence := &neo4j.Record{
+		Keys:   []string{"subjectId", "profile"},
+		Values: []any{args.SubjectId, firstValue(records)},
+	}
+
+	// Remaining sections are best effort so one failing query does not hide the rest
+	for _, section := range buildOptionalSections(args) {
+		slog.Debug("executing SAR evidence query", "section", section.name, "query", section.query)
+		var value any
+		records, err := deps.DBService.ExecuteReadQuery(ctx, section.query, params)
+		if err != nil {
+			slog.Error("error executing SAR evidence query", "section", section.name, "error", err)
+			value = map[string]any{"error": err.Error()}
+		} else {
+			value = firstValue(records)
+		}
+		evidence.Keys = append(evidence.Keys, section.name)
+		evidence.Values = append(evidence.Values, value)
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, []*neo4j.Record{evidence})
+	if err != nil {
+		slog.Error("error formatting query results", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateEvidenceInput checks the subject, its attribute mappings and each enabled section, filling in section defaults
+func validateEvidenceInput(args *GatherSAREvidenceInput) string {
+	if args.SubjectId == "" {
+		return "subjectId parameter is required"
+	}
+	if args.SubjectConfig.NodeLabel == "" || args.SubjectConfig.IdProperty == "" {
+		return "subjectConfig.nodeLabel and subjectConfig.idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+
+	for i, mapping := range args.AttributeMappings {
+		if mapping.RelationshipType == "" || mapping.TargetLabel == "" {
+			return fmt.Sprintf("attributeMappings[%d] requires relationshipType and targetLabel. Use get-schema to discover these first.", i)
+		}
+		if mapping.Direction != "" && mapping.Direction != "out" && mapping.Direction != "in" && mapping.Direction != "both" {
+			return fmt.Sprintf("attributeMappings[%d] has invalid direction '%s', must be one of: out, in, both", i, mapping.Direction)
+		}
+	}
+	if errMessage := query_builder.ValidateCollectionKeys(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeFilters(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeOrdering(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeAggregations(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateAttributeIdentifiers(args.AttributeMappings); errMessage != "" {
+		return errMessage
+	}
+
+	if txConfig := args.TransactionConfig; txConfig != nil {
+		if txConfig.TransactionLabel != "" {
+			if txConfig.PerformsRelationshipType == "" || txConfig.BenefitsToRelationshipType == "" {
+				return "transactionConfig.performsRelationshipType and transactionConfig.benefitsToRelationshipType are required when transactionLabel is set (e.g., 'PERFORMS' and 'BENEFITS_TO')."
+			}
+		} else if txConfig.TransactionRelationshipType == "" {
+			return "transactionConfig must describe the transaction model: set transactionLabel with performsRelationshipType/benefitsToRelationshipType for transaction nodes, or transactionRelationshipType for transaction relationships. Use get-schema to discover these first."
+		}
+		if txConfig.DateProperty == "" || txConfig.AmountProperty == "" {
+			return "transactionConfig.dateProperty and transactionConfig.amountProperty are required (e.g., 'date' and 'amount')."
+		}
+		if errMessage := query_builder.ValidateDateFormat("transactionConfig.dateFormat", txConfig.DateFormat); errMessage != "" {
+			return errMessage
+		}
+		if args.TransactionLimit == 0 {
+			args.TransactionLimit = defaultTransactionLimit
+		}
+		if args.TransactionLimit < 1 || args.TransactionLimit > maxTransactionLimit {
+			return fmt.Sprintf("transactionLimit must be between 1 and %d", maxTransactionLimit)
+		}
+
+		if args.VelocityConfig == nil {
+			args.VelocityConfig = &EvidenceVelocityConfig{}
+		}
+		if args.VelocityConfig.WindowHours == 0 {
+			args.VelocityConfig.WindowHours = defaultVelocityWindowHours
+		}
+		if args.VelocityConfig.WindowHours < 1 || args.VelocityConfig.WindowHours > maxVelocityWindowHours {
+			return fmt.Sprintf("velocityConfig.windowHours must be between 1 and %d", maxVelocityWindowHours)
+		}
+		if args.VelocityConfig.BusiestDays == 0 {
+			args.VelocityConfig.BusiestDays = defaultVelocityBusiestDays
+		}
+		if args.VelocityConfig.BusiestDays < 1 || args.VelocityConfig.BusiestDays > maxVelocityBusiestDays {
+			return fmt.Sprintf("velocityConfig.busiestDays must be between 1 and %d", maxVelocityBusiestDays)
+		}
+	} else if args.VelocityConfig != nil {
+		return "velocityConfig requires transactionConfig"
+	}
+
+	if network := args.NetworkConfig; network != nil {
+		if len(network.RelationshipTypes) == 0 {
+			return "networkConfig.relationshipTypes is required (e.g., ['HAS_EMAIL', 'HAS_PHONE']). Use get-schema to discover the relationships to shared attributes."
+		}
+		for i, relType := range network.RelationshipTypes {
+			if errMessage := query_builder.ValidateIdentifier(fmt.Sprintf("networkConfig.relationshipTypes[%d]", i), relType); errMessage != "" {
+				return errMessage
+			}
+		}
+		for i, property := range network.ViaProperties {
+			if errMessage := query_builder.ValidateIdentifier(fmt.Sprintf("networkConfig.viaProperties[%d]", i), property); errMessage != "" {
+				return errMessage
+			}
+		}
+		if network.MaxHops == 0 {
+			network.MaxHops = defaultNetworkMaxHops
+		}
+		if network.MaxHops < 1 || network.MaxHops > maxNetworkHops {
+			return fmt.Sprintf("networkConfig.maxHops must be between 1 and %d", maxNetworkHops)
+		}
+		if network.Limit == 0 {
+			network.Limit = defaultNetworkLimit
+		}
+		if network.Limit < 1 || network.Limit > maxNetworkLimit {
+			return fmt.Sprintf("networkConfig.limit must be between 1 and %d", maxNetworkLimit)
+		}
+	}
+
+	return ""
+}
+
+// buildEvidenceParams collects the parameters shared by all section queries.
+// Optional parameters are only set when the section using them is enabled.
+func buildEvidenceParams(args GatherSAREvidenceInput) map[string]any {
+	params := map[string]any{
+		"subjectId": args.SubjectId,
+	}
+	if args.StartDate != "" {
+		params["startDate"] = args.StartDate
+	}
+	if args.EndDate != "" {
+		params["endDate"] = args.EndDate
+	}
+	if args.TransactionConfig != nil {
+		params["transactionLimit"] = args.TransactionLimit
+		params["velocityWindowHours"] = args.VelocityConfig.WindowHours
+		params["busiestDays"] = args.VelocityConfig.BusiestDays
+	}
+	if args.NetworkConfig != nil {
+		params["networkLimit"] = args.NetworkConfig.Limit
+	}
+	return params
+}
+
+// buildOptionalSections returns the enabled sections after the profile, in result order
+func buildOptionalSections(args GatherSAREvidenceInput) []evidenceSection {
+	sections := make([]evidenceSection, 0, 3)
+	if args.TransactionConfig != nil {
+		sections = append(sections,
+			evidenceSection{name: "transactions", query: buildTransactionsEvidenceQuery(args)},
+			evidenceSection{name: "velocity", query: buildVelocityEvidenceQuery(args)},
+		)
+	}
+	if args.NetworkConfig != nil {
+		sections = append(sections, evidenceSection{name: "network", query: buildNetworkEvidenceQuery(args)})
+	}
+	return sections
+}
+
+// firstValue returns the single column of the first record, or nil when the query returned nothing
+func firstValue(records []*neo4j.Record) any {
+	if len(records) == 0 || len(records[0].Values) == 0 {
+		return nil
+	}
+	return records[0].Values[0]
+}
+
+func buildSubjectMatch(args GatherSAREvidenceInput) string {
+	return fmt.Sprintf("MATCH (e:%s {%s: $subjectId})\n", query_builder.EscapeLabelExpression(args.SubjectConfig.NodeLabel), query_builder.EscapeIdentifier(args.SubjectConfig.IdProperty))
+}
+
+// buildProfileEvidenceQuery builds the subject profile using the shared profile sections,
+// returning it with the parameters bound by attribute filters and limits
+func buildProfileEvidenceQuery(args GatherSAREvidenceInput) (string, map[string]any) {
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString(buildSubjectMatch(args))
+	sections := query_builder.BuildProfileSections("e", args.SubjectConfig.BaseProperties, args.AttributeMappings)
+	if sections.Matches != "" {
+		queryBuilder.WriteString(sections.Matches + "\n")
+	}
+	queryBuilder.WriteString(sections.With + "\n")
+	queryBuilder.WriteString(sections.Subqueries)
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString(strings.Join(sections.Entries, ",\n"))
+	queryBuilder.WriteString("\n} as profile")
+
+	return queryBuilder.String(), sections.Params
+}
+
+// buildTransactionsEvidenceQuery builds per-direction summaries and the largest transactions in the activity period
+func buildTransactionsEvidenceQuery(args GatherSAREvidenceInput) string {
+	txConfig := *args.TransactionConfig
+	periodFilter := buildPeriodFilter(args)
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString(buildSubjectMatch(args))
+	for _, direction := range []string{"out", "in"} {
+		alias := "outgoing"
+		if direction == "in" {
+			alias = "incoming"
+		}
+		queryBuilder.WriteString("CALL {\n")
+		queryBuilder.WriteString("  WITH e\n")
+		queryBuilder.WriteString(fmt.Sprintf("  OPTIONAL MATCH %s\n", buildEvidenceTransactionPattern(txConfig, direction)))
+		if periodFilter != "" {
+			queryBuilder.WriteString(fmt.Sprintf("  WHERE %s\n", periodFilter))
+		}
+		queryBuilder.WriteString("  RETURN {\n")
+		queryBuilder.WriteString("    count: count(DISTINCT t),\n")
+		queryBuilder.WriteString(fmt.Sprintf("    total: coalesce(sum(t.%s), 0),\n", query_builder.EscapeIdentifier(txConfig.AmountProperty)))
+		queryBuilder.WriteString(fmt.Sprintf("    largest: max(t.%s),\n", query_builder.EscapeIdentifier(txConfig.AmountProperty)))
+		queryBuilder.WriteString(fmt.Sprintf("    firstDate: min(%s),\n", evidenceDate(txConfig).Expression()))
+		queryBuilder.WriteString(fmt.Sprintf("    lastDate: max(%s),\n", evidenceDate(txConfig).Expression()))
+		queryBuilder.WriteString("    counterparties: count(DISTINCT cp)\n")
+		queryBuilder.WriteString(fmt.Sprintf("  } as %s\n", alias))
+		queryBuilder.WriteString("}\n")
+	}
+
+	counterparty := "properties(cp)"
+	if txConfig.AccountIdProperty != "" {
+		counterparty = "cp." + query_builder.EscapeIdentifier(txConfig.AccountIdProperty)
+	}
+	queryBuilder.WriteString("CALL {\n")
+	queryBuilder.WriteString("  WITH e\n")
+	queryBuilder.WriteString(buildDirectionalUnion(txConfig, periodFilter, "    "))
+	queryBuilder.WriteString(fmt.Sprintf("  WITH t, cp, direction\n  ORDER BY t.%s DESC\n", query_builder.EscapeIdentifier(txConfig.AmountProperty)))
+	queryBuilder.WriteString("  LIMIT $transactionLimit\n")
+	queryBuilder.WriteString(fmt.Sprintf("  RETURN collect({direction: direction, transaction: properties(t), counterparty: %s}) as largestTransactions\n", counterparty))
+	queryBuilder.WriteString("}\n")
+
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString("  outgoing: outgoing,\n")
+	queryBuilder.WriteString("  incoming: incoming,\n")
+	queryBuilder.WriteString("  largestTransactions: largestTransactions\n")
+	queryBuilder.WriteString("} as transactions")
+
+	return queryBuilder.String()
+}
+
+// buildVelocityEvidenceQuery builds the busiest days and the peak outgoing count within the velocity window
+func buildVelocityEvidenceQuery(args GatherSAREvidenceInput) string {
+	txConfig := *args.TransactionConfig
+	periodFilter := buildPeriodFilter(args)
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString(buildSubjectMatch(args))
+
+	// Daily activity in both directions
+	queryBuilder.WriteString("CALL {\n")
+	queryBuilder.WriteString("  WITH e\n")
+	queryBuilder.WriteString(buildDirectionalUnion(txConfig, periodFilter, "    "))
+	queryBuilder.WriteString(fmt.Sprintf("  WITH date(%s) as day, direction, t.%s as amount\n", evidenceDate(txConfig).Expression(), query_builder.EscapeIdentifier(txConfig.AmountProperty)))
+	queryBuilder.WriteString("  WITH day,\n")
+	queryBuilder.WriteString("       sum(CASE WHEN direction = 'out' THEN 1 ELSE 0 END) as outgoingCount,\n")
+	queryBuilder.WriteString("       sum(CASE WHEN direction = 'in' THEN 1 ELSE 0 END) as incomingCount,\n")
+	queryBuilder.WriteString("       sum(amount) as total\n")
+	queryBuilder.WriteString("  ORDER BY outgoingCount + incomingCount DESC, day ASC\n")
+	queryBuilder.WriteString("  LIMIT $busiestDays\n")
+	queryBuilder.WriteString("  RETURN collect({day: toString(day), outgoingCount: outgoingCount, incomingCount: incomingCount, total: total}) as busiestDays\n")
+	queryBuilder.WriteString("}\n")
+
+	// Peak number of outgoing transactions within any window: each transaction enters the window at its date
+	// and leaves it windowHours later, so the running count over the sorted events peaks at the busiest window.
+	// Leaving sorts before entering at the same instant, as a window does not include its end.
+	queryBuilder.WriteString("CALL {\n")
+	queryBuilder.WriteString("  WITH e\n")
+	queryBuilder.WriteString(fmt.Sprintf("  MATCH %s\n", buildEvidenceTransactionPattern(txConfig, "out")))
+	if periodFilter != "" {
+		queryBuilder.WriteString(fmt.Sprintf("  WHERE %s\n", periodFilter))
+	}
+	queryBuilder.WriteString(fmt.Sprintf("  WITH %s as at\n", evidenceDate(txConfig).Expression()))
+	queryBuilder.WriteString("  WHERE at IS NOT NULL\n")
+	queryBuilder.WriteString("  UNWIND [{at: at, change: 1}, {at: at + duration({hours: $velocityWindowHours}), change: -1}] as event\n")
+	queryBuilder.WriteString("  WITH event ORDER BY event.at ASC, event.change ASC\n")
+	queryBuilder.WriteString("  WITH collect(event.change) as changes\n")
+	queryBuilder.WriteString("  RETURN reduce(window = {open: 0, peak: 0}, change IN changes |\n")
+	queryBuilder.WriteString("    {open: window.open + change, peak: CASE WHEN window.open + change > window.peak THEN window.open + change ELSE window.peak END}).peak as peakOutgoingInWindow\n")
+	queryBuilder.WriteString("}\n")
+
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString("  windowHours: $velocityWindowHours,\n")
+	queryBuilder.WriteString("  peakOutgoingInWindow: peakOutgoingInWindow,\n")
+	queryBuilder.WriteString("  busiestDays: busiestDays\n")
+	queryBuilder.WriteString("} as velocity")
+
+	return queryBuilder.String()
+}
+
+// buildNetworkEvidenceQuery lists the other subjects of the same label within maxHops, nearest first.
+// Each linked subject is reached once and only the first networkLimit get a shortest connecting path.
+func buildNetworkEvidenceQuery(args GatherSAREvidenceInput) string {
+	network := *args.NetworkConfig
+	subject := args.SubjectConfig
+	relPattern := fmt.Sprintf("[:%s*1..%d]", strings.Join(query_builder.EscapeIdentifiers(network.RelationshipTypes), "|"), network.MaxHops)
+	var queryBuilder strings.Builder
+
+	via := "{labels: labels(n)}"
+	if len(network.ViaProperties) > 0 {
+		projections := make([]string, len(network.ViaProperties))
+		for i, property := range network.ViaProperties {
+			projections[i] = "." + query_builder.EscapeIdentifier(property)
+		}
+		via = fmt.Sprintf("{labels: labels(n), properties: n {%s}}", strings.Join(projections, ", "))
+	}
+
+	queryBuilder.WriteString(buildSubjectMatch(args))
+	queryBuilder.WriteString(fmt.Sprintf("OPTIONAL MATCH (e)-%s-(other:%s)\n", relPattern, query_builder.EscapeLabelExpression(subject.NodeLabel)))
+	queryBuilder.WriteString("WHERE other <> e\n")
+	queryBuilder.WriteString("WITH DISTINCT e, other\n")
+	queryBuilder.WriteString("WITH e, collect(other) as others\n")
+	queryBuilder.WriteString("CALL {\n")
+	queryBuilder.WriteString("  WITH e, others\n")
+	queryBuilder.WriteString("  UNWIND others as other\n")
+	queryBuilder.WriteString(fmt.Sprintf("  MATCH p = shortestPath((e)-%s-(other))\n", relPattern))
+	queryBuilder.WriteString("  WITH other, p\n")
+	queryBuilder.WriteString("  ORDER BY length(p) ASC\n")
+	queryBuilder.WriteString("  LIMIT $networkLimit\n")
+	queryBuilder.WriteString(fmt.Sprintf("  RETURN collect({%s: other.%s, distance: length(p), via: [n IN nodes(p)[1..-1] | %s]}) as linked\n",
+		query_builder.EscapeIdentifier(subject.IdProperty), query_builder.EscapeIdentifier(subject.IdProperty), via))
+	queryBuilder.WriteString("}\n")
+	queryBuilder.WriteString("RETURN {\n")
+	queryBuilder.WriteString("  linkedSubjectCount: size(others),\n")
+	queryBuilder.WriteString("  linkedSubjects: linked\n")
+	queryBuilder.WriteString("} as network")
+
+	return queryBuilder.String()
+}
+
+// buildDirectionalUnion returns a nested CALL subquery yielding t, cp and direction for both directions
+func buildDirectionalUnion(txConfig EvidenceTransactionConfig, periodFilter, indent string) string {
+	var union strings.Builder
+
+	union.WriteString("  CALL {\n")
+	for i, direction := range []string{"out", "in"} {
+		if i > 0 {
+			union.WriteString(indent + "UNION\n")
+		}
+		union.WriteString(indent + "WITH e\n")
+		union.WriteString(fmt.Sprintf("%sMATCH %s\n", indent, buildEvidenceTransactionPattern(txConfig, direction)))
+		if periodFilter != "" {
+			union.WriteString(fmt.Sprintf("%sWHERE %s\n", indent, periodFilter))
+		}
+		union.WriteString(fmt.Sprintf("%sRETURN t, cp, '%s' as direction\n", indent, direction))
+	}
+	union.WriteString("  }\n")
+
+	return union.String()
+}
+
+// buildEvidenceTransactionPattern builds the pattern binding the transaction (t) and counterparty (cp)
+// relative to the subject (e) for a single direction, going through the subject's accounts when configured
+func buildEvidenceTransactionPattern(txConfig EvidenceTransactionConfig, direction string) string {
+	account := "(e)"
+	if txConfig.AccountRelationshipType != "" {
+		accountLabel := ""
+		if txConfig.AccountLabel != "" {
+			accountLabel = ":" + query_builder.EscapeIdentifier(txConfig.AccountLabel)
+		}
+		account = fmt.Sprintf("(e)-[:%s]->(%s)", query_builder.EscapeIdentifier(txConfig.AccountRelationshipType), accountLabel)
+	}
+
+	if txConfig.TransactionLabel != "" {
+		// Node model: (sender)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(receiver)
+		if direction == "in" {
+			return fmt.Sprintf("%s<-[:%s]-(t:%s)<-[:%s]-(cp)",
+				account, query_builder.EscapeIdentifier(txConfig.BenefitsToRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel), query_builder.EscapeIdentifier(txConfig.PerformsRelationshipType))
+		}
+		return fmt.Sprintf("%s-[:%s]->(t:%s)-[:%s]->(cp)",
+			account, query_builder.EscapeIdentifier(txConfig.PerformsRelationshipType), query_builder.EscapeIdentifier(txConfig.TransactionLabel), query_builder.EscapeIdentifier(txConfig.BenefitsToRelationshipType))
+	}
+
+	// Relationship model: (sender)-[t:TRANSACTION]->(receiver)
+	if direction == "in" {
+		return fmt.Sprintf("%s<-[t:%s]-(cp)", account, query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType))
+	}
+	return fmt.Sprintf("%s-[t:%s]->(cp)", account, query_builder.EscapeIdentifier(txConfig.TransactionRelationshipType))
+}
+
+// buildPeriodFilter returns the WHERE predicate restricting transactions to the activity period, or an empty string
+func buildPeriodFilter(args GatherSAREvidenceInput) string {
+	startParam, endParam := "", ""
+	if args.StartDate != "" {
+		startParam = "startDate"
+	}
+	if args.EndDate != "" {
+		endParam = "endDate"
+	}
+	return query_builder.BetweenDates(evidenceDate(*args.TransactionConfig), startParam, endParam)
+}
+
+// evidenceDate returns the transaction date property, in the configured format
+func evidenceDate(txConfig EvidenceTransactionConfig) query_builder.TimeProperty {
+	return query_builder.TimeProperty{Variable: "t", Property: txConfig.DateProperty, Format: txConfig.DateFormat}
+}
@@ -0,0 +1,377 @@
+package sar_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/sar"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+var subjectConfig = map[string]any{
+	"nodeLabel":  "Customer",
+	"idProperty": "customerId",
+}
+
+var evidenceTransactionConfig = map[string]any{
+	"accountRelationshipType":    "OWNS",
+	"accountLabel":               "Account",
+	"accountIdProperty":          "accountNumber",
+	"transactionLabel":           "Transaction",
+	"performsRelationshipType":   "PERFORMS",
+	"benefitsToRelationshipType": "BENEFITS_TO",
+	"dateProperty":               "date",
+	"amountProperty":             "amount",
+}
+
+func sectionRecord(name string) []*neo4j.Record {
+	return []*neo4j.Record{{Keys: []string{name}, Values: []any{map[string]any{}}}}
+}
+
+func TestGatherSAREvidenceHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("gather-sar-evidence").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("runs every configured section", func(t *testing.T) {
+		expectedParams := map[string]any{
+			"subjectId":           "CUS123",
+			"startDate":           "2024-01-01T00:00:00Z",
+			"transactionLimit":    10,
+			"velocityWindowHours": 24,
+			"busiestDays":         7,
+			"networkLimit":        25,
+		}
+
+		var executed []string
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), expectedParams).
+			Times(4).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "MATCH (e:Customer {customerId: $subjectId})") {
+					t.Errorf("Expected subject match, got: %s", query)
+				}
+				switch {
+				case strings.HasSuffix(query, "as profile"):
+					if !strings.Contains(query, "OPTIONAL MATCH (e)-[:HAS_SSN]->(attr0:SSN)") {
+						t.Errorf("Expected attribute match in profile, got: %s", query)
+					}
+					executed = append(executed, "profile")
+					return sectionRecord("profile"), nil
+				case strings.HasSuffix(query, "as transactions"):
+					if !strings.Contains(query, "(e)-[:OWNS]->(:Account)-[:PERFORMS]->(t:Transaction)-[:BENEFITS_TO]->(cp)") {
+						t.Errorf("Expected outgoing transaction pattern, got: %s", query)
+					}
+					if !strings.Contains(query, "(e)-[:OWNS]->(:Account)<-[:BENEFITS_TO]-(t:Transaction)<-[:PERFORMS]-(cp)") {
+						t.Errorf("Expected incoming transaction pattern, got: %s", query)
+					}
+					if !strings.Contains(query, "WHERE t.date >= datetime($startDate)") {
+						t.Errorf("Expected activity period filter, got: %s", query)
+					}
+					if !strings.Contains(query, "counterparty: cp.accountNumber") {
+						t.Errorf("Expected counterparty id, got: %s", query)
+					}
+					executed = append(executed, "transactions")
+					return sectionRecord("transactions"), nil
+				case strings.HasSuffix(query, "as velocity"):
+					if !strings.Contains(query, "duration({hours: $velocityWindowHours})") {
+						t.Errorf("Expected velocity window, got: %s", query)
+					}
+					if !strings.Contains(query, "ORDER BY event.at ASC, event.change ASC") {
+						t.Errorf("Expected velocity over sorted window events, got: %s", query)
+					}
+					executed = append(executed, "velocity")
+					return sectionRecord("velocity"), nil
+				case strings.HasSuffix(query, "as network"):
+					if !strings.Contains(query, "OPTIONAL MATCH (e)-[:HAS_SSN|HAS_PHONE*1..2]-(other:Customer)") {
+						t.Errorf("Expected network expansion, got: %s", query)
+					}
+					if !strings.Contains(query, "MATCH p = shortestPath((e)-[:HAS_SSN|HAS_PHONE*1..2]-(other))") || !strings.Contains(query, "LIMIT $networkLimit") {
+						t.Errorf("Expected one limited shortest path per linked subject, got: %s", query)
+					}
+					if strings.Contains(query, "properties(n)") {
+						t.Errorf("Expected no connecting node properties without viaProperties, got: %s", query)
+					}
+					executed = append(executed, "network")
+					return sectionRecord("network"), nil
+				}
+				t.Errorf("Unexpected query: %s", query)
+				return nil, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, records []*neo4j.Record) (string, error) {
+				keys := strings.Join(records[0].Keys, ",")
+				if keys != "subjectId,profile,transactions,velocity,network" {
+					t.Errorf("Expected all sections in order, got: %s", keys)
+				}
+				return `[{"subjectId": "CUS123"}]`, nil
+			})
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":     "CUS123",
+					"subjectConfig": subjectConfig,
+					"attributeMappings": []map[string]any{
+						{"relationshipType": "HAS_SSN", "targetLabel": "SSN", "category": "identity_documents"},
+					},
+					"transactionConfig": evidenceTransactionConfig,
+					"networkConfig":     map[string]any{"relationshipTypes": []string{"HAS_SSN", "HAS_PHONE"}},
+					"startDate":         "2024-01-01T00:00:00Z",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+		if strings.Join(executed, ",") != "profile,transactions,velocity,network" {
+			t.Errorf("Expected sections to run in order, got: %v", executed)
+		}
+	})
+
+	t.Run("failing section is reported without failing the tool", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Times(2).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if strings.HasSuffix(query, "as profile") {
+					return sectionRecord("profile"), nil
+				}
+				return nil, errors.New("query timed out")
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, records []*neo4j.Record) (string, error) {
+				network, _ := records[0].Get("network")
+				if section, ok := network.(map[string]any); !ok || section["error"] != "query timed out" {
+					t.Errorf("Expected network section error, got: %v", network)
+				}
+				return `[]`, nil
+			})
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":     "CUS123",
+					"subjectConfig": subjectConfig,
+					"networkConfig": map[string]any{"relationshipTypes": []string{"HAS_EMAIL"}},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("subject not found", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{}, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":     "MISSING",
+					"subjectConfig": subjectConfig,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing subject")
+		}
+	})
+
+	t.Run("velocity without transactionConfig", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":      "CUS123",
+					"subjectConfig":  subjectConfig,
+					"velocityConfig": map[string]any{"windowHours": 12},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for velocityConfig without transactionConfig")
+		}
+	})
+
+	t.Run("network without relationshipTypes", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":     "CUS123",
+					"subjectConfig": subjectConfig,
+					"networkConfig": map[string]any{"maxHops": 2},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for networkConfig without relationshipTypes")
+		}
+	})
+
+	t.Run("velocity window too long", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":         "CUS123",
+					"subjectConfig":     subjectConfig,
+					"transactionConfig": map[string]any{"transactionRelationshipType": "TRANSACTION", "dateProperty": "date", "amountProperty": "amount"},
+					"velocityConfig":    map[string]any{"windowHours": 10000},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for velocity window over the maximum")
+		}
+	})
+
+	t.Run("previewQuery returns every section query without executing them", func(t *testing.T) {
+		// No expectations: any database call fails the test
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjectId":         "CUS123",
+					"subjectConfig":     subjectConfig,
+					"transactionConfig": evidenceTransactionConfig,
+					"previewQuery":      true,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		for _, section := range []string{`"name": "profile"`, `"name": "transactions"`, `"name": "velocity"`} {
+			if !strings.Contains(text, section) {
+				t.Errorf("Expected %s in the preview, got: %s", section, text)
+			}
+		}
+		if !strings.Contains(text, `"subjectId": "CUS123"`) {
+			t.Errorf("Expected the query parameters in the preview, got: %s", text)
+		}
+	})
+
+	t.Run("missing database service", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+		}
+
+		handler := sar.GatherSAREvidenceHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result when database service is nil")
+		}
+	})
+}
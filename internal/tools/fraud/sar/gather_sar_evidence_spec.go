@@ -0,0 +1,111 @@
+package sar
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+// SubjectConfig identifies the SAR subject node
+type SubjectConfig struct {
+	NodeLabel      string   `json:"nodeLabel" jsonschema:"description=Node label of the subject (e.g. Customer, Person). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
+	IdProperty     string   `json:"idProperty" jsonschema:"description=Property name for the unique identifier (e.g. customerId)"`
+	BaseProperties []string `json:"baseProperties,omitempty" jsonschema:"description=Subject properties to include in the profile (e.g. [firstName, lastName, dateOfBirth]). If empty, returns all properties."`
+}
+
+// EvidenceTransactionConfig describes how the subject reaches its transactions.
+// Node model: (:Account)-[:PERFORMS]->(:Transaction)-[:BENEFITS_TO]->(:Account).
+// Relationship model: (:Account)-[:TRANSACTION]->(:Account).
+type EvidenceTransactionConfig struct {
+	AccountRelationshipType     string `json:"accountRelationshipType,omitempty" jsonschema:"description=Relationship from the subject to its accounts (e.g. OWNS). Omit when the subject is the account itself."`
+	AccountLabel                string `json:"accountLabel,omitempty" jsonschema:"description=Node label of accounts (e.g. Account)"`
+	AccountIdProperty           string `json:"accountIdProperty,omitempty" jsonschema:"description=Property identifying counterparty accounts in results (e.g. accountNumber). If empty, counterparty properties are returned."`
+	TransactionLabel            string `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction)"`
+	PerformsRelationshipType    string `json:"performsRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the sending account to the transaction (e.g. PERFORMS)"`
+	BenefitsToRelationshipType  string `json:"benefitsToRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the receiving account (e.g. BENEFITS_TO)"`
+	TransactionRelationshipType string `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: relationship from the sending to the receiving account (e.g. TRANSACTION)"`
+	DateProperty                string `json:"dateProperty" jsonschema:"description=Property holding the transaction datetime (e.g. date)"`
+	DateFormat                  string `json:"dateFormat,omitempty" jsonschema:"enum=datetime,enum=date,enum=epochMillis,default=datetime,description=How dateProperty values are stored: datetime values (default), date values or epoch milliseconds"`
+	AmountProperty              string `json:"amountProperty" jsonschema:"description=Property holding the transaction amount (e.g. amount)"`
+}
+
+// EvidenceNetworkConfig controls the linked-subject search
+type EvidenceNetworkConfig struct {
+	RelationshipTypes []string `json:"relationshipTypes" jsonschema:"description=Relationship types to traverse (required, e.g. [HAS_EMAIL, HAS_PHONE, HAS_DEVICE])"`
+	MaxHops           int      `json:"maxHops,omitempty" jsonschema:"default=2,description=Maximum path length to other subjects (1-4). Two hops finds subjects sharing an attribute node."`
+	Limit             int      `json:"limit,omitempty" jsonschema:"default=25,description=Maximum number of linked subjects to return (1-100)"`
+	ViaProperties     []string `json:"viaProperties,omitempty" jsonschema:"description=Properties of the connecting nodes to return (e.g. [email, phoneNumber]). If empty, only their labels are returned."`
+}
+
+// EvidenceVelocityConfig controls the velocity section
+type EvidenceVelocityConfig struct {
+	WindowHours int `json:"windowHours,omitempty" jsonschema:"default=24,description=Window length in hours for the peak outgoing transaction count (1-720)"`
+	BusiestDays int `json:"busiestDays,omitempty" jsonschema:"default=7,description=Number of busiest days to return (1-31)"`
+}
+
+type GatherSAREvidenceInput struct {
+	SubjectId         string                           `json:"subjectId" jsonschema:"description=ID of the SAR subject (required)"`
+	SubjectConfig     SubjectConfig                    `json:"subjectConfig" jsonschema:"description=Configuration for the subject node. Discovered from get-schema."`
+	AttributeMappings []query_builder.AttributeMapping `json:"attributeMappings,omitempty" jsonschema:"description=Identity attributes for the profile section (addresses, emails, phones, identity documents), same format as get-customer-profile"`
+	MappingName       string                           `json:"mappingName,omitempty" jsonschema:"description=Optional: name of a mapping saved with save-schema-mapping. Supplies attributeMappings when they are omitted."`
+	TransactionConfig *EvidenceTransactionConfig       `json:"transactionConfig,omitempty" jsonschema:"description=How the subject reaches its transactions. Enables the transactions and velocity sections."`
+	NetworkConfig     *EvidenceNetworkConfig           `json:"networkConfig,omitempty" jsonschema:"description=Enables the network section listing other subjects linked to this one"`
+	VelocityConfig    *EvidenceVelocityConfig          `json:"velocityConfig,omitempty" jsonschema:"description=Optional velocity settings. The velocity section runs whenever transactionConfig is set."`
+	StartDate         string                           `json:"startDate,omitempty" jsonschema:"description=Optional: start of the suspicious activity period (ISO 8601)"`
+	EndDate           string                           `json:"endDate,omitempty" jsonschema:"description=Optional: end of the suspicious activity period (ISO 8601)"`
+	TransactionLimit  int                              `json:"transactionLimit,omitempty" jsonschema:"default=10,description=Number of largest transactions to return in the transactions section (1-100)"`
+	PreviewQuery      bool                             `json:"previewQuery,omitempty" jsonschema:"default=false,description=Return the generated Cypher query of each evidence section and its parameters without executing them, to review what the tool will run"`
+}
+
+// GatherSAREvidenceSpec returns the tool specification for gather-sar-evidence
+func GatherSAREvidenceSpec() mcp.Tool {
+	return mcp.NewTool("gather-sar-evidence",
+		mcp.WithDescription(`Runs the SAR evidence queries from get-sar-report-guidance against the actual schema for a single subject and returns structured evidence sections ready for narrative drafting.
+
+**SECTIONS:**
+- **profile** (always): subject properties and identity attributes from attributeMappings (SAR Part I)
+- **transactions** (transactionConfig): incoming and outgoing totals, counts, date range, counterparties and the largest transactions in the activity period (SAR Part II)
+- **velocity** (transactionConfig): busiest days and the peak number of outgoing transactions within windowHours
+- **network** (networkConfig): other subjects of the same label linked within maxHops through relationshipTypes, nearest first, and the nodes on the shortest path to each
+
+Each section runs as its own query. If a section fails, its value is {"error": "..."} and the other sections are still returned.
+The activity period (startDate/endDate) applies to the transactions and velocity sections.
+
+**REQUIRED WORKFLOW - Schema Discovery:**
+1. **Call get-schema tool** to retrieve the database schema
+2. **Configure subjectConfig and attributeMappings** as for get-customer-profile
+3. **Configure transactionConfig** as for get-transaction-history
+4. **Configure networkConfig** with the relationships that connect subjects to shared attributes
+
+**Example:**
+{
+  "subjectId": "CUS123",
+  "subjectConfig": {"nodeLabel": "Customer", "idProperty": "customerId", "baseProperties": ["firstName", "lastName", "dateOfBirth"]},
+  "attributeMappings": [
+    {"relationshipType": "HAS_ADDRESS", "targetLabel": "Address", "category": "contact_information"},
+    {"relationshipType": "HAS_SSN", "targetLabel": "SSN", "category": "identity_documents"}
+  ],
+  "transactionConfig": {
+    "accountRelationshipType": "OWNS",
+    "accountLabel": "Account",
+    "accountIdProperty": "accountNumber",
+    "transactionLabel": "Transaction",
+    "performsRelationshipType": "PERFORMS",
+    "benefitsToRelationshipType": "BENEFITS_TO",
+    "dateProperty": "date",
+    "amountProperty": "amount"
+  },
+  "networkConfig": {"relationshipTypes": ["HAS_EMAIL", "HAS_PHONE", "HAS_SSN"], "maxHops": 2, "viaProperties": ["email", "phoneNumber"]},
+  "startDate": "2024-01-01T00:00:00Z",
+  "endDate": "2024-03-31T23:59:59Z"
+}
+
+**Returns:**
+- subjectId, profile, transactions, velocity and network sections`),
+		mcp.WithInputSchema[GatherSAREvidenceInput](),
+		mcp.WithTitleAnnotation("Gather SAR Evidence"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
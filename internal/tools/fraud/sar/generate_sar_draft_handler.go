@@ -0,0 +1,243 @@
+package sar
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	sarFormName        = "FinCEN SAR (Form 111)"
+	maxNarrativeLength = 17000
+)
+
+var validFilingTypes = []string{"initial", "correct", "amend", "continuing"}
+
+// GenerateSARDraftHandler returns a handler function for the generate-sar-draft tool
+func GenerateSARDraftHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleGenerateSARDraft(ctx, deps, request)
+	}
+}
+
+// handleGenerateSARDraft builds the SAR draft and renders it in the requested format
+func handleGenerateSARDraft(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("generate-sar-draft"))
+
+	var args GenerateSARDraftInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateDraftInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	draft := buildSARDraft(args)
+
+	slog.Info("generated SAR draft",
+		"format", args.Format,
+		"subjects", len(draft.PartI.Subjects),
+		"missingFields", len(draft.MissingFields))
+
+	response, err := renderSARDraft(draft, args.Format)
+	if err != nil {
+		slog.Error("error formatting SAR draft", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateDraftInput rejects input that cannot produce a valid draft and fills in defaults.
+// Empty required SAR fields are not errors; they are reported in missingFields.
+func validateDraftInput(args *GenerateSARDraftInput) string {
+	if args.Format == "" {
+		args.Format = "json"
+	}
+	if args.Format != "json" && args.Format != "xml" {
+		return fmt.Sprintf("invalid format '%s', must be one of: json, xml", args.Format)
+	}
+	if args.FilingType == "" {
+		args.FilingType = "initial"
+	}
+	if !slices.Contains(validFilingTypes, args.FilingType) {
+		return fmt.Sprintf("invalid filingType '%s', must be one of: initial, correct, amend, continuing", args.FilingType)
+	}
+	if len(args.Narrative) > maxNarrativeLength {
+		return fmt.Sprintf("narrative is %d characters, the SAR narrative limit is %d", len(args.Narrative), maxNarrativeLength)
+	}
+	for i := range args.Subjects {
+		if args.Subjects[i].EntityType == "" {
+			args.Subjects[i].EntityType = "individual"
+		}
+		if args.Subjects[i].EntityType != "individual" && args.Subjects[i].EntityType != "entity" {
+			return fmt.Sprintf("subjects[%d] has invalid entityType '%s', must be one of: individual, entity", i, args.Subjects[i].EntityType)
+		}
+	}
+	return ""
+}
+
+// buildSARDraft assembles the draft, derives activity fields from evidence and records missing fields
+func buildSARDraft(args GenerateSARDraftInput) SARDraft {
+	activity := args.Activity
+	applyEvidenceToActivity(&activity, args.Evidence)
+
+	subjects := args.Subjects
+	if subjects == nil {
+		subjects = []SARSubject{}
+	}
+
+	draft := SARDraft{
+		Form:              sarFormName,
+		FilingType:        args.FilingType,
+		PriorReportNumber: args.PriorReportNumber,
+		PartI:             SARPartI{Subjects: subjects},
+		PartII:            activity,
+		PartIII:           args.Institution,
+		PartIV:            SARPartIV{Narrative: args.Narrative},
+	}
+	draft.MissingFields = findMissingFields(draft)
+
+	return draft
+}
+
+// applyEvidenceToActivity fills empty activity dates and amount from the gather-sar-evidence transactions section
+func applyEvidenceToActivity(activity *SARActivity, evidence map[string]any) {
+	transactions, ok := evidence["transactions"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	var firstDate, lastDate string
+	var total float64
+	for _, direction := range []string{"outgoing", "incoming"} {
+		summary, ok := transactions[direction].(map[string]any)
+		if !ok {
+			continue
+		}
+		if date, ok := summary["firstDate"].(string); ok && date != "" && (firstDate == "" || date < firstDate) {
+			firstDate = date
+		}
+		if date, ok := summary["lastDate"].(string); ok && date > lastDate {
+			lastDate = date
+		}
+		if amount, ok := summary["total"].(float64); ok {
+			total += amount
+		}
+	}
+
+	if activity.DateFrom == "" {
+		activity.DateFrom = datePart(firstDate)
+	}
+	if activity.DateTo == "" {
+		activity.DateTo = datePart(lastDate)
+	}
+	if activity.TotalAmount == 0 {
+		activity.TotalAmount = total
+	}
+}
+
+// datePart trims an ISO 8601 datetime to its YYYY-MM-DD date
+func datePart(datetime string) string {
+	if len(datetime) > 10 {
+		return datetime[:10]
+	}
+	return datetime
+}
+
+// findMissingFields lists the critical SAR fields that are still empty, using dotted paths into the draft
+func findMissingFields(draft SARDraft) []string {
+	missing := make([]string, 0)
+
+	if draft.FilingType != "initial" && draft.PriorReportNumber == "" {
+		missing = append(missing, "priorReportNumber")
+	}
+
+	if len(draft.PartI.Subjects) == 0 {
+		missing = append(missing, "partI.subjects")
+	}
+	for i, subject := range draft.PartI.Subjects {
+		prefix := fmt.Sprintf("partI.subjects[%d]", i)
+		if subject.LastNameOrEntityName == "" {
+			missing = append(missing, prefix+".lastNameOrEntityName")
+		}
+		if subject.EntityType == "individual" {
+			if subject.FirstName == "" {
+				missing = append(missing, prefix+".firstName")
+			}
+			if subject.DateOfBirth == "" {
+				missing = append(missing, prefix+".dateOfBirth")
+			}
+		}
+		if subject.TIN == "" {
+			missing = append(missing, prefix+".tin")
+		}
+		if len(subject.Addresses) == 0 {
+			missing = append(missing, prefix+".addresses")
+		}
+	}
+
+	if draft.PartII.DateFrom == "" {
+		missing = append(missing, "partII.dateFrom")
+	}
+	if draft.PartII.DateTo == "" {
+		missing = append(missing, "partII.dateTo")
+	}
+	if draft.PartII.TotalAmount == 0 {
+		missing = append(missing, "partII.totalAmount")
+	}
+	if len(draft.PartII.Categories) == 0 {
+		missing = append(missing, "partII.categories")
+	}
+
+	if draft.PartIII.Name == "" {
+		missing = append(missing, "partIII.name")
+	}
+	if draft.PartIII.TIN == "" {
+		missing = append(missing, "partIII.tin")
+	}
+	if draft.PartIII.PrimaryFederalRegulator == "" {
+		missing = append(missing, "partIII.primaryFederalRegulator")
+	}
+	if draft.PartIII.Address.City == "" || draft.PartIII.Address.State == "" {
+		missing = append(missing, "partIII.address")
+	}
+
+	if draft.PartIV.Narrative == "" {
+		missing = append(missing, "partIV.narrative")
+	}
+
+	return missing
+}
+
+// renderSARDraft marshals the draft as indented JSON or XML
+func renderSARDraft(draft SARDraft, format string) (string, error) {
+	if format == "xml" {
+		output, err := xml.MarshalIndent(draft, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to format SAR draft as XML: %w", err)
+		}
+		return xml.Header + string(output), nil
+	}
+
+	output, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to format SAR draft as JSON: %w", err)
+	}
+	return string(output), nil
+}
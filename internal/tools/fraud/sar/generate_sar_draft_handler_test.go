@@ -0,0 +1,198 @@
+package sar_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/sar"
+	"go.uber.org/mock/gomock"
+)
+
+var institution = map[string]any{
+	"name":                    "Example Bank",
+	"tin":                     "12-3456789",
+	"primaryFederalRegulator": "OCC",
+	"address":                 map[string]any{"street": "1 Main St", "city": "Springfield", "state": "IL", "zip": "62701", "country": "US"},
+}
+
+var subjects = []map[string]any{
+	{
+		"lastNameOrEntityName": "Doe",
+		"firstName":            "John",
+		"dateOfBirth":          "1980-01-01",
+		"tin":                  "123-45-6789",
+		"addresses":            []map[string]any{{"street": "2 Elm St", "city": "Springfield", "state": "IL"}},
+	},
+}
+
+func TestGenerateSARDraftHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("generate-sar-draft").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	deps := &tools.ToolDependencies{
+		AnalyticsService: analyticsService,
+	}
+
+	t.Run("derives activity from evidence", func(t *testing.T) {
+		handler := sar.GenerateSARDraftHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"subjects":    subjects,
+					"activity":    map[string]any{"categories": []string{"structuring"}},
+					"institution": institution,
+					"narrative":   "Subject made repeated cash deposits below the reporting threshold.",
+					"evidence": map[string]any{
+						"transactions": map[string]any{
+							"outgoing": map[string]any{"total": 9000.0, "firstDate": "2024-02-01T10:00:00Z", "lastDate": "2024-03-15T10:00:00Z"},
+							"incoming": map[string]any{"total": 10500.0, "firstDate": "2024-01-20T09:00:00Z", "lastDate": "2024-03-10T09:00:00Z"},
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		var draft sar.SARDraft
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &draft); err != nil {
+			t.Fatalf("Expected JSON draft, got error: %v", err)
+		}
+		if draft.FilingType != "initial" {
+			t.Errorf("Expected initial filing, got: %s", draft.FilingType)
+		}
+		if draft.PartII.DateFrom != "2024-01-20" || draft.PartII.DateTo != "2024-03-15" {
+			t.Errorf("Expected activity dates from evidence, got: %s - %s", draft.PartII.DateFrom, draft.PartII.DateTo)
+		}
+		if draft.PartII.TotalAmount != 19500 {
+			t.Errorf("Expected total amount from evidence, got: %v", draft.PartII.TotalAmount)
+		}
+		if len(draft.MissingFields) != 0 {
+			t.Errorf("Expected no missing fields, got: %v", draft.MissingFields)
+		}
+	})
+
+	t.Run("reports missing fields", func(t *testing.T) {
+		handler := sar.GenerateSARDraftHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"filingType":  "amend",
+					"institution": institution,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		var draft sar.SARDraft
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &draft); err != nil {
+			t.Fatalf("Expected JSON draft, got error: %v", err)
+		}
+		for _, field := range []string{"priorReportNumber", "partI.subjects", "partII.dateFrom", "partII.totalAmount", "partIV.narrative"} {
+			found := false
+			for _, missing := range draft.MissingFields {
+				if missing == field {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("Expected %s in missingFields, got: %v", field, draft.MissingFields)
+			}
+		}
+	})
+
+	t.Run("renders XML", func(t *testing.T) {
+		handler := sar.GenerateSARDraftHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"format":      "xml",
+					"subjects":    subjects,
+					"institution": institution,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		content := result.Content[0].(mcp.TextContent).Text
+		for _, expected := range []string{`<?xml`, `<SARDraft form="FinCEN SAR (Form 111)">`, `<LastNameOrEntityName>Doe</LastNameOrEntityName>`, `<PrimaryFederalRegulator>OCC</PrimaryFederalRegulator>`, `<Field>partIV.narrative</Field>`} {
+			if !strings.Contains(content, expected) {
+				t.Errorf("Expected XML to contain %s, got: %s", expected, content)
+			}
+		}
+	})
+
+	t.Run("rejects overlong narrative", func(t *testing.T) {
+		handler := sar.GenerateSARDraftHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"institution": institution,
+					"narrative":   strings.Repeat("x", 17001),
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for overlong narrative")
+		}
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		handler := sar.GenerateSARDraftHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"format":      "pdf",
+					"institution": institution,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for unknown format")
+		}
+	})
+}
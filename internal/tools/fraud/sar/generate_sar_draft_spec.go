@@ -0,0 +1,127 @@
+package sar
+
+import (
+	"encoding/xml"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type SARAddress struct {
+	Street  string `json:"street,omitempty" xml:"Street,omitempty" jsonschema:"description=Street address"`
+	City    string `json:"city,omitempty" xml:"City,omitempty" jsonschema:"description=City"`
+	State   string `json:"state,omitempty" xml:"State,omitempty" jsonschema:"description=State or province code"`
+	ZIP     string `json:"zip,omitempty" xml:"ZIP,omitempty" jsonschema:"description=ZIP or postal code"`
+	Country string `json:"country,omitempty" xml:"Country,omitempty" jsonschema:"description=Country code (e.g. US)"`
+}
+
+type SARIdentification struct {
+	Type           string `json:"type,omitempty" xml:"Type,omitempty" jsonschema:"description=Identification type (e.g. driver's license, passport)"`
+	Number         string `json:"number,omitempty" xml:"Number,omitempty" jsonschema:"description=Identification number"`
+	IssuingState   string `json:"issuingState,omitempty" xml:"IssuingState,omitempty" jsonschema:"description=Issuing state"`
+	IssuingCountry string `json:"issuingCountry,omitempty" xml:"IssuingCountry,omitempty" jsonschema:"description=Issuing country"`
+}
+
+// SARSubject is a Part I subject
+type SARSubject struct {
+	EntityType                string              `json:"entityType,omitempty" xml:"EntityType,omitempty" jsonschema:"enum=individual,enum=entity,default=individual,description=Whether the subject is an individual or a legal entity"`
+	LastNameOrEntityName      string              `json:"lastNameOrEntityName" xml:"LastNameOrEntityName" jsonschema:"description=Individual last name or entity legal name"`
+	FirstName                 string              `json:"firstName,omitempty" xml:"FirstName,omitempty" jsonschema:"description=Individual first name"`
+	MiddleName                string              `json:"middleName,omitempty" xml:"MiddleName,omitempty" jsonschema:"description=Individual middle name"`
+	AlternateNames            []string            `json:"alternateNames,omitempty" xml:"AlternateNames>Name,omitempty" jsonschema:"description=Also known as or doing business as names"`
+	DateOfBirth               string              `json:"dateOfBirth,omitempty" xml:"DateOfBirth,omitempty" jsonschema:"description=Date of birth (YYYY-MM-DD)"`
+	TIN                       string              `json:"tin,omitempty" xml:"TIN,omitempty" jsonschema:"description=SSN, ITIN or EIN"`
+	TINType                   string              `json:"tinType,omitempty" xml:"TINType,omitempty" jsonschema:"enum=SSN-ITIN,enum=EIN,enum=foreign,description=Type of TIN"`
+	Identification            []SARIdentification `json:"identification,omitempty" xml:"Identification,omitempty" jsonschema:"description=Government-issued identification"`
+	Addresses                 []SARAddress        `json:"addresses,omitempty" xml:"Address,omitempty" jsonschema:"description=Subject addresses"`
+	Phones                    []string            `json:"phones,omitempty" xml:"Phone,omitempty" jsonschema:"description=Phone numbers"`
+	Emails                    []string            `json:"emails,omitempty" xml:"Email,omitempty" jsonschema:"description=Email addresses"`
+	Occupation                string              `json:"occupation,omitempty" xml:"Occupation,omitempty" jsonschema:"description=Occupation or type of business"`
+	RelationshipToInstitution string              `json:"relationshipToInstitution,omitempty" xml:"RelationshipToInstitution,omitempty" jsonschema:"description=Relationship to the institution (e.g. customer, employee, borrower)"`
+	AccountNumbers            []string            `json:"accountNumbers,omitempty" xml:"AccountNumber,omitempty" jsonschema:"description=Accounts affected by the activity"`
+}
+
+// SARActivity is the Part II suspicious activity information
+type SARActivity struct {
+	DateFrom    string   `json:"dateFrom,omitempty" xml:"DateFrom,omitempty" jsonschema:"description=First date of suspicious activity (YYYY-MM-DD). Derived from evidence when omitted."`
+	DateTo      string   `json:"dateTo,omitempty" xml:"DateTo,omitempty" jsonschema:"description=Last date of suspicious activity (YYYY-MM-DD). Derived from evidence when omitted."`
+	TotalAmount float64  `json:"totalAmount,omitempty" xml:"TotalAmount,omitempty" jsonschema:"description=Total dollar amount involved. Derived from evidence when omitted."`
+	Categories  []string `json:"categories,omitempty" xml:"Category,omitempty" jsonschema:"description=Activity classifications (e.g. structuring, synthetic identity fraud, money laundering)"`
+	Products    []string `json:"products,omitempty" xml:"Product,omitempty" jsonschema:"description=Products involved (e.g. deposit account, wire, credit card)"`
+	Instruments []string `json:"instruments,omitempty" xml:"Instrument,omitempty" jsonschema:"description=Instruments or payment mechanisms involved (e.g. funds transfer, U.S. currency)"`
+}
+
+// SARInstitution is the Part III financial institution information
+type SARInstitution struct {
+	Name                    string     `json:"name" xml:"Name" jsonschema:"description=Legal name of the financial institution"`
+	TIN                     string     `json:"tin" xml:"TIN" jsonschema:"description=Institution TIN/EIN"`
+	PrimaryFederalRegulator string     `json:"primaryFederalRegulator" xml:"PrimaryFederalRegulator" jsonschema:"description=Primary federal regulator (e.g. OCC, FDIC, FRB, NCUA)"`
+	RSSDNumber              string     `json:"rssdNumber,omitempty" xml:"RSSDNumber,omitempty" jsonschema:"description=Optional RSSD number"`
+	Address                 SARAddress `json:"address" xml:"Address" jsonschema:"description=Institution address"`
+	ContactOffice           string     `json:"contactOffice,omitempty" xml:"ContactOffice,omitempty" jsonschema:"description=Designated contact office"`
+	ContactPhone            string     `json:"contactPhone,omitempty" xml:"ContactPhone,omitempty" jsonschema:"description=Contact office phone number"`
+}
+
+type GenerateSARDraftInput struct {
+	Format            string         `json:"format,omitempty" jsonschema:"enum=json,enum=xml,default=json,description=Output format"`
+	FilingType        string         `json:"filingType,omitempty" jsonschema:"enum=initial,enum=correct,enum=amend,enum=continuing,default=initial,description=Type of filing"`
+	PriorReportNumber string         `json:"priorReportNumber,omitempty" jsonschema:"description=BSA ID of the prior report (required for correct, amend and continuing filings)"`
+	Subjects          []SARSubject   `json:"subjects,omitempty" jsonschema:"description=Part I subjects"`
+	Activity          SARActivity    `json:"activity,omitempty" jsonschema:"description=Part II suspicious activity information"`
+	Institution       SARInstitution `json:"institution" jsonschema:"description=Part III filing institution details (user supplied)"`
+	Narrative         string         `json:"narrative,omitempty" jsonschema:"description=Part IV narrative (maximum 17000 characters)"`
+	Evidence          map[string]any `json:"evidence,omitempty" jsonschema:"description=Optional gather-sar-evidence result for the primary subject, used to fill activity dates and amounts that are not supplied"`
+}
+
+// SARDraft is the machine-readable FinCEN SAR draft returned by generate-sar-draft
+type SARDraft struct {
+	XMLName           xml.Name       `json:"-" xml:"SARDraft"`
+	Form              string         `json:"form" xml:"form,attr"`
+	FilingType        string         `json:"filingType" xml:"FilingType"`
+	PriorReportNumber string         `json:"priorReportNumber,omitempty" xml:"PriorReportNumber,omitempty"`
+	PartI             SARPartI       `json:"partI" xml:"PartI"`
+	PartII            SARActivity    `json:"partII" xml:"PartII"`
+	PartIII           SARInstitution `json:"partIII" xml:"PartIII"`
+	PartIV            SARPartIV      `json:"partIV" xml:"PartIV"`
+	MissingFields     []string       `json:"missingFields" xml:"MissingFields>Field"`
+}
+
+type SARPartI struct {
+	Subjects []SARSubject `json:"subjects" xml:"Subject"`
+}
+
+type SARPartIV struct {
+	Narrative string `json:"narrative" xml:"Narrative"`
+}
+
+// GenerateSARDraftSpec returns the tool specification for generate-sar-draft
+func GenerateSARDraftSpec() mcp.Tool {
+	return mcp.NewTool("generate-sar-draft",
+		mcp.WithDescription(`Fills the FinCEN SAR (Form 111) field structure from graph evidence and user-supplied institution details, producing a machine-readable draft as JSON or XML for downstream filing systems.
+
+**STRUCTURE (as in get-sar-report-guidance):**
+- **Part I:** subject information (names, DOB, TIN, identification, addresses, phones, emails, accounts)
+- **Part II:** suspicious activity (date range, total amount, categories, products, instruments)
+- **Part III:** financial institution (name, TIN, regulator, address, contact office)
+- **Part IV:** narrative
+
+**BEHAVIOUR:**
+- Activity dates and the total amount are derived from evidence.transactions when not supplied
+- Required fields that are still empty are listed in missingFields instead of failing, so the draft can be completed iteratively
+- The tool does not query the database or file anything; it only formats the draft
+
+**RECOMMENDED WORKFLOW:**
+1. **Call gather-sar-evidence** for the primary subject
+2. **Map the profile section** into Part I subjects
+3. **Write the narrative** using the evidence and the guidance from get-sar-report-guidance
+4. **Call generate-sar-draft** with the subjects, institution, narrative and the evidence result
+
+**Returns:**
+- The draft in the requested format, including missingFields`),
+		mcp.WithInputSchema[GenerateSARDraftInput](),
+		mcp.WithTitleAnnotation("Generate SAR Draft"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
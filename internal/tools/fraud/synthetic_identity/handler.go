@@ -7,9 +7,14 @@ import (
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud"
 )
 
+// maxTransitiveHops caps the chain length in transitive mode, as variable-length PII traversals grow quickly
+const maxTransitiveHops = 4
+
 // Handler returns the tool handler function for synthetic identity fraud detection
 func Handler(deps *fraud.ToolDeps) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -36,6 +41,12 @@ func handleDetectSyntheticIdentity(ctx context.Context, request mcp.CallToolRequ
 		deps.AnalyticsService.NewToolsEvent("detect-synthetic-identity"),
 	)
 
+	// Fill in the arguments of a saved mapping
+	if err := deps.MappingStore.Apply(ctx, &request); err != nil {
+		slog.Error("error applying saved mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
 	// Parse arguments
 	var args DetectSyntheticIdentityInput
 	if err := request.BindArguments(&args); err != nil {
@@ -62,6 +73,11 @@ func handleDetectSyntheticIdentity(ctx context.Context, request mcp.CallToolRequ
 		return mcp.NewToolResultError(errMessage), nil
 	}
 
+	if errMessage := validateIdentifiers(args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
 	// Set defaults
 	minShared := args.MinSharedAttributes
 	if minShared == 0 {
@@ -73,24 +89,54 @@ func handleDetectSyntheticIdentity(ctx context.Context, request mcp.CallToolRequ
 		limit = 20
 	}
 
+	maxHops := args.MaxHops
+	if maxHops == 0 {
+		maxHops = 1
+	}
+
+	if maxHops < 0 || maxHops > maxTransitiveHops {
+		errMessage := fmt.Sprintf("maxHops must be between 1 and %d", maxTransitiveHops)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
 	// Determine operation mode
 	isInvestigationMode := args.EntityId != ""
 
+	if maxHops > 1 && !isInvestigationMode {
+		errMessage := "maxHops greater than 1 is only supported in investigation mode. Provide an entityId to expand through chains of shared PII."
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
 	slog.Info("detecting synthetic identity fraud",
 		"mode", map[bool]string{true: "investigation", false: "discovery"}[isInvestigationMode],
 		"entityId", args.EntityId,
 		"entityLabel", args.EntityConfig.NodeLabel,
 		"piiRelationships", len(args.PIIRelationships),
 		"minSharedAttributes", minShared,
+		"maxHops", maxHops,
 		"limit", limit)
 
+	exclusions := exclusionFilter{
+		entityIds:        len(args.ExcludeEntityIds) > 0,
+		identifierValues: len(args.ExcludeIdentifierValues) > 0,
+	}
+
 	// Build dynamic Cypher query based on mode and PII relationships
 	var query string
 	var params map[string]any
 
-	if isInvestigationMode {
+	if isInvestigationMode && maxHops > 1 {
+		// Transitive investigation mode: expand through chains of entities sharing PII
+		query = buildTransitiveInvestigationQuery(args.EntityConfig, args.PIIRelationships, maxHops, exclusions)
+		params = map[string]any{
+			"entityId": args.EntityId,
+			"limit":    limit,
+		}
+	} else if isInvestigationMode {
 		// Investigation mode: find entities sharing PII with a specific entity
-		query = buildInvestigationQuery(args.EntityConfig, args.PIIRelationships)
+		query = buildInvestigationQuery(args.EntityConfig, args.PIIRelationships, exclusions)
 		params = map[string]any{
 			"entityId":            args.EntityId,
 			"minSharedAttributes": minShared,
@@ -98,13 +144,25 @@ func handleDetectSyntheticIdentity(ctx context.Context, request mcp.CallToolRequ
 		}
 	} else {
 		// Discovery mode: find all clusters of entities sharing PII
-		query = buildDiscoveryQuery(args.EntityConfig, args.PIIRelationships)
+		query = buildDiscoveryQuery(args.EntityConfig, args.PIIRelationships, exclusions)
 		params = map[string]any{
 			"minSharedAttributes": minShared,
 			"limit":               limit,
 		}
 	}
 
+	// Only pass the exclusion lists that are referenced by the query
+	if exclusions.entityIds {
+		params["excludeEntityIds"] = args.ExcludeEntityIds
+	}
+	if exclusions.identifierValues {
+		params["excludeIdentifierValues"] = args.ExcludeIdentifierValues
+	}
+
+	if args.PreviewQuery {
+		return tools.NewQueryPreviewResult(tools.QueryPreview{Query: query, Params: params}), nil
+	}
+
 	// Execute query
 	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
 	if err != nil {
@@ -113,7 +171,7 @@ func handleDetectSyntheticIdentity(ctx context.Context, request mcp.CallToolRequ
 	}
 
 	// Format records to JSON
-	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
 	if err != nil {
 		slog.Error("error formatting query results", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
@@ -123,16 +181,17 @@ func handleDetectSyntheticIdentity(ctx context.Context, request mcp.CallToolRequ
 }
 
 // buildInvestigationQuery constructs a Cypher query for investigation mode (specific entity)
-func buildInvestigationQuery(entityConfig EntityConfig, piiRelationships []PIIRelationship) string {
+func buildInvestigationQuery(entityConfig EntityConfig, piiRelationships []PIIRelationship, exclusions exclusionFilter) string {
 	relPattern, caseStatement := buildQueryComponents(piiRelationships)
 	returnClause := buildReturnClause(entityConfig, "other")
+	exclusionConditions := exclusions.conditions([]string{"other"}, entityConfig.IdProperty, "identifier", piiRelationships)
 
 	// Investigation mode: find entities sharing PII with a specific target entity
 	query := fmt.Sprintf(`
 		MATCH (target:%s {%s: $entityId})
 		MATCH (target)-[r:%s]->(identifier)
 		MATCH (identifier)<-[r2:%s]-(other:%s)
-		WHERE target.%s <> other.%s
+		WHERE target.%s <> other.%s%s
 		WITH other,
 		     collect(DISTINCT {
 		         type: type(r2),
@@ -147,24 +206,73 @@ func buildInvestigationQuery(entityConfig EntityConfig, piiRelationships []PIIRe
 		       size(sharedAttributes) as sharedAttributeCount
 		ORDER BY sharedAttributeCount DESC
 		LIMIT $limit
-	`, entityConfig.NodeLabel, entityConfig.IdProperty,
-		relPattern, relPattern, entityConfig.NodeLabel,
-		entityConfig.IdProperty, entityConfig.IdProperty,
+	`, query_builder.EscapeLabelExpression(entityConfig.NodeLabel), query_builder.EscapeIdentifier(entityConfig.IdProperty),
+		relPattern, relPattern, query_builder.EscapeLabelExpression(entityConfig.NodeLabel),
+		query_builder.EscapeIdentifier(entityConfig.IdProperty), query_builder.EscapeIdentifier(entityConfig.IdProperty), exclusionConditions,
 		caseStatement, returnClause)
 
 	return query
 }
 
+// buildTransitiveInvestigationQuery constructs a Cypher query for transitive investigation mode.
+// Each hop is an entity-to-PII-to-entity step, so chains span up to 2*maxHops relationships.
+// The reachable entities are found first, then one shortest chain is searched per entity, returning its
+// hop distance, the entity IDs along the chain and the PII identifiers linking them.
+// Excluded entities and identifier values cannot act as links.
+func buildTransitiveInvestigationQuery(entityConfig EntityConfig, piiRelationships []PIIRelationship, maxHops int, exclusions exclusionFilter) string {
+	relPattern, _ := buildQueryComponents(piiRelationships)
+	linkCaseStatement := buildIdentifierCaseStatement("n", piiRelationships)
+	returnClause := buildReturnClause(entityConfig, "other")
+	exclusionConditions := exclusions.pathConditions("path", entityConfig.IdProperty, piiRelationships)
+
+	// Transitive mode: entities sit at even positions of the chain, PII nodes at odd positions.
+	// The entity label check keeps chains from passing through other node types sharing the same relationship types.
+	// shortestPath only accepts a minimum length of 0 or 1; the label check rules out chains that are not entity-to-PII-to-entity.
+	query := fmt.Sprintf(`
+		MATCH (target:%s {%s: $entityId})
+		MATCH (target)-[:%s*2..%d]-(other:%s)
+		WHERE target.%s <> other.%s
+		WITH DISTINCT target, other
+		CALL {
+		  WITH target, other
+		  MATCH path = shortestPath((target)-[:%s*..%d]-(other))
+		  WHERE length(path) %% 2 = 0
+		    AND all(i IN range(2, size(nodes(path)) - 1, 2) WHERE nodes(path)[i]:%s)%s
+		  RETURN path as shortestChain
+		}
+		WITH other, shortestChain, length(shortestChain) / 2 as hopDistance
+		ORDER BY hopDistance ASC
+		LIMIT $limit
+		RETURN %s,
+		       hopDistance,
+		       [i IN range(0, size(nodes(shortestChain)) - 1, 2) | nodes(shortestChain)[i].%s] as clusterPath,
+		       [n IN [i IN range(1, size(nodes(shortestChain)) - 1, 2) | nodes(shortestChain)[i]] | {
+		           label: labels(n)[0],
+		           identifier: CASE
+		               %s
+		               ELSE 'Unknown'
+		           END
+		       }] as linkingAttributes
+	`, query_builder.EscapeLabelExpression(entityConfig.NodeLabel), query_builder.EscapeIdentifier(entityConfig.IdProperty),
+		relPattern, maxHops*2, query_builder.EscapeLabelExpression(entityConfig.NodeLabel),
+		query_builder.EscapeIdentifier(entityConfig.IdProperty), query_builder.EscapeIdentifier(entityConfig.IdProperty),
+		relPattern, maxHops*2, query_builder.EscapeLabelExpression(entityConfig.NodeLabel), exclusionConditions,
+		returnClause, query_builder.EscapeIdentifier(entityConfig.IdProperty), linkCaseStatement)
+
+	return query
+}
+
 // buildDiscoveryQuery constructs a Cypher query for discovery mode (find all clusters)
-func buildDiscoveryQuery(entityConfig EntityConfig, piiRelationships []PIIRelationship) string {
+func buildDiscoveryQuery(entityConfig EntityConfig, piiRelationships []PIIRelationship, exclusions exclusionFilter) string {
 	relPattern, caseStatement := buildQueryComponents(piiRelationships)
 	returnClause1 := buildReturnClause(entityConfig, "e1")
 	returnClause2 := buildReturnClause(entityConfig, "e2")
+	exclusionConditions := exclusions.conditions([]string{"e1", "e2"}, entityConfig.IdProperty, "identifier", piiRelationships)
 
 	// Discovery mode: find all pairs of entities sharing PII
 	query := fmt.Sprintf(`
 		MATCH (e1:%s)-[r1:%s]->(identifier)<-[r2:%s]-(e2:%s)
-		WHERE id(e1) < id(e2)
+		WHERE id(e1) < id(e2)%s
 		WITH e1, e2,
 		     collect(DISTINCT {
 		         type: type(r1),
@@ -181,17 +289,61 @@ func buildDiscoveryQuery(entityConfig EntityConfig, piiRelationships []PIIRelati
 		       %s,
 		       sharedAttributes,
 		       sharedAttributeCount
-	`, entityConfig.NodeLabel, relPattern, relPattern, entityConfig.NodeLabel,
-		caseStatement, returnClause1, returnClause2)
+	`, query_builder.EscapeLabelExpression(entityConfig.NodeLabel), relPattern, relPattern, query_builder.EscapeLabelExpression(entityConfig.NodeLabel),
+		exclusionConditions, caseStatement, returnClause1, returnClause2)
 
 	return query
 }
 
+// exclusionFilter records which exclusion lists were provided, so only the matching conditions
+// and parameters ($excludeEntityIds, $excludeIdentifierValues) are added to the query
+type exclusionFilter struct {
+	entityIds        bool
+	identifierValues bool
+}
+
+// conditions builds the extra WHERE conditions suppressing excluded entities and PII identifier values
+func (f exclusionFilter) conditions(entityVars []string, idProperty string, identifierVar string, piiRelationships []PIIRelationship) string {
+	var conditions []string
+	if f.entityIds {
+		for _, entityVar := range entityVars {
+			conditions = append(conditions, fmt.Sprintf("NOT %s.%s IN $excludeEntityIds", entityVar, query_builder.EscapeIdentifier(idProperty)))
+		}
+	}
+	if f.identifierValues {
+		conditions = append(conditions, fmt.Sprintf("NOT coalesce(CASE %s END, '') IN $excludeIdentifierValues",
+			buildIdentifierCaseStatement(identifierVar, piiRelationships)))
+	}
+	return joinConditions(conditions)
+}
+
+// pathConditions builds the exclusion conditions for a variable-length path, checking every entity and PII node along it
+func (f exclusionFilter) pathConditions(pathVar string, idProperty string, piiRelationships []PIIRelationship) string {
+	var conditions []string
+	if f.entityIds {
+		conditions = append(conditions, fmt.Sprintf("none(i IN range(2, size(nodes(%s)) - 1, 2) WHERE nodes(%s)[i].%s IN $excludeEntityIds)",
+			pathVar, pathVar, query_builder.EscapeIdentifier(idProperty)))
+	}
+	if f.identifierValues {
+		conditions = append(conditions, fmt.Sprintf("none(n IN [i IN range(1, size(nodes(%s)) - 1, 2) | nodes(%s)[i]] WHERE coalesce(CASE %s END, '') IN $excludeIdentifierValues)",
+			pathVar, pathVar, buildIdentifierCaseStatement("n", piiRelationships)))
+	}
+	return joinConditions(conditions)
+}
+
+// joinConditions renders conditions as AND clauses appended to an existing WHERE
+func joinConditions(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return "\n\t\t  AND " + strings.Join(conditions, "\n\t\t  AND ")
+}
+
 // buildReturnClause builds the RETURN clause for entity properties
 func buildReturnClause(entityConfig EntityConfig, varName string) string {
 	// Always return the ID property
 	returnParts := []string{
-		fmt.Sprintf("%s.%s as %sId", varName, entityConfig.IdProperty, varName),
+		fmt.Sprintf("%s.%s as %sId", varName, query_builder.EscapeIdentifier(entityConfig.IdProperty), varName),
 	}
 
 	// Add display properties if specified
@@ -202,7 +354,7 @@ func buildReturnClause(entityConfig EntityConfig, varName string) string {
 				continue
 			}
 			alias := fmt.Sprintf("%s%s", varName, titleCase(prop))
-			returnParts = append(returnParts, fmt.Sprintf("%s.%s as %s", varName, prop, alias))
+			returnParts = append(returnParts, fmt.Sprintf("%s.%s as %s", varName, query_builder.EscapeIdentifier(prop), query_builder.EscapeIdentifier(alias)))
 		}
 	} else {
 		// If no display properties specified, return all properties as a map
@@ -220,22 +372,55 @@ func titleCase(s string) string {
 	return strings.ToUpper(s[:1]) + s[1:]
 }
 
+// validateIdentifiers checks the labels, relationship types and property names that are written into the query
+func validateIdentifiers(args DetectSyntheticIdentityInput) string {
+	if errMessage := query_builder.ValidateLabelExpression("entityConfig.nodeLabel", args.EntityConfig.NodeLabel); errMessage != "" {
+		return errMessage
+	}
+	if errMessage := query_builder.ValidateIdentifier("entityConfig.idProperty", args.EntityConfig.IdProperty); errMessage != "" {
+		return errMessage
+	}
+	for i, prop := range args.EntityConfig.DisplayProperties {
+		if errMessage := query_builder.ValidateIdentifier(fmt.Sprintf("entityConfig.displayProperties[%d]", i), prop); errMessage != "" {
+			return errMessage
+		}
+	}
+	for i, pii := range args.PIIRelationships {
+		field := fmt.Sprintf("piiRelationships[%d]", i)
+		if errMessage := query_builder.ValidateIdentifier(field+".relationshipType", pii.RelationshipType); errMessage != "" {
+			return errMessage
+		}
+		if errMessage := query_builder.ValidateLabelExpression(field+".targetLabel", pii.TargetLabel); errMessage != "" {
+			return errMessage
+		}
+		if errMessage := query_builder.ValidateIdentifier(field+".identifierProperty", pii.IdentifierProperty); errMessage != "" {
+			return errMessage
+		}
+	}
+	return ""
+}
+
 // buildQueryComponents builds common query components (relationship pattern and CASE statement)
 func buildQueryComponents(piiRelationships []PIIRelationship) (relPattern string, caseStatement string) {
 	// Build the relationship type pattern (e.g., "HAS_EMAIL|HAS_PHONE|HAS_SSN")
 	relTypes := make([]string, len(piiRelationships))
 	for i, pii := range piiRelationships {
-		relTypes[i] = pii.RelationshipType
+		relTypes[i] = query_builder.EscapeIdentifier(pii.RelationshipType)
 	}
 	relPattern = strings.Join(relTypes, "|")
 
 	// Build the CASE statement for identifier extraction
+	caseStatement = buildIdentifierCaseStatement("identifier", piiRelationships)
+
+	return relPattern, caseStatement
+}
+
+// buildIdentifierCaseStatement builds the WHEN clauses extracting the identifier value of a PII node bound to varName
+func buildIdentifierCaseStatement(varName string, piiRelationships []PIIRelationship) string {
 	var caseClauses []string
 	for _, pii := range piiRelationships {
 		caseClauses = append(caseClauses,
-			fmt.Sprintf("WHEN identifier:%s THEN identifier.%s", pii.TargetLabel, pii.IdentifierProperty))
+			fmt.Sprintf("WHEN %s:%s THEN %s.%s", varName, query_builder.EscapeLabelExpression(pii.TargetLabel), varName, query_builder.EscapeIdentifier(pii.IdentifierProperty)))
 	}
-	caseStatement = strings.Join(caseClauses, "\n                 ")
-
-	return relPattern, caseStatement
+	return strings.Join(caseClauses, "\n                 ")
 }
@@ -3,6 +3,7 @@ package synthetic_identity_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -31,7 +32,7 @@ func TestDetectSyntheticIdentityHandler(t *testing.T) {
 			}).
 			Return([]*neo4j.Record{}, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"otherId": "CUS456", "otherFirstName": "Jane", "otherLastName": "Doe", "sharedAttributeCount": 2}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -85,7 +86,7 @@ func TestDetectSyntheticIdentityHandler(t *testing.T) {
 			}).
 			Return([]*neo4j.Record{}, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"otherId": "CUS789", "sharedAttributeCount": 3}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -144,7 +145,7 @@ func TestDetectSyntheticIdentityHandler(t *testing.T) {
 			}).
 			Return([]*neo4j.Record{}, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return(`[{"e1Id": "CUS123", "e2Id": "CUS456", "sharedAttributeCount": 2}]`, nil)
 
 		deps := &tools.ToolDependencies{
@@ -182,6 +183,299 @@ func TestDetectSyntheticIdentityHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("transitive investigation mode with maxHops", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"entityId": "CUS123",
+				"limit":    20,
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "[:HAS_EMAIL|HAS_PHONE*2..6]") {
+					t.Errorf("Expected variable-length pattern spanning 3 hops, got query: %s", query)
+				}
+				if !strings.Contains(query, "shortestPath((target)-[:HAS_EMAIL|HAS_PHONE*..6]-(other))") || strings.Contains(query, "collect(path)") {
+					t.Errorf("Expected one shortest chain searched per entity, got query: %s", query)
+				}
+				if strings.Index(query, "LIMIT $limit") > strings.Index(query, "clusterPath") {
+					t.Errorf("Expected the limit to apply before the chains are projected, got query: %s", query)
+				}
+				if !strings.Contains(query, "hopDistance") {
+					t.Errorf("Expected hopDistance in query, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[{"otherId": "CUS789", "hopDistance": 2, "clusterPath": ["CUS123", "CUS456", "CUS789"]}]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId": "CUS123",
+					"maxHops":  3,
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType":   "HAS_EMAIL",
+							"targetLabel":        "Email",
+							"identifierProperty": "address",
+						},
+						{
+							"relationshipType":   "HAS_PHONE",
+							"targetLabel":        "Phone",
+							"identifierProperty": "number",
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result for transitive mode")
+		}
+	})
+
+	t.Run("maxHops above the supported range", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId": "CUS123",
+					"maxHops":  10,
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType":   "HAS_EMAIL",
+							"targetLabel":        "Email",
+							"identifierProperty": "address",
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for maxHops above the supported range")
+		}
+	})
+
+	t.Run("maxHops in discovery mode", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"maxHops": 2,
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType":   "HAS_EMAIL",
+							"targetLabel":        "Email",
+							"identifierProperty": "address",
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for maxHops in discovery mode")
+		}
+	})
+
+	t.Run("labels and properties are escaped in the query", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "(target:`Customer``) DETACH DELETE e //` {customerId: $entityId})") {
+					t.Errorf("Expected escaped entity label in query, got: %s", query)
+				}
+				if !strings.Contains(query, "[r:`HAS EMAIL`]") {
+					t.Errorf("Expected escaped relationship type in query, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId": "CUS123",
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer`) DETACH DELETE e //",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType":   "HAS EMAIL",
+							"targetLabel":        "Email",
+							"identifierProperty": "address",
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected successful result for escaped identifiers")
+		}
+	})
+
+	t.Run("empty identifier property", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId": "CUS123",
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType": "HAS_EMAIL",
+							"targetLabel":      "Email",
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for empty identifier property")
+		}
+	})
+
+	t.Run("exclusion lists are passed as parameters", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"entityId":                "CUS123",
+				"minSharedAttributes":     2,
+				"limit":                   20,
+				"excludeEntityIds":        []string{"CUS999"},
+				"excludeIdentifierValues": []string{"+44 20 7946 0000"},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.Contains(query, "NOT other.customerId IN $excludeEntityIds") {
+					t.Errorf("Expected entity exclusion condition, got query: %s", query)
+				}
+				if !strings.Contains(query, "IN $excludeIdentifierValues") {
+					t.Errorf("Expected identifier exclusion condition, got query: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId":                "CUS123",
+					"excludeEntityIds":        []string{"CUS999"},
+					"excludeIdentifierValues": []string{"+44 20 7946 0000"},
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType":   "HAS_PHONE",
+							"targetLabel":        "Phone",
+							"identifierProperty": "number",
+						},
+					},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result with exclusion lists")
+		}
+	})
+
 	t.Run("missing piiRelationships parameter", func(t *testing.T) {
 		mockDB := db.NewMockService(ctrl)
 
@@ -329,7 +623,7 @@ func TestDetectSyntheticIdentityHandler(t *testing.T) {
 			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
 			Return([]*neo4j.Record{}, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return("", errors.New("JSON marshaling failed"))
 
 		deps := &tools.ToolDependencies{
@@ -366,4 +660,54 @@ func TestDetectSyntheticIdentityHandler(t *testing.T) {
 			t.Error("Expected error result for JSON formatting failure")
 		}
 	})
+
+	t.Run("previewQuery returns the query without executing it", func(t *testing.T) {
+		// No expectations: any database call fails the test
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := synthetic_identity.Handler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"entityId": "CUS123",
+					"entityConfig": map[string]any{
+						"nodeLabel":  "Customer",
+						"idProperty": "customerId",
+					},
+					"piiRelationships": []map[string]any{
+						{
+							"relationshipType":   "HAS_EMAIL",
+							"targetLabel":        "Email",
+							"identifierProperty": "address",
+						},
+					},
+					"previewQuery": true,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"preview": true`) {
+			t.Errorf("Expected a query preview, got: %s", text)
+		}
+		if !strings.Contains(text, "MATCH (target:Customer {customerId: $entityId})") {
+			t.Errorf("Expected the generated query in the preview, got: %s", text)
+		}
+		if !strings.Contains(text, `"entityId": "CUS123"`) {
+			t.Errorf("Expected the query parameters in the preview, got: %s", text)
+		}
+	})
 }
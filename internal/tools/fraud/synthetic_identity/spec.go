@@ -3,23 +3,28 @@ package synthetic_identity
 import "github.com/mark3labs/mcp-go/mcp"
 
 type PIIRelationship struct {
-	RelationshipType     string `json:"relationshipType" jsonschema:"description=The relationship type connecting the entity to PII (e.g. HAS_EMAIL)"`
-	TargetLabel          string `json:"targetLabel" jsonschema:"description=The node label of the PII entity (e.g. Email)"`
-	IdentifierProperty   string `json:"identifierProperty" jsonschema:"description=The property containing the identifier value (e.g. address for Email)"`
+	RelationshipType   string `json:"relationshipType" jsonschema:"description=The relationship type connecting the entity to PII (e.g. HAS_EMAIL)"`
+	TargetLabel        string `json:"targetLabel" jsonschema:"description=The node label of the PII entity (e.g. Email). Label expressions such as Email|EmailAddress are accepted"`
+	IdentifierProperty string `json:"identifierProperty" jsonschema:"description=The property containing the identifier value (e.g. address for Email)"`
 }
 
 type EntityConfig struct {
-	NodeLabel         string   `json:"nodeLabel" jsonschema:"description=The node label to search for shared PII (e.g. Customer, Person, Account, Merchant)"`
+	NodeLabel         string   `json:"nodeLabel" jsonschema:"description=The node label to search for shared PII (e.g. Customer, Person, Account, Merchant). Label expressions such as Customer|Person (either label) or Customer&Verified (both) are accepted"`
 	IdProperty        string   `json:"idProperty" jsonschema:"description=The property name containing the unique identifier (e.g. customerId, personId, accountId)"`
 	DisplayProperties []string `json:"displayProperties,omitempty" jsonschema:"description=Properties to return for display (e.g. firstName and lastName, or name, or accountNumber). If omitted, returns all properties."`
 }
 
 type DetectSyntheticIdentityInput struct {
-	EntityId            string            `json:"entityId,omitempty" jsonschema:"description=Optional: Entity ID to investigate. If provided, finds entities sharing PII with this specific entity. If omitted, discovers all clusters of entities sharing PII."`
-	EntityConfig        EntityConfig      `json:"entityConfig" jsonschema:"description=Configuration for the entity node type being investigated. Discovered from get-schema."`
-	PIIRelationships    []PIIRelationship `json:"piiRelationships" jsonschema:"description=Array of PII relationship configurations discovered from the schema. Use get-schema to discover these first."`
-	MinSharedAttributes int               `json:"minSharedAttributes,omitempty" jsonschema:"default=2,description=Minimum number of shared identity attributes to flag as suspicious"`
-	Limit               int               `json:"limit,omitempty" jsonschema:"default=20,description=Maximum number of results to return (discovery mode) or entities to find (investigation mode)"`
+	EntityId                string            `json:"entityId,omitempty" jsonschema:"description=Optional: Entity ID to investigate. If provided, finds entities sharing PII with this specific entity. If omitted, discovers all clusters of entities sharing PII."`
+	EntityConfig            EntityConfig      `json:"entityConfig" jsonschema:"description=Configuration for the entity node type being investigated. Discovered from get-schema."`
+	PIIRelationships        []PIIRelationship `json:"piiRelationships" jsonschema:"description=Array of PII relationship configurations discovered from the schema. Use get-schema to discover these first."`
+	MappingName             string            `json:"mappingName,omitempty" jsonschema:"description=Optional: name of a mapping saved with save-schema-mapping. Supplies entityConfig and piiRelationships when they are omitted."`
+	MinSharedAttributes     int               `json:"minSharedAttributes,omitempty" jsonschema:"default=2,description=Minimum number of shared identity attributes to flag as suspicious"`
+	Limit                   int               `json:"limit,omitempty" jsonschema:"default=20,description=Maximum number of results to return (discovery mode) or entities to find (investigation mode)"`
+	MaxHops                 int               `json:"maxHops,omitempty" jsonschema:"default=1,minimum=1,maximum=4,description=Investigation mode only: maximum number of shared-PII hops to expand through (e.g. 2 finds C when A shares an email with B and B shares a phone with C). Values above 1 enable transitive mode."`
+	ExcludeEntityIds        []string          `json:"excludeEntityIds,omitempty" jsonschema:"description=Optional: Entity IDs to suppress from results (e.g. known test accounts or already-cleared customers). In transitive mode they also cannot act as links."`
+	ExcludeIdentifierValues []string          `json:"excludeIdentifierValues,omitempty" jsonschema:"description=Optional: Identifier values to ignore when matching shared PII (e.g. a shared corporate phone number or a known family address). Values are compared against the identifierProperty of each PII node."`
+	PreviewQuery            bool              `json:"previewQuery,omitempty" jsonschema:"default=false,description=Return the generated Cypher query and its parameters without executing it, to review what the tool will run"`
 }
 
 // Spec returns the MCP tool specification for synthetic identity fraud detection
@@ -35,6 +40,12 @@ Example: "Show me 20 people with shared PII information"
 Finds entities sharing PII with a specific target entity. Use this for targeted fraud investigation.
 Example: "For customer CUS123, find any other customers related via shared PII"
 
+**Transitive Investigation (entityId provided, maxHops > 1):**
+Expands through chains of shared PII (A shares an email with B, B shares a phone with C) up to maxHops hops away.
+Returns cluster membership with the hop distance, the chain of entity IDs and the identifiers linking them.
+In this mode each link only requires one shared attribute, so minSharedAttributes is not applied.
+Example: "For customer CUS123, find everyone within 3 hops of shared PII"
+
 **SCHEMA-AGNOSTIC DESIGN:**
 This tool works with ANY entity type (Customer, Person, Account, Merchant, etc.) - not just customers.
 
@@ -53,6 +64,7 @@ This tool is schema-aware and requires you to discover the database structure fi
    - targetLabel: The connected node label (e.g., "Email")
    - identifierProperty: The property containing the identifier (e.g., "address" for Email, "number" for Phone/SSN)
 6. **Pass discovered configurations** to this tool
+7. **Optionally save them** with save-schema-mapping and pass mappingName on later calls instead of repeating them
 
 **Example for Customer entities:**
 {
@@ -101,6 +113,11 @@ This tool is schema-aware and requires you to discover the database structure fi
   "piiRelationships": [...]
 }
 
+**Suppressing known-benign sharing:**
+Use excludeIdentifierValues for identifiers that are legitimately shared (corporate switchboard numbers, family addresses, shared mailboxes)
+and excludeEntityIds for entities that have already been cleared. Excluded values are ignored when counting shared attributes.
+Example: { ..., "excludeIdentifierValues": ["+44 20 7946 0000"], "excludeEntityIds": ["CUS999"] }
+
 **When to use this tool:**
 - Discovering fraud patterns proactively (discovery mode)
 - Investigating suspected synthetic identity fraud (investigation mode)
@@ -128,7 +145,8 @@ This tool is schema-aware and requires you to discover the database structure fi
 **Returns:**
 - List of customers sharing identity attributes
 - Details of which specific attributes are shared (with type and value)
-- Count of shared attributes per customer connection`),
+- Count of shared attributes per customer connection
+- In transitive mode: hopDistance, clusterPath (entity IDs along the chain) and linkingAttributes`),
 		mcp.WithInputSchema[DetectSyntheticIdentityInput](),
 		mcp.WithTitleAnnotation("Detect Synthetic Identity Fraud"),
 		mcp.WithReadOnlyHintAnnotation(true),
@@ -0,0 +1,180 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+const estimateYields = "YIELD requiredMemory, bytesMin, bytesMax, nodeCount, relationshipCount, heapPercentageMin, heapPercentageMax\n" +
+	"RETURN requiredMemory, bytesMin, bytesMax, nodeCount, relationshipCount, heapPercentageMin, heapPercentageMax"
+
+const freeHeapQuery = `
+CALL gds.systemMonitor()
+YIELD freeHeap
+RETURN freeHeap`
+
+// estimableProcedures maps the algorithm names accepted by estimate-gds-memory to their GDS procedure
+var estimableProcedures = map[string]string{
+	"louvain":        "gds.louvain",
+	"wcc":            "gds.wcc",
+	"pagerank":       "gds.pageRank",
+	"degree":         "gds.degree",
+	"betweenness":    "gds.betweenness",
+	"nodesimilarity": "gds.nodeSimilarity",
+	"fastrp":         "gds.fastRP",
+	"node2vec":       "gds.node2vec",
+	"knn":            "gds.knn",
+}
+
+// EstimateGDSMemoryHandler returns a handler function for the estimate-gds-memory tool
+func EstimateGDSMemoryHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleEstimateGDSMemory(ctx, request, deps)
+	}
+}
+
+func handleEstimateGDSMemory(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("estimate-gds-memory"))
+
+	var args EstimateGDSMemoryInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateEstimateInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("estimating GDS memory",
+		"algorithm", args.Algorithm,
+		"graphName", args.GraphName)
+
+	query, params := buildEstimateQuery(args)
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("failed to execute estimate-gds-memory query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to estimate memory for %s: %v", args.Algorithm, err)), nil
+	}
+	if len(records) == 0 {
+		errMessage := fmt.Sprintf("no memory estimate returned for %s", args.Algorithm)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	estimate := records[0].AsMap()
+
+	// gds.systemMonitor is not available on every GDS version, so free heap is best effort
+	var freeHeap any
+	if deps.GDSCapabilities.MissingProcedure("gds.systemMonitor") {
+		slog.Debug("gds.systemMonitor is not available, feasibility is based on the maximum heap")
+	} else if monitorRecords, err := deps.DBService.ExecuteReadQuery(ctx, freeHeapQuery, nil); err != nil {
+		slog.Warn("could not read free heap from gds.systemMonitor", "error", err)
+	} else if len(monitorRecords) > 0 {
+		freeHeap, _ = monitorRecords[0].Get("freeHeap")
+	}
+
+	result := &neo4j.Record{
+		Keys:   []string{"algorithm", "estimate", "freeHeap", "feasible"},
+		Values: []any{args.Algorithm, estimate, freeHeap, isFeasible(estimate, freeHeap)},
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, []*neo4j.Record{result})
+	if err != nil {
+		slog.Error("failed to format estimate-gds-memory results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateEstimateInput checks that the algorithm is known and that exactly one graph source is given.
+func validateEstimateInput(args *EstimateGDSMemoryInput) string {
+	args.Algorithm = strings.ToLower(args.Algorithm)
+	if _, ok := estimableProcedures[args.Algorithm]; !ok && args.Algorithm != "projection" {
+		return "algorithm must be one of projection, louvain, wcc, pagerank, degree, betweenness, nodesimilarity, fastrp, node2vec or knn"
+	}
+
+	hasMappings := len(args.NodeMappings) > 0 || len(args.RelationshipMappings) > 0
+	if args.Algorithm == "projection" && args.GraphName != "" {
+		return "graphName cannot be used with algorithm projection; set nodeMappings and relationshipMappings instead"
+	}
+	if args.GraphName != "" && hasMappings {
+		return "set either graphName or nodeMappings/relationshipMappings, not both"
+	}
+	if args.GraphName == "" {
+		// Reuse the create-gds-projection validation for the mappings
+		projection := CreateGDSProjectionInput{
+			GraphName:            "estimate",
+			NodeMappings:         args.NodeMappings,
+			RelationshipMappings: args.RelationshipMappings,
+		}
+		if errMessage := validateProjectionInput(&projection); errMessage != "" {
+			return errMessage
+		}
+		args.RelationshipMappings = projection.RelationshipMappings
+	}
+
+	return ""
+}
+
+// buildEstimateQuery returns the estimate query and its parameters for the selected algorithm and graph source
+func buildEstimateQuery(args EstimateGDSMemoryInput) (string, map[string]any) {
+	if args.Algorithm == "projection" {
+		query := "CALL gds.graph.project.estimate($nodeProjection, $relationshipProjection)\n" + estimateYields
+		return query, map[string]any{
+			"nodeProjection":         buildNodeProjection(args.NodeMappings),
+			"relationshipProjection": buildRelationshipProjection(args.RelationshipMappings),
+		}
+	}
+
+	config := args.Config
+	if config == nil {
+		config = map[string]any{}
+	}
+
+	// Estimates accept either a projection name or an implicit projection configuration
+	var graphNameOrConfiguration any = args.GraphName
+	if args.GraphName == "" {
+		graphNameOrConfiguration = map[string]any{
+			"nodeProjection":         buildNodeProjection(args.NodeMappings),
+			"relationshipProjection": buildRelationshipProjection(args.RelationshipMappings),
+		}
+	}
+
+	query := fmt.Sprintf("CALL %s.stream.estimate($graphNameOrConfiguration, $config)\n%s", estimableProcedures[args.Algorithm], estimateYields)
+	return query, map[string]any{
+		"graphNameOrConfiguration": graphNameOrConfiguration,
+		"config":                   config,
+	}
+}
+
+// isFeasible compares the upper estimate with the free heap, falling back to the
+// estimated share of the maximum heap when the free heap is unknown
+func isFeasible(estimate map[string]any, freeHeap any) bool {
+	bytesMax, _ := estimate["bytesMax"].(int64)
+	if free, ok := freeHeap.(int64); ok {
+		return bytesMax <= free
+	}
+	heapPercentageMax, _ := estimate["heapPercentageMax"].(float64)
+	return heapPercentageMax < 100
+}
@@ -0,0 +1,232 @@
+package gds_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/gds"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+func estimateRecord(bytesMax int64, heapPercentageMax float64) *neo4j.Record {
+	return &neo4j.Record{
+		Keys:   []string{"requiredMemory", "bytesMax", "heapPercentageMax"},
+		Values: []any{"[1 MiB ... 2 MiB]", bytesMax, heapPercentageMax},
+	}
+}
+
+func TestEstimateGDSMemoryHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("estimate-gds-memory").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("algorithm on existing projection fits in free heap", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"graphNameOrConfiguration": "transfers",
+				"config":                   map[string]any{},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "CALL gds.betweenness.stream.estimate($graphNameOrConfiguration, $config)") {
+					t.Errorf("Expected betweenness estimate call, got: %s", query)
+				}
+				return []*neo4j.Record{estimateRecord(2048, 1.0)}, nil
+			})
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Nil()).
+			Return([]*neo4j.Record{{Keys: []string{"freeHeap"}, Values: []any{int64(4096)}}}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, records []*neo4j.Record) (string, error) {
+				feasible, _ := records[0].Get("feasible")
+				if feasible != true {
+					t.Errorf("Expected estimate to be feasible, got: %v", feasible)
+				}
+				return `[]`, nil
+			})
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.EstimateGDSMemoryHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"algorithm": "betweenness",
+					"graphName": "transfers",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("projection estimate without system monitor", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"nodeProjection": map[string]any{
+					"Account": map[string]any{"label": "Account"},
+				},
+				"relationshipProjection": map[string]any{
+					"TRANSFER": map[string]any{"type": "TRANSFER", "orientation": "NATURAL", "properties": []string{"amount"}},
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "CALL gds.graph.project.estimate($nodeProjection, $relationshipProjection)") {
+					t.Errorf("Expected projection estimate call, got: %s", query)
+				}
+				return []*neo4j.Record{estimateRecord(1<<40, 250.0)}, nil
+			})
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Nil()).
+			Return(nil, errors.New("There is no procedure with the name `gds.systemMonitor` registered"))
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, records []*neo4j.Record) (string, error) {
+				feasible, _ := records[0].Get("feasible")
+				if feasible != false {
+					t.Errorf("Expected estimate not to be feasible, got: %v", feasible)
+				}
+				return `[]`, nil
+			})
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.EstimateGDSMemoryHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"algorithm":            "projection",
+					"nodeMappings":         []map[string]any{{"label": "Account"}},
+					"relationshipMappings": []map[string]any{{"type": "TRANSFER", "properties": []string{"amount"}}},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("skips system monitor when GDS does not provide it", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return([]*neo4j.Record{estimateRecord(2048, 1.0)}, nil).
+			Times(1)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			GDSCapabilities:  tools.NewGDSCapabilities("2.1.0", []string{"gds.louvain.stream.estimate"}),
+		}
+
+		handler := gds.EstimateGDSMemoryHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"algorithm": "louvain",
+					"graphName": "shared-pii",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("both graph name and mappings", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.EstimateGDSMemoryHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"algorithm":    "louvain",
+					"graphName":    "transfers",
+					"nodeMappings": []map[string]any{{"label": "Account"}},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for ambiguous graph source")
+		}
+	})
+
+	t.Run("unknown algorithm", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.EstimateGDSMemoryHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"algorithm": "dijkstra",
+					"graphName": "transfers",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for unknown algorithm")
+		}
+	})
+}
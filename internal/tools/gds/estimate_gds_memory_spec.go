@@ -0,0 +1,39 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type EstimateGDSMemoryInput struct {
+	Algorithm            string                `json:"algorithm" jsonschema:"enum=projection,enum=louvain,enum=wcc,enum=pagerank,enum=degree,enum=betweenness,enum=nodesimilarity,enum=fastrp,enum=node2vec,enum=knn,description=What to estimate. Use projection to estimate gds.graph.project itself."`
+	GraphName            string                `json:"graphName,omitempty" jsonschema:"description=Existing projection to estimate the algorithm on. Leave empty and set nodeMappings/relationshipMappings to estimate a projection that does not exist yet."`
+	NodeMappings         []NodeMapping         `json:"nodeMappings,omitempty" jsonschema:"description=Node labels of the projection to estimate (same format as create-gds-projection)"`
+	RelationshipMappings []RelationshipMapping `json:"relationshipMappings,omitempty" jsonschema:"description=Relationship types of the projection to estimate (same format as create-gds-projection)"`
+	Config               map[string]any        `json:"config,omitempty" jsonschema:"description=Optional algorithm configuration, as passed to the algorithm (e.g. {\"embeddingDimension\": 256} for fastrp)"`
+}
+
+// EstimateGDSMemorySpec returns the MCP tool specification for GDS memory estimation
+func EstimateGDSMemorySpec() mcp.Tool {
+	return mcp.NewTool("estimate-gds-memory",
+		mcp.WithDescription(`Estimates the memory a GDS projection or algorithm needs (gds.<algo>.stream.estimate) and checks it against the free heap of the Neo4j server. Call it before projecting or running heavy algorithms (betweenness, nodesimilarity, node2vec, knn) on large fraud graphs to avoid running the server out of memory.
+
+**ESTIMATE AN ALGORITHM ON AN EXISTING PROJECTION:**
+{"algorithm": "betweenness", "graphName": "transfers"}
+
+**ESTIMATE BEFORE PROJECTING:**
+{
+  "algorithm": "projection",
+  "nodeMappings": [{"label": "Account"}],
+  "relationshipMappings": [{"type": "TRANSFER", "properties": ["amount"]}]
+}
+
+**Returns:**
+- estimate: requiredMemory (human readable), bytesMin, bytesMax, nodeCount, relationshipCount, heapPercentageMin, heapPercentageMax
+- freeHeap: free heap in bytes reported by gds.systemMonitor, or null if unavailable
+- feasible: true when bytesMax fits in the free heap (or within the maximum heap when freeHeap is unavailable)`),
+		mcp.WithInputSchema[EstimateGDSMemoryInput](),
+		mcp.WithTitleAnnotation("Estimate GDS Memory"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
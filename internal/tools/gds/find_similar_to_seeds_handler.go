@@ -0,0 +1,182 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	defaultEmbeddingDimension = 128
+	minEmbeddingDimension     = 16
+	maxEmbeddingDimension     = 1024
+	defaultEmbeddingProperty  = "embedding"
+	defaultKnnTopK            = 10
+	defaultSeedSearchLimit    = 25
+	maxSeedSearchLimit        = 500
+)
+
+var embeddingProcedures = map[string]string{
+	"fastrp":   "gds.fastRP",
+	"node2vec": "gds.node2vec",
+}
+
+const seedKnnQuery = `
+CALL gds.knn.stream($graphName, $knnConfig)
+YIELD node1, node2, similarity
+WITH gds.util.asNode(node1) as seed, gds.util.asNode(node2) as candidate, similarity
+WHERE $seedLabel IN labels(seed) AND seed[$seedIdProperty] IN $seedIds
+  AND NOT ($seedLabel IN labels(candidate) AND candidate[$seedIdProperty] IN $seedIds)
+  AND ($resultLabels IS NULL OR any(label IN labels(candidate) WHERE label IN $resultLabels))
+WITH candidate, max(similarity) as score, collect({seedId: seed[$seedIdProperty], similarity: similarity}) as matchedSeeds
+RETURN candidate {.*, labels: labels(candidate)} as candidate, score, matchedSeeds
+ORDER BY score DESC
+LIMIT $limit`
+
+// FindSimilarToSeedsHandler returns a handler function for the find-similar-to-seeds tool
+func FindSimilarToSeedsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleFindSimilarToSeeds(ctx, request, deps)
+	}
+}
+
+func handleFindSimilarToSeeds(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("find-similar-to-seeds"))
+
+	var args FindSimilarToSeedsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateSeedSearchInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("finding entities similar to seeds",
+		"graphName", args.GraphName,
+		"algorithm", args.Algorithm,
+		"seeds", len(args.SeedIds),
+		"reuseEmbeddings", args.ReuseEmbeddings)
+
+	// Embeddings are mutated into the projection so kNN can read them as a node property
+	if !args.ReuseEmbeddings {
+		embeddingParams := map[string]any{
+			"graphName": args.GraphName,
+			"config": map[string]any{
+				"embeddingDimension": args.EmbeddingDimension,
+				"mutateProperty":     args.EmbeddingProperty,
+			},
+		}
+		if _, err := deps.DBService.ExecuteReadQuery(ctx, buildEmbeddingQuery(args.Algorithm), embeddingParams); err != nil {
+			slog.Error("failed to compute embeddings", "error", err)
+			return mcp.NewToolResultError(fmt.Sprintf("failed to compute %s embeddings on projection '%s': %v. If embeddingProperty already exists on the projection, set reuseEmbeddings=true or choose another embeddingProperty", args.Algorithm, args.GraphName, err)), nil
+		}
+	}
+
+	knnConfig := map[string]any{
+		"nodeProperties": []string{args.EmbeddingProperty},
+		"topK":           args.TopK,
+	}
+	if args.SimilarityCutoff > 0 {
+		knnConfig["similarityCutoff"] = args.SimilarityCutoff
+	}
+
+	params := map[string]any{
+		"graphName":      args.GraphName,
+		"knnConfig":      knnConfig,
+		"seedLabel":      args.SeedConfig.NodeLabel,
+		"seedIdProperty": args.SeedConfig.IdProperty,
+		"seedIds":        args.SeedIds,
+		"resultLabels":   nil,
+		"limit":          args.Limit,
+	}
+	if len(args.ResultLabels) > 0 {
+		params["resultLabels"] = args.ResultLabels
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, seedKnnQuery, params)
+	if err != nil {
+		slog.Error("failed to execute kNN query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run kNN on projection '%s': %v", args.GraphName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format find-similar-to-seeds results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateSeedSearchInput checks the seeds and embedding settings and fills in defaults.
+func validateSeedSearchInput(args *FindSimilarToSeedsInput) string {
+	if args.GraphName == "" {
+		return "graphName is required. Use create-gds-projection to create a projection first."
+	}
+	if args.SeedConfig.NodeLabel == "" || args.SeedConfig.IdProperty == "" {
+		return "seedConfig.nodeLabel and seedConfig.idProperty are required (e.g., 'Customer' and 'customerId')."
+	}
+	if len(args.SeedIds) == 0 {
+		return "seedIds must contain at least one known-fraud entity"
+	}
+
+	if args.Algorithm == "" {
+		args.Algorithm = "fastrp"
+	}
+	args.Algorithm = strings.ToLower(args.Algorithm)
+	if _, ok := embeddingProcedures[args.Algorithm]; !ok {
+		return "algorithm must be fastrp or node2vec"
+	}
+
+	if args.EmbeddingDimension == 0 {
+		args.EmbeddingDimension = defaultEmbeddingDimension
+	}
+	if args.EmbeddingDimension < minEmbeddingDimension || args.EmbeddingDimension > maxEmbeddingDimension {
+		return fmt.Sprintf("embeddingDimension must be between %d and %d", minEmbeddingDimension, maxEmbeddingDimension)
+	}
+	if args.EmbeddingProperty == "" {
+		args.EmbeddingProperty = defaultEmbeddingProperty
+	}
+
+	if args.TopK == 0 {
+		args.TopK = defaultKnnTopK
+	}
+	if args.TopK < 1 {
+		return "topK must be at least 1"
+	}
+	if args.SimilarityCutoff < 0 || args.SimilarityCutoff > 1 {
+		return "similarityCutoff must be between 0 and 1"
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultSeedSearchLimit
+	}
+	if args.Limit < 1 || args.Limit > maxSeedSearchLimit {
+		return fmt.Sprintf("limit must be between 1 and %d", maxSeedSearchLimit)
+	}
+
+	return ""
+}
+
+// buildEmbeddingQuery mutates the projection with embeddings from the selected algorithm
+func buildEmbeddingQuery(algorithm string) string {
+	return fmt.Sprintf("CALL %s.mutate($graphName, $config)\nYIELD nodePropertiesWritten\nRETURN nodePropertiesWritten", embeddingProcedures[algorithm])
+}
@@ -0,0 +1,210 @@
+package gds_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/gds"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+var seedConfig = map[string]any{
+	"nodeLabel":  "Customer",
+	"idProperty": "customerId",
+}
+
+func TestFindSimilarToSeedsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("find-similar-to-seeds").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("computes fastRP embeddings then runs kNN", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		gomock.InOrder(
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+					"graphName": "fraud-network",
+					"config": map[string]any{
+						"embeddingDimension": 128,
+						"mutateProperty":     "embedding",
+					},
+				}).
+				DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+					if !strings.HasPrefix(query, "CALL gds.fastRP.mutate($graphName, $config)") {
+						t.Errorf("Expected fastRP mutate call, got: %s", query)
+					}
+					return []*neo4j.Record{}, nil
+				}),
+			mockDB.EXPECT().
+				ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+					"graphName": "fraud-network",
+					"knnConfig": map[string]any{
+						"nodeProperties": []string{"embedding"},
+						"topK":           10,
+					},
+					"seedLabel":      "Customer",
+					"seedIdProperty": "customerId",
+					"seedIds":        []string{"CUS123", "CUS456"},
+					"resultLabels":   []string{"Customer"},
+					"limit":          25,
+				}).
+				DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+					if !strings.Contains(query, "CALL gds.knn.stream($graphName, $knnConfig)") {
+						t.Errorf("Expected kNN stream call, got: %s", query)
+					}
+					return []*neo4j.Record{}, nil
+				}),
+		)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.FindSimilarToSeedsHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"graphName":    "fraud-network",
+					"seedConfig":   seedConfig,
+					"seedIds":      []string{"CUS123", "CUS456"},
+					"resultLabels": []string{"Customer"},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("reuses existing embeddings", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"graphName": "fraud-network",
+				"knnConfig": map[string]any{
+					"nodeProperties":   []string{"n2v"},
+					"topK":             5,
+					"similarityCutoff": 0.9,
+				},
+				"seedLabel":      "Customer",
+				"seedIdProperty": "customerId",
+				"seedIds":        []string{"CUS123"},
+				"resultLabels":   nil,
+				"limit":          25,
+			}).
+			Return([]*neo4j.Record{}, nil)
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.FindSimilarToSeedsHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"graphName":         "fraud-network",
+					"algorithm":         "node2vec",
+					"embeddingProperty": "n2v",
+					"reuseEmbeddings":   true,
+					"seedConfig":        seedConfig,
+					"seedIds":           []string{"CUS123"},
+					"topK":              5,
+					"similarityCutoff":  0.9,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("missing seeds", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.FindSimilarToSeedsHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"graphName":  "fraud-network",
+					"seedConfig": seedConfig,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing seeds")
+		}
+	})
+
+	t.Run("embedding failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("Node property `embedding` already exists"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.FindSimilarToSeedsHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"graphName":  "fraud-network",
+					"seedConfig": seedConfig,
+					"seedIds":    []string{"CUS123"},
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for embedding failure")
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type SeedConfig struct {
+	NodeLabel  string `json:"nodeLabel" jsonschema:"description=Node label of the seed entities (e.g. Customer)"`
+	IdProperty string `json:"idProperty" jsonschema:"description=Property name for the unique identifier (e.g. customerId)"`
+}
+
+type FindSimilarToSeedsInput struct {
+	GraphName          string     `json:"graphName" jsonschema:"description=Name of an existing projection (see create-gds-projection)"`
+	Algorithm          string     `json:"algorithm,omitempty" jsonschema:"enum=fastrp,enum=node2vec,default=fastrp,description=Embedding algorithm. fastrp is fast and deterministic; node2vec captures longer-range structure at higher cost."`
+	EmbeddingDimension int        `json:"embeddingDimension,omitempty" jsonschema:"default=128,description=Size of the embedding vectors (16-1024)"`
+	EmbeddingProperty  string     `json:"embeddingProperty,omitempty" jsonschema:"default=embedding,description=In-memory projection property that holds the embeddings"`
+	ReuseEmbeddings    bool       `json:"reuseEmbeddings,omitempty" jsonschema:"default=false,description=Skip computing embeddings and use embeddingProperty from a previous call on the same projection"`
+	SeedConfig         SeedConfig `json:"seedConfig" jsonschema:"description=How to identify the known-fraud seed entities"`
+	SeedIds            []string   `json:"seedIds" jsonschema:"description=Identifiers of known-fraud entities to search from"`
+	TopK               int        `json:"topK,omitempty" jsonschema:"default=10,description=Number of nearest neighbours found per seed"`
+	SimilarityCutoff   float64    `json:"similarityCutoff,omitempty" jsonschema:"description=Optional minimum cosine similarity for a candidate (0-1)"`
+	ResultLabels       []string   `json:"resultLabels,omitempty" jsonschema:"description=Only return candidates with one of these labels (e.g. Customer)"`
+	Limit              int        `json:"limit,omitempty" jsonschema:"default=25,description=Maximum number of candidates to return, most similar first (1-500)"`
+}
+
+// FindSimilarToSeedsSpec returns the MCP tool specification for embedding-based similarity search from known-fraud seeds
+func FindSimilarToSeedsSpec() mcp.Tool {
+	return mcp.NewTool("find-similar-to-seeds",
+		mcp.WithDescription(`Finds entities that are structurally similar to a set of known-fraud seeds. Computes FastRP or node2vec graph embeddings on a projection, then runs kNN (gds.knn) to rank the nearest neighbours of each seed as candidates for investigation.
+
+Unlike node similarity, embeddings capture the wider neighbourhood: entities that transact with the same counterparties, sit in the same part of a ring or share indirect links are ranked close to the seeds even without sharing PII directly.
+
+**REQUIRED WORKFLOW:**
+1. **Call create-gds-projection** with the entities, their PII and transaction relationships (UNDIRECTED works best for embeddings)
+2. **Find similar entities** from confirmed fraud cases as seeds
+3. **Repeat with reuseEmbeddings=true** to try other seeds without recomputing
+4. **Call drop-gds-projection** when finished
+
+**Example:**
+{
+  "graphName": "fraud-network",
+  "seedConfig": {"nodeLabel": "Customer", "idProperty": "customerId"},
+  "seedIds": ["CUS123", "CUS456"],
+  "resultLabels": ["Customer"],
+  "limit": 20
+}
+
+**Returns:**
+- {candidate, score, matchedSeeds} per candidate, highest cosine similarity first. Seeds themselves are excluded.
+
+Embeddings are stored in the projection only; the database is not modified.`),
+		mcp.WithInputSchema[FindSimilarToSeedsInput](),
+		mcp.WithTitleAnnotation("Find Entities Similar to Fraud Seeds"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,272 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const createGDSProjectionQuery = `
+CALL gds.graph.project($graphName, $nodeProjection, $relationshipProjection)
+YIELD graphName, nodeCount, relationshipCount, projectMillis
+RETURN graphName, nodeCount, relationshipCount, projectMillis`
+
+const listGDSProjectionsQuery = `
+CALL gds.graph.list()
+YIELD graphName, database, nodeCount, relationshipCount, memoryUsage, creationTime, schemaWithOrientation
+RETURN graphName, database, nodeCount, relationshipCount, memoryUsage, toString(creationTime) as creationTime, schemaWithOrientation as schema
+ORDER BY graphName`
+
+const listGDSProjectionQuery = `
+CALL gds.graph.list($graphName)
+YIELD graphName, database, nodeCount, relationshipCount, memoryUsage, creationTime, schemaWithOrientation
+RETURN graphName, database, nodeCount, relationshipCount, memoryUsage, toString(creationTime) as creationTime, schemaWithOrientation as schema`
+
+const dropGDSProjectionQuery = `
+CALL gds.graph.drop($graphName, false)
+YIELD graphName, nodeCount, relationshipCount
+RETURN graphName, nodeCount, relationshipCount`
+
+var validOrientations = map[string]bool{
+	"NATURAL":    true,
+	"REVERSE":    true,
+	"UNDIRECTED": true,
+}
+
+// CreateGDSProjectionHandler returns a handler function for the create-gds-projection tool
+func CreateGDSProjectionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleCreateGDSProjection(ctx, request, deps)
+	}
+}
+
+// ListGDSProjectionsHandler returns a handler function for the list-gds-projections tool
+func ListGDSProjectionsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListGDSProjections(ctx, request, deps)
+	}
+}
+
+// DropGDSProjectionHandler returns a handler function for the drop-gds-projection tool
+func DropGDSProjectionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleDropGDSProjection(ctx, request, deps)
+	}
+}
+
+func handleCreateGDSProjection(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("create-gds-projection"))
+
+	var args CreateGDSProjectionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateProjectionInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("creating GDS projection",
+		"graphName", args.GraphName,
+		"nodeMappings", len(args.NodeMappings),
+		"relationshipMappings", len(args.RelationshipMappings))
+
+	params := map[string]any{
+		"graphName":              args.GraphName,
+		"nodeProjection":         buildNodeProjection(args.NodeMappings),
+		"relationshipProjection": buildRelationshipProjection(args.RelationshipMappings),
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, createGDSProjectionQuery, params)
+	if err != nil {
+		slog.Error("failed to execute create-gds-projection query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to create GDS projection '%s': %v. Use list-gds-projections to check for an existing projection with the same name", args.GraphName, err)), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewGDSProjCreatedEvent())
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format create-gds-projection results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+func handleListGDSProjections(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("list-gds-projections"))
+
+	var args ListGDSProjectionsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	query := listGDSProjectionsQuery
+	var params map[string]any
+	if args.GraphName != "" {
+		query = listGDSProjectionQuery
+		params = map[string]any{"graphName": args.GraphName}
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, params)
+	if err != nil {
+		slog.Error("failed to execute list-gds-projections query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to list GDS projections: %v", err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format list-gds-projections results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+func handleDropGDSProjection(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("drop-gds-projection"))
+
+	var args DropGDSProjectionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.GraphName == "" {
+		errMessage := "graphName is required"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("dropping GDS projection", "graphName", args.GraphName)
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, dropGDSProjectionQuery, map[string]any{"graphName": args.GraphName})
+	if err != nil {
+		slog.Error("failed to execute drop-gds-projection query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to drop GDS projection '%s': %v", args.GraphName, err)), nil
+	}
+
+	// failIfMissing is false, so an unknown projection yields no rows
+	if len(records) == 0 {
+		errMessage := fmt.Sprintf("no GDS projection named '%s' found", args.GraphName)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewGDSProjDropEvent())
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format drop-gds-projection results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateProjectionInput checks the projection mappings and fills in defaults.
+func validateProjectionInput(args *CreateGDSProjectionInput) string {
+	if args.GraphName == "" {
+		return "graphName is required"
+	}
+	if len(args.NodeMappings) == 0 {
+		return "nodeMappings must contain at least one node label. Use get-schema to discover labels first."
+	}
+	if len(args.RelationshipMappings) == 0 {
+		return "relationshipMappings must contain at least one relationship type. Use get-schema to discover relationship types first."
+	}
+
+	for i, mapping := range args.NodeMappings {
+		if mapping.Label == "" {
+			return fmt.Sprintf("nodeMappings[%d].label is required", i)
+		}
+	}
+	for i := range args.RelationshipMappings {
+		mapping := &args.RelationshipMappings[i]
+		if mapping.Type == "" {
+			return fmt.Sprintf("relationshipMappings[%d].type is required", i)
+		}
+		if mapping.Orientation == "" {
+			mapping.Orientation = "NATURAL"
+		}
+		mapping.Orientation = strings.ToUpper(mapping.Orientation)
+		if !validOrientations[mapping.Orientation] {
+			return fmt.Sprintf("relationshipMappings[%d].orientation must be NATURAL, REVERSE or UNDIRECTED", i)
+		}
+	}
+
+	return ""
+}
+
+// buildNodeProjection converts node mappings into a GDS node projection map keyed by label
+func buildNodeProjection(mappings []NodeMapping) map[string]any {
+	projection := make(map[string]any, len(mappings))
+	for _, mapping := range mappings {
+		node := map[string]any{"label": mapping.Label}
+		if len(mapping.Properties) > 0 {
+			node["properties"] = mapping.Properties
+		}
+		projection[mapping.Label] = node
+	}
+	return projection
+}
+
+// buildRelationshipProjection converts relationship mappings into a GDS relationship projection map keyed by type
+func buildRelationshipProjection(mappings []RelationshipMapping) map[string]any {
+	projection := make(map[string]any, len(mappings))
+	for _, mapping := range mappings {
+		relationship := map[string]any{
+			"type":        mapping.Type,
+			"orientation": mapping.Orientation,
+		}
+		if len(mapping.Properties) > 0 {
+			relationship["properties"] = mapping.Properties
+		}
+		projection[mapping.Type] = relationship
+	}
+	return projection
+}
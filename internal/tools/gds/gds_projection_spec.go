@@ -0,0 +1,96 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// NodeMapping describes one node label to include in a projection
+type NodeMapping struct {
+	Label      string   `json:"label" jsonschema:"description=Node label to project (e.g. Customer, Account)"`
+	Properties []string `json:"properties,omitempty" jsonschema:"description=Optional numeric node properties to load into the projection (e.g. riskScore)"`
+}
+
+// RelationshipMapping describes one relationship type to include in a projection
+type RelationshipMapping struct {
+	Type        string   `json:"type" jsonschema:"description=Relationship type to project (e.g. HAS_EMAIL, TRANSFER)"`
+	Orientation string   `json:"orientation,omitempty" jsonschema:"enum=NATURAL,enum=REVERSE,enum=UNDIRECTED,default=NATURAL,description=Orientation of the projected relationships. Use UNDIRECTED for community detection and shared-attribute analysis."`
+	Properties  []string `json:"properties,omitempty" jsonschema:"description=Optional numeric relationship properties to load (e.g. amount for weighted algorithms)"`
+}
+
+type CreateGDSProjectionInput struct {
+	GraphName            string                `json:"graphName" jsonschema:"description=Unique name of the in-memory projection (e.g. fraud-ring-2024-06)"`
+	NodeMappings         []NodeMapping         `json:"nodeMappings" jsonschema:"description=Node labels to project. Discovered from get-schema."`
+	RelationshipMappings []RelationshipMapping `json:"relationshipMappings" jsonschema:"description=Relationship types to project. Discovered from get-schema."`
+}
+
+type ListGDSProjectionsInput struct {
+	GraphName string `json:"graphName,omitempty" jsonschema:"description=Optional: only return the projection with this name"`
+}
+
+type DropGDSProjectionInput struct {
+	GraphName string `json:"graphName" jsonschema:"description=Name of the projection to drop"`
+}
+
+// CreateGDSProjectionSpec returns the MCP tool specification for creating a GDS graph projection
+func CreateGDSProjectionSpec() mcp.Tool {
+	return mcp.NewTool("create-gds-projection",
+		mcp.WithDescription(`Creates a named in-memory GDS graph projection (gds.graph.project) from node labels and relationship types. GDS algorithms run against projections, so create one before running community detection, centrality or similarity.
+
+**REQUIRED WORKFLOW - Schema Discovery:**
+1. **Call get-schema tool** to find the labels and relationship types that connect the entities you want to analyse
+2. **Create the projection** with a unique graphName
+3. **Run algorithms** against the graphName
+4. **Call drop-gds-projection** when finished to release memory
+
+**Example (shared PII network):**
+{
+  "graphName": "shared-pii",
+  "nodeMappings": [{"label": "Customer"}, {"label": "Email"}, {"label": "Phone"}],
+  "relationshipMappings": [
+    {"type": "HAS_EMAIL", "orientation": "UNDIRECTED"},
+    {"type": "HAS_PHONE", "orientation": "UNDIRECTED"}
+  ]
+}
+
+**Returns:**
+- graphName, nodeCount, relationshipCount and projectMillis
+
+Fails if a projection with the same name already exists. The database itself is not modified.`),
+		mcp.WithInputSchema[CreateGDSProjectionInput](),
+		mcp.WithTitleAnnotation("Create GDS Graph Projection"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// ListGDSProjectionsSpec returns the MCP tool specification for listing GDS graph projections
+func ListGDSProjectionsSpec() mcp.Tool {
+	return mcp.NewTool("list-gds-projections",
+		mcp.WithDescription(`Lists the in-memory GDS graph projections (gds.graph.list) with their size and schema. Use it to reuse an existing projection, or to find projections that should be dropped.
+
+**Returns:**
+- graphName, database, nodeCount, relationshipCount, memoryUsage, creationTime and schema per projection`),
+		mcp.WithInputSchema[ListGDSProjectionsInput](),
+		mcp.WithTitleAnnotation("List GDS Graph Projections"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// DropGDSProjectionSpec returns the MCP tool specification for dropping a GDS graph projection
+func DropGDSProjectionSpec() mcp.Tool {
+	return mcp.NewTool("drop-gds-projection",
+		mcp.WithDescription(`Drops a named in-memory GDS graph projection (gds.graph.drop) to release memory. The database itself is not modified.
+
+**Returns:**
+- graphName, nodeCount and relationshipCount of the dropped projection`),
+		mcp.WithInputSchema[DropGDSProjectionInput](),
+		mcp.WithTitleAnnotation("Drop GDS Graph Projection"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
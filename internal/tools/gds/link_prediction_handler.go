@@ -0,0 +1,351 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	defaultLinkEmbeddingDimension = 64
+	maxLinkEmbeddingDimension     = 512
+	defaultTestFraction           = 0.1
+	defaultTrainFraction          = 0.1
+	defaultPredictTopN            = 20
+	maxPredictTopN                = 1000
+	linkPredictionRandomSeed      = 42
+	linkEmbeddingProperty         = "linkPredictionEmbedding"
+)
+
+// linkPredictionNamespaces lists where GDS versions have placed the link prediction procedures, preferred first
+var linkPredictionNamespaces = []string{
+	"gds.beta.pipeline.linkPrediction",
+	"gds.pipeline.linkPrediction",
+	"gds.alpha.pipeline.linkPrediction",
+}
+
+var linkFeatureTypes = map[string]bool{
+	"hadamard": true,
+	"cosine":   true,
+	"l2":       true,
+}
+
+// ConfigureLinkPredictionPipelineHandler returns a handler function for the configure-link-prediction-pipeline tool
+func ConfigureLinkPredictionPipelineHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleConfigureLinkPredictionPipeline(ctx, request, deps)
+	}
+}
+
+// TrainLinkPredictionModelHandler returns a handler function for the train-link-prediction-model tool
+func TrainLinkPredictionModelHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleTrainLinkPredictionModel(ctx, request, deps)
+	}
+}
+
+// PredictLinksHandler returns a handler function for the predict-links tool
+func PredictLinksHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handlePredictLinks(ctx, request, deps)
+	}
+}
+
+func handleConfigureLinkPredictionPipeline(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errMessage := validateLinkPredictionDeps(deps, "configure-link-prediction-pipeline"); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	var args ConfigureLinkPredictionPipelineInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateConfigurePipelineInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	namespace, errMessage := linkPredictionNamespace(deps.GDSCapabilities)
+	if errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("configuring link prediction pipeline",
+		"pipelineName", args.PipelineName,
+		"featureType", args.FeatureType,
+		"includeRandomForest", args.IncludeRandomForest)
+
+	params := map[string]any{
+		"pipelineName": args.PipelineName,
+		"embeddingConfig": map[string]any{
+			"mutateProperty":     linkEmbeddingProperty,
+			"embeddingDimension": args.EmbeddingDimension,
+			"randomSeed":         linkPredictionRandomSeed,
+		},
+		"featureType": args.FeatureType,
+		"featureConfig": map[string]any{
+			"nodeProperties": []string{linkEmbeddingProperty},
+		},
+		"splitConfig": map[string]any{
+			"testFraction":  args.TestFraction,
+			"trainFraction": args.TrainFraction,
+		},
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, buildConfigurePipelineQuery(namespace, args.IncludeRandomForest), params)
+	if err != nil {
+		slog.Error("failed to execute configure-link-prediction-pipeline query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to configure link prediction pipeline '%s': %v", args.PipelineName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format configure-link-prediction-pipeline results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+func handleTrainLinkPredictionModel(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errMessage := validateLinkPredictionDeps(deps, "train-link-prediction-model"); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	var args TrainLinkPredictionModelInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.PipelineName == "" || args.ModelName == "" || args.GraphName == "" || args.TargetRelationshipType == "" {
+		errMessage := "pipelineName, modelName, graphName and targetRelationshipType are required"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	namespace, errMessage := linkPredictionNamespace(deps.GDSCapabilities)
+	if errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("training link prediction model",
+		"pipelineName", args.PipelineName,
+		"modelName", args.ModelName,
+		"graphName", args.GraphName,
+		"targetRelationshipType", args.TargetRelationshipType)
+
+	config := map[string]any{
+		"pipeline":               args.PipelineName,
+		"modelName":              args.ModelName,
+		"targetRelationshipType": args.TargetRelationshipType,
+		"metrics":                []string{"AUCPR"},
+		"randomSeed":             linkPredictionRandomSeed,
+	}
+	if args.SourceNodeLabel != "" {
+		config["sourceNodeLabel"] = args.SourceNodeLabel
+	}
+	if args.TargetNodeLabel != "" {
+		config["targetNodeLabel"] = args.TargetNodeLabel
+	}
+
+	query := fmt.Sprintf(`CALL %s.train($graphName, $config)
+YIELD modelInfo, modelSelectionStats
+RETURN modelInfo.modelName as modelName,
+       modelInfo.metrics as metrics,
+       modelInfo.bestParameters as bestParameters,
+       modelSelectionStats`, namespace)
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, map[string]any{
+		"graphName": args.GraphName,
+		"config":    config,
+	})
+	if err != nil {
+		slog.Error("failed to execute train-link-prediction-model query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to train link prediction model '%s': %v. Check that the pipeline exists and the target relationship type is projected UNDIRECTED", args.ModelName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format train-link-prediction-model results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+func handlePredictLinks(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if errMessage := validateLinkPredictionDeps(deps, "predict-links"); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	var args PredictLinksInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validatePredictLinksInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	namespace, errMessage := linkPredictionNamespace(deps.GDSCapabilities)
+	if errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("predicting links",
+		"graphName", args.GraphName,
+		"modelName", args.ModelName,
+		"topN", args.TopN)
+
+	config := map[string]any{
+		"modelName": args.ModelName,
+		"topN":      args.TopN,
+	}
+	if args.Threshold > 0 {
+		config["threshold"] = args.Threshold
+	}
+	if args.SourceNodeLabel != "" {
+		config["sourceNodeLabel"] = args.SourceNodeLabel
+	}
+	if args.TargetNodeLabel != "" {
+		config["targetNodeLabel"] = args.TargetNodeLabel
+	}
+
+	query := fmt.Sprintf(`CALL %s.predict.stream($graphName, $config)
+YIELD node1, node2, probability
+WITH gds.util.asNode(node1) as source, gds.util.asNode(node2) as target, probability
+RETURN source {.*, labels: labels(source)} as source,
+       target {.*, labels: labels(target)} as target,
+       probability
+ORDER BY probability DESC`, namespace)
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, query, map[string]any{
+		"graphName": args.GraphName,
+		"config":    config,
+	})
+	if err != nil {
+		slog.Error("failed to execute predict-links query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to predict links with model '%s': %v", args.ModelName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format predict-links results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateLinkPredictionDeps checks the dependencies shared by the link prediction tools and emits the tool event
+func validateLinkPredictionDeps(deps *tools.ToolDependencies, toolName string) string {
+	if deps.DBService == nil {
+		return "Database service is not initialized"
+	}
+	if deps.AnalyticsService == nil {
+		return "Analytics service is not initialized"
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent(toolName))
+	return ""
+}
+
+// linkPredictionNamespace picks the procedure namespace of the installed GDS version.
+// Without detected capabilities the beta namespace of GDS 2.x is assumed.
+func linkPredictionNamespace(capabilities *tools.GDSCapabilities) (string, string) {
+	for _, namespace := range linkPredictionNamespaces {
+		if capabilities.HasProcedure(namespace + ".create") {
+			return namespace, ""
+		}
+	}
+	if capabilities.MissingProcedure(linkPredictionNamespaces[0] + ".create") {
+		return "", "link prediction pipelines are not available in this GDS installation. Use list-capabilities to check the ml family."
+	}
+	return linkPredictionNamespaces[0], ""
+}
+
+// validateConfigurePipelineInput checks the pipeline settings and fills in defaults.
+func validateConfigurePipelineInput(args *ConfigureLinkPredictionPipelineInput) string {
+	if args.PipelineName == "" {
+		return "pipelineName is required"
+	}
+
+	if args.EmbeddingDimension == 0 {
+		args.EmbeddingDimension = defaultLinkEmbeddingDimension
+	}
+	if args.EmbeddingDimension < minEmbeddingDimension || args.EmbeddingDimension > maxLinkEmbeddingDimension {
+		return fmt.Sprintf("embeddingDimension must be between %d and %d", minEmbeddingDimension, maxLinkEmbeddingDimension)
+	}
+
+	if args.FeatureType == "" {
+		args.FeatureType = "hadamard"
+	}
+	args.FeatureType = strings.ToLower(args.FeatureType)
+	if !linkFeatureTypes[args.FeatureType] {
+		return "featureType must be hadamard, cosine or l2"
+	}
+
+	if args.TestFraction == 0 {
+		args.TestFraction = defaultTestFraction
+	}
+	if args.TrainFraction == 0 {
+		args.TrainFraction = defaultTrainFraction
+	}
+	if args.TestFraction <= 0 || args.TestFraction >= 1 || args.TrainFraction <= 0 || args.TrainFraction >= 1 {
+		return "testFraction and trainFraction must be between 0 and 1"
+	}
+
+	return ""
+}
+
+// validatePredictLinksInput checks the prediction settings and fills in defaults.
+func validatePredictLinksInput(args *PredictLinksInput) string {
+	if args.GraphName == "" || args.ModelName == "" {
+		return "graphName and modelName are required"
+	}
+	if args.TopN == 0 {
+		args.TopN = defaultPredictTopN
+	}
+	if args.TopN < 1 || args.TopN > maxPredictTopN {
+		return fmt.Sprintf("topN must be between 1 and %d", maxPredictTopN)
+	}
+	if args.Threshold < 0 || args.Threshold > 1 {
+		return "threshold must be between 0 and 1"
+	}
+	return ""
+}
+
+// buildConfigurePipelineQuery creates the pipeline and adds the embedding, feature, split and model steps in one query
+func buildConfigurePipelineQuery(namespace string, includeRandomForest bool) string {
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.create($pipelineName) YIELD name\n", namespace))
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.addNodeProperty($pipelineName, 'fastRP', $embeddingConfig) YIELD nodePropertySteps\n", namespace))
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.addFeature($pipelineName, $featureType, $featureConfig) YIELD featureSteps\n", namespace))
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.configureSplit($pipelineName, $splitConfig) YIELD splitConfig\n", namespace))
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.addLogisticRegression($pipelineName) YIELD parameterSpace as logisticRegressionSpace\n", namespace))
+	parameterSpace := "logisticRegressionSpace"
+	if includeRandomForest {
+		queryBuilder.WriteString(fmt.Sprintf("CALL %s.addRandomForest($pipelineName, {numberOfDecisionTrees: 10}) YIELD parameterSpace\n", namespace))
+		parameterSpace = "parameterSpace"
+	}
+	queryBuilder.WriteString("RETURN name as pipelineName, nodePropertySteps, featureSteps, splitConfig, ")
+	queryBuilder.WriteString(parameterSpace + " as parameterSpace")
+
+	return queryBuilder.String()
+}
@@ -0,0 +1,355 @@
+package gds_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/gds"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"go.uber.org/mock/gomock"
+)
+
+func TestConfigureLinkPredictionPipelineHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("configure-link-prediction-pipeline").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("configures pipeline with defaults", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"pipelineName": "shared-pii-lp",
+				"embeddingConfig": map[string]any{
+					"mutateProperty":     "linkPredictionEmbedding",
+					"embeddingDimension": 64,
+					"randomSeed":         42,
+				},
+				"featureType": "hadamard",
+				"featureConfig": map[string]any{
+					"nodeProperties": []string{"linkPredictionEmbedding"},
+				},
+				"splitConfig": map[string]any{
+					"testFraction":  0.1,
+					"trainFraction": 0.1,
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "CALL gds.beta.pipeline.linkPrediction.create($pipelineName)") {
+					t.Errorf("Expected beta pipeline namespace, got: %s", query)
+				}
+				if strings.Contains(query, "addRandomForest") {
+					t.Errorf("Expected no random forest, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.ConfigureLinkPredictionPipelineHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"pipelineName": "shared-pii-lp"},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("uses namespace of detected GDS version", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "CALL gds.alpha.pipeline.linkPrediction.create($pipelineName)") {
+					t.Errorf("Expected alpha pipeline namespace, got: %s", query)
+				}
+				if !strings.Contains(query, "addRandomForest") {
+					t.Errorf("Expected random forest, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			GDSCapabilities:  tools.NewGDSCapabilities("2.0.0", []string{"gds.alpha.pipeline.linkPrediction.create"}),
+		}
+
+		handler := gds.ConfigureLinkPredictionPipelineHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"pipelineName":        "shared-pii-lp",
+					"includeRandomForest": true,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("link prediction not available", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			GDSCapabilities:  tools.NewGDSCapabilities("2.6.0", []string{"gds.louvain.stream"}),
+		}
+
+		handler := gds.ConfigureLinkPredictionPipelineHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"pipelineName": "shared-pii-lp"},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result when link prediction is not available")
+		}
+	})
+
+	t.Run("invalid feature type", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.ConfigureLinkPredictionPipelineHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"pipelineName": "shared-pii-lp",
+					"featureType":  "concat",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid feature type")
+		}
+	})
+}
+
+func TestTrainLinkPredictionModelHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("train-link-prediction-model").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("trains named model", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"graphName": "customer-network",
+				"config": map[string]any{
+					"pipeline":               "shared-pii-lp",
+					"modelName":              "shared-pii-lp-model",
+					"targetRelationshipType": "SHARED_PII",
+					"metrics":                []string{"AUCPR"},
+					"randomSeed":             42,
+					"sourceNodeLabel":        "Customer",
+					"targetNodeLabel":        "Customer",
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "CALL gds.beta.pipeline.linkPrediction.train($graphName, $config)") {
+					t.Errorf("Expected train call, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.TrainLinkPredictionModelHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"pipelineName":           "shared-pii-lp",
+					"modelName":              "shared-pii-lp-model",
+					"graphName":              "customer-network",
+					"targetRelationshipType": "SHARED_PII",
+					"sourceNodeLabel":        "Customer",
+					"targetNodeLabel":        "Customer",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("missing target relationship type", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.TrainLinkPredictionModelHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"pipelineName": "shared-pii-lp",
+					"modelName":    "shared-pii-lp-model",
+					"graphName":    "customer-network",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing target relationship type")
+		}
+	})
+}
+
+func TestPredictLinksHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("predict-links").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("streams top predictions", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), map[string]any{
+				"graphName": "customer-network",
+				"config": map[string]any{
+					"modelName": "shared-pii-lp-model",
+					"topN":      20,
+					"threshold": 0.8,
+				},
+			}).
+			DoAndReturn(func(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+				if !strings.HasPrefix(query, "CALL gds.beta.pipeline.linkPrediction.predict.stream($graphName, $config)") {
+					t.Errorf("Expected predict stream call, got: %s", query)
+				}
+				return []*neo4j.Record{}, nil
+			})
+		mockDB.EXPECT().
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
+			Return(`[]`, nil)
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.PredictLinksHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"graphName": "customer-network",
+					"modelName": "shared-pii-lp-model",
+					"threshold": 0.8,
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Error("Expected success result")
+		}
+	})
+
+	t.Run("database query failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("Model with name `missing` does not exist"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.PredictLinksHandler(deps)
+		request := mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"graphName": "customer-network",
+					"modelName": "missing",
+				},
+			},
+		}
+
+		result, err := handler(context.Background(), request)
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for database failure")
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type ConfigureLinkPredictionPipelineInput struct {
+	PipelineName        string  `json:"pipelineName" jsonschema:"description=Unique name of the pipeline (e.g. shared-pii-lp)"`
+	EmbeddingDimension  int     `json:"embeddingDimension,omitempty" jsonschema:"default=64,description=Dimension of the FastRP embeddings used as link features (16-512)"`
+	FeatureType         string  `json:"featureType,omitempty" jsonschema:"enum=hadamard,enum=cosine,enum=l2,default=hadamard,description=How the embeddings of both nodes are combined into a link feature"`
+	TestFraction        float64 `json:"testFraction,omitempty" jsonschema:"default=0.1,description=Fraction of target relationships held out for testing (0-1)"`
+	TrainFraction       float64 `json:"trainFraction,omitempty" jsonschema:"default=0.1,description=Fraction of the remaining relationships used for training (0-1)"`
+	IncludeRandomForest bool    `json:"includeRandomForest,omitempty" jsonschema:"default=false,description=Also try a random forest next to logistic regression during model selection"`
+}
+
+type TrainLinkPredictionModelInput struct {
+	PipelineName           string `json:"pipelineName" jsonschema:"description=Pipeline created with configure-link-prediction-pipeline"`
+	ModelName              string `json:"modelName" jsonschema:"description=Unique name for the trained model (e.g. shared-pii-lp-model)"`
+	GraphName              string `json:"graphName" jsonschema:"description=Projection to train on. The target relationship type must be projected UNDIRECTED."`
+	TargetRelationshipType string `json:"targetRelationshipType" jsonschema:"description=Relationship type to learn to predict (e.g. SHARED_PII, TRANSACTS_WITH)"`
+	SourceNodeLabel        string `json:"sourceNodeLabel,omitempty" jsonschema:"description=Optional label of the source nodes of predicted links (e.g. Customer)"`
+	TargetNodeLabel        string `json:"targetNodeLabel,omitempty" jsonschema:"description=Optional label of the target nodes of predicted links (e.g. Customer)"`
+}
+
+type PredictLinksInput struct {
+	GraphName       string  `json:"graphName" jsonschema:"description=Projection to predict on, usually the one the model was trained on"`
+	ModelName       string  `json:"modelName" jsonschema:"description=Model trained with train-link-prediction-model"`
+	TopN            int     `json:"topN,omitempty" jsonschema:"default=20,description=Number of most probable missing links to return (1-1000)"`
+	Threshold       float64 `json:"threshold,omitempty" jsonschema:"description=Optional minimum probability for a predicted link (0-1)"`
+	SourceNodeLabel string  `json:"sourceNodeLabel,omitempty" jsonschema:"description=Optional label of the source nodes of predicted links"`
+	TargetNodeLabel string  `json:"targetNodeLabel,omitempty" jsonschema:"description=Optional label of the target nodes of predicted links"`
+}
+
+// ConfigureLinkPredictionPipelineSpec returns the MCP tool specification for configuring a link prediction pipeline
+func ConfigureLinkPredictionPipelineSpec() mcp.Tool {
+	return mcp.NewTool("configure-link-prediction-pipeline",
+		mcp.WithDescription(`Creates a GDS link prediction pipeline for finding probable hidden links in a fraud graph, such as undisclosed SHARED_PII or TRANSACTS_WITH relationships between customers.
+
+The pipeline computes FastRP embeddings, combines them into link features, splits the known links into train and test sets and selects the best of logistic regression (and optionally random forest).
+
+**LINK PREDICTION WORKFLOW:**
+1. **Call create-gds-projection** including the target relationship type in UNDIRECTED orientation
+2. **Configure the pipeline** (this tool)
+3. **Call train-link-prediction-model** to train a named model on the projection
+4. **Call predict-links** to stream the most probable missing links
+5. **Call drop-gds-projection** when finished
+
+**Example:**
+{"pipelineName": "shared-pii-lp", "embeddingDimension": 64, "featureType": "hadamard"}
+
+**Returns:**
+- pipelineName, nodePropertySteps, featureSteps, splitConfig and parameterSpace`),
+		mcp.WithInputSchema[ConfigureLinkPredictionPipelineInput](),
+		mcp.WithTitleAnnotation("Configure Link Prediction Pipeline"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// TrainLinkPredictionModelSpec returns the MCP tool specification for training a link prediction model
+func TrainLinkPredictionModelSpec() mcp.Tool {
+	return mcp.NewTool("train-link-prediction-model",
+		mcp.WithDescription(`Trains a named link prediction model from a pipeline created with configure-link-prediction-pipeline. Training runs on a GDS projection; the target relationship type must be projected UNDIRECTED.
+
+**Example:**
+{
+  "pipelineName": "shared-pii-lp",
+  "modelName": "shared-pii-lp-model",
+  "graphName": "customer-network",
+  "targetRelationshipType": "SHARED_PII",
+  "sourceNodeLabel": "Customer",
+  "targetNodeLabel": "Customer"
+}
+
+**Returns:**
+- modelName, test and train metrics (AUCPR), the winning model parameters and model selection statistics
+
+The model is stored in the GDS model catalog; the database is not modified.`),
+		mcp.WithInputSchema[TrainLinkPredictionModelInput](),
+		mcp.WithTitleAnnotation("Train Link Prediction Model"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
+
+// PredictLinksSpec returns the MCP tool specification for streaming link predictions
+func PredictLinksSpec() mcp.Tool {
+	return mcp.NewTool("predict-links",
+		mcp.WithDescription(`Streams the most probable missing links from a trained link prediction model (see train-link-prediction-model). Each pair is a candidate hidden connection worth investigating, for example two customers likely to share undisclosed PII.
+
+**Example:**
+{"graphName": "customer-network", "modelName": "shared-pii-lp-model", "topN": 20, "threshold": 0.8}
+
+**Returns:**
+- {source, target, probability} per predicted link, most probable first. Existing links are never predicted.`),
+		mcp.WithInputSchema[PredictLinksInput](),
+		mcp.WithTitleAnnotation("Predict Links"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
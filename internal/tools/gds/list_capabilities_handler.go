@@ -0,0 +1,46 @@
+package gds
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// capabilitiesResponse is the output of the list-capabilities tool
+type capabilitiesResponse struct {
+	GDS *tools.GDSCapabilities `json:"gds"`
+}
+
+// ListCapabilitiesHandler returns a handler function for the list-capabilities tool
+func ListCapabilitiesHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(_ context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListCapabilities(deps)
+	}
+}
+
+func handleListCapabilities(deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("list-capabilities"))
+
+	// Capabilities are detected once at startup; nil means GDS was not found
+	response := capabilitiesResponse{GDS: deps.GDSCapabilities}
+	if response.GDS == nil {
+		response.GDS = &tools.GDSCapabilities{Installed: false}
+	}
+
+	output, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		slog.Error("failed to format list-capabilities results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(output)), nil
+}
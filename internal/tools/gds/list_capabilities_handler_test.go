@@ -0,0 +1,63 @@
+package gds_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/gds"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListCapabilitiesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("list-capabilities").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("reports detected GDS families", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			GDSCapabilities:  tools.NewGDSCapabilities("2.6.3", []string{"gds.louvain.stream", "gds.pageRank.stream"}),
+		}
+
+		handler := gds.ListCapabilitiesHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"version": "2.6.3"`) || !strings.Contains(text, `"louvain"`) {
+			t.Errorf("Expected version and community family, got: %s", text)
+		}
+	})
+
+	t.Run("reports GDS as not installed", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+		}
+
+		handler := gds.ListCapabilitiesHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, `"installed": false`) {
+			t.Errorf("Expected GDS to be reported as not installed, got: %s", text)
+		}
+	})
+}
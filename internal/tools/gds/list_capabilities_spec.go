@@ -0,0 +1,20 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+// ListCapabilitiesSpec returns the MCP tool specification for listing the detected GDS capabilities
+func ListCapabilitiesSpec() mcp.Tool {
+	return mcp.NewTool("list-capabilities",
+		mcp.WithDescription(
+			"Reports the analytics capabilities detected on the Neo4j server at startup: whether the Graph Data Science (GDS) library is installed, its version, "+
+				"and which algorithm families are available (community, centrality, similarity, embeddings, pathFinding, ml). "+
+				"Use it before planning graph analytics so you only call tools whose algorithms exist. "+
+				"If GDS is not installed, tell the user that the GDS tools are unavailable.",
+		),
+		mcp.WithTitleAnnotation("List server capabilities"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
@@ -44,7 +44,7 @@ func handleListGdsProcedures(ctx context.Context, deps *tools.ToolDependencies)
 		return mcp.NewToolResultError(formattedErrorMessage.Error()), nil
 	}
 
-	response, err := deps.DBService.Neo4jRecordsToJSON(records)
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
 	if err != nil {
 		slog.Error("failed to format list-gds-procedures results to JSON", "error", err)
 		return mcp.NewToolResultError(err.Error()), nil
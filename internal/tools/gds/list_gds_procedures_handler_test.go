@@ -27,7 +27,7 @@ func TestListGdsProceduresHandler(t *testing.T) {
 			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Nil()).
 			Return([]*neo4j.Record{}, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return("", nil)
 
 		deps := &tools.ToolDependencies{
@@ -115,7 +115,7 @@ func TestListGdsProceduresHandler(t *testing.T) {
 			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Nil()).
 			Return([]*neo4j.Record{}, nil)
 		mockDB.EXPECT().
-			Neo4jRecordsToJSON(gomock.Any()).
+			Neo4jRecordsToJSON(gomock.Any(), gomock.Any()).
 			Return("", errors.New("JSON marshaling failed"))
 
 		deps := &tools.ToolDependencies{
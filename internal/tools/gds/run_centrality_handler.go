@@ -0,0 +1,141 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	defaultCentralityLimit = 25
+	maxCentralityLimit     = 1000
+)
+
+// centralityAlgorithm describes the procedure and default write property of a centrality algorithm
+type centralityAlgorithm struct {
+	procedure     string
+	writeProperty string
+}
+
+var centralityAlgorithms = map[string]centralityAlgorithm{
+	"pagerank":    {procedure: "gds.pageRank", writeProperty: "pageRank"},
+	"degree":      {procedure: "gds.degree", writeProperty: "degree"},
+	"betweenness": {procedure: "gds.betweenness", writeProperty: "betweenness"},
+}
+
+// RunCentralityHandler returns a handler function for the run-centrality tool
+func RunCentralityHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleRunCentrality(ctx, request, deps)
+	}
+}
+
+func handleRunCentrality(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("run-centrality"))
+
+	var args RunCentralityInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateCentralityInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("running centrality",
+		"graphName", args.GraphName,
+		"algorithm", args.Algorithm)
+
+	config := map[string]any{}
+	if args.RelationshipWeightProperty != "" {
+		config["relationshipWeightProperty"] = args.RelationshipWeightProperty
+	}
+	params := map[string]any{
+		"graphName": args.GraphName,
+		"config":    config,
+		"limit":     args.Limit,
+	}
+	if len(args.ResultLabels) > 0 {
+		params["resultLabels"] = args.ResultLabels
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, buildCentralityStreamQuery(centralityAlgorithms[args.Algorithm], len(args.ResultLabels) > 0), params)
+	if err != nil {
+		slog.Error("failed to execute run-centrality query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run %s on projection '%s': %v. Use list-gds-projections to check the projection exists", args.Algorithm, args.GraphName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format run-centrality results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateCentralityInput checks the algorithm and limit and fills in defaults.
+func validateCentralityInput(args *RunCentralityInput) string {
+	if errMessage := validateCentralityAlgorithm(args.GraphName, &args.Algorithm); errMessage != "" {
+		return errMessage
+	}
+
+	if args.Limit == 0 {
+		args.Limit = defaultCentralityLimit
+	}
+	if args.Limit < 1 || args.Limit > maxCentralityLimit {
+		return fmt.Sprintf("limit must be between 1 and %d", maxCentralityLimit)
+	}
+
+	return ""
+}
+
+// validateCentralityAlgorithm requires a projection and defaults the algorithm to pagerank
+func validateCentralityAlgorithm(graphName string, algorithm *string) string {
+	if graphName == "" {
+		return "graphName is required. Use create-gds-projection to create a projection first."
+	}
+	if *algorithm == "" {
+		*algorithm = "pagerank"
+	}
+	*algorithm = strings.ToLower(*algorithm)
+	if _, ok := centralityAlgorithms[*algorithm]; !ok {
+		return "algorithm must be pagerank, degree or betweenness"
+	}
+	return ""
+}
+
+// buildCentralityStreamQuery returns the top-scoring nodes, optionally restricted to resultLabels
+func buildCentralityStreamQuery(algorithm centralityAlgorithm, filterLabels bool) string {
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.stream($graphName, $config)\n", algorithm.procedure))
+	queryBuilder.WriteString("YIELD nodeId, score\n")
+	queryBuilder.WriteString("WITH gds.util.asNode(nodeId) as node, score\n")
+	if filterLabels {
+		queryBuilder.WriteString("WHERE any(label IN labels(node) WHERE label IN $resultLabels)\n")
+	}
+	queryBuilder.WriteString("RETURN node {.*, labels: labels(node)} as node, score\n")
+	queryBuilder.WriteString("ORDER BY score DESC\n")
+	queryBuilder.WriteString("LIMIT $limit")
+
+	return queryBuilder.String()
+}
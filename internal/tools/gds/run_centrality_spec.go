@@ -0,0 +1,48 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type RunCentralityInput struct {
+	GraphName                  string   `json:"graphName" jsonschema:"description=Name of an existing projection (see create-gds-projection)"`
+	Algorithm                  string   `json:"algorithm,omitempty" jsonschema:"enum=pagerank,enum=degree,enum=betweenness,default=pagerank,description=Centrality measure to compute"`
+	RelationshipWeightProperty string   `json:"relationshipWeightProperty,omitempty" jsonschema:"description=Optional relationship property loaded into the projection to use as weight (e.g. amount)"`
+	ResultLabels               []string `json:"resultLabels,omitempty" jsonschema:"description=Only return nodes with one of these labels (e.g. Account). The score is still computed over the whole projection."`
+	Limit                      int      `json:"limit,omitempty" jsonschema:"default=25,description=Number of top-scoring nodes to return (1-1000)"`
+}
+
+// RunCentralitySpec returns the MCP tool specification for PageRank, degree and betweenness centrality
+func RunCentralitySpec() mcp.Tool {
+	return mcp.NewTool("run-centrality",
+		mcp.WithDescription(`Runs PageRank, degree or betweenness centrality on a GDS projection and returns the most central nodes. On transaction projections this surfaces hub accounts, money mule collectors and the brokers that connect otherwise separate groups.
+
+**ALGORITHMS:**
+- **pagerank:** influence based on the importance of the nodes pointing at a node. Finds collection points that many flows end up in.
+- **degree:** number of (optionally weighted) relationships. Finds accounts with unusually many counterparties.
+- **betweenness:** how often a node lies on shortest paths between others. Finds intermediaries that bridge groups.
+
+Returns the top-K nodes with their score. The database is not modified; use write-centrality to store the scores on the nodes.
+
+**REQUIRED WORKFLOW:**
+1. **Call create-gds-projection** with the account and transaction relationships (NATURAL orientation keeps money direction)
+2. **Run centrality**, filtering to the labels of interest with resultLabels
+3. **Call drop-gds-projection** when finished
+
+**Example:**
+{
+  "graphName": "transfers",
+  "algorithm": "pagerank",
+  "relationshipWeightProperty": "amount",
+  "resultLabels": ["Account"],
+  "limit": 10
+}
+
+**Returns:**
+- {node, score} per node, highest score first`),
+		mcp.WithInputSchema[RunCentralityInput](),
+		mcp.WithTitleAnnotation("Run Centrality"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,163 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	defaultMinCommunitySize = 2
+	defaultCommunityLimit   = 25
+	maxCommunityLimit       = 500
+	defaultMemberLimit      = 10
+	maxMemberLimit          = 100
+)
+
+// communityAlgorithm describes the procedure and yielded columns of a community detection algorithm
+type communityAlgorithm struct {
+	procedure   string
+	idColumn    string
+	writeYields string
+}
+
+var communityAlgorithms = map[string]communityAlgorithm{
+	"louvain": {
+		procedure:   "gds.louvain",
+		idColumn:    "communityId",
+		writeYields: "communityCount, modularity, nodePropertiesWritten, communityDistribution",
+	},
+	"wcc": {
+		procedure:   "gds.wcc",
+		idColumn:    "componentId",
+		writeYields: "componentCount as communityCount, nodePropertiesWritten, componentDistribution as communityDistribution",
+	},
+}
+
+// RunCommunityDetectionHandler returns a handler function for the run-community-detection tool
+func RunCommunityDetectionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleRunCommunityDetection(ctx, request, deps)
+	}
+}
+
+func handleRunCommunityDetection(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("run-community-detection"))
+
+	var args RunCommunityDetectionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateCommunityDetectionInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("running community detection",
+		"graphName", args.GraphName,
+		"algorithm", args.Algorithm)
+
+	config := map[string]any{}
+	if args.RelationshipWeightProperty != "" {
+		config["relationshipWeightProperty"] = args.RelationshipWeightProperty
+	}
+	params := map[string]any{
+		"graphName":        args.GraphName,
+		"config":           config,
+		"minCommunitySize": args.MinCommunitySize,
+		"limit":            args.Limit,
+		"memberLimit":      args.MemberLimit,
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, buildCommunityStreamQuery(communityAlgorithms[args.Algorithm]), params)
+	if err != nil {
+		slog.Error("failed to execute run-community-detection query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run %s on projection '%s': %v. Use list-gds-projections to check the projection exists", args.Algorithm, args.GraphName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format run-community-detection results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateCommunityDetectionInput checks the algorithm and limits and fills in defaults.
+func validateCommunityDetectionInput(args *RunCommunityDetectionInput) string {
+	if errMessage := validateCommunityAlgorithm(args.GraphName, &args.Algorithm); errMessage != "" {
+		return errMessage
+	}
+
+	if args.MinCommunitySize == 0 {
+		args.MinCommunitySize = defaultMinCommunitySize
+	}
+	if args.MinCommunitySize < 1 {
+		return "minCommunitySize must be at least 1"
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultCommunityLimit
+	}
+	if args.Limit < 1 || args.Limit > maxCommunityLimit {
+		return fmt.Sprintf("limit must be between 1 and %d", maxCommunityLimit)
+	}
+	if args.MemberLimit == 0 {
+		args.MemberLimit = defaultMemberLimit
+	}
+	if args.MemberLimit < 1 || args.MemberLimit > maxMemberLimit {
+		return fmt.Sprintf("memberLimit must be between 1 and %d", maxMemberLimit)
+	}
+
+	return ""
+}
+
+// validateCommunityAlgorithm requires a projection and defaults the algorithm to louvain
+func validateCommunityAlgorithm(graphName string, algorithm *string) string {
+	if graphName == "" {
+		return "graphName is required. Use create-gds-projection to create a projection first."
+	}
+	if *algorithm == "" {
+		*algorithm = "louvain"
+	}
+	*algorithm = strings.ToLower(*algorithm)
+	if _, ok := communityAlgorithms[*algorithm]; !ok {
+		return "algorithm must be louvain or wcc"
+	}
+	return ""
+}
+
+// buildCommunityStreamQuery groups streamed assignments into communities, largest first
+func buildCommunityStreamQuery(algorithm communityAlgorithm) string {
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString(fmt.Sprintf("CALL %s.stream($graphName, $config)\n", algorithm.procedure))
+	queryBuilder.WriteString(fmt.Sprintf("YIELD nodeId, %s\n", algorithm.idColumn))
+	queryBuilder.WriteString(fmt.Sprintf("WITH %s as communityId, collect(nodeId) as nodeIds\n", algorithm.idColumn))
+	queryBuilder.WriteString("WHERE size(nodeIds) >= $minCommunitySize\n")
+	queryBuilder.WriteString("RETURN communityId,\n")
+	queryBuilder.WriteString("       size(nodeIds) as size,\n")
+	queryBuilder.WriteString("       [nodeId IN nodeIds[0..$memberLimit] | gds.util.asNode(nodeId) {.*, labels: labels(gds.util.asNode(nodeId))}] as members\n")
+	queryBuilder.WriteString("ORDER BY size DESC, communityId ASC\n")
+	queryBuilder.WriteString("LIMIT $limit")
+
+	return queryBuilder.String()
+}
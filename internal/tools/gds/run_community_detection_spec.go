@@ -0,0 +1,47 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type RunCommunityDetectionInput struct {
+	GraphName                  string `json:"graphName" jsonschema:"description=Name of an existing projection (see create-gds-projection)"`
+	Algorithm                  string `json:"algorithm,omitempty" jsonschema:"enum=louvain,enum=wcc,default=louvain,description=louvain finds densely connected communities; wcc finds connected components (every node reachable from every other)"`
+	RelationshipWeightProperty string `json:"relationshipWeightProperty,omitempty" jsonschema:"description=Optional relationship property loaded into the projection to use as weight"`
+	MinCommunitySize           int    `json:"minCommunitySize,omitempty" jsonschema:"default=2,description=Ignore communities smaller than this"`
+	Limit                      int    `json:"limit,omitempty" jsonschema:"default=25,description=Maximum number of communities to return, largest first (1-500)"`
+	MemberLimit                int    `json:"memberLimit,omitempty" jsonschema:"default=10,description=Maximum number of members returned per community (1-100)"`
+}
+
+// RunCommunityDetectionSpec returns the MCP tool specification for Louvain/WCC community detection
+func RunCommunityDetectionSpec() mcp.Tool {
+	return mcp.NewTool("run-community-detection",
+		mcp.WithDescription(`Runs Louvain or Weakly Connected Components (WCC) on a GDS projection to find fraud rings: groups of customers, accounts and identifiers that are tightly linked to each other.
+
+**ALGORITHMS:**
+- **wcc:** connected components. Every customer sharing any identifier, directly or transitively, lands in the same component. Best first pass for shared-PII rings.
+- **louvain:** modularity-based communities. Splits large components into densely connected groups. Supports relationshipWeightProperty.
+
+Returns communities with their size and members, largest first. The database is not modified; use write-community-detection to store the communities on the nodes.
+
+**REQUIRED WORKFLOW:**
+1. **Call create-gds-projection** with the customer and identifier labels, using UNDIRECTED relationships
+2. **Run community detection** to review the rings
+3. **Optionally call write-community-detection** to persist communityId for follow-up queries
+4. **Call drop-gds-projection** when finished
+
+**Example:**
+{
+  "graphName": "shared-pii",
+  "algorithm": "wcc",
+  "minCommunitySize": 3
+}
+
+**Returns:**
+- {communityId, size, members} per community, largest first`),
+		mcp.WithInputSchema[RunCommunityDetectionInput](),
+		mcp.WithTitleAnnotation("Run Community Detection"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,155 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const (
+	defaultSimilarityCutoff = 0.5
+	defaultSimilarityTopK   = 10
+	defaultDegreeCutoff     = 1
+	defaultSimilarityLimit  = 50
+	maxSimilarityLimit      = 1000
+)
+
+var similarityMetrics = map[string]string{
+	"jaccard": "JACCARD",
+	"overlap": "OVERLAP",
+}
+
+// RunNodeSimilarityHandler returns a handler function for the run-node-similarity tool
+func RunNodeSimilarityHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleRunNodeSimilarity(ctx, request, deps)
+	}
+}
+
+func handleRunNodeSimilarity(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("run-node-similarity"))
+
+	var args RunNodeSimilarityInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateNodeSimilarityInput(&args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	slog.Info("running node similarity",
+		"graphName", args.GraphName,
+		"similarityMetric", args.SimilarityMetric,
+		"similarityCutoff", args.SimilarityCutoff)
+
+	params := map[string]any{
+		"graphName": args.GraphName,
+		"config": map[string]any{
+			"similarityMetric": similarityMetrics[args.SimilarityMetric],
+			"similarityCutoff": args.SimilarityCutoff,
+			"topK":             args.TopK,
+			"degreeCutoff":     args.DegreeCutoff,
+		},
+		"limit": args.Limit,
+	}
+	if len(args.ResultLabels) > 0 {
+		params["resultLabels"] = args.ResultLabels
+	}
+
+	records, err := deps.DBService.ExecuteReadQuery(ctx, buildNodeSimilarityQuery(len(args.ResultLabels) > 0), params)
+	if err != nil {
+		slog.Error("failed to execute run-node-similarity query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run node similarity on projection '%s': %v. Use list-gds-projections to check the projection exists", args.GraphName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format run-node-similarity results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// validateNodeSimilarityInput checks the metric and thresholds and fills in defaults.
+func validateNodeSimilarityInput(args *RunNodeSimilarityInput) string {
+	if args.GraphName == "" {
+		return "graphName is required. Use create-gds-projection to create a projection first."
+	}
+
+	if args.SimilarityMetric == "" {
+		args.SimilarityMetric = "jaccard"
+	}
+	args.SimilarityMetric = strings.ToLower(args.SimilarityMetric)
+	if _, ok := similarityMetrics[args.SimilarityMetric]; !ok {
+		return "similarityMetric must be jaccard or overlap"
+	}
+
+	if args.SimilarityCutoff == 0 {
+		args.SimilarityCutoff = defaultSimilarityCutoff
+	}
+	if args.SimilarityCutoff < 0 || args.SimilarityCutoff > 1 {
+		return "similarityCutoff must be between 0 and 1"
+	}
+	if args.TopK == 0 {
+		args.TopK = defaultSimilarityTopK
+	}
+	if args.TopK < 1 {
+		return "topK must be at least 1"
+	}
+	if args.DegreeCutoff == 0 {
+		args.DegreeCutoff = defaultDegreeCutoff
+	}
+	if args.DegreeCutoff < 1 {
+		return "degreeCutoff must be at least 1"
+	}
+	if args.Limit == 0 {
+		args.Limit = defaultSimilarityLimit
+	}
+	if args.Limit < 1 || args.Limit > maxSimilarityLimit {
+		return fmt.Sprintf("limit must be between 1 and %d", maxSimilarityLimit)
+	}
+
+	return ""
+}
+
+// buildNodeSimilarityQuery returns each similar pair once, most similar first
+func buildNodeSimilarityQuery(filterLabels bool) string {
+	var queryBuilder strings.Builder
+
+	queryBuilder.WriteString("CALL gds.nodeSimilarity.stream($graphName, $config)\n")
+	queryBuilder.WriteString("YIELD node1, node2, similarity\n")
+	// Similarity is symmetric, so a pair can be streamed in both directions
+	queryBuilder.WriteString("WITH CASE WHEN node1 < node2 THEN [node1, node2] ELSE [node2, node1] END as pair, max(similarity) as similarity\n")
+	queryBuilder.WriteString("WITH gds.util.asNode(pair[0]) as entity1, gds.util.asNode(pair[1]) as entity2, similarity\n")
+	if filterLabels {
+		queryBuilder.WriteString("WHERE any(label IN labels(entity1) WHERE label IN $resultLabels)\n")
+		queryBuilder.WriteString("  AND any(label IN labels(entity2) WHERE label IN $resultLabels)\n")
+	}
+	queryBuilder.WriteString("RETURN entity1 {.*, labels: labels(entity1)} as entity1,\n")
+	queryBuilder.WriteString("       entity2 {.*, labels: labels(entity2)} as entity2,\n")
+	queryBuilder.WriteString("       similarity\n")
+	queryBuilder.WriteString("ORDER BY similarity DESC\n")
+	queryBuilder.WriteString("LIMIT $limit")
+
+	return queryBuilder.String()
+}
@@ -0,0 +1,47 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type RunNodeSimilarityInput struct {
+	GraphName        string   `json:"graphName" jsonschema:"description=Name of an existing projection linking entities to their PII nodes (see create-gds-projection)"`
+	SimilarityMetric string   `json:"similarityMetric,omitempty" jsonschema:"enum=jaccard,enum=overlap,default=jaccard,description=jaccard scores shared neighbours against all neighbours; overlap scores them against the smaller neighbourhood so a subset of another identity scores 1.0"`
+	SimilarityCutoff float64  `json:"similarityCutoff,omitempty" jsonschema:"default=0.5,description=Minimum similarity for a pair to be returned (0-1)"`
+	TopK             int      `json:"topK,omitempty" jsonschema:"default=10,description=Maximum number of similar entities considered per entity"`
+	DegreeCutoff     int      `json:"degreeCutoff,omitempty" jsonschema:"default=1,description=Ignore entities with fewer PII links than this"`
+	ResultLabels     []string `json:"resultLabels,omitempty" jsonschema:"description=Only return pairs where both entities have one of these labels (e.g. Customer)"`
+	Limit            int      `json:"limit,omitempty" jsonschema:"default=50,description=Maximum number of pairs to return, most similar first (1-1000)"`
+}
+
+// RunNodeSimilaritySpec returns the MCP tool specification for GDS node similarity
+func RunNodeSimilaritySpec() mcp.Tool {
+	return mcp.NewTool("run-node-similarity",
+		mcp.WithDescription(`Scores how similar entities are based on the PII they share (gds.nodeSimilarity). Returns pairs of customers or accounts whose neighbourhoods of emails, phones, addresses, devices and SSNs overlap. Complements detect-synthetic-identity: instead of fixed rules, each pair gets a graded linkage score.
+
+**METRICS:**
+- **jaccard:** shared PII / all PII of both entities. High only when the identities are near duplicates.
+- **overlap:** shared PII / PII of the smaller entity. Scores 1.0 when one identity is built entirely from another's details, a common synthetic identity pattern.
+
+**REQUIRED WORKFLOW:**
+1. **Call get-schema** to find the relationships from entities to PII nodes
+2. **Call create-gds-projection** with the entity and PII labels and those relationships in NATURAL orientation (entity -> PII)
+3. **Run node similarity**, using resultLabels to keep entity pairs only
+4. **Call drop-gds-projection** when finished
+
+**Example:**
+{
+  "graphName": "customer-pii",
+  "similarityMetric": "overlap",
+  "similarityCutoff": 0.6,
+  "resultLabels": ["Customer"]
+}
+
+**Returns:**
+- {entity1, entity2, similarity} per pair, most similar first. Each pair is returned once.`),
+		mcp.WithInputSchema[RunNodeSimilarityInput](),
+		mcp.WithTitleAnnotation("Run Node Similarity"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,81 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// WriteCentralityHandler returns a handler function for the write-centrality tool
+func WriteCentralityHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleWriteCentrality(ctx, request, deps)
+	}
+}
+
+func handleWriteCentrality(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("write-centrality"))
+
+	var args WriteCentralityInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateCentralityAlgorithm(args.GraphName, &args.Algorithm); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	algorithm := centralityAlgorithms[args.Algorithm]
+	if args.WriteProperty == "" {
+		args.WriteProperty = algorithm.writeProperty
+	}
+
+	slog.Info("writing centrality",
+		"graphName", args.GraphName,
+		"algorithm", args.Algorithm,
+		"writeProperty", args.WriteProperty)
+
+	config := map[string]any{"writeProperty": args.WriteProperty}
+	if args.RelationshipWeightProperty != "" {
+		config["relationshipWeightProperty"] = args.RelationshipWeightProperty
+	}
+	params := map[string]any{
+		"graphName": args.GraphName,
+		"config":    config,
+	}
+
+	records, err := deps.DBService.ExecuteWriteQuery(ctx, buildCentralityWriteQuery(algorithm), params)
+	if err != nil {
+		slog.Error("failed to execute write-centrality query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run %s on projection '%s': %v. Use list-gds-projections to check the projection exists", args.Algorithm, args.GraphName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format write-centrality results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// buildCentralityWriteQuery writes the score of every node and returns the summary
+func buildCentralityWriteQuery(algorithm centralityAlgorithm) string {
+	return fmt.Sprintf("CALL %s.write($graphName, $config)\nYIELD nodePropertiesWritten, centralityDistribution\nRETURN *", algorithm.procedure)
+}
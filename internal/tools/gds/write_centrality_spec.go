@@ -0,0 +1,40 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type WriteCentralityInput struct {
+	GraphName                  string `json:"graphName" jsonschema:"description=Name of an existing projection (see create-gds-projection)"`
+	Algorithm                  string `json:"algorithm,omitempty" jsonschema:"enum=pagerank,enum=degree,enum=betweenness,default=pagerank,description=Centrality measure to compute"`
+	WriteProperty              string `json:"writeProperty,omitempty" jsonschema:"description=Node property the score is written to. Defaults to pageRank, degree or betweenness."`
+	RelationshipWeightProperty string `json:"relationshipWeightProperty,omitempty" jsonschema:"description=Optional relationship property loaded into the projection to use as weight (e.g. amount)"`
+}
+
+// WriteCentralitySpec returns the MCP tool specification for writing PageRank, degree and betweenness scores to the database
+func WriteCentralitySpec() mcp.Tool {
+	return mcp.NewTool("write-centrality",
+		mcp.WithDescription(`Runs PageRank, degree or betweenness centrality on a GDS projection and stores the score of every node in the database as writeProperty, so hub and bridging accounts can be queried with Cypher.
+Use run-centrality first to review the top-scoring nodes without modifying the database.
+
+**REQUIRED WORKFLOW:**
+1. **Call create-gds-projection** with the account and transaction relationships (NATURAL orientation keeps money direction)
+2. **Call run-centrality** to review the most central nodes
+3. **Write the scores** with the same algorithm
+4. **Call drop-gds-projection** when finished
+
+**Example:**
+{
+  "graphName": "transfers",
+  "algorithm": "pagerank",
+  "relationshipWeightProperty": "amount"
+}
+
+**Returns:**
+- nodePropertiesWritten and the score distribution`),
+		mcp.WithInputSchema[WriteCentralityInput](),
+		mcp.WithTitleAnnotation("Write Centrality"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
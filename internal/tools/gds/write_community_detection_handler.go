@@ -0,0 +1,82 @@
+package gds
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+const defaultCommunityWriteProperty = "communityId"
+
+// WriteCommunityDetectionHandler returns a handler function for the write-community-detection tool
+func WriteCommunityDetectionHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleWriteCommunityDetection(ctx, request, deps)
+	}
+}
+
+func handleWriteCommunityDetection(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "Database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("write-community-detection"))
+
+	var args WriteCommunityDetectionInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateCommunityAlgorithm(args.GraphName, &args.Algorithm); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+	if args.WriteProperty == "" {
+		args.WriteProperty = defaultCommunityWriteProperty
+	}
+
+	slog.Info("writing communities",
+		"graphName", args.GraphName,
+		"algorithm", args.Algorithm,
+		"writeProperty", args.WriteProperty)
+
+	config := map[string]any{"writeProperty": args.WriteProperty}
+	if args.RelationshipWeightProperty != "" {
+		config["relationshipWeightProperty"] = args.RelationshipWeightProperty
+	}
+	params := map[string]any{
+		"graphName": args.GraphName,
+		"config":    config,
+	}
+
+	records, err := deps.DBService.ExecuteWriteQuery(ctx, buildCommunityWriteQuery(communityAlgorithms[args.Algorithm]), params)
+	if err != nil {
+		slog.Error("failed to execute write-community-detection query", "error", err)
+		return mcp.NewToolResultError(fmt.Sprintf("failed to run %s on projection '%s': %v. Use list-gds-projections to check the projection exists", args.Algorithm, args.GraphName, err)), nil
+	}
+
+	response, err := deps.DBService.Neo4jRecordsToJSON(ctx, records)
+	if err != nil {
+		slog.Error("failed to format write-community-detection results to JSON", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(response), nil
+}
+
+// buildCommunityWriteQuery writes the community of every node and returns the summary
+func buildCommunityWriteQuery(algorithm communityAlgorithm) string {
+	return fmt.Sprintf("CALL %s.write($graphName, $config)\nYIELD %s\nRETURN *", algorithm.procedure, algorithm.writeYields)
+}
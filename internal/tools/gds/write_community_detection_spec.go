@@ -0,0 +1,40 @@
+package gds
+
+import "github.com/mark3labs/mcp-go/mcp"
+
+type WriteCommunityDetectionInput struct {
+	GraphName                  string `json:"graphName" jsonschema:"description=Name of an existing projection (see create-gds-projection)"`
+	Algorithm                  string `json:"algorithm,omitempty" jsonschema:"enum=louvain,enum=wcc,default=louvain,description=louvain finds densely connected communities; wcc finds connected components (every node reachable from every other)"`
+	WriteProperty              string `json:"writeProperty,omitempty" jsonschema:"default=communityId,description=Node property the community is written to"`
+	RelationshipWeightProperty string `json:"relationshipWeightProperty,omitempty" jsonschema:"description=Optional relationship property loaded into the projection to use as weight"`
+}
+
+// WriteCommunityDetectionSpec returns the MCP tool specification for writing Louvain/WCC communities to the database
+func WriteCommunityDetectionSpec() mcp.Tool {
+	return mcp.NewTool("write-community-detection",
+		mcp.WithDescription(`Runs Louvain or Weakly Connected Components (WCC) on a GDS projection and stores the community of every node in the database as writeProperty (default communityId), so rings can be queried with Cypher.
+Use run-community-detection first to review the communities without modifying the database.
+
+**REQUIRED WORKFLOW:**
+1. **Call create-gds-projection** with the customer and identifier labels, using UNDIRECTED relationships
+2. **Call run-community-detection** to review the rings
+3. **Write the communities** with the same algorithm
+4. **Call drop-gds-projection** when finished
+
+**Example:**
+{
+  "graphName": "shared-pii",
+  "algorithm": "wcc",
+  "writeProperty": "ringId"
+}
+
+**Returns:**
+- community count, nodePropertiesWritten and the community size distribution`),
+		mcp.WithInputSchema[WriteCommunityDetectionInput](),
+		mcp.WithTitleAnnotation("Write Community Detection"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GDS procedure families reported by GDSCapabilities
+const (
+	GDSFamilyCommunity   = "community"
+	GDSFamilyCentrality  = "centrality"
+	GDSFamilySimilarity  = "similarity"
+	GDSFamilyEmbeddings  = "embeddings"
+	GDSFamilyPathFinding = "pathFinding"
+	GDSFamilyML          = "ml"
+)
+
+// gdsAlgorithmFamilies maps a GDS algorithm (the first segment of the procedure name after
+// the gds and tier prefixes) to its family. Algorithms not listed here are not classified.
+var gdsAlgorithmFamilies = map[string]string{
+	"louvain":                    GDSFamilyCommunity,
+	"leiden":                     GDSFamilyCommunity,
+	"wcc":                        GDSFamilyCommunity,
+	"scc":                        GDSFamilyCommunity,
+	"labelPropagation":           GDSFamilyCommunity,
+	"triangleCount":              GDSFamilyCommunity,
+	"localClusteringCoefficient": GDSFamilyCommunity,
+	"modularityOptimization":     GDSFamilyCommunity,
+	"kcore":                      GDSFamilyCommunity,
+	"k1coloring":                 GDSFamilyCommunity,
+	"kmeans":                     GDSFamilyCommunity,
+	"pageRank":                   GDSFamilyCentrality,
+	"articleRank":                GDSFamilyCentrality,
+	"eigenvector":                GDSFamilyCentrality,
+	"degree":                     GDSFamilyCentrality,
+	"betweenness":                GDSFamilyCentrality,
+	"closeness":                  GDSFamilyCentrality,
+	"influenceMaximization":      GDSFamilyCentrality,
+	"nodeSimilarity":             GDSFamilySimilarity,
+	"knn":                        GDSFamilySimilarity,
+	"fastRP":                     GDSFamilyEmbeddings,
+	"node2vec":                   GDSFamilyEmbeddings,
+	"graphSage":                  GDSFamilyEmbeddings,
+	"hashgnn":                    GDSFamilyEmbeddings,
+	"shortestPath":               GDSFamilyPathFinding,
+	"allShortestPaths":           GDSFamilyPathFinding,
+	"bfs":                        GDSFamilyPathFinding,
+	"dfs":                        GDSFamilyPathFinding,
+	"randomWalk":                 GDSFamilyPathFinding,
+	"spanningTree":               GDSFamilyPathFinding,
+	"pipeline":                   GDSFamilyML,
+	"model":                      GDSFamilyML,
+}
+
+// GDSCapabilities describes the Graph Data Science library detected at startup,
+// so tools can adapt to the installed version and the procedures it provides.
+type GDSCapabilities struct {
+	Installed  bool                `json:"installed"`
+	Version    string              `json:"version,omitempty"`
+	Families   map[string][]string `json:"families,omitempty"`
+	procedures map[string]bool
+}
+
+// NewGDSCapabilities builds the capabilities of an installed GDS library from its version
+// and the procedure names returned by gds.list()
+func NewGDSCapabilities(version string, procedures []string) *GDSCapabilities {
+	capabilities := &GDSCapabilities{
+		Installed:  true,
+		Version:    version,
+		Families:   make(map[string][]string),
+		procedures: make(map[string]bool, len(procedures)),
+	}
+
+	seen := make(map[string]bool)
+	for _, procedure := range procedures {
+		capabilities.procedures[procedure] = true
+
+		algorithm := gdsAlgorithm(procedure)
+		family, ok := gdsAlgorithmFamilies[algorithm]
+		if !ok || seen[algorithm] {
+			continue
+		}
+		seen[algorithm] = true
+		capabilities.Families[family] = append(capabilities.Families[family], algorithm)
+	}
+	for _, algorithms := range capabilities.Families {
+		sort.Strings(algorithms)
+	}
+
+	return capabilities
+}
+
+// HasProcedure reports whether the named procedure (e.g. gds.systemMonitor) is available
+func (c *GDSCapabilities) HasProcedure(name string) bool {
+	return c != nil && c.procedures[name]
+}
+
+// MissingProcedure reports whether the procedure is known to be unavailable.
+// It is false when the procedure list could not be read, so callers should still try the call.
+func (c *GDSCapabilities) MissingProcedure(name string) bool {
+	return c != nil && len(c.procedures) > 0 && !c.procedures[name]
+}
+
+// HasFamily reports whether at least one algorithm of the family is available
+func (c *GDSCapabilities) HasFamily(family string) bool {
+	return c != nil && len(c.Families[family]) > 0
+}
+
+// MajorVersion returns the major version of GDS, or 0 when it is unknown
+func (c *GDSCapabilities) MajorVersion() int {
+	if c == nil {
+		return 0
+	}
+	major, _, _ := strings.Cut(c.Version, ".")
+	version, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// gdsAlgorithm returns the algorithm segment of a GDS procedure name,
+// e.g. gds.beta.pipeline.linkPrediction.train -> pipeline
+func gdsAlgorithm(procedure string) string {
+	name := strings.TrimPrefix(procedure, "gds.")
+	for _, tier := range []string{"alpha.", "beta."} {
+		name = strings.TrimPrefix(name, tier)
+	}
+	algorithm, _, _ := strings.Cut(name, ".")
+	return algorithm
+}
@@ -0,0 +1,50 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGDSCapabilities(t *testing.T) {
+	capabilities := tools.NewGDSCapabilities("2.6.3", []string{
+		"gds.louvain.stream",
+		"gds.louvain.write",
+		"gds.wcc.stream",
+		"gds.pageRank.stream",
+		"gds.nodeSimilarity.stream",
+		"gds.fastRP.mutate",
+		"gds.beta.pipeline.linkPrediction.create",
+		"gds.alpha.scc.stream",
+		"gds.graph.project",
+		"gds.systemMonitor",
+	})
+
+	assert.True(t, capabilities.Installed)
+	assert.Equal(t, 2, capabilities.MajorVersion())
+	assert.Equal(t, []string{"louvain", "scc", "wcc"}, capabilities.Families[tools.GDSFamilyCommunity])
+	assert.Equal(t, []string{"pageRank"}, capabilities.Families[tools.GDSFamilyCentrality])
+	assert.Equal(t, []string{"pipeline"}, capabilities.Families[tools.GDSFamilyML])
+	assert.True(t, capabilities.HasFamily(tools.GDSFamilyEmbeddings))
+	assert.False(t, capabilities.HasFamily(tools.GDSFamilyPathFinding))
+	assert.True(t, capabilities.HasProcedure("gds.systemMonitor"))
+	assert.False(t, capabilities.HasProcedure("gds.knn.stream"))
+	assert.True(t, capabilities.MissingProcedure("gds.knn.stream"))
+}
+
+func TestGDSCapabilities_UnknownProcedures(t *testing.T) {
+	capabilities := tools.NewGDSCapabilities("2.6.3", nil)
+
+	assert.False(t, capabilities.HasProcedure("gds.systemMonitor"))
+	assert.False(t, capabilities.MissingProcedure("gds.systemMonitor"))
+}
+
+func TestGDSCapabilities_NotDetected(t *testing.T) {
+	var capabilities *tools.GDSCapabilities
+
+	assert.False(t, capabilities.HasProcedure("gds.louvain.stream"))
+	assert.False(t, capabilities.HasFamily(tools.GDSFamilyCommunity))
+	assert.False(t, capabilities.MissingProcedure("gds.louvain.stream"))
+	assert.Equal(t, 0, capabilities.MajorVersion())
+}
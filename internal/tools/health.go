@@ -0,0 +1,114 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Health check statuses
+const (
+	HealthOK          = "ok"
+	HealthError       = "error"
+	HealthSkipped     = "skipped"     // The check cannot run in this configuration
+	HealthUnavailable = "unavailable" // An optional dependency is missing; the server still works without it
+)
+
+// HealthReport is the outcome of the server health checks, shared by the health-check tool
+// and the HTTP readiness endpoint
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+	Tools  *ToolsHealth  `json:"tools,omitempty"`
+}
+
+// HealthCheck is the outcome of one check
+type HealthCheck struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Message    string `json:"message,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// ToolsHealth reports the tools loaded on this server
+type ToolsHealth struct {
+	Enabled    int            `json:"enabled"`
+	Categories map[string]int `json:"categories"`
+}
+
+// HealthOptions selects what CheckHealth verifies
+type HealthOptions struct {
+	QueryDatabase bool // Query the database; callers without per-request credentials cannot run queries
+	AllTools      bool // Count every enabled tool instead of the tools the caller may use
+}
+
+// Healthy reports whether every check passed or was not applicable
+func (r HealthReport) Healthy() bool {
+	return r.Status == HealthOK
+}
+
+// CheckHealth verifies driver connectivity, database reachability, GDS availability and the tool load status.
+// The driver is only checked when the server holds its own Neo4j credentials, and the database only when
+// opts.QueryDatabase is set.
+func CheckHealth(ctx context.Context, deps *ToolDependencies, opts HealthOptions) HealthReport {
+	report := HealthReport{Status: HealthOK}
+
+	report.add(timedCheck("driver", func() (string, string) {
+		if !deps.ServiceCredentials {
+			return HealthSkipped, "Neo4j credentials are provided per request"
+		}
+		if err := deps.DBService.VerifyConnectivity(ctx); err != nil {
+			return HealthError, err.Error()
+		}
+		return HealthOK, ""
+	}))
+
+	report.add(timedCheck("database", func() (string, string) {
+		if !opts.QueryDatabase {
+			return HealthSkipped, "Neo4j credentials are provided per request"
+		}
+		if _, err := deps.DBService.ExecuteReadQuery(ctx, "RETURN 1 AS ok", nil); err != nil {
+			return HealthError, err.Error()
+		}
+		return HealthOK, fmt.Sprintf("database %q is reachable", deps.DBService.GetDatabaseName(ctx))
+	}))
+
+	report.add(timedCheck("gds", func() (string, string) {
+		if deps.GDSCapabilities == nil || !deps.GDSCapabilities.Installed {
+			return HealthUnavailable, "Graph Data Science is not installed, GDS tools are disabled"
+		}
+		return HealthOK, "Graph Data Science " + deps.GDSCapabilities.Version
+	}))
+
+	categories := deps.ToolCatalog.Categories(ctx)
+	if opts.AllTools {
+		categories = deps.ToolCatalog.AllCategories()
+	}
+	tools := &ToolsHealth{Categories: make(map[string]int)}
+	for _, category := range categories {
+		tools.Categories[category.Name] = len(category.Tools)
+		tools.Enabled += len(category.Tools)
+	}
+	report.Tools = tools
+	report.add(timedCheck("tools", func() (string, string) {
+		if tools.Enabled == 0 {
+			return HealthError, "no tools are enabled"
+		}
+		return HealthOK, fmt.Sprintf("%d tools enabled", tools.Enabled)
+	}))
+
+	return report
+}
+
+func (r *HealthReport) add(check HealthCheck) {
+	r.Checks = append(r.Checks, check)
+	if check.Status == HealthError {
+		r.Status = HealthError
+	}
+}
+
+func timedCheck(name string, check func() (status, message string)) HealthCheck {
+	start := time.Now()
+	status, message := check()
+	return HealthCheck{Name: name, Status: status, Message: message, DurationMs: time.Since(start).Milliseconds()}
+}
@@ -0,0 +1,254 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// MaxSessionMappings caps the mappings a session can keep in memory
+const MaxSessionMappings = 100
+
+// Scopes a saved mapping can be stored in
+const (
+	MappingScopeSession = "session"
+	MappingScopeFile    = "file"
+)
+
+// SchemaMapping is a confirmed set of schema-aware tool arguments saved under a name.
+// The arguments are kept as JSON, so each tool binds them to its own types.
+type SchemaMapping struct {
+	Name              string          `json:"name"`
+	EntityConfig      json.RawMessage `json:"entityConfig,omitempty"`
+	AttributeMappings json.RawMessage `json:"attributeMappings,omitempty"`
+	PIIRelationships  json.RawMessage `json:"piiRelationships,omitempty"`
+	Scope             string          `json:"scope,omitempty"` // MappingScopeSession or MappingScopeFile, set when listed
+	SavedAt           time.Time       `json:"savedAt"`
+}
+
+// arguments returns the tool arguments held by the mapping, keyed by argument name
+func (m SchemaMapping) arguments() map[string]json.RawMessage {
+	arguments := map[string]json.RawMessage{
+		"entityConfig":      m.EntityConfig,
+		"attributeMappings": m.AttributeMappings,
+		"piiRelationships":  m.PIIRelationships,
+	}
+	maps.DeleteFunc(arguments, func(_ string, value json.RawMessage) bool {
+		return len(value) == 0
+	})
+	return arguments
+}
+
+// MappingStore keeps saved schema mappings so tools can be called with a mappingName instead of the full
+// mapping arguments. Mappings are kept in memory per MCP session; mappings saved to the optional file are
+// shared by all sessions and survive restarts. A session's own mappings shadow file mappings of the same name.
+// A nil store holds no mappings.
+type MappingStore struct {
+	path     string
+	mu       sync.Mutex
+	sessions map[string]map[string]SchemaMapping
+	file     map[string]SchemaMapping
+}
+
+// NewMappingStore creates a mapping store persisting to path. An empty path keeps mappings in memory only.
+// Mappings already in the file are loaded; an unreadable file is logged and replaced on the next save.
+func NewMappingStore(path string) *MappingStore {
+	store := &MappingStore{
+		path:     path,
+		sessions: make(map[string]map[string]SchemaMapping),
+		file:     make(map[string]SchemaMapping),
+	}
+	if path == "" {
+		return store
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store
+	}
+	var mappings []SchemaMapping
+	if err == nil {
+		err = json.Unmarshal(content, &mappings)
+	}
+	if err != nil {
+		slog.Warn("ignoring unreadable mapping store file", "path", path, "error", err)
+		return store
+	}
+	for _, mapping := range mappings {
+		store.file[mapping.Name] = mapping
+	}
+	return store
+}
+
+// Persistent reports whether mappings can be saved to a file
+func (s *MappingStore) Persistent() bool {
+	return s != nil && s.path != ""
+}
+
+// Save stores a mapping for the calling session, replacing any mapping of the same name.
+// With persist, the mapping is written to the file instead, so every session can use it.
+func (s *MappingStore) Save(ctx context.Context, mapping SchemaMapping, persist bool) error {
+	if s == nil {
+		return errors.New("mapping store is not initialized")
+	}
+	if persist && s.path == "" {
+		return errors.New("mappings cannot be persisted: no mapping store file is configured (NEO4J_MAPPING_STORE_FILE)")
+	}
+	mapping.Scope = ""
+	mapping.SavedAt = time.Now().UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if persist {
+		file := maps.Clone(s.file)
+		file[mapping.Name] = mapping
+		if err := s.write(file); err != nil {
+			return err
+		}
+		s.file = file
+		return nil
+	}
+
+	session := sessionKey(ctx)
+	mappings, ok := s.sessions[session]
+	if !ok {
+		mappings = make(map[string]SchemaMapping)
+		s.sessions[session] = mappings
+	}
+	if _, exists := mappings[mapping.Name]; !exists && len(mappings) >= MaxSessionMappings {
+		return fmt.Errorf("a session can keep at most %d mappings; reuse a name to replace one", MaxSessionMappings)
+	}
+	mappings[mapping.Name] = mapping
+	return nil
+}
+
+// Get returns the named mapping of the calling session, or failing that the one saved to the file
+func (s *MappingStore) Get(ctx context.Context, name string) (SchemaMapping, bool) {
+	if s == nil {
+		return SchemaMapping{}, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if mapping, ok := s.sessions[sessionKey(ctx)][name]; ok {
+		mapping.Scope = MappingScopeSession
+		return mapping, true
+	}
+	if mapping, ok := s.file[name]; ok {
+		mapping.Scope = MappingScopeFile
+		return mapping, true
+	}
+	return SchemaMapping{}, false
+}
+
+// List returns the mappings visible to the calling session, sorted by name
+func (s *MappingStore) List(ctx context.Context) []SchemaMapping {
+	if s == nil {
+		return []SchemaMapping{}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	visible := make(map[string]SchemaMapping, len(s.file))
+	for name, mapping := range s.file {
+		mapping.Scope = MappingScopeFile
+		visible[name] = mapping
+	}
+	for name, mapping := range s.sessions[sessionKey(ctx)] {
+		mapping.Scope = MappingScopeSession
+		visible[name] = mapping
+	}
+
+	mappings := make([]SchemaMapping, 0, len(visible))
+	for _, name := range slices.Sorted(maps.Keys(visible)) {
+		mappings = append(mappings, visible[name])
+	}
+	return mappings
+}
+
+// Apply fills the entityConfig, attributeMappings and piiRelationships arguments of a request from the mapping
+// named by its mappingName argument. Arguments passed explicitly are kept, so a call can override part of a mapping.
+// Requests without a mappingName are left unchanged.
+func (s *MappingStore) Apply(ctx context.Context, request *mcp.CallToolRequest) error {
+	arguments := request.GetArguments()
+	if raw, ok := request.Params.Arguments.(json.RawMessage); ok {
+		if err := json.Unmarshal(raw, &arguments); err != nil {
+			return fmt.Errorf("failed to read arguments: %w", err)
+		}
+	}
+
+	name, _ := arguments["mappingName"].(string)
+	if name == "" {
+		return nil
+	}
+
+	mapping, ok := s.Get(ctx, name)
+	if !ok {
+		return fmt.Errorf("mapping '%s' not found. Save it with save-schema-mapping, or call list-schema-mappings for the saved names", name)
+	}
+
+	merged := maps.Clone(arguments)
+	for argument, value := range mapping.arguments() {
+		if _, set := merged[argument]; !set {
+			merged[argument] = value
+		}
+	}
+	request.Params.Arguments = merged
+	return nil
+}
+
+// write replaces the mapping file with mappings, through a temporary file so a failed write keeps the old one
+func (s *MappingStore) write(mappings map[string]SchemaMapping) error {
+	list := make([]SchemaMapping, 0, len(mappings))
+	for _, name := range slices.Sorted(maps.Keys(mappings)) {
+		list = append(list, mappings[name])
+	}
+	content, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to serialize mappings: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create mapping store directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write mapping store file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write mapping store file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write mapping store file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write mapping store file: %w", err)
+	}
+	return nil
+}
+
+// sessionKey identifies the MCP session of a call; calls outside a session share the empty key
+func sessionKey(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is a client session identified only by its ID
+type testSession string
+
+func (s testSession) Initialize()       {}
+func (s testSession) Initialized() bool { return true }
+func (s testSession) SessionID() string { return string(s) }
+func (s testSession) NotificationChannel() chan<- mcp.JSONRPCNotification {
+	return make(chan mcp.JSONRPCNotification)
+}
+
+// sessionContext returns a context carrying a client session with the given ID
+func sessionContext(id string) context.Context {
+	return server.NewMCPServer("test", "0").WithContext(context.Background(), testSession(id))
+}
+
+func customerMapping(name string) tools.SchemaMapping {
+	return tools.SchemaMapping{
+		Name:              name,
+		EntityConfig:      json.RawMessage(`{"nodeLabel":"Customer","idProperty":"customerId"}`),
+		AttributeMappings: json.RawMessage(`[{"relationshipType":"HAS_EMAIL","targetLabel":"Email","attributeCategory":"contact_information"}]`),
+	}
+}
+
+func TestMappingStore(t *testing.T) {
+	t.Run("session mappings are only visible to their session", func(t *testing.T) {
+		store := tools.NewMappingStore("")
+		alice, bob := sessionContext("alice"), sessionContext("bob")
+
+		require.NoError(t, store.Save(alice, customerMapping("retail"), false))
+
+		mapping, ok := store.Get(alice, "retail")
+		require.True(t, ok)
+		assert.Equal(t, tools.MappingScopeSession, mapping.Scope)
+		assert.JSONEq(t, `{"nodeLabel":"Customer","idProperty":"customerId"}`, string(mapping.EntityConfig))
+
+		_, ok = store.Get(bob, "retail")
+		assert.False(t, ok)
+		assert.Empty(t, store.List(bob))
+	})
+
+	t.Run("persisted mappings are shared and reloaded from the file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "mappings", "store.json")
+		store := tools.NewMappingStore(path)
+		require.True(t, store.Persistent())
+
+		require.NoError(t, store.Save(sessionContext("alice"), customerMapping("retail"), true))
+
+		reloaded := tools.NewMappingStore(path)
+		mapping, ok := reloaded.Get(sessionContext("bob"), "retail")
+		require.True(t, ok)
+		assert.Equal(t, tools.MappingScopeFile, mapping.Scope)
+		assert.JSONEq(t, `[{"relationshipType":"HAS_EMAIL","targetLabel":"Email","attributeCategory":"contact_information"}]`, string(mapping.AttributeMappings))
+	})
+
+	t.Run("session mappings shadow persisted ones in listings", func(t *testing.T) {
+		store := tools.NewMappingStore(filepath.Join(t.TempDir(), "store.json"))
+		ctx := sessionContext("alice")
+
+		require.NoError(t, store.Save(ctx, customerMapping("retail"), true))
+		require.NoError(t, store.Save(ctx, customerMapping("retail"), false))
+		require.NoError(t, store.Save(ctx, customerMapping("business"), true))
+
+		mappings := store.List(ctx)
+		require.Len(t, mappings, 2)
+		assert.Equal(t, "business", mappings[0].Name)
+		assert.Equal(t, tools.MappingScopeFile, mappings[0].Scope)
+		assert.Equal(t, "retail", mappings[1].Name)
+		assert.Equal(t, tools.MappingScopeSession, mappings[1].Scope)
+	})
+
+	t.Run("persisting without a file fails", func(t *testing.T) {
+		store := tools.NewMappingStore("")
+
+		err := store.Save(context.Background(), customerMapping("retail"), true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "NEO4J_MAPPING_STORE_FILE")
+	})
+
+	t.Run("a session keeps a bounded number of mappings", func(t *testing.T) {
+		store := tools.NewMappingStore("")
+		ctx := context.Background()
+		for i := range tools.MaxSessionMappings {
+			require.NoError(t, store.Save(ctx, customerMapping(fmt.Sprintf("mapping-%d", i)), false))
+		}
+
+		assert.Error(t, store.Save(ctx, customerMapping("one-too-many"), false))
+		assert.NoError(t, store.Save(ctx, customerMapping("mapping-0"), false), "replacing a mapping stays within the limit")
+	})
+}
+
+func TestMappingStoreApply(t *testing.T) {
+	store := tools.NewMappingStore("")
+	ctx := context.Background()
+	require.NoError(t, store.Save(ctx, customerMapping("retail"), false))
+
+	t.Run("fills omitted arguments and keeps explicit ones", func(t *testing.T) {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{
+			"mappingName":  "retail",
+			"entityId":     "CUS123",
+			"entityConfig": map[string]any{"nodeLabel": "Person", "idProperty": "personId"},
+		}}}
+
+		require.NoError(t, store.Apply(ctx, &request))
+
+		var args struct {
+			EntityId     string `json:"entityId"`
+			EntityConfig struct {
+				NodeLabel string `json:"nodeLabel"`
+			} `json:"entityConfig"`
+			AttributeMappings []map[string]any `json:"attributeMappings"`
+		}
+		require.NoError(t, request.BindArguments(&args))
+		assert.Equal(t, "CUS123", args.EntityId)
+		assert.Equal(t, "Person", args.EntityConfig.NodeLabel)
+		require.Len(t, args.AttributeMappings, 1)
+		assert.Equal(t, "HAS_EMAIL", args.AttributeMappings[0]["relationshipType"])
+	})
+
+	t.Run("raw JSON arguments are supported", func(t *testing.T) {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: json.RawMessage(`{"mappingName": "retail"}`)}}
+
+		require.NoError(t, store.Apply(ctx, &request))
+		assert.Contains(t, request.GetArguments(), "entityConfig")
+	})
+
+	t.Run("requests without mappingName are unchanged", func(t *testing.T) {
+		arguments := map[string]any{"entityId": "CUS123"}
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}}
+
+		require.NoError(t, store.Apply(ctx, &request))
+		assert.Equal(t, arguments, request.GetArguments())
+	})
+
+	t.Run("unknown mapping names fail", func(t *testing.T) {
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"mappingName": "missing"}}}
+
+		err := store.Apply(ctx, &request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mapping 'missing' not found")
+	})
+
+	t.Run("a nil store rejects mapping names", func(t *testing.T) {
+		var nilStore *tools.MappingStore
+		request := mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: map[string]any{"mappingName": "retail"}}}
+
+		assert.Error(t, nilStore.Apply(ctx, &request))
+	})
+}
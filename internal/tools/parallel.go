@@ -0,0 +1,44 @@
+package tools
+
+import (
+	"context"
+	"sync"
+)
+
+// MaxParallelQueries bounds the queries a single tool call runs at once, so one call
+// cannot take over the driver connection pool. Each query runs in its own session.
+const MaxParallelQueries = 4
+
+// RunParallel runs independent tasks concurrently, at most limit at a time, and waits for all of them.
+// It returns the error of each task in task order, so callers decide which failures are fatal.
+// Tasks not yet started when ctx is done are skipped and report the context error.
+func RunParallel(ctx context.Context, limit int, tasks ...func(context.Context) error) []error {
+	errs := make([]error, len(tasks))
+	slots := make(chan struct{}, max(limit, 1))
+	var wg sync.WaitGroup
+
+	for i, task := range tasks {
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			errs[i] = task(ctx)
+		}()
+	}
+
+	wg.Wait()
+	return errs
+}
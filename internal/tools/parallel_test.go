@@ -0,0 +1,83 @@
+package tools_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunParallel(t *testing.T) {
+	t.Run("returns the error of each task in order", func(t *testing.T) {
+		failure := errors.New("query failed")
+
+		errs := tools.RunParallel(context.Background(), 2,
+			func(context.Context) error { return nil },
+			func(context.Context) error { return failure },
+			func(context.Context) error { return nil },
+		)
+
+		assert.Equal(t, []error{nil, failure, nil}, errs)
+	})
+
+	t.Run("runs at most limit tasks at once", func(t *testing.T) {
+		var running, peak atomic.Int32
+		task := func(context.Context) error {
+			current := running.Add(1)
+			for {
+				previous := peak.Load()
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			running.Add(-1)
+			return nil
+		}
+
+		tools.RunParallel(context.Background(), 3, task, task, task, task, task, task, task, task)
+
+		assert.Equal(t, int32(3), peak.Load())
+	})
+
+	t.Run("runs tasks concurrently", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{}, 2)
+		task := func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}
+
+		done := make(chan []error)
+		go func() { done <- tools.RunParallel(context.Background(), 2, task, task) }()
+		for range 2 {
+			select {
+			case <-started:
+			case <-time.After(time.Second):
+				t.Fatal("Expected both tasks to start before either finished")
+			}
+		}
+		close(release)
+
+		assert.Equal(t, []error{nil, nil}, <-done)
+	})
+
+	t.Run("skips tasks once the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		called := false
+
+		errs := tools.RunParallel(ctx, 1, func(context.Context) error {
+			called = true
+			return nil
+		})
+
+		assert.False(t, called)
+		assert.Equal(t, []error{context.Canceled}, errs)
+	})
+}
@@ -0,0 +1,38 @@
+package tools
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// QueryPreview is a generated query and the parameters it would run with
+type QueryPreview struct {
+	Name   string         `json:"name,omitempty"` // Part of the tool output the query loads, when the tool runs several queries
+	Query  string         `json:"query"`
+	Params map[string]any `json:"params"`
+}
+
+// queryPreviewResult is returned in place of the results of a tool call with previewQuery set
+type queryPreviewResult struct {
+	Preview bool           `json:"preview"`
+	Queries []QueryPreview `json:"queries"`
+}
+
+// NewQueryPreviewResult returns the queries a schema-aware tool generated, without executing them,
+// so analysts can review what the tool will run against their database
+func NewQueryPreviewResult(queries ...QueryPreview) *mcp.CallToolResult {
+	for i := range queries {
+		if queries[i].Params == nil {
+			queries[i].Params = map[string]any{}
+		}
+	}
+
+	response, err := json.MarshalIndent(queryPreviewResult{Preview: true, Queries: queries}, "", "  ")
+	if err != nil {
+		slog.Error("error formatting query preview", "error", err)
+		return mcp.NewToolResultError(err.Error())
+	}
+	return mcp.NewToolResultText(string(response))
+}
@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewQueryPreviewResult(t *testing.T) {
+	result := NewQueryPreviewResult(
+		QueryPreview{Name: "profile", Query: "MATCH (e:Customer {customerId: $entityId}) RETURN e", Params: map[string]any{"entityId": "CUS123"}},
+		QueryPreview{Query: "RETURN 1"},
+	)
+	require.False(t, result.IsError)
+
+	var preview queryPreviewResult
+	require.NoError(t, json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &preview))
+
+	assert.True(t, preview.Preview)
+	require.Len(t, preview.Queries, 2)
+	assert.Equal(t, "profile", preview.Queries[0].Name)
+	assert.Equal(t, "MATCH (e:Customer {customerId: $entityId}) RETURN e", preview.Queries[0].Query)
+	assert.Equal(t, map[string]any{"entityId": "CUS123"}, preview.Queries[0].Params)
+	assert.Empty(t, preview.Queries[1].Name)
+	assert.Equal(t, map[string]any{}, preview.Queries[1].Params, "missing params are reported as an empty map")
+}
@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+)
+
+const (
+	// charsPerToken is the rough number of characters of JSON or English text per model token
+	charsPerToken = 4
+	// chunkEnvelopeChars is kept free in each chunk for the fields around its content
+	chunkEnvelopeChars = 256
+	// continuationTTL is how long the remaining chunks of a response can be fetched after the last fetch
+	continuationTTL = 10 * time.Minute
+	// maxPendingResponses bounds the split responses held in memory; the oldest is dropped first
+	maxPendingResponses = 100
+)
+
+// ErrContinuationNotFound is returned for continuation tokens that are unknown, expired, or belong to another caller
+var ErrContinuationNotFound = errors.New("continuation token not found; it expired or the response was evicted, run the original tool call again")
+
+// ResponseChunker splits tool responses estimated to exceed a token budget into chunks.
+// The first chunk is returned in place of the response, with a continuation token
+// that get-next-chunk exchanges for the next one. A nil chunker never splits.
+type ResponseChunker struct {
+	maxTokens int
+	mu        sync.Mutex
+	pending   map[string]*pendingResponse
+}
+
+// pendingResponse holds the chunks of a split response until they expire
+type pendingResponse struct {
+	array     bool // The chunks are elements of a JSON array rather than pieces of text
+	chunks    []string
+	owner     string // identity of the HTTP caller the response was returned to
+	expiresAt time.Time
+}
+
+// ResponseChunk is a part of a split tool response. A JSON array response is split between its
+// elements, so each chunk holds valid JSON items; any other response is split into pieces of text
+// to concatenate.
+type ResponseChunk struct {
+	Items             json.RawMessage `json:"items,omitempty"`
+	Text              string          `json:"text,omitempty"`
+	Chunk             int             `json:"chunk"`
+	TotalChunks       int             `json:"totalChunks"`
+	ContinuationToken string          `json:"continuationToken,omitempty"` // Pass to get-next-chunk to fetch the next chunk
+}
+
+// NewResponseChunker creates a chunker splitting responses estimated above maxTokens, or nil when maxTokens is not positive
+func NewResponseChunker(maxTokens int) *ResponseChunker {
+	if maxTokens <= 0 {
+		return nil
+	}
+	return &ResponseChunker{
+		maxTokens: maxTokens,
+		pending:   make(map[string]*pendingResponse),
+	}
+}
+
+// EstimateTokens returns a rough estimate of the number of model tokens in text
+func EstimateTokens(text string) int {
+	return (len(text) + charsPerToken - 1) / charsPerToken
+}
+
+// Limit returns the first chunk of text as JSON when text is estimated to exceed the token budget.
+// It reports false, returning text unchanged, when the response fits.
+func (c *ResponseChunker) Limit(ctx context.Context, text string) (string, bool) {
+	if c == nil || EstimateTokens(text) <= c.maxTokens {
+		return text, false
+	}
+
+	budget := max(c.maxTokens*charsPerToken-chunkEnvelopeChars, chunkEnvelopeChars)
+	response := &pendingResponse{}
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(text), &items); err == nil && len(items) > 0 {
+		response.array = true
+		response.chunks = splitItems(items, budget)
+	} else {
+		response.chunks = splitText(text, budget)
+	}
+	if len(response.chunks) < 2 {
+		return text, false
+	}
+	response.owner, _ = auth.GetIdentity(ctx)
+
+	id, err := newContinuationID()
+	if err != nil {
+		return text, false
+	}
+	c.store(id, response)
+
+	chunk, err := json.Marshal(response.chunk(id, 0))
+	if err != nil {
+		return text, false
+	}
+	return string(chunk), true
+}
+
+// Next returns the chunk a continuation token points to, as JSON
+func (c *ResponseChunker) Next(ctx context.Context, token string) (string, error) {
+	id, indexStr, ok := strings.Cut(token, ".")
+	index, err := strconv.Atoi(indexStr)
+	if c == nil || !ok || err != nil {
+		return "", ErrContinuationNotFound
+	}
+
+	c.mu.Lock()
+	c.expire()
+	response, ok := c.pending[id]
+	if ok {
+		response.expiresAt = time.Now().Add(continuationTTL)
+	}
+	c.mu.Unlock()
+
+	identity, _ := auth.GetIdentity(ctx)
+	if !ok || response.owner != identity || index < 1 || index >= len(response.chunks) {
+		return "", ErrContinuationNotFound
+	}
+
+	chunk, err := json.Marshal(response.chunk(id, index))
+	if err != nil {
+		return "", fmt.Errorf("failed to format response chunk: %w", err)
+	}
+	return string(chunk), nil
+}
+
+// store keeps a split response, dropping the oldest one when too many are held
+func (c *ResponseChunker) store(id string, response *pendingResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.expire()
+	if len(c.pending) >= maxPendingResponses {
+		oldest := ""
+		for key, pending := range c.pending {
+			if oldest == "" || pending.expiresAt.Before(c.pending[oldest].expiresAt) {
+				oldest = key
+			}
+		}
+		delete(c.pending, oldest)
+	}
+	response.expiresAt = time.Now().Add(continuationTTL)
+	c.pending[id] = response
+}
+
+// expire drops the responses whose chunks were not fetched in time. Must be called with mu held.
+func (c *ResponseChunker) expire() {
+	now := time.Now()
+	for id, response := range c.pending {
+		if now.After(response.expiresAt) {
+			delete(c.pending, id)
+		}
+	}
+}
+
+// chunk returns the chunk at index, with the token of the following one when there is one
+func (r *pendingResponse) chunk(id string, index int) ResponseChunk {
+	chunk := ResponseChunk{Chunk: index + 1, TotalChunks: len(r.chunks)}
+	if r.array {
+		chunk.Items = json.RawMessage(r.chunks[index])
+	} else {
+		chunk.Text = r.chunks[index]
+	}
+	if index+1 < len(r.chunks) {
+		chunk.ContinuationToken = id + "." + strconv.Itoa(index+1)
+	}
+	return chunk
+}
+
+// splitItems packs JSON array elements into arrays of at most budget characters.
+// An element larger than the budget is returned alone, since splitting it would break its JSON.
+func splitItems(items []json.RawMessage, budget int) []string {
+	var chunks []string
+	var current bytes.Buffer
+	for _, item := range items {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, item); err != nil {
+			compact.Write(item)
+		}
+		if current.Len() > 0 && current.Len()+compact.Len()+2 > budget {
+			current.WriteByte(']')
+			chunks = append(chunks, current.String())
+			current.Reset()
+		}
+		if current.Len() == 0 {
+			current.WriteByte('[')
+		} else {
+			current.WriteByte(',')
+		}
+		current.Write(compact.Bytes())
+	}
+	current.WriteByte(']')
+	return append(chunks, current.String())
+}
+
+// splitText cuts text into pieces whose JSON string encoding holds at most budget characters,
+// never in the middle of a UTF-8 character
+func splitText(text string, budget int) []string {
+	var chunks []string
+	start, size := 0, 0
+	for i, r := range text {
+		runeSize := escapedLen(r)
+		if size > 0 && size+runeSize > budget {
+			chunks = append(chunks, text[start:i])
+			start, size = i, 0
+		}
+		size += runeSize
+	}
+	return append(chunks, text[start:])
+}
+
+// escapedLen returns the length of a character once escaped in a JSON string
+func escapedLen(r rune) int {
+	switch {
+	case r == '"' || r == '\\' || r == '\n' || r == '\r' || r == '\t':
+		return 2
+	case r < 0x20 || r == '<' || r == '>' || r == '&' || r == '\u2028' || r == '\u2029':
+		return 6
+	}
+	return utf8.RuneLen(r)
+}
+
+// newContinuationID returns a random ID for a split response
+func newContinuationID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate continuation token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,114 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseChunker(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns responses within the budget unchanged", func(t *testing.T) {
+		chunker := tools.NewResponseChunker(100)
+
+		text, split := chunker.Limit(ctx, `[{"id": 1}]`)
+		assert.False(t, split)
+		assert.Equal(t, `[{"id": 1}]`, text)
+	})
+
+	t.Run("nil chunker never splits", func(t *testing.T) {
+		var chunker *tools.ResponseChunker
+		assert.Nil(t, tools.NewResponseChunker(0))
+
+		_, split := chunker.Limit(ctx, strings.Repeat("x", 100000))
+		assert.False(t, split)
+	})
+
+	t.Run("splits JSON arrays between elements", func(t *testing.T) {
+		chunker := tools.NewResponseChunker(100)
+		rows := make([]map[string]any, 200)
+		for i := range rows {
+			rows[i] = map[string]any{"id": i, "name": fmt.Sprintf("customer-%d", i)}
+		}
+		response, err := json.Marshal(rows)
+		require.NoError(t, err)
+
+		text, split := chunker.Limit(ctx, string(response))
+		require.True(t, split)
+
+		var items []map[string]any
+		token := ""
+		for chunkText := text; ; {
+			var chunk tools.ResponseChunk
+			require.NoError(t, json.Unmarshal([]byte(chunkText), &chunk))
+			var chunkItems []map[string]any
+			require.NoError(t, json.Unmarshal(chunk.Items, &chunkItems), "each chunk holds a valid JSON array")
+			items = append(items, chunkItems...)
+			assert.LessOrEqual(t, tools.EstimateTokens(chunkText), 100)
+
+			if chunk.ContinuationToken == "" {
+				assert.Equal(t, chunk.TotalChunks, chunk.Chunk)
+				break
+			}
+			token = chunk.ContinuationToken
+			chunkText, err = chunker.Next(ctx, token)
+			require.NoError(t, err)
+		}
+		assert.Len(t, items, 200)
+		assert.Equal(t, float64(199), items[199]["id"])
+
+		// Fetching a chunk again returns the same chunk, so a retried call is safe
+		again, err := chunker.Next(ctx, token)
+		require.NoError(t, err)
+		assert.Contains(t, again, "customer-199")
+	})
+
+	t.Run("splits other responses into text pieces", func(t *testing.T) {
+		chunker := tools.NewResponseChunker(50)
+		response := `{"report": "` + strings.Repeat(`line with "quotes" and é\n`, 40) + `"}`
+
+		text, split := chunker.Limit(ctx, response)
+		require.True(t, split)
+
+		var joined strings.Builder
+		for chunkText := text; ; {
+			var chunk tools.ResponseChunk
+			require.NoError(t, json.Unmarshal([]byte(chunkText), &chunk))
+			joined.WriteString(chunk.Text)
+			if chunk.ContinuationToken == "" {
+				break
+			}
+			var err error
+			chunkText, err = chunker.Next(ctx, chunk.ContinuationToken)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, response, joined.String())
+	})
+
+	t.Run("continuation tokens belong to the caller", func(t *testing.T) {
+		chunker := tools.NewResponseChunker(10)
+		aliceCtx := auth.WithIdentity(ctx, "alice")
+
+		text, split := chunker.Limit(aliceCtx, strings.Repeat("x", 2000))
+		require.True(t, split)
+		var chunk tools.ResponseChunk
+		require.NoError(t, json.Unmarshal([]byte(text), &chunk))
+
+		_, err := chunker.Next(auth.WithIdentity(ctx, "bob"), chunk.ContinuationToken)
+		assert.ErrorIs(t, err, tools.ErrContinuationNotFound)
+
+		_, err = chunker.Next(aliceCtx, chunk.ContinuationToken)
+		assert.NoError(t, err)
+
+		_, err = chunker.Next(aliceCtx, "unknown.1")
+		assert.ErrorIs(t, err, tools.ErrContinuationNotFound)
+	})
+}
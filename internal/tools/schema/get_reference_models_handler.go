@@ -2,37 +2,31 @@ package schema
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
-	"net/http"
 	"strings"
-	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
 )
 
 const (
-	httpTimeout = 10 * time.Second
-)
-
-var (
-	defaultReferenceModelURLs = []string{
-		"https://neo4j.com/developer/industry-use-cases/_attachments/transaction-base-model.txt",
-		"https://neo4j.com/developer/industry-use-cases/_attachments/fraud-event-sequence-model.txt",
-	}
+	formatText    = "text"
+	formatJSON    = "json"
+	formatCompact = "compact"
 )
 
 // GetReferenceModelsHandler returns a handler function for the get-neo4j-reference-data-models tool
-func GetReferenceModelsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func GetReferenceModelsHandler(deps *tools.ToolDependencies, store *ReferenceModelStore) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		return handleGetReferenceModels(ctx, deps, request)
+		return handleGetReferenceModels(ctx, deps, store, request)
 	}
 }
 
 // handleGetReferenceModels fetches and returns Neo4j reference data models
-func handleGetReferenceModels(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+func handleGetReferenceModels(ctx context.Context, deps *tools.ToolDependencies, store *ReferenceModelStore, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	if deps.AnalyticsService == nil {
 		errMessage := "analytics service is not initialized"
 		slog.Error(errMessage)
@@ -41,66 +35,147 @@ func handleGetReferenceModels(ctx context.Context, deps *tools.ToolDependencies,
 
 	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("get-neo4j-reference-data-models"))
 
-	slog.Info("fetching Neo4j reference data models")
+	var args GetReferenceModelsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
-	// Fetch reference models from default URLs
-	var referenceModels []string
-	referenceModelURLs := defaultReferenceModelURLs
+	if args.Format == "" {
+		args.Format = formatText
+	}
+	args.Format = strings.ToLower(args.Format)
+	if args.Format != formatText && args.Format != formatJSON && args.Format != formatCompact {
+		errMessage := fmt.Sprintf("format must be one of %s, %s or %s", formatText, formatJSON, formatCompact)
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
 
-	// Fetch models from URLs
-	for _, url := range referenceModelURLs {
-		content, err := fetchReferenceModelFromURL(ctx, url)
+	if args.List {
+		response, err := json.Marshal(store.Models())
 		if err != nil {
-			slog.Warn("failed to fetch reference model from URL", "url", url, "error", err)
-			continue
+			slog.Error("failed to serialize reference model list", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
 		}
-		referenceModels = append(referenceModels, fmt.Sprintf("=== Reference Model from %s ===\n%s", url, content))
+		return mcp.NewToolResultText(string(response)), nil
 	}
 
-	// Combine all reference models
-	var combinedReferenceModel string
-	if len(referenceModels) > 0 {
-		combinedReferenceModel = strings.Join(referenceModels, "\n\n")
-	} else {
+	models, err := store.resolveModels(args.ModelName)
+	if err != nil {
+		slog.Error("failed to resolve reference model", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.Info("fetching Neo4j reference data models", "modelName", args.ModelName)
+
+	documents := fetchReferenceModels(ctx, store, models, args.ForceRefresh)
+	if len(documents) == 0 {
 		slog.Warn("no reference models could be loaded")
 		return mcp.NewToolResultError("Failed to fetch reference models from Neo4j"), nil
 	}
 
-	// Truncate to prevent timeout (max 15KB)
-	truncated := truncateReferenceModel(combinedReferenceModel, 15000)
+	if args.Format == formatText {
+		var referenceModels []string
+		for _, document := range documents {
+			header := fmt.Sprintf("=== Reference Model from %s (%s) ===", document.URL, document.Name)
+			if document.Source == sourceBundled {
+				header = fmt.Sprintf("=== Reference Model from %s (%s, bundled offline copy) ===", document.URL, document.Name)
+			}
+			referenceModels = append(referenceModels, header+"\n"+document.Content)
+		}
 
-	slog.Info("returning reference models", "size", len(truncated))
+		// Truncate to prevent timeout (max 15KB)
+		truncated := truncateReferenceModel(strings.Join(referenceModels, "\n\n"), 15000)
 
-	return mcp.NewToolResultText(truncated), nil
-}
+		slog.Info("returning reference models", "size", len(truncated))
 
-// fetchReferenceModelFromURL fetches a reference model from a URL
-func fetchReferenceModelFromURL(ctx context.Context, url string) (string, error) {
-	client := &http.Client{
-		Timeout: httpTimeout,
+		return mcp.NewToolResultText(truncated), nil
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	referenceModel, err := parseReferenceModels(documents)
 	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+		slog.Error("failed to parse reference models", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
 	}
+	referenceModel = filterSchemaItems(referenceModel, args.Labels)
 
-	resp, err := client.Do(req)
+	if args.Format == formatCompact {
+		return mcp.NewToolResultText(cypher.FormatSchemaCompact(referenceModel)), nil
+	}
+
+	response, err := json.Marshal(referenceModel)
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch URL: %w", err)
+		slog.Error("failed to serialize reference models", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// referenceModelDocument is a reference model as fetched, before parsing
+type referenceModelDocument struct {
+	Name    string
+	URL     string
+	Content string
+	Source  string // remote, cache, bundled or file
+}
+
+// fetchReferenceModels fetches the given reference models, skipping any that cannot be loaded
+func fetchReferenceModels(ctx context.Context, store *ReferenceModelStore, models []ReferenceModel, forceRefresh bool) []referenceModelDocument {
+	var documents []referenceModelDocument
+	for _, model := range models {
+		content, source, err := store.Fetch(ctx, model.URL, forceRefresh)
+		if err != nil {
+			slog.Warn("failed to fetch reference model", "name", model.Name, "url", model.URL, "error", err)
+			continue
+		}
+		documents = append(documents, referenceModelDocument{Name: model.Name, URL: model.URL, Content: content, Source: source})
+	}
+	return documents
+}
+
+// parseReferenceModels merges the fetched documents into one set of schema items
+func parseReferenceModels(documents []referenceModelDocument) ([]cypher.SchemaItem, error) {
+	builder := newSchemaBuilder()
+	for _, document := range documents {
+		if err := builder.parse(document.Content); err != nil {
+			return nil, fmt.Errorf("failed to parse reference model from %s: %w", document.URL, err)
+		}
 	}
+	return builder.items(), nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// loadReferenceModel fetches and parses the named reference model, or the built-in models when name is empty
+func loadReferenceModel(ctx context.Context, store *ReferenceModelStore, name string) ([]cypher.SchemaItem, error) {
+	models, err := store.resolveModels(name)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
+	}
+	documents := fetchReferenceModels(ctx, store, models, false)
+	if len(documents) == 0 {
+		return nil, fmt.Errorf("failed to fetch reference models from Neo4j")
+	}
+	return parseReferenceModels(documents)
+}
+
+// filterSchemaItems keeps the labels and relationship types named in keys; no keys keeps everything
+func filterSchemaItems(items []cypher.SchemaItem, keys []string) []cypher.SchemaItem {
+	if len(keys) == 0 {
+		return items
 	}
 
-	return string(body), nil
+	wanted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		wanted[strings.TrimPrefix(key, ":")] = true
+	}
+
+	filtered := make([]cypher.SchemaItem, 0, len(keys))
+	for _, item := range items {
+		if wanted[item.Key] {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
 }
 
 // truncateReferenceModel truncates the reference model to a maximum size to prevent response timeouts
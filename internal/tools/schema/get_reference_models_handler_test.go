@@ -25,7 +25,7 @@ func TestGetReferenceModelsHandler(t *testing.T) {
 			AnalyticsService: analyticsService,
 		}
 
-		handler := schema.GetReferenceModelsHandler(deps)
+		handler := schema.GetReferenceModelsHandler(deps, nil)
 		result, err := handler(context.Background(), mcp.CallToolRequest{})
 
 		if err != nil {
@@ -55,7 +55,7 @@ func TestGetReferenceModelsHandler(t *testing.T) {
 			AnalyticsService: nil,
 		}
 
-		handler := schema.GetReferenceModelsHandler(deps)
+		handler := schema.GetReferenceModelsHandler(deps, nil)
 		result, err := handler(context.Background(), mcp.CallToolRequest{})
 
 		if err != nil {
@@ -65,4 +65,23 @@ func TestGetReferenceModelsHandler(t *testing.T) {
 			t.Error("Expected error result for nil analytics service")
 		}
 	})
+	t.Run("invalid format", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.GetReferenceModelsHandler(deps, nil)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"format": "yaml"},
+			},
+		})
+
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid format")
+		}
+	})
 }
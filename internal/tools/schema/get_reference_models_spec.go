@@ -2,6 +2,14 @@ package schema
 
 import "github.com/mark3labs/mcp-go/mcp"
 
+type GetReferenceModelsInput struct {
+	Format       string   `json:"format,omitempty" jsonschema:"default=text,enum=text,enum=json,enum=compact,description=Output format: text (the reference documents as published), json (parsed schema items in the get-schema json format) or compact (one line per label, pattern and relationship type)"`
+	Labels       []string `json:"labels,omitempty" jsonschema:"description=Optional: only return these labels and relationship types (json and compact formats only)"`
+	ForceRefresh bool     `json:"forceRefresh,omitempty" jsonschema:"default=false,description=Download the reference models again instead of using the cached copies"`
+	ModelName    string   `json:"modelName,omitempty" jsonschema:"description=Optional: name of the reference model to return (e.g. transaction-base). Defaults to the built-in Neo4j fraud models."`
+	List         bool     `json:"list,omitempty" jsonschema:"default=false,description=Return the available reference models (name, description, url) instead of their content"`
+}
+
 // GetReferenceModelsSpec returns the tool specification for get-neo4j-reference-data-models
 func GetReferenceModelsSpec() mcp.Tool {
 	return mcp.NewTool("get-neo4j-reference-data-models",
@@ -23,11 +31,22 @@ func GetReferenceModelsSpec() mcp.Tool {
 		The reference models are independent of your database - they show Neo4j's
 		recommended patterns, not what currently exists in your database.
 
+		Call with list=true to see the available models, including any registered for other domains
+		(AML, insurance or card fraud), then pass modelName to fetch the one that fits the question.
+
+		Downloaded models are cached, and a copy bundled with the server is used when
+		neo4j.com cannot be reached. Set forceRefresh to download them again.
+
 		Use this tool when you need guidance on:
 		- How to extend an existing fraud detection schema
 		- What properties and relationships are recommended for fraud detection
 		- Neo4j best practices for banking and financial crime applications
-		- Understanding standard patterns for customer identity, transactions, and accounts`),
+		- Understanding standard patterns for customer identity, transactions, and accounts
+
+		Use format "compact" for a token-efficient summary of the parsed models, or "json" for schema
+		items that can be passed to validate-schema as the referenceModel. Combine either with labels
+		to return only the parts of the model you need.`),
+		mcp.WithInputSchema[GetReferenceModelsInput](),
 		mcp.WithTitleAnnotation("Get Neo4j Reference Data Models"),
 		mcp.WithReadOnlyHintAnnotation(true),
 		mcp.WithIdempotentHintAnnotation(true),
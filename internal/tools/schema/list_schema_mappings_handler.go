@@ -0,0 +1,57 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// ListSchemaMappingsHandler returns a handler function for the list-schema-mappings tool
+func ListSchemaMappingsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleListSchemaMappings(ctx, deps, request)
+	}
+}
+
+// handleListSchemaMappings returns the mappings visible to the calling session, or the one requested by name
+func handleListSchemaMappings(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("list-schema-mappings"))
+
+	var args ListSchemaMappingsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	mappings := deps.MappingStore.List(ctx)
+	if args.Name != "" {
+		mapping, ok := deps.MappingStore.Get(ctx, args.Name)
+		if !ok {
+			errMessage := fmt.Sprintf("mapping '%s' not found", args.Name)
+			slog.Error(errMessage)
+			return mcp.NewToolResultError(errMessage), nil
+		}
+		mappings = []tools.SchemaMapping{mapping}
+	}
+
+	response, err := json.MarshalIndent(map[string]any{
+		"mappings":   mappings,
+		"persistent": deps.MappingStore.Persistent(),
+	}, "", "  ")
+	if err != nil {
+		slog.Error("failed to serialize schema mappings", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
@@ -0,0 +1,26 @@
+package schema
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type ListSchemaMappingsInput struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Optional: return only the mapping with this name, including its full arguments"`
+}
+
+// ListSchemaMappingsSpec returns the tool specification for list-schema-mappings
+func ListSchemaMappingsSpec() mcp.Tool {
+	return mcp.NewTool("list-schema-mappings",
+		mcp.WithDescription(`Lists the schema mappings saved with save-schema-mapping that the current session can pass as mappingName.
+
+Returns each mapping's name, scope ("session" for mappings kept for this session, "file" for persisted ones shared by all sessions),
+the time it was saved and its entityConfig, attributeMappings and piiRelationships.
+Use it to find an existing mapping before building a new one from get-schema.`),
+		mcp.WithInputSchema[ListSchemaMappingsInput](),
+		mcp.WithTitleAnnotation("List Schema Mappings"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
@@ -0,0 +1,240 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/synthetic_identity"
+)
+
+// SuggestedEntityConfig is the entity node configuration guessed for the schema-aware tools
+type SuggestedEntityConfig struct {
+	NodeLabel  string `json:"nodeLabel"`
+	IdProperty string `json:"idProperty,omitempty"` // Empty when no identifier could be guessed
+}
+
+// MappingSuggestions are the tool arguments guessed from the relationships of an entity label.
+// AttributeMappings feed get-customer-profile and the other profile tools; PIIRelationships feed
+// detect-synthetic-identity and the sharedPII signals of compute-risk-score.
+type MappingSuggestions struct {
+	EntityConfig      SuggestedEntityConfig                `json:"entityConfig"`
+	AttributeMappings []query_builder.AttributeMapping     `json:"attributeMappings"`
+	PIIRelationships  []synthetic_identity.PIIRelationship `json:"piiRelationships"`
+	Notes             []string                             `json:"notes,omitempty"` // Guesses worth checking before the mappings are used
+}
+
+// attributeCategory pairs an attribute category with the label keywords that suggest it
+type attributeCategory struct {
+	name     string
+	keywords []string
+	pii      bool // Attributes of the category identify a person and can be shared between synthetic identities
+}
+
+// attributeCategories are checked in order against the lowercase target label, then the relationship type.
+// Devices come first so IpAddress is not taken for a postal address.
+var attributeCategories = []attributeCategory{
+	{name: "device_information", keywords: []string{"device", "ipaddress", "ipv4", "ipv6", "session", "browser", "fingerprint"}, pii: true},
+	{name: "contact_information", keywords: []string{"email", "phone", "mobile", "address", "contact"}, pii: true},
+	{name: "identity_documents", keywords: []string{"ssn", "passport", "driver", "licence", "license", "document", "nationalid", "taxid", "identity"}, pii: true},
+	{name: "account_information", keywords: []string{"account", "card", "wallet"}},
+	{name: "employment_details", keywords: []string{"employ", "occupation", "job", "business"}},
+}
+
+// partyLabels name nodes that are parties in their own right, linked to the entity as relationships
+var partyLabels = []string{"person", "customer", "entity", "company", "organization", "organisation", "party", "merchant", "individual"}
+
+// transactionKeywords name nodes holding activity rather than attributes, too many to collect in a profile
+var transactionKeywords = []string{"transaction", "payment", "transfer", "purchase"}
+
+// identifierCandidates are property names commonly holding a node's key, in order of preference
+var identifierCandidates = []string{"id", "number", "value", "address", "email", "phoneNumber", "accountNumber", "code", "name"}
+
+// SuggestMappings guesses the attribute mappings and PII relationships of the nodes connected to label,
+// from the processed schema returned by get-schema. Categories and identifier properties are guessed from
+// label names and uniqueness constraints, so the result is a starting point to check, not a certainty.
+func SuggestMappings(schemaItems []cypher.SchemaItem, label string) (MappingSuggestions, error) {
+	nodes := make(map[string]cypher.SchemaDetail)
+	for _, item := range schemaItems {
+		if item.Value.Type == "node" {
+			nodes[item.Key] = item.Value
+		}
+	}
+
+	entity, ok := nodes[label]
+	if !ok {
+		available := make([]string, 0, len(nodes))
+		for name := range nodes {
+			available = append(available, name)
+		}
+		sort.Strings(available)
+		return MappingSuggestions{}, fmt.Errorf("label '%s' not found in the schema. Available labels: %s", label, strings.Join(available, ", "))
+	}
+
+	suggestions := MappingSuggestions{
+		EntityConfig:      SuggestedEntityConfig{NodeLabel: label, IdProperty: guessIdentifierProperty(label, entity)},
+		AttributeMappings: []query_builder.AttributeMapping{},
+		PIIRelationships:  []synthetic_identity.PIIRelationship{},
+	}
+	if suggestions.EntityConfig.IdProperty == "" {
+		suggestions.Notes = append(suggestions.Notes, fmt.Sprintf("no identifier property found on %s; set entityConfig.idProperty to the property holding its ID", label))
+	}
+
+	relTypes := make([]string, 0, len(entity.Relationships))
+	for relType := range entity.Relationships {
+		relTypes = append(relTypes, relType)
+	}
+	sort.Strings(relTypes)
+
+	collectionKeys := make(map[string]bool)
+	for _, relType := range relTypes {
+		relationship := entity.Relationships[relType]
+		for _, targetLabel := range relationship.Labels {
+			if containsKeyword(strings.ToLower(targetLabel), transactionKeywords) {
+				suggestions.Notes = append(suggestions.Notes, fmt.Sprintf("skipped %s -> %s: transactions are read with get-transaction-history rather than collected in a profile", relType, targetLabel))
+				continue
+			}
+
+			target := nodes[targetLabel]
+			category := guessAttributeCategory(label, targetLabel, relType)
+			mapping := query_builder.AttributeMapping{
+				RelationshipType:              relType,
+				TargetLabel:                   targetLabel,
+				IdentifierProperty:            guessIdentifierProperty(targetLabel, target),
+				AttributeCategory:             category.name,
+				IncludeRelationshipProperties: sortedKeys(relationship.Properties),
+			}
+			if relationship.Direction == "in" {
+				mapping.Direction = "in"
+			}
+
+			// Two relationships to the same label in one category need their own collection keys
+			key := category.name + "." + query_builder.CollectionKey(mapping)
+			if collectionKeys[key] {
+				mapping.CollectionKey = collectionKeyFor(relType, targetLabel)
+				key = category.name + "." + mapping.CollectionKey
+			}
+			collectionKeys[key] = true
+
+			suggestions.AttributeMappings = append(suggestions.AttributeMappings, mapping)
+
+			if !category.pii {
+				continue
+			}
+			if mapping.Direction == "in" {
+				suggestions.Notes = append(suggestions.Notes, fmt.Sprintf("%s -> %s looks like PII but points at %s; detect-synthetic-identity only follows outgoing PII relationships, so it is left out of piiRelationships", relType, targetLabel, label))
+				continue
+			}
+			if mapping.IdentifierProperty == "" {
+				suggestions.Notes = append(suggestions.Notes, fmt.Sprintf("%s -> %s looks like PII but has no identifier property to compare; it is left out of piiRelationships", relType, targetLabel))
+				continue
+			}
+			suggestions.PIIRelationships = append(suggestions.PIIRelationships, synthetic_identity.PIIRelationship{
+				RelationshipType:   relType,
+				TargetLabel:        targetLabel,
+				IdentifierProperty: mapping.IdentifierProperty,
+			})
+		}
+	}
+
+	return suggestions, nil
+}
+
+// guessAttributeCategory returns the category suggested by the target label, or failing that the relationship type.
+// Nodes of the entity's own label or another party label are relationships; anything else is other_attributes.
+func guessAttributeCategory(entityLabel, targetLabel, relType string) attributeCategory {
+	label := strings.ToLower(targetLabel)
+	for _, candidate := range []string{label, strings.ToLower(relType)} {
+		if candidate == "ip" {
+			return attributeCategories[0]
+		}
+		for _, category := range attributeCategories {
+			if containsKeyword(candidate, category.keywords) {
+				return category
+			}
+		}
+		if candidate == label && (targetLabel == entityLabel || containsKeyword(label, partyLabels)) {
+			return attributeCategory{name: "relationships"}
+		}
+	}
+	return attributeCategory{name: "other_attributes"}
+}
+
+// guessIdentifierProperty returns the property most likely to identify nodes of a label:
+// a property with a uniqueness or key constraint, then <label>Id or <label>Number, then a common
+// key name, then any property ending in Id. Returns "" when none of them exist.
+func guessIdentifierProperty(label string, detail cypher.SchemaDetail) string {
+	for _, constraint := range detail.Constraints {
+		constraintType := strings.ToUpper(constraint.Type)
+		if (strings.Contains(constraintType, "UNIQUE") || strings.Contains(constraintType, "KEY")) && len(constraint.Properties) == 1 {
+			return constraint.Properties[0]
+		}
+	}
+
+	prefix := lowerFirst(label)
+	candidates := append([]string{prefix + "Id", prefix + "Number"}, identifierCandidates...)
+	for _, candidate := range candidates {
+		if _, ok := detail.Properties[candidate]; ok {
+			return candidate
+		}
+	}
+
+	for _, property := range sortedKeys(detail.Properties) {
+		if strings.HasSuffix(property, "Id") || strings.HasSuffix(property, "ID") {
+			return property
+		}
+	}
+	return ""
+}
+
+// collectionKeyFor returns a collection key naming a list after its relationship type, e.g. home_address_addresses
+// for HAS_HOME_ADDRESS to Address. A leading HAS_ is dropped.
+func collectionKeyFor(relType, targetLabel string) string {
+	name := strings.TrimPrefix(strings.ToLower(relType), "has_")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+	return name + "_" + query_builder.CollectionKey(query_builder.AttributeMapping{TargetLabel: targetLabel})
+}
+
+// containsKeyword reports whether text contains any of the keywords
+func containsKeyword(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// lowerFirst lowercases the first letter of a label, e.g. DriverLicense -> driverLicense
+func lowerFirst(label string) string {
+	if label == "" {
+		return label
+	}
+	runes := []rune(label)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// sortedKeys returns the keys of a property map in alphabetical order, or nil when it is empty
+func sortedKeys(properties map[string]string) []string {
+	if len(properties) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,318 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+var (
+	// outgoingPatternRe matches (:Start)-[:TYPE]->(:End), with optional variables and property maps
+	outgoingPatternRe = regexp.MustCompile(`\(\w*:(\w+)[^)]*\)\s*-\[\w*:(\w+)[^\]]*\]->\s*(\(\w*:(\w+)[^)]*\))`)
+	// incomingPatternRe matches (:End)<-[:TYPE]-(:Start)
+	incomingPatternRe = regexp.MustCompile(`\(\w*:(\w+)[^)]*\)\s*<-\[\w*:(\w+)[^\]]*\]-\s*(\(\w*:(\w+)[^)]*\))`)
+	// nodePropertiesRe matches (:Label {name: TYPE, ...})
+	nodePropertiesRe = regexp.MustCompile(`\(\w*:(\w+)\s*\{([^}]*)\}\)`)
+	// relationshipPropertiesRe matches [:TYPE {name: TYPE, ...}]
+	relationshipPropertiesRe = regexp.MustCompile(`\[\w*:(\w+)\s*\{([^}]*)\}\]`)
+	// headingRe matches markdown headings naming a label (### Customer) or relationship type (### :HAS_ACCOUNT)
+	headingRe = regexp.MustCompile(`^#{2,4}\s+(?:\d+\.\s+)?(:?)` + "`?" + `:?(\w+)` + "`?" + `\s*$`)
+	// propertyBulletRe matches "- `name` (TYPE)", "- name: TYPE" and "- **name**: TYPE"
+	propertyBulletRe = regexp.MustCompile("^\\s*[-*]\\s+(?:`|\\*\\*)?(\\w+)(?:`|\\*\\*)?\\s*(?:\\((\\w[\\w<> ]*)\\)|:\\s*(\\w[\\w<> ]*))")
+)
+
+// propertyTypeNames are the normalised property type names recognised in reference models
+var propertyTypeNames = map[string]bool{
+	"STRING": true, "INTEGER": true, "FLOAT": true, "BOOLEAN": true, "DATE": true, "DATETIME": true,
+	"LOCALTIME": true, "TIME": true, "DURATION": true, "POINT": true,
+}
+
+// arrowsGraph is the arrows.app export format: nodes and relationships referencing node ids
+type arrowsGraph struct {
+	Nodes []struct {
+		ID         string            `json:"id"`
+		Labels     []string          `json:"labels"`
+		Caption    string            `json:"caption"`
+		Properties map[string]string `json:"properties"`
+	} `json:"nodes"`
+	Relationships []struct {
+		Type       string            `json:"type"`
+		FromID     string            `json:"fromId"`
+		ToID       string            `json:"toId"`
+		Properties map[string]string `json:"properties"`
+	} `json:"relationships"`
+}
+
+// ParseReferenceModel converts a reference model document into schema items.
+// arrows.app JSON exports are read as graphs; any other content is scanned as markdown and Cypher
+// for label headings, property bullets, node property maps and relationship patterns.
+// Items are sorted by type and key so the result is stable.
+func ParseReferenceModel(content string) ([]cypher.SchemaItem, error) {
+	builder := newSchemaBuilder()
+	if err := builder.parse(content); err != nil {
+		return nil, err
+	}
+	return builder.items(), nil
+}
+
+// parse adds the labels, relationship types and patterns of one reference model document
+func (b *schemaBuilder) parse(content string) error {
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "{") {
+		return parseArrowsModel(trimmed, b)
+	}
+	parseTextModel(content, b)
+	return nil
+}
+
+func parseArrowsModel(content string, builder *schemaBuilder) error {
+	var wrapper struct {
+		Graph *arrowsGraph `json:"graph"`
+	}
+	var graph arrowsGraph
+	if err := json.Unmarshal([]byte(content), &wrapper); err != nil {
+		return fmt.Errorf("invalid arrows.app JSON: %w", err)
+	}
+	if wrapper.Graph != nil {
+		graph = *wrapper.Graph
+	} else if err := json.Unmarshal([]byte(content), &graph); err != nil {
+		return fmt.Errorf("invalid arrows.app JSON: %w", err)
+	}
+
+	labelsByID := make(map[string][]string, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		labels := node.Labels
+		if len(labels) == 0 && node.Caption != "" {
+			labels = []string{node.Caption}
+		}
+		labelsByID[node.ID] = labels
+		for _, label := range labels {
+			builder.addNode(label, typedProperties(node.Properties))
+		}
+	}
+
+	for _, rel := range graph.Relationships {
+		if rel.Type == "" {
+			continue
+		}
+		builder.addRelationshipType(rel.Type, typedProperties(rel.Properties))
+		for _, from := range labelsByID[rel.FromID] {
+			for _, to := range labelsByID[rel.ToID] {
+				builder.addPattern(from, rel.Type, to)
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseTextModel(content string, builder *schemaBuilder) {
+	for _, match := range nodePropertiesRe.FindAllStringSubmatch(content, -1) {
+		builder.addNode(match[1], parsePropertyMap(match[2]))
+	}
+	for _, match := range relationshipPropertiesRe.FindAllStringSubmatch(content, -1) {
+		builder.addRelationshipType(match[1], parsePropertyMap(match[2]))
+	}
+	for _, match := range findPatterns(outgoingPatternRe, content) {
+		builder.addPattern(match[0], match[1], match[2])
+	}
+	for _, match := range findPatterns(incomingPatternRe, content) {
+		builder.addPattern(match[2], match[1], match[0])
+	}
+
+	// Property bullets belong to the most recent label or relationship type heading
+	var currentKey string
+	var currentIsRelationship bool
+	for _, line := range strings.Split(content, "\n") {
+		if match := headingRe.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			currentKey = match[2]
+			currentIsRelationship = match[1] == ":" || strings.Contains(line, ":"+match[2])
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			currentKey = ""
+			continue
+		}
+		if currentKey == "" {
+			continue
+		}
+		match := propertyBulletRe.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		// Bullets under prose headings ("- Purpose: ...") are not properties
+		propertyType := strings.TrimSpace(match[2] + match[3])
+		if !isPropertyTypeName(propertyType) {
+			continue
+		}
+		properties := map[string]string{match[1]: propertyType}
+		if currentIsRelationship {
+			builder.addRelationshipType(currentKey, properties)
+		} else {
+			builder.addNode(currentKey, properties)
+		}
+	}
+}
+
+// findPatterns returns the start label, type and end label of every relationship pattern.
+// Matching resumes at the end node, so chained paths such as (:A)-[:R]->(:B)-[:S]->(:C) yield both hops.
+func findPatterns(re *regexp.Regexp, content string) [][3]string {
+	var patterns [][3]string
+	for offset := 0; offset < len(content); {
+		loc := re.FindStringSubmatchIndex(content[offset:])
+		if loc == nil {
+			break
+		}
+		patterns = append(patterns, [3]string{
+			content[offset+loc[2] : offset+loc[3]],
+			content[offset+loc[4] : offset+loc[5]],
+			content[offset+loc[8] : offset+loc[9]],
+		})
+		offset += loc[6]
+	}
+	return patterns
+}
+
+// parsePropertyMap reads "name: TYPE, other: TYPE" from a Cypher property map
+func parsePropertyMap(content string) map[string]string {
+	properties := make(map[string]string)
+	for _, pair := range strings.Split(content, ",") {
+		name, propertyType, found := strings.Cut(pair, ":")
+		name = strings.Trim(strings.TrimSpace(name), "`")
+		if name == "" {
+			continue
+		}
+		if !found {
+			properties[name] = ""
+			continue
+		}
+		// Example queries carry values ({customerId: $id}) rather than types; keep the property, drop the value
+		propertyType = strings.TrimSpace(propertyType)
+		if !isPropertyTypeName(propertyType) {
+			propertyType = ""
+		}
+		properties[name] = propertyType
+	}
+	return properties
+}
+
+// typedProperties keeps arrows.app property values that name a type and blanks example values
+func typedProperties(properties map[string]string) map[string]string {
+	result := make(map[string]string, len(properties))
+	for name, value := range properties {
+		if !isPropertyTypeName(value) {
+			value = ""
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// isPropertyTypeName reports whether a value names a Neo4j property type, including aliases and lists
+func isPropertyTypeName(value string) bool {
+	normalized := normalizePropertyType(value)
+	if strings.HasPrefix(normalized, "LIST<") && strings.HasSuffix(normalized, ">") {
+		normalized = normalizePropertyType(strings.TrimSuffix(strings.TrimPrefix(normalized, "LIST<"), ">"))
+	}
+	return propertyTypeNames[normalized]
+}
+
+// schemaBuilder merges parsed fragments into schema items keyed by type and name
+type schemaBuilder struct {
+	nodes         map[string]*cypher.SchemaItem
+	relationships map[string]*cypher.SchemaItem
+}
+
+func newSchemaBuilder() *schemaBuilder {
+	return &schemaBuilder{
+		nodes:         make(map[string]*cypher.SchemaItem),
+		relationships: make(map[string]*cypher.SchemaItem),
+	}
+}
+
+func (b *schemaBuilder) addNode(label string, properties map[string]string) *cypher.SchemaItem {
+	item, ok := b.nodes[label]
+	if !ok {
+		item = &cypher.SchemaItem{Key: label, Value: cypher.SchemaDetail{Type: "node"}}
+		b.nodes[label] = item
+	}
+	mergeProperties(&item.Value, properties)
+	return item
+}
+
+func (b *schemaBuilder) addRelationshipType(relType string, properties map[string]string) {
+	item, ok := b.relationships[relType]
+	if !ok {
+		item = &cypher.SchemaItem{Key: relType, Value: cypher.SchemaDetail{Type: "relationship"}}
+		b.relationships[relType] = item
+	}
+	mergeProperties(&item.Value, properties)
+}
+
+// addPattern records (:from)-[:relType]->(:to) on both end nodes, as get-schema does
+func (b *schemaBuilder) addPattern(from, relType, to string) {
+	b.addRelationshipType(relType, nil)
+	addRelationshipLabel(b.addNode(from, nil), relType, "out", to)
+	addRelationshipLabel(b.addNode(to, nil), relType, "in", from)
+}
+
+func addRelationshipLabel(item *cypher.SchemaItem, relType, direction, label string) {
+	if item.Value.Relationships == nil {
+		item.Value.Relationships = make(map[string]cypher.Relationship)
+	}
+	rel, ok := item.Value.Relationships[relType]
+	if ok && rel.Direction != direction {
+		// A label on both ends of the same type (e.g. TRANSFERRED_TO) is recorded by its outgoing side
+		if direction != "out" {
+			return
+		}
+		rel = cypher.Relationship{}
+	}
+	rel.Direction = direction
+	for _, existing := range rel.Labels {
+		if existing == label {
+			item.Value.Relationships[relType] = rel
+			return
+		}
+	}
+	rel.Labels = append(rel.Labels, label)
+	sort.Strings(rel.Labels)
+	item.Value.Relationships[relType] = rel
+}
+
+// mergeProperties adds properties to a schema item, keeping a known type over an empty one
+func mergeProperties(detail *cypher.SchemaDetail, properties map[string]string) {
+	if len(properties) == 0 {
+		return
+	}
+	if detail.Properties == nil {
+		detail.Properties = make(map[string]string)
+	}
+	for name, propertyType := range properties {
+		if existing, ok := detail.Properties[name]; ok && propertyType == "" {
+			detail.Properties[name] = existing
+			continue
+		}
+		detail.Properties[name] = propertyType
+	}
+}
+
+// items returns nodes sorted by label followed by relationship types sorted by name
+func (b *schemaBuilder) items() []cypher.SchemaItem {
+	result := make([]cypher.SchemaItem, 0, len(b.nodes)+len(b.relationships))
+	for _, group := range []map[string]*cypher.SchemaItem{b.nodes, b.relationships} {
+		keys := make([]string, 0, len(group))
+		for key := range group {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			result = append(result, *group[key])
+		}
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+package schema_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+)
+
+func findSchemaItem(items []cypher.SchemaItem, itemType, key string) *cypher.SchemaItem {
+	for i := range items {
+		if items[i].Value.Type == itemType && items[i].Key == key {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func TestParseReferenceModel(t *testing.T) {
+	t.Run("arrows.app JSON", func(t *testing.T) {
+		content := `{
+			"graph": {
+				"nodes": [
+					{"id": "n0", "labels": ["Customer"], "properties": {"customerId": "String", "firstName": "Jane"}},
+					{"id": "n1", "labels": ["Account"], "properties": {"balance": "Float"}}
+				],
+				"relationships": [
+					{"id": "r0", "type": "HAS_ACCOUNT", "fromId": "n0", "toId": "n1", "properties": {"since": "Date"}}
+				]
+			}
+		}`
+
+		items, err := schema.ParseReferenceModel(content)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		keys := make([]string, 0, len(items))
+		for _, item := range items {
+			keys = append(keys, item.Value.Type+":"+item.Key)
+		}
+		if !reflect.DeepEqual(keys, []string{"node:Account", "node:Customer", "relationship:HAS_ACCOUNT"}) {
+			t.Fatalf("Expected sorted nodes then relationship types, got: %v", keys)
+		}
+
+		customer := findSchemaItem(items, "node", "Customer")
+		if !reflect.DeepEqual(customer.Value.Properties, map[string]string{"customerId": "String", "firstName": ""}) {
+			t.Errorf("Expected example values to be dropped, got: %v", customer.Value.Properties)
+		}
+		if rel := customer.Value.Relationships["HAS_ACCOUNT"]; rel.Direction != "out" || !reflect.DeepEqual(rel.Labels, []string{"Account"}) {
+			t.Errorf("Expected outgoing HAS_ACCOUNT to Account, got: %+v", rel)
+		}
+
+		account := findSchemaItem(items, "node", "Account")
+		if rel := account.Value.Relationships["HAS_ACCOUNT"]; rel.Direction != "in" || !reflect.DeepEqual(rel.Labels, []string{"Customer"}) {
+			t.Errorf("Expected incoming HAS_ACCOUNT from Customer, got: %+v", rel)
+		}
+
+		hasAccount := findSchemaItem(items, "relationship", "HAS_ACCOUNT")
+		if hasAccount.Value.Properties["since"] != "Date" {
+			t.Errorf("Expected since property on HAS_ACCOUNT, got: %v", hasAccount.Value.Properties)
+		}
+	})
+
+	t.Run("markdown and Cypher", func(t *testing.T) {
+		content := "# Transaction Base Model\n\n" +
+			"## Overview\n" +
+			"- Purpose: detect first party fraud\n\n" +
+			"### Customer\n" +
+			"- `customerId` (STRING)\n" +
+			"- **dateOfBirth**: DATE\n\n" +
+			"### :PERFORMS\n" +
+			"- amount: FLOAT\n\n" +
+			"```cypher\n" +
+			"(:Customer)-[:HAS_ACCOUNT]->(:Account {accountNumber: STRING})-[:PERFORMS]->(:Transaction)\n" +
+			"(:Account)<-[:BENEFITS_TO]-(t:Transaction)\n" +
+			"MATCH (c:Customer {customerId: $id}) RETURN c\n" +
+			"```\n"
+
+		items, err := schema.ParseReferenceModel(content)
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if findSchemaItem(items, "node", "Overview") != nil {
+			t.Error("Expected prose headings to be ignored")
+		}
+
+		customer := findSchemaItem(items, "node", "Customer")
+		if customer == nil {
+			t.Fatal("Expected Customer node")
+		}
+		if !reflect.DeepEqual(customer.Value.Properties, map[string]string{"customerId": "STRING", "dateOfBirth": "DATE"}) {
+			t.Errorf("Expected typed Customer properties, got: %v", customer.Value.Properties)
+		}
+
+		account := findSchemaItem(items, "node", "Account")
+		if account == nil || account.Value.Properties["accountNumber"] != "STRING" {
+			t.Fatalf("Expected Account with accountNumber, got: %+v", account)
+		}
+		if rel := account.Value.Relationships["PERFORMS"]; rel.Direction != "out" || !reflect.DeepEqual(rel.Labels, []string{"Transaction"}) {
+			t.Errorf("Expected chained PERFORMS pattern, got: %+v", rel)
+		}
+
+		transaction := findSchemaItem(items, "node", "Transaction")
+		if rel := transaction.Value.Relationships["BENEFITS_TO"]; rel.Direction != "out" || !reflect.DeepEqual(rel.Labels, []string{"Account"}) {
+			t.Errorf("Expected incoming pattern normalised to (:Transaction)-[:BENEFITS_TO]->(:Account), got: %+v", rel)
+		}
+
+		performs := findSchemaItem(items, "relationship", "PERFORMS")
+		if performs == nil || performs.Value.Properties["amount"] != "FLOAT" {
+			t.Errorf("Expected PERFORMS with amount, got: %+v", performs)
+		}
+	})
+
+	t.Run("parsed model feeds the schema diff", func(t *testing.T) {
+		reference, err := schema.ParseReferenceModel("(:Customer)-[:HAS_ACCOUNT]->(:Account)")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		diff := schema.DiffSchema(reference, reference)
+		if !diff.Conforms {
+			t.Errorf("Expected a parsed model to conform to itself, got: %+v", diff)
+		}
+	})
+
+	t.Run("invalid arrows.app JSON", func(t *testing.T) {
+		if _, err := schema.ParseReferenceModel(`{"nodes": [`); err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ReferenceModel is a named reference data model that get-neo4j-reference-data-models can return
+type ReferenceModel struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	URL         string `json:"url"`     // http(s) URL or local file path
+	BuiltIn     bool   `json:"builtIn"` // Built-in models are returned when no model is named
+}
+
+// builtInReferenceModels are the Neo4j fraud reference models, bundled for offline use
+var builtInReferenceModels = []ReferenceModel{
+	{
+		Name:        "transaction-base",
+		Description: "Customers, accounts, transactions and the identity attributes (email, phone, address, documents) linking them",
+		URL:         "https://neo4j.com/developer/industry-use-cases/_attachments/transaction-base-model.txt",
+		BuiltIn:     true,
+	},
+	{
+		Name:        "fraud-event-sequence",
+		Description: "Digital sessions as ordered event chains with devices, IPs and locations",
+		URL:         "https://neo4j.com/developer/industry-use-cases/_attachments/fraud-event-sequence-model.txt",
+		BuiltIn:     true,
+	},
+}
+
+// ParseReferenceModelList reads additional reference models from a comma-separated list of name=url pairs,
+// e.g. "aml=https://example.com/aml-model.txt,card-fraud=/etc/models/card.json".
+// Names are lower-cased; invalid entries are reported together.
+func ParseReferenceModelList(value string) ([]ReferenceModel, error) {
+	var models []ReferenceModel
+	var invalid []string
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, url, found := strings.Cut(entry, "=")
+		name = strings.ToLower(strings.TrimSpace(name))
+		url = strings.TrimSpace(url)
+		if !found || name == "" || url == "" {
+			invalid = append(invalid, entry)
+			continue
+		}
+		models = append(models, ReferenceModel{
+			Name:        name,
+			Description: "Custom reference model",
+			URL:         url,
+		})
+	}
+
+	if len(invalid) > 0 {
+		return models, fmt.Errorf("invalid reference model entries %q, expected name=url", invalid)
+	}
+	return models, nil
+}
+
+// Register adds models to the store, replacing any registered model with the same name
+func (s *ReferenceModelStore) Register(models ...ReferenceModel) {
+	for _, model := range models {
+		if s.models == nil {
+			s.models = make(map[string]ReferenceModel)
+		}
+		s.models[model.Name] = model
+	}
+}
+
+// Models returns the built-in and registered models sorted by name
+func (s *ReferenceModelStore) Models() []ReferenceModel {
+	byName := make(map[string]ReferenceModel, len(builtInReferenceModels))
+	for _, model := range builtInReferenceModels {
+		byName[model.Name] = model
+	}
+	if s != nil {
+		for name, model := range s.models {
+			byName[name] = model
+		}
+	}
+
+	models := make([]ReferenceModel, 0, len(byName))
+	for _, model := range byName {
+		models = append(models, model)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		return models[i].Name < models[j].Name
+	})
+	return models
+}
+
+// resolveModels returns the named model, or the built-in models when name is empty
+func (s *ReferenceModelStore) resolveModels(name string) ([]ReferenceModel, error) {
+	models := s.Models()
+	if name == "" {
+		var builtIn []ReferenceModel
+		for _, model := range models {
+			if model.BuiltIn {
+				builtIn = append(builtIn, model)
+			}
+		}
+		return builtIn, nil
+	}
+
+	names := make([]string, 0, len(models))
+	for _, model := range models {
+		if model.Name == strings.ToLower(name) {
+			return []ReferenceModel{model}, nil
+		}
+		names = append(names, model.Name)
+	}
+	return nil, fmt.Errorf("unknown reference model %q, available models: %s", name, strings.Join(names, ", "))
+}
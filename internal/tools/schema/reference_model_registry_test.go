@@ -0,0 +1,116 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"go.uber.org/mock/gomock"
+)
+
+func TestParseReferenceModelList(t *testing.T) {
+	models, err := schema.ParseReferenceModelList(" AML=https://example.com/aml.txt , card-fraud=/etc/models/card.json,")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(models) != 2 || models[0].Name != "aml" || models[0].URL != "https://example.com/aml.txt" || models[1].Name != "card-fraud" {
+		t.Errorf("Expected aml and card-fraud models, got: %+v", models)
+	}
+
+	models, err = schema.ParseReferenceModelList("aml=https://example.com/aml.txt,insurance")
+	if err == nil || !strings.Contains(err.Error(), "insurance") {
+		t.Errorf("Expected error naming the invalid entry, got: %v", err)
+	}
+	if len(models) != 1 {
+		t.Errorf("Expected valid entries to be kept, got: %+v", models)
+	}
+}
+
+func TestGetReferenceModelsHandler_Registry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent(gomock.Any()).AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	modelFile := filepath.Join(t.TempDir(), "aml.txt")
+	if err := os.WriteFile(modelFile, []byte("(:Customer)-[:SENT]->(:WireTransfer)"), 0o644); err != nil {
+		t.Fatalf("failed to write model file: %v", err)
+	}
+
+	store := schema.NewReferenceModelStore("", time.Hour)
+	store.Register(schema.ReferenceModel{Name: "aml", Description: "Anti-money laundering", URL: modelFile})
+
+	deps := &tools.ToolDependencies{
+		AnalyticsService: analyticsService,
+	}
+	handler := schema.GetReferenceModelsHandler(deps, store)
+
+	t.Run("lists built-in and registered models", func(t *testing.T) {
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"list": true},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var models []schema.ReferenceModel
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &models); err != nil {
+			t.Fatalf("Expected model list JSON, got: %v", err)
+		}
+		names := make([]string, 0, len(models))
+		for _, model := range models {
+			names = append(names, model.Name)
+		}
+		if strings.Join(names, ",") != "aml,fraud-event-sequence,transaction-base" {
+			t.Errorf("Expected sorted built-in and registered models, got: %v", names)
+		}
+	})
+
+	t.Run("returns the named model", func(t *testing.T) {
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"modelName": "AML", "format": "compact"},
+			},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		text := result.Content[0].(mcp.TextContent).Text
+		if !strings.Contains(text, "(:Customer)-[:SENT]->(:WireTransfer)") {
+			t.Errorf("Expected the aml model only, got: %s", text)
+		}
+		if strings.Contains(text, "HAS_ACCOUNT") {
+			t.Errorf("Expected built-in models to be excluded, got: %s", text)
+		}
+	})
+
+	t.Run("unknown model", func(t *testing.T) {
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"modelName": "insurance"},
+			},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Fatal("Expected error result for unknown model")
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, "aml") {
+			t.Errorf("Expected error to list available models, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+}
@@ -0,0 +1,297 @@
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// bundledModels holds offline copies of the default reference models, keyed by the file name of their URL
+//
+//go:embed models/*.txt
+var bundledModels embed.FS
+
+const (
+	// httpTimeout bounds each reference model request
+	httpTimeout = 10 * time.Second
+	// maxReferenceModelSize caps a downloaded model; the published models are a few kilobytes of text
+	maxReferenceModelSize = 1 << 20
+	// downloadAttempts is the number of times a download failing with a transient error is tried
+	downloadAttempts = 3
+	// retryDelay is the wait before the first retry, doubled before each later one
+	retryDelay = 250 * time.Millisecond
+	// maxRedirects matches the limit of the default HTTP client
+	maxRedirects = 10
+)
+
+// Sources a reference model document can be served from
+const (
+	sourceRemote  = "remote"
+	sourceCache   = "cache"
+	sourceBundled = "bundled"
+	sourceFile    = "file"
+)
+
+// ReferenceModelStore fetches reference models over HTTP and keeps a copy on disk.
+// Cached copies younger than the TTL are served without a request; older copies are revalidated
+// with their ETag. When the network is unavailable the stale disk copy is used, then the copy
+// bundled into the binary. Models are only downloaded from the hosts of the built-in models and the
+// hosts allowed with AllowHosts. A nil store fetches without a disk cache.
+type ReferenceModelStore struct {
+	cacheDir     string
+	ttl          time.Duration
+	client       *http.Client
+	models       map[string]ReferenceModel // Registered in addition to the built-in models
+	allowedHosts []string                  // Allowed in addition to the hosts of the built-in models
+}
+
+// NewReferenceModelStore creates a store caching under cacheDir. An empty cacheDir disables the disk cache.
+func NewReferenceModelStore(cacheDir string, ttl time.Duration) *ReferenceModelStore {
+	return &ReferenceModelStore{
+		cacheDir: cacheDir,
+		ttl:      ttl,
+		client:   newReferenceModelClient(),
+	}
+}
+
+// newReferenceModelClient returns the HTTP client models are downloaded with. Redirects are only followed
+// on the host of the model URL, so a registered model cannot send the server to another host.
+func newReferenceModelClient() *http.Client {
+	return &http.Client{
+		Timeout: httpTimeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if req.URL.Host != via[0].URL.Host {
+				return fmt.Errorf("redirect to host %q is not allowed", req.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// AllowHosts adds hosts reference models may be downloaded from, in addition to those of the built-in models.
+// Hosts are matched without their port, ignoring case.
+func (s *ReferenceModelStore) AllowHosts(hosts ...string) {
+	for _, host := range hosts {
+		host = strings.ToLower(strings.TrimSpace(host))
+		if host != "" && !slices.Contains(s.allowedHosts, host) {
+			s.allowedHosts = append(s.allowedHosts, host)
+		}
+	}
+}
+
+// hostAllowed reports whether models may be downloaded from the host of rawURL
+func (s *ReferenceModelStore) hostAllowed(rawURL string) bool {
+	parsed, err := neturl.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(parsed.Hostname())
+	if host == "" {
+		return false
+	}
+	for _, model := range builtInReferenceModels {
+		if builtIn, err := neturl.Parse(model.URL); err == nil && strings.ToLower(builtIn.Hostname()) == host {
+			return true
+		}
+	}
+	return slices.Contains(s.allowedHosts, host)
+}
+
+// Fetch returns the content of the reference model at url and the source it was served from.
+// forceRefresh skips the disk cache and downloads the model unconditionally.
+// Locations that are not http(s) URLs are read from the local filesystem; URLs on hosts
+// that are not allowed are refused before any request is made.
+func (s *ReferenceModelStore) Fetch(ctx context.Context, url string, forceRefresh bool) (string, string, error) {
+	if s == nil {
+		s = &ReferenceModelStore{client: newReferenceModelClient()}
+	}
+
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		content, err := os.ReadFile(url)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read reference model file: %w", err)
+		}
+		return string(content), sourceFile, nil
+	}
+
+	if !s.hostAllowed(url) {
+		return "", "", fmt.Errorf("reference model host of %q is not allowed", url)
+	}
+
+	cached, etag, modTime, cacheErr := s.readCache(url)
+	hasCache := cacheErr == nil
+
+	if hasCache && !forceRefresh && s.ttl > 0 && time.Since(modTime) < s.ttl {
+		return cached, sourceCache, nil
+	}
+
+	if forceRefresh {
+		etag = ""
+	}
+	content, notModified, newETag, err := s.download(ctx, url, etag)
+	if err == nil {
+		if notModified && hasCache {
+			s.touchCache(url)
+			return cached, sourceCache, nil
+		}
+		s.writeCache(url, content, newETag)
+		return content, sourceRemote, nil
+	}
+
+	slog.Warn("failed to fetch reference model, using offline copy", "url", url, "error", err)
+	if hasCache {
+		return cached, sourceCache, nil
+	}
+	if bundled, bundledErr := bundledModels.ReadFile("models/" + path.Base(url)); bundledErr == nil {
+		return string(bundled), sourceBundled, nil
+	}
+	return "", "", err
+}
+
+// download fetches a model, retrying transient failures with an increasing delay
+func (s *ReferenceModelStore) download(ctx context.Context, url, etag string) (content string, notModified bool, newETag string, err error) {
+	delay := retryDelay
+	for attempt := 1; ; attempt++ {
+		content, notModified, newETag, err = s.get(ctx, url, etag)
+		if err == nil || attempt == downloadAttempts || !isRetryableDownload(err) {
+			return content, notModified, newETag, err
+		}
+
+		slog.Debug("retrying reference model download", "url", url, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return "", false, "", err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// statusError reports an unexpected HTTP status
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.code)
+}
+
+// isRetryableDownload reports whether a download error may succeed when retried: timeouts, rate limiting and server errors.
+// Unknown hosts, refused connections and client errors fail the same way again.
+func isRetryableDownload(err error) bool {
+	var status *statusError
+	if errors.As(err, &status) {
+		return status.code == http.StatusTooManyRequests || status.code >= http.StatusInternalServerError
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// get performs a GET, conditional on etag when one is known
+func (s *ReferenceModelStore) get(ctx context.Context, url, etag string) (content string, notModified bool, newETag string, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", false, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", false, "", fmt.Errorf("failed to fetch URL: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && etag != "" {
+		return "", true, etag, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", false, "", &statusError{code: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxReferenceModelSize+1))
+	if err != nil {
+		return "", false, "", fmt.Errorf("failed to read response body: %w", err)
+	}
+	if len(body) > maxReferenceModelSize {
+		return "", false, "", fmt.Errorf("reference model is larger than %d bytes", maxReferenceModelSize)
+	}
+
+	return string(body), false, resp.Header.Get("ETag"), nil
+}
+
+// cachePath returns the file a URL is cached in; the ETag is stored next to it
+func (s *ReferenceModelStore) cachePath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(s.cacheDir, hex.EncodeToString(sum[:8])+"-"+path.Base(url))
+}
+
+func (s *ReferenceModelStore) readCache(url string) (content, etag string, modTime time.Time, err error) {
+	if s.cacheDir == "" {
+		return "", "", time.Time{}, errors.New("disk cache disabled")
+	}
+
+	file := s.cachePath(url)
+	info, err := os.Stat(file)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	etagData, _ := os.ReadFile(file + ".etag")
+
+	return string(data), string(etagData), info.ModTime(), nil
+}
+
+// writeCache stores a downloaded model. Failures are logged: the cache only saves requests.
+func (s *ReferenceModelStore) writeCache(url, content, etag string) {
+	if s.cacheDir == "" {
+		return
+	}
+
+	if err := os.MkdirAll(s.cacheDir, 0o755); err != nil {
+		slog.Warn("failed to create reference model cache directory", "dir", s.cacheDir, "error", err)
+		return
+	}
+
+	file := s.cachePath(url)
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		slog.Warn("failed to cache reference model", "file", file, "error", err)
+		return
+	}
+	if etag == "" {
+		_ = os.Remove(file + ".etag")
+		return
+	}
+	if err := os.WriteFile(file+".etag", []byte(etag), 0o644); err != nil {
+		slog.Warn("failed to cache reference model ETag", "file", file, "error", err)
+	}
+}
+
+// touchCache restarts the TTL of a copy the server confirmed is unchanged
+func (s *ReferenceModelStore) touchCache(url string) {
+	now := time.Now()
+	if err := os.Chtimes(s.cachePath(url), now, now); err != nil {
+		slog.Warn("failed to update reference model cache time", "url", url, "error", err)
+	}
+}
@@ -0,0 +1,217 @@
+package schema_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+)
+
+func TestReferenceModelStore(t *testing.T) {
+	// The test servers listen on the loopback address, which is not a reference model host by default
+	newStore := func(cacheDir string, ttl time.Duration) *schema.ReferenceModelStore {
+		store := schema.NewReferenceModelStore(cacheDir, ttl)
+		store.AllowHosts("127.0.0.1")
+		return store
+	}
+
+	newModelServer := func(etag, body string, requests *[]string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*requests = append(*requests, r.Header.Get("If-None-Match"))
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			_, _ = w.Write([]byte(body))
+		}))
+	}
+
+	t.Run("serves fresh copies from disk", func(t *testing.T) {
+		var requests []string
+		server := newModelServer(`"v1"`, "(:Customer)-[:HAS_ACCOUNT]->(:Account)", &requests)
+		defer server.Close()
+
+		store := newStore(t.TempDir(), time.Hour)
+		url := server.URL + "/model.txt"
+
+		content, source, err := store.Fetch(context.Background(), url, false)
+		if err != nil || source != "remote" || !strings.Contains(content, "HAS_ACCOUNT") {
+			t.Fatalf("Expected remote fetch, got source %q, err %v", source, err)
+		}
+
+		_, source, err = store.Fetch(context.Background(), url, false)
+		if err != nil || source != "cache" {
+			t.Fatalf("Expected cached copy, got source %q, err %v", source, err)
+		}
+		if len(requests) != 1 {
+			t.Errorf("Expected one request while the cache is fresh, got %d", len(requests))
+		}
+	})
+
+	t.Run("revalidates stale copies with the ETag", func(t *testing.T) {
+		var requests []string
+		server := newModelServer(`"v1"`, "(:Customer)-[:HAS_ACCOUNT]->(:Account)", &requests)
+		defer server.Close()
+
+		store := newStore(t.TempDir(), 0)
+		url := server.URL + "/model.txt"
+
+		if _, _, err := store.Fetch(context.Background(), url, false); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		content, source, err := store.Fetch(context.Background(), url, false)
+		if err != nil || source != "cache" || !strings.Contains(content, "HAS_ACCOUNT") {
+			t.Fatalf("Expected not modified response to serve the cache, got source %q, err %v", source, err)
+		}
+		if len(requests) != 2 || requests[1] != `"v1"` {
+			t.Errorf("Expected a conditional request with the cached ETag, got: %v", requests)
+		}
+	})
+
+	t.Run("force refresh downloads unconditionally", func(t *testing.T) {
+		var requests []string
+		server := newModelServer(`"v1"`, "(:Customer)-[:HAS_ACCOUNT]->(:Account)", &requests)
+		defer server.Close()
+
+		store := newStore(t.TempDir(), time.Hour)
+		url := server.URL + "/model.txt"
+
+		if _, _, err := store.Fetch(context.Background(), url, false); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		_, source, err := store.Fetch(context.Background(), url, true)
+		if err != nil || source != "remote" {
+			t.Fatalf("Expected remote fetch, got source %q, err %v", source, err)
+		}
+		if len(requests) != 2 || requests[1] != "" {
+			t.Errorf("Expected an unconditional second request, got: %v", requests)
+		}
+	})
+
+	t.Run("falls back to the disk cache when offline", func(t *testing.T) {
+		var requests []string
+		server := newModelServer(`"v1"`, "(:Customer)-[:HAS_ACCOUNT]->(:Account)", &requests)
+
+		store := newStore(t.TempDir(), 0)
+		url := server.URL + "/model.txt"
+
+		if _, _, err := store.Fetch(context.Background(), url, false); err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		server.Close()
+
+		content, source, err := store.Fetch(context.Background(), url, false)
+		if err != nil || source != "cache" || !strings.Contains(content, "HAS_ACCOUNT") {
+			t.Errorf("Expected stale cached copy, got source %q, err %v", source, err)
+		}
+	})
+
+	t.Run("falls back to the bundled copy when offline", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		store := newStore("", 0)
+		content, source, err := store.Fetch(context.Background(), server.URL+"/transaction-base-model.txt", false)
+		if err != nil || source != "bundled" {
+			t.Fatalf("Expected bundled copy, got source %q, err %v", source, err)
+		}
+
+		items, err := schema.ParseReferenceModel(content)
+		if err != nil {
+			t.Fatalf("Expected bundled model to parse, got: %v", err)
+		}
+		if findSchemaItem(items, "node", "Customer") == nil || findSchemaItem(items, "relationship", "HAS_ACCOUNT") == nil {
+			t.Error("Expected bundled model to describe Customer and HAS_ACCOUNT")
+		}
+	})
+
+	t.Run("unknown model without network", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		defer server.Close()
+
+		if _, _, err := newStore("", 0).Fetch(context.Background(), server.URL+"/unknown.txt", false); err == nil {
+			t.Error("Expected error when no copy is available")
+		}
+	})
+
+	t.Run("retries server errors", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte("(:Customer)-[:HAS_ACCOUNT]->(:Account)"))
+		}))
+		defer server.Close()
+
+		_, source, err := newStore("", 0).Fetch(context.Background(), server.URL+"/unknown.txt", false)
+		if err != nil || source != "remote" {
+			t.Fatalf("Expected remote fetch after a retry, got source %q, err %v", source, err)
+		}
+		if attempts != 2 {
+			t.Errorf("Expected 2 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("rejects oversized models", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(strings.Repeat("x", 2<<20)))
+		}))
+		defer server.Close()
+
+		_, _, err := newStore("", 0).Fetch(context.Background(), server.URL+"/unknown.txt", false)
+		if err == nil || !strings.Contains(err.Error(), "larger than") {
+			t.Errorf("Expected a size limit error, got: %v", err)
+		}
+	})
+
+	t.Run("does not follow redirects to other hosts", func(t *testing.T) {
+		other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte("(:Customer)-[:HAS_ACCOUNT]->(:Account)"))
+		}))
+		defer other.Close()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, other.URL+r.URL.Path, http.StatusFound)
+		}))
+		defer server.Close()
+
+		_, _, err := newStore("", 0).Fetch(context.Background(), server.URL+"/unknown.txt", false)
+		if err == nil || !strings.Contains(err.Error(), "redirect to host") {
+			t.Errorf("Expected the redirect to be refused, got: %v", err)
+		}
+	})
+
+	t.Run("refuses hosts that are not allowed", func(t *testing.T) {
+		var requests []string
+		server := newModelServer(`"v1"`, "(:Customer)-[:HAS_ACCOUNT]->(:Account)", &requests)
+		defer server.Close()
+
+		_, _, err := schema.NewReferenceModelStore("", 0).Fetch(context.Background(), server.URL+"/transaction-base-model.txt", false)
+		if err == nil || !strings.Contains(err.Error(), "is not allowed") {
+			t.Errorf("Expected the host to be refused, got: %v", err)
+		}
+		if len(requests) != 0 {
+			t.Errorf("Expected no request to a host that is not allowed, got %d", len(requests))
+		}
+	})
+
+	t.Run("allows hosts ignoring case and port", func(t *testing.T) {
+		var requests []string
+		server := newModelServer(`"v1"`, "(:Customer)-[:HAS_ACCOUNT]->(:Account)", &requests)
+		defer server.Close()
+
+		store := schema.NewReferenceModelStore("", 0)
+		store.AllowHosts(" LOCALHOST ")
+		url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1) + "/model.txt"
+		if _, source, err := store.Fetch(context.Background(), url, false); err != nil || source != "remote" {
+			t.Errorf("Expected remote fetch from an allowed host, got source %q, err %v", source, err)
+		}
+	})
+}
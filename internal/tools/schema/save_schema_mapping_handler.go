@@ -0,0 +1,163 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+)
+
+// mappingNamePattern restricts mapping names to short identifiers that are easy to repeat in tool calls
+var mappingNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// SaveSchemaMappingHandler returns a handler function for the save-schema-mapping tool
+func SaveSchemaMappingHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSaveSchemaMapping(ctx, deps, request)
+	}
+}
+
+// handleSaveSchemaMapping validates a mapping the way the tools using it would, then saves it under its name
+func handleSaveSchemaMapping(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.MappingStore == nil {
+		errMessage := "mapping store is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("save-schema-mapping"))
+
+	var args SaveSchemaMappingInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if errMessage := validateSchemaMapping(args); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	mapping, err := toSchemaMapping(args)
+	if err != nil {
+		slog.Error("failed to serialize schema mapping", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if err := deps.MappingStore.Save(ctx, mapping, args.Persist); err != nil {
+		slog.Error("failed to save schema mapping", "name", args.Name, "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	scope := tools.MappingScopeSession
+	if args.Persist {
+		scope = tools.MappingScopeFile
+	}
+	slog.Info("saved schema mapping", "name", args.Name, "scope", scope)
+
+	response, err := json.MarshalIndent(map[string]any{
+		"name":  args.Name,
+		"scope": scope,
+		"usage": fmt.Sprintf(`pass {"mappingName": "%s"} instead of the mapping arguments`, args.Name),
+	}, "", "  ")
+	if err != nil {
+		slog.Error("failed to serialize save-schema-mapping response", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// validateSchemaMapping checks the name and that every part of the mapping could be used in a query.
+func validateSchemaMapping(args SaveSchemaMappingInput) string {
+	if args.Name == "" {
+		return "name parameter is required. Choose a name to pass as mappingName, e.g. 'retail-customer'."
+	}
+	if !mappingNamePattern.MatchString(args.Name) {
+		return fmt.Sprintf("invalid name '%s': use up to 64 letters, digits, '-', '_' or '.'", args.Name)
+	}
+	if args.EntityConfig == nil && len(args.AttributeMappings) == 0 && len(args.PIIRelationships) == 0 {
+		return "nothing to save: provide at least one of entityConfig, attributeMappings or piiRelationships"
+	}
+
+	if config := args.EntityConfig; config != nil {
+		if errMessage := query_builder.ValidateLabelExpression("entityConfig.nodeLabel", config.NodeLabel); errMessage != "" {
+			return errMessage
+		}
+		if errMessage := query_builder.ValidateIdentifier("entityConfig.idProperty", config.IdProperty); errMessage != "" {
+			return errMessage
+		}
+		for i, property := range config.BaseProperties {
+			if errMessage := query_builder.ValidateIdentifier(fmt.Sprintf("entityConfig.baseProperties[%d]", i), property); errMessage != "" {
+				return errMessage
+			}
+		}
+		for i, property := range config.DisplayProperties {
+			if errMessage := query_builder.ValidateIdentifier(fmt.Sprintf("entityConfig.displayProperties[%d]", i), property); errMessage != "" {
+				return errMessage
+			}
+		}
+	}
+
+	for _, validate := range []func([]query_builder.AttributeMapping) string{
+		query_builder.ValidateAttributeIdentifiers,
+		query_builder.ValidateCollectionKeys,
+		query_builder.ValidateAttributeFilters,
+		query_builder.ValidateAttributeOrdering,
+		query_builder.ValidateAttributeAggregations,
+	} {
+		if errMessage := validate(args.AttributeMappings); errMessage != "" {
+			return errMessage
+		}
+	}
+
+	for i, pii := range args.PIIRelationships {
+		field := fmt.Sprintf("piiRelationships[%d]", i)
+		if errMessage := query_builder.ValidateIdentifier(field+".relationshipType", pii.RelationshipType); errMessage != "" {
+			return errMessage
+		}
+		if errMessage := query_builder.ValidateLabelExpression(field+".targetLabel", pii.TargetLabel); errMessage != "" {
+			return errMessage
+		}
+		if errMessage := query_builder.ValidateIdentifier(field+".identifierProperty", pii.IdentifierProperty); errMessage != "" {
+			return errMessage
+		}
+	}
+	return ""
+}
+
+// toSchemaMapping converts the tool arguments to the JSON form kept by the mapping store
+func toSchemaMapping(args SaveSchemaMappingInput) (tools.SchemaMapping, error) {
+	mapping := tools.SchemaMapping{Name: args.Name}
+	parts := []struct {
+		target *json.RawMessage
+		value  any
+		empty  bool
+	}{
+		{&mapping.EntityConfig, args.EntityConfig, args.EntityConfig == nil},
+		{&mapping.AttributeMappings, args.AttributeMappings, len(args.AttributeMappings) == 0},
+		{&mapping.PIIRelationships, args.PIIRelationships, len(args.PIIRelationships) == 0},
+	}
+	for _, part := range parts {
+		if part.empty {
+			continue
+		}
+		value, err := json.Marshal(part.value)
+		if err != nil {
+			return tools.SchemaMapping{}, err
+		}
+		*part.target = value
+	}
+	return mapping, nil
+}
@@ -0,0 +1,124 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSaveAndListSchemaMappings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent(gomock.Any()).AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	call := func(handler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error), arguments map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handler(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil {
+			t.Fatal("Expected a result")
+		}
+		return result
+	}
+
+	t.Run("saved mappings are listed", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			MappingStore:     tools.NewMappingStore(""),
+		}
+
+		result := call(schema.SaveSchemaMappingHandler(deps), map[string]any{
+			"name":         "retail-customer",
+			"entityConfig": map[string]any{"nodeLabel": "Customer", "idProperty": "customerId"},
+			"piiRelationships": []map[string]any{
+				{"relationshipType": "HAS_EMAIL", "targetLabel": "Email", "identifierProperty": "address"},
+			},
+		})
+		if result.IsError {
+			t.Fatalf("Expected success, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+
+		result = call(schema.ListSchemaMappingsHandler(deps), map[string]any{})
+		if result.IsError {
+			t.Fatalf("Expected success, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+		var listed struct {
+			Mappings   []tools.SchemaMapping `json:"mappings"`
+			Persistent bool                  `json:"persistent"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &listed); err != nil {
+			t.Fatalf("Expected mappings JSON, got: %v", err)
+		}
+		if len(listed.Mappings) != 1 || listed.Mappings[0].Name != "retail-customer" || listed.Mappings[0].Scope != tools.MappingScopeSession {
+			t.Fatalf("Unexpected mappings: %+v", listed.Mappings)
+		}
+		if listed.Mappings[0].AttributeMappings != nil {
+			t.Errorf("Expected no attribute mappings, got: %s", listed.Mappings[0].AttributeMappings)
+		}
+		if !strings.Contains(string(listed.Mappings[0].PIIRelationships), `"address"`) {
+			t.Errorf("Expected the saved PII relationships, got: %s", listed.Mappings[0].PIIRelationships)
+		}
+		if listed.Persistent {
+			t.Error("Expected a store without a file not to be persistent")
+		}
+	})
+
+	t.Run("invalid mappings are rejected", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			MappingStore:     tools.NewMappingStore(""),
+		}
+
+		tests := []struct {
+			name      string
+			arguments map[string]any
+			expected  string
+		}{
+			{"missing name", map[string]any{"entityConfig": map[string]any{"nodeLabel": "Customer", "idProperty": "customerId"}}, "name parameter is required"},
+			{"invalid name", map[string]any{"name": "retail customer", "entityConfig": map[string]any{"nodeLabel": "Customer", "idProperty": "customerId"}}, "invalid name"},
+			{"nothing to save", map[string]any{"name": "empty"}, "nothing to save"},
+			{"missing id property", map[string]any{"name": "retail", "entityConfig": map[string]any{"nodeLabel": "Customer"}}, "entityConfig.idProperty cannot be empty"},
+			{"PII without identifier", map[string]any{"name": "retail", "piiRelationships": []map[string]any{{"relationshipType": "HAS_EMAIL", "targetLabel": "Email"}}}, "piiRelationships[0].identifierProperty cannot be empty"},
+			{"persist without a file", map[string]any{"name": "retail", "persist": true, "entityConfig": map[string]any{"nodeLabel": "Customer", "idProperty": "customerId"}}, "NEO4J_MAPPING_STORE_FILE"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result := call(schema.SaveSchemaMappingHandler(deps), tt.arguments)
+				if !result.IsError {
+					t.Fatal("Expected error result")
+				}
+				if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.expected) {
+					t.Errorf("Expected error containing %q, got: %s", tt.expected, text)
+				}
+			})
+		}
+
+		if mappings := deps.MappingStore.List(context.Background()); len(mappings) != 0 {
+			t.Errorf("Expected no mappings to be saved, got: %+v", mappings)
+		}
+	})
+
+	t.Run("unknown mapping name", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			MappingStore:     tools.NewMappingStore(""),
+		}
+
+		result := call(schema.ListSchemaMappingsHandler(deps), map[string]any{"name": "missing"})
+		if !result.IsError {
+			t.Error("Expected error result for an unknown mapping name")
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/synthetic_identity"
+)
+
+// MappingEntityConfig is the entity node configuration saved with a mapping. Each tool reads the fields it knows.
+type MappingEntityConfig struct {
+	NodeLabel         string   `json:"nodeLabel" jsonschema:"description=Node label of the entity (e.g. Customer, Person, Account)"`
+	IdProperty        string   `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. customerId)"`
+	BaseProperties    []string `json:"baseProperties,omitempty" jsonschema:"description=Optional: properties get-customer-profile returns in base_details"`
+	DisplayProperties []string `json:"displayProperties,omitempty" jsonschema:"description=Optional: properties detect-synthetic-identity returns for each entity"`
+}
+
+type SaveSchemaMappingInput struct {
+	Name              string                               `json:"name" jsonschema:"description=Name to save the mapping under (letters, digits, '-', '_' and '.', up to 64 characters), e.g. retail-customer"`
+	EntityConfig      *MappingEntityConfig                 `json:"entityConfig,omitempty" jsonschema:"description=Entity node configuration, as passed to the schema-aware tools"`
+	AttributeMappings []query_builder.AttributeMapping     `json:"attributeMappings,omitempty" jsonschema:"description=Attribute mappings, as passed to get-customer-profile"`
+	PIIRelationships  []synthetic_identity.PIIRelationship `json:"piiRelationships,omitempty" jsonschema:"description=PII relationships, as passed to detect-synthetic-identity"`
+	Persist           bool                                 `json:"persist,omitempty" jsonschema:"default=false,description=Save the mapping to the mapping store file, so every session can use it and it survives restarts. Requires NEO4J_MAPPING_STORE_FILE."`
+}
+
+// SaveSchemaMappingSpec returns the tool specification for save-schema-mapping
+func SaveSchemaMappingSpec() mcp.Tool {
+	return mcp.NewTool("save-schema-mapping",
+		mcp.WithDescription(`Saves a confirmed entityConfig, attributeMappings and piiRelationships under a name, so later tool calls pass mappingName instead of repeating the full mapping JSON.
+
+**WORKFLOW:**
+1. Call suggest-attribute-mappings (or get-schema) and review the mappings
+2. Save them once: {"name": "retail-customer", "entityConfig": {...}, "attributeMappings": [...], "piiRelationships": [...]}
+3. Call the schema-aware tools with {"mappingName": "retail-customer", ...}
+
+**TOOLS ACCEPTING mappingName:**
+get-customer-profile, get-transaction-history, get-entity-network, detect-synthetic-identity, compute-risk-score (entityConfig) and gather-sar-evidence (attributeMappings).
+Arguments passed explicitly take precedence over the saved ones, so a call can override part of a mapping.
+
+**SCOPE:**
+Mappings are kept for the current session. With persist, the mapping is written to the mapping store file instead
+and shared by all sessions; a session's own mapping shadows a persisted one of the same name.
+Saving under an existing name replaces the mapping.`),
+		mcp.WithInputSchema[SaveSchemaMappingInput](),
+		mcp.WithTitleAnnotation("Save Schema Mapping"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
@@ -0,0 +1,175 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+// PropertyGap is a reference property that does not exist on the live label or relationship type
+type PropertyGap struct {
+	Owner        string `json:"owner"` // Label, or :TYPE for relationship types
+	Property     string `json:"property"`
+	ExpectedType string `json:"expectedType,omitempty"`
+}
+
+// TypeMismatch is a property present in both schemas with different types
+type TypeMismatch struct {
+	Owner        string `json:"owner"`
+	Property     string `json:"property"`
+	ExpectedType string `json:"expectedType"`
+	ActualType   string `json:"actualType"`
+}
+
+// SchemaDiff lists the structural differences between the live schema and a reference model
+type SchemaDiff struct {
+	MissingLabels            []string       `json:"missingLabels"`
+	MissingRelationshipTypes []string       `json:"missingRelationshipTypes"`
+	MissingProperties        []PropertyGap  `json:"missingProperties"`
+	TypeMismatches           []TypeMismatch `json:"typeMismatches"`
+	MissingRelationships     []string       `json:"missingRelationships"` // Reference patterns absent from the database
+	ExtraRelationships       []string       `json:"extraRelationships"`   // Database patterns absent from the reference
+	Conforms                 bool           `json:"conforms"`
+}
+
+// propertyTypeAliases maps type names used by reference models and db.schema.* procedures to one spelling
+var propertyTypeAliases = map[string]string{
+	"LONG":           "INTEGER",
+	"INT":            "INTEGER",
+	"DOUBLE":         "FLOAT",
+	"STR":            "STRING",
+	"BOOL":           "BOOLEAN",
+	"ZONEDDATETIME":  "DATETIME",
+	"LOCALDATETIME":  "DATETIME",
+	"DATE_TIME":      "DATETIME",
+	"STRINGARRAY":    "LIST<STRING>",
+	"LONGARRAY":      "LIST<INTEGER>",
+	"DOUBLEARRAY":    "LIST<FLOAT>",
+	"BOOLEANARRAY":   "LIST<BOOLEAN>",
+	"DATEARRAY":      "LIST<DATE>",
+	"DATETIMEARRAY":  "LIST<DATETIME>",
+	"POINTARRAY":     "LIST<POINT>",
+	"DURATIONARRAY":  "LIST<DURATION>",
+	"LOCALTIMEARRAY": "LIST<LOCALTIME>",
+}
+
+// DiffSchema compares the live schema against a reference model.
+// Properties without a type in the reference are checked for presence only.
+func DiffSchema(live, reference []cypher.SchemaItem) SchemaDiff {
+	diff := SchemaDiff{
+		MissingLabels:            []string{},
+		MissingRelationshipTypes: []string{},
+		MissingProperties:        []PropertyGap{},
+		TypeMismatches:           []TypeMismatch{},
+		MissingRelationships:     []string{},
+		ExtraRelationships:       []string{},
+	}
+
+	liveItems := make(map[string]cypher.SchemaItem, len(live))
+	for _, item := range live {
+		liveItems[item.Value.Type+":"+item.Key] = item
+	}
+
+	for _, expected := range reference {
+		actual, ok := liveItems[expected.Value.Type+":"+expected.Key]
+		if !ok {
+			if expected.Value.Type == "relationship" {
+				diff.MissingRelationshipTypes = append(diff.MissingRelationshipTypes, expected.Key)
+			} else {
+				diff.MissingLabels = append(diff.MissingLabels, expected.Key)
+			}
+			continue
+		}
+
+		owner := expected.Key
+		if expected.Value.Type == "relationship" {
+			owner = ":" + expected.Key
+		}
+		for property, expectedType := range expected.Value.Properties {
+			actualType, ok := actual.Value.Properties[property]
+			if !ok {
+				diff.MissingProperties = append(diff.MissingProperties, PropertyGap{
+					Owner:        owner,
+					Property:     property,
+					ExpectedType: expectedType,
+				})
+				continue
+			}
+			if expectedType != "" && normalizePropertyType(expectedType) != normalizePropertyType(actualType) {
+				diff.TypeMismatches = append(diff.TypeMismatches, TypeMismatch{
+					Owner:        owner,
+					Property:     property,
+					ExpectedType: expectedType,
+					ActualType:   actualType,
+				})
+			}
+		}
+	}
+
+	livePatterns := relationshipPatterns(live)
+	referencePatterns := relationshipPatterns(reference)
+	for pattern := range referencePatterns {
+		if !livePatterns[pattern] {
+			diff.MissingRelationships = append(diff.MissingRelationships, pattern)
+		}
+	}
+	for pattern := range livePatterns {
+		if !referencePatterns[pattern] {
+			diff.ExtraRelationships = append(diff.ExtraRelationships, pattern)
+		}
+	}
+
+	sort.Strings(diff.MissingLabels)
+	sort.Strings(diff.MissingRelationshipTypes)
+	sort.Strings(diff.MissingRelationships)
+	sort.Strings(diff.ExtraRelationships)
+	sort.Slice(diff.MissingProperties, func(i, j int) bool {
+		a, b := diff.MissingProperties[i], diff.MissingProperties[j]
+		return a.Owner+"."+a.Property < b.Owner+"."+b.Property
+	})
+	sort.Slice(diff.TypeMismatches, func(i, j int) bool {
+		a, b := diff.TypeMismatches[i], diff.TypeMismatches[j]
+		return a.Owner+"."+a.Property < b.Owner+"."+b.Property
+	})
+
+	// Extra relationships extend the reference model and do not break conformance
+	diff.Conforms = len(diff.MissingLabels) == 0 &&
+		len(diff.MissingRelationshipTypes) == 0 &&
+		len(diff.MissingProperties) == 0 &&
+		len(diff.TypeMismatches) == 0 &&
+		len(diff.MissingRelationships) == 0
+
+	return diff
+}
+
+// relationshipPatterns returns the set of (:Start)-[:TYPE]->(:End) patterns declared on node items.
+// Incoming relationships are normalised to the outgoing direction so both ends describe the same pattern.
+func relationshipPatterns(items []cypher.SchemaItem) map[string]bool {
+	patterns := make(map[string]bool)
+	for _, item := range items {
+		if item.Value.Type != "node" {
+			continue
+		}
+		for relType, rel := range item.Value.Relationships {
+			for _, label := range rel.Labels {
+				if rel.Direction == "in" {
+					patterns[fmt.Sprintf("(:%s)-[:%s]->(:%s)", label, relType, item.Key)] = true
+				} else {
+					patterns[fmt.Sprintf("(:%s)-[:%s]->(:%s)", item.Key, relType, label)] = true
+				}
+			}
+		}
+	}
+	return patterns
+}
+
+// normalizePropertyType upper-cases a type name and resolves aliases such as Long and INTEGER
+func normalizePropertyType(propertyType string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(propertyType, " ", ""))
+	if alias, ok := propertyTypeAliases[normalized]; ok {
+		return alias
+	}
+	return normalized
+}
@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+// StoreEnrichedSchemaHandler returns a handler function for the store-enriched-schema tool
+func StoreEnrichedSchemaHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleStoreEnrichedSchema(ctx, deps, request)
+	}
+}
+
+// handleStoreEnrichedSchema keeps the descriptions that match the live schema and stores them for the database
+func handleStoreEnrichedSchema(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.EnrichmentCache == nil {
+		errMessage := "enrichment cache is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("store-enriched-schema"))
+
+	var args StoreEnrichedSchemaInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	args.Summary = strings.TrimSpace(args.Summary)
+	if args.Summary == "" && len(args.Labels) == 0 && len(args.RelationshipTypes) == 0 {
+		errMessage := "nothing to store: provide a summary, labels or relationshipTypes"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps = deps.ForDatabase(args.Database)
+	live, err := cypher.LoadSchema(ctx, deps, args.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var ignored []string
+	labels := matchDescriptions(args.Labels, schemaProperties(live, "node"), "label", &ignored)
+	relationshipTypes := matchDescriptions(args.RelationshipTypes, schemaProperties(live, "relationship"), "relationship type", &ignored)
+	if args.Summary == "" && len(labels) == 0 && len(relationshipTypes) == 0 {
+		errMessage := fmt.Sprintf("nothing to store: no description matches the schema of the database (%s). Call get-schema for the current labels, relationship types and properties", strings.Join(ignored, ", "))
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	database := deps.DBService.GetDatabaseName(ctx)
+	stored := deps.EnrichmentCache.Store(database, tools.SchemaEnrichment{
+		Summary:           args.Summary,
+		Labels:            labels,
+		RelationshipTypes: relationshipTypes,
+	}, args.Merge)
+
+	slog.Info("stored enriched schema",
+		"database", database,
+		"labels", len(stored.Labels),
+		"relationshipTypes", len(stored.RelationshipTypes),
+		"ignored", len(ignored))
+
+	response, err := json.MarshalIndent(map[string]any{
+		"database": database,
+		"stored":   stored,
+		"ignored":  ignored,
+	}, "", "  ")
+	if err != nil {
+		slog.Error("failed to serialize enriched schema", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// schemaProperties returns the property names of each schema item of the given type, by label or relationship type
+func schemaProperties(live []cypher.SchemaItem, itemType string) map[string]map[string]string {
+	properties := make(map[string]map[string]string)
+	for _, item := range live {
+		if item.Value.Type == itemType {
+			properties[item.Key] = item.Value.Properties
+		}
+	}
+	return properties
+}
+
+// matchDescriptions keeps the non-empty descriptions of names and properties present in the schema,
+// adding the others to ignored in a stable order
+func matchDescriptions(descriptions map[string]tools.ElementDescription, live map[string]map[string]string, kind string, ignored *[]string) map[string]tools.ElementDescription {
+	names := make([]string, 0, len(descriptions))
+	for name := range descriptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	matched := make(map[string]tools.ElementDescription)
+	for _, name := range names {
+		liveProperties, ok := live[name]
+		if !ok {
+			*ignored = append(*ignored, fmt.Sprintf("%s '%s'", kind, name))
+			continue
+		}
+
+		description := descriptions[name]
+		element := tools.ElementDescription{Description: strings.TrimSpace(description.Description)}
+		propertyNames := make([]string, 0, len(description.Properties))
+		for property := range description.Properties {
+			propertyNames = append(propertyNames, property)
+		}
+		sort.Strings(propertyNames)
+		for _, property := range propertyNames {
+			if _, ok := liveProperties[property]; !ok {
+				*ignored = append(*ignored, fmt.Sprintf("property '%s.%s'", name, property))
+				continue
+			}
+			text := strings.TrimSpace(description.Properties[property])
+			if text == "" {
+				continue
+			}
+			if element.Properties == nil {
+				element.Properties = make(map[string]string)
+			}
+			element.Properties[property] = text
+		}
+
+		if element.Description != "" || len(element.Properties) > 0 {
+			matched[name] = element
+		}
+	}
+	return matched
+}
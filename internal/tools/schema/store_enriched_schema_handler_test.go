@@ -0,0 +1,178 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStoreEnrichedSchemaHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent(gomock.Any()).AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	newDeps := func() *tools.ToolDependencies {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(liveSchemaQueries).
+			AnyTimes()
+
+		return &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+			EnrichmentCache:  tools.NewEnrichmentCache(),
+		}
+	}
+
+	call := func(t *testing.T, deps *tools.ToolDependencies, arguments map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := schema.StoreEnrichedSchemaHandler(deps)(context.Background(), mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: arguments}})
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil {
+			t.Fatal("Expected a result")
+		}
+		return result
+	}
+
+	t.Run("stores descriptions matching the schema and serves them with get-schema", func(t *testing.T) {
+		deps := newDeps()
+
+		result := call(t, deps, map[string]any{
+			"summary": "Retail banking customers and their accounts",
+			"labels": map[string]any{
+				"Customer": map[string]any{"description": "A retail banking client", "properties": map[string]any{
+					"customerId":  "Identifier assigned at onboarding",
+					"loyaltyTier": "Not in the schema",
+				}},
+				"Merchant": map[string]any{"description": "Not in the schema"},
+			},
+			"relationshipTypes": map[string]any{"HAS_ACCOUNT": map[string]any{"description": "The customer owns the account"}},
+		})
+		if result.IsError {
+			t.Fatalf("Expected success, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+
+		var response struct {
+			Database string                 `json:"database"`
+			Stored   tools.SchemaEnrichment `json:"stored"`
+			Ignored  []string               `json:"ignored"`
+		}
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &response); err != nil {
+			t.Fatalf("Expected enrichment JSON, got: %v", err)
+		}
+		if expected := []string{"property 'Customer.loyaltyTier'", "label 'Merchant'"}; !reflect.DeepEqual(response.Ignored, expected) {
+			t.Errorf("Expected ignored %v, got: %v", expected, response.Ignored)
+		}
+		if _, ok := response.Stored.Labels["Merchant"]; ok {
+			t.Error("Expected unknown labels not to be stored")
+		}
+
+		result, err := cypher.GetSchemaHandler(deps, 100)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"format": "json"}},
+		})
+		if err != nil || result.IsError {
+			t.Fatalf("Expected get-schema to succeed, got: %v", err)
+		}
+		var items []cypher.SchemaItem
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &items); err != nil {
+			t.Fatalf("Expected schema items JSON, got: %v", err)
+		}
+		for _, item := range items {
+			switch item.Key {
+			case "Customer":
+				if item.Value.Description != "A retail banking client" || item.Value.PropertyDescriptions["customerId"] != "Identifier assigned at onboarding" {
+					t.Errorf("Expected Customer descriptions, got: %+v", item.Value)
+				}
+			case "HAS_ACCOUNT":
+				if item.Value.Type == "relationship" && item.Value.Description != "The customer owns the account" {
+					t.Errorf("Expected HAS_ACCOUNT description, got: %+v", item.Value)
+				}
+			case "Account":
+				if item.Value.Description != "" {
+					t.Errorf("Expected no Account description, got: %q", item.Value.Description)
+				}
+			}
+		}
+
+		result, err = cypher.GetSchemaHandler(deps, 100)(context.Background(), mcp.CallToolRequest{})
+		if err != nil || result.IsError {
+			t.Fatalf("Expected get-schema to succeed, got: %v", err)
+		}
+		markdown := result.Content[0].(mcp.TextContent).Text
+		for _, expected := range []string{"Retail banking customers and their accounts", "*Description:* A retail banking client", "`customerId` (String) - Identifier assigned at onboarding"} {
+			if !strings.Contains(markdown, expected) {
+				t.Errorf("Expected markdown to contain %q, got:\n%s", expected, markdown)
+			}
+		}
+	})
+
+	t.Run("merge keeps the stored descriptions", func(t *testing.T) {
+		deps := newDeps()
+
+		call(t, deps, map[string]any{"summary": "Retail banking", "labels": map[string]any{"Customer": map[string]any{"description": "A client"}}})
+		result := call(t, deps, map[string]any{"merge": true, "labels": map[string]any{"Account": map[string]any{"description": "A bank account"}}})
+		if result.IsError {
+			t.Fatalf("Expected success, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+
+		stored, ok := deps.EnrichmentCache.Get("neo4j")
+		if !ok {
+			t.Fatal("Expected an enrichment to be stored")
+		}
+		if stored.Summary != "Retail banking" || stored.Labels["Customer"].Description != "A client" || stored.Labels["Account"].Description != "A bank account" {
+			t.Errorf("Expected merged descriptions, got: %+v", stored)
+		}
+	})
+
+	t.Run("rejects descriptions that match nothing", func(t *testing.T) {
+		deps := newDeps()
+
+		tests := []struct {
+			name      string
+			arguments map[string]any
+			expected  string
+		}{
+			{"empty", map[string]any{}, "nothing to store"},
+			{"unknown names only", map[string]any{"labels": map[string]any{"Merchant": map[string]any{"description": "A shop"}}}, "label 'Merchant'"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				result := call(t, deps, tt.arguments)
+				if !result.IsError {
+					t.Fatal("Expected error result")
+				}
+				if text := result.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.expected) {
+					t.Errorf("Expected error containing %q, got: %s", tt.expected, text)
+				}
+			})
+		}
+
+		if _, ok := deps.EnrichmentCache.Get("neo4j"); ok {
+			t.Error("Expected nothing to be stored")
+		}
+	})
+
+	t.Run("missing database service", func(t *testing.T) {
+		result := call(t, &tools.ToolDependencies{AnalyticsService: analyticsService, EnrichmentCache: tools.NewEnrichmentCache()}, map[string]any{"summary": "Retail banking"})
+		if !result.IsError {
+			t.Error("Expected error result when database service is nil")
+		}
+	})
+}
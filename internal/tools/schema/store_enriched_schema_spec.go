@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+type StoreEnrichedSchemaInput struct {
+	Summary           string                              `json:"summary,omitempty" jsonschema:"description=Short description of what the database models, in business terms"`
+	Labels            map[string]tools.ElementDescription `json:"labels,omitempty" jsonschema:"description=Descriptions by node label, e.g. {\"Customer\": {\"description\": \"...\", \"properties\": {\"riskRating\": \"...\"}}}"`
+	RelationshipTypes map[string]tools.ElementDescription `json:"relationshipTypes,omitempty" jsonschema:"description=Descriptions by relationship type, e.g. {\"PERFORMED\": {\"description\": \"...\"}}"`
+	Merge             bool                                `json:"merge,omitempty" jsonschema:"default=false,description=Add the descriptions to those already stored instead of replacing them"`
+	Refresh           bool                                `json:"refresh,omitempty" jsonschema:"default=false,description=Reload the live schema before checking the names instead of using the cached copy"`
+	Database          string                              `json:"database,omitempty" jsonschema:"description=Optional: name of the database the descriptions belong to. Defaults to the configured database."`
+}
+
+// StoreEnrichedSchemaSpec returns the tool specification for store-enriched-schema
+func StoreEnrichedSchemaSpec() mcp.Tool {
+	return mcp.NewTool("store-enriched-schema",
+		mcp.WithDescription(`Stores business descriptions of the database schema on the server, so get-schema and the schema resource return them to every client without enriching the schema again.
+
+**WORKFLOW:**
+1. Call get-schema and describe what each label, relationship type and property means in business terms
+2. Store the descriptions once: {"summary": "...", "labels": {"Customer": {"description": "...", "properties": {"riskRating": "..."}}}}
+3. Later get-schema calls include them, so queries are written from the same descriptions every time
+
+**VALIDATION:**
+Names are checked against the live schema. Labels, relationship types and properties that do not exist are not stored and are listed under "ignored".
+
+**SCOPE:**
+Descriptions are kept per database in server memory and shared by all sessions until replaced or the server restarts.
+Storing replaces the previous descriptions of the database; with merge, only the labels, relationship types and properties given are updated.`),
+		mcp.WithInputSchema[StoreEnrichedSchemaInput](),
+		mcp.WithTitleAnnotation("Store Enriched Schema"),
+		mcp.WithReadOnlyHintAnnotation(false),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(false),
+	)
+}
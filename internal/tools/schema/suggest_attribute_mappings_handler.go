@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+// SuggestAttributeMappingsHandler returns a handler function for the suggest-attribute-mappings tool
+func SuggestAttributeMappingsHandler(deps *tools.ToolDependencies) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleSuggestAttributeMappings(ctx, deps, request)
+	}
+}
+
+// handleSuggestAttributeMappings loads the live schema and guesses the mappings of the requested label
+func handleSuggestAttributeMappings(ctx context.Context, deps *tools.ToolDependencies, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("suggest-attribute-mappings"))
+
+	var args SuggestAttributeMappingsInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if args.Label == "" {
+		errMessage := "label parameter is required. Specify the entity node label (e.g., 'Customer')."
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	live, err := cypher.LoadSchema(ctx, deps.ForDatabase(args.Database), args.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	suggestions, err := SuggestMappings(live, args.Label)
+	if err != nil {
+		slog.Error("failed to suggest attribute mappings", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	slog.Info("suggested attribute mappings",
+		"label", args.Label,
+		"attributeMappings", len(suggestions.AttributeMappings),
+		"piiRelationships", len(suggestions.PIIRelationships))
+
+	response, err := json.MarshalIndent(suggestions, "", "  ")
+	if err != nil {
+		slog.Error("failed to serialize attribute mapping suggestions", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
@@ -0,0 +1,192 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/synthetic_identity"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"go.uber.org/mock/gomock"
+)
+
+// customerSchema is a processed schema of a Customer with contact, identity, device, account and party relationships
+func customerSchema() []cypher.SchemaItem {
+	node := func(label string, properties map[string]string, relationships map[string]cypher.Relationship, constraints ...cypher.Constraint) cypher.SchemaItem {
+		return cypher.SchemaItem{Key: label, Value: cypher.SchemaDetail{Type: "node", Properties: properties, Relationships: relationships, Constraints: constraints}}
+	}
+	return []cypher.SchemaItem{
+		node("Customer", map[string]string{"customerId": "STRING", "firstName": "STRING"}, map[string]cypher.Relationship{
+			"HAS_EMAIL":           {Direction: "out", Labels: []string{"Email"}},
+			"HAS_PHONE":           {Direction: "out", Labels: []string{"Phone"}},
+			"HAS_ADDRESS":         {Direction: "out", Labels: []string{"Address"}},
+			"HAS_MAILING_ADDRESS": {Direction: "out", Labels: []string{"Address"}},
+			"HAS_SSN":             {Direction: "out", Labels: []string{"SSN"}},
+			"USES_DEVICE":         {Direction: "out", Labels: []string{"Device"}},
+			"HAS_IP":              {Direction: "out", Labels: []string{"IpAddress"}},
+			"OWNS":                {Direction: "out", Labels: []string{"Account"}, Properties: map[string]string{"since": "DATE"}},
+			"REFERRED":            {Direction: "in", Labels: []string{"Customer"}},
+			"PERFORMS":            {Direction: "out", Labels: []string{"Transaction"}},
+		}),
+		node("Email", map[string]string{"address": "STRING", "verified": "BOOLEAN"}, nil),
+		node("Phone", map[string]string{"phoneNumber": "STRING"}, nil),
+		node("Address", map[string]string{"street": "STRING", "city": "STRING"}, nil),
+		node("SSN", map[string]string{"value": "STRING", "ssnRef": "STRING"}, nil,
+			cypher.Constraint{Name: "ssn_unique", Type: "UNIQUENESS", Properties: []string{"ssnRef"}}),
+		node("Device", map[string]string{"deviceId": "STRING"}, nil),
+		node("IpAddress", map[string]string{"ip": "STRING", "ipAddressId": "STRING"}, nil),
+		node("Account", map[string]string{"accountNumber": "STRING", "balance": "FLOAT"}, nil),
+		node("Transaction", map[string]string{"transactionId": "STRING"}, nil),
+		{Key: "HAS_EMAIL", Value: cypher.SchemaDetail{Type: "relationship"}},
+	}
+}
+
+func TestSuggestMappings(t *testing.T) {
+	t.Run("guesses categories, identifiers and PII relationships", func(t *testing.T) {
+		suggestions, err := schema.SuggestMappings(customerSchema(), "Customer")
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		if suggestions.EntityConfig != (schema.SuggestedEntityConfig{NodeLabel: "Customer", IdProperty: "customerId"}) {
+			t.Errorf("Unexpected entity config: %+v", suggestions.EntityConfig)
+		}
+
+		// Relationship types are sorted; transactions are skipped
+		expectedMappings := []query_builder.AttributeMapping{
+			{RelationshipType: "HAS_ADDRESS", TargetLabel: "Address", AttributeCategory: "contact_information"},
+			{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", IdentifierProperty: "address", AttributeCategory: "contact_information"},
+			{RelationshipType: "HAS_IP", TargetLabel: "IpAddress", IdentifierProperty: "ipAddressId", AttributeCategory: "device_information"},
+			{RelationshipType: "HAS_MAILING_ADDRESS", TargetLabel: "Address", AttributeCategory: "contact_information", CollectionKey: "mailing_address_addresses"},
+			{RelationshipType: "HAS_PHONE", TargetLabel: "Phone", IdentifierProperty: "phoneNumber", AttributeCategory: "contact_information"},
+			{RelationshipType: "HAS_SSN", TargetLabel: "SSN", IdentifierProperty: "ssnRef", AttributeCategory: "identity_documents"},
+			{RelationshipType: "OWNS", TargetLabel: "Account", IdentifierProperty: "accountNumber", AttributeCategory: "account_information", IncludeRelationshipProperties: []string{"since"}},
+			{RelationshipType: "REFERRED", TargetLabel: "Customer", IdentifierProperty: "customerId", AttributeCategory: "relationships", Direction: "in"},
+			{RelationshipType: "USES_DEVICE", TargetLabel: "Device", IdentifierProperty: "deviceId", AttributeCategory: "device_information"},
+		}
+		if !reflect.DeepEqual(suggestions.AttributeMappings, expectedMappings) {
+			t.Errorf("Unexpected attribute mappings:\n got: %+v\nwant: %+v", suggestions.AttributeMappings, expectedMappings)
+		}
+		if errMessage := query_builder.ValidateCollectionKeys(suggestions.AttributeMappings); errMessage != "" {
+			t.Errorf("Expected usable collection keys, got: %s", errMessage)
+		}
+
+		expectedPII := []synthetic_identity.PIIRelationship{
+			{RelationshipType: "HAS_EMAIL", TargetLabel: "Email", IdentifierProperty: "address"},
+			{RelationshipType: "HAS_IP", TargetLabel: "IpAddress", IdentifierProperty: "ipAddressId"},
+			{RelationshipType: "HAS_PHONE", TargetLabel: "Phone", IdentifierProperty: "phoneNumber"},
+			{RelationshipType: "HAS_SSN", TargetLabel: "SSN", IdentifierProperty: "ssnRef"},
+			{RelationshipType: "USES_DEVICE", TargetLabel: "Device", IdentifierProperty: "deviceId"},
+		}
+		if !reflect.DeepEqual(suggestions.PIIRelationships, expectedPII) {
+			t.Errorf("Unexpected PII relationships:\n got: %+v\nwant: %+v", suggestions.PIIRelationships, expectedPII)
+		}
+
+		notes := strings.Join(suggestions.Notes, "\n")
+		if !strings.Contains(notes, "skipped PERFORMS -> Transaction") {
+			t.Errorf("Expected a note about the skipped transactions, got: %s", notes)
+		}
+		if !strings.Contains(notes, "HAS_ADDRESS -> Address looks like PII but has no identifier property") {
+			t.Errorf("Expected a note about the address without identifier, got: %s", notes)
+		}
+	})
+
+	t.Run("unknown label lists the available labels", func(t *testing.T) {
+		_, err := schema.SuggestMappings(customerSchema(), "Merchant")
+		if err == nil {
+			t.Fatal("Expected an error for an unknown label")
+		}
+		if !strings.Contains(err.Error(), "label 'Merchant' not found") || !strings.Contains(err.Error(), "Account, Address, Customer") {
+			t.Errorf("Expected the available labels in the error, got: %v", err)
+		}
+	})
+}
+
+func TestSuggestAttributeMappingsHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("suggest-attribute-mappings").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("suggests mappings from the live schema", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(liveSchemaQueries).
+			AnyTimes()
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.SuggestAttributeMappingsHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{"label": "Customer"},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		var suggestions schema.MappingSuggestions
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &suggestions); err != nil {
+			t.Fatalf("Expected mapping suggestions JSON, got: %v", err)
+		}
+		if suggestions.EntityConfig.IdProperty != "customerId" {
+			t.Errorf("Expected customerId as the entity identifier, got: %+v", suggestions.EntityConfig)
+		}
+		if len(suggestions.AttributeMappings) != 2 {
+			t.Errorf("Expected mappings for HAS_ACCOUNT and HAS_EMAIL, got: %+v", suggestions.AttributeMappings)
+		}
+	})
+
+	t.Run("missing label", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			DBService:        db.NewMockService(ctrl),
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.SuggestAttributeMappingsHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result when label is missing")
+		}
+	})
+
+	t.Run("missing database service", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.SuggestAttributeMappingsHandler(deps)
+		result, err := handler(context.Background(), mcp.CallToolRequest{})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result when database service is nil")
+		}
+	})
+}
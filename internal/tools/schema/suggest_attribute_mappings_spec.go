@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+type SuggestAttributeMappingsInput struct {
+	Label    string `json:"label" jsonschema:"description=Node label of the entity to build mappings for (e.g. Customer, Account, Merchant)"`
+	Refresh  bool   `json:"refresh,omitempty" jsonschema:"default=false,description=Reload the live schema instead of using the cached copy"`
+	Database string `json:"database,omitempty" jsonschema:"description=Optional: name of the database to inspect. Defaults to the configured database."`
+}
+
+// SuggestAttributeMappingsSpec returns the tool specification for suggest-attribute-mappings
+func SuggestAttributeMappingsSpec() mcp.Tool {
+	return mcp.NewTool("suggest-attribute-mappings",
+		mcp.WithDescription(`Inspects the cached database schema around an entity label and returns ready-to-use mapping arguments for the schema-aware tools, instead of building them by hand from get-schema output.
+
+**RETURNS:**
+- **entityConfig:** {nodeLabel, idProperty} for the entity itself
+- **attributeMappings:** one mapping per relationship of the entity, for get-customer-profile, get-account-profile, get-merchant-profile and gather-sar-evidence
+- **piiRelationships:** the contact, identity document and device mappings that have an identifier property, for detect-synthetic-identity and compute-risk-score sharedPII signals
+- **notes:** guesses worth checking, such as nodes without an identifier property
+
+**HOW GUESSES ARE MADE:**
+- identifierProperty: a property with a uniqueness or key constraint, then <label>Id or <label>Number, then common key names (id, number, value, address, ...)
+- attributeCategory: from the target label, then the relationship type: contact_information (Email, Phone, Address), identity_documents (SSN, Passport, DriverLicense), device_information (Device, IpAddress), account_information, employment_details, relationships (other parties) or other_attributes
+- direction is "in" for relationships pointing at the entity; transactions are skipped, since get-transaction-history reads them
+
+Review the suggestions, drop the mappings you do not need, then pass them to the tools unchanged.
+
+**Example:**
+{"label": "Customer"}`),
+		mcp.WithInputSchema[SuggestAttributeMappingsInput](),
+		mcp.WithTitleAnnotation("Suggest Attribute Mappings"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,96 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+// ValidateSchemaHandler returns a handler function for the validate-schema tool
+func ValidateSchemaHandler(deps *tools.ToolDependencies, store *ReferenceModelStore) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleValidateSchema(ctx, deps, store, request)
+	}
+}
+
+// handleValidateSchema loads the live schema and diffs it against the reference model
+func handleValidateSchema(ctx context.Context, deps *tools.ToolDependencies, store *ReferenceModelStore, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if deps.DBService == nil {
+		errMessage := "database service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	if deps.AnalyticsService == nil {
+		errMessage := "analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent("validate-schema"))
+
+	var args ValidateSchemaInput
+	if err := request.BindArguments(&args); err != nil {
+		slog.Error("error binding arguments", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if len(args.ReferenceModel) == 0 {
+		referenceModel, err := loadReferenceModel(ctx, store, args.ModelName)
+		if err != nil {
+			slog.Error("failed to load reference model", "error", err)
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		args.ReferenceModel = filterSchemaItems(referenceModel, args.Labels)
+		if len(args.ReferenceModel) == 0 {
+			errMessage := "none of the requested labels are defined in the reference models"
+			slog.Error(errMessage)
+			return mcp.NewToolResultError(errMessage), nil
+		}
+	}
+
+	if errMessage := validateReferenceModel(args.ReferenceModel); errMessage != "" {
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	live, err := cypher.LoadSchema(ctx, deps.ForDatabase(args.Database), args.Refresh)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	diff := DiffSchema(live, args.ReferenceModel)
+
+	slog.Info("validated schema against reference model",
+		"referenceItems", len(args.ReferenceModel),
+		"conforms", diff.Conforms)
+
+	response, err := json.Marshal(diff)
+	if err != nil {
+		slog.Error("failed to serialize schema diff", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// validateReferenceModel checks every reference item names a label or relationship type.
+func validateReferenceModel(referenceModel []cypher.SchemaItem) string {
+	if len(referenceModel) == 0 {
+		return "referenceModel must contain at least one item"
+	}
+	for i, item := range referenceModel {
+		if item.Key == "" {
+			return fmt.Sprintf("referenceModel[%d].key is required", i)
+		}
+		if item.Value.Type != "node" && item.Value.Type != "relationship" {
+			return fmt.Sprintf("referenceModel[%d].value.type must be node or relationship", i)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,227 @@
+package schema_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	db "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/schema"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j/dbtype"
+	"go.uber.org/mock/gomock"
+)
+
+// liveSchemaQueries answers the get-schema queries with (:Customer)-[:HAS_ACCOUNT]->(:Account) and (:Customer)-[:HAS_EMAIL]->(:Email)
+func liveSchemaQueries(_ context.Context, query string, _ map[string]any) ([]*neo4j.Record, error) {
+	switch {
+	case strings.Contains(query, "db.schema.visualization"):
+		return []*neo4j.Record{
+			{
+				Keys: []string{"nodes", "relationships"},
+				Values: []any{
+					[]any{
+						dbtype.Node{Id: 1, Labels: []string{"Customer"}, Props: map[string]any{"name": "Customer"}},
+						dbtype.Node{Id: 2, Labels: []string{"Account"}, Props: map[string]any{"name": "Account"}},
+						dbtype.Node{Id: 3, Labels: []string{"Email"}, Props: map[string]any{"name": "Email"}},
+					},
+					[]any{
+						dbtype.Relationship{Id: 1, StartId: 1, EndId: 2, Type: "HAS_ACCOUNT", Props: map[string]any{"name": "HAS_ACCOUNT"}},
+						dbtype.Relationship{Id: 2, StartId: 1, EndId: 3, Type: "HAS_EMAIL", Props: map[string]any{"name": "HAS_EMAIL"}},
+					},
+				},
+			},
+		}, nil
+	case strings.Contains(query, "db.schema.nodeTypeProperties"):
+		return []*neo4j.Record{
+			{Keys: []string{"nodeLabels", "propertyName", "propertyTypes"}, Values: []any{[]any{"Customer"}, "customerId", []any{"String"}}},
+			{Keys: []string{"nodeLabels", "propertyName", "propertyTypes"}, Values: []any{[]any{"Customer"}, "dateOfBirth", []any{"String"}}},
+			{Keys: []string{"nodeLabels", "propertyName", "propertyTypes"}, Values: []any{[]any{"Account"}, "balance", []any{"Double"}}},
+		}, nil
+	}
+	return []*neo4j.Record{}, nil
+}
+
+func TestValidateSchemaHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("validate-schema").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+
+	t.Run("reports gaps against the reference model", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(liveSchemaQueries).
+			AnyTimes()
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.ValidateSchemaHandler(deps, nil)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"referenceModel": []map[string]any{
+						{"key": "Customer", "value": map[string]any{
+							"type":       "node",
+							"properties": map[string]any{"customerId": "STRING", "dateOfBirth": "DATE", "firstName": ""},
+							"relationships": map[string]any{
+								"HAS_ACCOUNT": map[string]any{"direction": "out", "labels": []string{"Account"}},
+								"HAS_SSN":     map[string]any{"direction": "out", "labels": []string{"SSN"}},
+							},
+						}},
+						{"key": "Account", "value": map[string]any{
+							"type":       "node",
+							"properties": map[string]any{"balance": "FLOAT"},
+						}},
+						{"key": "SSN", "value": map[string]any{"type": "node"}},
+						{"key": "HAS_SSN", "value": map[string]any{"type": "relationship"}},
+					},
+				},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+
+		var diff schema.SchemaDiff
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &diff); err != nil {
+			t.Fatalf("Expected schema diff JSON, got: %v", err)
+		}
+
+		if !reflect.DeepEqual(diff.MissingLabels, []string{"SSN"}) {
+			t.Errorf("Expected SSN to be missing, got: %v", diff.MissingLabels)
+		}
+		if !reflect.DeepEqual(diff.MissingRelationshipTypes, []string{"HAS_SSN"}) {
+			t.Errorf("Expected HAS_SSN to be missing, got: %v", diff.MissingRelationshipTypes)
+		}
+		if len(diff.MissingProperties) != 1 || diff.MissingProperties[0].Property != "firstName" {
+			t.Errorf("Expected firstName to be missing, got: %+v", diff.MissingProperties)
+		}
+		if len(diff.TypeMismatches) != 1 || diff.TypeMismatches[0].Property != "dateOfBirth" || diff.TypeMismatches[0].ActualType != "String" {
+			t.Errorf("Expected dateOfBirth type mismatch only (Double matches FLOAT), got: %+v", diff.TypeMismatches)
+		}
+		if !reflect.DeepEqual(diff.MissingRelationships, []string{"(:Customer)-[:HAS_SSN]->(:SSN)"}) {
+			t.Errorf("Expected HAS_SSN pattern to be missing, got: %v", diff.MissingRelationships)
+		}
+		if !reflect.DeepEqual(diff.ExtraRelationships, []string{"(:Customer)-[:HAS_EMAIL]->(:Email)"}) {
+			t.Errorf("Expected HAS_EMAIL pattern to be extra, got: %v", diff.ExtraRelationships)
+		}
+		if diff.Conforms {
+			t.Error("Expected schema not to conform")
+		}
+	})
+
+	t.Run("matching schema conforms", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(liveSchemaQueries).
+			AnyTimes()
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.ValidateSchemaHandler(deps, nil)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"referenceModel": []map[string]any{
+						{"key": "Account", "value": map[string]any{
+							"type":       "node",
+							"properties": map[string]any{"balance": "Double"},
+							"relationships": map[string]any{
+								"HAS_ACCOUNT": map[string]any{"direction": "in", "labels": []string{"Customer"}},
+							},
+						}},
+					},
+				},
+			},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if result == nil || result.IsError {
+			t.Fatal("Expected success result")
+		}
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, `"conforms":true`) {
+			t.Errorf("Expected schema to conform, got: %s", result.Content[0].(mcp.TextContent).Text)
+		}
+	})
+
+	t.Run("invalid reference item type", func(t *testing.T) {
+		deps := &tools.ToolDependencies{
+			DBService:        db.NewMockService(ctrl),
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.ValidateSchemaHandler(deps, nil)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"referenceModel": []map[string]any{
+						{"key": "Customer", "value": map[string]any{"type": "label"}},
+					},
+				},
+			},
+		})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for invalid reference item type")
+		}
+	})
+
+	t.Run("schema retrieval failure", func(t *testing.T) {
+		mockDB := db.NewMockService(ctrl)
+		mockDB.EXPECT().GetDatabaseName(gomock.Any()).Return("neo4j").AnyTimes()
+		mockDB.EXPECT().
+			ExecuteReadQuery(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("connection failed"))
+
+		deps := &tools.ToolDependencies{
+			DBService:        mockDB,
+			AnalyticsService: analyticsService,
+		}
+
+		handler := schema.ValidateSchemaHandler(deps, nil)
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{
+				Arguments: map[string]any{
+					"referenceModel": []map[string]any{
+						{"key": "Customer", "value": map[string]any{"type": "node"}},
+					},
+				},
+			},
+		})
+
+		if err != nil {
+			t.Errorf("Expected no error, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for schema retrieval failure")
+		}
+	})
+}
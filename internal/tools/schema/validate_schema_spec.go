@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
+)
+
+type ValidateSchemaInput struct {
+	ReferenceModel []cypher.SchemaItem `json:"referenceModel,omitempty" jsonschema:"description=Expected schema items in the get-schema json format: {key, value: {type: node|relationship, properties: {name: TYPE}, relationships: {TYPE: {direction: out|in, labels: [Label]}}}}. Leave a property type empty to check presence only. Omit to validate against the Neo4j fraud reference models."`
+	ModelName      string              `json:"modelName,omitempty" jsonschema:"description=Optional: name of a registered reference model to validate against when referenceModel is omitted (see get-neo4j-reference-data-models with list=true)"`
+	Labels         []string            `json:"labels,omitempty" jsonschema:"description=Optional: when validating against the Neo4j reference models, only check these labels and relationship types"`
+	Refresh        bool                `json:"refresh,omitempty" jsonschema:"default=false,description=Reload the live schema instead of using the cached copy"`
+	Database       string              `json:"database,omitempty" jsonschema:"description=Optional: name of the database to validate. Defaults to the configured database."`
+}
+
+// ValidateSchemaSpec returns the tool specification for validate-schema
+func ValidateSchemaSpec() mcp.Tool {
+	return mcp.NewTool("validate-schema",
+		mcp.WithDescription(`Compares the live database schema against a reference model and returns the structural gaps as JSON.
+
+Use this instead of comparing get-schema and get-neo4j-reference-data-models output by hand: the comparison is deterministic and lists every gap.
+
+**REPORTED GAPS:**
+- **missingLabels / missingRelationshipTypes:** reference labels and types that do not exist in the database
+- **missingProperties:** reference properties absent from an existing label or type
+- **typeMismatches:** properties whose type differs (Long and INTEGER, Double and FLOAT are treated as equal)
+- **missingRelationships:** reference patterns such as (:Customer)-[:HAS_ACCOUNT]->(:Account) that do not exist
+- **extraRelationships:** database patterns the reference does not describe
+- **conforms:** true when nothing is missing or mismatched (extra relationships are allowed)
+
+Omit referenceModel to validate against the Neo4j fraud reference models (see get-neo4j-reference-data-models), optionally narrowed with labels, e.g. {"labels": ["Customer", "Account", "HAS_ACCOUNT"]}.
+
+**Example:**
+{
+  "referenceModel": [
+    {"key": "Customer", "value": {"type": "node", "properties": {"customerId": "STRING", "dateOfBirth": "DATE"}, "relationships": {"HAS_ACCOUNT": {"direction": "out", "labels": ["Account"]}}}},
+    {"key": "Account", "value": {"type": "node", "properties": {"accountNumber": "STRING"}}},
+    {"key": "HAS_ACCOUNT", "value": {"type": "relationship", "properties": {"since": ""}}}
+  ]
+}`),
+		mcp.WithInputSchema[ValidateSchemaInput](),
+		mcp.WithTitleAnnotation("Validate Schema Against Reference Model"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+)
+
+// SchemaCache keeps processed schema results in memory, keyed by database name and caller,
+// so schema-aware tools do not run the db.schema.* procedures on every call.
+// Neo4j security rules may show callers different schemas, and a Basic Auth password is only checked by Neo4j,
+// so entries are only served to calls with the same identity and credentials as the call that stored them.
+// A nil cache, or one with a non-positive TTL, never returns a hit.
+type SchemaCache struct {
+	ttl      time.Duration
+	mu       sync.Mutex
+	entries  map[string]schemaCacheEntry
+	onUpdate func(key string)
+}
+
+type schemaCacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// NewSchemaCache creates a schema cache whose entries expire after ttl
+func NewSchemaCache(ttl time.Duration) *SchemaCache {
+	return &SchemaCache{
+		ttl:     ttl,
+		entries: make(map[string]schemaCacheEntry),
+	}
+}
+
+// Get returns the schema cached for a database by the caller of ctx if it has not expired
+func (c *SchemaCache) Get(ctx context.Context, database string) (any, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+
+	key := cacheKey(ctx, database)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+// Set stores the schema of a database for the caller of ctx, replacing any previous entry.
+// The update is reported to the OnUpdate function even when caching is disabled.
+func (c *SchemaCache) Set(ctx context.Context, database string, value any) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	if c.ttl > 0 {
+		c.entries[cacheKey(ctx, database)] = schemaCacheEntry{
+			value:     value,
+			expiresAt: time.Now().Add(c.ttl),
+		}
+	}
+	onUpdate := c.onUpdate
+	c.mu.Unlock()
+
+	if onUpdate != nil {
+		onUpdate(database)
+	}
+}
+
+// OnUpdate registers a function called with the database of every entry set in the cache,
+// so clients can be told that a schema was reloaded
+func (c *SchemaCache) OnUpdate(fn func(key string)) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onUpdate = fn
+}
+
+// Invalidate drops the schema cached for a database by the caller of ctx
+func (c *SchemaCache) Invalidate(ctx context.Context, database string) {
+	if c == nil {
+		return
+	}
+
+	key := cacheKey(ctx, database)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// cacheKey returns the entry key of a database for the caller of ctx: the database name alone for calls
+// without an identity or credentials of their own, as in STDIO mode, else the name and a digest of both
+func cacheKey(ctx context.Context, database string) string {
+	identity, _ := auth.GetIdentity(ctx)
+	username, password, hasAuth := auth.GetBasicAuthCredentials(ctx)
+	if identity == "" && !hasAuth {
+		return database
+	}
+
+	encoded, err := json.Marshal([]string{identity, username, password})
+	if err != nil {
+		return database
+	}
+	sum := sha256.Sum256(encoded)
+	return database + "\x00" + hex.EncodeToString(sum[:])
+}
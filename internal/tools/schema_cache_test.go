@@ -0,0 +1,100 @@
+package tools_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns stored schema per database", func(t *testing.T) {
+		cache := tools.NewSchemaCache(time.Minute)
+		cache.Set(ctx, "neo4j", "schema")
+
+		value, ok := cache.Get(ctx, "neo4j")
+		assert.True(t, ok)
+		assert.Equal(t, "schema", value)
+
+		_, ok = cache.Get(ctx, "other")
+		assert.False(t, ok)
+	})
+
+	t.Run("expires entries after the TTL", func(t *testing.T) {
+		cache := tools.NewSchemaCache(10 * time.Millisecond)
+		cache.Set(ctx, "neo4j", "schema")
+
+		time.Sleep(20 * time.Millisecond)
+
+		_, ok := cache.Get(ctx, "neo4j")
+		assert.False(t, ok)
+	})
+
+	t.Run("invalidate drops the entry", func(t *testing.T) {
+		cache := tools.NewSchemaCache(time.Minute)
+		cache.Set(ctx, "neo4j", "schema")
+		cache.Invalidate(ctx, "neo4j")
+
+		_, ok := cache.Get(ctx, "neo4j")
+		assert.False(t, ok)
+	})
+
+	t.Run("zero TTL disables caching", func(t *testing.T) {
+		cache := tools.NewSchemaCache(0)
+		cache.Set(ctx, "neo4j", "schema")
+
+		_, ok := cache.Get(ctx, "neo4j")
+		assert.False(t, ok)
+	})
+
+	t.Run("reports updates even when caching is disabled", func(t *testing.T) {
+		var updated []string
+		cache := tools.NewSchemaCache(0)
+		cache.OnUpdate(func(key string) {
+			updated = append(updated, key)
+		})
+		cache.Set(ctx, "neo4j", "schema")
+
+		assert.Equal(t, []string{"neo4j"}, updated)
+	})
+
+	t.Run("entries are scoped to the caller's identity and credentials", func(t *testing.T) {
+		var updated []string
+		cache := tools.NewSchemaCache(time.Minute)
+		cache.OnUpdate(func(key string) {
+			updated = append(updated, key)
+		})
+		caller := func(username, password string) context.Context {
+			return auth.WithIdentity(auth.WithBasicAuth(context.Background(), username, password), username)
+		}
+
+		cache.Set(caller("alice", "secret"), "neo4j", "alice's schema")
+
+		value, ok := cache.Get(caller("alice", "secret"), "neo4j")
+		assert.True(t, ok)
+		assert.Equal(t, "alice's schema", value)
+
+		_, ok = cache.Get(caller("alice", "wrong-password"), "neo4j")
+		assert.False(t, ok, "a wrong password must not be served the cached schema")
+		_, ok = cache.Get(caller("bob", "secret"), "neo4j")
+		assert.False(t, ok, "another user must not be served the cached schema")
+		_, ok = cache.Get(ctx, "neo4j")
+		assert.False(t, ok, "calls without credentials must not be served the cached schema")
+
+		assert.Equal(t, []string{"neo4j"}, updated)
+	})
+
+	t.Run("nil cache is a no-op", func(t *testing.T) {
+		var cache *tools.SchemaCache
+		cache.Set(ctx, "neo4j", "schema")
+		cache.Invalidate(ctx, "neo4j")
+
+		_, ok := cache.Get(ctx, "neo4j")
+		assert.False(t, ok)
+	})
+}
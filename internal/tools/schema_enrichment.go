@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// ElementDescription is the business meaning of a node label or relationship type and of its properties
+type ElementDescription struct {
+	Description string            `json:"description,omitempty" jsonschema:"description=What the label or relationship type represents in business terms"`
+	Properties  map[string]string `json:"properties,omitempty" jsonschema:"description=Business description of each property, by property name"`
+}
+
+// SchemaEnrichment holds the business descriptions a client produced for a database schema
+type SchemaEnrichment struct {
+	Summary           string                        `json:"summary,omitempty"`
+	Labels            map[string]ElementDescription `json:"labels,omitempty"`
+	RelationshipTypes map[string]ElementDescription `json:"relationshipTypes,omitempty"`
+	StoredAt          time.Time                     `json:"storedAt"`
+}
+
+// EnrichmentCache keeps the schema enrichment stored for each database, keyed by database name,
+// so every client is served the same descriptions without running the enrichment again.
+// Entries do not expire; they last until replaced or the server restarts. A nil cache holds nothing.
+type EnrichmentCache struct {
+	mu      sync.Mutex
+	entries map[string]SchemaEnrichment
+}
+
+// NewEnrichmentCache creates an empty enrichment cache
+func NewEnrichmentCache() *EnrichmentCache {
+	return &EnrichmentCache{entries: make(map[string]SchemaEnrichment)}
+}
+
+// Get returns the enrichment stored for a database
+func (c *EnrichmentCache) Get(database string) (SchemaEnrichment, bool) {
+	if c == nil {
+		return SchemaEnrichment{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	enrichment, ok := c.entries[database]
+	return enrichment, ok
+}
+
+// Store saves the enrichment of a database and returns what is now stored. With merge, the descriptions are
+// added to the stored ones, replacing those of the same labels, relationship types and properties;
+// otherwise the stored enrichment is replaced.
+func (c *EnrichmentCache) Store(database string, enrichment SchemaEnrichment, merge bool) SchemaEnrichment {
+	if c == nil {
+		return enrichment
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.entries[database]; ok && merge {
+		if enrichment.Summary == "" {
+			enrichment.Summary = existing.Summary
+		}
+		enrichment.Labels = mergeDescriptions(existing.Labels, enrichment.Labels)
+		enrichment.RelationshipTypes = mergeDescriptions(existing.RelationshipTypes, enrichment.RelationshipTypes)
+	}
+	enrichment.StoredAt = time.Now().UTC()
+	c.entries[database] = enrichment
+	return enrichment
+}
+
+// mergeDescriptions returns the stored descriptions updated with the new ones, property by property
+func mergeDescriptions(stored, updates map[string]ElementDescription) map[string]ElementDescription {
+	merged := maps.Clone(stored)
+	if merged == nil {
+		merged = make(map[string]ElementDescription, len(updates))
+	}
+	for name, update := range updates {
+		element := merged[name]
+		if update.Description != "" {
+			element.Description = update.Description
+		}
+		properties := maps.Clone(element.Properties)
+		if properties == nil && len(update.Properties) > 0 {
+			properties = make(map[string]string, len(update.Properties))
+		}
+		maps.Copy(properties, update.Properties)
+		element.Properties = properties
+		merged[name] = element
+	}
+	return merged
+}
@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ToolHandler is the signature of an MCP tool handler
+type ToolHandler func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// ToolCatalog describes the tools enabled on this server, grouped by category,
+// so agents can discover what the server can do without reading every tool description.
+// It is filled once the server has applied its tool filters, and lets composite tools
+// call the other enabled tools.
+type ToolCatalog struct {
+	mu         sync.RWMutex
+	categories []ToolCatalogCategory
+	handlers   map[string]ToolHandler
+	permitted  func(ctx context.Context, name string) bool
+}
+
+// ToolCatalogCategory is a group of tools sharing an intent, such as fraud detection
+type ToolCatalogCategory struct {
+	Name   string             `json:"name"`
+	Intent string             `json:"intent"`
+	Tools  []ToolCatalogEntry `json:"tools"`
+}
+
+// ToolCatalogEntry summarises one enabled tool
+type ToolCatalogEntry struct {
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description"`
+	ReadOnly    bool   `json:"readOnly"`
+}
+
+// NewToolCatalog creates an empty tool catalog
+func NewToolCatalog() *ToolCatalog {
+	return &ToolCatalog{}
+}
+
+// Set replaces the catalog contents and the handlers of the enabled tools
+func (c *ToolCatalog) Set(categories []ToolCatalogCategory, handlers map[string]ToolHandler) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.categories = categories
+	c.handlers = handlers
+}
+
+// SetAccess restricts the catalog to the tools permitted for the caller of each request,
+// so role-based access control applies to discovery and composite tools as well
+func (c *ToolCatalog) SetAccess(permitted func(ctx context.Context, name string) bool) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.permitted = permitted
+}
+
+// Categories returns the catalog contents the caller may use; a nil catalog has no categories
+func (c *ToolCatalog) Categories(ctx context.Context) []ToolCatalogCategory {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.permitted == nil {
+		return c.categories
+	}
+
+	categories := make([]ToolCatalogCategory, 0, len(c.categories))
+	for _, category := range c.categories {
+		entries := make([]ToolCatalogEntry, 0, len(category.Tools))
+		for _, entry := range category.Tools {
+			if c.permitted(ctx, entry.Name) {
+				entries = append(entries, entry)
+			}
+		}
+		if len(entries) > 0 {
+			category.Tools = entries
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
+
+// AllCategories returns every enabled tool regardless of the caller's role, for checks of the
+// server itself such as the readiness probe, whose requests carry no identity
+func (c *ToolCatalog) AllCategories() []ToolCatalogCategory {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.categories
+}
+
+// Call runs an enabled tool with the given arguments. Disabled tools cannot be called,
+// so composite tools honour read-only mode and the other tool filters.
+func (c *ToolCatalog) Call(ctx context.Context, name string, arguments map[string]any) (*mcp.CallToolResult, error) {
+	if c == nil {
+		return nil, fmt.Errorf("tool %q is not enabled", name)
+	}
+
+	c.mu.RLock()
+	handler, ok := c.handlers[name]
+	permitted := c.permitted
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not enabled", name)
+	}
+	if permitted != nil && !permitted(ctx, name) {
+		return nil, fmt.Errorf("tool %q is not permitted for your role", name)
+	}
+
+	request := mcp.CallToolRequest{}
+	request.Params.Name = name
+	request.Params.Arguments = arguments
+	return handler(ctx, request)
+}
@@ -1,13 +1,37 @@
 package tools
 
 import (
+	"time"
+
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 )
 
 // ToolDependencies contains all dependencies needed by tools
 type ToolDependencies struct {
-	DBService        database.Service
-	AnalyticsService analytics.Service
-	SchemaSampleSize int
+	DBService          database.Service
+	AnalyticsService   analytics.Service
+	SchemaSampleSize   int
+	GDSCapabilities    *GDSCapabilities     // nil when GDS was not detected
+	SchemaCache        *SchemaCache         // nil disables schema caching
+	QueryTimeout       time.Duration        // Default timeout for Cypher tool queries; 0 disables it
+	QueryMaxRows       int                  // Default row limit for Cypher tool results; 0 disables it
+	QueryStats         *database.QueryStats // Recently executed queries, reported by get-query-stats
+	ToolCatalog        *ToolCatalog         // Enabled tools, reported by list-available-tools
+	ResponseChunker    *ResponseChunker     // Splits large responses into chunks fetched with get-next-chunk; nil disables chunking
+	MappingStore       *MappingStore        // Schema mappings saved by name, applied to tools called with mappingName
+	EnrichmentCache    *EnrichmentCache     // Business descriptions of the schema stored by clients, served by get-schema; nil disables them
+	ServiceCredentials bool                 // Neo4j is accessed with the server's own credentials rather than per-request ones
+}
+
+// ForDatabase returns dependencies whose DBService targets the named database.
+// An empty name returns the dependencies unchanged, so the configured database is used.
+func (d *ToolDependencies) ForDatabase(name string) *ToolDependencies {
+	if name == "" || d.DBService == nil {
+		return d
+	}
+
+	scoped := *d
+	scoped.DBService = d.DBService.ForDatabase(name)
+	return &scoped
 }
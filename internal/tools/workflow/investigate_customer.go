@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher/query_builder"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/fraud/synthetic_identity"
+)
+
+// InvestigateCustomerEntityConfig identifies the customer node
+type InvestigateCustomerEntityConfig struct {
+	NodeLabel      string   `json:"nodeLabel" jsonschema:"description=Node label of the customer (e.g. Customer, Person)"`
+	IdProperty     string   `json:"idProperty" jsonschema:"description=Property name for unique identifier (e.g. customerId)"`
+	BaseProperties []string `json:"baseProperties,omitempty" jsonschema:"description=Optional: customer properties to include in the profile. If empty, returns all properties."`
+}
+
+// InvestigateCustomerTransactionConfig describes how the customer reaches its transactions
+type InvestigateCustomerTransactionConfig struct {
+	AccountRelationshipType     string `json:"accountRelationshipType,omitempty" jsonschema:"description=Relationship from the customer to its accounts (e.g. OWNS). Omit when the customer is itself an account."`
+	AccountLabel                string `json:"accountLabel,omitempty" jsonschema:"description=Node label of accounts (e.g. Account)"`
+	TransactionLabel            string `json:"transactionLabel,omitempty" jsonschema:"description=Node model only: label of transaction nodes (e.g. Transaction)"`
+	PerformsRelationshipType    string `json:"performsRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the sending account to the transaction (e.g. PERFORMS)"`
+	BenefitsToRelationshipType  string `json:"benefitsToRelationshipType,omitempty" jsonschema:"description=Node model only: relationship from the transaction to the receiving account (e.g. BENEFITS_TO)"`
+	TransactionRelationshipType string `json:"transactionRelationshipType,omitempty" jsonschema:"description=Relationship model only: relationship from the sending to the receiving account (e.g. TRANSFERRED_TO)"`
+	DateProperty                string `json:"dateProperty" jsonschema:"description=Property holding the transaction datetime (e.g. date)"`
+}
+
+// InvestigateCustomerInput defines the input parameters for the investigate-customer workflow
+type InvestigateCustomerInput struct {
+	EntityId          string                                `json:"entityId" jsonschema:"description=Customer ID to investigate (required)"`
+	EntityConfig      InvestigateCustomerEntityConfig       `json:"entityConfig" jsonschema:"description=Configuration for the customer node. Discovered from get-schema."`
+	AttributeMappings []query_builder.AttributeMapping      `json:"attributeMappings" jsonschema:"description=Identity attribute mappings as used by get-customer-profile"`
+	PIIRelationships  []synthetic_identity.PIIRelationship  `json:"piiRelationships,omitempty" jsonschema:"description=Optional: PII relationships with an identifier property, as passed to detect-synthetic-identity (see suggest-attribute-mappings). Without them the synthetic identity check is skipped."`
+	TransactionConfig *InvestigateCustomerTransactionConfig `json:"transactionConfig,omitempty" jsonschema:"description=Optional: how the customer reaches its transactions. Without it the velocity analysis is skipped."`
+}
+
+// InvestigateCustomer chains the schema, profile, synthetic identity and velocity tools
+var InvestigateCustomer = Workflow{
+	Name:     "investigate-customer",
+	Required: []string{"entityId", "entityConfig", "attributeMappings"},
+	Steps: []Step{
+		{
+			ID:        "schema",
+			Tool:      "get-schema",
+			Arguments: map[string]any{"format": "compact"},
+		},
+		{
+			ID:   "profile",
+			Tool: "get-customer-profile",
+			Inputs: map[string]string{
+				"entityId":          "entityId",
+				"entityConfig":      "entityConfig",
+				"attributeMappings": "attributeMappings",
+			},
+		},
+		{
+			ID:   "syntheticIdentity",
+			Tool: "detect-synthetic-identity",
+			Inputs: map[string]string{
+				"entityId":         "entityId",
+				"entityConfig":     "entityConfig",
+				"piiRelationships": "piiRelationships",
+			},
+			Requires: []string{"piiRelationships"},
+		},
+		{
+			ID:   "velocity",
+			Tool: "compute-risk-score",
+			Arguments: map[string]any{
+				"signals": []any{
+					map[string]any{"name": "velocity", "velocity": map[string]any{"windowHours": 24}},
+				},
+			},
+			Inputs: map[string]string{
+				"entityId":          "entityId",
+				"entityConfig":      "entityConfig",
+				"transactionConfig": "transactionConfig",
+			},
+			Requires: []string{"transactionConfig"},
+		},
+	},
+}
+
+// InvestigateCustomerSpec returns the MCP tool specification for the investigate-customer workflow
+func InvestigateCustomerSpec() mcp.Tool {
+	return mcp.NewTool(InvestigateCustomer.Name,
+		mcp.WithDescription(`Runs a standard customer investigation in one call and returns a consolidated report.
+		Steps, run server-side in order: get-schema (compact), get-customer-profile, detect-synthetic-identity on the customer's piiRelationships, and a 24 hour transaction velocity score from compute-risk-score.
+		Each step reports its status (ok, error or skipped) and its result, so a failing step does not hide the others. The synthetic identity step is skipped without piiRelationships and the velocity step without transactionConfig.
+		Use the individual tools instead when you need options this workflow does not expose.`),
+		mcp.WithInputSchema[InvestigateCustomerInput](),
+		mcp.WithTitleAnnotation("Investigate Customer"),
+		mcp.WithReadOnlyHintAnnotation(true),
+		mcp.WithDestructiveHintAnnotation(false),
+		mcp.WithIdempotentHintAnnotation(true),
+		mcp.WithOpenWorldHintAnnotation(true),
+	)
+}
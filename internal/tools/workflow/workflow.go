@@ -0,0 +1,173 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+)
+
+// Workflow chains enabled tools server-side and returns their results as one report,
+// saving the client a round trip per tool for common investigations.
+type Workflow struct {
+	Name     string
+	Required []string // Workflow arguments that must be provided
+	Steps    []Step
+}
+
+// Step calls one tool. Steps run in order and a failing step does not stop the workflow.
+type Step struct {
+	ID        string
+	Tool      string
+	Arguments map[string]any    // Fixed tool arguments
+	Inputs    map[string]string // Tool argument name -> workflow argument copied into it
+	Requires  []string          // Workflow arguments without which the step is skipped
+}
+
+// stepResult is the outcome of one workflow step
+type stepResult struct {
+	ID         string `json:"id"`
+	Tool       string `json:"tool"`
+	Status     string `json:"status"` // ok, error or skipped
+	DurationMs int64  `json:"durationMs"`
+	Reason     string `json:"reason,omitempty"`
+	Result     any    `json:"result,omitempty"`
+}
+
+// workflowReport is the consolidated output of a workflow tool
+type workflowReport struct {
+	Workflow  string       `json:"workflow"`
+	Completed int          `json:"completed"`
+	Failed    int          `json:"failed"`
+	Skipped   int          `json:"skipped"`
+	Steps     []stepResult `json:"steps"`
+}
+
+// Handler returns a handler function running the workflow
+func Handler(deps *tools.ToolDependencies, workflow Workflow) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return handleWorkflow(ctx, request, deps, workflow)
+	}
+}
+
+func handleWorkflow(ctx context.Context, request mcp.CallToolRequest, deps *tools.ToolDependencies, workflow Workflow) (*mcp.CallToolResult, error) {
+	if deps.AnalyticsService == nil {
+		errMessage := "Analytics service is not initialized"
+		slog.Error(errMessage)
+		return mcp.NewToolResultError(errMessage), nil
+	}
+
+	deps.AnalyticsService.EmitEvent(deps.AnalyticsService.NewToolsEvent(workflow.Name))
+
+	args := request.GetArguments()
+	for _, name := range workflow.Required {
+		if !hasArgument(args, name) {
+			errMessage := fmt.Sprintf("%s parameter is required", name)
+			slog.Error(errMessage)
+			return mcp.NewToolResultError(errMessage), nil
+		}
+	}
+
+	report := workflowReport{Workflow: workflow.Name, Steps: make([]stepResult, 0, len(workflow.Steps))}
+	for _, step := range workflow.Steps {
+		if ctx.Err() != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("workflow cancelled before step %s: %v", step.ID, ctx.Err())), nil
+		}
+
+		result := runStep(ctx, deps, step, args)
+		switch result.Status {
+		case "ok":
+			report.Completed++
+		case "error":
+			report.Failed++
+		default:
+			report.Skipped++
+		}
+		report.Steps = append(report.Steps, result)
+	}
+
+	response, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		slog.Error("error formatting workflow report", "error", err)
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText(string(response)), nil
+}
+
+// runStep calls the step's tool with its fixed arguments and the workflow arguments it takes
+func runStep(ctx context.Context, deps *tools.ToolDependencies, step Step, args map[string]any) stepResult {
+	result := stepResult{ID: step.ID, Tool: step.Tool}
+
+	for _, name := range step.Requires {
+		if !hasArgument(args, name) {
+			result.Status = "skipped"
+			result.Reason = fmt.Sprintf("%s was not provided", name)
+			return result
+		}
+	}
+
+	toolArgs := make(map[string]any, len(step.Arguments)+len(step.Inputs))
+	for name, value := range step.Arguments {
+		toolArgs[name] = value
+	}
+	for name, from := range step.Inputs {
+		if value, ok := args[from]; ok {
+			toolArgs[name] = value
+		}
+	}
+
+	started := time.Now()
+	toolResult, err := deps.ToolCatalog.Call(ctx, step.Tool, toolArgs)
+	result.DurationMs = time.Since(started).Milliseconds()
+	if err != nil {
+		slog.Error("workflow step failed", "step", step.ID, "tool", step.Tool, "error", err)
+		result.Status = "error"
+		result.Reason = err.Error()
+		return result
+	}
+
+	text := resultText(toolResult)
+	if toolResult.IsError {
+		result.Status = "error"
+		result.Reason = text
+		return result
+	}
+
+	// Tools answer in JSON or markdown; embed JSON as structured data
+	result.Status = "ok"
+	var parsed any
+	if err := json.Unmarshal([]byte(text), &parsed); err == nil {
+		result.Result = parsed
+	} else {
+		result.Result = text
+	}
+	return result
+}
+
+// resultText joins the text content of a tool result
+func resultText(result *mcp.CallToolResult) string {
+	parts := make([]string, 0, len(result.Content))
+	for _, content := range result.Content {
+		if text, ok := content.(mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func hasArgument(args map[string]any, name string) bool {
+	value, ok := args[name]
+	if !ok || value == nil {
+		return false
+	}
+	if list, ok := value.([]any); ok {
+		return len(list) > 0
+	}
+	return value != ""
+}
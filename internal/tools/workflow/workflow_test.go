@@ -0,0 +1,154 @@
+package workflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	analytics "github.com/mkd-neo4j/neo4j-mcp-fraud/internal/analytics/mocks"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/workflow"
+	"go.uber.org/mock/gomock"
+)
+
+type report struct {
+	Completed int `json:"completed"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+	Steps     []struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		Reason string `json:"reason"`
+		Result any    `json:"result"`
+	} `json:"steps"`
+}
+
+func TestInvestigateCustomerWorkflow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	analyticsService := analytics.NewMockService(ctrl)
+	analyticsService.EXPECT().NewToolsEvent("investigate-customer").AnyTimes()
+	analyticsService.EXPECT().EmitEvent(gomock.Any()).AnyTimes()
+	defer ctrl.Finish()
+
+	received := make(map[string]map[string]any)
+	respond := func(text string, isError bool) tools.ToolHandler {
+		return func(_ context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			received[request.Params.Name] = request.GetArguments()
+			if isError {
+				return mcp.NewToolResultError(text), nil
+			}
+			return mcp.NewToolResultText(text), nil
+		}
+	}
+	catalog := tools.NewToolCatalog()
+	catalog.Set(nil, map[string]tools.ToolHandler{
+		"get-schema":                respond("# Schema", false),
+		"get-customer-profile":      respond(`{"customerId": "C1"}`, false),
+		"detect-synthetic-identity": respond("no shared PII relationships found", true),
+		"compute-risk-score":        respond(`{"score": 0.5}`, false),
+	})
+	deps := &tools.ToolDependencies{
+		AnalyticsService: analyticsService,
+		ToolCatalog:      catalog,
+	}
+	arguments := map[string]any{
+		"entityId":          "C1",
+		"entityConfig":      map[string]any{"nodeLabel": "Customer", "idProperty": "customerId"},
+		"attributeMappings": []any{map[string]any{"relationshipType": "HAS_EMAIL", "targetLabel": "Email", "identifierProperty": "address", "attributeCategory": "contact_information"}},
+		"piiRelationships":  []any{map[string]any{"relationshipType": "HAS_EMAIL", "targetLabel": "Email", "identifierProperty": "address"}},
+	}
+
+	t.Run("runs every step and reports failures and skips", func(t *testing.T) {
+		result, err := workflow.Handler(deps, workflow.InvestigateCustomer)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: arguments},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var got report
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &got); err != nil {
+			t.Fatalf("Expected workflow report JSON, got: %v", err)
+		}
+		if got.Completed != 2 || got.Failed != 1 || got.Skipped != 1 {
+			t.Errorf("Expected 2 completed, 1 failed and 1 skipped step, got: %+v", got)
+		}
+		if got.Steps[0].Result != "# Schema" {
+			t.Errorf("Expected markdown results to be embedded as text, got: %v", got.Steps[0].Result)
+		}
+		if profile, ok := got.Steps[1].Result.(map[string]any); !ok || profile["customerId"] != "C1" {
+			t.Errorf("Expected JSON results to be embedded as objects, got: %v", got.Steps[1].Result)
+		}
+		if got.Steps[3].Status != "skipped" {
+			t.Errorf("Expected the velocity step to be skipped without transactionConfig, got: %+v", got.Steps[3])
+		}
+		if received["get-schema"]["format"] != "compact" {
+			t.Errorf("Expected fixed arguments to be passed, got: %v", received["get-schema"])
+		}
+		synthetic := received["detect-synthetic-identity"]
+		if _, ok := synthetic["attributeMappings"]; ok || !reflect.DeepEqual(synthetic["piiRelationships"], arguments["piiRelationships"]) {
+			t.Errorf("Expected only piiRelationships to be passed to detect-synthetic-identity, got: %v", synthetic)
+		}
+	})
+
+	t.Run("skips the synthetic identity check without piiRelationships", func(t *testing.T) {
+		withoutPII := map[string]any{}
+		for name, value := range arguments {
+			if name != "piiRelationships" {
+				withoutPII[name] = value
+			}
+		}
+		result, err := workflow.Handler(deps, workflow.InvestigateCustomer)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: withoutPII},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var got report
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &got); err != nil {
+			t.Fatalf("Expected workflow report JSON, got: %v", err)
+		}
+		if got.Steps[2].Status != "skipped" || got.Steps[2].Reason != "piiRelationships was not provided" {
+			t.Errorf("Expected the synthetic identity step to be skipped, got: %+v", got.Steps[2])
+		}
+	})
+
+	t.Run("reports disabled tools as failed steps", func(t *testing.T) {
+		limited := tools.NewToolCatalog()
+		limited.Set(nil, map[string]tools.ToolHandler{"get-schema": respond("# Schema", false)})
+		limitedDeps := &tools.ToolDependencies{
+			AnalyticsService: analyticsService,
+			ToolCatalog:      limited,
+		}
+
+		result, err := workflow.Handler(limitedDeps, workflow.InvestigateCustomer)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: arguments},
+		})
+		if err != nil || result == nil || result.IsError {
+			t.Fatalf("Expected success result, got: %v", err)
+		}
+
+		var got report
+		if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &got); err != nil {
+			t.Fatalf("Expected workflow report JSON, got: %v", err)
+		}
+		if got.Completed != 1 || got.Failed != 2 || got.Steps[1].Reason != `tool "get-customer-profile" is not enabled` {
+			t.Errorf("Expected disabled tools to fail their steps, got: %+v", got)
+		}
+	})
+
+	t.Run("missing required argument", func(t *testing.T) {
+		result, err := workflow.Handler(deps, workflow.InvestigateCustomer)(context.Background(), mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"entityId": "C1"}},
+		})
+		if err != nil {
+			t.Errorf("Expected no error from handler, got: %v", err)
+		}
+		if result == nil || !result.IsError {
+			t.Error("Expected error result for missing entityConfig")
+		}
+	})
+}
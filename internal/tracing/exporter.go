@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	tracesPath     = "/v1/traces"
+	exportInterval = 5 * time.Second  // Pending spans are exported at least this often
+	exportTimeout  = 10 * time.Second // Maximum time one export request may take
+	maxBatchSize   = 512              // Spans per export request; reaching it triggers an export
+	maxQueueSize   = 2048             // Spans kept while the collector is slow; further spans are dropped
+)
+
+// ExporterConfig configures where spans are exported
+type ExporterConfig struct {
+	Endpoint string            // Base URL of the OTLP/HTTP collector, e.g. http://localhost:4318
+	Headers  map[string]string // Headers sent with every export request, e.g. for authentication
+}
+
+// NewExporter returns an OTLP/HTTP exporter sending spans to the collector of cfg
+func NewExporter(ctx context.Context, cfg ExporterConfig) (sdktrace.SpanExporter, error) {
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(strings.TrimSuffix(cfg.Endpoint, "/")+tracesPath),
+		otlptracehttp.WithHeaders(cfg.Headers),
+		otlptracehttp.WithTimeout(exportTimeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// ParseHeaders parses the comma-separated key=value pairs of OTEL_EXPORTER_OTLP_HEADERS,
+// whose values may be URL encoded
+func ParseHeaders(pairs string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(pairs, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", pair)
+		}
+		decoded, err := url.PathUnescape(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of header %q: %w", key, err)
+		}
+		headers[key] = decoded
+	}
+	return headers, nil
+}
@@ -0,0 +1,155 @@
+// Package tracing records OpenTelemetry spans for tool calls and the queries they run,
+// and exports them to an OTLP collector over HTTP with the OpenTelemetry SDK.
+//
+// Spans are carried by the context: Tracer.Start begins a span, and Start begins a child of the
+// span in the context with the same tracer, or does nothing when the context carries no span.
+// All Span methods are no-ops on a nil Span, so instrumented code does not check whether tracing is enabled.
+package tracing
+
+import (
+	"context"
+	"log/slog"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// scopeName is the instrumentation scope spans are reported under
+const scopeName = "github.com/mkd-neo4j/neo4j-mcp-fraud"
+
+// Span kinds
+const (
+	KindInternal = trace.SpanKindInternal
+	KindServer   = trace.SpanKindServer
+	KindClient   = trace.SpanKindClient
+)
+
+// Attribute is a key and a string, int64, float64 or bool value
+type Attribute = attribute.KeyValue
+
+// String returns a string attribute
+func String(key, value string) Attribute { return attribute.String(key, value) }
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute { return attribute.Int(key, value) }
+
+// Float64 returns a floating point attribute
+func Float64(key string, value float64) Attribute { return attribute.Float64(key, value) }
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute { return attribute.Bool(key, value) }
+
+// Tracer creates spans with an OpenTelemetry tracer provider, which batches the ended ones to its exporter
+type Tracer struct {
+	provider *sdktrace.TracerProvider
+	tracer   trace.Tracer
+}
+
+// NewTracer creates a tracer exporting its spans with exporter, attributed to the named service.
+// A nil exporter records spans without exporting them.
+func NewTracer(exporter sdktrace.SpanExporter, serviceName, serviceVersion string) *Tracer {
+	service := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", serviceVersion),
+	)
+	if merged, err := resource.Merge(resource.Default(), service); err == nil {
+		service = merged
+	}
+
+	options := []sdktrace.TracerProviderOption{sdktrace.WithResource(service)}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter,
+			sdktrace.WithBatchTimeout(exportInterval),
+			sdktrace.WithExportTimeout(exportTimeout),
+			sdktrace.WithMaxExportBatchSize(maxBatchSize),
+			sdktrace.WithMaxQueueSize(maxQueueSize),
+		))
+	}
+	// Export failures happen in the background; report them in the server log
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		slog.Warn("Failed to export trace spans", "error", err)
+	}))
+
+	provider := sdktrace.NewTracerProvider(options...)
+	return &Tracer{provider: provider, tracer: provider.Tracer(scopeName, trace.WithInstrumentationVersion(serviceVersion))}
+}
+
+// Start begins a span of the given kind. It is a child of the span in ctx, or of the remote
+// parent set with ContextWithRemoteParent, else the root of a new trace.
+// A nil Tracer returns ctx and a nil span.
+func (t *Tracer) Start(ctx context.Context, name string, kind trace.SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+	return ctx, &Span{span: span}
+}
+
+// Shutdown exports the pending spans and stops the exporter
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t == nil {
+		return nil
+	}
+	return t.provider.Shutdown(ctx)
+}
+
+// Start begins a child of the span in ctx with the tracer that started it.
+// Without a recording span in ctx it returns ctx and a nil span, so untraced calls cost nothing.
+func Start(ctx context.Context, name string, kind trace.SpanKind, attrs ...Attribute) (context.Context, *Span) {
+	parent := trace.SpanFromContext(ctx)
+	if !parent.IsRecording() {
+		return ctx, nil
+	}
+	ctx, span := parent.TracerProvider().Tracer(scopeName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+	return ctx, &Span{span: span}
+}
+
+// Span is an operation being traced
+type Span struct {
+	span trace.Span
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.span.SetAttributes(attrs...)
+}
+
+// RecordError marks the span as failed with err; a nil err leaves it unchanged
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+// End ends the span and queues it for export; ending a span again does nothing
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.span.End()
+}
+
+// TraceID returns the hex trace ID of the span, or "" for a nil span
+func (s *Span) TraceID() string {
+	if s == nil {
+		return ""
+	}
+	return s.span.SpanContext().TraceID().String()
+}
+
+// ContextWithRemoteParent returns a context whose spans continue the trace of a W3C traceparent
+// header value (version-traceid-parentid-flags), so a client's trace spans the server.
+// An invalid or empty value returns ctx unchanged.
+func ContextWithRemoteParent(ctx context.Context, traceparent string) context.Context {
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceparent})
+}
@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a fake OTLP/HTTP collector keeping the received requests
+type collector struct {
+	mu       sync.Mutex
+	requests []*coltracepb.ExportTraceServiceRequest
+	headers  http.Header
+}
+
+func newCollector(t *testing.T) (*collector, *httptest.Server) {
+	t.Helper()
+	c := &collector{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != tracesPath {
+			t.Errorf("expected spans on %s, got %s", tracesPath, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		request := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, request); err != nil {
+			t.Errorf("invalid export request: %v", err)
+		}
+		c.mu.Lock()
+		c.requests = append(c.requests, request)
+		c.headers = r.Header.Clone()
+		c.mu.Unlock()
+	}))
+	t.Cleanup(server.Close)
+	return c, server
+}
+
+func (c *collector) spans() []*tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spans []*tracepb.Span
+	for _, request := range c.requests {
+		for _, rs := range request.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+	}
+	return spans
+}
+
+func newTestTracer(t *testing.T, endpoint string) *Tracer {
+	t.Helper()
+	exporter, err := NewExporter(context.Background(), ExporterConfig{
+		Endpoint: endpoint,
+		Headers:  map[string]string{"Authorization": "Bearer secret"},
+	})
+	if err != nil {
+		t.Fatalf("NewExporter() unexpected error = %v", err)
+	}
+	return NewTracer(exporter, "neo4j-fraud-mcp", "test-version")
+}
+
+func TestTracer_ExportsSpanTree(t *testing.T) {
+	c, server := newCollector(t)
+	tracer := newTestTracer(t, server.URL)
+
+	ctx, toolSpan := tracer.Start(context.Background(), "tools/call read-cypher", KindServer, String("mcp.tool.name", "read-cypher"))
+	_, querySpan := Start(ctx, "ExecuteReadQuery", KindClient)
+	querySpan.SetAttributes(Int("db.response.returned_rows", 3), Float64("neo4j_mcp.query.duration_ms", 1.5), Bool("truncated", false))
+	querySpan.RecordError(errors.New("boom"))
+	querySpan.End()
+	toolSpan.End()
+	toolSpan.End() // Ending twice exports the span once
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := tracer.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() unexpected error = %v", err)
+	}
+
+	spans := c.spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 exported spans, got %d", len(spans))
+	}
+	query, tool := spans[0], spans[1]
+	if !bytes.Equal(query.TraceId, tool.TraceId) || !bytes.Equal(query.ParentSpanId, tool.SpanId) || len(tool.ParentSpanId) != 0 {
+		t.Errorf("expected the query span to be a child of the tool span, got %v and %v", query, tool)
+	}
+	if hex.EncodeToString(tool.TraceId) != toolSpan.TraceID() {
+		t.Errorf("TraceID() = %s, want %x", toolSpan.TraceID(), tool.TraceId)
+	}
+	if tool.Kind != tracepb.Span_SPAN_KIND_SERVER || query.Kind != tracepb.Span_SPAN_KIND_CLIENT {
+		t.Errorf("unexpected kinds %v and %v", tool.Kind, query.Kind)
+	}
+	if query.Status.GetCode() != tracepb.Status_STATUS_CODE_ERROR || query.Status.GetMessage() != "boom" || tool.Status.GetCode() != tracepb.Status_STATUS_CODE_UNSET {
+		t.Errorf("unexpected statuses %v and %v", query.Status, tool.Status)
+	}
+	attrs := query.Attributes
+	if len(attrs) != 3 || attrs[0].Value.GetIntValue() != 3 || attrs[1].Value.GetDoubleValue() != 1.5 || attrs[2].Value.GetBoolValue() {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+	if c.headers.Get("Authorization") != "Bearer secret" || c.headers.Get("Content-Type") != "application/x-protobuf" {
+		t.Errorf("unexpected export headers %v", c.headers)
+	}
+	resource := map[string]string{}
+	for _, attr := range c.requests[0].ResourceSpans[0].Resource.Attributes {
+		resource[attr.Key] = attr.Value.GetStringValue()
+	}
+	if resource["service.name"] != "neo4j-fraud-mcp" || resource["service.version"] != "test-version" {
+		t.Errorf("unexpected resource attributes %v", resource)
+	}
+}
+
+func TestContextWithRemoteParent(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	const parentID = "00f067aa0ba902b7"
+
+	tracer := NewTracer(nil, "neo4j-fraud-mcp", "test-version")
+	ctx := ContextWithRemoteParent(context.Background(), "00-"+traceID+"-"+parentID+"-01")
+	_, span := tracer.Start(ctx, "tools/call", KindServer)
+
+	parent := span.span.(sdktrace.ReadOnlySpan).Parent()
+	if span.TraceID() != traceID || parent.SpanID().String() != parentID || !parent.IsRemote() {
+		t.Errorf("expected the span to continue the remote trace, got trace %s parent %s", span.TraceID(), parent.SpanID())
+	}
+
+	for _, invalid := range []string{"", "garbage", "00-" + traceID + "-" + parentID, "ff-" + traceID + "-" + parentID + "-01",
+		"00-00000000000000000000000000000000-" + parentID + "-01", "00-" + traceID + "zz-" + parentID + "-01", "00-" + traceID + "-xyz-01"} {
+		if ContextWithRemoteParent(context.Background(), invalid) != context.Background() {
+			t.Errorf("ContextWithRemoteParent(%q) expected the context unchanged", invalid)
+		}
+	}
+}
+
+func TestNilTracerAndSpan(t *testing.T) {
+	var tracer *Tracer
+	ctx, span := tracer.Start(context.Background(), "tools/call", KindServer)
+	if span != nil || ctx != context.Background() {
+		t.Fatal("expected a nil tracer to start no span")
+	}
+	if _, child := Start(ctx, "ExecuteReadQuery", KindClient); child != nil {
+		t.Error("expected no child span without a span in the context")
+	}
+
+	// Nil spans are no-ops
+	span.SetAttributes(String("key", "value"))
+	span.RecordError(errors.New("boom"))
+	span.End()
+	if span.TraceID() != "" || tracer.Shutdown(context.Background()) != nil {
+		t.Error("expected nil tracer and span methods to do nothing")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders("Authorization=Bearer%20secret, x-tenant = fraud ,")
+	if err != nil {
+		t.Fatalf("ParseHeaders() unexpected error = %v", err)
+	}
+	if len(headers) != 2 || headers["Authorization"] != "Bearer secret" || headers["x-tenant"] != "fraud" {
+		t.Errorf("ParseHeaders() = %v", headers)
+	}
+
+	for _, invalid := range []string{"novalue", "=value", "key=%zz"} {
+		if _, err := ParseHeaders(invalid); err == nil {
+			t.Errorf("ParseHeaders(%q) expected error", invalid)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/auth"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/test/integration/helpers"
+)
+
+func TestCancelQuery(t *testing.T) {
+	t.Parallel()
+	t.Run("cancel-query should refuse to cancel the query of another identity", func(t *testing.T) {
+		tc := helpers.NewTestContext(t, dbs.GetDriver())
+		slowQuery := "UNWIND range(1, 2000000000) AS x WITH x WHERE x < 0 RETURN count(x) AS total, '" + tc.TestID + "' AS marker"
+
+		aliceCtx, cancelAlice := context.WithTimeout(auth.WithIdentity(context.Background(), "alice"), time.Minute)
+		defer cancelAlice()
+		done := make(chan error, 1)
+		go func() {
+			_, err := tc.Service.ExecuteReadQuery(aliceCtx, slowQuery, nil)
+			done <- err
+		}()
+
+		queryID := waitForRunningQuery(t, tc.Service, tc.TestID)
+
+		_, err := tc.Service.CancelQuery(auth.WithIdentity(context.Background(), "bob"), queryID)
+		if !errors.Is(err, database.ErrQueryNotFound) {
+			t.Fatalf("expected bob's cancel to be refused with ErrQueryNotFound, got: %v", err)
+		}
+
+		if _, err := tc.Service.CancelQuery(auth.WithIdentity(context.Background(), "alice"), queryID); err != nil {
+			t.Fatalf("expected alice to cancel her own query, got: %v", err)
+		}
+		if err := <-done; err == nil {
+			t.Fatal("expected the cancelled query to fail")
+		}
+	})
+}
+
+// waitForRunningQuery returns the query ID of the running query containing marker
+func waitForRunningQuery(t *testing.T, service database.Service, marker string) string {
+	t.Helper()
+
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		queries, err := service.ListRunningQueries(context.Background(), true)
+		if err != nil {
+			t.Fatalf("failed to list running queries: %v", err)
+		}
+		for _, query := range queries {
+			if strings.Contains(query.Query, marker) {
+				return query.QueryID
+			}
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("query %s did not start", marker)
+	return ""
+}
@@ -3,9 +3,11 @@
 package integration
 
 import (
+	"context"
 	"strings"
 	"testing"
 
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/test/integration/helpers"
 )
@@ -59,3 +61,20 @@ func TestReadCypher(t *testing.T) {
 	})
 
 }
+
+func TestQueryLog(t *testing.T) {
+	t.Parallel()
+	tc := helpers.NewTestContext(t, dbs.GetDriver())
+
+	log := &database.QueryLog{}
+	ctx := database.WithQueryLog(context.Background(), log)
+	const query = "UNWIND range(1, 3) AS n RETURN n"
+	if _, err := tc.Service.ExecuteReadQuery(ctx, query, nil); err != nil {
+		t.Fatalf("ExecuteReadQuery() unexpected error = %v", err)
+	}
+
+	queries := log.Queries()
+	if len(queries) != 1 || queries[0].Cypher != query || queries[0].Rows != 3 || queries[0].Failed {
+		t.Errorf("Queries() = %+v, want the query with 3 rows", queries)
+	}
+}
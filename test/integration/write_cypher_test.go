@@ -3,8 +3,11 @@
 package integration
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/database"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/internal/tools/cypher"
 	"github.com/mkd-neo4j/neo4j-mcp-fraud/test/integration/helpers"
 )
@@ -23,3 +26,30 @@ func TestWriteCypher(t *testing.T) {
 
 	tc.VerifyNodeInDB(personLabel, map[string]any{"name": "Alice"})
 }
+
+func TestRollbackOpenTransactions(t *testing.T) {
+	t.Parallel()
+	tc := helpers.NewTestContext(t, dbs.GetDriver())
+	service := tc.Service.(*database.Neo4jService)
+	ctx := context.Background()
+
+	caseLabel := tc.GetUniqueLabel("Case")
+	id, err := service.BeginTransaction(ctx)
+	if err != nil {
+		t.Fatalf("BeginTransaction() unexpected error = %v", err)
+	}
+	if _, _, err := service.RunInTransaction(ctx, id, "CREATE (:"+string(caseLabel)+" {id: 'CASE-1'})", nil); err != nil {
+		t.Fatalf("RunInTransaction() unexpected error = %v", err)
+	}
+
+	if rolledBack := service.RollbackOpenTransactions(ctx); rolledBack != 1 {
+		t.Errorf("RollbackOpenTransactions() = %d, want 1", rolledBack)
+	}
+	if _, _, err := service.RunInTransaction(ctx, id, "RETURN 1", nil); !errors.Is(err, database.ErrTransactionNotFound) {
+		t.Errorf("RunInTransaction() error = %v, want ErrTransactionNotFound", err)
+	}
+	records, err := service.ExecuteReadQuery(ctx, "MATCH (c:"+string(caseLabel)+") RETURN c", nil)
+	if err != nil || len(records) != 0 {
+		t.Errorf("expected the rolled back node not to exist, got %d records, error %v", len(records), err)
+	}
+}